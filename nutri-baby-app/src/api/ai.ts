@@ -1,4 +1,5 @@
-import { request } from "@/utils/request";
+import { request, requestStream } from "@/utils/request";
+import { SSEParser } from "@/utils/sse";
 import type {
   AnalysisResponse,
   AnalysisStatusResponse,
@@ -7,6 +8,7 @@ import type {
   DailyTipsResponse,
   AnalysisStatsResponse,
   AIAnalysisType,
+  AnalysisStreamEvent,
 } from "@/types/ai";
 import type { ApiResponse } from "@/types";
 
@@ -191,6 +193,85 @@ export const pollAnalysisStatus = async (
   throw new Error("分析超时");
 };
 
+/**
+ * 分析失败（服务端推送的 error 事件，不应再降级轮询）
+ */
+export class AnalysisStreamError extends Error {}
+
+/**
+ * 分析进度流回调
+ */
+export interface AnalysisStreamHandlers {
+  onStatus?: (status: string, progress?: number, message?: string) => void;
+  onToolCall?: (label: string) => void;
+  onNarrative?: (text: string) => void;
+}
+
+/**
+ * 订阅分析进度流（SSE）
+ * 每个 thinking 事件开始新一轮输出并清空已累积的文本：
+ * 流式输出中途失败时服务端会以同一轮次再次推送 thinking，已收到的部分文本随之丢弃
+ * 连接在 result/error 之前结束时抛出普通错误，调用方可降级为轮询
+ */
+export const streamAnalysis = async (
+  analysisId: number | string,
+  handlers: AnalysisStreamHandlers = {},
+): Promise<AnalysisResponse> => {
+  let narrative = "";
+  let finished = false;
+  let failure: string | null = null;
+
+  const parser = new SSEParser((message) => {
+    if (finished || message.event === "ping") {
+      return;
+    }
+    let event: AnalysisStreamEvent;
+    try {
+      event = JSON.parse(message.data);
+    } catch {
+      return;
+    }
+
+    switch (event.event) {
+      case "status":
+        handlers.onStatus?.(event.status || "", event.progress, event.message);
+        break;
+      case "thinking":
+        narrative = "";
+        handlers.onNarrative?.(narrative);
+        break;
+      case "tool_call":
+        handlers.onToolCall?.(event.tool_label || event.tool || "");
+        break;
+      case "delta":
+        narrative += event.text || "";
+        handlers.onNarrative?.(narrative);
+        break;
+      case "result":
+        finished = true;
+        break;
+      case "error":
+        finished = true;
+        failure = event.message || "分析失败";
+        break;
+    }
+  });
+
+  await requestStream(`/ai-analysis/${analysisId}/stream`, (chunk) =>
+    parser.push(chunk),
+  );
+
+  if (failure) {
+    throw new AnalysisStreamError(failure);
+  }
+  if (!finished) {
+    throw new Error("分析进度流已断开");
+  }
+
+  const resultResponse = await getAIAnalysisResult(analysisId);
+  return resultResponse.data;
+};
+
 /**
  * 获取分析图表数据
  */
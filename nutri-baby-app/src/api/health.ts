@@ -0,0 +1,137 @@
+/**
+ * 体温与症状记录 API 接口
+ * 职责: 纯 API 调用,无状态,无副作用
+ */
+import { get } from "@/utils/request";
+import type {
+  TemperatureRecord,
+  TemperatureSite,
+  SymptomRecord,
+  SymptomSeverity,
+} from "@/types";
+
+// ============ 类型定义 ============
+
+/**
+ * API 响应: 体温记录详情
+ */
+export interface TemperatureRecordResponse {
+  recordId: string;
+  babyId: string;
+  episodeId: string;
+  time: number;
+  temperature: number;
+  site: TemperatureSite;
+  fever: boolean;
+  note: string;
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * API 响应: 症状记录详情
+ */
+export interface SymptomRecordResponse {
+  recordId: string;
+  babyId: string;
+  episodeId: string;
+  time: number;
+  symptom: string;
+  severity: SymptomSeverity;
+  note: string;
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * API 响应: 记录列表
+ */
+export interface HealthRecordsListResponse<T> {
+  records: T[];
+  total: number;
+  page: number;
+  pageSize: number;
+}
+
+/**
+ * 列表查询参数
+ */
+export interface HealthRecordsQuery {
+  babyId: string;
+  startTime?: number;
+  endTime?: number;
+  page?: number;
+  pageSize?: number;
+}
+
+/**
+ * 将API响应转换为前端TemperatureRecord类型
+ */
+export function transformTemperatureRecordResponse(
+  response: TemperatureRecordResponse,
+): TemperatureRecord {
+  return {
+    id: response.recordId,
+    babyId: response.babyId,
+    episodeId: response.episodeId || undefined,
+    time: response.time,
+    temperature: response.temperature,
+    site: response.site,
+    fever: response.fever,
+    note: response.note || undefined,
+    createBy: response.createBy,
+    createTime: response.createTime,
+  };
+}
+
+/**
+ * 将API响应转换为前端SymptomRecord类型
+ */
+export function transformSymptomRecordResponse(
+  response: SymptomRecordResponse,
+): SymptomRecord {
+  return {
+    id: response.recordId,
+    babyId: response.babyId,
+    episodeId: response.episodeId || undefined,
+    time: response.time,
+    symptom: response.symptom,
+    severity: response.severity,
+    note: response.note || undefined,
+    createBy: response.createBy,
+    createTime: response.createTime,
+  };
+}
+
+// ============ API 函数 ============
+
+/**
+ * 获取体温记录列表
+ *
+ * @param params 查询参数
+ * @returns Promise<HealthRecordsListResponse<TemperatureRecordResponse>>
+ */
+export async function apiFetchTemperatureRecords(
+  params: HealthRecordsQuery,
+): Promise<HealthRecordsListResponse<TemperatureRecordResponse>> {
+  const response = await get<
+    HealthRecordsListResponse<TemperatureRecordResponse>
+  >("/temperature-records", params);
+  return response.data || { records: [], total: 0, page: 1, pageSize: 10 };
+}
+
+/**
+ * 获取症状记录列表
+ *
+ * @param params 查询参数
+ * @returns Promise<HealthRecordsListResponse<SymptomRecordResponse>>
+ */
+export async function apiFetchSymptomRecords(
+  params: HealthRecordsQuery,
+): Promise<HealthRecordsListResponse<SymptomRecordResponse>> {
+  const response = await get<HealthRecordsListResponse<SymptomRecordResponse>>(
+    "/symptom-records",
+    params,
+  );
+  return response.data || { records: [], total: 0, page: 1, pageSize: 10 };
+}
@@ -15,6 +15,8 @@ import {
   getDailyTips as apiGetDailyTips,
   generateDailyTips as apiGenerateDailyTips,
   pollAnalysisStatus as apiPollAnalysisStatus,
+  streamAnalysis as apiStreamAnalysis,
+  AnalysisStreamError,
   batchAIAnalysis,
 } from "@/api/ai";
 
@@ -28,6 +30,9 @@ export const useAIStore = () => {
   const stats = ref<AnalysisStatsResponse | null>(null); // 分析统计
   const isAnalyzing = ref(false); // 是否正在分析
   const analyzingIds = reactive<Set<string>>(new Set()); // 正在分析的ID集合
+  const analysisProgress = reactive<
+    Record<string, { toolLabel: string; narrative: string }>
+  >({}); // 分析进度流（当前工具、流式输出文本）
   const currentAnalysis = ref<AIAnalysis | null>(null); // 当前分析
   const backgroundPollingEnabled = ref(true); // 是否启用后台轮询
   const pollingTimers = reactive<Map<string, number>>(new Map()); // 轮询定时器映射
@@ -123,20 +128,44 @@ export const useAIStore = () => {
    */
   const pollAnalysisStatusInternal = async (analysisId: number | string) => {
     const idStr = analysisId.toString();
-    try {
-      const result = await apiPollAnalysisStatus(
-        idStr,
-        (status, progress, message) => {
-          // 更新分析状态
-          const a = analyses[idStr];
-          if (a) {
-            a.status = status as AIAnalysisStatus;
-          }
-          console.log(
-            `分析${idStr}状态更新: ${status}, 进度: ${progress}%, 消息: ${message}`,
-          );
-        },
+    const onStatusUpdate = (
+      status: string,
+      progress?: number,
+      message?: string,
+    ) => {
+      // 更新分析状态
+      const a = analyses[idStr];
+      if (a) {
+        a.status = status as AIAnalysisStatus;
+      }
+      console.log(
+        `分析${idStr}状态更新: ${status}, 进度: ${progress}%, 消息: ${message}`,
       );
+    };
+
+    try {
+      let result;
+      try {
+        // 优先订阅进度流，不支持或连接中断时降级为轮询
+        analysisProgress[idStr] = { toolLabel: "", narrative: "" };
+        result = await apiStreamAnalysis(idStr, {
+          onStatus: onStatusUpdate,
+          onToolCall: (label) => {
+            analysisProgress[idStr]!.toolLabel = label;
+          },
+          onNarrative: (text) => {
+            analysisProgress[idStr]!.narrative = text;
+          },
+        });
+      } catch (error) {
+        if (error instanceof AnalysisStreamError) {
+          throw error;
+        }
+        console.warn("分析进度流不可用，降级为轮询:", error);
+        result = await apiPollAnalysisStatus(idStr, onStatusUpdate);
+      } finally {
+        delete analysisProgress[idStr];
+      }
 
       // 更新完整分析结果
       const a = analyses[idStr];
@@ -573,6 +602,7 @@ export const useAIStore = () => {
     stats,
     isAnalyzing,
     analyzingIds,
+    analysisProgress,
     currentAnalysis,
     backgroundPollingEnabled,
 
@@ -211,6 +211,24 @@ export interface AnalysisStatusResponse {
   updated_at: string;
 }
 
+/**
+ * 分析进度流事件（SSE）
+ * 同一轮推理再次收到 thinking 事件表示流式输出中断后重新生成，之前的 delta 文本需要丢弃
+ */
+export interface AnalysisStreamEvent {
+  event: "status" | "thinking" | "tool_call" | "delta" | "result" | "error";
+  analysis_id: number;
+  status?: AIAnalysisStatus;
+  progress?: number;
+  message?: string;
+  iteration?: number; // 第几轮推理（从1开始）
+  tool?: string;
+  tool_label?: string; // 工具描述
+  text?: string; // 增量文本
+  result?: AIAnalysisResult;
+  timestamp: number;
+}
+
 /**
  * 批量分析响应
  */
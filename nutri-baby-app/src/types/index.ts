@@ -250,6 +250,173 @@ export interface GrowthRecord {
   createTime: number;
 }
 
+/**
+ * 体温测量部位
+ */
+export type TemperatureSite = "axillary" | "oral" | "rectal" | "ear" | "forehead";
+
+/**
+ * 体温记录
+ */
+export interface TemperatureRecord {
+  id: string;
+  babyId: string;
+  episodeId?: string; // 所属患病经过
+  time: number;
+  temperature: number; // 体温(℃)
+  site: TemperatureSite;
+  fever: boolean; // 是否达到该部位的发热标准
+  note?: string;
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * 症状严重程度
+ */
+export type SymptomSeverity = "mild" | "moderate" | "severe";
+
+/**
+ * 症状记录
+ */
+export interface SymptomRecord {
+  id: string;
+  babyId: string;
+  episodeId?: string; // 所属患病经过
+  time: number;
+  symptom: string; // cough, runny_nose, vomiting 等
+  severity: SymptomSeverity;
+  note?: string;
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * 药品/补充剂
+ */
+export type MedicationKind = "medicine" | "supplement";
+export type DoseUnit = "ml" | "mg" | "drop" | "tablet" | "sachet" | "iu";
+
+export interface MedicationSchedule {
+  scheduleId: string;
+  medicationId: string;
+  mode: "times" | "interval"; // 每天固定时间 / 距上次给药固定间隔
+  timesOfDay: string[]; // HH:mm
+  intervalHours: number;
+  startDate: string;
+  endDate: string; // 为空表示长期
+  enabled: boolean;
+  lastRemindedAt: number;
+}
+
+export interface Medication {
+  medicationId: string;
+  babyId: string;
+  name: string;
+  kind: MedicationKind;
+  doseUnit: DoseUnit;
+  defaultDose: number;
+  strength: number; // 每剂量单位含多少毫克
+  maxDoseMgPerKg: number; // 0 表示不校验
+  maxDailyMgPerKg: number;
+  maxDailyMg: number;
+  maxDailyDoses: number;
+  minIntervalHours: number;
+  active: boolean;
+  note?: string;
+  lastDoseTime?: number;
+  schedules: MedicationSchedule[];
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * 给药记录
+ */
+export interface MedicationDose {
+  doseId: string;
+  babyId: string;
+  medicationId: string;
+  medicationName: string;
+  doseUnit: DoseUnit;
+  time: number;
+  amount: number;
+  warnings: string[]; // 记录时的剂量警告
+  note?: string;
+  createBy: string;
+  createTime: number;
+}
+
+/**
+ * 给药前剂量校验结果
+ */
+export interface MedicationDoseCheck {
+  amount: number;
+  amountMg?: number;
+  weightKg?: number;
+  dailyTotalMg?: number;
+  dailyDoses: number;
+  nextDoseTime?: number; // 满足最短间隔的最早给药时间
+  warnings: string[];
+}
+
+/**
+ * 发育里程碑
+ */
+export type MilestoneDomain = "motor" | "language" | "social" | "cognitive";
+export type MilestoneStatus =
+  | "achieved" // 已达成
+  | "upcoming" // 未到常见月龄
+  | "in_window" // 常见月龄内
+  | "delayed"; // 超过常见月龄仍未达成(软提醒)
+
+export interface MilestoneCatalogItem {
+  code: string;
+  name: string;
+  domain: MilestoneDomain;
+  startMonths: number; // 常见最早月龄
+  endMonths: number; // 常见最晚月龄
+}
+
+/**
+ * 里程碑达成记录
+ */
+export interface MilestoneRecord {
+  recordId: string;
+  babyId: string;
+  milestoneCode: string;
+  milestoneName: string;
+  domain: MilestoneDomain;
+  achievedAt: number;
+  ageMonths: number; // 达成时的满月月龄
+  note?: string;
+  photoUrl?: string;
+  createBy: string;
+  createTime: number;
+}
+
+export interface MilestoneChecklistItem extends MilestoneCatalogItem {
+  status: MilestoneStatus;
+  record?: MilestoneRecord;
+}
+
+/**
+ * 宝宝的里程碑清单
+ */
+export interface MilestoneChecklist {
+  ageMonths: number;
+  items: MilestoneChecklistItem[];
+  achieved: number;
+  alerts: {
+    source: string;
+    ruleCode: string;
+    level: "critical" | "warning" | "info";
+    title: string;
+    message: string;
+    triggeredAt: number;
+  }[];
+}
+
 /**
  * 其他事件记录
  */
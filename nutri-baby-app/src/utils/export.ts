@@ -7,6 +7,8 @@ import type {
   FeedingRecord,
   DiaperRecord,
   SleepRecord,
+  TemperatureRecord,
+  SymptomRecord,
 } from "@/types";
 import { formatDate } from "./date";
 
@@ -20,6 +22,8 @@ export interface ExportData {
   feedingRecords: FeedingRecord[];
   diaperRecords: DiaperRecord[];
   sleepRecords: SleepRecord[];
+  temperatureRecords?: TemperatureRecord[]; // 旧版本导出的文件没有该字段
+  symptomRecords?: SymptomRecord[];
 }
 
 /**
@@ -128,12 +132,16 @@ export function readFileContent(filePath: string): Promise<string> {
  * 生成导出数据摘要
  */
 export function generateExportSummary(data: ExportData): string {
+  const temperatureCount = data.temperatureRecords?.length ?? 0;
+  const symptomCount = data.symptomRecords?.length ?? 0;
   return `
 导出时间: ${data.exportTimeText}
 宝宝数量: ${data.babies.length}
 喂养记录: ${data.feedingRecords.length} 条
 换尿布记录: ${data.diaperRecords.length} 条
 睡眠记录: ${data.sleepRecords.length} 条
-总记录数: ${data.feedingRecords.length + data.diaperRecords.length + data.sleepRecords.length} 条
+体温记录: ${temperatureCount} 条
+症状记录: ${symptomCount} 条
+总记录数: ${data.feedingRecords.length + data.diaperRecords.length + data.sleepRecords.length + temperatureCount + symptomCount} 条
   `.trim();
 }
@@ -250,6 +250,54 @@ export function del<T = any>(url: string, data?: any): Promise<ApiResponse<T>> {
   return request<T>({ url, method: "DELETE", data });
 }
 
+/**
+ * 分块接收的流式 GET 请求（用于 SSE）
+ * 云托管 callContainer 不支持分块传输，直接拒绝，由调用方降级为轮询；
+ * 不支持 onChunkReceived 的平台在请求结束后一次性回调完整响应
+ */
+export function requestStream(
+  url: string,
+  onChunk: (chunk: ArrayBuffer | string) => void,
+): Promise<void> {
+  const isWechatMp = typeof wx !== "undefined" && !!wx.cloud;
+  if (isWechatMp && CLOUD_ENV_ID) {
+    return Promise.reject(new Error("callContainer 不支持流式响应"));
+  }
+
+  return new Promise((resolve, reject) => {
+    let chunked = false;
+    const task: any = uni.request({
+      url: `${BASE_URL}${url}`,
+      method: "GET",
+      header: {
+        ...getHeaders(),
+        Accept: "text/event-stream",
+      },
+      timeout: TIMEOUT,
+      enableChunked: true,
+      responseType: "arraybuffer",
+      success: (res: any) => {
+        if (res.statusCode !== 200) {
+          reject(res);
+          return;
+        }
+        if (!chunked && res.data) {
+          onChunk(res.data);
+        }
+        resolve();
+      },
+      fail: reject,
+    } as any);
+
+    if (task && typeof task.onChunkReceived === "function") {
+      task.onChunkReceived((res: { data: ArrayBuffer }) => {
+        chunked = true;
+        onChunk(res.data);
+      });
+    }
+  });
+}
+
 /**
  * 文件上传 (适配云托管?)
  * 云托管 callContainer 暂不支持直接 uploadFile，通常建议走 COS SDK 上传或 HTTPS 上传
@@ -0,0 +1,117 @@
+/**
+ * Server-Sent Events 解析
+ * 小程序分块接收的数据是 ArrayBuffer，一个多字节字符可能被拆到两个分块中，
+ * 因此按字节缓存到事件分隔符（空行）后再整体解码
+ */
+
+export interface SSEMessage {
+  event: string;
+  data: string;
+}
+
+const LF = 0x0a;
+const CR = 0x0d;
+
+/**
+ * UTF-8 解码（小程序环境没有 TextDecoder）
+ */
+function decodeUTF8(bytes: Uint8Array): string {
+  if (typeof TextDecoder !== "undefined") {
+    return new TextDecoder("utf-8").decode(bytes);
+  }
+  let result = "";
+  let i = 0;
+  while (i < bytes.length) {
+    const byte = bytes[i++]!;
+    let codePoint = byte;
+    if (byte >= 0xf0) {
+      codePoint =
+        ((byte & 0x07) << 18) |
+        ((bytes[i++]! & 0x3f) << 12) |
+        ((bytes[i++]! & 0x3f) << 6) |
+        (bytes[i++]! & 0x3f);
+    } else if (byte >= 0xe0) {
+      codePoint =
+        ((byte & 0x0f) << 12) |
+        ((bytes[i++]! & 0x3f) << 6) |
+        (bytes[i++]! & 0x3f);
+    } else if (byte >= 0xc0) {
+      codePoint = ((byte & 0x1f) << 6) | (bytes[i++]! & 0x3f);
+    }
+    result += String.fromCodePoint(codePoint);
+  }
+  return result;
+}
+
+/**
+ * 解析单个事件块（event:/data: 行）
+ */
+function parseBlock(block: string): SSEMessage | null {
+  let event = "message";
+  const data: string[] = [];
+  for (const line of block.split(/\r?\n/)) {
+    if (!line || line.startsWith(":")) {
+      continue;
+    }
+    const index = line.indexOf(":");
+    const field = index < 0 ? line : line.slice(0, index);
+    let value = index < 0 ? "" : line.slice(index + 1);
+    if (value.startsWith(" ")) {
+      value = value.slice(1);
+    }
+    if (field === "event") {
+      event = value;
+    } else if (field === "data") {
+      data.push(value);
+    }
+  }
+  return data.length > 0 ? { event, data: data.join("\n") } : null;
+}
+
+/**
+ * 增量 SSE 解析器
+ */
+export class SSEParser {
+  private buffer = new Uint8Array(0);
+
+  constructor(private onMessage: (message: SSEMessage) => void) {}
+
+  /**
+   * 追加接收到的数据，解析出完整的事件
+   */
+  push(chunk: ArrayBuffer | Uint8Array | string) {
+    const bytes =
+      typeof chunk === "string"
+        ? new Uint8Array(
+            Array.from(unescape(encodeURIComponent(chunk)), (c) =>
+              c.charCodeAt(0),
+            ),
+          )
+        : chunk instanceof Uint8Array
+          ? chunk
+          : new Uint8Array(chunk);
+
+    const merged = new Uint8Array(this.buffer.length + bytes.length);
+    merged.set(this.buffer);
+    merged.set(bytes, this.buffer.length);
+
+    let start = 0;
+    for (let i = 0; i < merged.length; i++) {
+      if (merged[i] !== LF) {
+        continue;
+      }
+      // 空行（\n\n 或 \n\r\n）结束一个事件
+      const next = merged[i + 1] === CR ? i + 2 : i + 1;
+      if (merged[next] !== LF) {
+        continue;
+      }
+      const message = parseBlock(decodeUTF8(merged.subarray(start, i)));
+      if (message) {
+        this.onMessage(message);
+      }
+      start = next + 1;
+      i = next;
+    }
+    this.buffer = merged.slice(start);
+  }
+}
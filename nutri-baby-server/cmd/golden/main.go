@@ -0,0 +1,159 @@
+// golden 分析链录制/回放评测工具
+//
+// 回放(默认，不访问网络): 用录制的模型输出驱动当前代码，报告提示词、工具和分析结果的差异，
+// 分析结果(结构或评分)有变化时退出码为 1
+//
+//	go run ./cmd/golden
+//	go run ./cmd/golden -case newborn_feeding -json
+//
+// 录制: 用指定提供商重新运行内置的合成宝宝用例并覆盖用例文件
+//
+//	go run ./cmd/golden -record -provider mock
+//	go run ./cmd/golden -record -provider deepseek -config config/config.yaml -case infant_short_sleep
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/golden"
+	aimodel "github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/model"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
+	"go.uber.org/zap"
+)
+
+func main() {
+	dir := flag.String("dir", "internal/infrastructure/eino/golden/testdata", "评测用例目录")
+	record := flag.Bool("record", false, "重新录制用例")
+	provider := flag.String("provider", "mock", "录制使用的提供商: mock、offline 或配置文件中的提供商")
+	configPath := flag.String("config", "", "配置文件路径，录制真实提供商时需要")
+	caseNames := flag.String("case", "", "只处理指定用例，多个用逗号分隔")
+	asJSON := flag.Bool("json", false, "以 JSON 输出回放报告")
+	verbose := flag.Bool("v", false, "输出分析链日志")
+	flag.Parse()
+
+	logger := zap.NewNop()
+	if *verbose {
+		logger, _ = zap.NewDevelopment()
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(*caseNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	ctx := context.Background()
+	if *record {
+		if err := recordCases(ctx, *dir, *provider, *configPath, selected, logger); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	changed, err := replayCases(ctx, *dir, selected, *asJSON, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if changed {
+		os.Exit(1)
+	}
+}
+
+// recordCases 录制内置用例
+func recordCases(ctx context.Context, dir, provider, configPath string, selected map[string]bool, logger *zap.Logger) error {
+	var cfg *config.Config
+	if configPath != "" {
+		loaded, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+
+	for _, c := range golden.SyntheticCases() {
+		if len(selected) > 0 && !selected[c.Name] {
+			continue
+		}
+		chatModel, err := newChatModel(provider, cfg, c, logger)
+		if err != nil {
+			return err
+		}
+		c.Provider = provider
+		if err := golden.Record(ctx, c, chatModel, logger); err != nil {
+			return err
+		}
+		if err := golden.SaveCase(dir, c); err != nil {
+			return err
+		}
+		status := "完成"
+		if c.Expected.Error != "" {
+			status = "分析链返回错误: " + c.Expected.Error
+		}
+		fmt.Printf("%s: 录制 %d 轮模型调用，%s\n", c.Name, len(c.Transcript), status)
+	}
+	return nil
+}
+
+// newChatModel 创建录制使用的模型，离线引擎和真实提供商都读取用例的内存数据
+func newChatModel(provider string, cfg *config.Config, c *golden.Case, logger *zap.Logger) (model.ToolCallingChatModel, error) {
+	if provider == "mock" {
+		return chain.NewToolCallingMockChatModel(logger), nil
+	}
+
+	store := golden.NewStore(c)
+	analyzer := offline.NewAnalyzer(store.Babies, store.Feedings, store.Sleeps, store.Diapers, store.Growth,
+		store.Vaccines, store.HealthAlerts, logger)
+	if provider == "offline" {
+		return offline.NewChatModel(analyzer), nil
+	}
+
+	if cfg == nil {
+		return nil, fmt.Errorf("录制提供商 %s 需要通过 -config 指定配置文件", provider)
+	}
+	providerCfg := *cfg
+	providerCfg.AI.Providers = []string{provider}
+	return aimodel.NewProviderChain(&providerCfg, store.Usage, analyzer, logger), nil
+}
+
+// replayCases 回放目录中的用例并输出报告，返回是否有分析结果变化
+func replayCases(ctx context.Context, dir string, selected map[string]bool, asJSON bool, logger *zap.Logger) (bool, error) {
+	cases, err := golden.LoadCases(dir)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	reports := make([]*golden.Report, 0, len(cases))
+	for _, c := range cases {
+		if len(selected) > 0 && !selected[c.Name] {
+			continue
+		}
+		report, err := golden.Replay(ctx, c, logger)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || report.ResultChanged()
+		reports = append(reports, report)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return changed, encoder.Encode(reports)
+	}
+	for _, report := range reports {
+		fmt.Print(report.String())
+	}
+	return changed, nil
+}
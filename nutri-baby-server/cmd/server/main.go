@@ -54,6 +54,10 @@ func main() {
 	app.Scheduler.Start()
 	defer app.Scheduler.Stop()
 
+	// 启动AI分析任务执行器
+	app.AIJobRunner.Start()
+	defer app.AIJobRunner.Stop()
+
 	// 启动HTTP服务器
 	port := cfg.Server.Port
 	if envPort := os.Getenv("PORT"); envPort != "" {
@@ -7,6 +7,7 @@ require (
 	github.com/cloudwego/eino v0.5.13
 	github.com/cloudwego/eino-ext/components/model/deepseek v0.0.0-20251111090228-91a10bbc864f
 	github.com/cloudwego/eino-ext/components/model/gemini v0.1.12
+	github.com/getkin/kin-openapi v0.118.0
 	github.com/gin-gonic/gin v1.11.0
 	github.com/go-co-op/gocron v1.37.0
 	github.com/golang-jwt/jwt/v5 v5.3.0
@@ -50,7 +51,6 @@ require (
 	github.com/fatih/structs v1.1.0 // indirect
 	github.com/fsnotify/fsnotify v1.9.0 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.12 // indirect
-	github.com/getkin/kin-openapi v0.118.0 // indirect
 	github.com/gin-contrib/sse v1.1.0 // indirect
 	github.com/go-openapi/jsonpointer v0.22.1 // indirect
 	github.com/go-openapi/jsonreference v0.21.3 // indirect
@@ -0,0 +1,31 @@
+package dto
+
+// ============ AI 育儿助手对话 DTO ============
+
+// AIChatRequest 向助手提问请求
+type AIChatRequest struct {
+	Question string `json:"question" binding:"required,max=500"` // 问题内容
+}
+
+// AIChatCitationDTO 回答引用的记录
+type AIChatCitationDTO struct {
+	RecordType string `json:"recordType"` // 记录类型: feeding, sleep, diaper, growth, vaccine, illness, crying, temperature, symptom, milestone
+	RecordID   string `json:"recordId"`   // 记录ID
+	Time       int64  `json:"time"`       // 记录时间(毫秒时间戳)
+}
+
+// AIChatMessageDTO 对话消息
+type AIChatMessageDTO struct {
+	MessageID string               `json:"messageId"`
+	Role      string               `json:"role"` // user, assistant
+	Content   string               `json:"content"`
+	Citations []*AIChatCitationDTO `json:"citations"` // 引用的记录(仅助手消息)
+	Tools     []string             `json:"tools"`     // 查询过的数据工具(仅助手消息)
+	CreatedAt int64                `json:"createdAt"` // 创建时间(毫秒时间戳)
+}
+
+// AIChatHistoryQuery 对话历史查询参数
+type AIChatHistoryQuery struct {
+	Before int64 `form:"before"`                                  // 查询该时间之前的消息(毫秒时间戳)，不传表示最新
+	Limit  int   `form:"limit" binding:"omitempty,min=1,max=100"` // 条数，默认20
+}
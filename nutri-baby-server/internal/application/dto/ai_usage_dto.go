@@ -0,0 +1,79 @@
+package dto
+
+// AIUsageReportRequest AI用量报表查询条件(日期按服务器时区)
+type AIUsageReportRequest struct {
+	StartDate string `form:"startDate"` // YYYY-MM-DD，默认最近7天
+	EndDate   string `form:"endDate"`   // YYYY-MM-DD(含)，默认今天
+	OpenID    string `form:"openid"`    // 可选:只统计该用户
+	BabyID    string `form:"babyId"`    // 可选:只统计该宝宝
+	Top       int    `form:"top"`       // 用量排行条数，默认10
+}
+
+// AIUsageSummaryDTO 用量汇总
+type AIUsageSummaryDTO struct {
+	Requests         int64   `json:"requests"`         // 业务请求数(一次分析/建议/对话/快速记录)
+	Calls            int64   `json:"calls"`            // 模型调用次数
+	FailedCalls      int64   `json:"failedCalls"`      // 失败的模型调用次数
+	PromptTokens     int64   `json:"promptTokens"`     // 输入token数
+	CompletionTokens int64   `json:"completionTokens"` // 输出token数
+	TotalTokens      int64   `json:"totalTokens"`      // 总token数
+	Cost             float64 `json:"cost"`             // 估算费用(美元)
+	AvgLatencyMs     float64 `json:"avgLatencyMs"`     // 平均调用耗时(毫秒)
+}
+
+// AIUsageDailyDTO 按天+提供商+模型的用量
+type AIUsageDailyDTO struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	AIUsageSummaryDTO
+}
+
+// AIUsageConsumerDTO 用户或宝宝的用量
+type AIUsageConsumerDTO struct {
+	OpenID string `json:"openid,omitempty"`
+	BabyID string `json:"babyId,omitempty"`
+	AIUsageSummaryDTO
+}
+
+// AIQuotaDTO 当前生效的每日配额(0 表示不限制)
+type AIQuotaDTO struct {
+	Enabled           bool  `json:"enabled"`
+	UserDailyRequests int   `json:"userDailyRequests"`
+	UserDailyTokens   int64 `json:"userDailyTokens"`
+	BabyDailyRequests int   `json:"babyDailyRequests"`
+	BabyDailyTokens   int64 `json:"babyDailyTokens"`
+}
+
+// AIUsageReportResponse AI用量报表
+type AIUsageReportResponse struct {
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+	Timezone  string               `json:"timezone"` // 划分自然日使用的时区
+	Totals    AIUsageSummaryDTO    `json:"totals"`
+	Daily     []AIUsageDailyDTO    `json:"daily"`
+	TopUsers  []AIUsageConsumerDTO `json:"topUsers"`
+	TopBabies []AIUsageConsumerDTO `json:"topBabies"`
+	Quota     AIQuotaDTO           `json:"quota"`
+}
+
+// AIProviderHealthDTO 单个AI模型提供商的健康状态
+type AIProviderHealthDTO struct {
+	Provider            string `json:"provider"`
+	Model               string `json:"model"`
+	State               string `json:"state"` // closed-正常 open-熔断中 half_open-探测中
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	TotalSuccesses      int64  `json:"totalSuccesses"`
+	TotalFailures       int64  `json:"totalFailures"`
+	LastError           string `json:"lastError,omitempty"`
+	LastSuccessAt       int64  `json:"lastSuccessAt,omitempty"`
+	LastFailureAt       int64  `json:"lastFailureAt,omitempty"`
+	OpenUntil           int64  `json:"openUntil,omitempty"` // 熔断结束时间
+	TimeoutSeconds      int    `json:"timeoutSeconds"`      // 0 表示不限制
+	RateLimitPerMinute  int    `json:"rateLimitPerMinute"`  // 0 表示不限制
+}
+
+// AIProviderHealthResponse AI模型提供商健康状态，顺序即回退顺序
+type AIProviderHealthResponse struct {
+	Providers []AIProviderHealthDTO `json:"providers"`
+}
@@ -20,6 +20,7 @@ type UserInfoDTO struct {
 	NickName      string `json:"nickName"`
 	AvatarURL     string `json:"avatarUrl"`
 	DefaultBabyID string `json:"defaultBabyId"`
+	Locale        string `json:"locale"` // 语言偏好，为空表示跟随客户端
 	CreateTime    int64  `json:"createTime"`
 	LastLoginTime int64  `json:"lastLoginTime"`
 }
@@ -35,6 +36,11 @@ type SetDefaultBabyRequest struct {
 	BabyID string `json:"babyId" binding:"required"`
 }
 
+// SetLocaleRequest 设置语言偏好请求
+type SetLocaleRequest struct {
+	Locale string `json:"locale"` // zh-CN、en-US 等，为空表示跟随客户端
+}
+
 // UpdateUserInfoRequest 更新用户信息请求
 type UpdateUserInfoRequest struct {
 	NickName  string `json:"nickName" binding:"required"`
@@ -7,6 +7,7 @@ type CreateBabyRequest struct {
 	Gender                string `json:"gender" binding:"required,oneof=male female"`
 	BirthDate             string `json:"birthDate" binding:"required"` // YYYY-MM-DD
 	AvatarURL             string `json:"avatarUrl"`
+	Timezone              string `json:"timezone"`              // 可选:IANA时区名称，如 Asia/Shanghai
 	CopyCollaboratorsFrom string `json:"copyCollaboratorsFrom"` // 可选:复制协作者的源宝宝ID
 }
 
@@ -17,8 +18,9 @@ type UpdateBabyRequest struct {
 	Gender    string `json:"gender" binding:"omitempty,oneof=male female"`
 	BirthDate string `json:"birthDate"` // YYYY-MM-DD
 	AvatarURL string `json:"avatarUrl"`
-	Height    int    `json:"height"` // cm
-	Weight    int    `json:"weight"` // g
+	Timezone  string `json:"timezone"` // IANA时区名称，如 Asia/Shanghai
+	Height    int    `json:"height"`   // cm
+	Weight    int    `json:"weight"`   // g
 }
 
 // BabyDTO 宝宝DTO (去家庭化架构)
@@ -30,6 +32,7 @@ type BabyDTO struct {
 	BirthDate  string `json:"birthDate"`
 	AvatarURL  string `json:"avatarUrl"`
 	CreatorID  string `json:"creatorId"` // 创建者 openid
+	Timezone   string `json:"timezone"`  // 时区(IANA名称)，为空表示使用服务器时区
 	Height     int    `json:"height"`
 	Weight     int    `json:"weight"`
 	CreateTime int64  `json:"createTime"`
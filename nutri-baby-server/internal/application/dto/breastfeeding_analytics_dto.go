@@ -0,0 +1,62 @@
+package dto
+
+// ============ 母乳喂养分析 DTO ============
+
+// BreastfeedingAnalyticsRequest 母乳喂养分析请求
+type BreastfeedingAnalyticsRequest struct {
+	BabyID    string `form:"babyId"`                       // 宝宝ID(路径参数)
+	StartDate int64  `form:"startDate" binding:"required"` // 开始时间（毫秒时间戳）
+	EndDate   int64  `form:"endDate" binding:"required"`   // 结束时间（毫秒时间戳）
+}
+
+// BreastfeedingSummary 母乳喂养汇总
+type BreastfeedingSummary struct {
+	TotalCount         int     `json:"totalCount"`         // 母乳喂养次数
+	TotalDuration      int     `json:"totalDuration"`      // 总时长（秒）
+	LeftDuration       int     `json:"leftDuration"`       // 左侧总时长（秒）
+	RightDuration      int     `json:"rightDuration"`      // 右侧总时长（秒）
+	LeftRatio          float64 `json:"leftRatio"`          // 左侧占比(0-1)
+	AvgSessionDuration int     `json:"avgSessionDuration"` // 平均每次时长（秒）
+	AvgInterval        int     `json:"avgInterval"`        // 平均喂养间隔（分钟）
+}
+
+// DailyBreastBalanceItem 每日左右侧平衡
+type DailyBreastBalanceItem struct {
+	Date          string  `json:"date"`          // 日期，格式 YYYY-MM-DD
+	Count         int     `json:"count"`         // 喂养次数
+	LeftDuration  int     `json:"leftDuration"`  // 左侧时长（秒）
+	RightDuration int     `json:"rightDuration"` // 右侧时长（秒）
+	LeftRatio     float64 `json:"leftRatio"`     // 左侧占比(0-1)
+}
+
+// NextBreastSide 下次建议喂养侧
+type NextBreastSide struct {
+	Side            string `json:"side"`                      // left, right
+	Reason          string `json:"reason"`                    // 建议原因
+	LastSide        string `json:"lastSide,omitempty"`        // 上次结束侧
+	LastFeedingTime *int64 `json:"lastFeedingTime,omitempty"` // 上次喂养时间（毫秒时间戳）
+}
+
+// ClusterFeedingItem 密集喂养(Cluster Feeding)时段
+type ClusterFeedingItem struct {
+	StartTime     int64 `json:"startTime"`     // 开始时间（毫秒时间戳）
+	EndTime       int64 `json:"endTime"`       // 最后一次喂养时间（毫秒时间戳）
+	FeedCount     int   `json:"feedCount"`     // 时段内喂养次数
+	TotalDuration int   `json:"totalDuration"` // 时段内总时长（秒）
+}
+
+// HourlyBreastfeedingItem 每小时喂养热力图项
+type HourlyBreastfeedingItem struct {
+	Hour          int `json:"hour"`          // 小时(0-23)
+	Count         int `json:"count"`         // 喂养次数
+	TotalDuration int `json:"totalDuration"` // 总时长（秒）
+}
+
+// BreastfeedingAnalyticsResponse 母乳喂养分析响应
+type BreastfeedingAnalyticsResponse struct {
+	Summary         BreastfeedingSummary       `json:"summary"`         // 汇总
+	DailyBalance    []*DailyBreastBalanceItem  `json:"dailyBalance"`    // 每日左右侧平衡
+	NextSide        *NextBreastSide            `json:"nextSide"`        // 下次建议喂养侧
+	ClusterFeedings []*ClusterFeedingItem      `json:"clusterFeedings"` // 密集喂养时段
+	HourlyHeatmap   []*HourlyBreastfeedingItem `json:"hourlyHeatmap"`   // 24小时热力图
+}
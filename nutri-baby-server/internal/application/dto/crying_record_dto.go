@@ -0,0 +1,30 @@
+package dto
+
+// ============ 哭闹记录 DTO ============
+
+// CreateCryingRecordRequest 创建哭闹记录请求
+type CreateCryingRecordRequest struct {
+	BabyID    string  `json:"babyId" binding:"required"`
+	StartTime int64   `json:"startTime"`                                                                      // 开始时间(毫秒时间戳)，为空时取当前时间
+	Duration  int     `json:"duration" binding:"gte=0"`                                                       // 时长(秒)
+	Type      string  `json:"type" binding:"required,oneof=crying fussy"`                                     // 类型: crying(哭闹), fussy(烦躁/闹觉)
+	Intensity string  `json:"intensity" binding:"omitempty,oneof=mild moderate intense"`                      // 强度，为空时记为 moderate
+	Trigger   string  `json:"trigger" binding:"omitempty,oneof=hunger tired discomfort gas teething unknown"` // 诱因，为空时记为 unknown
+	Soothing  *string `json:"soothing"`                                                                       // 有效的安抚方式(如抱着走动、白噪音、喂奶)
+	Note      *string `json:"note"`                                                                           // 备注
+}
+
+// CryingRecordDTO 哭闹记录DTO
+type CryingRecordDTO struct {
+	RecordID   string `json:"recordId"`
+	BabyID     string `json:"babyId"`
+	StartTime  int64  `json:"startTime"`
+	Duration   int    `json:"duration"`
+	Type       string `json:"type"`
+	Intensity  string `json:"intensity"`
+	Trigger    string `json:"trigger"`
+	Soothing   string `json:"soothing"`
+	Note       string `json:"note"`
+	CreateBy   string `json:"createBy"`
+	CreateTime int64  `json:"createTime"`
+}
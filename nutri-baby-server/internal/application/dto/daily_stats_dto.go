@@ -34,18 +34,59 @@ type DailyGrowthStatsItem struct {
 	RecordCount             int64  `json:"recordCount"`             // 当日记录数
 }
 
+// DailyTemperatureStatsItem 每日体温统计项
+type DailyTemperatureStatsItem struct {
+	Date           string  `json:"date"`           // 日期，格式 YYYY-MM-DD
+	MaxTemperature float64 `json:"maxTemperature"` // 当日最高体温（℃）
+	FeverCount     int64   `json:"feverCount"`     // 达到发热标准的次数
+	TotalCount     int64   `json:"totalCount"`     // 测量次数
+}
+
+// DailySymptomStatsItem 每日症状统计项
+type DailySymptomStatsItem struct {
+	Date       string `json:"date"`       // 日期，格式 YYYY-MM-DD
+	Symptom    string `json:"symptom"`    // 症状
+	TotalCount int64  `json:"totalCount"` // 记录次数
+}
+
+// 奶量充足度标记
+const (
+	IntakeFlagLowVolume    = "low_volume"     // 每公斤奶量明显低于参考范围
+	IntakeFlagLowFeedCount = "low_feed_count" // 喂奶次数明显低于参考范围
+)
+
+// DailyIntakeStatsItem 每日奶量充足度统计项
+type DailyIntakeStatsItem struct {
+	Date        string   `json:"date"`                 // 日期，格式 YYYY-MM-DD
+	BottleMl    int64    `json:"bottleMl"`             // 奶瓶总奶量（ml，盎司已换算）
+	BottleCount int      `json:"bottleCount"`          // 奶瓶喂养次数
+	BreastCount int      `json:"breastCount"`          // 母乳亲喂次数
+	FeedCount   int      `json:"feedCount"`            // 喂奶总次数（不含辅食）
+	WeightKg    float64  `json:"weightKg"`             // 当日体重（kg，测量间线性插值）
+	MlPerKg     *float64 `json:"mlPerKg,omitempty"`    // 每公斤奶量（ml/kg/day）
+	MinMlPerKg  *float64 `json:"minMlPerKg,omitempty"` // 参考范围下限（ml/kg/day）
+	MaxMlPerKg  *float64 `json:"maxMlPerKg,omitempty"` // 参考范围上限（ml/kg/day）
+	MinFeeds    int      `json:"minFeeds"`             // 参考喂奶次数下限
+	MaxFeeds    int      `json:"maxFeeds"`             // 参考喂奶次数上限
+	BelowRange  bool     `json:"belowRange"`           // 是否明显低于参考范围
+	Flags       []string `json:"flags"`                // 偏低原因：low_volume/low_feed_count
+}
+
 // DailyStatsRequest 按日统计请求
 type DailyStatsRequest struct {
 	BabyID    string `form:"babyId" binding:"required"`    // 宝宝ID
 	StartDate int64  `form:"startDate" binding:"required"` // 开始日期（毫秒时间戳）
 	EndDate   int64  `form:"endDate" binding:"required"`   // 结束日期（毫秒时间戳）
-	Types     string `form:"types"`                        // 统计类型，逗号分隔：feeding,sleep,diaper,growth，默认全部
+	Types     string `form:"types"`                        // 统计类型，逗号分隔：feeding,sleep,diaper,growth,intake,temperature,symptom，默认除 intake 外全部
 }
 
 // DailyStatsResponse 按日统计响应
 type DailyStatsResponse struct {
-	Feeding []*DailyFeedingStatsItem `json:"feeding,omitempty"` // 喂养统计
-	Sleep   []*DailySleepStatsItem   `json:"sleep,omitempty"`   // 睡眠统计
-	Diaper  []*DailyDiaperStatsItem  `json:"diaper,omitempty"`  // 排泄统计
-	Growth  []*DailyGrowthStatsItem  `json:"growth,omitempty"`  // 成长统计
+	Feeding     []*DailyFeedingStatsItem     `json:"feeding,omitempty"`     // 喂养统计
+	Sleep       []*DailySleepStatsItem       `json:"sleep,omitempty"`       // 睡眠统计
+	Diaper      []*DailyDiaperStatsItem      `json:"diaper,omitempty"`      // 排泄统计
+	Growth      []*DailyGrowthStatsItem      `json:"growth,omitempty"`      // 成长统计
+	Intake      []*DailyIntakeStatsItem      `json:"intake,omitempty"`      // 奶量充足度
+	Temperature []*DailyTemperatureStatsItem `json:"temperature,omitempty"` // 体温统计
+	Symptom     []*DailySymptomStatsItem     `json:"symptom,omitempty"`     // 症状统计
 }
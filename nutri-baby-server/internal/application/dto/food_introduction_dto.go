@@ -0,0 +1,79 @@
+package dto
+
+// ============ 辅食引入与过敏原 DTO ============
+
+// FoodCatalogItemDTO 食物目录条目
+type FoodCatalogItemDTO struct {
+	Code         string   `json:"code"`         // 目录编码
+	Name         string   `json:"name"`         // 食物名称
+	Category     string   `json:"category"`     // 分类: grain, vegetable, fruit, meat, egg, dairy, seafood, legume, nut, seed
+	Allergens    []string `json:"allergens"`    // 过敏原标签
+	MinAgeMonths int      `json:"minAgeMonths"` // 建议最早引入月龄
+}
+
+// FoodReactionDTO 食物反应
+type FoodReactionDTO struct {
+	ReactionID      string   `json:"reactionId"`
+	FoodKey         string   `json:"foodKey"`
+	FoodName        string   `json:"foodName"`
+	FeedingRecordID *string  `json:"feedingRecordId,omitempty"`
+	Severity        string   `json:"severity"` // mild, moderate, severe
+	Symptoms        []string `json:"symptoms"`
+	Note            string   `json:"note,omitempty"`
+	OccurredAt      int64    `json:"occurredAt"` // 发生时间(毫秒时间戳)
+}
+
+// FoodIntroductionDTO 辅食引入记录
+type FoodIntroductionDTO struct {
+	FoodKey       string             `json:"foodKey"`
+	FoodName      string             `json:"foodName"`
+	Category      string             `json:"category,omitempty"`
+	Allergens     []string           `json:"allergens"`
+	FirstTriedAt  int64              `json:"firstTriedAt"`  // 首次尝试时间(毫秒时间戳)
+	LastOfferedAt int64              `json:"lastOfferedAt"` // 最近喂食时间(毫秒时间戳)
+	TimesOffered  int                `json:"timesOffered"`  // 累计喂食次数
+	Status        string             `json:"status"`        // trying(观察中), in_diet(已纳入日常), lapsed(已中断), reaction(出现反应)
+	Reactions     []*FoodReactionDTO `json:"reactions"`
+}
+
+// CreateFoodReactionRequest 记录食物反应请求
+type CreateFoodReactionRequest struct {
+	FoodName        string   `json:"foodName" binding:"required,max=64"`                     // 引起反应的食物名称
+	FeedingRecordID *string  `json:"feedingRecordId"`                                        // 关联的喂养记录ID(可选)
+	Severity        string   `json:"severity" binding:"required,oneof=mild moderate severe"` // 严重程度
+	Symptoms        []string `json:"symptoms"`                                               // 症状
+	Note            string   `json:"note"`                                                   // 备注
+	OccurredAt      int64    `json:"occurredAt"`                                             // 发生时间(毫秒时间戳)，为空时取当前时间
+}
+
+// NewFoodGuidanceResponse 新食物引入等待期建议
+type NewFoodGuidanceResponse struct {
+	CanIntroduceNew    bool                 `json:"canIntroduceNew"`       // 现在是否适合尝试新食物
+	NextNewFoodAt      int64                `json:"nextNewFoodAt"`         // 最早可尝试新食物的时间(毫秒时间戳)
+	WaitDays           int                  `json:"waitDays"`              // 本次适用的等待天数
+	Reason             string               `json:"reason"`                // 说明
+	LastNewFood        *FoodIntroductionDTO `json:"lastNewFood,omitempty"` // 最近一次引入的新食物
+	SuggestedAllergens []string             `json:"suggestedAllergens"`    // 建议下一步引入的过敏原
+	MaintenanceDue     []string             `json:"maintenanceDue"`        // 已引入但近期未喂、需要继续维持的过敏原
+}
+
+// AllergenStatusItem 单个过敏原的引入状态
+type AllergenStatusItem struct {
+	Allergen      string   `json:"allergen"`      // 过敏原编码
+	Label         string   `json:"label"`         // 过敏原名称
+	Status        string   `json:"status"`        // not_introduced, trying, in_diet, lapsed, reaction
+	Foods         []string `json:"foods"`         // 含该过敏原的已尝试食物
+	FirstTriedAt  int64    `json:"firstTriedAt"`  // 首次尝试时间(毫秒时间戳)
+	LastOfferedAt int64    `json:"lastOfferedAt"` // 最近喂食时间(毫秒时间戳)
+	TimesOffered  int      `json:"timesOffered"`  // 累计喂食次数
+	ReactionCount int      `json:"reactionCount"` // 反应次数
+	MaxSeverity   string   `json:"maxSeverity,omitempty"`
+}
+
+// AllergenReportResponse 过敏原引入报告
+type AllergenReportResponse struct {
+	IntroducedCount int                   `json:"introducedCount"` // 已引入的过敏原数量
+	KeptInDietCount int                   `json:"keptInDietCount"` // 仍保持在日常饮食中的数量
+	TotalCount      int                   `json:"totalCount"`      // 重点过敏原总数
+	Items           []*AllergenStatusItem `json:"items"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// HealthAlertDTO 健康提醒DTO
+type HealthAlertDTO struct {
+	Source      string `json:"source"`             // 来源: diaper, temperature, milestone
+	RuleCode    string `json:"ruleCode"`           // 触发规则
+	Level       string `json:"level"`              // 级别: critical, warning, info
+	Title       string `json:"title"`              // 标题
+	Message     string `json:"message"`            // 提醒内容(含就医建议)
+	RecordID    string `json:"recordId,omitempty"` // 触发的记录ID
+	TriggeredAt int64  `json:"triggeredAt"`        // 触发时间(毫秒时间戳)
+}
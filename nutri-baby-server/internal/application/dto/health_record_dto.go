@@ -0,0 +1,50 @@
+package dto
+
+// ============ 体温与症状记录 DTO ============
+
+// CreateTemperatureRecordRequest 创建体温记录请求
+type CreateTemperatureRecordRequest struct {
+	BabyID      string  `json:"babyId" binding:"required"`
+	EpisodeID   string  `json:"episodeId"`                                                       // 所属患病经过ID，为空时自动归入进行中的患病经过
+	Time        int64   `json:"time"`                                                            // 测量时间(毫秒时间戳)，为空时取当前时间
+	Temperature float64 `json:"temperature" binding:"required,gte=34,lte=43"`                    // 体温(℃)
+	Site        string  `json:"site" binding:"required,oneof=axillary oral rectal ear forehead"` // 测量部位
+	Note        *string `json:"note"`                                                            // 备注
+}
+
+// TemperatureRecordDTO 体温记录DTO
+type TemperatureRecordDTO struct {
+	RecordID    string  `json:"recordId"`
+	BabyID      string  `json:"babyId"`
+	EpisodeID   string  `json:"episodeId"`
+	Time        int64   `json:"time"`
+	Temperature float64 `json:"temperature"`
+	Site        string  `json:"site"`
+	Fever       bool    `json:"fever"` // 是否达到该部位的发热标准
+	Note        string  `json:"note"`
+	CreateBy    string  `json:"createBy"`
+	CreateTime  int64   `json:"createTime"`
+}
+
+// CreateSymptomRecordRequest 创建症状记录请求
+type CreateSymptomRecordRequest struct {
+	BabyID    string  `json:"babyId" binding:"required"`
+	EpisodeID string  `json:"episodeId"`                                               // 所属患病经过ID，为空时自动归入进行中的患病经过
+	Time      int64   `json:"time"`                                                    // 出现时间(毫秒时间戳)，为空时取当前时间
+	Symptom   string  `json:"symptom" binding:"required,max=32"`                       // 症状: cough, runny_nose, congestion, vomiting, diarrhea, rash, poor_feed, lethargy, wheezing 或自定义
+	Severity  string  `json:"severity" binding:"omitempty,oneof=mild moderate severe"` // 严重程度，为空时记为 mild
+	Note      *string `json:"note"`                                                    // 备注
+}
+
+// SymptomRecordDTO 症状记录DTO
+type SymptomRecordDTO struct {
+	RecordID   string `json:"recordId"`
+	BabyID     string `json:"babyId"`
+	EpisodeID  string `json:"episodeId"`
+	Time       int64  `json:"time"`
+	Symptom    string `json:"symptom"`
+	Severity   string `json:"severity"`
+	Note       string `json:"note"`
+	CreateBy   string `json:"createBy"`
+	CreateTime int64  `json:"createTime"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+// ============ 患病经过 DTO ============
+
+// CreateIllnessEpisodeRequest 创建患病经过请求
+type CreateIllnessEpisodeRequest struct {
+	BabyID    string  `json:"babyId" binding:"required"`
+	Name      string  `json:"name" binding:"required,max=64"` // 名称, 如"感冒"、"幼儿急疹"
+	StartTime int64   `json:"startTime"`                      // 开始时间(毫秒时间戳)，为空时取当前时间
+	EndTime   *int64  `json:"endTime"`                        // 痊愈时间(毫秒时间戳)，为空表示尚未痊愈
+	SawDoctor bool    `json:"sawDoctor"`                      // 是否就医
+	Diagnosis *string `json:"diagnosis"`                      // 医生诊断
+	Note      *string `json:"note"`                           // 备注
+}
+
+// UpdateIllnessEpisodeRequest 更新患病经过请求
+// 所有字段使用指针类型，支持部分更新（只更新非nil字段）
+type UpdateIllnessEpisodeRequest struct {
+	Name      *string `json:"name,omitempty" binding:"omitempty,max=64"`
+	StartTime *int64  `json:"startTime,omitempty"`
+	EndTime   *int64  `json:"endTime,omitempty"` // 痊愈时间，传 0 表示重新标记为尚未痊愈
+	SawDoctor *bool   `json:"sawDoctor,omitempty"`
+	Diagnosis *string `json:"diagnosis,omitempty"`
+	Note      *string `json:"note,omitempty"`
+}
+
+// IllnessEpisodeDTO 患病经过DTO
+type IllnessEpisodeDTO struct {
+	EpisodeID  string `json:"episodeId"`
+	BabyID     string `json:"babyId"`
+	Name       string `json:"name"`
+	StartTime  int64  `json:"startTime"`
+	EndTime    *int64 `json:"endTime"` // 为空表示尚未痊愈
+	Ongoing    bool   `json:"ongoing"`
+	SawDoctor  bool   `json:"sawDoctor"`
+	Diagnosis  string `json:"diagnosis"`
+	Note       string `json:"note"`
+	CreateBy   string `json:"createBy"`
+	CreateTime int64  `json:"createTime"`
+}
+
+// IllnessEpisodeDetailDTO 患病经过详情(含归入该经过的体温和症状记录)
+type IllnessEpisodeDetailDTO struct {
+	*IllnessEpisodeDTO
+	MaxTemperature *float64                `json:"maxTemperature"` // 期间最高体温(℃)，没有体温记录时为空
+	Temperatures   []*TemperatureRecordDTO `json:"temperatures"`   // 体温记录(按时间正序)
+	Symptoms       []*SymptomRecordDTO     `json:"symptoms"`       // 症状记录(按时间正序)
+}
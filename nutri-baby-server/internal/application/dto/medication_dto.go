@@ -0,0 +1,132 @@
+package dto
+
+// ============ 用药与补充剂 DTO ============
+
+// CreateMedicationRequest 创建药品请求
+type CreateMedicationRequest struct {
+	BabyID           string  `json:"babyId" binding:"required"`
+	Name             string  `json:"name" binding:"required,max=64"`                                // 名称
+	Kind             string  `json:"kind" binding:"required,oneof=medicine supplement"`             // 类型
+	DoseUnit         string  `json:"doseUnit" binding:"required,oneof=ml mg drop tablet sachet iu"` // 剂量单位
+	DefaultDose      float64 `json:"defaultDose" binding:"gte=0"`                                   // 常用剂量
+	Strength         float64 `json:"strength" binding:"gte=0"`                                      // 规格(每剂量单位含多少毫克)
+	MaxDoseMgPerKg   float64 `json:"maxDoseMgPerKg" binding:"gte=0"`                                // 单次最大剂量(mg/kg)
+	MaxDailyMgPerKg  float64 `json:"maxDailyMgPerKg" binding:"gte=0"`                               // 24小时最大剂量(mg/kg)
+	MaxDailyMg       float64 `json:"maxDailyMg" binding:"gte=0"`                                    // 24小时最大剂量(mg)
+	MaxDailyDoses    int     `json:"maxDailyDoses" binding:"gte=0"`                                 // 24小时最多给药次数
+	MinIntervalHours float64 `json:"minIntervalHours" binding:"gte=0,lte=72"`                       // 两次给药最短间隔(小时)
+	Note             *string `json:"note"`                                                          // 备注(如医嘱)
+}
+
+// UpdateMedicationRequest 更新药品请求
+// 所有字段使用指针类型，支持部分更新（只更新非nil字段）
+type UpdateMedicationRequest struct {
+	Name             *string  `json:"name,omitempty" binding:"omitempty,max=64"`
+	DefaultDose      *float64 `json:"defaultDose,omitempty" binding:"omitempty,gte=0"`
+	Strength         *float64 `json:"strength,omitempty" binding:"omitempty,gte=0"`
+	MaxDoseMgPerKg   *float64 `json:"maxDoseMgPerKg,omitempty" binding:"omitempty,gte=0"`
+	MaxDailyMgPerKg  *float64 `json:"maxDailyMgPerKg,omitempty" binding:"omitempty,gte=0"`
+	MaxDailyMg       *float64 `json:"maxDailyMg,omitempty" binding:"omitempty,gte=0"`
+	MaxDailyDoses    *int     `json:"maxDailyDoses,omitempty" binding:"omitempty,gte=0"`
+	MinIntervalHours *float64 `json:"minIntervalHours,omitempty" binding:"omitempty,gte=0,lte=72"`
+	Active           *bool    `json:"active,omitempty"` // 停药时设为 false，同时停用该药品的提醒
+	Note             *string  `json:"note,omitempty"`
+}
+
+// MedicationListQuery 药品列表查询
+type MedicationListQuery struct {
+	BabyID          string `form:"babyId" binding:"required"`
+	IncludeInactive bool   `form:"includeInactive"` // 是否包含已停用的药品
+}
+
+// MedicationDTO 药品DTO
+type MedicationDTO struct {
+	MedicationID     string                   `json:"medicationId"`
+	BabyID           string                   `json:"babyId"`
+	Name             string                   `json:"name"`
+	Kind             string                   `json:"kind"`
+	DoseUnit         string                   `json:"doseUnit"`
+	DefaultDose      float64                  `json:"defaultDose"`
+	Strength         float64                  `json:"strength"`
+	MaxDoseMgPerKg   float64                  `json:"maxDoseMgPerKg"`
+	MaxDailyMgPerKg  float64                  `json:"maxDailyMgPerKg"`
+	MaxDailyMg       float64                  `json:"maxDailyMg"`
+	MaxDailyDoses    int                      `json:"maxDailyDoses"`
+	MinIntervalHours float64                  `json:"minIntervalHours"`
+	Active           bool                     `json:"active"`
+	Note             string                   `json:"note"`
+	LastDoseTime     int64                    `json:"lastDoseTime,omitempty"` // 最近一次给药时间(毫秒时间戳)
+	Schedules        []*MedicationScheduleDTO `json:"schedules"`              // 用药计划
+	CreateBy         string                   `json:"createBy"`
+	CreateTime       int64                    `json:"createTime"`
+}
+
+// CreateMedicationScheduleRequest 创建用药计划请求
+type CreateMedicationScheduleRequest struct {
+	Mode          string   `json:"mode" binding:"required,oneof=times interval"`      // 计划类型
+	TimesOfDay    []string `json:"timesOfDay" binding:"omitempty,max=8,dive,len=5"`   // 每天给药时间(HH:mm)，mode=times 时必填
+	IntervalHours float64  `json:"intervalHours" binding:"omitempty,gte=1,lte=72"`    // 给药间隔(小时)，mode=interval 时必填
+	StartDate     string   `json:"startDate" binding:"omitempty,datetime=2006-01-02"` // 开始日期，为空时取今天
+	EndDate       *string  `json:"endDate" binding:"omitempty,datetime=2006-01-02"`   // 结束日期，为空表示长期
+}
+
+// UpdateMedicationScheduleRequest 更新用药计划请求
+type UpdateMedicationScheduleRequest struct {
+	TimesOfDay    []string `json:"timesOfDay,omitempty" binding:"omitempty,max=8,dive,len=5"`
+	IntervalHours *float64 `json:"intervalHours,omitempty" binding:"omitempty,gte=1,lte=72"`
+	EndDate       *string  `json:"endDate,omitempty"` // 传空字符串表示改为长期
+	Enabled       *bool    `json:"enabled,omitempty"`
+}
+
+// MedicationScheduleDTO 用药计划DTO
+type MedicationScheduleDTO struct {
+	ScheduleID     string   `json:"scheduleId"`
+	MedicationID   string   `json:"medicationId"`
+	Mode           string   `json:"mode"`
+	TimesOfDay     []string `json:"timesOfDay"`
+	IntervalHours  float64  `json:"intervalHours"`
+	StartDate      string   `json:"startDate"`
+	EndDate        string   `json:"endDate"`
+	Enabled        bool     `json:"enabled"`
+	LastRemindedAt int64    `json:"lastRemindedAt"`
+}
+
+// CreateMedicationDoseRequest 记录给药请求(也用于给药前的剂量校验)
+type CreateMedicationDoseRequest struct {
+	MedicationID string  `json:"medicationId" binding:"required"`
+	Time         int64   `json:"time"`                            // 给药时间(毫秒时间戳)，为空时取当前时间
+	Amount       float64 `json:"amount" binding:"omitempty,gt=0"` // 剂量(按药品剂量单位)，为空时取常用剂量
+	Note         *string `json:"note"`
+}
+
+// MedicationDoseListQuery 给药记录列表查询
+type MedicationDoseListQuery struct {
+	MedicationID string `form:"medicationId"` // 为空时返回全部药品
+	RecordListQuery
+}
+
+// MedicationDoseCheckDTO 剂量校验结果
+type MedicationDoseCheckDTO struct {
+	Amount       float64  `json:"amount"`                 // 剂量(按药品剂量单位)
+	AmountMg     float64  `json:"amountMg,omitempty"`     // 换算后的毫克数(填写规格时)
+	WeightKg     float64  `json:"weightKg,omitempty"`     // 校验使用的最新体重(kg)
+	DailyTotalMg float64  `json:"dailyTotalMg,omitempty"` // 含本次在内24小时累计毫克数
+	DailyDoses   int      `json:"dailyDoses"`             // 含本次在内24小时给药次数
+	NextDoseTime int64    `json:"nextDoseTime,omitempty"` // 满足最短间隔的最早给药时间(毫秒时间戳)
+	Warnings     []string `json:"warnings"`               // 剂量警告，为空表示未发现问题
+}
+
+// MedicationDoseDTO 给药记录DTO
+type MedicationDoseDTO struct {
+	DoseID         string   `json:"doseId"`
+	BabyID         string   `json:"babyId"`
+	MedicationID   string   `json:"medicationId"`
+	MedicationName string   `json:"medicationName"`
+	DoseUnit       string   `json:"doseUnit"`
+	Time           int64    `json:"time"`
+	Amount         float64  `json:"amount"`
+	Warnings       []string `json:"warnings"` // 记录时的剂量警告
+	Note           string   `json:"note"`
+	CreateBy       string   `json:"createBy"`
+	CreateTime     int64    `json:"createTime"`
+}
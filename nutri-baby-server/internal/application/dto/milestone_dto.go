@@ -0,0 +1,58 @@
+package dto
+
+// ============ 发育里程碑 DTO ============
+
+// MilestoneCatalogItemDTO 里程碑目录条目
+type MilestoneCatalogItemDTO struct {
+	Code        string `json:"code"`        // 里程碑编码
+	Name        string `json:"name"`        // 名称
+	Domain      string `json:"domain"`      // 领域: motor, language, social, cognitive
+	StartMonths int    `json:"startMonths"` // 常见最早月龄
+	EndMonths   int    `json:"endMonths"`   // 常见最晚月龄
+}
+
+// CreateMilestoneRecordRequest 记录里程碑达成请求
+type CreateMilestoneRecordRequest struct {
+	MilestoneCode string  `json:"milestoneCode" binding:"required"`         // 里程碑编码
+	AchievedAt    int64   `json:"achievedAt"`                               // 达成时间(毫秒时间戳)，为空时取当前时间
+	Note          *string `json:"note"`                                     // 备注
+	PhotoURL      *string `json:"photoUrl" binding:"omitempty,url,max=512"` // 照片(通过上传接口获得的地址)
+}
+
+// UpdateMilestoneRecordRequest 更新里程碑记录请求
+// 所有字段使用指针类型，支持部分更新（只更新非nil字段）
+type UpdateMilestoneRecordRequest struct {
+	AchievedAt *int64  `json:"achievedAt,omitempty"`
+	Note       *string `json:"note,omitempty"`
+	PhotoURL   *string `json:"photoUrl,omitempty" binding:"omitempty,max=512"` // 传空字符串表示移除照片
+}
+
+// MilestoneRecordDTO 里程碑达成记录DTO
+type MilestoneRecordDTO struct {
+	RecordID      string `json:"recordId"`
+	BabyID        string `json:"babyId"`
+	MilestoneCode string `json:"milestoneCode"`
+	MilestoneName string `json:"milestoneName"`
+	Domain        string `json:"domain"`
+	AchievedAt    int64  `json:"achievedAt"` // 达成时间(毫秒时间戳)
+	AgeMonths     int    `json:"ageMonths"`  // 达成时的满月月龄
+	Note          string `json:"note"`       // 备注
+	PhotoURL      string `json:"photoUrl"`   // 照片
+	CreateBy      string `json:"createBy"`   // 创建者用户ID
+	CreateTime    int64  `json:"createTime"` // 创建时间(毫秒时间戳)
+}
+
+// MilestoneChecklistItemDTO 里程碑清单条目
+type MilestoneChecklistItemDTO struct {
+	MilestoneCatalogItemDTO
+	Status string              `json:"status"`           // achieved(已达成), upcoming(未到常见月龄), in_window(常见月龄内), delayed(超过常见月龄仍未达成)
+	Record *MilestoneRecordDTO `json:"record,omitempty"` // 达成记录
+}
+
+// MilestoneChecklistResponse 宝宝的里程碑清单
+type MilestoneChecklistResponse struct {
+	AgeMonths int                          `json:"ageMonths"` // 当前满月月龄
+	Items     []*MilestoneChecklistItemDTO `json:"items"`     // 按领域、常见月龄排序
+	Achieved  int                          `json:"achieved"`  // 已达成数量
+	Alerts    []*HealthAlertDTO            `json:"alerts"`    // 超过常见月龄仍未达成的软提醒(level=info)
+}
@@ -0,0 +1,79 @@
+package dto
+
+// ============ 吸奶记录与母乳库存 DTO ============
+
+// MilkStashBagInput 入库的单袋母乳
+type MilkStashBagInput struct {
+	Volume int64   `json:"volume" binding:"required,gt=0"` // 奶量(ml)
+	Note   *string `json:"note"`                           // 备注(如袋子编号)
+}
+
+// CreatePumpingRecordRequest 创建吸奶记录请求
+type CreatePumpingRecordRequest struct {
+	BabyID          string              `json:"babyId" binding:"required"`
+	PumpingTime     int64               `json:"pumpingTime"`                                                   // 吸奶时间(毫秒时间戳)，为空时取当前时间
+	Duration        int                 `json:"duration"`                                                      // 时长(秒)
+	LeftVolume      int64               `json:"leftVolume" binding:"gte=0"`                                    // 左侧奶量(ml)
+	RightVolume     int64               `json:"rightVolume" binding:"gte=0"`                                   // 右侧奶量(ml)
+	Note            *string             `json:"note"`                                                          // 备注
+	StorageLocation string              `json:"storageLocation" binding:"omitempty,oneof=room fridge freezer"` // 入库位置，为空时不入库(如直接喂掉)
+	Bags            []MilkStashBagInput `json:"bags" binding:"omitempty,dive"`                                 // 分装明细，为空时整次吸奶作为一袋入库
+}
+
+// PumpingRecordDTO 吸奶记录DTO
+type PumpingRecordDTO struct {
+	RecordID    string              `json:"recordId"`
+	BabyID      string              `json:"babyId"`
+	PumpingTime int64               `json:"pumpingTime"`
+	Duration    int                 `json:"duration"`
+	LeftVolume  int64               `json:"leftVolume"`
+	RightVolume int64               `json:"rightVolume"`
+	TotalVolume int64               `json:"totalVolume"`
+	Note        string              `json:"note"`
+	StashItems  []*MilkStashItemDTO `json:"stashItems,omitempty"` // 本次入库的母乳
+	CreateBy    string              `json:"createBy"`
+	CreateTime  int64               `json:"createTime"`
+}
+
+// CreateMilkStashItemRequest 手动入库请求(如历史库存、非本应用记录的吸奶)
+type CreateMilkStashItemRequest struct {
+	Volume          int64   `json:"volume" binding:"required,gt=0"`                               // 奶量(ml)
+	PumpedAt        int64   `json:"pumpedAt" binding:"required"`                                  // 吸奶时间(毫秒时间戳)
+	StorageLocation string  `json:"storageLocation" binding:"required,oneof=room fridge freezer"` // 储存位置
+	Note            *string `json:"note"`                                                         // 备注
+}
+
+// MoveMilkStashItemRequest 转移储存位置请求(冷藏转冷冻、冷冻解冻)
+type MoveMilkStashItemRequest struct {
+	StorageLocation string `json:"storageLocation" binding:"required,oneof=fridge freezer"`
+}
+
+// MilkStashItemDTO 母乳库存DTO
+type MilkStashItemDTO struct {
+	ItemID          string `json:"itemId"`
+	PumpingRecordID string `json:"pumpingRecordId,omitempty"`
+	Volume          int64  `json:"volume"`          // 入库奶量(ml)
+	RemainingVolume int64  `json:"remainingVolume"` // 剩余奶量(ml)
+	PumpedAt        int64  `json:"pumpedAt"`        // 吸奶时间(毫秒时间戳)
+	StorageLocation string `json:"storageLocation"` // room, fridge, freezer
+	ThawedAt        *int64 `json:"thawedAt,omitempty"`
+	ExpiresAt       int64  `json:"expiresAt"`    // 过期时间(毫秒时间戳)
+	Status          string `json:"status"`       // available, consumed, expired, discarded
+	ExpiringSoon    bool   `json:"expiringSoon"` // 24小时内过期
+	Note            string `json:"note"`
+}
+
+// MilkStashSummary 母乳库存汇总
+type MilkStashSummary struct {
+	TotalVolume       int64 `json:"totalVolume"`       // 可用总奶量(ml)
+	FridgeVolume      int64 `json:"fridgeVolume"`      // 冷藏(含室温、已解冻)奶量(ml)
+	FreezerVolume     int64 `json:"freezerVolume"`     // 冷冻奶量(ml)
+	ItemCount         int   `json:"itemCount"`         // 可用袋数
+	ExpiringSoonCount int   `json:"expiringSoonCount"` // 24小时内过期袋数
+}
+
+// MilkStashResponse 母乳库存响应
+type MilkStashResponse struct {
+	Summary MilkStashSummary    `json:"summary"`
+	Items   []*MilkStashItemDTO `json:"items"`
+}
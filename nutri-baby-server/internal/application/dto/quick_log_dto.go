@@ -0,0 +1,29 @@
+package dto
+
+// QuickLogParseRequest 自然语言快速记录解析请求
+type QuickLogParseRequest struct {
+	Text     string `json:"text" binding:"required,max=500"` // 如 "3:10 喂了120ml奶粉，左边亲喂12分钟"
+	Timezone string `json:"timezone"`                        // 可选:宝宝未设置时区时使用的客户端时区(IANA名称)
+}
+
+// QuickLogProposal 待确认的记录草稿，确认后由客户端提交到对应的创建接口
+type QuickLogProposal struct {
+	RecordType string                      `json:"recordType"`         // feeding, sleep, diaper, growth
+	Endpoint   string                      `json:"endpoint"`           // 确认后提交的接口，如 /v1/feeding-records
+	Summary    string                      `json:"summary"`            // 预览文案
+	Feeding    *CreateFeedingRecordRequest `json:"feeding,omitempty"`  // recordType=feeding 时的请求体
+	Sleep      *CreateSleepRecordRequest   `json:"sleep,omitempty"`    // recordType=sleep 时的请求体
+	Diaper     *CreateDiaperRecordRequest  `json:"diaper,omitempty"`   // recordType=diaper 时的请求体
+	Growth     *CreateGrowthRecordRequest  `json:"growth,omitempty"`   // recordType=growth 时的请求体
+	Warnings   []string                    `json:"warnings,omitempty"` // 需要用户核对的地方(如使用了默认时间)
+}
+
+// QuickLogParseResponse 快速记录解析结果(仅预览，不会写入记录)
+type QuickLogParseResponse struct {
+	Parser    string              `json:"parser"`             // ai: 模型解析, rule: 规则解析
+	Timezone  string              `json:"timezone"`           // 解析相对时间使用的时区
+	Preview   string              `json:"preview"`            // 整体确认文案
+	Proposals []*QuickLogProposal `json:"proposals"`          // 记录草稿
+	Unparsed  []string            `json:"unparsed,omitempty"` // 未能识别的片段
+	Warnings  []string            `json:"warnings,omitempty"` // 整体提示(如智能解析不可用已改用规则解析)
+}
@@ -31,9 +31,10 @@ type BottleFeedingDetail struct {
 
 // FoodFeedingDetail 辅食详情
 type FoodFeedingDetail struct {
-	Type     string  `json:"type"`              // "food" 固定值
-	FoodName string  `json:"foodName"`          // 辅食名称
-	Note     *string `json:"note,omitempty"`    // 备注(接受程度、过敏反应等)
+	Type      string   `json:"type"`                // "food" 固定值
+	FoodName  string   `json:"foodName"`            // 辅食名称
+	Allergens []string `json:"allergens,omitempty"` // 过敏原标签(目录外食物可手动标注)
+	Note      *string  `json:"note,omitempty"`      // 备注(接受程度、过敏反应等)
 }
 
 // FeedingDetail 喂养详情(向后兼容的全能结构体，用于数据库JSONB存储)
@@ -56,7 +57,8 @@ type FeedingDetail struct {
 	Remaining  *float64 `json:"remaining,omitempty"`  // 剩余量
 
 	// 辅食相关
-	FoodName string `json:"foodName,omitempty"` // 辅食名称
+	FoodName  string   `json:"foodName,omitempty"`  // 辅食名称
+	Allergens []string `json:"allergens,omitempty"` // 过敏原标签
 
 	// 通用
 	Note *string `json:"note,omitempty"` // 备注
@@ -69,6 +71,7 @@ type FeedingDetail struct {
 }
 
 // ToBreastFeeding 转换为母乳喂养详情
+// 旧数据(breastSide/leftTime/rightTime)与多段式 Sessions 会被归一化为左右侧时长
 func (d *FeedingDetail) ToBreastFeeding() *BreastFeedingDetail {
 	if d.Type != "breast" {
 		return nil
@@ -80,17 +83,71 @@ func (d *FeedingDetail) ToBreastFeeding() *BreastFeedingDetail {
 		side = d.BreastSide
 	}
 
+	leftDuration := d.LeftDuration
+	rightDuration := d.RightDuration
+
+	// 旧字段 leftTime/rightTime
+	if leftDuration == nil && rightDuration == nil && (d.LeftTime > 0 || d.RightTime > 0) {
+		left, right := d.LeftTime, d.RightTime
+		leftDuration, rightDuration = &left, &right
+	}
+
+	// 多段式记录按侧汇总
+	if leftDuration == nil && rightDuration == nil && len(d.Sessions) > 0 {
+		left, right := 0, 0
+		for _, session := range d.Sessions {
+			switch session.Side {
+			case "left":
+				left += session.Duration
+			case "right":
+				right += session.Duration
+			case "both":
+				left += session.Duration / 2
+				right += session.Duration - session.Duration/2
+			}
+		}
+		leftDuration, rightDuration = &left, &right
+	}
+
 	duration := d.Duration
-	if duration == 0 && (d.LeftTime > 0 || d.RightTime > 0) {
-		duration = d.LeftTime + d.RightTime
+	if duration == 0 && (leftDuration != nil || rightDuration != nil) {
+		if leftDuration != nil {
+			duration += *leftDuration
+		}
+		if rightDuration != nil {
+			duration += *rightDuration
+		}
+	}
+
+	// 只记录了单侧和总时长的旧数据
+	if leftDuration == nil && rightDuration == nil && duration > 0 {
+		zero, total := 0, duration
+		switch side {
+		case "left":
+			leftDuration, rightDuration = &total, &zero
+		case "right":
+			leftDuration, rightDuration = &zero, &total
+		}
+	}
+
+	// 根据左右时长推断主要喂养侧
+	if side == "" && leftDuration != nil && rightDuration != nil {
+		switch {
+		case *leftDuration > 0 && *rightDuration > 0:
+			side = "both"
+		case *leftDuration > 0:
+			side = "left"
+		case *rightDuration > 0:
+			side = "right"
+		}
 	}
 
 	return &BreastFeedingDetail{
 		Type:          "breast",
 		Side:          side,
 		Duration:      duration,
-		LeftDuration:  d.LeftDuration,
-		RightDuration: d.RightDuration,
+		LeftDuration:  leftDuration,
+		RightDuration: rightDuration,
 		Sessions:      d.Sessions,
 	}
 }
@@ -115,9 +172,10 @@ func (d *FeedingDetail) ToFoodFeeding() *FoodFeedingDetail {
 		return nil
 	}
 	return &FoodFeedingDetail{
-		Type:     "food",
-		FoodName: d.FoodName,
-		Note:     d.Note,
+		Type:      "food",
+		FoodName:  d.FoodName,
+		Allergens: d.Allergens,
+		Note:      d.Note,
 	}
 }
 
@@ -147,9 +205,10 @@ func FromBottleFeeding(detail *BottleFeedingDetail) *FeedingDetail {
 // FromFoodFeeding 从辅食详情创建
 func FromFoodFeeding(detail *FoodFeedingDetail) *FeedingDetail {
 	return &FeedingDetail{
-		Type:     "food",
-		FoodName: detail.FoodName,
-		Note:     detail.Note,
+		Type:      "food",
+		FoodName:  detail.FoodName,
+		Allergens: detail.Allergens,
+		Note:      detail.Note,
 	}
 }
 
@@ -198,6 +257,8 @@ type SleepRecordDTO struct {
 type CreateDiaperRecordRequest struct {
 	BabyID     string `json:"babyId" binding:"required"`
 	DiaperType string `json:"diaperType" binding:"required,oneof=pee poop both"`
+	PooColor   string `json:"pooColor"`   // 大便颜色: yellow, green, brown, black, red, white
+	PooTexture string `json:"pooTexture"` // 大便性状: watery, loose, paste, soft, formed, hard
 	Note       string `json:"note"`
 	ChangeTime int64  `json:"changeTime"` // 毫秒时间戳
 }
@@ -207,6 +268,8 @@ type DiaperRecordDTO struct {
 	RecordID   string `json:"recordId"`
 	BabyID     string `json:"babyId"`
 	DiaperType string `json:"diaperType"`
+	PooColor   string `json:"pooColor,omitempty"`
+	PooTexture string `json:"pooTexture,omitempty"`
 	Note       string `json:"note"`
 	ChangeTime int64  `json:"changeTime"`
 	CreateBy   string `json:"createBy"`
@@ -260,6 +323,8 @@ type UpdateSleepRecordRequest struct {
 // 所有字段使用指针类型，支持部分更新（只更新非nil字段）
 type UpdateDiaperRecordRequest struct {
 	DiaperType *string `json:"diaperType,omitempty" binding:"omitempty,oneof=pee poop both"`
+	PooColor   *string `json:"pooColor,omitempty"`
+	PooTexture *string `json:"pooTexture,omitempty"`
 	Note       *string `json:"note,omitempty"`
 	ChangeTime *int64  `json:"changeTime,omitempty"`
 }
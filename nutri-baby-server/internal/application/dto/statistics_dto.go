@@ -75,6 +75,24 @@ type WeeklyStatistics struct {
 
 // BabyStatisticsResponse 宝宝统计响应
 type BabyStatisticsResponse struct {
-	Today  TodayStatistics  `json:"today"`  // 今日统计
-	Weekly WeeklyStatistics `json:"weekly"` // 本周统计
+	Today  TodayStatistics   `json:"today"`  // 今日统计
+	Weekly WeeklyStatistics  `json:"weekly"` // 本周统计
+	Alerts []*HealthAlertDTO `json:"alerts"` // 健康提醒(排泄筛查等)
+}
+
+// ============ 周期统计(周报/月报) ============
+
+// PeriodStatistics 任意时间段的汇总统计，用于周期报告的趋势对比
+type PeriodStatistics struct {
+	Days               int      `json:"days"`                   // 统计天数
+	FeedingCount       int      `json:"feedingCount"`           // 喂养次数
+	FeedingPerDay      float64  `json:"feedingPerDay"`          // 日均喂养次数
+	BottleMl           int64    `json:"bottleMl"`               // 奶瓶总毫升数
+	SleepMinutes       int      `json:"sleepMinutes"`           // 总睡眠分钟数
+	SleepMinutesPerDay float64  `json:"sleepMinutesPerDay"`     // 日均睡眠分钟数
+	DiaperCount        int      `json:"diaperCount"`            // 换尿布次数
+	DiaperPerDay       float64  `json:"diaperPerDay"`           // 日均换尿布次数
+	PoopCount          int      `json:"poopCount"`              // 大便次数
+	WeightGain         float64  `json:"weightGain"`             // 期内体重增长 (kg)
+	LatestWeight       *float64 `json:"latestWeight,omitempty"` // 期内最新体重 (kg)
 }
@@ -5,13 +5,13 @@ type TimelineQuery struct {
 	BabyID     string `form:"babyId" binding:"required"`
 	StartTime  int64  `form:"startTime"`
 	EndTime    int64  `form:"endTime"`
-	RecordType string `form:"recordType"` // 可选: "feeding" | "sleep" | "diaper" | "growth" | "" (空表示全部)
+	RecordType string `form:"recordType"` // 可选: "feeding" | "sleep" | "diaper" | "growth" | "temperature" | "symptom" | "milestone" | "" (空表示全部)
 	PaginationRequest
 }
 
 // TimelineItem 时间线记录项
 type TimelineItem struct {
-	RecordType   string `json:"recordType"` // "feeding" | "sleep" | "diaper" | "growth"
+	RecordType   string `json:"recordType"` // "feeding" | "sleep" | "diaper" | "growth" | "temperature" | "symptom" | "milestone"
 	RecordID     string `json:"recordId"`
 	BabyID       string `json:"babyId"`
 	EventTime    int64  `json:"eventTime"` // 统一时间戳
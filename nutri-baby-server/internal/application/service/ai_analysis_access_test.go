@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// accessUserRepo 权限测试用的用户仓储
+type accessUserRepo struct {
+	repository.UserRepository
+}
+
+func (r accessUserRepo) FindByOpenID(ctx context.Context, openID string) (*entity.User, error) {
+	return &entity.User{ID: 7, OpenID: openID}, nil
+}
+
+// accessCollaboratorRepo 权限测试用的协作者仓储，只有 babyID 为 1 的宝宝允许访问
+type accessCollaboratorRepo struct {
+	repository.BabyCollaboratorRepository
+}
+
+func (r accessCollaboratorRepo) IsCollaborator(ctx context.Context, babyID int64, userID int64) (bool, error) {
+	return babyID == 1, nil
+}
+
+// accessAnalysisRepo 权限测试用的分析仓储，记录是否调用了取消
+type accessAnalysisRepo struct {
+	repository.AIAnalysisRepository
+	analyses  map[int64]*entity.AIAnalysis
+	cancelled bool
+}
+
+func (r *accessAnalysisRepo) GetByID(ctx context.Context, id int64) (*entity.AIAnalysis, error) {
+	analysis, ok := r.analyses[id]
+	if !ok {
+		return nil, errors.ErrRecordNotFound
+	}
+	return analysis, nil
+}
+
+func (r *accessAnalysisRepo) Cancel(ctx context.Context, id int64) (bool, error) {
+	r.cancelled = true
+	return true, nil
+}
+
+func TestAIAnalysisService_DeniesNonCollaborator(t *testing.T) {
+	repo := &accessAnalysisRepo{analyses: map[int64]*entity.AIAnalysis{
+		100: {ID: 100, BabyID: 1, Status: entity.AIAnalysisStatusCompleted},
+		200: {ID: 200, BabyID: 2, Status: entity.AIAnalysisStatusAnalyzing},
+		201: {ID: 201, BabyID: 2, Status: entity.AIAnalysisStatusCompleted},
+	}}
+	s := &aiAnalysisServiceImpl{
+		BaseRecordService: NewBaseRecordService(nil, accessCollaboratorRepo{}, accessUserRepo{}, zap.NewNop()),
+		aiAnalysisRepo:    repo,
+		progressHub:       NewAnalysisProgressHub(),
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"GetAnalysisResult", func() error {
+			_, err := s.GetAnalysisResult(ctx, "stranger", "200")
+			return err
+		}},
+		{"CancelAnalysis", func() error {
+			_, err := s.CancelAnalysis(ctx, "stranger", "200")
+			return err
+		}},
+		{"StreamAnalysis", func() error {
+			_, err := s.StreamAnalysis(ctx, "stranger", "200")
+			return err
+		}},
+		{"CompareAnalyses", func() error {
+			_, err := s.CompareAnalyses(ctx, "stranger", "200", "201")
+			return err
+		}},
+		{"CompareAnalyses 其中一条无权限", func() error {
+			_, err := s.CompareAnalyses(ctx, "stranger", "100", "201")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			require.Error(t, err)
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok)
+			assert.Equal(t, errors.PermissionDenied, appErr.Code)
+		})
+	}
+	assert.False(t, repo.cancelled, "无权限时不应取消任务")
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// analysisStatsMaxDays 统计与趋势的最大回溯天数
+	analysisStatsMaxDays = 365
+	// analysisHistoryMaxPageSize 历史列表单页上限
+	analysisHistoryMaxPageSize = 50
+)
+
+// AnalysisScoreSummary 某一分析类型在统计区间内的评分概况
+type AnalysisScoreSummary struct {
+	Type     entity.AIAnalysisType `json:"type"`
+	Count    int                   `json:"count"`              // 有评分的分析次数
+	AvgScore float64               `json:"avg_score"`          // 平均分
+	Latest   float64               `json:"latest"`             // 最近一次评分
+	Previous *float64              `json:"previous,omitempty"` // 上一次评分
+	Change   *float64              `json:"change,omitempty"`   // 最近一次相对上一次的变化
+}
+
+// AnalysisHistoryQuery 分析历史查询条件
+type AnalysisHistoryQuery struct {
+	Type      entity.AIAnalysisType   `form:"type"`
+	Status    entity.AIAnalysisStatus `form:"status"`
+	StartDate string                  `form:"start_date"` // YYYY-MM-DD，按宝宝时区的创建日期过滤(含)
+	EndDate   string                  `form:"end_date"`   // YYYY-MM-DD(含)
+	Page      int                     `form:"page"`
+	PageSize  int                     `form:"page_size"`
+}
+
+// AnalysisHistoryItem 分析历史条目(不含完整结果，详情通过 GetAnalysisResult 获取)
+type AnalysisHistoryItem struct {
+	AnalysisID    int64                   `json:"analysis_id"`
+	AnalysisType  entity.AIAnalysisType   `json:"analysis_type"`
+	Status        entity.AIAnalysisStatus `json:"status"`
+	StartDate     time.Time               `json:"start_date"`
+	EndDate       time.Time               `json:"end_date"`
+	Score         *float64                `json:"score,omitempty"`
+	InsightCount  int                     `json:"insight_count"`
+	AlertCount    int                     `json:"alert_count"`
+	FailureReason string                  `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// AnalysisHistoryResponse 分析历史分页结果
+type AnalysisHistoryResponse struct {
+	Items    []AnalysisHistoryItem `json:"items"`
+	Total    int64                 `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"page_size"`
+}
+
+// AnalysisComparisonResponse 两次分析的差异
+// 以较早的一次为基准，New 为较新一次中新出现的条目，Resolved 为较新一次中已不再出现的条目
+type AnalysisComparisonResponse struct {
+	AnalysisType       entity.AIAnalysisType `json:"analysis_type"`
+	BaseAnalysisID     int64                 `json:"base_analysis_id"`
+	TargetAnalysisID   int64                 `json:"target_analysis_id"`
+	BaseCreatedAt      time.Time             `json:"base_created_at"`
+	TargetCreatedAt    time.Time             `json:"target_created_at"`
+	BaseScore          float64               `json:"base_score"`
+	TargetScore        float64               `json:"target_score"`
+	ScoreChange        float64               `json:"score_change"`
+	NewInsights        []entity.AIInsight    `json:"new_insights"`
+	ResolvedInsights   []entity.AIInsight    `json:"resolved_insights"`
+	PersistingInsights []entity.AIInsight    `json:"persisting_insights"`
+	NewAlerts          []entity.AIAlert      `json:"new_alerts"`
+	ResolvedAlerts     []entity.AIAlert      `json:"resolved_alerts"`
+	PersistingAlerts   []entity.AIAlert      `json:"persisting_alerts"`
+}
+
+// GetAnalysisStats 获取最近 days 天的分析统计与评分趋势
+func (s *aiAnalysisServiceImpl) GetAnalysisStats(ctx context.Context, openID, babyID string, days int) (*AnalysisStatsResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	if days <= 0 {
+		days = 30
+	}
+	if days > analysisStatsMaxDays {
+		days = analysisStatsMaxDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	stats, err := s.aiAnalysisRepo.GetAnalysisStats(ctx, babyIDInt64, since)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := entity.AIAnalysisStatusCompleted
+	analyses, _, err := s.aiAnalysisRepo.FindByParams(ctx, repository.AIAnalysisParams{
+		BabyID:    babyIDInt64,
+		Status:    &completed,
+		StartDate: &since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AnalysisStatsResponse{
+		TotalAnalyses: int(stats.TotalAnalyses),
+		Days:          days,
+		ByType:        make(map[entity.AIAnalysisType]int, len(stats.AnalysisTypeCounts)),
+		ByStatus:      make(map[entity.AIAnalysisStatus]int, len(stats.StatusCounts)),
+		Trends:        []AnalysisTrend{},
+	}
+	for analysisType, count := range stats.AnalysisTypeCounts {
+		response.ByType[entity.AIAnalysisType(analysisType)] = int(count)
+	}
+	for status, count := range stats.StatusCounts {
+		response.ByStatus[entity.AIAnalysisStatus(status)] = int(count)
+	}
+
+	// 按时间升序输出趋势点，平均分与趋势使用同一批评分
+	var total float64
+	for i := len(analyses) - 1; i >= 0; i-- {
+		score, ok := s.analysisScore(analyses[i])
+		if !ok {
+			continue
+		}
+		total += score
+		response.Trends = append(response.Trends, AnalysisTrend{
+			AnalysisID: analyses[i].ID,
+			Date:       analyses[i].CreatedAt,
+			Score:      score,
+			Type:       analyses[i].AnalysisType,
+		})
+	}
+	if len(response.Trends) > 0 {
+		response.AvgScore = roundToTwoDecimals(total / float64(len(response.Trends)))
+	}
+	response.ScoreSummaries = summarizeAnalysisTrends(response.Trends)
+
+	return response, nil
+}
+
+// ListAnalyses 分页查询宝宝的分析历史
+func (s *aiAnalysisServiceImpl) ListAnalyses(ctx context.Context, openID, babyID string, query *AnalysisHistoryQuery) (*AnalysisHistoryResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.AIAnalysisParams{BabyID: babyIDInt64}
+	if query.Type != "" {
+		analysisType := query.Type
+		params.AnalysisType = &analysisType
+	}
+	if query.Status != "" {
+		status := query.Status
+		params.Status = &status
+	}
+
+	// 日期按宝宝所在时区解析，结束日期包含当天
+	loc := baby.Location()
+	if query.StartDate != "" {
+		start, err := time.ParseInLocation("2006-01-02", query.StartDate, loc)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "开始日期格式错误，应为YYYY-MM-DD")
+		}
+		params.StartDate = &start
+	}
+	if query.EndDate != "" {
+		end, err := time.ParseInLocation("2006-01-02", query.EndDate, loc)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "结束日期格式错误，应为YYYY-MM-DD")
+		}
+		end = end.AddDate(0, 0, 1)
+		params.EndDate = &end
+	}
+	if params.StartDate != nil && params.EndDate != nil && !params.StartDate.Before(*params.EndDate) {
+		return nil, errors.New(errors.ParamError, "开始日期不能晚于结束日期")
+	}
+
+	page := positiveOr(query.Page, 1)
+	pageSize := positiveOr(query.PageSize, 20)
+	if pageSize > analysisHistoryMaxPageSize {
+		pageSize = analysisHistoryMaxPageSize
+	}
+	params.Limit = pageSize
+	params.Offset = (page - 1) * pageSize
+
+	analyses, total, err := s.aiAnalysisRepo.FindByParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]AnalysisHistoryItem, 0, len(analyses))
+	for _, analysis := range analyses {
+		item := AnalysisHistoryItem{
+			AnalysisID:    analysis.ID,
+			AnalysisType:  analysis.AnalysisType,
+			Status:        analysis.Status,
+			StartDate:     analysis.StartDate,
+			EndDate:       analysis.EndDate,
+			FailureReason: analysis.FailureReason,
+			CreatedAt:     analysis.CreatedAt,
+		}
+		if result := s.parseAnalysisResult(analysis); result != nil {
+			score := result.Score
+			if analysis.Score != nil {
+				score = *analysis.Score
+			}
+			item.Score = &score
+			item.InsightCount = len(result.Insights)
+			item.AlertCount = len(result.Alerts)
+		}
+		items = append(items, item)
+	}
+
+	return &AnalysisHistoryResponse{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// CompareAnalyses 对比同一宝宝同一类型的两次分析，列出新增和已消失的洞察与警告
+func (s *aiAnalysisServiceImpl) CompareAnalyses(ctx context.Context, openID, analysisID, otherID string) (*AnalysisComparisonResponse, error) {
+	first, err := s.getAccessibleAnalysis(ctx, openID, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	second, err := s.getAccessibleAnalysis(ctx, openID, otherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if first.ID == second.ID {
+		return nil, errors.New(errors.ParamError, "不能与自身进行对比")
+	}
+	if first.BabyID != second.BabyID {
+		return nil, errors.New(errors.ParamError, "只能对比同一宝宝的分析")
+	}
+	if first.AnalysisType != second.AnalysisType {
+		return nil, errors.New(errors.ParamError, "只能对比同一类型的分析")
+	}
+
+	firstResult := s.parseAnalysisResult(first)
+	secondResult := s.parseAnalysisResult(second)
+	if firstResult == nil || secondResult == nil {
+		return nil, errors.New(errors.ParamError, "只能对比已完成的分析")
+	}
+
+	// 以较早的一次为基准
+	base, baseResult, target, targetResult := first, firstResult, second, secondResult
+	if target.CreatedAt.Before(base.CreatedAt) {
+		base, baseResult, target, targetResult = target, targetResult, base, baseResult
+	}
+	if base.Score != nil {
+		baseResult.Score = *base.Score
+	}
+	if target.Score != nil {
+		targetResult.Score = *target.Score
+	}
+
+	comparison := diffAnalysisResults(baseResult, targetResult)
+	comparison.AnalysisType = base.AnalysisType
+	comparison.BaseAnalysisID = base.ID
+	comparison.TargetAnalysisID = target.ID
+	comparison.BaseCreatedAt = base.CreatedAt
+	comparison.TargetCreatedAt = target.CreatedAt
+	return comparison, nil
+}
+
+// parseAnalysisResult 解析已完成分析的结果JSON，未完成或解析失败时返回 nil
+func (s *aiAnalysisServiceImpl) parseAnalysisResult(analysis *entity.AIAnalysis) *entity.AIAnalysisResult {
+	if analysis.Status != entity.AIAnalysisStatusCompleted || analysis.Result == "" {
+		return nil
+	}
+	var result entity.AIAnalysisResult
+	if err := json.Unmarshal([]byte(analysis.Result), &result); err != nil {
+		s.logger.Warn("解析分析结果失败",
+			zap.Int64("analysis_id", analysis.ID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return &result
+}
+
+// analysisScore 获取分析评分，评分列为空时(早期记录)从结果JSON中读取
+func (s *aiAnalysisServiceImpl) analysisScore(analysis *entity.AIAnalysis) (float64, bool) {
+	if analysis.Score != nil {
+		return *analysis.Score, true
+	}
+	result := s.parseAnalysisResult(analysis)
+	if result == nil {
+		return 0, false
+	}
+	return result.Score, true
+}
+
+// summarizeAnalysisTrends 按分析类型汇总评分，trends 需按时间升序
+func summarizeAnalysisTrends(trends []AnalysisTrend) []AnalysisScoreSummary {
+	byType := make(map[entity.AIAnalysisType][]float64)
+	for _, trend := range trends {
+		byType[trend.Type] = append(byType[trend.Type], trend.Score)
+	}
+
+	summaries := make([]AnalysisScoreSummary, 0, len(byType))
+	for analysisType, scores := range byType {
+		var total float64
+		for _, score := range scores {
+			total += score
+		}
+		summary := AnalysisScoreSummary{
+			Type:     analysisType,
+			Count:    len(scores),
+			AvgScore: roundToTwoDecimals(total / float64(len(scores))),
+			Latest:   scores[len(scores)-1],
+		}
+		if len(scores) > 1 {
+			previous := scores[len(scores)-2]
+			change := roundToTwoDecimals(summary.Latest - previous)
+			summary.Previous = &previous
+			summary.Change = &change
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Type < summaries[j].Type
+	})
+	return summaries
+}
+
+// diffAnalysisResults 对比两次分析结果，洞察和警告按标题(忽略大小写和空白)匹配
+func diffAnalysisResults(base, target *entity.AIAnalysisResult) *AnalysisComparisonResponse {
+	comparison := &AnalysisComparisonResponse{
+		BaseScore:          base.Score,
+		TargetScore:        target.Score,
+		ScoreChange:        roundToTwoDecimals(target.Score - base.Score),
+		NewInsights:        []entity.AIInsight{},
+		ResolvedInsights:   []entity.AIInsight{},
+		PersistingInsights: []entity.AIInsight{},
+		NewAlerts:          []entity.AIAlert{},
+		ResolvedAlerts:     []entity.AIAlert{},
+		PersistingAlerts:   []entity.AIAlert{},
+	}
+
+	baseInsights := make(map[string]bool, len(base.Insights))
+	for _, insight := range base.Insights {
+		baseInsights[analysisItemKey(insight.Title, insight.Description)] = true
+	}
+	targetInsights := make(map[string]bool, len(target.Insights))
+	for _, insight := range target.Insights {
+		key := analysisItemKey(insight.Title, insight.Description)
+		targetInsights[key] = true
+		if baseInsights[key] {
+			comparison.PersistingInsights = append(comparison.PersistingInsights, insight)
+		} else {
+			comparison.NewInsights = append(comparison.NewInsights, insight)
+		}
+	}
+	for _, insight := range base.Insights {
+		if !targetInsights[analysisItemKey(insight.Title, insight.Description)] {
+			comparison.ResolvedInsights = append(comparison.ResolvedInsights, insight)
+		}
+	}
+
+	baseAlerts := make(map[string]bool, len(base.Alerts))
+	for _, alert := range base.Alerts {
+		baseAlerts[analysisItemKey(alert.Title, alert.Description)] = true
+	}
+	targetAlerts := make(map[string]bool, len(target.Alerts))
+	for _, alert := range target.Alerts {
+		key := analysisItemKey(alert.Title, alert.Description)
+		targetAlerts[key] = true
+		if baseAlerts[key] {
+			comparison.PersistingAlerts = append(comparison.PersistingAlerts, alert)
+		} else {
+			comparison.NewAlerts = append(comparison.NewAlerts, alert)
+		}
+	}
+	for _, alert := range base.Alerts {
+		if !targetAlerts[analysisItemKey(alert.Title, alert.Description)] {
+			comparison.ResolvedAlerts = append(comparison.ResolvedAlerts, alert)
+		}
+	}
+
+	return comparison
+}
+
+// analysisItemKey 洞察/警告的匹配键，标题为空时使用描述
+func analysisItemKey(title, description string) string {
+	text := title
+	if strings.TrimSpace(text) == "" {
+		text = description
+	}
+	return strings.ToLower(strings.Join(strings.Fields(text), ""))
+}
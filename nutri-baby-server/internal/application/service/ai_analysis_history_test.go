@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestDiffAnalysisResults(t *testing.T) {
+	base := &entity.AIAnalysisResult{
+		Score: 72,
+		Insights: []entity.AIInsight{
+			{Title: "奶量稳定"},
+			{Title: "夜醒频繁"},
+		},
+		Alerts: []entity.AIAlert{
+			{Level: "warning", Title: "奶量偏低"},
+		},
+	}
+
+	later := &entity.AIAnalysisResult{
+		Score: 80.5,
+		Insights: []entity.AIInsight{
+			{Title: " 奶量 稳定"},
+			{Title: "", Description: "开始规律小睡"},
+		},
+		Alerts: []entity.AIAlert{
+			{Level: "critical", Title: "体重增长缓慢"},
+		},
+	}
+
+	comparison := diffAnalysisResults(base, later)
+	assert.Equal(t, 8.5, comparison.ScoreChange)
+
+	// 标题忽略空白匹配，无标题时按描述匹配
+	require.Len(t, comparison.PersistingInsights, 1)
+	assert.Equal(t, " 奶量 稳定", comparison.PersistingInsights[0].Title)
+	require.Len(t, comparison.NewInsights, 1)
+	assert.Equal(t, "开始规律小睡", comparison.NewInsights[0].Description)
+	require.Len(t, comparison.ResolvedInsights, 1)
+	assert.Equal(t, "夜醒频繁", comparison.ResolvedInsights[0].Title)
+
+	require.Len(t, comparison.NewAlerts, 1)
+	assert.Equal(t, "体重增长缓慢", comparison.NewAlerts[0].Title)
+	require.Len(t, comparison.ResolvedAlerts, 1)
+	assert.Equal(t, "奶量偏低", comparison.ResolvedAlerts[0].Title)
+	assert.Empty(t, comparison.PersistingAlerts)
+}
+
+func TestSummarizeAnalysisTrends(t *testing.T) {
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	summaries := summarizeAnalysisTrends([]AnalysisTrend{
+		{Date: day, Score: 70, Type: entity.AIAnalysisTypeFeeding},
+		{Date: day.AddDate(0, 0, 1), Score: 60, Type: entity.AIAnalysisTypeSleep},
+		{Date: day.AddDate(0, 0, 7), Score: 76, Type: entity.AIAnalysisTypeFeeding},
+	})
+
+	require.Len(t, summaries, 2)
+	feeding := summaries[0]
+	assert.Equal(t, entity.AIAnalysisTypeFeeding, feeding.Type)
+	assert.Equal(t, 2, feeding.Count)
+	assert.Equal(t, 73.0, feeding.AvgScore)
+	assert.Equal(t, 76.0, feeding.Latest)
+	require.NotNil(t, feeding.Change)
+	assert.Equal(t, 6.0, *feeding.Change)
+
+	sleep := summaries[1]
+	assert.Equal(t, 1, sleep.Count)
+	assert.Nil(t, sleep.Previous)
+	assert.Nil(t, sleep.Change)
+}
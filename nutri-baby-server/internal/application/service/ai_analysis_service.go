@@ -10,7 +10,9 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/guardrail"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +41,11 @@ type AnalysisStatusResponse struct {
 	Progress   int                     `json:"progress"` // 进度百分比 0-100
 	Message    string                  `json:"message"`  // 状态描述
 	UpdatedAt  time.Time               `json:"updated_at"`
+
+	Attempts      int        `json:"attempts"`                  // 已尝试次数
+	MaxAttempts   int        `json:"max_attempts"`              // 最大尝试次数
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // 下次重试时间
+	FailureReason string     `json:"failure_reason,omitempty"`  // 最近一次失败原因
 }
 
 // DailyTipsResponse 每日建议响应
@@ -46,6 +53,7 @@ type DailyTipsResponse struct {
 	Tips        []entity.DailyTip `json:"tips"`
 	GeneratedAt time.Time         `json:"generated_at"`
 	ExpiredAt   time.Time         `json:"expired_at"`
+	Disclaimer  string            `json:"disclaimer"`
 }
 
 // BatchAnalysisRequest 批量分析请求
@@ -66,18 +74,21 @@ type BatchAnalysisResponse struct {
 
 // AnalysisStatsResponse 分析统计响应
 type AnalysisStatsResponse struct {
-	TotalAnalyses int                             `json:"total_analyses"`
-	ByType        map[entity.AIAnalysisType]int   `json:"by_type"`
-	ByStatus      map[entity.AIAnalysisStatus]int `json:"by_status"`
-	AvgScore      float64                         `json:"avg_score"`
-	Trends        []AnalysisTrend                 `json:"trends"`
+	TotalAnalyses  int                             `json:"total_analyses"`
+	Days           int                             `json:"days"` // 统计区间(最近N天)
+	ByType         map[entity.AIAnalysisType]int   `json:"by_type"`
+	ByStatus       map[entity.AIAnalysisStatus]int `json:"by_status"`
+	AvgScore       float64                         `json:"avg_score"`
+	Trends         []AnalysisTrend                 `json:"trends"`          // 已完成分析的评分，按时间升序
+	ScoreSummaries []AnalysisScoreSummary          `json:"score_summaries"` // 各分析类型的评分概况
 }
 
 // AnalysisTrend 分析趋势
 type AnalysisTrend struct {
-	Date  time.Time             `json:"date"`
-	Score float64               `json:"score"`
-	Type  entity.AIAnalysisType `json:"type"`
+	AnalysisID int64                 `json:"analysis_id"`
+	Date       time.Time             `json:"date"`
+	Score      float64               `json:"score"`
+	Type       entity.AIAnalysisType `json:"type"`
 }
 
 // CustomTime 自定义时间类型
@@ -103,43 +114,65 @@ func (ct CustomTime) MarshalJSON() ([]byte, error) {
 }
 
 // AIAnalysisService AI分析服务接口
+// 面向用户的操作都需要传入调用者 openID，并校验其是否为宝宝的协作者
 type AIAnalysisService interface {
 	// 创建分析任务
-	CreateAnalysis(ctx context.Context, req *CreateAnalysisRequest) (*AnalysisResponse, error)
+	CreateAnalysis(ctx context.Context, openID string, req *CreateAnalysisRequest) (*AnalysisResponse, error)
 
 	// 生成每日建议
-	GenerateDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error)
+	GenerateDailyTips(ctx context.Context, openID, babyID string, date time.Time) (*DailyTipsResponse, error)
+
+	// 为宝宝生成每日建议(系统调用，不校验权限，仅供定时任务使用)
+	GenerateScheduledDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error)
 
-	// 处理待分析的任务
+	// 处理待分析的任务(唤醒任务执行器立即调度)
 	ProcessPendingAnalyses(ctx context.Context) error
 
+	// 取消待执行或执行中的分析任务
+	CancelAnalysis(ctx context.Context, openID, analysisID string) (*AnalysisStatusResponse, error)
+
+	// 订阅分析进度事件流，任务结束(结果/失败/取消)或 ctx 取消时关闭通道
+	StreamAnalysis(ctx context.Context, openID, analysisID string) (<-chan AnalysisStreamEvent, error)
+
 	// 获取分析结果
-	GetAnalysisResult(ctx context.Context, analysisID string) (*AnalysisResponse, error)
+	GetAnalysisResult(ctx context.Context, openID, analysisID string) (*AnalysisResponse, error)
 
 	// 获取分析状态（用于轮询）
-	GetAnalysisStatus(ctx context.Context, analysisID string) (*AnalysisStatusResponse, error)
+	GetAnalysisStatus(ctx context.Context, openID, analysisID string) (*AnalysisStatusResponse, error)
 
 	// 获取最新分析
-	GetLatestAnalysis(ctx context.Context, babyID string, analysisType entity.AIAnalysisType) (*AnalysisResponse, error)
+	GetLatestAnalysis(ctx context.Context, openID, babyID string, analysisType entity.AIAnalysisType) (*AnalysisResponse, error)
 
 	// 批量分析
-	BatchAnalyze(ctx context.Context, req *BatchAnalysisRequest) (*BatchAnalysisResponse, error)
+	BatchAnalyze(ctx context.Context, openID string, req *BatchAnalysisRequest) (*BatchAnalysisResponse, error)
+
+	// 为宝宝批量创建分析任务(系统调用，不校验权限和配额，仅供定时任务使用)
+	ScheduleBatchAnalyze(ctx context.Context, babyID int64, analysisTypes []entity.AIAnalysisType, startDate, endDate time.Time) ([]AnalysisResponse, error)
 
 	// 获取每日建议
-	GetDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error)
+	GetDailyTips(ctx context.Context, openID, babyID string, date time.Time) (*DailyTipsResponse, error)
 
-	// 获取分析统计
-	GetAnalysisStats(ctx context.Context, babyID string, days int) (*AnalysisStatsResponse, error)
+	// 获取分析统计(按类型/状态计数及评分趋势)
+	GetAnalysisStats(ctx context.Context, openID, babyID string, days int) (*AnalysisStatsResponse, error)
+
+	// 分页查询分析历史
+	ListAnalyses(ctx context.Context, openID, babyID string, query *AnalysisHistoryQuery) (*AnalysisHistoryResponse, error)
+
+	// 对比两次分析，列出新增和已消失的洞察与警告
+	CompareAnalyses(ctx context.Context, openID, analysisID, otherID string) (*AnalysisComparisonResponse, error)
 }
 
 // aiAnalysisServiceImpl AI分析服务实现
 type aiAnalysisServiceImpl struct {
+	*BaseRecordService
 	aiAnalysisRepo repository.AIAnalysisRepository
 	dailyTipsRepo  repository.DailyTipsRepository
-	babyRepo       repository.BabyRepository
 	chainBuilder   *chain.AnalysisChainBuilder
+	fingerprinter  *AIFingerprinter
+	jobRunner      *AIJobRunner
+	progressHub    *AnalysisProgressHub
+	usageService   *AIUsageService
 	cfg            *config.Config
-	logger         *zap.Logger
 }
 
 // NewAIAnalysisService 创建AI分析服务实例
@@ -147,22 +180,35 @@ func NewAIAnalysisService(
 	aiAnalysisRepo repository.AIAnalysisRepository,
 	dailyTipsRepo repository.DailyTipsRepository,
 	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
 	chainBuilder *chain.AnalysisChainBuilder,
+	fingerprinter *AIFingerprinter,
+	jobRunner *AIJobRunner,
+	progressHub *AnalysisProgressHub,
+	usageService *AIUsageService,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) AIAnalysisService {
 	return &aiAnalysisServiceImpl{
-		aiAnalysisRepo: aiAnalysisRepo,
-		dailyTipsRepo:  dailyTipsRepo,
-		babyRepo:       babyRepo,
-		chainBuilder:   chainBuilder,
-		cfg:            cfg,
-		logger:         logger,
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		aiAnalysisRepo:    aiAnalysisRepo,
+		dailyTipsRepo:     dailyTipsRepo,
+		chainBuilder:      chainBuilder,
+		fingerprinter:     fingerprinter,
+		jobRunner:         jobRunner,
+		progressHub:       progressHub,
+		usageService:      usageService,
+		cfg:               cfg,
 	}
 }
 
 // CreateAnalysis 创建分析任务（异步模式）
-func (s *aiAnalysisServiceImpl) CreateAnalysis(ctx context.Context, req *CreateAnalysisRequest) (*AnalysisResponse, error) {
+func (s *aiAnalysisServiceImpl) CreateAnalysis(ctx context.Context, openID string, req *CreateAnalysisRequest) (*AnalysisResponse, error) {
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(req.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+
 	// 验证宝宝是否存在
 	_, err := s.babyRepo.FindByID(ctx, req.BabyID)
 	if err != nil {
@@ -174,33 +220,28 @@ func (s *aiAnalysisServiceImpl) CreateAnalysis(ctx context.Context, req *CreateA
 		return nil, errors.New(errors.ParamError, "结束日期不能早于开始日期")
 	}
 
+	if err := s.usageService.CheckQuota(ctx, openID, req.BabyID, 1); err != nil {
+		return nil, err
+	}
+
 	// 创建分析记录
 	analysis := &entity.AIAnalysis{
 		BabyID:       req.BabyID,
+		OpenID:       openID,
 		AnalysisType: req.AnalysisType,
 		Status:       entity.AIAnalysisStatusPending,
 		StartDate:    req.StartDate.Time,
 		EndDate:      req.EndDate.Time,
+		MaxAttempts:  s.jobRunner.MaxAttempts(),
+		Locale:       string(s.RequesterLocale(ctx, openID)),
 	}
 
 	if err := s.aiAnalysisRepo.Create(ctx, analysis); err != nil {
 		return nil, errors.Wrap(errors.InternalError, "创建分析任务失败", err)
 	}
 
-	// 异步处理分析任务
-	go func() {
-		// 使用新的context，避免原context被取消
-		bgCtx := context.Background()
-
-		if err := s.processAnalysis(bgCtx, analysis.ID); err != nil {
-			s.logger.Error("AI分析失败",
-				zap.Int64("analysis_id", analysis.ID),
-				zap.Error(err),
-			)
-			// 更新状态为失败
-			s.aiAnalysisRepo.UpdateStatus(bgCtx, analysis.ID, entity.AIAnalysisStatusFailed)
-		}
-	}()
+	// 由任务执行器异步处理
+	s.jobRunner.Notify()
 
 	// 立即返回任务ID和pending状态
 	return &AnalysisResponse{
@@ -211,15 +252,33 @@ func (s *aiAnalysisServiceImpl) CreateAnalysis(ctx context.Context, req *CreateA
 }
 
 // GenerateDailyTips 生成每日建议
-func (s *aiAnalysisServiceImpl) GenerateDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error) {
+func (s *aiAnalysisServiceImpl) GenerateDailyTips(ctx context.Context, openID, babyID string, date time.Time) (*DailyTipsResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+	return s.generateDailyTips(ctx, openID, babyID, date)
+}
+
+// GenerateScheduledDailyTips 为宝宝生成每日建议，已存在当日建议时直接返回
+// 不校验调用者权限，只能由定时任务调用，用量不计入任何用户
+func (s *aiAnalysisServiceImpl) GenerateScheduledDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error) {
+	return s.generateDailyTips(ctx, "", babyID, date)
+}
+
+// generateDailyTips 生成每日建议，已存在当日建议时直接返回
+// openID 为发起用户(用于配额和用量归属)，定时任务为空
+func (s *aiAnalysisServiceImpl) generateDailyTips(ctx context.Context, openID, babyID string, date time.Time) (*DailyTipsResponse, error) {
 	// 转换ID类型
 	id, err := strconv.ParseInt(babyID, 10, 64)
 	if err != nil {
 		return nil, errors.Wrap(errors.ParamError, "无效的宝宝ID", err)
 	}
 
+	// 每种语言单独保存当日建议，定时任务使用默认语言
+	locale := s.RequesterLocale(ctx, openID)
+
 	// 优先检查是否已存在当日建议，如果存在直接返回
-	existingTips, err := s.dailyTipsRepo.GetByBabyIDAndDate(ctx, id, date)
+	existingTips, err := s.dailyTipsRepo.GetByBabyIDAndDate(ctx, id, date, string(locale))
 	if err == nil && existingTips != nil {
 		s.logger.Info("返回已存在的每日建议",
 			zap.String("baby_id", babyID),
@@ -229,6 +288,7 @@ func (s *aiAnalysisServiceImpl) GenerateDailyTips(ctx context.Context, babyID st
 			Tips:        existingTips.Tips,
 			GeneratedAt: existingTips.CreatedAt,
 			ExpiredAt:   existingTips.ExpiredAt,
+			Disclaimer:  tipsDisclaimer(existingTips),
 		}, nil
 	}
 
@@ -246,8 +306,68 @@ func (s *aiAnalysisServiceImpl) GenerateDailyTips(ctx context.Context, babyID st
 		return nil, errors.Wrap(errors.NotFound, "获取宝宝信息失败", err)
 	}
 
-	s.logger.Info("开始生成新的每日建议",
+	fingerprint, reused := s.findReusableTips(ctx, baby, date, locale)
+	var dailyTips *entity.DailyTips
+	if reused != nil {
+		s.logger.Info("复用输入相同的每日建议",
+			zap.String("baby_id", babyID),
+			zap.Int64("reused_from_id", reused.ID),
+		)
+		dailyTips = &entity.DailyTips{Tips: reused.Tips, Disclaimer: reused.Disclaimer, GuardrailLog: reused.GuardrailLog}
+	} else if dailyTips, err = s.generateTipsWithModel(ctx, openID, baby, date, locale); err != nil {
+		return nil, err
+	}
+	dailyTips.Fingerprint = fingerprint
+
+	// 保存建议
+	dailyTips.BabyID = id
+	dailyTips.Date = date
+	dailyTips.Locale = string(locale)
+	dailyTips.ExpiredAt = date.AddDate(0, 0, 1) // 24小时后过期
+
+	if err := s.dailyTipsRepo.Create(ctx, dailyTips); err != nil {
+		return nil, errors.Wrap(errors.InternalError, "保存每日建议失败", err)
+	}
+
+	s.logger.Info("成功生成并保存每日建议",
 		zap.String("baby_id", babyID),
+		zap.Int("tips_count", len(dailyTips.Tips)),
+		zap.Bool("repaired", dailyTips.ValidationErrors != ""),
+		zap.Bool("guarded", dailyTips.GuardrailLog != ""),
+	)
+
+	return &DailyTipsResponse{
+		Tips:        dailyTips.Tips,
+		GeneratedAt: dailyTips.CreatedAt,
+		ExpiredAt:   dailyTips.ExpiredAt,
+		Disclaimer:  tipsDisclaimer(dailyTips),
+	}, nil
+}
+
+// findReusableTips 计算每日建议的输入指纹并查找可复用的建议
+// 指纹计算或查询失败时只记录日志，照常调用模型生成
+func (s *aiAnalysisServiceImpl) findReusableTips(ctx context.Context, baby *entity.Baby, date time.Time, locale i18n.Locale) (string, *entity.DailyTips) {
+	fingerprint, err := s.fingerprinter.DailyTips(ctx, baby, date, locale)
+	if err != nil {
+		s.logger.Warn("计算每日建议输入指纹失败", zap.Int64("baby_id", baby.ID), zap.Error(err))
+		return "", nil
+	}
+	reused, err := s.dailyTipsRepo.FindByFingerprint(ctx, baby.ID, fingerprint)
+	if err != nil {
+		s.logger.Warn("查询可复用的每日建议失败", zap.Int64("baby_id", baby.ID), zap.Error(err))
+		return fingerprint, nil
+	}
+	return fingerprint, reused
+}
+
+// generateTipsWithModel 调用分析链生成每日建议，openID 为空(定时任务)时只校验宝宝配额
+func (s *aiAnalysisServiceImpl) generateTipsWithModel(ctx context.Context, openID string, baby *entity.Baby, date time.Time, locale i18n.Locale) (*entity.DailyTips, error) {
+	if err := s.usageService.CheckQuota(ctx, openID, baby.ID, 1); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("开始生成新的每日建议",
+		zap.Int64("baby_id", baby.ID),
 		zap.String("date", date.Format("2006-01-02")),
 	)
 
@@ -264,64 +384,69 @@ func (s *aiAnalysisServiceImpl) GenerateDailyTips(ctx context.Context, babyID st
 	// 我们添加 timeout 来防止永久挂起
 	genCtx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
+	genCtx = chain.WithUsageScope(genCtx, NewUsageScope(entity.AIUsageOperationDailyTips, openID, baby.ID))
 
-	tips, err := s.chainBuilder.GenerateDailyTips(genCtx, baby, date)
+	dailyTips, err := s.chainBuilder.GenerateDailyTips(genCtx, baby, date, locale)
 	if err != nil {
 		s.logger.Error("生成每日建议失败",
-			zap.String("baby_id", babyID),
+			zap.Int64("baby_id", baby.ID),
 			zap.Error(err),
 		)
 		return nil, errors.Wrap(errors.InternalError, "生成每日建议失败", err)
 	}
+	return dailyTips, nil
+}
 
-	// 保存建议
-	dailyTips := &entity.DailyTips{
-		BabyID:    id,
-		Date:      date,
-		Tips:      tips,
-		ExpiredAt: date.AddDate(0, 0, 1), // 24小时后过期
-	}
-
-	if err := s.dailyTipsRepo.Create(ctx, dailyTips); err != nil {
-		return nil, errors.Wrap(errors.InternalError, "保存每日建议失败", err)
+// tipsDisclaimer 每日建议的免责声明，护栏上线前生成的建议使用默认声明
+func tipsDisclaimer(tips *entity.DailyTips) string {
+	if tips.Disclaimer != "" {
+		return tips.Disclaimer
 	}
-
-	s.logger.Info("成功生成并保存每日建议",
-		zap.String("baby_id", babyID),
-		zap.Int("tips_count", len(tips)),
-	)
-
-	return &DailyTipsResponse{
-		Tips:        tips,
-		GeneratedAt: dailyTips.CreatedAt,
-		ExpiredAt:   dailyTips.ExpiredAt,
-	}, nil
+	return guardrail.DisclaimerFor(i18n.Normalize(tips.Locale), false)
 }
 
 // ProcessPendingAnalyses 处理待分析的任务
+// 任务由 AIJobRunner 按租约抢占执行，这里只唤醒执行器立即调度一次
 func (s *aiAnalysisServiceImpl) ProcessPendingAnalyses(ctx context.Context) error {
-	pendingAnalyses, err := s.aiAnalysisRepo.GetPendingAnalyses(ctx, 10)
+	s.jobRunner.Notify()
+	return nil
+}
+
+// CancelAnalysis 取消分析任务
+func (s *aiAnalysisServiceImpl) CancelAnalysis(ctx context.Context, openID, analysisID string) (*AnalysisStatusResponse, error) {
+	analysis, err := s.getAccessibleAnalysis(ctx, openID, analysisID)
 	if err != nil {
-		return errors.Wrap(errors.InternalError, "获取待分析任务失败", err)
+		return nil, err
 	}
+	id := analysis.ID
 
-	for _, analysis := range pendingAnalyses {
-		if err := s.processAnalysis(ctx, analysis.ID); err != nil {
-			s.logger.Error("处理分析任务失败",
-				zap.Int64("analysis_id", analysis.ID),
-				zap.Error(err),
-			)
-			// 更新状态为失败
-			s.aiAnalysisRepo.UpdateStatus(ctx, analysis.ID, entity.AIAnalysisStatusFailed)
-			continue
-		}
+	cancelled, err := s.aiAnalysisRepo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, errors.New(errors.Conflict, "分析任务已结束，无法取消")
 	}
 
-	return nil
+	// 本实例执行中的任务立即中止，其他实例会在下次心跳时发现租约丢失
+	s.jobRunner.Cancel(id)
+	s.progressHub.Publish(AnalysisStreamEvent{
+		Event:      AnalysisStreamEventStatus,
+		AnalysisID: id,
+		Status:     entity.AIAnalysisStatusCancelled,
+	})
+
+	s.logger.Info("AI分析任务已取消", zap.Int64("analysis_id", id))
+
+	analysis, err = s.aiAnalysisRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(errors.NotFound, "获取分析记录失败", err)
+	}
+	return newAnalysisStatusResponse(analysis), nil
 }
 
-// GetAnalysisResult 获取分析结果
-func (s *aiAnalysisServiceImpl) GetAnalysisResult(ctx context.Context, analysisID string) (*AnalysisResponse, error) {
+// getAccessibleAnalysis 获取分析记录，并校验调用者是否为所属宝宝的协作者
+func (s *aiAnalysisServiceImpl) getAccessibleAnalysis(ctx context.Context, openID, analysisID string) (*entity.AIAnalysis, error) {
 	id, err := strconv.ParseInt(analysisID, 10, 64)
 	if err != nil {
 		return nil, errors.Wrap(errors.ParamError, "无效的分析ID", err)
@@ -332,6 +457,24 @@ func (s *aiAnalysisServiceImpl) GetAnalysisResult(ctx context.Context, analysisI
 		return nil, errors.Wrap(errors.NotFound, "获取分析记录失败", err)
 	}
 
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(analysis.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
+
+// GetAnalysisResult 获取分析结果
+func (s *aiAnalysisServiceImpl) GetAnalysisResult(ctx context.Context, openID, analysisID string) (*AnalysisResponse, error) {
+	analysis, err := s.getAccessibleAnalysis(ctx, openID, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAnalysisResponse(analysis), nil
+}
+
+// newAnalysisResponse 构造分析响应，已完成的任务附带解析后的结果
+func (s *aiAnalysisServiceImpl) newAnalysisResponse(analysis *entity.AIAnalysis) *AnalysisResponse {
+	id := analysis.ID
 	response := &AnalysisResponse{
 		AnalysisID: id, // 使用int64类型的id
 		Status:     analysis.Status,
@@ -346,26 +489,25 @@ func (s *aiAnalysisServiceImpl) GetAnalysisResult(ctx context.Context, analysisI
 				zap.Int64("analysis_id", id),
 				zap.Error(err),
 			)
-			return response, nil
+			return response
 		}
 		response.Result = &result
 	}
 
-	return response, nil
+	return response
 }
 
 // GetAnalysisStatus 获取分析状态（用于轮询）
-func (s *aiAnalysisServiceImpl) GetAnalysisStatus(ctx context.Context, analysisID string) (*AnalysisStatusResponse, error) {
-	id, err := strconv.ParseInt(analysisID, 10, 64)
-	if err != nil {
-		return nil, errors.Wrap(errors.ParamError, "无效的分析ID", err)
-	}
-
-	analysis, err := s.aiAnalysisRepo.GetByID(ctx, id)
+func (s *aiAnalysisServiceImpl) GetAnalysisStatus(ctx context.Context, openID, analysisID string) (*AnalysisStatusResponse, error) {
+	analysis, err := s.getAccessibleAnalysis(ctx, openID, analysisID)
 	if err != nil {
-		return nil, errors.Wrap(errors.NotFound, "获取分析记录失败", err)
+		return nil, err
 	}
+	return newAnalysisStatusResponse(analysis), nil
+}
 
+// newAnalysisStatusResponse 根据任务状态计算进度和描述
+func newAnalysisStatusResponse(analysis *entity.AIAnalysis) *AnalysisStatusResponse {
 	// 根据状态计算进度和消息
 	var progress int
 	var message string
@@ -374,6 +516,9 @@ func (s *aiAnalysisServiceImpl) GetAnalysisStatus(ctx context.Context, analysisI
 	case entity.AIAnalysisStatusPending:
 		progress = 10
 		message = "分析任务已创建，等待处理"
+		if analysis.Attempts > 0 {
+			message = "分析失败，等待自动重试"
+		}
 	case entity.AIAnalysisStatusAnalyzing:
 		progress = 50
 		message = "AI正在分析数据中..."
@@ -383,22 +528,34 @@ func (s *aiAnalysisServiceImpl) GetAnalysisStatus(ctx context.Context, analysisI
 	case entity.AIAnalysisStatusFailed:
 		progress = 0
 		message = "分析失败，请重试"
+	case entity.AIAnalysisStatusCancelled:
+		progress = 0
+		message = "分析已取消"
 	default:
 		progress = 0
 		message = "未知状态"
 	}
 
 	return &AnalysisStatusResponse{
-		AnalysisID: analysisID,
+		AnalysisID: strconv.FormatInt(analysis.ID, 10),
 		Status:     analysis.Status,
 		Progress:   progress,
 		Message:    message,
 		UpdatedAt:  analysis.UpdatedAt,
-	}, nil
+
+		Attempts:      analysis.Attempts,
+		MaxAttempts:   analysis.MaxAttempts,
+		NextAttemptAt: analysis.NextAttemptAt,
+		FailureReason: analysis.FailureReason,
+	}
 }
 
 // GetLatestAnalysis 获取最新分析
-func (s *aiAnalysisServiceImpl) GetLatestAnalysis(ctx context.Context, babyID string, analysisType entity.AIAnalysisType) (*AnalysisResponse, error) {
+func (s *aiAnalysisServiceImpl) GetLatestAnalysis(ctx context.Context, openID, babyID string, analysisType entity.AIAnalysisType) (*AnalysisResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
 	id, err := strconv.ParseInt(babyID, 10, 64)
 	if err != nil {
 		return nil, errors.Wrap(errors.ParamError, "无效的宝宝ID", err)
@@ -409,11 +566,15 @@ func (s *aiAnalysisServiceImpl) GetLatestAnalysis(ctx context.Context, babyID st
 		return nil, errors.Wrap(errors.NotFound, "获取最新分析失败", err)
 	}
 
-	return s.GetAnalysisResult(ctx, strconv.FormatInt(analysis.ID, 10))
+	return s.newAnalysisResponse(analysis), nil
 }
 
 // BatchAnalyze 批量分析（异步模式）
-func (s *aiAnalysisServiceImpl) BatchAnalyze(ctx context.Context, req *BatchAnalysisRequest) (*BatchAnalysisResponse, error) {
+func (s *aiAnalysisServiceImpl) BatchAnalyze(ctx context.Context, openID string, req *BatchAnalysisRequest) (*BatchAnalysisResponse, error) {
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(req.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+
 	// 验证宝宝是否存在
 	_, err := s.babyRepo.FindByID(ctx, req.BabyID)
 	if err != nil {
@@ -425,8 +586,6 @@ func (s *aiAnalysisServiceImpl) BatchAnalyze(ctx context.Context, req *BatchAnal
 		return nil, errors.New(errors.ParamError, "结束日期不能早于开始日期")
 	}
 
-	var results []AnalysisResponse
-
 	// 如果没有指定分析类型，使用所有类型
 	analysisTypes := req.AnalysisTypes
 	if len(analysisTypes) == 0 {
@@ -439,40 +598,55 @@ func (s *aiAnalysisServiceImpl) BatchAnalyze(ctx context.Context, req *BatchAnal
 		}
 	}
 
+	if err := s.usageService.CheckQuota(ctx, openID, req.BabyID, len(analysisTypes)); err != nil {
+		return nil, err
+	}
+
+	results := s.createBatchAnalyses(ctx, openID, req.BabyID, analysisTypes, req.StartDate.Time, req.EndDate.Time)
+
+	return &BatchAnalysisResponse{
+		TotalCount:     len(results),
+		Analyses:       results,
+		CompletedCount: 0, // 初始时都是pending状态
+		FailedCount:    0,
+	}, nil
+}
+
+// ScheduleBatchAnalyze 为宝宝批量创建分析任务(系统调用，不校验权限和配额，仅供定时任务使用)
+// 用量不计入任何用户，返回创建成功的任务
+func (s *aiAnalysisServiceImpl) ScheduleBatchAnalyze(ctx context.Context, babyID int64, analysisTypes []entity.AIAnalysisType, startDate, endDate time.Time) ([]AnalysisResponse, error) {
+	if endDate.Before(startDate) {
+		return nil, errors.New(errors.ParamError, "结束日期不能早于开始日期")
+	}
+	return s.createBatchAnalyses(ctx, "", babyID, analysisTypes, startDate, endDate), nil
+}
+
+// createBatchAnalyses 创建一组待执行的分析任务并唤醒任务执行器，单个任务创建失败时跳过
+func (s *aiAnalysisServiceImpl) createBatchAnalyses(ctx context.Context, openID string, babyID int64, analysisTypes []entity.AIAnalysisType, startDate, endDate time.Time) []AnalysisResponse {
+	var results []AnalysisResponse
+	locale := s.RequesterLocale(ctx, openID)
 	for _, analysisType := range analysisTypes {
 		// 创建分析记录
 		analysis := &entity.AIAnalysis{
-			BabyID:       req.BabyID,
+			BabyID:       babyID,
+			OpenID:       openID,
 			AnalysisType: analysisType,
 			Status:       entity.AIAnalysisStatusPending,
-			StartDate:    req.StartDate.Time,
-			EndDate:      req.EndDate.Time,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			MaxAttempts:  s.jobRunner.MaxAttempts(),
+			Locale:       string(locale),
 		}
 
 		if err := s.aiAnalysisRepo.Create(ctx, analysis); err != nil {
 			s.logger.Error("创建批量分析任务失败",
-				zap.Int64("baby_id", req.BabyID),
+				zap.Int64("baby_id", babyID),
 				zap.String("analysis_type", string(analysisType)),
 				zap.Error(err),
 			)
 			continue
 		}
 
-		// 异步处理分析任务
-		analysisID := analysis.ID
-		go func(id int64, aType entity.AIAnalysisType) {
-			bgCtx := context.Background()
-
-			if err := s.processAnalysis(bgCtx, id); err != nil {
-				s.logger.Error("批量AI分析失败",
-					zap.Int64("analysis_id", id),
-					zap.String("analysis_type", string(aType)),
-					zap.Error(err),
-				)
-				s.aiAnalysisRepo.UpdateStatus(bgCtx, id, entity.AIAnalysisStatusFailed)
-			}
-		}(analysisID, analysisType)
-
 		// 添加到结果列表
 		results = append(results, AnalysisResponse{
 			AnalysisID: analysis.ID, // 使用int64类型
@@ -481,74 +655,12 @@ func (s *aiAnalysisServiceImpl) BatchAnalyze(ctx context.Context, req *BatchAnal
 		})
 	}
 
-	return &BatchAnalysisResponse{
-		TotalCount:     len(results),
-		Analyses:       results,
-		CompletedCount: 0, // 初始时都是pending状态
-		FailedCount:    0,
-	}, nil
+	// 由任务执行器按并发上限异步处理
+	s.jobRunner.Notify()
+	return results
 }
 
 // GetDailyTips 获取每日建议
-func (s *aiAnalysisServiceImpl) GetDailyTips(ctx context.Context, babyID string, date time.Time) (*DailyTipsResponse, error) {
-	return s.GenerateDailyTips(ctx, babyID, date)
-}
-
-// GetAnalysisStats 获取分析统计
-func (s *aiAnalysisServiceImpl) GetAnalysisStats(ctx context.Context, babyID string, days int) (*AnalysisStatsResponse, error) {
-	// 简化实现，返回基本统计信息
-	stats := &AnalysisStatsResponse{
-		TotalAnalyses: 0,
-		ByType:        make(map[entity.AIAnalysisType]int),
-		ByStatus:      make(map[entity.AIAnalysisStatus]int),
-		AvgScore:      0.0,
-		Trends:        []AnalysisTrend{},
-	}
-
-	// TODO: 实现完整的统计功能，需要扩展 repository 接口
-	s.logger.Info("GetAnalysisStats 调用",
-		zap.String("baby_id", babyID),
-		zap.Int("days", days),
-	)
-
-	return stats, nil
-}
-
-// processAnalysis 处理单个分析任务
-func (s *aiAnalysisServiceImpl) processAnalysis(ctx context.Context, analysisID int64) error {
-	// 更新状态为分析中
-	if err := s.aiAnalysisRepo.UpdateStatus(ctx, analysisID, entity.AIAnalysisStatusAnalyzing); err != nil {
-		return errors.Wrap(errors.InternalError, "更新分析状态失败", err)
-	}
-
-	// 获取分析记录
-	analysis, err := s.aiAnalysisRepo.GetByID(ctx, analysisID)
-	if err != nil {
-		return errors.Wrap(errors.InternalError, "获取分析记录失败", err)
-	}
-
-	// 使用分析链进行分析
-	result, err := s.chainBuilder.Analyze(ctx, analysis)
-	if err != nil {
-		return errors.Wrap(errors.InternalError, "AI分析失败", err)
-	}
-
-	// 序列化结果
-	resultJSON, err := json.Marshal(result)
-	if err != nil {
-		return errors.Wrap(errors.ParamError, "序列化分析结果失败", err)
-	}
-
-	// 更新分析结果
-	if err := s.aiAnalysisRepo.UpdateResult(ctx, analysisID, string(resultJSON), entity.AIAnalysisStatusCompleted); err != nil {
-		return errors.Wrap(errors.InternalError, "更新分析结果失败", err)
-	}
-
-	s.logger.Info("AI分析任务完成",
-		zap.Int64("analysis_id", analysisID),
-		zap.String("analysis_type", string(analysis.AnalysisType)),
-		zap.Float64("score", result.Score),
-	)
-
-	return nil
+func (s *aiAnalysisServiceImpl) GetDailyTips(ctx context.Context, openID, babyID string, date time.Time) (*DailyTipsResponse, error) {
+	return s.GenerateDailyTips(ctx, openID, babyID, date)
 }
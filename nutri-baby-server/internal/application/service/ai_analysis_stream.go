@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// analysisStreamPollInterval 流式订阅时轮询数据库状态的间隔
+// 任务在其他实例执行时收不到进程内事件，以轮询兜底
+const analysisStreamPollInterval = 3 * time.Second
+
+// StreamAnalysis 订阅分析进度事件流
+// 先推送当前状态；执行中转发推理轮次、工具调用和增量文本；结束时推送最终结果或失败原因后关闭
+func (s *aiAnalysisServiceImpl) StreamAnalysis(ctx context.Context, openID, analysisID string) (<-chan AnalysisStreamEvent, error) {
+	analysis, err := s.getAccessibleAnalysis(ctx, openID, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	id := analysis.ID
+
+	// 先订阅再推送当前状态，避免两者之间的状态变化丢失
+	events, unsubscribe := s.progressHub.Subscribe(id)
+	out := make(chan AnalysisStreamEvent, 16)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		send := func(event AnalysisStreamEvent) bool {
+			if event.Timestamp == 0 {
+				event.Timestamp = time.Now().UnixMilli()
+			}
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		lastStatus := analysis.Status
+		if !send(statusStreamEvent(analysis)) || s.sendFinalStreamEvent(ctx, analysis, send) {
+			return
+		}
+
+		ticker := time.NewTicker(analysisStreamPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				if event.Event != AnalysisStreamEventStatus {
+					if !send(event) {
+						return
+					}
+					continue
+				}
+			case <-ticker.C:
+			}
+
+			// 状态事件或定时轮询: 以数据库状态为准
+			current, err := s.aiAnalysisRepo.GetByID(ctx, id)
+			if err != nil {
+				s.logger.Warn("刷新分析状态失败", zap.Int64("analysis_id", id), zap.Error(err))
+				continue
+			}
+			if current.Status == lastStatus {
+				continue
+			}
+			lastStatus = current.Status
+
+			if !send(statusStreamEvent(current)) || s.sendFinalStreamEvent(ctx, current, send) {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// sendFinalStreamEvent 任务已结束时推送最终事件，返回 true 表示流应当关闭
+func (s *aiAnalysisServiceImpl) sendFinalStreamEvent(ctx context.Context, analysis *entity.AIAnalysis, send func(AnalysisStreamEvent) bool) bool {
+	switch analysis.Status {
+	case entity.AIAnalysisStatusCompleted:
+		result := s.newAnalysisResponse(analysis)
+		if result.Result == nil {
+			send(AnalysisStreamEvent{
+				Event:      AnalysisStreamEventError,
+				AnalysisID: analysis.ID,
+				Status:     analysis.Status,
+				Message:    "读取分析结果失败",
+			})
+			return true
+		}
+		send(AnalysisStreamEvent{
+			Event:      AnalysisStreamEventResult,
+			AnalysisID: analysis.ID,
+			Status:     analysis.Status,
+			Progress:   100,
+			Result:     result.Result,
+		})
+		return true
+	case entity.AIAnalysisStatusFailed, entity.AIAnalysisStatusCancelled:
+		status := newAnalysisStatusResponse(analysis)
+		message := status.Message
+		if analysis.Status == entity.AIAnalysisStatusFailed && analysis.FailureReason != "" {
+			message = message + ": " + analysis.FailureReason
+		}
+		send(AnalysisStreamEvent{
+			Event:      AnalysisStreamEventError,
+			AnalysisID: analysis.ID,
+			Status:     analysis.Status,
+			Message:    message,
+		})
+		return true
+	default:
+		return false
+	}
+}
+
+// statusStreamEvent 构造状态事件
+func statusStreamEvent(analysis *entity.AIAnalysis) AnalysisStreamEvent {
+	status := newAnalysisStatusResponse(analysis)
+	return AnalysisStreamEvent{
+		Event:      AnalysisStreamEventStatus,
+		AnalysisID: analysis.ID,
+		Status:     status.Status,
+		Progress:   status.Progress,
+		Message:    status.Message,
+	}
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// 对话记忆参数
+const (
+	aiChatHistoryTurns  = 20 // 带入模型的历史消息条数(约10轮问答)
+	aiChatDefaultLimit  = 20 // 历史消息默认分页大小
+	aiChatAnswerTimeout = 90 * time.Second
+)
+
+// aiChatCitationPattern 回答中的记录引用标记，如 [sleep#1234567890]
+var aiChatCitationPattern = regexp.MustCompile(`\s?\[(feeding|sleep|diaper|growth|vaccine|illness|crying|temperature|symptom|milestone)#(\d+)\]`)
+
+// aiChatToolRecordTypes 工具结果类型到记录列表的映射: 记录列表路径(多层用 . 分隔) -> 记录类型
+var aiChatToolRecordTypes = map[string]map[string]string{
+	"feeding_data":     {"records": "feeding"},
+	"sleep_data":       {"records": "sleep"},
+	"diaper_data":      {"records": "diaper"},
+	"growth_data":      {"records": "growth"},
+	"vaccine_data":     {"records": "vaccine"},
+	"illness_episodes": {"episodes": "illness"},
+	"crying_data":      {"records": "crying"},
+	"temperature_data": {"readings": "temperature", "symptoms": "symptom"},
+	"milestone_data":   {"domains.achieved": "milestone"},
+}
+
+// aiChatCitationTimeFields 记录中的时间字段，毫秒时间戳或按宝宝时区格式化的时间
+var aiChatCitationTimeFields = []string{"time", "startTime", "scheduledDate", "start_time", "achieved_date"}
+
+// AIChatService AI育儿助手对话服务
+type AIChatService struct {
+	*BaseRecordService
+	chatRepo     repository.AIChatMessageRepository
+	chainBuilder *chain.AnalysisChainBuilder
+	dataTools    *tools.DataQueryTools
+	usageService *AIUsageService
+}
+
+// NewAIChatService 创建AI育儿助手对话服务
+func NewAIChatService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	chatRepo repository.AIChatMessageRepository,
+	chainBuilder *chain.AnalysisChainBuilder,
+	dataTools *tools.DataQueryTools,
+	usageService *AIUsageService,
+	logger *zap.Logger,
+) *AIChatService {
+	return &AIChatService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		chatRepo:          chatRepo,
+		chainBuilder:      chainBuilder,
+		dataTools:         dataTools,
+		usageService:      usageService,
+	}
+}
+
+// Ask 向助手提问，回答基于工具查询到的记录并附带引用
+func (s *AIChatService) Ask(ctx context.Context, openID, babyID string, req *dto.AIChatRequest) (*dto.AIChatMessageDTO, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, errors.New(errors.ParamError, "问题不能为空")
+	}
+
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.usageService.CheckQuota(ctx, openID, babyIDInt64, 1); err != nil {
+		return nil, err
+	}
+
+	// 多轮记忆: 带入同一宝宝+用户会话的最近消息
+	recent, err := s.chatRepo.FindRecent(ctx, babyIDInt64, openID, 0, aiChatHistoryTurns)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]chain.ChatTurn, 0, len(recent))
+	for _, msg := range recent {
+		history = append(history, chain.ChatTurn{Role: msg.Role, Content: msg.Content})
+	}
+
+	askedAt := time.Now().UnixMilli()
+
+	// 模型调用与HTTP请求解耦，避免客户端断开导致已产生费用的回答丢失
+	chatCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), aiChatAnswerTimeout)
+	defer cancel()
+	chatCtx = chain.WithUsageScope(chatCtx, NewUsageScope(entity.AIUsageOperationChat, openID, babyIDInt64))
+
+	var (
+		mu      sync.Mutex
+		fetched = make(map[string]entity.AIChatCitation)
+		denied  error
+	)
+
+	// 每次工具调用都重新校验协作者权限，并且只允许查询当前宝宝
+	execute := func(toolCtx context.Context, toolName string, params map[string]interface{}) (string, error) {
+		if !sameBabyID(params["baby_id"], baby.ID) {
+			return "", errors.New(errors.PermissionDenied, "只能查询当前宝宝的数据")
+		}
+		if err := s.CheckBabyAccess(toolCtx, babyID, openID); err != nil {
+			mu.Lock()
+			denied = err
+			mu.Unlock()
+			cancel()
+			return "", err
+		}
+		params["baby_id"] = baby.ID
+
+		result, err := s.dataTools.ExecuteTool(toolCtx, toolName, params)
+		if err != nil {
+			return "", err
+		}
+
+		mu.Lock()
+		collectCitations(result, baby.Location(), fetched)
+		mu.Unlock()
+		return result, nil
+	}
+
+	locale := s.RequesterLocale(ctx, openID)
+	reply, err := s.chainBuilder.Chat(chatCtx, baby, history, question, locale, execute)
+	if denied != nil {
+		return nil, denied
+	}
+	if err != nil {
+		s.logger.Error("AI助手回答失败",
+			zap.Int64("baby_id", babyIDInt64),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	answer, citations := resolveCitations(reply.Answer, fetched)
+	if answer == "" {
+		answer = locale.T("chat.fallback_answer")
+	}
+
+	userMsg := &entity.AIChatMessage{
+		BabyID:    babyIDInt64,
+		OpenID:    openID,
+		Role:      entity.AIChatRoleUser,
+		Content:   question,
+		CreatedAt: askedAt,
+	}
+	assistantMsg := &entity.AIChatMessage{
+		BabyID:    babyIDInt64,
+		OpenID:    openID,
+		Role:      entity.AIChatRoleAssistant,
+		Content:   answer,
+		Citations: citations,
+		Tools:     reply.Tools,
+		CreatedAt: max(time.Now().UnixMilli(), askedAt+1),
+	}
+	if err := s.chatRepo.CreateBatch(context.WithoutCancel(ctx), []*entity.AIChatMessage{userMsg, assistantMsg}); err != nil {
+		return nil, err
+	}
+
+	return s.toChatMessageDTO(assistantMsg), nil
+}
+
+// GetHistory 获取对话历史(按时间正序)
+func (s *AIChatService) GetHistory(ctx context.Context, openID, babyID string, query *dto.AIChatHistoryQuery) ([]*dto.AIChatMessageDTO, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = aiChatDefaultLimit
+	}
+
+	messages, err := s.chatRepo.FindRecent(ctx, babyIDInt64, openID, query.Before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.AIChatMessageDTO, 0, len(messages))
+	for _, msg := range messages {
+		result = append(result, s.toChatMessageDTO(msg))
+	}
+	return result, nil
+}
+
+// ClearHistory 清空对话历史(只影响当前用户自己的会话)
+func (s *AIChatService) ClearHistory(ctx context.Context, openID, babyID string) error {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	return s.chatRepo.DeleteConversation(ctx, babyIDInt64, openID)
+}
+
+func (s *AIChatService) toChatMessageDTO(msg *entity.AIChatMessage) *dto.AIChatMessageDTO {
+	citations := make([]*dto.AIChatCitationDTO, 0, len(msg.Citations))
+	for _, c := range msg.Citations {
+		citations = append(citations, &dto.AIChatCitationDTO{
+			RecordType: c.RecordType,
+			RecordID:   c.RecordID,
+			Time:       c.Time,
+		})
+	}
+
+	toolNames := []string(msg.Tools)
+	if toolNames == nil {
+		toolNames = []string{}
+	}
+
+	return &dto.AIChatMessageDTO{
+		MessageID: strconv.FormatInt(msg.ID, 10),
+		Role:      msg.Role,
+		Content:   msg.Content,
+		Citations: citations,
+		Tools:     toolNames,
+		CreatedAt: msg.CreatedAt,
+	}
+}
+
+// sameBabyID 判断模型传入的 baby_id 是否为当前宝宝
+// 模型参数中的大整数会被解析为 float64，按 float64 精度比较
+func sameBabyID(value interface{}, babyID int64) bool {
+	switch v := value.(type) {
+	case float64:
+		return v == float64(babyID)
+	case string:
+		return v == strconv.FormatInt(babyID, 10)
+	case int64:
+		return v == babyID
+	default:
+		return false
+	}
+}
+
+// collectCitations 从工具结果中收集可引用的记录
+// loc 为宝宝所在时区，用于解析专用工具中格式化的时间
+func collectCitations(result string, loc *time.Location, fetched map[string]entity.AIChatCitation) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result), &payload); err != nil {
+		return
+	}
+
+	var resultType string
+	if err := json.Unmarshal(payload["type"], &resultType); err != nil {
+		return
+	}
+	sources, ok := aiChatToolRecordTypes[resultType]
+	if !ok {
+		return
+	}
+
+	for path, recordType := range sources {
+		for _, record := range citationRecords(payload, strings.Split(path, ".")) {
+			// 雪花ID按原始数字文本读取，不经过 float64
+			var id json.Number
+			if err := json.Unmarshal(record["id"], &id); err != nil || id == "" {
+				continue
+			}
+
+			fetched[recordType+"#"+id.String()] = entity.AIChatCitation{
+				RecordType: recordType,
+				RecordID:   id.String(),
+				Time:       citationTime(record, loc),
+			}
+		}
+	}
+}
+
+// citationRecords 按路径取出记录列表，路径中间的数组逐个展开
+func citationRecords(object map[string]json.RawMessage, path []string) []map[string]json.RawMessage {
+	raw, ok := object[path[0]]
+	if !ok {
+		return nil
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var nested map[string]json.RawMessage
+		if len(path) == 1 || json.Unmarshal(raw, &nested) != nil {
+			return nil
+		}
+		return citationRecords(nested, path[1:])
+	}
+	if len(path) == 1 {
+		return items
+	}
+
+	var records []map[string]json.RawMessage
+	for _, item := range items {
+		records = append(records, citationRecords(item, path[1:])...)
+	}
+	return records
+}
+
+// citationTime 读取记录时间(毫秒时间戳)，读取不到时为0
+func citationTime(record map[string]json.RawMessage, loc *time.Location) int64 {
+	for _, field := range aiChatCitationTimeFields {
+		raw, ok := record[field]
+		if !ok {
+			continue
+		}
+		var ms int64
+		if json.Unmarshal(raw, &ms) == nil {
+			return ms
+		}
+		var text string
+		if json.Unmarshal(raw, &text) != nil {
+			continue
+		}
+		for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+			if at, err := time.ParseInLocation(layout, text, loc); err == nil {
+				return at.UnixMilli()
+			}
+		}
+	}
+	return 0
+}
+
+// resolveCitations 解析回答中的引用标记
+// 只保留工具实际返回过的记录，模型编造的引用从回答中移除
+func resolveCitations(answer string, fetched map[string]entity.AIChatCitation) (string, []entity.AIChatCitation) {
+	var citations []entity.AIChatCitation
+	seen := make(map[string]bool)
+
+	cleaned := aiChatCitationPattern.ReplaceAllStringFunc(answer, func(marker string) string {
+		match := aiChatCitationPattern.FindStringSubmatch(marker)
+		key := match[1] + "#" + match[2]
+		citation, ok := fetched[key]
+		if !ok {
+			return ""
+		}
+		if !seen[key] {
+			seen[key] = true
+			citations = append(citations, citation)
+		}
+		return marker
+	})
+
+	return strings.TrimSpace(cleaned), citations
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestResolveCitations(t *testing.T) {
+	fetched := make(map[string]entity.AIChatCitation)
+	collectCitations(`{"type":"sleep_data","count":2,"records":[
+		{"id":1834567890123456789,"startTime":1710900000000},
+		{"id":1834567890123456790,"startTime":1710990000000}]}`, time.UTC, fetched)
+	collectCitations(`{"type":"baby_info","baby":{"id":1}}`, time.UTC, fetched)
+
+	// 雪花ID按原样保留，不经过 float64
+	assert.Len(t, fetched, 2)
+	assert.Equal(t, int64(1710900000000), fetched["sleep#1834567890123456789"].Time)
+
+	answer, citations := resolveCitations(
+		"本周夜醒增加主要集中在周三 [sleep#1834567890123456789]，同时奶量偏少 [feeding#42]。",
+		fetched,
+	)
+
+	// 工具未返回过的记录引用被移除
+	assert.Equal(t, "本周夜醒增加主要集中在周三 [sleep#1834567890123456789]，同时奶量偏少。", answer)
+	assert.Len(t, citations, 1)
+	assert.Equal(t, "1834567890123456789", citations[0].RecordID)
+	assert.Equal(t, "sleep", citations[0].RecordType)
+}
+
+func TestCollectCitations_SpecializedTools(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	fetched := make(map[string]entity.AIChatCitation)
+	collectCitations(`{"type":"temperature_data","readings":[{"id":11,"time":"2024-03-20 08:30","temperature":38.2}],
+		"symptoms":[{"id":12,"time":"2024-03-20 09:00","symptom":"cough"}]}`, loc, fetched)
+	collectCitations(`{"type":"illness_episodes","episodes":[{"id":13,"start_time":"2024-03-19 20:00"}]}`, loc, fetched)
+	collectCitations(`{"type":"crying_data","records":[{"id":14,"startTime":1710900000000}]}`, loc, fetched)
+	collectCitations(`{"type":"milestone_data","domains":[{"domain":"motor","achieved":[{"id":15,"achieved_date":"2024-03-01"}],"delayed":[]},
+		{"domain":"language","achieved":[]}]}`, loc, fetched)
+
+	assert.Len(t, fetched, 5)
+	assert.Equal(t, time.Date(2024, 3, 20, 8, 30, 0, 0, loc).UnixMilli(), fetched["temperature#11"].Time)
+	assert.Equal(t, "symptom", fetched["symptom#12"].RecordType)
+	assert.Equal(t, time.Date(2024, 3, 19, 20, 0, 0, 0, loc).UnixMilli(), fetched["illness#13"].Time)
+	assert.Equal(t, int64(1710900000000), fetched["crying#14"].Time)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, loc).UnixMilli(), fetched["milestone#15"].Time)
+
+	answer, citations := resolveCitations("昨晚发热到38.2℃ [temperature#11]，同时有咳嗽 [symptom#12]。", fetched)
+	assert.Equal(t, "昨晚发热到38.2℃ [temperature#11]，同时有咳嗽 [symptom#12]。", answer)
+	assert.Len(t, citations, 2)
+}
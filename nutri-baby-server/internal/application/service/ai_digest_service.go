@@ -0,0 +1,578 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+const (
+	// aiDigestTemplateType 周期报告订阅消息模板类型
+	aiDigestTemplateType = "ai_digest"
+	// aiDigestDetailPage 周期报告详情页(订阅消息跳转)
+	aiDigestDetailPage = "pages/ai/digest/detail?id=%d"
+	// aiDigestFinalizeTimeout 分析任务迟迟未结束时，超过该时长后用已完成的部分汇总
+	aiDigestFinalizeTimeout = 6 * time.Hour
+	// aiDigestCreateGrace 报告创建后等待写入分析任务的时长
+	aiDigestCreateGrace = 10 * time.Minute
+	// aiDigestFinalizeBatch 每次汇总处理的报告数上限
+	aiDigestFinalizeBatch = 100
+	// aiDigestMaxItems 亮点和关注项各自的条数上限
+	aiDigestMaxItems = 6
+	// aiDigestMaxPageSize 报告列表每页上限
+	aiDigestMaxPageSize = 50
+)
+
+// digestAnalysisTypes 周期报告包含的分析类型，排泄情况包含在综合健康分析中
+var digestAnalysisTypes = []entity.AIAnalysisType{
+	entity.AIAnalysisTypeFeeding,
+	entity.AIAnalysisTypeSleep,
+	entity.AIAnalysisTypeGrowth,
+	entity.AIAnalysisTypeHealth,
+}
+
+// digestAnalysisNames 分析类型的中文名称
+var digestAnalysisNames = map[entity.AIAnalysisType]string{
+	entity.AIAnalysisTypeFeeding:  "喂养",
+	entity.AIAnalysisTypeSleep:    "睡眠",
+	entity.AIAnalysisTypeGrowth:   "成长",
+	entity.AIAnalysisTypeHealth:   "健康",
+	entity.AIAnalysisTypeBehavior: "行为",
+}
+
+// AIDigestListQuery 周期报告列表查询参数
+type AIDigestListQuery struct {
+	Period   entity.AIDigestPeriod `form:"period"` // weekly/monthly，为空表示不限
+	Page     int                   `form:"page"`
+	PageSize int                   `form:"page_size"`
+}
+
+// AIDigestListResponse 周期报告分页列表
+type AIDigestListResponse struct {
+	Items    []*entity.AIDigest `json:"items"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
+
+// AIDigestService AI周期报告服务
+// 定时任务为活跃宝宝批量创建分析，分析结束后结合统计趋势汇总为周报/月报并推送给协作者
+type AIDigestService struct {
+	*BaseRecordService
+	digestRepo        repository.AIDigestRepository
+	aiAnalysisRepo    repository.AIAnalysisRepository
+	aiAnalysisService AIAnalysisService
+	statisticsService *StatisticsService
+	notifier          *BabyNotifier
+}
+
+// NewAIDigestService 创建AI周期报告服务
+func NewAIDigestService(
+	digestRepo repository.AIDigestRepository,
+	aiAnalysisRepo repository.AIAnalysisRepository,
+	aiAnalysisService AIAnalysisService,
+	statisticsService *StatisticsService,
+	notifier *BabyNotifier,
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	logger *zap.Logger,
+) *AIDigestService {
+	return &AIDigestService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		digestRepo:        digestRepo,
+		aiAnalysisRepo:    aiAnalysisRepo,
+		aiAnalysisService: aiAnalysisService,
+		statisticsService: statisticsService,
+		notifier:          notifier,
+	}
+}
+
+// CreateScheduledDigests 为活跃宝宝创建上一周期的报告并批量提交分析任务，返回新建的报告数
+// 同一宝宝同一周期只会创建一次，周期内没有任何记录的宝宝跳过
+func (s *AIDigestService) CreateScheduledDigests(ctx context.Context, period entity.AIDigestPeriod, now time.Time) (int, error) {
+	activeSince := now.AddDate(0, 0, -7).UnixMilli()
+	babies, err := s.babyRepo.FindActiveBabies(ctx, activeSince)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, baby := range babies {
+		select {
+		case <-ctx.Done():
+			return created, ctx.Err()
+		default:
+		}
+
+		ok, err := s.createDigest(ctx, baby, period, now)
+		if err != nil {
+			s.logger.Error("创建周期报告失败",
+				zap.Int64("babyID", baby.ID),
+				zap.String("period", string(period)),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// createDigest 为宝宝创建一期报告，已存在或周期内无记录时返回 false
+func (s *AIDigestService) createDigest(ctx context.Context, baby *entity.Baby, period entity.AIDigestPeriod, now time.Time) (bool, error) {
+	start, end := digestWindow(period, now.In(baby.Location()))
+	stats, err := s.statisticsService.GetPeriodStatistics(ctx, baby.ID, start, end)
+	if err != nil {
+		return false, err
+	}
+	if stats.FeedingCount == 0 && stats.SleepMinutes == 0 && stats.DiaperCount == 0 {
+		return false, nil
+	}
+
+	// 分析任务按日期(含)计算，结束日期为周期最后一天
+	lastDay := end.AddDate(0, 0, -1)
+	digest := &entity.AIDigest{
+		BabyID:    baby.ID,
+		Period:    period,
+		StartDate: start,
+		EndDate:   lastDay,
+		Status:    entity.AIDigestStatusPending,
+	}
+	created, err := s.digestRepo.CreateIfNotExists(ctx, digest)
+	if err != nil || !created {
+		return false, err
+	}
+
+	analyses, err := s.aiAnalysisService.ScheduleBatchAnalyze(ctx, baby.ID, digestAnalysisTypes, start, lastDay)
+	if err != nil {
+		// 标记失败，避免没有分析任务的报告在等待超时后被当作空报告汇总推送
+		digest.Status = entity.AIDigestStatusFailed
+		if updateErr := s.digestRepo.Update(ctx, digest); updateErr != nil {
+			s.logger.Warn("标记周期报告失败状态失败", zap.Int64("digestID", digest.ID), zap.Error(updateErr))
+		}
+		return false, err
+	}
+	for _, analysis := range analyses {
+		digest.AnalysisIDs = append(digest.AnalysisIDs, analysis.AnalysisID)
+	}
+	if err := s.digestRepo.Update(ctx, digest); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("已创建周期报告",
+		zap.Int64("babyID", baby.ID),
+		zap.Int64("digestID", digest.ID),
+		zap.String("period", string(period)),
+		zap.Int("analyses", len(analyses)))
+	return true, nil
+}
+
+// FinalizePendingDigests 汇总分析任务已全部结束(或等待超时)的报告并推送，返回完成的报告数
+func (s *AIDigestService) FinalizePendingDigests(ctx context.Context, now time.Time) (int, error) {
+	digests, err := s.digestRepo.FindPending(ctx, aiDigestFinalizeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	finalized := 0
+	for _, digest := range digests {
+		select {
+		case <-ctx.Done():
+			return finalized, ctx.Err()
+		default:
+		}
+
+		ok, err := s.finalizeDigest(ctx, digest, now)
+		if err != nil {
+			s.logger.Error("汇总周期报告失败", zap.Int64("digestID", digest.ID), zap.Error(err))
+			continue
+		}
+		if ok {
+			finalized++
+		}
+	}
+	return finalized, nil
+}
+
+// finalizeDigest 汇总一期报告，仍有分析任务未结束且未超时时返回 false
+// 多个实例可能同时汇总同一期报告，只有把报告从等待汇总更新为完成的实例负责推送
+func (s *AIDigestService) finalizeDigest(ctx context.Context, digest *entity.AIDigest, now time.Time) (bool, error) {
+	analyses := make([]*entity.AIAnalysis, 0, len(digest.AnalysisIDs))
+	waiting := false
+	for _, id := range digest.AnalysisIDs {
+		analysis, err := s.aiAnalysisRepo.GetByID(ctx, id)
+		if err != nil {
+			s.logger.Warn("获取周期报告的分析任务失败", zap.Int64("digestID", digest.ID), zap.Int64("analysisID", id), zap.Error(err))
+			continue
+		}
+		if analysis.Status == entity.AIAnalysisStatusPending || analysis.Status == entity.AIAnalysisStatusAnalyzing {
+			waiting = true
+		}
+		analyses = append(analyses, analysis)
+	}
+	// 报告刚创建、分析任务尚未写入时同样等待
+	if len(digest.AnalysisIDs) == 0 && now.Sub(digest.CreatedAt) < aiDigestCreateGrace {
+		waiting = true
+	}
+	if waiting && now.Sub(digest.CreatedAt) < aiDigestFinalizeTimeout {
+		return false, nil
+	}
+
+	baby, err := s.babyRepo.FindByID(ctx, digest.BabyID)
+	if err != nil {
+		return false, err
+	}
+
+	// 统计趋势与上一个等长周期对比
+	start := digest.StartDate
+	end := digest.EndDate.AddDate(0, 0, 1)
+	previousStart := previousDigestStart(digest.Period, start)
+	current, err := s.statisticsService.GetPeriodStatistics(ctx, digest.BabyID, start, end)
+	if err != nil {
+		return false, err
+	}
+	previousStats, err := s.statisticsService.GetPeriodStatistics(ctx, digest.BabyID, previousStart, start)
+	if err != nil {
+		return false, err
+	}
+	previous, err := s.digestRepo.FindPrevious(ctx, digest.BabyID, digest.Period, digest.StartDate)
+	if err != nil {
+		return false, err
+	}
+
+	results := make(map[int64]*entity.AIAnalysisResult, len(analyses))
+	for _, analysis := range analyses {
+		if result := parseDigestAnalysisResult(analysis); result != nil {
+			results[analysis.ID] = result
+		}
+	}
+
+	composeDigest(digest, analyses, results, previous, current, previousStats)
+	completedAt := now
+	digest.Status = entity.AIDigestStatusCompleted
+	digest.CompletedAt = &completedAt
+	completed, err := s.digestRepo.CompletePending(ctx, digest)
+	if err != nil || !completed {
+		return false, err
+	}
+
+	if s.notifyCaregivers(ctx, baby, digest) > 0 {
+		notifiedAt := time.Now()
+		digest.NotifiedAt = &notifiedAt
+		if err := s.digestRepo.Update(ctx, digest); err != nil {
+			s.logger.Warn("标记周期报告已推送失败", zap.Int64("digestID", digest.ID), zap.Error(err))
+		}
+	}
+	return true, nil
+}
+
+// notifyCaregivers 向宝宝的协作者推送报告，返回成功发送数
+func (s *AIDigestService) notifyCaregivers(ctx context.Context, baby *entity.Baby, digest *entity.AIDigest) int {
+	data := func(locale i18n.Locale) map[string]any {
+		// 报告摘要按默认语言生成，其他语言提示查看报告
+		summary := digest.Summary
+		if !locale.IsDefault() {
+			summary = locale.T("notify.digest.summary")
+		}
+		return map[string]any{
+			"thing1": truncateRunes(locale.T("notify.digest.title", baby.Name, locale.T(digestPeriodKey(digest.Period))), 20), // 报告名称
+			"time2":  digest.CompletedAt.Format(time.DateTime),                                                                // 生成时间
+			"thing3": truncateRunes(summary, 20),                                                                              // 报告摘要
+		}
+	}
+	return s.notifier.NotifyCaregivers(ctx, baby.ID, aiDigestTemplateType, data, fmt.Sprintf(aiDigestDetailPage, digest.ID))
+}
+
+// ListDigests 分页查询宝宝的已完成报告
+func (s *AIDigestService) ListDigests(ctx context.Context, openID, babyID string, query *AIDigestListQuery) (*AIDigestListResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+	period := query.Period
+	if period != "" && period != entity.AIDigestPeriodWeekly && period != entity.AIDigestPeriodMonthly {
+		return nil, errors.New(errors.ParamError, "无效的报告周期")
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	page := positiveOr(query.Page, 1)
+	pageSize := positiveOr(query.PageSize, 20)
+	if pageSize > aiDigestMaxPageSize {
+		pageSize = aiDigestMaxPageSize
+	}
+
+	digests, total, err := s.digestRepo.ListByBabyID(ctx, babyIDInt64, period, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &AIDigestListResponse{
+		Items:    digests,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// GetDigest 获取报告详情，并校验调用者是否为所属宝宝的协作者
+func (s *AIDigestService) GetDigest(ctx context.Context, openID, digestID string) (*entity.AIDigest, error) {
+	id, err := strconv.ParseInt(digestID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的报告ID")
+	}
+
+	digest, err := s.digestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(digest.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// digestWindow 计算 now 所在时刻应生成的报告周期 [start, end)，按 now 的时区取整到天
+// 周报为截至今天零点的前7天，月报为上一个自然月
+func digestWindow(period entity.AIDigestPeriod, now time.Time) (time.Time, time.Time) {
+	today := getTodayStart(now)
+	if period == entity.AIDigestPeriodMonthly {
+		end := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return end.AddDate(0, -1, 0), end
+	}
+	return today.AddDate(0, 0, -7), today
+}
+
+// previousDigestStart 上一个等长周期的开始时间
+func previousDigestStart(period entity.AIDigestPeriod, start time.Time) time.Time {
+	if period == entity.AIDigestPeriodMonthly {
+		return start.AddDate(0, -1, 0)
+	}
+	return start.AddDate(0, 0, -7)
+}
+
+// digestPeriodKey 周期名称的消息键
+func digestPeriodKey(period entity.AIDigestPeriod) string {
+	if period == entity.AIDigestPeriodMonthly {
+		return "digest.period.monthly"
+	}
+	return "digest.period.weekly"
+}
+
+// digestPeriodName 周期名称(本周/本月)
+func digestPeriodName(period entity.AIDigestPeriod) string {
+	if period == entity.AIDigestPeriodMonthly {
+		return "本月"
+	}
+	return "本周"
+}
+
+// parseDigestAnalysisResult 解析已完成分析的结果，未完成或解析失败时返回 nil
+func parseDigestAnalysisResult(analysis *entity.AIAnalysis) *entity.AIAnalysisResult {
+	if analysis.Status != entity.AIAnalysisStatusCompleted || analysis.Result == "" {
+		return nil
+	}
+	var result entity.AIAnalysisResult
+	if err := json.Unmarshal([]byte(analysis.Result), &result); err != nil {
+		return nil
+	}
+	if analysis.Score != nil {
+		result.Score = *analysis.Score
+	}
+	return &result
+}
+
+// composeDigest 根据分析结果、上一期报告和统计趋势填充报告内容
+// previous 为上一期已完成的报告，可为 nil；results 以分析ID为键，只包含已完成的分析
+func composeDigest(
+	digest *entity.AIDigest,
+	analyses []*entity.AIAnalysis,
+	results map[int64]*entity.AIAnalysisResult,
+	previous *entity.AIDigest,
+	current, previousStats *dto.PeriodStatistics,
+) {
+	previousScores := make(map[entity.AIAnalysisType]float64)
+	if previous != nil {
+		for _, section := range previous.Sections {
+			if section.Score != nil {
+				previousScores[section.AnalysisType] = *section.Score
+			}
+		}
+	}
+
+	digest.Sections = nil
+	digest.Milestones = nil
+	digest.Concerns = nil
+	var scoreSum float64
+	var scored int
+	for _, analysis := range analyses {
+		section := entity.AIDigestSection{
+			AnalysisType: analysis.AnalysisType,
+			AnalysisID:   analysis.ID,
+			Status:       analysis.Status,
+		}
+		name := digestAnalysisNames[analysis.AnalysisType]
+		if prev, ok := previousScores[analysis.AnalysisType]; ok {
+			section.PreviousScore = &prev
+		}
+
+		if result := results[analysis.ID]; result != nil {
+			score := result.Score
+			section.Score = &score
+			section.Summary = digestSectionSummary(result)
+			scoreSum += score
+			scored++
+
+			if result.UserFriendly != nil {
+				for _, highlight := range result.UserFriendly.KeyHighlights {
+					digest.Milestones = append(digest.Milestones, entity.AIDigestItem{
+						Type:        string(analysis.AnalysisType),
+						Title:       highlight.Title,
+						Description: highlight.Description,
+					})
+				}
+			}
+			for _, alert := range result.Alerts {
+				if alert.Level != "critical" && alert.Level != "warning" {
+					continue
+				}
+				digest.Concerns = append(digest.Concerns, entity.AIDigestItem{
+					Type:        string(analysis.AnalysisType),
+					Title:       alert.Title,
+					Description: alert.Suggestion,
+					Level:       alert.Level,
+				})
+			}
+
+			if section.PreviousScore != nil {
+				switch delta := score - *section.PreviousScore; {
+				case delta >= 5:
+					digest.Milestones = append(digest.Milestones, entity.AIDigestItem{
+						Type:  string(analysis.AnalysisType),
+						Title: fmt.Sprintf("%s评分提升%.0f分", name, delta),
+					})
+				case delta <= -10:
+					digest.Concerns = append(digest.Concerns, entity.AIDigestItem{
+						Type:  string(analysis.AnalysisType),
+						Title: fmt.Sprintf("%s评分下降%.0f分", name, -delta),
+						Level: "warning",
+					})
+				}
+			}
+		}
+		digest.Sections = append(digest.Sections, section)
+	}
+
+	digest.Score = nil
+	if scored > 0 {
+		score := math.Round(scoreSum/float64(scored)*10) / 10
+		digest.Score = &score
+	}
+
+	digest.Trends = digestTrends(current, previousStats)
+	for _, trend := range digest.Trends {
+		switch {
+		case trend.Metric == "weight_gain" && trend.Current > 0:
+			digest.Milestones = append(digest.Milestones, entity.AIDigestItem{
+				Type:  "trend",
+				Title: fmt.Sprintf("体重增长%.1fkg", trend.Current),
+			})
+		case trend.Metric == "sleep_minutes_per_day" && trend.Previous > 0 && trend.Change <= -15:
+			digest.Concerns = append(digest.Concerns, entity.AIDigestItem{
+				Type:  "trend",
+				Title: fmt.Sprintf("日均睡眠减少%.0f%%", -trend.Change),
+				Level: "warning",
+			})
+		case trend.Metric == "feeding_per_day" && trend.Previous > 0 && trend.Change <= -20:
+			digest.Concerns = append(digest.Concerns, entity.AIDigestItem{
+				Type:  "trend",
+				Title: fmt.Sprintf("日均喂养减少%.0f%%", -trend.Change),
+				Level: "warning",
+			})
+		}
+	}
+
+	// 严重级别的关注项排在前面
+	criticalFirst := make([]entity.AIDigestItem, 0, len(digest.Concerns))
+	for _, level := range []string{"critical", "warning"} {
+		for _, concern := range digest.Concerns {
+			if concern.Level == level {
+				criticalFirst = append(criticalFirst, concern)
+			}
+		}
+	}
+	digest.Concerns = criticalFirst
+	if len(digest.Milestones) > aiDigestMaxItems {
+		digest.Milestones = digest.Milestones[:aiDigestMaxItems]
+	}
+	if len(digest.Concerns) > aiDigestMaxItems {
+		digest.Concerns = digest.Concerns[:aiDigestMaxItems]
+	}
+
+	digest.Summary = digestSummary(digest, previous, current)
+}
+
+// digestSectionSummary 单项分析的一句话摘要
+func digestSectionSummary(result *entity.AIAnalysisResult) string {
+	if result.UserFriendly != nil && result.UserFriendly.OverallSummary != "" {
+		return result.UserFriendly.OverallSummary
+	}
+	if len(result.Insights) > 0 {
+		return result.Insights[0].Title
+	}
+	return ""
+}
+
+// digestTrends 与上一周期对比的统计趋势
+func digestTrends(current, previous *dto.PeriodStatistics) []entity.AIDigestTrend {
+	if current == nil || previous == nil {
+		return nil
+	}
+	return []entity.AIDigestTrend{
+		newDigestTrend("feeding_per_day", "日均喂养", "次", current.FeedingPerDay, previous.FeedingPerDay),
+		newDigestTrend("sleep_minutes_per_day", "日均睡眠", "分钟", current.SleepMinutesPerDay, previous.SleepMinutesPerDay),
+		newDigestTrend("diaper_per_day", "日均换尿布", "次", current.DiaperPerDay, previous.DiaperPerDay),
+		newDigestTrend("weight_gain", "体重增长", "kg", current.WeightGain, previous.WeightGain),
+	}
+}
+
+func newDigestTrend(metric, label, unit string, current, previous float64) entity.AIDigestTrend {
+	trend := entity.AIDigestTrend{Metric: metric, Label: label, Unit: unit, Current: current, Previous: previous}
+	if previous != 0 {
+		trend.Change = math.Round((current-previous)/previous*1000) / 10
+	}
+	return trend
+}
+
+// digestSummary 报告总结，同时用作订阅消息摘要
+func digestSummary(digest *entity.AIDigest, previous *entity.AIDigest, current *dto.PeriodStatistics) string {
+	periodName := digestPeriodName(digest.Period)
+	var parts []string
+	if digest.Score != nil {
+		part := fmt.Sprintf("%s综合评分%.0f分", periodName, *digest.Score)
+		if previous != nil && previous.Score != nil {
+			part += fmt.Sprintf("(上期%.0f分)", *previous.Score)
+		}
+		parts = append(parts, part)
+	}
+	if current != nil {
+		parts = append(parts, fmt.Sprintf("日均喂养%.1f次、睡眠%.1f小时", current.FeedingPerDay, current.SleepMinutesPerDay/60))
+	}
+	parts = append(parts, fmt.Sprintf("亮点%d项，需关注%d项", len(digest.Milestones), len(digest.Concerns)))
+	return strings.Join(parts, "，")
+}
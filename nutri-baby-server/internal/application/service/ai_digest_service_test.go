@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestDigestWindow(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, loc)
+
+	start, end := digestWindow(entity.AIDigestPeriodWeekly, now)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, loc), end)
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, loc), previousDigestStart(entity.AIDigestPeriodWeekly, start))
+
+	// 月报为上一个自然月，跨年时回到上一年12月
+	start, end = digestWindow(entity.AIDigestPeriodMonthly, time.Date(2027, 1, 1, 7, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, loc), end)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, loc), previousDigestStart(entity.AIDigestPeriodMonthly, start))
+}
+
+func TestComposeDigest(t *testing.T) {
+	feedingScore, sleepScore, previousScore := 70.0, 85.0, 77.5
+	previous := &entity.AIDigest{
+		Score: &previousScore,
+		Sections: []entity.AIDigestSection{
+			{AnalysisType: entity.AIAnalysisTypeFeeding, Score: &feedingScore},
+			{AnalysisType: entity.AIAnalysisTypeSleep, Score: &sleepScore},
+		},
+	}
+
+	analyses := []*entity.AIAnalysis{
+		{ID: 1, AnalysisType: entity.AIAnalysisTypeFeeding, Status: entity.AIAnalysisStatusCompleted},
+		{ID: 2, AnalysisType: entity.AIAnalysisTypeSleep, Status: entity.AIAnalysisStatusCompleted},
+		{ID: 3, AnalysisType: entity.AIAnalysisTypeGrowth, Status: entity.AIAnalysisStatusFailed},
+	}
+	results := map[int64]*entity.AIAnalysisResult{
+		1: {
+			Score: 80,
+			UserFriendly: &entity.UserFriendlyResult{
+				OverallSummary: "喂养规律",
+				KeyHighlights:  []entity.UserFriendlyHighlight{{Title: "奶量稳定增长"}},
+			},
+		},
+		2: {
+			Score:    70,
+			Insights: []entity.AIInsight{{Title: "夜醒增多"}},
+			Alerts: []entity.AIAlert{
+				{Level: "info", Title: "午睡偏短"},
+				{Level: "critical", Title: "夜间睡眠不足"},
+			},
+		},
+	}
+	current := &dto.PeriodStatistics{Days: 7, FeedingPerDay: 8, SleepMinutesPerDay: 600, DiaperPerDay: 7, WeightGain: 0.2}
+	previousStats := &dto.PeriodStatistics{Days: 7, FeedingPerDay: 8, SleepMinutesPerDay: 800, DiaperPerDay: 0}
+
+	digest := &entity.AIDigest{Period: entity.AIDigestPeriodWeekly}
+	composeDigest(digest, analyses, results, previous, current, previousStats)
+
+	require.NotNil(t, digest.Score)
+	assert.Equal(t, 75.0, *digest.Score)
+
+	require.Len(t, digest.Sections, 3)
+	assert.Equal(t, "喂养规律", digest.Sections[0].Summary)
+	assert.Equal(t, 70.0, *digest.Sections[0].PreviousScore)
+	assert.Equal(t, "夜醒增多", digest.Sections[1].Summary)
+	assert.Nil(t, digest.Sections[2].Score)
+	assert.Equal(t, entity.AIAnalysisStatusFailed, digest.Sections[2].Status)
+
+	var milestones []string
+	for _, item := range digest.Milestones {
+		milestones = append(milestones, item.Title)
+	}
+	assert.Equal(t, []string{"奶量稳定增长", "喂养评分提升10分", "体重增长0.2kg"}, milestones)
+
+	// info 级别警告不计入，严重级别排在前面
+	var concerns []string
+	for _, item := range digest.Concerns {
+		concerns = append(concerns, item.Level+"/"+item.Title)
+	}
+	assert.Equal(t, []string{"critical/夜间睡眠不足", "warning/睡眠评分下降15分", "warning/日均睡眠减少25%"}, concerns)
+
+	require.Len(t, digest.Trends, 4)
+	assert.Equal(t, -25.0, digest.Trends[1].Change)
+	assert.Equal(t, 0.0, digest.Trends[2].Change) // 上一周期为 0 时不计算变化
+	assert.Equal(t, "本周综合评分75分(上期78分)，日均喂养8.0次、睡眠10.0小时，亮点3项，需关注3项", digest.Summary)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+const (
+	// fingerprintRecordLimit 计算指纹时每类记录的读取上限
+	fingerprintRecordLimit = 2000
+	// dailyTipsLookbackDays 每日建议提示模型查询最近7天的数据
+	dailyTipsLookbackDays = 7
+)
+
+// AIFingerprinter 计算AI分析和每日建议的输入指纹
+//
+// 指纹是提示词版本、任务参数、宝宝信息和模型可能查询到的全部记录(含更新时间)的摘要，
+// 记录新增、修改、删除或提示词变化都会得到不同的指纹；指纹相同时直接复用已完成的结果，不再调用模型。
+// 记录按分析日期前后各多取一天，覆盖工具按UTC日期与宝宝时区之间的差异；成长记录取截至区间结束的全部历史。
+// 健康、行为和成长分析另有专用工具，分别加入患病经过、疫苗接种、体温症状、哭闹记录和里程碑记录。
+type AIFingerprinter struct {
+	babyRepo      repository.BabyRepository
+	feedingRepo   repository.FeedingRecordRepository
+	sleepRepo     repository.SleepRecordRepository
+	diaperRepo    repository.DiaperRecordRepository
+	growthRepo    repository.GrowthRecordRepository
+	vaccineRepo   repository.BabyVaccineScheduleRepository
+	cryingRepo    repository.CryingRecordRepository
+	illnessRepo   repository.IllnessEpisodeRepository
+	tempRepo      repository.TemperatureRecordRepository
+	symptomRepo   repository.SymptomRecordRepository
+	milestoneRepo repository.MilestoneRecordRepository
+	dataCache     *cache.AnalysisDataCache
+	chainBuilder  *chain.AnalysisChainBuilder
+	now           func() time.Time
+}
+
+// NewAIFingerprinter 创建输入指纹计算器
+func NewAIFingerprinter(
+	babyRepo repository.BabyRepository,
+	feedingRepo repository.FeedingRecordRepository,
+	sleepRepo repository.SleepRecordRepository,
+	diaperRepo repository.DiaperRecordRepository,
+	growthRepo repository.GrowthRecordRepository,
+	vaccineRepo repository.BabyVaccineScheduleRepository,
+	cryingRepo repository.CryingRecordRepository,
+	illnessRepo repository.IllnessEpisodeRepository,
+	tempRepo repository.TemperatureRecordRepository,
+	symptomRepo repository.SymptomRecordRepository,
+	milestoneRepo repository.MilestoneRecordRepository,
+	dataCache *cache.AnalysisDataCache,
+	chainBuilder *chain.AnalysisChainBuilder,
+) *AIFingerprinter {
+	return &AIFingerprinter{
+		babyRepo:      babyRepo,
+		feedingRepo:   feedingRepo,
+		sleepRepo:     sleepRepo,
+		diaperRepo:    diaperRepo,
+		growthRepo:    growthRepo,
+		vaccineRepo:   vaccineRepo,
+		cryingRepo:    cryingRepo,
+		illnessRepo:   illnessRepo,
+		tempRepo:      tempRepo,
+		symptomRepo:   symptomRepo,
+		milestoneRepo: milestoneRepo,
+		dataCache:     dataCache,
+		chainBuilder:  chainBuilder,
+		now:           time.Now,
+	}
+}
+
+// fingerprintInput 参与指纹计算的全部输入
+type fingerprintInput struct {
+	Kind          string                        `json:"kind"`
+	PromptVersion string                        `json:"prompt_version"`
+	AnalysisType  entity.AIAnalysisType         `json:"analysis_type,omitempty"`
+	StartDate     string                        `json:"start_date,omitempty"`
+	EndDate       string                        `json:"end_date,omitempty"`
+	AsOf          string                        `json:"as_of"` // 计算月龄的日期
+	Baby          *entity.Baby                  `json:"baby"`
+	Feedings      []*entity.FeedingRecord       `json:"feedings"`
+	Sleeps        []*entity.SleepRecord         `json:"sleeps"`
+	Diapers       []*entity.DiaperRecord        `json:"diapers"`
+	Growth        []*entity.GrowthRecord        `json:"growth"`
+	Vaccines      []*entity.BabyVaccineSchedule `json:"vaccines,omitempty"`     // 仅健康分析
+	Illness       []*entity.IllnessEpisode      `json:"illness,omitempty"`      // 仅健康分析
+	Temperatures  []*entity.TemperatureRecord   `json:"temperatures,omitempty"` // 仅健康分析
+	Symptoms      []*entity.SymptomRecord       `json:"symptoms,omitempty"`     // 仅健康分析
+	Crying        []*entity.CryingRecord        `json:"crying,omitempty"`       // 仅行为分析
+	Milestones    []*entity.MilestoneRecord     `json:"milestones,omitempty"`   // 仅成长分析
+}
+
+// Analysis 计算分析任务的输入指纹
+// 区间结束晚于今天时，月龄按今天计算，因此第二天再次分析同一区间会得到不同的指纹
+func (f *AIFingerprinter) Analysis(ctx context.Context, analysis *entity.AIAnalysis) (string, error) {
+	input := &fingerprintInput{
+		Kind:          chain.AnalysisTaskAnalysis,
+		PromptVersion: f.chainBuilder.PromptVersion(analysis),
+		AnalysisType:  analysis.AnalysisType,
+		StartDate:     analysis.StartDate.Format("2006-01-02"),
+		EndDate:       analysis.EndDate.Format("2006-01-02"),
+	}
+	return f.compute(ctx, input, analysis.BabyID, analysis.StartDate, analysis.EndDate)
+}
+
+// DailyTips 计算每日建议的输入指纹
+// 日期本身不参与计算，最近7天的数据没有变化时可以复用之前生成的建议
+func (f *AIFingerprinter) DailyTips(ctx context.Context, baby *entity.Baby, date time.Time, locale i18n.Locale) (string, error) {
+	input := &fingerprintInput{
+		Kind:          chain.AnalysisTaskDailyTips,
+		PromptVersion: f.chainBuilder.DailyTipsPromptVersion(baby, locale),
+	}
+	return f.compute(ctx, input, baby.ID, date.AddDate(0, 0, -dailyTipsLookbackDays), date)
+}
+
+// compute 读取区间内的数据并计算摘要
+func (f *AIFingerprinter) compute(ctx context.Context, input *fingerprintInput, babyID int64, startDate, endDate time.Time) (string, error) {
+	baby, err := f.dataCache.GetBabyInfo(ctx, babyID, func(ctx context.Context) (*entity.Baby, error) {
+		return f.babyRepo.FindByID(ctx, babyID)
+	})
+	if err != nil {
+		return "", err
+	}
+	input.Baby = baby
+
+	asOf := f.now().In(baby.Location())
+	if end := endDate.Format("2006-01-02"); end < asOf.Format("2006-01-02") {
+		input.AsOf = end
+	} else {
+		input.AsOf = asOf.Format("2006-01-02")
+	}
+
+	startTime := dateStart(startDate).AddDate(0, 0, -1).UnixMilli()
+	endTime := dateStart(endDate).AddDate(0, 0, 2).UnixMilli()
+
+	if input.Feedings, err = f.dataCache.GetFeedingRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.FeedingRecord, error) {
+		records, _, err := f.feedingRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+		return records, err
+	}); err != nil {
+		return "", err
+	}
+	if input.Sleeps, err = f.dataCache.GetSleepRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.SleepRecord, error) {
+		records, _, err := f.sleepRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+		return records, err
+	}); err != nil {
+		return "", err
+	}
+	if input.Diapers, err = f.dataCache.GetDiaperRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.DiaperRecord, error) {
+		records, _, err := f.diaperRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+		return records, err
+	}); err != nil {
+		return "", err
+	}
+	if input.Growth, err = f.dataCache.GetGrowthRecords(ctx, babyID, 0, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.GrowthRecord, error) {
+		records, _, err := f.growthRepo.FindByBabyID(ctx, babyID, 0, endTime, 1, fingerprintRecordLimit)
+		return records, err
+	}); err != nil {
+		return "", err
+	}
+
+	switch input.AnalysisType {
+	case entity.AIAnalysisTypeHealth:
+		if input.Vaccines, err = f.vaccineRepo.FindByBabyID(ctx, babyID, 1, 100); err != nil {
+			return "", err
+		}
+		if input.Illness, err = f.dataCache.GetIllnessEpisodes(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.IllnessEpisode, error) {
+			records, _, err := f.illnessRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+			return records, err
+		}); err != nil {
+			return "", err
+		}
+		if input.Temperatures, err = f.dataCache.GetTemperatureRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.TemperatureRecord, error) {
+			records, _, err := f.tempRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+			return records, err
+		}); err != nil {
+			return "", err
+		}
+		if input.Symptoms, err = f.dataCache.GetSymptomRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.SymptomRecord, error) {
+			records, _, err := f.symptomRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+			return records, err
+		}); err != nil {
+			return "", err
+		}
+	case entity.AIAnalysisTypeBehavior:
+		if input.Crying, err = f.dataCache.GetCryingRecords(ctx, babyID, startTime, endTime, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.CryingRecord, error) {
+			records, _, err := f.cryingRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, fingerprintRecordLimit)
+			return records, err
+		}); err != nil {
+			return "", err
+		}
+	case entity.AIAnalysisTypeGrowth:
+		// 里程碑工具返回全部达成记录，与分析区间无关
+		if input.Milestones, err = f.dataCache.GetMilestoneRecords(ctx, babyID, 0, 0, fingerprintRecordLimit, func(ctx context.Context) ([]*entity.MilestoneRecord, error) {
+			records, _, err := f.milestoneRepo.FindByBabyID(ctx, babyID, 0, 0, 1, fingerprintRecordLimit)
+			return records, err
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	return fingerprintOf(input)
+}
+
+// fingerprintOf 输入的 SHA-256 摘要
+func fingerprintOf(input *fingerprintInput) (string, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// dateStart 日期当天零点(UTC)，与数据工具解析日期参数的方式一致
+func dateStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
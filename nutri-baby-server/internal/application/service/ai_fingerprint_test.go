@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+)
+
+// fingerprintBabyRepo 指纹测试用的宝宝仓储
+type fingerprintBabyRepo struct {
+	repository.BabyRepository
+	baby *entity.Baby
+}
+
+func (r fingerprintBabyRepo) FindByID(ctx context.Context, babyID int64) (*entity.Baby, error) {
+	return r.baby, nil
+}
+
+// fingerprintFeedingRepo 指纹测试用的喂养记录仓储，按时间区间过滤记录
+type fingerprintFeedingRepo struct {
+	repository.FeedingRecordRepository
+	feedings []*entity.FeedingRecord
+}
+
+func (s *fingerprintFeedingRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.FeedingRecord, int64, error) {
+	var records []*entity.FeedingRecord
+	for _, record := range s.feedings {
+		if record.Time >= startTime && record.Time <= endTime {
+			records = append(records, record)
+		}
+	}
+	return records, int64(len(records)), nil
+}
+
+type emptySleepRepo struct {
+	repository.SleepRecordRepository
+}
+
+func (emptySleepRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.SleepRecord, int64, error) {
+	return nil, 0, nil
+}
+
+type emptyDiaperRepo struct {
+	repository.DiaperRecordRepository
+}
+
+func (emptyDiaperRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.DiaperRecord, int64, error) {
+	return nil, 0, nil
+}
+
+type emptyGrowthRepo struct {
+	repository.GrowthRecordRepository
+}
+
+func (emptyGrowthRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.GrowthRecord, int64, error) {
+	return nil, 0, nil
+}
+
+func newTestFingerprinter(baby *entity.Baby, feedings *fingerprintFeedingRepo, now time.Time) *AIFingerprinter {
+	logger := zap.NewNop()
+	dataTools := tools.NewDataQueryTools(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, logger)
+	builder := chain.NewAnalysisChainBuilder(nil, dataTools, nil, nil, logger)
+	f := NewAIFingerprinter(fingerprintBabyRepo{baby: baby}, feedings, emptySleepRepo{}, emptyDiaperRepo{}, emptyGrowthRepo{}, nil, nil, nil, nil, nil, nil, nil, builder)
+	f.now = func() time.Time { return now }
+	return f
+}
+
+func TestAIFingerprinter_Analysis(t *testing.T) {
+	ctx := context.Background()
+	weekStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	store := &fingerprintFeedingRepo{
+		feedings: []*entity.FeedingRecord{
+			{ID: 1, BabyID: 1, Time: weekStart.Add(8 * time.Hour).UnixMilli(), FeedingType: "bottle", Amount: 120, UpdatedAt: 1},
+		},
+	}
+	analysis := &entity.AIAnalysis{BabyID: 1, AnalysisType: entity.AIAnalysisTypeFeeding, StartDate: weekStart, EndDate: weekStart.AddDate(0, 0, 6)}
+	f := newTestFingerprinter(&entity.Baby{ID: 1, BirthDate: "2026-06-01"}, store, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+
+	first, err := f.Analysis(ctx, analysis)
+	require.NoError(t, err)
+	again, err := f.Analysis(ctx, analysis)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	// 区间已结束，之后再分析同一区间指纹不变
+	f.now = func() time.Time { return time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC) }
+	later, err := f.Analysis(ctx, analysis)
+	require.NoError(t, err)
+	assert.Equal(t, first, later)
+
+	// 区间外的记录不影响指纹
+	store.feedings = append(store.feedings, &entity.FeedingRecord{ID: 2, BabyID: 1, Time: weekStart.AddDate(0, 0, 20).UnixMilli()})
+	outside, err := f.Analysis(ctx, analysis)
+	require.NoError(t, err)
+	assert.Equal(t, first, outside)
+
+	// 修改区间内的记录
+	store.feedings[0].Amount, store.feedings[0].UpdatedAt = 150, 2
+	edited, err := f.Analysis(ctx, analysis)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, edited)
+
+	// 分析类型不同
+	sleep := *analysis
+	sleep.AnalysisType = entity.AIAnalysisTypeSleep
+	other, err := f.Analysis(ctx, &sleep)
+	require.NoError(t, err)
+	assert.NotEqual(t, edited, other)
+}
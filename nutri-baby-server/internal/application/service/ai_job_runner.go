@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
+	"go.uber.org/zap"
+)
+
+// AIJobRunner AI分析任务执行器
+//
+// 任务以数据库行为准: worker 通过租约抢占任务并定期心跳续约，实例宕机后租约过期由其他实例接管；
+// 全局 worker 数和各提供商并发数都有上限；失败按指数退避重试并记录失败原因；
+// 任务取消后 worker 在本地立即中止(或在下次心跳发现租约丢失时中止)，且不会再写回结果。
+type AIJobRunner struct {
+	aiAnalysisRepo repository.AIAnalysisRepository
+	chainBuilder   *chain.AnalysisChainBuilder
+	fingerprinter  *AIFingerprinter
+	progressHub    *AnalysisProgressHub
+	cfg            *config.Config
+	logger         *zap.Logger
+
+	workerID      string
+	workers       chan struct{}            // 全局worker槽位
+	providerSlots map[string]chan struct{} // 各提供商并发槽位
+	wake          chan struct{}
+
+	mu      sync.Mutex
+	running map[int64]context.CancelFunc // 本实例正在执行的任务
+
+	ctx    context.Context
+	stop   context.CancelFunc
+	wg     sync.WaitGroup
+	loopWG sync.WaitGroup
+}
+
+// NewAIJobRunner 创建AI分析任务执行器
+func NewAIJobRunner(
+	aiAnalysisRepo repository.AIAnalysisRepository,
+	chainBuilder *chain.AnalysisChainBuilder,
+	fingerprinter *AIFingerprinter,
+	progressHub *AnalysisProgressHub,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *AIJobRunner {
+	hostname, _ := os.Hostname()
+	ctx, stop := context.WithCancel(context.Background())
+
+	return &AIJobRunner{
+		aiAnalysisRepo: aiAnalysisRepo,
+		chainBuilder:   chainBuilder,
+		fingerprinter:  fingerprinter,
+		progressHub:    progressHub,
+		cfg:            cfg,
+		logger:         logger,
+		workerID:       fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()%100000),
+		workers:        make(chan struct{}, positiveOr(cfg.AI.Analysis.Workers, 4)),
+		providerSlots:  make(map[string]chan struct{}),
+		wake:           make(chan struct{}, 1),
+		running:        make(map[int64]context.CancelFunc),
+		ctx:            ctx,
+		stop:           stop,
+	}
+}
+
+// Start 启动调度循环
+func (r *AIJobRunner) Start() {
+	r.loopWG.Add(1)
+	go r.loop()
+	r.logger.Info("AI分析任务执行器已启动",
+		zap.String("worker_id", r.workerID),
+		zap.Int("workers", cap(r.workers)),
+	)
+}
+
+// Stop 停止调度并等待执行中的任务退出，未完成的任务释放租约退回待执行
+func (r *AIJobRunner) Stop() {
+	r.stop()
+	r.loopWG.Wait()
+	r.wg.Wait()
+	r.logger.Info("AI分析任务执行器已停止")
+}
+
+// Notify 唤醒调度循环(有新任务时调用)，不阻塞
+func (r *AIJobRunner) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel 中止本实例正在执行的任务(数据库状态由调用方先行更新)
+func (r *AIJobRunner) Cancel(analysisID int64) {
+	r.mu.Lock()
+	cancel, ok := r.running[analysisID]
+	r.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// MaxAttempts 新任务的最大尝试次数(首次执行 + 重试次数)
+func (r *AIJobRunner) MaxAttempts() int {
+	if r.cfg.AI.Analysis.RetryCount < 0 {
+		return 1
+	}
+	return r.cfg.AI.Analysis.RetryCount + 1
+}
+
+func (r *AIJobRunner) loop() {
+	defer r.loopWG.Done()
+
+	ticker := time.NewTicker(time.Duration(positiveOr(r.cfg.AI.Analysis.PollInterval, 5)) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		r.dispatch()
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// dispatch 按空闲槽位数抢占任务并交给worker执行
+func (r *AIJobRunner) dispatch() {
+	// 并发槽位按首选提供商计算，调用失败时由模型层回退到后续提供商
+	provider := r.cfg.AI.ProviderChain()[0]
+	providerSlot := r.providerSlot(provider)
+
+	// 先占槽位再抢任务，保证抢到的任务都能立即执行
+	acquired := 0
+	for acquired < cap(r.workers) && r.tryAcquire(providerSlot) {
+		acquired++
+	}
+	if acquired == 0 {
+		return
+	}
+
+	jobs, err := r.aiAnalysisRepo.ClaimPending(r.ctx, r.workerID, provider, time.Now().Add(r.leaseDuration()), acquired)
+	if err != nil && r.ctx.Err() == nil {
+		r.logger.Error("抢占AI分析任务失败", zap.Error(err))
+	}
+
+	for i := len(jobs); i < acquired; i++ {
+		<-providerSlot
+		<-r.workers
+	}
+
+	for _, job := range jobs {
+		r.wg.Add(1)
+		go r.run(job, func() {
+			<-providerSlot
+			<-r.workers
+		})
+	}
+}
+
+// run 执行单个任务: 心跳续约、执行分析、写回结果或按退避重试
+func (r *AIJobRunner) run(job *entity.AIAnalysis, release func()) {
+	defer r.wg.Done()
+	defer r.Notify()
+	defer release()
+
+	timeout := time.Duration(positiveOr(r.cfg.AI.Analysis.Timeout, 120)) * time.Second
+	jobCtx, cancel := context.WithTimeout(r.ctx, timeout)
+	defer cancel()
+
+	r.mu.Lock()
+	r.running[job.ID] = cancel
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.running, job.ID)
+		r.mu.Unlock()
+	}()
+
+	heartbeatDone := make(chan struct{})
+	go r.heartbeat(jobCtx, cancel, job.ID, heartbeatDone)
+
+	r.publishStatus(job.ID, entity.AIAnalysisStatusAnalyzing)
+
+	var completion *repository.AIAnalysisCompletion
+	var err error
+	if job.MaxAttempts > 0 && job.Attempts > job.MaxAttempts {
+		// 执行中宕机被接管的任务也计入尝试次数，超过上限直接失败
+		err = fmt.Errorf("超过最大尝试次数(%d)", job.MaxAttempts)
+	} else {
+		completion, err = r.execute(jobCtx, job)
+	}
+
+	cancel()
+	<-heartbeatDone
+
+	// 写回使用独立context: 服务停止时仍需释放租约
+	writeCtx, writeCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer writeCancel()
+
+	logFields := []zap.Field{
+		zap.Int64("analysis_id", job.ID),
+		zap.String("analysis_type", string(job.AnalysisType)),
+		zap.Int("attempt", job.Attempts),
+	}
+
+	if r.ctx.Err() != nil {
+		if err := r.aiAnalysisRepo.ReleaseJob(writeCtx, job.ID, r.workerID); err != nil {
+			r.logger.Error("释放AI分析任务失败", append(logFields, zap.Error(err))...)
+		}
+		return
+	}
+
+	var held bool
+	if err == nil {
+		held, err = r.aiAnalysisRepo.CompleteJob(writeCtx, job.ID, r.workerID, *completion)
+		if err != nil {
+			r.logger.Error("保存AI分析结果失败", append(logFields, zap.Error(err))...)
+			return
+		}
+		if held {
+			r.logger.Info("AI分析任务完成", logFields...)
+			r.publishStatus(job.ID, entity.AIAnalysisStatusCompleted)
+		}
+	} else {
+		reason := truncateRunes(err.Error(), 500)
+		retryAt := r.nextRetryAt(job, time.Now())
+
+		failure := repository.AIAnalysisFailure{Reason: reason, RetryAt: retryAt}
+		var validationErr *structured.ValidationError
+		if errors.As(err, &validationErr) {
+			failure.ValidationErrors = validationErr.Report.String()
+		}
+
+		var failErr error
+		held, failErr = r.aiAnalysisRepo.FailJob(writeCtx, job.ID, r.workerID, failure)
+		if failErr != nil {
+			r.logger.Error("记录AI分析失败状态失败", append(logFields, zap.Error(failErr))...)
+			return
+		}
+		if held {
+			if retryAt != nil {
+				r.logger.Warn("AI分析任务失败，等待重试", append(logFields, zap.String("reason", reason), zap.Time("retry_at", *retryAt))...)
+				r.publishStatus(job.ID, entity.AIAnalysisStatusPending)
+			} else {
+				r.logger.Error("AI分析任务失败，已达最大尝试次数", append(logFields, zap.String("reason", reason))...)
+				r.publishStatus(job.ID, entity.AIAnalysisStatusFailed)
+			}
+		}
+	}
+
+	if !held {
+		r.logger.Info("AI分析任务已被取消或被其他worker接管，丢弃本次结果", logFields...)
+	}
+}
+
+// execute 调用分析链并序列化结果，过程事件广播给订阅方
+// 返回结果JSON、评分及实际产出结果的提供商，评分单独落库用于历史趋势统计
+// 已有输入指纹相同的已完成分析时直接复用其结果，不调用模型
+func (r *AIJobRunner) execute(ctx context.Context, job *entity.AIAnalysis) (*repository.AIAnalysisCompletion, error) {
+	fingerprint, reused := r.findReusable(ctx, job)
+	if reused != nil {
+		r.logger.Info("复用输入相同的AI分析结果",
+			zap.Int64("analysis_id", job.ID),
+			zap.Int64("reused_from_id", reused.ID),
+		)
+		return &repository.AIAnalysisCompletion{
+			Result:       reused.Result,
+			Score:        reused.Score,
+			Provider:     reused.Provider,
+			GuardrailLog: reused.GuardrailLog,
+			Fingerprint:  fingerprint,
+			ReusedFromID: &reused.ID,
+		}, nil
+	}
+
+	// 同一任务的重试共用请求ID，按一次请求计入配额
+	ctx = chain.WithUsageScope(ctx, chain.UsageScope{
+		RequestID: "analysis-" + strconv.FormatInt(job.ID, 10),
+		Operation: entity.AIUsageOperationAnalysis,
+		OpenID:    job.OpenID,
+		BabyID:    job.BabyID,
+	})
+	result, err := r.chainBuilder.AnalyzeWithProgress(ctx, job, func(event chain.ProgressEvent) {
+		r.progressHub.Publish(AnalysisStreamEvent{
+			Event:      event.Type,
+			AnalysisID: job.ID,
+			Iteration:  event.Iteration,
+			Tool:       event.Tool,
+			ToolLabel:  event.ToolLabel,
+			Text:       event.Text,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化分析结果失败: %w", err)
+	}
+	score := result.Score
+	return &repository.AIAnalysisCompletion{
+		Result:           string(resultJSON),
+		Score:            &score,
+		Provider:         result.Provider,
+		ValidationErrors: result.ValidationErrors,
+		GuardrailLog:     result.GuardrailLog,
+		Fingerprint:      fingerprint,
+	}, nil
+}
+
+// findReusable 计算任务的输入指纹并查找可复用的已完成分析
+// 指纹计算或查询失败时只记录日志，任务照常调用模型
+func (r *AIJobRunner) findReusable(ctx context.Context, job *entity.AIAnalysis) (string, *entity.AIAnalysis) {
+	fingerprint, err := r.fingerprinter.Analysis(ctx, job)
+	if err != nil {
+		r.logger.Warn("计算AI分析输入指纹失败", zap.Int64("analysis_id", job.ID), zap.Error(err))
+		return "", nil
+	}
+	reused, err := r.aiAnalysisRepo.FindCompletedByFingerprint(ctx, job.BabyID, job.AnalysisType, fingerprint, job.ID)
+	if err != nil {
+		r.logger.Warn("查询可复用的AI分析结果失败", zap.Int64("analysis_id", job.ID), zap.Error(err))
+		return fingerprint, nil
+	}
+	return fingerprint, reused
+}
+
+// publishStatus 广播状态变化，订阅方据此从数据库读取最新状态
+func (r *AIJobRunner) publishStatus(analysisID int64, status entity.AIAnalysisStatus) {
+	r.progressHub.Publish(AnalysisStreamEvent{
+		Event:      AnalysisStreamEventStatus,
+		AnalysisID: analysisID,
+		Status:     status,
+	})
+}
+
+// heartbeat 定期续约，租约丢失(任务被取消或被接管)时中止任务
+func (r *AIJobRunner) heartbeat(ctx context.Context, cancel context.CancelFunc, analysisID int64, done chan<- struct{}) {
+	defer close(done)
+
+	lease := r.leaseDuration()
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := r.aiAnalysisRepo.RenewLease(ctx, analysisID, r.workerID, time.Now().Add(lease))
+			if err != nil {
+				// 数据库暂时不可用时继续执行，租约过期前还有两次续约机会
+				r.logger.Warn("AI分析任务续约失败", zap.Int64("analysis_id", analysisID), zap.Error(err))
+				continue
+			}
+			if !held {
+				r.logger.Info("AI分析任务租约已丢失，中止执行", zap.Int64("analysis_id", analysisID))
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// tryAcquire 非阻塞地同时占用一个全局槽位和一个提供商槽位
+func (r *AIJobRunner) tryAcquire(providerSlot chan struct{}) bool {
+	select {
+	case r.workers <- struct{}{}:
+	default:
+		return false
+	}
+	select {
+	case providerSlot <- struct{}{}:
+		return true
+	default:
+		<-r.workers
+		return false
+	}
+}
+
+func (r *AIJobRunner) providerSlot(provider string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slot, ok := r.providerSlots[provider]
+	if !ok {
+		limit := r.cfg.AI.Analysis.ProviderConcurrency[provider]
+		if limit <= 0 || limit > cap(r.workers) {
+			limit = cap(r.workers)
+		}
+		slot = make(chan struct{}, limit)
+		r.providerSlots[provider] = slot
+	}
+	return slot
+}
+
+// nextRetryAt 任务失败后的重试时间，已达最大尝试次数时返回 nil 表示最终失败
+func (r *AIJobRunner) nextRetryAt(job *entity.AIAnalysis, now time.Time) *time.Time {
+	if job.Attempts >= job.MaxAttempts {
+		return nil
+	}
+	at := now.Add(retryBackoff(job.Attempts,
+		time.Duration(positiveOr(r.cfg.AI.Analysis.RetryBackoff, 30))*time.Second,
+		time.Duration(positiveOr(r.cfg.AI.Analysis.RetryBackoffMax, 1800))*time.Second,
+	))
+	return &at
+}
+
+func (r *AIJobRunner) leaseDuration() time.Duration {
+	return time.Duration(positiveOr(r.cfg.AI.Analysis.LeaseSeconds, 60)) * time.Second
+}
+
+// retryBackoff 第 attempt 次失败后的退避时长: base * 2^(attempt-1)，不超过 max
+func retryBackoff(attempt int, base, max time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= max {
+			return max
+		}
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+func positiveOr(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+)
+
+// leaseJobRepo 任务执行器测试用的仓储，held 为 false 时模拟任务已被取消或被其他worker接管
+type leaseJobRepo struct {
+	repository.AIAnalysisRepository
+
+	mu       sync.Mutex
+	held     bool
+	renewals int
+	failures []repository.AIAnalysisFailure
+	released []int64
+}
+
+func (r *leaseJobRepo) RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renewals++
+	return r.held, nil
+}
+
+func (r *leaseJobRepo) FailJob(ctx context.Context, id int64, owner string, failure repository.AIAnalysisFailure) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, failure)
+	return r.held, nil
+}
+
+func (r *leaseJobRepo) ReleaseJob(ctx context.Context, id int64, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, id)
+	return nil
+}
+
+func newTestJobRunner(repo repository.AIAnalysisRepository) *AIJobRunner {
+	cfg := &config.Config{}
+	cfg.AI.Analysis.Workers = 2
+	cfg.AI.Analysis.LeaseSeconds = 1
+	cfg.AI.Analysis.RetryBackoff = 30
+	cfg.AI.Analysis.RetryBackoffMax = 300
+	return NewAIJobRunner(repo, nil, nil, NewAnalysisProgressHub(), cfg, zap.NewNop())
+}
+
+func TestRetryBackoff(t *testing.T) {
+	base, max := 30*time.Second, 5*time.Minute
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{20, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryBackoff(tt.attempt, base, max), "attempt %d", tt.attempt)
+	}
+}
+
+func TestAIJobRunner_NextRetryAt(t *testing.T) {
+	runner := newTestJobRunner(&leaseJobRepo{})
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		attempts    int
+		maxAttempts int
+		want        *time.Time
+	}{
+		{"首次失败按基数退避", 1, 3, ptrTime(now.Add(30 * time.Second))},
+		{"第二次失败退避翻倍", 2, 3, ptrTime(now.Add(time.Minute))},
+		{"达到最大尝试次数后最终失败", 3, 3, nil},
+		{"接管后超过上限也最终失败", 4, 3, nil},
+		{"旧任务未设置上限时不重试", 1, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &entity.AIAnalysis{Attempts: tt.attempts, MaxAttempts: tt.maxAttempts}
+			assert.Equal(t, tt.want, runner.nextRetryAt(job, now))
+		})
+	}
+}
+
+func TestAIJobRunner_HeartbeatAbortsOnLostLease(t *testing.T) {
+	repo := &leaseJobRepo{held: false}
+	runner := newTestJobRunner(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go runner.heartbeat(ctx, cancel, 1, done)
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("租约丢失后心跳未退出")
+	}
+	assert.Error(t, ctx.Err(), "租约丢失后应中止任务")
+	assert.Equal(t, 1, repo.renewals)
+}
+
+func TestAIJobRunner_CancelAbortsRunningJob(t *testing.T) {
+	runner := newTestJobRunner(&leaseJobRepo{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.running[1] = cancel
+
+	runner.Cancel(2)
+	assert.NoError(t, ctx.Err(), "取消其他任务不影响本任务")
+
+	runner.Cancel(1)
+	assert.Error(t, ctx.Err())
+}
+
+func TestAIJobRunner_RunWritesBackOnlyWhileHoldingLease(t *testing.T) {
+	tests := []struct {
+		name       string
+		held       bool
+		wantStatus entity.AIAnalysisStatus
+	}{
+		{"持有租约时记录最终失败", true, entity.AIAnalysisStatusFailed},
+		{"任务已取消时丢弃结果", false, entity.AIAnalysisStatusAnalyzing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &leaseJobRepo{held: tt.held}
+			runner := newTestJobRunner(repo)
+			events, unsubscribe := runner.progressHub.Subscribe(1)
+			defer unsubscribe()
+
+			// 超过最大尝试次数的任务不调用模型，直接按最终失败处理
+			runner.wg.Add(1)
+			runner.run(&entity.AIAnalysis{ID: 1, Attempts: 4, MaxAttempts: 3}, func() {})
+
+			require.Len(t, repo.failures, 1)
+			assert.Nil(t, repo.failures[0].RetryAt)
+			assert.Empty(t, repo.released)
+
+			var last entity.AIAnalysisStatus
+			for len(events) > 0 {
+				last = (<-events).Status
+			}
+			assert.Equal(t, tt.wantStatus, last)
+		})
+	}
+}
+
+func TestAIJobRunner_RunReleasesJobOnShutdown(t *testing.T) {
+	repo := &leaseJobRepo{held: true}
+	runner := newTestJobRunner(repo)
+	runner.stop()
+
+	runner.wg.Add(1)
+	runner.run(&entity.AIAnalysis{ID: 1, Attempts: 4, MaxAttempts: 3}, func() {})
+
+	assert.Equal(t, []int64{1}, repo.released)
+	assert.Empty(t, repo.failures)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/model"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/snowflake"
+)
+
+// 用量报表参数
+const (
+	aiUsageReportDefaultDays = 7
+	aiUsageReportMaxDays     = 92
+	aiUsageReportDefaultTop  = 10
+	aiUsageReportMaxTop      = 100
+)
+
+// AIUsageService AI调用用量统计与每日配额服务
+// 用量由模型装饰器在每次调用后写入，这里负责配额校验和报表
+type AIUsageService struct {
+	usageRepo      repository.AIUsageRepository
+	aiAnalysisRepo repository.AIAnalysisRepository
+	providers      *model.ProviderChain
+	cfg            *config.Config
+	logger         *zap.Logger
+}
+
+// NewAIUsageService 创建AI用量服务
+func NewAIUsageService(
+	usageRepo repository.AIUsageRepository,
+	aiAnalysisRepo repository.AIAnalysisRepository,
+	providers *model.ProviderChain,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *AIUsageService {
+	return &AIUsageService{
+		usageRepo:      usageRepo,
+		aiAnalysisRepo: aiAnalysisRepo,
+		providers:      providers,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// NewUsageScope 为一次业务请求生成用量归属
+func NewUsageScope(operation, openID string, babyID int64) chain.UsageScope {
+	return chain.UsageScope{
+		RequestID: strconv.FormatInt(snowflake.Generate(), 10),
+		Operation: operation,
+		OpenID:    openID,
+		BabyID:    babyID,
+	}
+}
+
+// CheckQuota 检查当日剩余配额是否足够再发起 requests 次AI请求
+// openID 为空(定时任务)时只检查宝宝配额；已排队未执行的分析任务计入已用请求数
+func (s *AIUsageService) CheckQuota(ctx context.Context, openID string, babyID int64, requests int) error {
+	quota := s.cfg.AI.Quota
+	if !quota.Enabled {
+		return nil
+	}
+	since := startOfDay(time.Now()).UnixMilli()
+
+	if openID != "" && (quota.UserDailyRequests > 0 || quota.UserDailyTokens > 0) {
+		used, err := s.usageRepo.SumUsage(ctx, repository.AIUsageFilter{OpenID: openID, Since: since})
+		if err != nil {
+			return err
+		}
+		queued, err := s.aiAnalysisRepo.CountQueued(ctx, openID, 0)
+		if err != nil {
+			return err
+		}
+		if err := evaluateQuota("您", used.Requests+queued, used.TotalTokens, requests,
+			quota.UserDailyRequests, quota.UserDailyTokens); err != nil {
+			s.logger.Info("用户AI配额不足", zap.String("openid", openID), zap.Int64("tokens", used.TotalTokens))
+			return err
+		}
+	}
+
+	if babyID != 0 && (quota.BabyDailyRequests > 0 || quota.BabyDailyTokens > 0) {
+		used, err := s.usageRepo.SumUsage(ctx, repository.AIUsageFilter{BabyID: babyID, Since: since})
+		if err != nil {
+			return err
+		}
+		queued, err := s.aiAnalysisRepo.CountQueued(ctx, "", babyID)
+		if err != nil {
+			return err
+		}
+		if err := evaluateQuota("该宝宝", used.Requests+queued, used.TotalTokens, requests,
+			quota.BabyDailyRequests, quota.BabyDailyTokens); err != nil {
+			s.logger.Info("宝宝AI配额不足", zap.Int64("baby_id", babyID), zap.Int64("tokens", used.TotalTokens))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetReport 按日期范围生成用量报表
+func (s *AIUsageService) GetReport(ctx context.Context, req *dto.AIUsageReportRequest) (*dto.AIUsageReportResponse, error) {
+	loc := time.Local
+	today := startOfDay(time.Now())
+
+	end := today
+	if req.EndDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "结束日期格式错误，应为YYYY-MM-DD")
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -(aiUsageReportDefaultDays - 1))
+	if req.StartDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "开始日期格式错误，应为YYYY-MM-DD")
+		}
+		start = parsed
+	}
+	if start.After(end) {
+		return nil, errors.New(errors.ParamError, "开始日期不能晚于结束日期")
+	}
+	if end.Sub(start) >= aiUsageReportMaxDays*24*time.Hour {
+		return nil, errors.New(errors.ParamError, fmt.Sprintf("查询范围不能超过%d天", aiUsageReportMaxDays))
+	}
+
+	filter := repository.AIUsageFilter{
+		OpenID: req.OpenID,
+		Since:  start.UnixMilli(),
+		Until:  end.AddDate(0, 0, 1).UnixMilli(),
+	}
+	if req.BabyID != "" {
+		babyID, err := strconv.ParseInt(req.BabyID, 10, 64)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+		}
+		filter.BabyID = babyID
+	}
+
+	top := positiveOr(req.Top, aiUsageReportDefaultTop)
+	if top > aiUsageReportMaxTop {
+		top = aiUsageReportMaxTop
+	}
+
+	totals, err := s.usageRepo.SumUsage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	_, utcOffset := start.Zone()
+	daily, err := s.usageRepo.DailyUsage(ctx, filter, utcOffset)
+	if err != nil {
+		return nil, err
+	}
+	topUsers, err := s.usageRepo.TopUsers(ctx, filter, top)
+	if err != nil {
+		return nil, err
+	}
+	topBabies, err := s.usageRepo.TopBabies(ctx, filter, top)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := s.cfg.AI.Quota
+	response := &dto.AIUsageReportResponse{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Timezone:  loc.String(),
+		Totals:    toAIUsageSummaryDTO(totals),
+		Daily:     make([]dto.AIUsageDailyDTO, 0, len(daily)),
+		TopUsers:  make([]dto.AIUsageConsumerDTO, 0, len(topUsers)),
+		TopBabies: make([]dto.AIUsageConsumerDTO, 0, len(topBabies)),
+		Quota: dto.AIQuotaDTO{
+			Enabled:           quota.Enabled,
+			UserDailyRequests: quota.UserDailyRequests,
+			UserDailyTokens:   quota.UserDailyTokens,
+			BabyDailyRequests: quota.BabyDailyRequests,
+			BabyDailyTokens:   quota.BabyDailyTokens,
+		},
+	}
+	for _, row := range daily {
+		response.Daily = append(response.Daily, dto.AIUsageDailyDTO{
+			Date:              time.UnixMilli(row.DayStart).In(loc).Format("2006-01-02"),
+			Provider:          row.Provider,
+			Model:             row.Model,
+			AIUsageSummaryDTO: toAIUsageSummaryDTO(&row.AIUsageTotals),
+		})
+	}
+	for _, row := range topUsers {
+		response.TopUsers = append(response.TopUsers, dto.AIUsageConsumerDTO{
+			OpenID:            row.OpenID,
+			AIUsageSummaryDTO: toAIUsageSummaryDTO(&row.AIUsageTotals),
+		})
+	}
+	for _, row := range topBabies {
+		response.TopBabies = append(response.TopBabies, dto.AIUsageConsumerDTO{
+			BabyID:            strconv.FormatInt(row.BabyID, 10),
+			AIUsageSummaryDTO: toAIUsageSummaryDTO(&row.AIUsageTotals),
+		})
+	}
+
+	return response, nil
+}
+
+// GetProviderHealth 获取各AI模型提供商的健康与熔断状态
+func (s *AIUsageService) GetProviderHealth() *dto.AIProviderHealthResponse {
+	health := s.providers.Health()
+	response := &dto.AIProviderHealthResponse{
+		Providers: make([]dto.AIProviderHealthDTO, 0, len(health)),
+	}
+	for _, item := range health {
+		response.Providers = append(response.Providers, dto.AIProviderHealthDTO{
+			Provider:            item.Provider,
+			Model:               item.Model,
+			State:               item.State,
+			ConsecutiveFailures: item.ConsecutiveFailures,
+			TotalSuccesses:      item.TotalSuccesses,
+			TotalFailures:       item.TotalFailures,
+			LastError:           item.LastError,
+			LastSuccessAt:       item.LastSuccessAt,
+			LastFailureAt:       item.LastFailureAt,
+			OpenUntil:           item.OpenUntil,
+			TimeoutSeconds:      item.TimeoutSeconds,
+			RateLimitPerMinute:  item.RateLimitPerMinute,
+		})
+	}
+	return response
+}
+
+// evaluateQuota 判断在已用量基础上再发起 requests 次请求是否超出配额，limit 为0表示不限制
+// token 配额无法预估单次请求用量，已用量达到上限即拒绝
+func evaluateQuota(subject string, usedRequests, usedTokens int64, requests, requestLimit int, tokenLimit int64) error {
+	if requestLimit > 0 && usedRequests+int64(requests) > int64(requestLimit) {
+		remaining := int64(requestLimit) - usedRequests
+		if remaining < 0 {
+			remaining = 0
+		}
+		return errors.New(errors.QuotaExceeded,
+			fmt.Sprintf("%s今日的AI使用次数已达上限(%d次，剩余%d次)，请明天再试", subject, requestLimit, remaining))
+	}
+	if tokenLimit > 0 && usedTokens >= tokenLimit {
+		return errors.New(errors.QuotaExceeded,
+			fmt.Sprintf("%s今日的AI使用额度已用完，请明天再试", subject))
+	}
+	return nil
+}
+
+// startOfDay 返回当天零点
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// toAIUsageSummaryDTO 转换用量汇总，费用保留6位小数
+func toAIUsageSummaryDTO(totals *repository.AIUsageTotals) dto.AIUsageSummaryDTO {
+	return dto.AIUsageSummaryDTO{
+		Requests:         totals.Requests,
+		Calls:            totals.Calls,
+		FailedCalls:      totals.FailedCalls,
+		PromptTokens:     totals.PromptTokens,
+		CompletionTokens: totals.CompletionTokens,
+		TotalTokens:      totals.TotalTokens,
+		Cost:             math.Round(totals.Cost*1e6) / 1e6,
+		AvgLatencyMs:     roundToOneDecimal(totals.AvgLatencyMs),
+	}
+}
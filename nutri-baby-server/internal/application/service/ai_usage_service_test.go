@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+func TestEvaluateQuota(t *testing.T) {
+	// 不限制
+	assert.NoError(t, evaluateQuota("您", 100, 1e9, 5, 0, 0))
+
+	// 请求数: 已用 8 次，上限 10 次，还能再发起 2 次
+	assert.NoError(t, evaluateQuota("您", 8, 0, 2, 10, 0))
+	err := evaluateQuota("您", 8, 0, 3, 10, 0)
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.QuotaExceeded, appErr.Code)
+	assert.Contains(t, appErr.Message, "剩余2次")
+
+	// token: 已用量达到上限即拒绝
+	assert.NoError(t, evaluateQuota("该宝宝", 0, 9999, 1, 0, 10000))
+	assert.Error(t, evaluateQuota("该宝宝", 0, 10000, 1, 0, 10000))
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+)
+
+// 分析流事件名(SSE event 字段)
+const (
+	AnalysisStreamEventStatus   = "status"                    // 任务状态变化
+	AnalysisStreamEventThinking = chain.ProgressEventThinking // 模型开始新一轮推理
+	AnalysisStreamEventToolCall = chain.ProgressEventToolCall // 正在调用数据工具
+	AnalysisStreamEventDelta    = chain.ProgressEventDelta    // 模型输出的增量文本
+	AnalysisStreamEventResult   = "result"                    // 最终分析结果
+	AnalysisStreamEventError    = "error"                     // 分析失败或已取消
+)
+
+// AnalysisStreamEvent 分析进度流事件
+type AnalysisStreamEvent struct {
+	Event      string                   `json:"event"`
+	AnalysisID int64                    `json:"analysis_id"`
+	Status     entity.AIAnalysisStatus  `json:"status,omitempty"`
+	Progress   int                      `json:"progress,omitempty"`
+	Message    string                   `json:"message,omitempty"`
+	Iteration  int                      `json:"iteration,omitempty"`
+	Tool       string                   `json:"tool,omitempty"`
+	ToolLabel  string                   `json:"tool_label,omitempty"`
+	Text       string                   `json:"text,omitempty"`
+	Result     *entity.AIAnalysisResult `json:"result,omitempty"`
+	Timestamp  int64                    `json:"timestamp"`
+}
+
+// AnalysisProgressHub 分析进度事件的进程内广播
+// 只能收到本实例执行的任务事件，订阅方需要轮询数据库状态兜底(任务可能在其他实例执行)
+type AnalysisProgressHub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[chan AnalysisStreamEvent]struct{}
+}
+
+// NewAnalysisProgressHub 创建分析进度广播
+func NewAnalysisProgressHub() *AnalysisProgressHub {
+	return &AnalysisProgressHub{
+		subscribers: make(map[int64]map[chan AnalysisStreamEvent]struct{}),
+	}
+}
+
+// Subscribe 订阅任务事件，返回事件通道和取消订阅函数
+func (h *AnalysisProgressHub) Subscribe(analysisID int64) (<-chan AnalysisStreamEvent, func()) {
+	ch := make(chan AnalysisStreamEvent, 64)
+
+	h.mu.Lock()
+	if h.subscribers[analysisID] == nil {
+		h.subscribers[analysisID] = make(map[chan AnalysisStreamEvent]struct{})
+	}
+	h.subscribers[analysisID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[analysisID], ch)
+		if len(h.subscribers[analysisID]) == 0 {
+			delete(h.subscribers, analysisID)
+		}
+	}
+}
+
+// Publish 广播事件，订阅方消费过慢时丢弃事件(状态以数据库为准，不会丢失最终结果)
+func (h *AnalysisProgressHub) Publish(event AnalysisStreamEvent) {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixMilli()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[event.AnalysisID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
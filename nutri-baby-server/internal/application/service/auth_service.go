@@ -13,6 +13,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/wechat"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 )
 
 // AuthService 认证服务 (去家庭化架构)
@@ -97,6 +98,7 @@ func (s *AuthService) WechatLogin(ctx context.Context, req *dto.WechatLoginReque
 			NickName:      user.NickName,
 			AvatarURL:     user.AvatarURL,
 			DefaultBabyID: strconv.FormatInt(user.DefaultBabyID, 10),
+			Locale:        user.Locale,
 		},
 		IsNewUser: isNewUser, // 前端根据此字段判断是否需要引导创建宝宝
 	}, nil
@@ -133,6 +135,7 @@ func (s *AuthService) GetUserInfo(ctx context.Context, openID string) (*dto.User
 		NickName:      user.NickName,
 		AvatarURL:     user.AvatarURL,
 		DefaultBabyID: strconv.FormatInt(user.DefaultBabyID, 10),
+		Locale:        user.Locale,
 		CreateTime:    user.CreatedAt,
 		LastLoginTime: user.LastLoginTime,
 	}, nil
@@ -160,6 +163,24 @@ func (s *AuthService) SetDefaultBaby(ctx context.Context, openID string, req *dt
 	return nil
 }
 
+// SetLocale 设置语言偏好，为空时清除偏好(跟随客户端语言)
+func (s *AuthService) SetLocale(ctx context.Context, openID string, req *dto.SetLocaleRequest) (*dto.UserInfoDTO, error) {
+	locale := ""
+	if req.Locale != "" {
+		parsed, ok := i18n.Parse(req.Locale)
+		if !ok {
+			return nil, errors.New(errors.ParamError, "无效的语言")
+		}
+		locale = string(parsed)
+	}
+
+	if err := s.userRepo.UpdateLocale(ctx, openID, locale); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserInfo(ctx, openID)
+}
+
 // UpdateUserInfo 更新用户信息
 func (s *AuthService) UpdateUserInfo(ctx context.Context, openID string, req *dto.UpdateUserInfoRequest) (*dto.UserInfoDTO, error) {
 	// 查找用户
@@ -183,6 +204,7 @@ func (s *AuthService) UpdateUserInfo(ctx context.Context, openID string, req *dt
 		NickName:      user.NickName,
 		AvatarURL:     user.AvatarURL,
 		DefaultBabyID: strconv.FormatInt(user.DefaultBabyID, 10),
+		Locale:        user.Locale,
 		CreateTime:    user.CreatedAt,
 		LastLoginTime: user.LastLoginTime,
 	}, nil
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// MessageData 按接收人的语言构建订阅消息数据
+type MessageData func(locale i18n.Locale) map[string]any
+
+// BabyNotifier 向宝宝的管理员或全部协作者推送订阅消息(健康提醒、库存临期、周期报告等系统通知共用)
+type BabyNotifier struct {
+	collaboratorRepo repository.BabyCollaboratorRepository
+	userRepo         repository.UserRepository
+	subscribeService *SubscribeService
+	config           *config.Config
+	logger           *zap.Logger
+}
+
+// NewBabyNotifier 创建宝宝通知服务
+func NewBabyNotifier(
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	subscribeService *SubscribeService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *BabyNotifier {
+	return &BabyNotifier{
+		collaboratorRepo: collaboratorRepo,
+		userRepo:         userRepo,
+		subscribeService: subscribeService,
+		config:           cfg,
+		logger:           logger,
+	}
+}
+
+// NotifyAdmins 向宝宝的管理员发送订阅消息，返回成功发送数
+// 未配置模板或管理员未授权时静默跳过
+func (n *BabyNotifier) NotifyAdmins(ctx context.Context, babyID int64, templateType string, data MessageData, page string) int {
+	return n.notify(ctx, babyID, templateType, data, page, true)
+}
+
+// NotifyCaregivers 向宝宝的全部有效协作者发送订阅消息，返回成功发送数
+// 未配置模板或协作者未授权时静默跳过
+func (n *BabyNotifier) NotifyCaregivers(ctx context.Context, babyID int64, templateType string, data MessageData, page string) int {
+	return n.notify(ctx, babyID, templateType, data, page, false)
+}
+
+// notify 向协作者发送订阅消息，adminsOnly 为 true 时只发给管理员
+// 消息内容使用接收人保存的语言偏好，未设置时使用默认语言
+func (n *BabyNotifier) notify(ctx context.Context, babyID int64, templateType string, data MessageData, page string, adminsOnly bool) int {
+	templateID := n.config.Wechat.SubscribeTemplates[templateType]
+	if templateID == "" {
+		n.logger.Debug("未配置订阅消息模板，跳过通知",
+			zap.Int64("babyID", babyID),
+			zap.String("templateType", templateType))
+		return 0
+	}
+
+	collaborators, err := n.collaboratorRepo.FindByBabyID(ctx, babyID)
+	if err != nil {
+		n.logger.Error("获取宝宝协作者列表失败", zap.Int64("babyID", babyID), zap.Error(err))
+		return 0
+	}
+
+	sentCount := 0
+	for _, collaborator := range collaborators {
+		if collaborator.IsExpired() || (adminsOnly && !collaborator.IsAdmin()) {
+			continue
+		}
+
+		user, err := n.userRepo.FindByID(ctx, collaborator.UserID)
+		if err != nil {
+			n.logger.Warn("获取协作者用户信息失败", zap.Int64("userID", collaborator.UserID), zap.Error(err))
+			continue
+		}
+
+		hasAuth, err := n.subscribeService.CheckAuthorizationStatus(ctx, user.OpenID, templateType)
+		if err != nil || !hasAuth {
+			continue
+		}
+
+		sendReq := &dto.SendMessageRequest{
+			OpenID:       user.OpenID,
+			TemplateType: templateType,
+			TemplateID:   templateID,
+			Data:         data(i18n.Normalize(user.Locale)),
+			Page:         page,
+		}
+		if err := n.subscribeService.SendSubscribeMessage(ctx, sendReq); err != nil {
+			n.logger.Warn("发送订阅消息失败",
+				zap.String("openID", user.OpenID),
+				zap.String("templateType", templateType),
+				zap.Error(err))
+			continue
+		}
+		sentCount++
+	}
+
+	return sentCount
+}
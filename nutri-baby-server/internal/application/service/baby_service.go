@@ -11,6 +11,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
 	"github.com/wxlbd/nutri-baby-server/pkg/utils"
 	"go.uber.org/zap"
@@ -24,6 +25,7 @@ type BabyService struct {
 	userRepo               repository.UserRepository
 	vaccineScheduleService *VaccineScheduleService
 	wechatService          *WechatService
+	dataCache              *cache.AnalysisDataCache
 	logger                 *zap.Logger
 }
 
@@ -35,6 +37,7 @@ func NewBabyService(
 	userRepo repository.UserRepository,
 	vaccineScheduleService *VaccineScheduleService,
 	wechatService *WechatService,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *BabyService {
 	return &BabyService{
@@ -44,6 +47,7 @@ func NewBabyService(
 		userRepo:               userRepo,
 		vaccineScheduleService: vaccineScheduleService,
 		wechatService:          wechatService,
+		dataCache:              dataCache,
 		logger:                 logger,
 	}
 }
@@ -54,6 +58,9 @@ func (s *BabyService) CreateBaby(ctx context.Context, openID string, req *dto.Cr
 	if _, err := time.Parse(time.DateOnly, req.BirthDate); err != nil {
 		return nil, errors.New(errors.ParamError, "出生日期格式错误，应为YYYY-MM-DD")
 	}
+	if err := validateTimezone(req.Timezone); err != nil {
+		return nil, err
+	}
 
 	// 获取用户信息以获取UserID
 	user, err := s.userRepo.FindByOpenID(ctx, openID)
@@ -68,6 +75,7 @@ func (s *BabyService) CreateBaby(ctx context.Context, openID string, req *dto.Cr
 		Gender:    req.Gender,
 		BirthDate: req.BirthDate,
 		AvatarURL: req.AvatarURL,
+		Timezone:  req.Timezone,
 		UserID:    user.ID,
 	}
 
@@ -117,6 +125,7 @@ func (s *BabyService) CreateBaby(ctx context.Context, openID string, req *dto.Cr
 		BirthDate:  baby.BirthDate,
 		AvatarURL:  baby.AvatarURL,
 		CreatorID:  strconv.FormatInt(baby.UserID, 10),
+		Timezone:   baby.Timezone,
 		CreateTime: baby.CreatedAt,
 		UpdateTime: baby.UpdatedAt,
 	}, nil
@@ -145,6 +154,7 @@ func (s *BabyService) GetUserBabies(ctx context.Context, openID string) ([]dto.B
 			BirthDate:  baby.BirthDate,
 			AvatarURL:  baby.AvatarURL,
 			CreatorID:  strconv.FormatInt(baby.UserID, 10),
+			Timezone:   baby.Timezone,
 			CreateTime: baby.CreatedAt,
 			UpdateTime: baby.UpdatedAt,
 		})
@@ -179,6 +189,7 @@ func (s *BabyService) GetBabyDetail(ctx context.Context, babyID, openID string)
 		BirthDate:  baby.BirthDate,
 		AvatarURL:  baby.AvatarURL,
 		CreatorID:  strconv.FormatInt(baby.UserID, 10),
+		Timezone:   baby.Timezone,
 		CreateTime: baby.CreatedAt,
 		UpdateTime: baby.UpdatedAt,
 	}, nil
@@ -231,8 +242,29 @@ func (s *BabyService) UpdateBaby(ctx context.Context, babyID, openID string, req
 	if req.AvatarURL != "" {
 		baby.AvatarURL = req.AvatarURL
 	}
+	if req.Timezone != "" {
+		if err := validateTimezone(req.Timezone); err != nil {
+			return err
+		}
+		baby.Timezone = req.Timezone
+	}
 
-	return s.babyRepo.Update(ctx, baby)
+	if err := s.babyRepo.Update(ctx, baby); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, baby.ID)
+	return nil
+}
+
+// validateTimezone 校验IANA时区名称，空值表示使用服务器时区
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.New(errors.ParamError, "时区格式错误，应为IANA时区名称，如 Asia/Shanghai")
+	}
+	return nil
 }
 
 // DeleteBaby 删除宝宝
@@ -258,7 +290,11 @@ func (s *BabyService) DeleteBaby(ctx context.Context, babyID, openID string) err
 		return errors.New(errors.PermissionDenied, "只有管理员可以删除宝宝")
 	}
 
-	return s.babyRepo.Delete(ctx, babyIDInt64)
+	if err := s.babyRepo.Delete(ctx, babyIDInt64); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, babyIDInt64)
+	return nil
 }
 
 // GetCollaborators 获取宝宝的协作者列表
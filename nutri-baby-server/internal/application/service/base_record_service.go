@@ -8,6 +8,7 @@ import (
 
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 )
 
 // BaseRecordService 基础记录服务，提供所有记录服务的共享逻辑
@@ -33,6 +34,16 @@ func NewBaseRecordService(
 	}
 }
 
+// RequesterLocale 发起用户的语言：优先使用保存的语言偏好，未设置时使用请求声明的语言
+func (s *BaseRecordService) RequesterLocale(ctx context.Context, openID string) i18n.Locale {
+	if openID != "" {
+		if user, err := s.userRepo.FindByOpenID(ctx, openID); err == nil && user.Locale != "" {
+			return i18n.Normalize(user.Locale)
+		}
+	}
+	return i18n.FromContext(ctx)
+}
+
 // CheckBabyAccess 检查用户对宝宝的访问权限 (去家庭化架构)
 func (s *BaseRecordService) CheckBabyAccess(ctx context.Context, babyID, openID string) error {
 	// 转换babyID from string to int64
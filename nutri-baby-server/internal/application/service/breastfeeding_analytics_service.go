@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+const (
+	// clusterFeedingMaxGap 相邻两次喂养开始时间间隔不超过该值视为同一密集时段
+	clusterFeedingMaxGap = 90 * time.Minute
+	// clusterFeedingMinCount 密集喂养时段的最少喂养次数
+	clusterFeedingMinCount = 3
+	// breastfeedingPageSize 分页拉取记录时的单页大小
+	breastfeedingPageSize = 500
+)
+
+// BreastfeedingAnalyticsService 母乳喂养分析服务
+type BreastfeedingAnalyticsService struct {
+	*BaseRecordService
+	feedingRecordRepo repository.FeedingRecordRepository
+}
+
+// NewBreastfeedingAnalyticsService 创建母乳喂养分析服务
+func NewBreastfeedingAnalyticsService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	feedingRecordRepo repository.FeedingRecordRepository,
+	logger *zap.Logger,
+) *BreastfeedingAnalyticsService {
+	return &BreastfeedingAnalyticsService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		feedingRecordRepo: feedingRecordRepo,
+	}
+}
+
+// GetBreastfeedingAnalytics 获取母乳喂养分析数据
+func (s *BreastfeedingAnalyticsService) GetBreastfeedingAnalytics(ctx context.Context, openID string, req *dto.BreastfeedingAnalyticsRequest) (*dto.BreastfeedingAnalyticsResponse, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	if req.EndDate < req.StartDate {
+		return nil, errors.New(errors.ParamError, "结束时间不能早于开始时间")
+	}
+
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.findBreastRecords(ctx, babyIDInt64, req.StartDate, req.EndDate)
+	if err != nil {
+		s.logger.Error("查询母乳喂养记录失败", zap.Int64("babyId", babyIDInt64), zap.Error(err))
+		return nil, err
+	}
+
+	// 按小时热力图与每日平衡按宝宝所在时区分桶
+	return analyzeBreastfeeding(records, baby.Location()), nil
+}
+
+// findBreastRecords 分页拉取时间范围内全部母乳喂养记录
+func (s *BreastfeedingAnalyticsService) findBreastRecords(ctx context.Context, babyID int64, startTime, endTime int64) ([]*entity.FeedingRecord, error) {
+	var all []*entity.FeedingRecord
+	for page := 1; ; page++ {
+		records, total, err := s.feedingRecordRepo.FindByBabyIDAndType(ctx, babyID, entity.FeedingTypeBreast, startTime, endTime, page, breastfeedingPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+		if len(records) < breastfeedingPageSize || int64(len(all)) >= total {
+			break
+		}
+	}
+	return all, nil
+}
+
+// breastFeed 归一化后的单次母乳喂养
+type breastFeed struct {
+	time     int64
+	duration int
+	left     int
+	right    int
+	lastSide string // 结束时的喂养侧
+}
+
+// analyzeBreastfeeding 根据母乳喂养记录计算分析结果
+func analyzeBreastfeeding(records []*entity.FeedingRecord, loc *time.Location) *dto.BreastfeedingAnalyticsResponse {
+	feeds := make([]breastFeed, 0, len(records))
+	for _, record := range records {
+		feeds = append(feeds, normalizeBreastFeed(record))
+	}
+	sort.Slice(feeds, func(i, j int) bool { return feeds[i].time < feeds[j].time })
+
+	response := &dto.BreastfeedingAnalyticsResponse{
+		DailyBalance:    make([]*dto.DailyBreastBalanceItem, 0),
+		ClusterFeedings: make([]*dto.ClusterFeedingItem, 0),
+		HourlyHeatmap:   make([]*dto.HourlyBreastfeedingItem, 24),
+	}
+	for hour := range response.HourlyHeatmap {
+		response.HourlyHeatmap[hour] = &dto.HourlyBreastfeedingItem{Hour: hour}
+	}
+
+	dailyIndex := make(map[string]*dto.DailyBreastBalanceItem)
+	summary := &response.Summary
+	for _, feed := range feeds {
+		summary.TotalCount++
+		summary.TotalDuration += feed.duration
+		summary.LeftDuration += feed.left
+		summary.RightDuration += feed.right
+
+		t := time.UnixMilli(feed.time).In(loc)
+		date := t.Format("2006-01-02")
+		item, ok := dailyIndex[date]
+		if !ok {
+			item = &dto.DailyBreastBalanceItem{Date: date}
+			dailyIndex[date] = item
+			response.DailyBalance = append(response.DailyBalance, item)
+		}
+		item.Count++
+		item.LeftDuration += feed.left
+		item.RightDuration += feed.right
+
+		heat := response.HourlyHeatmap[t.Hour()]
+		heat.Count++
+		heat.TotalDuration += feed.duration
+	}
+
+	for _, item := range response.DailyBalance {
+		item.LeftRatio = sideRatio(item.LeftDuration, item.RightDuration)
+	}
+	summary.LeftRatio = sideRatio(summary.LeftDuration, summary.RightDuration)
+	if summary.TotalCount > 0 {
+		summary.AvgSessionDuration = summary.TotalDuration / summary.TotalCount
+	}
+	if len(feeds) > 1 {
+		span := feeds[len(feeds)-1].time - feeds[0].time
+		summary.AvgInterval = int(span / int64(len(feeds)-1) / int64(time.Minute/time.Millisecond))
+	}
+
+	response.ClusterFeedings = detectClusterFeedings(feeds)
+	response.NextSide = suggestNextSide(feeds)
+
+	return response
+}
+
+// normalizeBreastFeed 将喂养记录归一化为左右侧时长(兼容旧字段)
+func normalizeBreastFeed(record *entity.FeedingRecord) breastFeed {
+	var detail dto.FeedingDetail
+	if record.Detail != nil {
+		if detailBytes, err := json.Marshal(record.Detail); err == nil {
+			_ = json.Unmarshal(detailBytes, &detail)
+		}
+	}
+	detail.Type = entity.FeedingTypeBreast
+	if detail.Duration == 0 && detail.LeftDuration == nil && detail.RightDuration == nil &&
+		detail.LeftTime == 0 && detail.RightTime == 0 && len(detail.Sessions) == 0 {
+		detail.Duration = record.Duration
+	}
+
+	breast := detail.ToBreastFeeding()
+	feed := breastFeed{
+		time:     record.Time,
+		duration: breast.Duration,
+		lastSide: breast.Side,
+	}
+
+	if breast.LeftDuration != nil || breast.RightDuration != nil {
+		if breast.LeftDuration != nil {
+			feed.left = *breast.LeftDuration
+		}
+		if breast.RightDuration != nil {
+			feed.right = *breast.RightDuration
+		}
+	} else if breast.Side == "both" {
+		// 未记录左右拆分时按两侧平均计算
+		feed.left = breast.Duration / 2
+		feed.right = breast.Duration - feed.left
+	}
+	if feed.duration == 0 {
+		feed.duration = feed.left + feed.right
+	}
+
+	// 多段式记录以最后一段的喂养侧作为结束侧
+	if n := len(breast.Sessions); n > 0 && breast.Sessions[n-1].Side != "" {
+		feed.lastSide = breast.Sessions[n-1].Side
+	}
+
+	return feed
+}
+
+// detectClusterFeedings 识别密集喂养时段
+func detectClusterFeedings(feeds []breastFeed) []*dto.ClusterFeedingItem {
+	clusters := make([]*dto.ClusterFeedingItem, 0)
+	maxGap := clusterFeedingMaxGap.Milliseconds()
+
+	start := 0
+	for i := 1; i <= len(feeds); i++ {
+		if i < len(feeds) && feeds[i].time-feeds[i-1].time <= maxGap {
+			continue
+		}
+		if i-start >= clusterFeedingMinCount {
+			cluster := &dto.ClusterFeedingItem{
+				StartTime: feeds[start].time,
+				EndTime:   feeds[i-1].time,
+				FeedCount: i - start,
+			}
+			for _, feed := range feeds[start:i] {
+				cluster.TotalDuration += feed.duration
+			}
+			clusters = append(clusters, cluster)
+		}
+		start = i
+	}
+
+	return clusters
+}
+
+// suggestNextSide 根据上次喂养建议下次开始的喂养侧
+func suggestNextSide(feeds []breastFeed) *dto.NextBreastSide {
+	if len(feeds) == 0 {
+		return &dto.NextBreastSide{Side: "left", Reason: "暂无母乳喂养记录，可从任意一侧开始"}
+	}
+
+	last := feeds[len(feeds)-1]
+	lastTime := last.time
+	next := &dto.NextBreastSide{LastSide: last.lastSide, LastFeedingTime: &lastTime}
+
+	switch last.lastSide {
+	case "left":
+		next.Side = "right"
+		next.Reason = "上次以左侧结束，建议本次从右侧开始"
+	case "right":
+		next.Side = "left"
+		next.Reason = "上次以右侧结束，建议本次从左侧开始"
+	default:
+		// 两侧都喂过时，从上次喂得较少的一侧开始
+		if last.left <= last.right {
+			next.Side = "left"
+		} else {
+			next.Side = "right"
+		}
+		next.Reason = "上次两侧都有喂养，建议从喂养时间较短的一侧开始"
+	}
+
+	return next
+}
+
+// sideRatio 计算左侧时长占比，保留两位小数
+func sideRatio(left, right int) float64 {
+	if left+right == 0 {
+		return 0
+	}
+	return math.Round(float64(left)/float64(left+right)*100) / 100
+}
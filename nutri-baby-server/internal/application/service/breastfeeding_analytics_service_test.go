@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestAnalyzeBreastfeeding_LegacyAndCluster(t *testing.T) {
+	base := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC).UnixMilli()
+	minute := int64(time.Minute / time.Millisecond)
+
+	records := []*entity.FeedingRecord{
+		// 旧数据: 只有 breastSide/leftTime/rightTime
+		{Time: base, FeedingType: entity.FeedingTypeBreast, Detail: entity.FeedingDetail{
+			"type": "breast", "breastSide": "both", "leftTime": float64(600), "rightTime": float64(300),
+		}},
+		// 旧数据: 只有单侧和记录上的 duration
+		{Time: base + 60*minute, FeedingType: entity.FeedingTypeBreast, Duration: 480, Detail: entity.FeedingDetail{
+			"type": "breast", "breastSide": "left",
+		}},
+		// 新数据: 多段式记录, 以右侧结束
+		{Time: base + 120*minute, FeedingType: entity.FeedingTypeBreast, Detail: entity.FeedingDetail{
+			"type": "breast",
+			"sessions": []any{
+				map[string]any{"side": "left", "startTime": float64(base + 120*minute), "duration": float64(300)},
+				map[string]any{"side": "right", "startTime": float64(base + 126*minute), "duration": float64(420)},
+			},
+		}},
+		// 间隔较远的一次, 不属于密集时段
+		{Time: base + 300*minute, FeedingType: entity.FeedingTypeBreast, Detail: entity.FeedingDetail{
+			"type": "breast", "side": "right", "duration": float64(600), "rightDuration": float64(600), "leftDuration": float64(0),
+		}},
+	}
+
+	result := analyzeBreastfeeding(records, time.UTC)
+
+	assert.Equal(t, 4, result.Summary.TotalCount)
+	assert.Equal(t, 600+480+300, result.Summary.LeftDuration)
+	assert.Equal(t, 300+420+600, result.Summary.RightDuration)
+	assert.Equal(t, (900+480+720+600)/4, result.Summary.AvgSessionDuration)
+
+	assert.Len(t, result.ClusterFeedings, 1)
+	assert.Equal(t, 3, result.ClusterFeedings[0].FeedCount)
+
+	assert.Equal(t, "left", result.NextSide.Side)
+	assert.Equal(t, "right", result.NextSide.LastSide)
+
+	assert.Len(t, result.HourlyHeatmap, 24)
+	assert.Equal(t, 1, result.HourlyHeatmap[18].Count)
+	assert.Len(t, result.DailyBalance, 1)
+}
+
+func TestAnalyzeBreastfeeding_BucketsInBabyTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	// UTC 18:00 对应上海次日 02:00
+	base := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC).UnixMilli()
+	records := []*entity.FeedingRecord{
+		{Time: base, FeedingType: entity.FeedingTypeBreast, Detail: entity.FeedingDetail{
+			"type": "breast", "side": "left", "duration": float64(600), "leftDuration": float64(600), "rightDuration": float64(0),
+		}},
+	}
+
+	result := analyzeBreastfeeding(records, loc)
+
+	assert.Equal(t, 1, result.HourlyHeatmap[2].Count)
+	assert.Equal(t, 0, result.HourlyHeatmap[18].Count)
+	assert.Len(t, result.DailyBalance, 1)
+	assert.Equal(t, "2024-05-02", result.DailyBalance[0].Date)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+// CryingRecordService 哭闹记录服务
+type CryingRecordService struct {
+	*BaseRecordService
+	cryingRecordRepo repository.CryingRecordRepository
+	dataCache        *cache.AnalysisDataCache
+}
+
+// NewCryingRecordService 创建哭闹记录服务
+func NewCryingRecordService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	cryingRecordRepo repository.CryingRecordRepository,
+	dataCache *cache.AnalysisDataCache,
+	logger *zap.Logger,
+) *CryingRecordService {
+	return &CryingRecordService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		cryingRecordRepo:  cryingRecordRepo,
+		dataCache:         dataCache,
+	}
+}
+
+// CreateCryingRecord 创建哭闹记录
+func (s *CryingRecordService) CreateCryingRecord(ctx context.Context, openID string, req *dto.CreateCryingRecordRequest) (*dto.CryingRecordDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := req.StartTime
+	if startTime == 0 {
+		startTime = time.Now().UnixMilli()
+	}
+	intensity := req.Intensity
+	if intensity == "" {
+		intensity = entity.CryingIntensityModerate
+	}
+	trigger := req.Trigger
+	if trigger == "" {
+		trigger = entity.CryingTriggerUnknown
+	}
+
+	record := &entity.CryingRecord{
+		BabyID:          babyIDInt64,
+		StartTime:       startTime,
+		Duration:        req.Duration,
+		Type:            req.Type,
+		Intensity:       intensity,
+		Trigger:         trigger,
+		Soothing:        req.Soothing,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	if err := s.cryingRecordRepo.Create(ctx, record); err != nil {
+		s.logger.Error("保存哭闹记录失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+
+	return toCryingRecordDTO(record), nil
+}
+
+// GetCryingRecords 获取哭闹记录列表
+func (s *CryingRecordService) GetCryingRecords(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.CryingRecordDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	records, total, err := s.cryingRecordRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*dto.CryingRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toCryingRecordDTO(record))
+	}
+	return result, total, nil
+}
+
+// DeleteCryingRecord 删除哭闹记录
+func (s *CryingRecordService) DeleteCryingRecord(ctx context.Context, openID, recordID string) error {
+	recordIDInt64, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	record, err := s.cryingRecordRepo.FindByID(ctx, recordIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(record.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	if err := s.cryingRecordRepo.Delete(ctx, recordIDInt64); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+	return nil
+}
+
+// toCryingRecordDTO 转换哭闹记录DTO
+func toCryingRecordDTO(record *entity.CryingRecord) *dto.CryingRecordDTO {
+	return &dto.CryingRecordDTO{
+		RecordID:   strconv.FormatInt(record.ID, 10),
+		BabyID:     strconv.FormatInt(record.BabyID, 10),
+		StartTime:  record.StartTime,
+		Duration:   record.Duration,
+		Type:       record.Type,
+		Intensity:  record.Intensity,
+		Trigger:    record.Trigger,
+		Soothing:   utils.DerefString(record.Soothing),
+		Note:       utils.DerefString(record.Note),
+		CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime: record.CreatedAt,
+	}
+}
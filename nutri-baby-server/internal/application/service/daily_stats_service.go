@@ -4,14 +4,23 @@ import (
 	"context"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
 )
 
+const (
+	// intakePageSize 奶量统计分页拉取喂养记录的单页大小
+	intakePageSize = 500
+	// dailyStatsMaxDays 单次按日统计允许的最大时间跨度(天)
+	dailyStatsMaxDays = 365
+)
+
 // DailyStatsService 按日统计服务
 type DailyStatsService struct {
 	*BaseRecordService
@@ -19,6 +28,8 @@ type DailyStatsService struct {
 	sleepRecordRepo   repository.SleepRecordRepository
 	diaperRecordRepo  repository.DiaperRecordRepository
 	growthRecordRepo  repository.GrowthRecordRepository
+	temperatureRepo   repository.TemperatureRecordRepository
+	symptomRepo       repository.SymptomRecordRepository
 }
 
 // NewDailyStatsService 创建按日统计服务
@@ -30,6 +41,8 @@ func NewDailyStatsService(
 	sleepRecordRepo repository.SleepRecordRepository,
 	diaperRecordRepo repository.DiaperRecordRepository,
 	growthRecordRepo repository.GrowthRecordRepository,
+	temperatureRepo repository.TemperatureRecordRepository,
+	symptomRepo repository.SymptomRecordRepository,
 	logger *zap.Logger,
 ) *DailyStatsService {
 	return &DailyStatsService{
@@ -38,6 +51,8 @@ func NewDailyStatsService(
 		sleepRecordRepo:   sleepRecordRepo,
 		diaperRecordRepo:  diaperRecordRepo,
 		growthRecordRepo:  growthRecordRepo,
+		temperatureRepo:   temperatureRepo,
+		symptomRepo:       symptomRepo,
 	}
 }
 
@@ -54,6 +69,10 @@ func (s *DailyStatsService) GetDailyStats(ctx context.Context, openID string, re
 		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
 	}
 
+	if err := checkStatsRange(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+
 	// 解析统计类型
 	types := parseStatsTypes(req.Types)
 
@@ -99,6 +118,36 @@ func (s *DailyStatsService) GetDailyStats(ctx context.Context, openID string, re
 		response.Growth = growthStats
 	}
 
+	// 获取奶量充足度
+	if contains(types, "intake") {
+		intakeStats, err := s.getIntakeDailyStats(ctx, babyIDInt64, req.StartDate, req.EndDate)
+		if err != nil {
+			s.logger.Error("获取奶量充足度统计失败", zap.Error(err))
+			return nil, err
+		}
+		response.Intake = intakeStats
+	}
+
+	// 获取体温统计
+	if contains(types, "temperature") {
+		temperatureStats, err := s.getTemperatureDailyStats(ctx, babyIDInt64, req.StartDate, req.EndDate)
+		if err != nil {
+			s.logger.Error("获取体温按日统计失败", zap.Error(err))
+			return nil, err
+		}
+		response.Temperature = temperatureStats
+	}
+
+	// 获取症状统计
+	if contains(types, "symptom") {
+		symptomStats, err := s.getSymptomDailyStats(ctx, babyIDInt64, req.StartDate, req.EndDate)
+		if err != nil {
+			s.logger.Error("获取症状按日统计失败", zap.Error(err))
+			return nil, err
+		}
+		response.Symptom = symptomStats
+	}
+
 	return response, nil
 }
 
@@ -182,10 +231,97 @@ func (s *DailyStatsService) getGrowthDailyStats(ctx context.Context, babyID int6
 	return result, nil
 }
 
+// getTemperatureDailyStats 获取体温按日统计
+func (s *DailyStatsService) getTemperatureDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*dto.DailyTemperatureStatsItem, error) {
+	records, err := s.temperatureRepo.GetDailyStats(ctx, babyID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.DailyTemperatureStatsItem, 0, len(records))
+	for _, record := range records {
+		result = append(result, &dto.DailyTemperatureStatsItem{
+			Date:           record.Date,
+			MaxTemperature: record.MaxTemperature,
+			FeverCount:     record.FeverCount,
+			TotalCount:     record.TotalCount,
+		})
+	}
+
+	return result, nil
+}
+
+// getSymptomDailyStats 获取症状按日统计
+func (s *DailyStatsService) getSymptomDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*dto.DailySymptomStatsItem, error) {
+	records, err := s.symptomRepo.GetDailyStats(ctx, babyID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.DailySymptomStatsItem, 0, len(records))
+	for _, record := range records {
+		result = append(result, &dto.DailySymptomStatsItem{
+			Date:       record.Date,
+			Symptom:    record.Symptom,
+			TotalCount: record.TotalCount,
+		})
+	}
+
+	return result, nil
+}
+
+// getIntakeDailyStats 获取每日奶量充足度(ml/kg/day 与喂奶次数对比月龄参考范围)
+func (s *DailyStatsService) getIntakeDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*dto.DailyIntakeStatsItem, error) {
+	baby, err := s.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 出生日期和按天汇总都按宝宝所在时区计算
+	loc := baby.Location()
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, loc)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "宝宝出生日期格式错误")
+	}
+
+	// 体重插值需要范围外的测量点，因此取全部成长记录
+	growthRecords, _, err := s.growthRecordRepo.FindByBabyID(ctx, babyID, 0, 0, 1, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedingRecords []*entity.FeedingRecord
+	for page := 1; ; page++ {
+		records, total, err := s.feedingRecordRepo.FindByBabyID(ctx, babyID, startDate, endDate, page, intakePageSize)
+		if err != nil {
+			return nil, err
+		}
+		feedingRecords = append(feedingRecords, records...)
+		if len(records) < intakePageSize || int64(len(feedingRecords)) >= total {
+			break
+		}
+	}
+
+	points := buildWeightPoints(growthRecords, baby)
+	return buildIntakeSeries(feedingRecords, points, birthDate, startDate, endDate, loc), nil
+}
+
+// checkStatsRange 校验统计时间范围, 跨度不能超过 dailyStatsMaxDays 天
+func checkStatsRange(startDate, endDate int64) error {
+	if endDate < startDate {
+		return errors.New(errors.ParamError, "结束时间不能早于开始时间")
+	}
+	if time.Duration(endDate-startDate)*time.Millisecond > dailyStatsMaxDays*24*time.Hour {
+		return errors.New(errors.ParamError, "统计时间范围不能超过365天")
+	}
+	return nil
+}
+
 // parseStatsTypes 解析统计类型
 func parseStatsTypes(types string) []string {
 	if types == "" {
-		return []string{"feeding", "sleep", "diaper", "growth"} // 默认全部
+		// 默认不含 intake: 奶量充足度需要拉取区间内全部喂养记录，按需通过 types 开启
+		return []string{"feeding", "sleep", "diaper", "growth", "temperature", "symptom"}
 	}
 	return strings.Split(strings.ReplaceAll(types, " ", ""), ",")
 }
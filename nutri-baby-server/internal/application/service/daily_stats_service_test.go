@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+func TestParseStatsTypes_IntakeIsOptIn(t *testing.T) {
+	assert.NotContains(t, parseStatsTypes(""), "intake")
+	assert.Contains(t, parseStatsTypes(""), "feeding")
+	assert.Equal(t, []string{"feeding", "intake"}, parseStatsTypes("feeding, intake"))
+}
+
+func TestCheckStatsRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	day := (24 * time.Hour).Milliseconds()
+
+	tests := []struct {
+		name    string
+		end     int64
+		wantErr bool
+	}{
+		{"同一天", start, false},
+		{"正好365天", start + 365*day, false},
+		{"超过365天", start + 365*day + 1, true},
+		{"结束早于开始", start - 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStatsRange(start, tt.end)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok)
+			assert.Equal(t, errors.ParamError, appErr.Code)
+		})
+	}
+}
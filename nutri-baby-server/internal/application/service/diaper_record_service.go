@@ -10,12 +10,15 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 )
 
 // DiaperRecordService 尿布记录服务
 type DiaperRecordService struct {
 	*BaseRecordService
-	diaperRecordRepo repository.DiaperRecordRepository
+	diaperRecordRepo       repository.DiaperRecordRepository
+	diaperScreeningService *DiaperScreeningService
+	dataCache              *cache.AnalysisDataCache
 }
 
 // NewDiaperRecordService 创建尿布记录服务
@@ -24,11 +27,15 @@ func NewDiaperRecordService(
 	collaboratorRepo repository.BabyCollaboratorRepository,
 	userRepo repository.UserRepository,
 	diaperRecordRepo repository.DiaperRecordRepository,
+	diaperScreeningService *DiaperScreeningService,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *DiaperRecordService {
 	return &DiaperRecordService{
-		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
-		diaperRecordRepo:  diaperRecordRepo,
+		BaseRecordService:      NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		diaperRecordRepo:       diaperRecordRepo,
+		diaperScreeningService: diaperScreeningService,
+		dataCache:              dataCache,
 	}
 }
 
@@ -60,12 +67,22 @@ func (s *DiaperRecordService) CreateDiaperRecord(ctx context.Context, openID str
 		note = &req.Note
 	}
 
+	var poopColor, poopTexture *string
+	if req.PooColor != "" {
+		poopColor = &req.PooColor
+	}
+	if req.PooTexture != "" {
+		poopTexture = &req.PooTexture
+	}
+
 	record := &entity.DiaperRecord{
-		BabyID:    babyIDInt64,
-		Time:      changeTime,
-		Type:      req.DiaperType,
-		Note:      note,
-		CreatedBy: user.ID,
+		BabyID:      babyIDInt64,
+		Time:        changeTime,
+		Type:        req.DiaperType,
+		PoopColor:   poopColor,
+		PoopTexture: poopTexture,
+		Note:        note,
+		CreatedBy:   user.ID,
 		// ID auto-generated by snowflake
 		// CreatedAt/UpdatedAt auto-set by GORM
 	}
@@ -73,21 +90,11 @@ func (s *DiaperRecordService) CreateDiaperRecord(ctx context.Context, openID str
 	if err := s.diaperRecordRepo.Create(ctx, record); err != nil {
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
-	resultNote := ""
-	if record.Note != nil {
-		resultNote = *record.Note
-	}
+	s.screenAsync(record.BabyID)
 
-	return &dto.DiaperRecordDTO{
-		RecordID:   strconv.FormatInt(record.ID, 10),
-		BabyID:     strconv.FormatInt(record.BabyID, 10),
-		DiaperType: record.Type,
-		Note:       resultNote,
-		ChangeTime: record.Time,
-		CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
-		CreateTime: record.CreatedAt,
-	}, nil
+	return toDiaperRecordDTO(record), nil
 }
 
 // GetDiaperRecords 获取尿布记录列表
@@ -116,20 +123,7 @@ func (s *DiaperRecordService) GetDiaperRecords(ctx context.Context, openID strin
 
 	result := make([]dto.DiaperRecordDTO, 0, len(records))
 	for _, record := range records {
-		note := ""
-		if record.Note != nil {
-			note = *record.Note
-		}
-
-		result = append(result, dto.DiaperRecordDTO{
-			RecordID:   strconv.FormatInt(record.ID, 10),
-			BabyID:     strconv.FormatInt(record.BabyID, 10),
-			DiaperType: record.Type,
-			Note:       note,
-			ChangeTime: record.Time,
-			CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
-			CreateTime: record.CreatedAt,
-		})
+		result = append(result, *toDiaperRecordDTO(record))
 	}
 
 	return result, total, nil
@@ -157,20 +151,7 @@ func (s *DiaperRecordService) GetDiaperRecordById(ctx context.Context, openID, r
 		return nil, err
 	}
 
-	note := ""
-	if record.Note != nil {
-		note = *record.Note
-	}
-
-	return &dto.DiaperRecordDTO{
-		RecordID:   strconv.FormatInt(record.ID, 10),
-		BabyID:     strconv.FormatInt(record.BabyID, 10),
-		DiaperType: record.Type,
-		Note:       note,
-		ChangeTime: record.Time,
-		CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
-		CreateTime: record.CreatedAt,
-	}, nil
+	return toDiaperRecordDTO(record), nil
 }
 
 // UpdateDiaperRecord 更新尿布记录
@@ -208,6 +189,16 @@ func (s *DiaperRecordService) UpdateDiaperRecord(ctx context.Context, openID, re
 		updated = true
 	}
 
+	if req.PooColor != nil {
+		record.PoopColor = req.PooColor
+		updated = true
+	}
+
+	if req.PooTexture != nil {
+		record.PoopTexture = req.PooTexture
+		updated = true
+	}
+
 	if req.Note != nil {
 		record.Note = req.Note
 		updated = true
@@ -227,11 +218,14 @@ func (s *DiaperRecordService) UpdateDiaperRecord(ctx context.Context, openID, re
 			zap.Error(err))
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("尿布记录更新成功",
 		zap.String("recordID", strconv.FormatInt(record.ID, 10)),
 		zap.String("babyID", strconv.FormatInt(record.BabyID, 10)))
 
+	s.screenAsync(record.BabyID)
+
 	// 返回更新后的记录
 	return s.GetDiaperRecordById(ctx, openID, recordID)
 }
@@ -265,6 +259,7 @@ func (s *DiaperRecordService) DeleteDiaperRecord(ctx context.Context, openID, re
 			zap.Error(err))
 		return err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("尿布记录删除成功",
 		zap.String("recordID", recordID),
@@ -272,3 +267,45 @@ func (s *DiaperRecordService) DeleteDiaperRecord(ctx context.Context, openID, re
 
 	return nil
 }
+
+// screenAsync 异步执行排泄健康筛查(不影响记录写入结果)
+func (s *DiaperRecordService) screenAsync(babyID int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.diaperScreeningService.ScreenAndNotify(ctx, babyID); err != nil {
+			s.logger.Warn("排泄健康筛查失败", zap.Int64("babyID", babyID), zap.Error(err))
+		}
+	}()
+}
+
+// toDiaperRecordDTO 转换尿布记录DTO
+func toDiaperRecordDTO(record *entity.DiaperRecord) *dto.DiaperRecordDTO {
+	note := ""
+	if record.Note != nil {
+		note = *record.Note
+	}
+
+	poopColor := ""
+	if record.PoopColor != nil {
+		poopColor = *record.PoopColor
+	}
+
+	poopTexture := ""
+	if record.PoopTexture != nil {
+		poopTexture = *record.PoopTexture
+	}
+
+	return &dto.DiaperRecordDTO{
+		RecordID:   strconv.FormatInt(record.ID, 10),
+		BabyID:     strconv.FormatInt(record.BabyID, 10),
+		DiaperType: record.Type,
+		PooColor:   poopColor,
+		PooTexture: poopTexture,
+		Note:       note,
+		ChangeTime: record.Time,
+		CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime: record.CreatedAt,
+	}
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 排泄筛查规则编码
+const (
+	DiaperRuleLowWetDiapers = "low_wet_diapers" // 24小时小便次数偏少
+	DiaperRulePaleStool     = "pale_stool"      // 灰白/陶土色大便(胆道闭锁筛查)
+	DiaperRuleBlackStool    = "black_stool"     // 胎便期后黑色大便
+	DiaperRuleRedStool      = "red_stool"       // 红色大便
+	DiaperRuleStoolGap      = "stool_gap"       // 长时间未排便
+)
+
+const (
+	// diaperScreeningLookback 筛查时拉取的排泄记录范围
+	diaperScreeningLookback = 14 * 24 * time.Hour
+	// diaperColorAlertWindow 大便颜色异常在统计中展示的时间范围
+	diaperColorAlertWindow = 7 * 24 * time.Hour
+	// meconiumPeriodDays 胎便期(出生后天数)，之后的黑色大便需要警惕
+	meconiumPeriodDays = 5
+	// healthAlertTemplateType 健康提醒订阅消息模板类型
+	healthAlertTemplateType = "health_alert"
+)
+
+// paleStoolColors 大便比色卡中需警惕的浅色
+var paleStoolColors = map[string]bool{
+	"white": true,
+	"pale":  true,
+	"clay":  true,
+	"gray":  true,
+	"grey":  true,
+}
+
+// DiaperScreeningService 排泄健康筛查服务
+type DiaperScreeningService struct {
+	babyRepo         repository.BabyRepository
+	diaperRecordRepo repository.DiaperRecordRepository
+	healthAlertRepo  repository.HealthAlertRepository
+	notifier         *BabyNotifier
+	logger           *zap.Logger
+}
+
+// NewDiaperScreeningService 创建排泄健康筛查服务
+func NewDiaperScreeningService(
+	babyRepo repository.BabyRepository,
+	diaperRecordRepo repository.DiaperRecordRepository,
+	healthAlertRepo repository.HealthAlertRepository,
+	notifier *BabyNotifier,
+	logger *zap.Logger,
+) *DiaperScreeningService {
+	return &DiaperScreeningService{
+		babyRepo:         babyRepo,
+		diaperRecordRepo: diaperRecordRepo,
+		healthAlertRepo:  healthAlertRepo,
+		notifier:         notifier,
+		logger:           logger,
+	}
+}
+
+// ScreenAndNotify 对宝宝执行排泄筛查，新触发的提醒会保存并通知管理员
+func (s *DiaperScreeningService) ScreenAndNotify(ctx context.Context, babyID int64) error {
+	baby, err := s.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		return err
+	}
+
+	alerts, err := screenBabyDiapers(ctx, s.diaperRecordRepo, baby, time.Now())
+	if err != nil {
+		return err
+	}
+
+	publishHealthAlerts(ctx, s.healthAlertRepo, s.notifier, s.logger, baby, alerts)
+	return nil
+}
+
+// publishHealthAlerts 保存新触发的健康提醒(按去重键)并通知宝宝的管理员
+func publishHealthAlerts(
+	ctx context.Context,
+	healthAlertRepo repository.HealthAlertRepository,
+	notifier *BabyNotifier,
+	logger *zap.Logger,
+	baby *entity.Baby,
+	alerts []*entity.HealthAlert,
+) {
+	for _, alert := range alerts {
+		created, err := healthAlertRepo.CreateIfNotExists(ctx, alert)
+		if err != nil {
+			logger.Error("保存健康提醒失败",
+				zap.Int64("babyID", baby.ID),
+				zap.String("ruleCode", alert.RuleCode),
+				zap.Error(err))
+			continue
+		}
+		if !created {
+			continue
+		}
+
+		logger.Info("触发健康提醒",
+			zap.Int64("babyID", baby.ID),
+			zap.String("source", alert.Source),
+			zap.String("ruleCode", alert.RuleCode),
+			zap.String("level", alert.Level))
+
+		if notifyHealthAlert(ctx, notifier, baby, alert) > 0 {
+			if err := healthAlertRepo.MarkNotified(ctx, alert.ID, time.Now().UnixMilli()); err != nil {
+				logger.Warn("标记健康提醒已通知失败", zap.Int64("alertID", alert.ID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// notifyHealthAlert 向宝宝的管理员发送健康提醒订阅消息，返回成功发送数
+func notifyHealthAlert(ctx context.Context, notifier *BabyNotifier, baby *entity.Baby, alert *entity.HealthAlert) int {
+	data := func(locale i18n.Locale) map[string]any {
+		title, message := alert.LocalizedText(locale)
+		// 英文内容较长，截断后不完整，改为提示查看详情
+		if !locale.IsDefault() {
+			message = locale.T("notify.health_alert.tip")
+		}
+		return map[string]any{
+			"thing1": truncateRunes(baby.Name+" "+title, 20),                  // 提醒事项
+			"time2":  time.UnixMilli(alert.TriggeredAt).Format(time.DateTime), // 提醒时间
+			"thing3": truncateRunes(message, 20),                              // 温馨提示
+		}
+	}
+	return notifier.NotifyAdmins(ctx, baby.ID, healthAlertTemplateType, data, "pages/statistics/statistics")
+}
+
+// screenBabyDiapers 拉取近期排泄记录并执行筛查规则
+func screenBabyDiapers(ctx context.Context, diaperRecordRepo repository.DiaperRecordRepository, baby *entity.Baby, now time.Time) ([]*entity.HealthAlert, error) {
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, baby.Location())
+	if err != nil {
+		// 出生日期无效时无法按月龄筛查
+		return nil, nil
+	}
+
+	records, _, err := diaperRecordRepo.FindByBabyID(ctx, baby.ID, now.Add(-diaperScreeningLookback).UnixMilli(), now.UnixMilli(), 1, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := screenDiaperRecords(records, birthDate, now)
+	for _, alert := range alerts {
+		alert.BabyID = baby.ID
+	}
+	return alerts, nil
+}
+
+// screenDiaperRecords 排泄筛查规则引擎
+func screenDiaperRecords(records []*entity.DiaperRecord, birthDate time.Time, now time.Time) []*entity.HealthAlert {
+	alerts := make([]*entity.HealthAlert, 0)
+	if len(records) == 0 {
+		return alerts
+	}
+
+	sorted := make([]*entity.DiaperRecord, len(records))
+	copy(sorted, records)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	nowMs := now.UnixMilli()
+	earliest := sorted[0].Time
+	latest := sorted[len(sorted)-1].Time
+	ageDays := int(now.Sub(birthDate).Hours() / 24)
+
+	// 1. 大便颜色筛查
+	colorSince := now.Add(-diaperColorAlertWindow).UnixMilli()
+	for _, record := range sorted {
+		if record.Time < colorSince || record.PoopColor == nil || !hasPoop(record) {
+			continue
+		}
+		if alert := screenStoolColor(record, birthDate); alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	// 只有持续记录时才评估次数类规则，避免把"没记录"误判为"没排泄"
+	dayMs := (24 * time.Hour).Milliseconds()
+	activelyLogging := nowMs-latest <= dayMs
+
+	// 2. 24小时小便次数
+	windowStart := nowMs - dayMs
+	if activelyLogging && earliest <= windowStart {
+		wetCount := 0
+		for _, record := range sorted {
+			if record.Time >= windowStart && hasPee(record) {
+				wetCount++
+			}
+		}
+		required := minWetDiapersForAge(ageDays)
+		if wetCount < required {
+			level := entity.HealthAlertLevelWarning
+			if wetCount == 0 && ageDays >= meconiumPeriodDays {
+				level = entity.HealthAlertLevelCritical
+			}
+			alerts = append(alerts, &entity.HealthAlert{
+				Source:      entity.HealthAlertSourceDiaper,
+				RuleCode:    DiaperRuleLowWetDiapers,
+				Level:       level,
+				Title:       "尿量偏少",
+				Message:     fmt.Sprintf("过去24小时仅记录到%d次小便，低于该月龄建议的至少%d次，可能存在摄入不足或脱水风险。请关注喂养情况，如持续偏少或伴有精神差、口唇干燥、囟门凹陷，请及时联系医生。", wetCount, required),
+				DedupKey:    DiaperRuleLowWetDiapers + ":" + now.Format("2006-01-02"),
+				TriggeredAt: nowMs,
+			})
+		}
+	}
+
+	// 3. 长时间未排便
+	gapThreshold := maxStoolGapForAge(ageDays)
+	lastStool := earliest
+	for _, record := range sorted {
+		if hasPoop(record) {
+			lastStool = record.Time
+		}
+	}
+	gap := time.Duration(nowMs-lastStool) * time.Millisecond
+	if activelyLogging && gap > gapThreshold {
+		alerts = append(alerts, &entity.HealthAlert{
+			Source:      entity.HealthAlertSourceDiaper,
+			RuleCode:    DiaperRuleStoolGap,
+			Level:       entity.HealthAlertLevelWarning,
+			Title:       "长时间未排便",
+			Message:     fmt.Sprintf("已有约%d小时未记录大便，超过该月龄的常见间隔。如伴有腹胀、呕吐、拒奶或哭闹不安，请联系医生。", int(gap.Hours())),
+			DedupKey:    DiaperRuleStoolGap + ":" + strconv.FormatInt(lastStool, 10),
+			TriggeredAt: nowMs,
+		})
+	}
+
+	return alerts
+}
+
+// screenStoolColor 单条记录的大便颜色筛查
+func screenStoolColor(record *entity.DiaperRecord, birthDate time.Time) *entity.HealthAlert {
+	recordID := record.ID
+	alert := &entity.HealthAlert{
+		Source:      entity.HealthAlertSourceDiaper,
+		RecordID:    &recordID,
+		TriggeredAt: record.Time,
+	}
+
+	color := *record.PoopColor
+	switch {
+	case paleStoolColors[color]:
+		alert.RuleCode = DiaperRulePaleStool
+		alert.Level = entity.HealthAlertLevelCritical
+		alert.Title = "大便颜色发白"
+		alert.Message = "记录到灰白/陶土色大便，这是大便比色卡筛查中需要警惕的颜色，可能提示胆道闭锁等肝胆问题。请尽快联系医生，并保留大便照片供医生参考。"
+	case color == "black":
+		ageDays := int(time.UnixMilli(record.Time).Sub(birthDate).Hours() / 24)
+		if ageDays < meconiumPeriodDays {
+			// 胎便期的黑绿色大便属于正常现象
+			return nil
+		}
+		alert.RuleCode = DiaperRuleBlackStool
+		alert.Level = entity.HealthAlertLevelWarning
+		alert.Title = "黑色大便"
+		alert.Message = fmt.Sprintf("出生%d天后(已过胎便期)出现黑色大便，可能提示消化道出血；服用铁剂也可能使大便发黑。请联系医生确认。", ageDays)
+	case color == "red":
+		alert.RuleCode = DiaperRuleRedStool
+		alert.Level = entity.HealthAlertLevelWarning
+		alert.Title = "红色大便"
+		alert.Message = "记录到红色大便，可能含有血液。请留意是否与近期食物颜色有关，并尽快联系医生。"
+	default:
+		return nil
+	}
+
+	alert.DedupKey = alert.RuleCode + ":" + strconv.FormatInt(record.ID, 10)
+	return alert
+}
+
+// minWetDiapersForAge 按日龄的24小时最少小便次数(出生第N天至少N次，第6天起至少6次)
+func minWetDiapersForAge(ageDays int) int {
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	if ageDays+1 < 6 {
+		return ageDays + 1
+	}
+	return 6
+}
+
+// maxStoolGapForAge 按日龄的最长排便间隔(6周内的新生儿更频繁排便)
+func maxStoolGapForAge(ageDays int) time.Duration {
+	if ageDays < 42 {
+		return 72 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// hasPee 是否包含小便
+func hasPee(record *entity.DiaperRecord) bool {
+	return record.Type == "pee" || record.Type == "both"
+}
+
+// hasPoop 是否包含大便
+func hasPoop(record *entity.DiaperRecord) bool {
+	return record.Type == "poop" || record.Type == "both"
+}
+
+// toHealthAlertDTO 转换健康提醒DTO，标题和内容按请求语言展示
+func toHealthAlertDTO(alert *entity.HealthAlert, locale i18n.Locale) *dto.HealthAlertDTO {
+	title, message := alert.LocalizedText(locale)
+	result := &dto.HealthAlertDTO{
+		Source:      alert.Source,
+		RuleCode:    alert.RuleCode,
+		Level:       alert.Level,
+		Title:       title,
+		Message:     message,
+		TriggeredAt: alert.TriggeredAt,
+	}
+	if alert.RecordID != nil {
+		result.RecordID = strconv.FormatInt(*alert.RecordID, 10)
+	}
+	return result
+}
+
+// truncateRunes 按字符截断字符串(微信订阅消息 thing 类型限制20个字符)
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
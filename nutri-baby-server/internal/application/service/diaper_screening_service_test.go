@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestScreenDiaperRecords(t *testing.T) {
+	now := time.Date(2024, 3, 20, 20, 0, 0, 0, time.UTC)
+	birth := now.AddDate(0, 0, -20) // 20日龄: 24小时至少6次小便, 72小时未排便需警惕
+	hour := time.Hour
+
+	color := func(c string) *string { return &c }
+	records := []*entity.DiaperRecord{
+		{ID: 1, Time: now.Add(-90 * hour).UnixMilli(), Type: "poop", PoopColor: color("yellow")},
+		{ID: 2, Time: now.Add(-80 * hour).UnixMilli(), Type: "both", PoopColor: color("white")},
+		{ID: 3, Time: now.Add(-20 * hour).UnixMilli(), Type: "pee"},
+		{ID: 4, Time: now.Add(-10 * hour).UnixMilli(), Type: "pee"},
+		{ID: 5, Time: now.Add(-2 * hour).UnixMilli(), Type: "pee"},
+	}
+
+	alerts := screenDiaperRecords(records, birth, now)
+
+	rules := make(map[string]*entity.HealthAlert)
+	for _, alert := range alerts {
+		rules[alert.RuleCode] = alert
+	}
+
+	assert.Len(t, alerts, 3)
+	assert.Equal(t, entity.HealthAlertLevelCritical, rules[DiaperRulePaleStool].Level)
+	assert.Equal(t, int64(2), *rules[DiaperRulePaleStool].RecordID)
+	assert.Equal(t, entity.HealthAlertLevelWarning, rules[DiaperRuleLowWetDiapers].Level)
+	assert.Contains(t, rules[DiaperRuleLowWetDiapers].Message, "联系医生")
+	assert.NotNil(t, rules[DiaperRuleStoolGap])
+
+	// 胎便期内的黑色大便不提醒, 停止记录超过一天时不评估次数类规则
+	newborn := now.AddDate(0, 0, -2)
+	quiet := []*entity.DiaperRecord{
+		{ID: 6, Time: now.Add(-30 * hour).UnixMilli(), Type: "poop", PoopColor: color("black")},
+	}
+	assert.Empty(t, screenDiaperRecords(quiet, newborn, now))
+}
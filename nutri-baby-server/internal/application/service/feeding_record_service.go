@@ -11,6 +11,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"github.com/wxlbd/nutri-baby-server/pkg/utils"
 )
 
@@ -19,6 +20,8 @@ type FeedingRecordService struct {
 	*BaseRecordService
 	feedingRecordRepo repository.FeedingRecordRepository
 	schedulerService  *SchedulerService
+	milkStashService  *MilkStashService
+	dataCache         *cache.AnalysisDataCache
 }
 
 // NewFeedingRecordService 创建喂养记录服务
@@ -28,12 +31,16 @@ func NewFeedingRecordService(
 	userRepo repository.UserRepository,
 	feedingRecordRepo repository.FeedingRecordRepository,
 	schedulerService *SchedulerService,
+	milkStashService *MilkStashService,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *FeedingRecordService {
 	return &FeedingRecordService{
 		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
 		feedingRecordRepo: feedingRecordRepo,
 		schedulerService:  schedulerService,
+		milkStashService:  milkStashService,
+		dataCache:         dataCache,
 	}
 }
 
@@ -123,6 +130,7 @@ func (s *FeedingRecordService) CreateFeedingRecord(ctx context.Context, openID s
 			zap.Error(err))
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("喂养记录创建成功",
 		zap.String("recordID", strconv.FormatInt(record.ID, 10)),
@@ -145,6 +153,13 @@ func (s *FeedingRecordService) CreateFeedingRecord(ctx context.Context, openID s
 		}
 	}
 
+	// 母乳奶瓶喂养扣减母乳库存, 失败不影响记录保存
+	if err := s.milkStashService.ConsumeForFeeding(ctx, record); err != nil {
+		s.logger.Warn("扣减母乳库存失败",
+			zap.String("recordID", strconv.FormatInt(record.ID, 10)),
+			zap.Error(err))
+	}
+
 	return &dto.FeedingRecordDTO{
 		RecordID:           strconv.FormatInt(record.ID, 10),
 		BabyID:             strconv.FormatInt(record.BabyID, 10),
@@ -317,6 +332,9 @@ func (s *FeedingRecordService) UpdateFeedingRecord(ctx context.Context, openID,
 		return nil, err
 	}
 
+	// 记录修改前的库存扣减, 奶量、类型和时间都未变化时无需重新扣减
+	drawBefore := milkStashDrawOf(record)
+
 	// 更新字段 (只更新非nil字段)
 	updated := false
 
@@ -396,11 +414,21 @@ func (s *FeedingRecordService) UpdateFeedingRecord(ctx context.Context, openID,
 			zap.Error(err))
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("喂养记录更新成功",
 		zap.String("recordID", strconv.FormatInt(record.ID, 10)),
 		zap.String("babyID", strconv.FormatInt(record.BabyID, 10)))
 
+	// 奶量、类型或时间变化时, 先撤销原扣减再按新记录重新扣减
+	if milkStashDrawOf(record) != drawBefore {
+		if err := s.milkStashService.RestoreForFeeding(ctx, record.ID); err != nil {
+			s.logger.Warn("撤销母乳库存扣减失败", zap.String("recordID", recordID), zap.Error(err))
+		} else if err := s.milkStashService.ConsumeForFeeding(ctx, record); err != nil {
+			s.logger.Warn("扣减母乳库存失败", zap.String("recordID", recordID), zap.Error(err))
+		}
+	}
+
 	// 返回更新后的记录
 	return s.GetFeedingRecordById(ctx, openID, recordID)
 }
@@ -434,10 +462,16 @@ func (s *FeedingRecordService) DeleteFeedingRecord(ctx context.Context, openID,
 			zap.Error(err))
 		return err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("喂养记录删除成功",
 		zap.String("recordID", recordID),
 		zap.String("babyID", strconv.FormatInt(record.BabyID, 10)))
 
+	// 归还该记录扣减的母乳库存
+	if err := s.milkStashService.RestoreForFeeding(ctx, recordIDInt64); err != nil {
+		s.logger.Warn("撤销母乳库存扣减失败", zap.String("recordID", recordID), zap.Error(err))
+	}
+
 	return nil
 }
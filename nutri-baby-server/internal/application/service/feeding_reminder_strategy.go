@@ -8,6 +8,7 @@ import (
 
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 )
 
 // FeedingReminderStrategy 喂养提醒策略接口
@@ -18,8 +19,9 @@ type FeedingReminderStrategy interface {
 	// GetTemplateID 获取微信订阅消息模板ID
 	GetTemplateID() string
 
-	// BuildMessageData 构建消息数据
-	BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64) map[string]any
+	// BuildMessageData 按接收人的语言构建消息数据
+	// phrase 类字段只能是汉字，始终使用中文
+	BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64, locale i18n.Locale) map[string]any
 
 	// CanHandle 判断是否能处理该类型的喂养记录
 	CanHandle(record *entity.FeedingRecord) bool
@@ -44,7 +46,7 @@ func (s *BreastFeedingReminderStrategy) GetTemplateType() string {
 	return "breast_feeding_reminder"
 }
 
-func (s *BreastFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64) map[string]any {
+func (s *BreastFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64, locale i18n.Locale) map[string]any {
 	// 微信订阅消息模板字段: time1(上次时间), thing2(距离上次), character_string3(喂养量), phrase4(喂养类型), thing5(温馨提示)
 
 	// 获取喂养侧
@@ -61,10 +63,10 @@ func (s *BreastFeedingReminderStrategy) BuildMessageData(record *entity.FeedingR
 	}
 
 	return map[string]any{
-		"time1":   lastFeedingTime.Format(time.DateTime),  // 上次时间
-		"thing2":  formatTimeSince(hoursSinceLastFeeding), // 距离上次
-		"phrase3": side,                                   // 喂养位置
-		"thing4":  "该喂奶啦，注意观察宝宝的饥饿信号",                     // 温馨提示
+		"time1":   lastFeedingTime.Format(time.DateTime),          // 上次时间
+		"thing2":  formatTimeSince(locale, hoursSinceLastFeeding), // 距离上次
+		"phrase3": side,                                           // 喂养位置
+		"thing4":  locale.T("reminder.breast.tip"),                // 温馨提示
 	}
 }
 
@@ -91,7 +93,7 @@ func (s *BottleFeedingReminderStrategy) GetTemplateType() string {
 	return "bottle_feeding_reminder"
 }
 
-func (s *BottleFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64) map[string]interface{} {
+func (s *BottleFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64, locale i18n.Locale) map[string]interface{} {
 	// 微信订阅消息模板字段: time1(上次时间), thing2(距离上次), character_string3(喂养量), phrase4(喂养类型), thing5(温馨提示)
 
 	// 获取奶瓶类型
@@ -109,11 +111,11 @@ func (s *BottleFeedingReminderStrategy) BuildMessageData(record *entity.FeedingR
 	}
 
 	return map[string]interface{}{
-		"time1":             lastFeedingTime.Format(time.DateTime),  // 上次时间
-		"thing2":            formatTimeSince(hoursSinceLastFeeding), // 距离上次
-		"character_string3": amount,                                 // 喂养量
-		"phrase4":           bottleType,                             // 喂养类型
-		"thing5":            "该喂奶啦，记得准备好奶瓶哦",                        // 温馨提示
+		"time1":             lastFeedingTime.Format(time.DateTime),          // 上次时间
+		"thing2":            formatTimeSince(locale, hoursSinceLastFeeding), // 距离上次
+		"character_string3": amount,                                         // 喂养量
+		"phrase4":           bottleType,                                     // 喂养类型
+		"thing5":            locale.T("reminder.bottle.tip"),                // 温馨提示
 	}
 }
 
@@ -140,7 +142,7 @@ func (s *FoodFeedingReminderStrategy) GetTemplateID() string {
 	return s.config.Wechat.SubscribeTemplates["food_feeding_reminder"]
 }
 
-func (s *FoodFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64) map[string]interface{} {
+func (s *FoodFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRecord, lastFeedingTime time.Time, hoursSinceLastFeeding float64, locale i18n.Locale) map[string]interface{} {
 	// 微信订阅消息模板字段: time1(上次时间), thing2(距离上次), character_string3(食物名称), phrase4(喂养类型), thing5(温馨提示)
 
 	// 获取辅食名称
@@ -150,11 +152,11 @@ func (s *FoodFeedingReminderStrategy) BuildMessageData(record *entity.FeedingRec
 	}
 
 	return map[string]interface{}{
-		"time1":             lastFeedingTime.Format(time.DateTime),  // 上次时间
-		"thing2":            formatTimeSince(hoursSinceLastFeeding), // 距离上次
-		"character_string3": foodName,                               // 食物名称
-		"phrase4":           "辅食",                                   // 喂养类型
-		"thing5":            "该给宝宝准备辅食啦，注意观察过敏反应",                   // 温馨提示
+		"time1":             lastFeedingTime.Format(time.DateTime),          // 上次时间
+		"thing2":            formatTimeSince(locale, hoursSinceLastFeeding), // 距离上次
+		"character_string3": foodName,                                       // 食物名称
+		"phrase4":           "辅食",                                           // 喂养类型
+		"thing5":            locale.T("reminder.food.tip"),                  // 温馨提示
 	}
 }
 
@@ -188,16 +190,16 @@ func (f *FeedingReminderStrategyFactory) GetStrategy(record *entity.FeedingRecor
 }
 
 // formatTimeSince 格式化距离上次的时间
-func formatTimeSince(hours float64) string {
+func formatTimeSince(locale i18n.Locale, hours float64) string {
 	if hours < 1 {
 		minutes := int(hours * 60)
-		return fmt.Sprintf("约%d分钟", minutes)
+		return locale.T("reminder.time_since.minutes", minutes)
 	}
 
 	h := int(hours)
 	if h == 1 {
-		return "约1小时"
+		return locale.T("reminder.time_since.hour")
 	}
 
-	return fmt.Sprintf("约%d小时", h)
+	return locale.T("reminder.time_since.hours", h)
 }
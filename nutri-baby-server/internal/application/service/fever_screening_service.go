@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+)
+
+// 体温筛查规则编码
+const (
+	TemperatureRuleInfantFever     = "infant_fever"      // 3月龄以下发热
+	TemperatureRuleInfantHighFever = "infant_high_fever" // 3-6月龄体温≥39℃
+	TemperatureRuleHighFever       = "high_fever"        // 体温≥40℃
+	TemperatureRuleProlongedFever  = "prolonged_fever"   // 持续发热
+)
+
+const (
+	// feverScreeningLookback 筛查时拉取的体温记录范围
+	feverScreeningLookback = 7 * 24 * time.Hour
+	// feverAlertWindow 最近一次发热测量在该时间内才给出提醒(退热后不再提示)
+	feverAlertWindow = 48 * time.Hour
+	// feverEpisodeGap 两次发热测量间隔超过该时长视为不同的发热过程
+	feverEpisodeGap = 24 * time.Hour
+	// youngInfantAgeDays 3月龄以下婴儿发热需立即就医
+	youngInfantAgeDays = 90
+	// infantAgeDays 6月龄以下婴儿体温≥39℃建议就医
+	infantAgeDays = 180
+	// toddlerAgeDays 2岁以下发热超过24小时建议就医，2岁以上为72小时
+	toddlerAgeDays = 730
+)
+
+// temperatureSiteNames 测量部位的中文名称(用于提醒内容)
+var temperatureSiteNames = map[string]string{
+	entity.TemperatureSiteAxillary: "腋温",
+	entity.TemperatureSiteOral:     "口温",
+	entity.TemperatureSiteRectal:   "肛温",
+	entity.TemperatureSiteEar:      "耳温",
+	entity.TemperatureSiteForehead: "额温",
+}
+
+// FeverScreeningService 发热健康筛查服务
+type FeverScreeningService struct {
+	babyRepo              repository.BabyRepository
+	temperatureRecordRepo repository.TemperatureRecordRepository
+	healthAlertRepo       repository.HealthAlertRepository
+	notifier              *BabyNotifier
+	logger                *zap.Logger
+}
+
+// NewFeverScreeningService 创建发热健康筛查服务
+func NewFeverScreeningService(
+	babyRepo repository.BabyRepository,
+	temperatureRecordRepo repository.TemperatureRecordRepository,
+	healthAlertRepo repository.HealthAlertRepository,
+	notifier *BabyNotifier,
+	logger *zap.Logger,
+) *FeverScreeningService {
+	return &FeverScreeningService{
+		babyRepo:              babyRepo,
+		temperatureRecordRepo: temperatureRecordRepo,
+		healthAlertRepo:       healthAlertRepo,
+		notifier:              notifier,
+		logger:                logger,
+	}
+}
+
+// ScreenAndNotify 对宝宝执行发热筛查，新触发的提醒会保存并通知管理员
+func (s *FeverScreeningService) ScreenAndNotify(ctx context.Context, babyID int64) error {
+	baby, err := s.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		return err
+	}
+
+	alerts, err := screenBabyTemperatures(ctx, s.temperatureRecordRepo, baby, time.Now())
+	if err != nil {
+		return err
+	}
+
+	publishHealthAlerts(ctx, s.healthAlertRepo, s.notifier, s.logger, baby, alerts)
+	return nil
+}
+
+// screenBabyTemperatures 拉取近期体温记录并执行筛查规则
+func screenBabyTemperatures(ctx context.Context, temperatureRecordRepo repository.TemperatureRecordRepository, baby *entity.Baby, now time.Time) ([]*entity.HealthAlert, error) {
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, baby.Location())
+	if err != nil {
+		// 出生日期无效时无法按月龄筛查
+		return nil, nil
+	}
+
+	records, _, err := temperatureRecordRepo.FindByBabyID(ctx, baby.ID, now.Add(-feverScreeningLookback).UnixMilli(), now.UnixMilli(), 1, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := screenTemperatureRecords(records, birthDate, now)
+	for _, alert := range alerts {
+		alert.BabyID = baby.ID
+	}
+	return alerts, nil
+}
+
+// screenTemperatureRecords 发热筛查规则引擎
+// 只评估最近一次发热过程(相邻发热测量间隔不超过24小时，中间的退热读数不打断)，
+// 不同测量部位先换算为肛温等效值再按月龄阈值判断
+func screenTemperatureRecords(records []*entity.TemperatureRecord, birthDate time.Time, now time.Time) []*entity.HealthAlert {
+	alerts := make([]*entity.HealthAlert, 0)
+
+	var fevers []*entity.TemperatureRecord
+	for _, record := range records {
+		if record.IsFever() {
+			fevers = append(fevers, record)
+		}
+	}
+	if len(fevers) == 0 {
+		return alerts
+	}
+	sort.Slice(fevers, func(i, j int) bool { return fevers[i].Time < fevers[j].Time })
+
+	latest := fevers[len(fevers)-1]
+	if now.UnixMilli()-latest.Time > feverAlertWindow.Milliseconds() {
+		return alerts
+	}
+
+	// 向前追溯本次发热过程的起点，同时找出峰值
+	first, peak := len(fevers)-1, latest
+	for i := len(fevers) - 2; i >= 0; i-- {
+		if fevers[i+1].Time-fevers[i].Time > feverEpisodeGap.Milliseconds() {
+			break
+		}
+		first = i
+		if fevers[i].CoreEquivalent() > peak.CoreEquivalent() {
+			peak = fevers[i]
+		}
+	}
+	onset := fevers[first]
+	onsetKey := strconv.FormatInt(onset.ID, 10)
+	ageDays := int(time.UnixMilli(onset.Time).Sub(birthDate).Hours() / 24)
+	peakText := fmt.Sprintf("%s%.1f℃", temperatureSiteName(peak.Site), peak.Temperature)
+	peakID := peak.ID
+
+	// 1. 按月龄的发热阈值
+	core := peak.CoreEquivalent()
+	switch {
+	case ageDays < youngInfantAgeDays:
+		alerts = append(alerts, &entity.HealthAlert{
+			Source:      entity.HealthAlertSourceTemperature,
+			RuleCode:    TemperatureRuleInfantFever,
+			Level:       entity.HealthAlertLevelCritical,
+			Title:       "小月龄发热",
+			Message:     fmt.Sprintf("宝宝未满3月龄，测得%s，已达到发热标准。3月龄以下婴儿发热可能提示严重感染，请立即就医，不要自行使用退热药。", peakText),
+			RecordID:    &peakID,
+			DedupKey:    TemperatureRuleInfantFever + ":" + onsetKey,
+			TriggeredAt: peak.Time,
+		})
+	case core >= 40.0:
+		alerts = append(alerts, &entity.HealthAlert{
+			Source:      entity.HealthAlertSourceTemperature,
+			RuleCode:    TemperatureRuleHighFever,
+			Level:       entity.HealthAlertLevelCritical,
+			Title:       "高热",
+			Message:     fmt.Sprintf("测得%s，属于高热。请尽快就医，同时注意补充水分、观察精神状态，如出现抽搐、呼吸困难或嗜睡请立即就医。", peakText),
+			RecordID:    &peakID,
+			DedupKey:    TemperatureRuleHighFever + ":" + onsetKey,
+			TriggeredAt: peak.Time,
+		})
+	case ageDays < infantAgeDays && core >= 39.0:
+		alerts = append(alerts, &entity.HealthAlert{
+			Source:      entity.HealthAlertSourceTemperature,
+			RuleCode:    TemperatureRuleInfantHighFever,
+			Level:       entity.HealthAlertLevelWarning,
+			Title:       "体温较高",
+			Message:     fmt.Sprintf("宝宝未满6月龄，测得%s，建议联系医生评估。如精神差、拒奶、呼吸急促或出现皮疹，请立即就医。", peakText),
+			RecordID:    &peakID,
+			DedupKey:    TemperatureRuleInfantHighFever + ":" + onsetKey,
+			TriggeredAt: peak.Time,
+		})
+	}
+
+	// 2. 发热持续时间(3月龄以下已要求立即就医，不再重复提醒)
+	limit := 72 * time.Hour
+	if ageDays < toddlerAgeDays {
+		limit = 24 * time.Hour
+	}
+	duration := time.Duration(latest.Time-onset.Time) * time.Millisecond
+	if ageDays >= youngInfantAgeDays && duration > limit {
+		latestID := latest.ID
+		alerts = append(alerts, &entity.HealthAlert{
+			Source:      entity.HealthAlertSourceTemperature,
+			RuleCode:    TemperatureRuleProlongedFever,
+			Level:       entity.HealthAlertLevelWarning,
+			Title:       "持续发热",
+			Message:     fmt.Sprintf("发热已持续约%d小时，超过该年龄建议就医的%d小时。请带宝宝就医查明原因，并继续记录体温变化。", int(duration.Hours()), int(limit.Hours())),
+			RecordID:    &latestID,
+			DedupKey:    TemperatureRuleProlongedFever + ":" + onsetKey,
+			TriggeredAt: latest.Time,
+		})
+	}
+
+	return alerts
+}
+
+// temperatureSiteName 测量部位的中文名称
+func temperatureSiteName(site string) string {
+	if name, ok := temperatureSiteNames[site]; ok {
+		return name
+	}
+	return "体温"
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestScreenTemperatureRecords(t *testing.T) {
+	now := time.Date(2024, 3, 20, 20, 0, 0, 0, time.UTC)
+	hour := time.Hour
+
+	records := []*entity.TemperatureRecord{
+		{ID: 1, Time: now.Add(-30 * hour).UnixMilli(), Temperature: 38.1, Site: entity.TemperatureSiteAxillary},
+		{ID: 2, Time: now.Add(-20 * hour).UnixMilli(), Temperature: 37.0, Site: entity.TemperatureSiteAxillary}, // 退热药后的正常读数不打断发热过程
+		{ID: 3, Time: now.Add(-12 * hour).UnixMilli(), Temperature: 38.7, Site: entity.TemperatureSiteAxillary}, // 相当于肛温39.2℃
+		{ID: 4, Time: now.Add(-2 * hour).UnixMilli(), Temperature: 38.3, Site: entity.TemperatureSiteRectal},
+	}
+
+	// 2月龄: 任何发热都需立即就医, 不再单独提示持续时间
+	alerts := screenTemperatureRecords(records, now.AddDate(0, -2, 0), now)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, TemperatureRuleInfantFever, alerts[0].RuleCode)
+	assert.Equal(t, entity.HealthAlertLevelCritical, alerts[0].Level)
+	assert.Equal(t, int64(3), *alerts[0].RecordID)
+	assert.Equal(t, TemperatureRuleInfantFever+":1", alerts[0].DedupKey)
+
+	// 4月龄: 峰值≥39℃提示联系医生, 2岁以下发热超过24小时提示就医
+	rules := make(map[string]*entity.HealthAlert)
+	for _, alert := range screenTemperatureRecords(records, now.AddDate(0, -4, 0), now) {
+		rules[alert.RuleCode] = alert
+	}
+	assert.Len(t, rules, 2)
+	assert.Equal(t, entity.HealthAlertLevelWarning, rules[TemperatureRuleInfantHighFever].Level)
+	assert.Contains(t, rules[TemperatureRuleInfantHighFever].Message, "腋温38.7℃")
+	assert.Equal(t, int64(4), *rules[TemperatureRuleProlongedFever].RecordID)
+
+	// 3岁: 未到40℃且不足72小时不提醒
+	assert.Empty(t, screenTemperatureRecords(records, now.AddDate(-3, 0, 0), now))
+
+	// 任何年龄≥40℃为高热; 最近一次发热已超过48小时则不再提醒
+	high := []*entity.TemperatureRecord{
+		{ID: 5, Time: now.Add(-1 * hour).UnixMilli(), Temperature: 40.1, Site: entity.TemperatureSiteEar},
+	}
+	alerts = screenTemperatureRecords(high, now.AddDate(-3, 0, 0), now)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, TemperatureRuleHighFever, alerts[0].RuleCode)
+	assert.Equal(t, entity.HealthAlertLevelCritical, alerts[0].Level)
+	assert.Empty(t, screenTemperatureRecords(high, now.AddDate(-3, 0, 0), now.Add(50*hour)))
+}
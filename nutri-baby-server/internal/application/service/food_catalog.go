@@ -0,0 +1,164 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// foodCatalogEntry 食物目录条目
+type foodCatalogEntry struct {
+	Code         string   // 目录编码, 同时作为食物标识
+	Name         string   // 标准名称
+	Category     string   // 分类
+	Allergens    []string // 过敏原标签
+	MinAgeMonths int      // 建议最早引入月龄
+	Aliases      []string // 常见别名
+}
+
+// foodCatalog 内置辅食目录
+// 整粒坚果、花生有窒息风险, 目录中只收录泥/酱/粉状形态
+var foodCatalog = []foodCatalogEntry{
+	// 谷物
+	{Code: "rice_cereal", Name: "高铁米粉", Category: "grain", MinAgeMonths: 6, Aliases: []string{"米粉", "米糊", "婴儿米粉"}},
+	{Code: "millet_congee", Name: "小米粥", Category: "grain", MinAgeMonths: 6, Aliases: []string{"小米"}},
+	{Code: "oatmeal", Name: "燕麦", Category: "grain", MinAgeMonths: 6, Aliases: []string{"燕麦粥", "燕麦片"}},
+	{Code: "wheat_noodle", Name: "婴儿面条", Category: "grain", Allergens: []string{entity.AllergenWheat}, MinAgeMonths: 6, Aliases: []string{"面条", "宝宝面", "碎碎面"}},
+	{Code: "bread", Name: "面包", Category: "grain", Allergens: []string{entity.AllergenWheat}, MinAgeMonths: 8, Aliases: []string{"吐司"}},
+	// 蔬菜
+	{Code: "pumpkin", Name: "南瓜", Category: "vegetable", MinAgeMonths: 6, Aliases: []string{"南瓜泥"}},
+	{Code: "carrot", Name: "胡萝卜", Category: "vegetable", MinAgeMonths: 6, Aliases: []string{"胡萝卜泥"}},
+	{Code: "sweet_potato", Name: "红薯", Category: "vegetable", MinAgeMonths: 6, Aliases: []string{"地瓜", "红薯泥"}},
+	{Code: "potato", Name: "土豆", Category: "vegetable", MinAgeMonths: 6, Aliases: []string{"土豆泥", "马铃薯"}},
+	{Code: "broccoli", Name: "西兰花", Category: "vegetable", MinAgeMonths: 6, Aliases: []string{"西蓝花"}},
+	{Code: "spinach", Name: "菠菜", Category: "vegetable", MinAgeMonths: 6},
+	// 水果
+	{Code: "apple", Name: "苹果", Category: "fruit", MinAgeMonths: 6, Aliases: []string{"苹果泥"}},
+	{Code: "banana", Name: "香蕉", Category: "fruit", MinAgeMonths: 6, Aliases: []string{"香蕉泥"}},
+	{Code: "pear", Name: "梨", Category: "fruit", MinAgeMonths: 6, Aliases: []string{"梨泥"}},
+	{Code: "avocado", Name: "牛油果", Category: "fruit", MinAgeMonths: 6, Aliases: []string{"鳄梨"}},
+	// 肉类
+	{Code: "pork", Name: "猪肉", Category: "meat", MinAgeMonths: 6, Aliases: []string{"猪肉泥"}},
+	{Code: "beef", Name: "牛肉", Category: "meat", MinAgeMonths: 6, Aliases: []string{"牛肉泥"}},
+	{Code: "chicken", Name: "鸡肉", Category: "meat", MinAgeMonths: 6, Aliases: []string{"鸡肉泥"}},
+	{Code: "pork_liver", Name: "猪肝", Category: "meat", MinAgeMonths: 6, Aliases: []string{"猪肝泥"}},
+	// 常见过敏原食物
+	{Code: "egg_yolk", Name: "蛋黄", Category: "egg", Allergens: []string{entity.AllergenEgg}, MinAgeMonths: 6, Aliases: []string{"鸡蛋黄", "蛋黄泥"}},
+	{Code: "whole_egg", Name: "全蛋", Category: "egg", Allergens: []string{entity.AllergenEgg}, MinAgeMonths: 6, Aliases: []string{"鸡蛋", "蒸蛋", "鸡蛋羹"}},
+	{Code: "yogurt", Name: "酸奶", Category: "dairy", Allergens: []string{entity.AllergenDairy}, MinAgeMonths: 6, Aliases: []string{"无糖酸奶"}},
+	{Code: "cheese", Name: "奶酪", Category: "dairy", Allergens: []string{entity.AllergenDairy}, MinAgeMonths: 8, Aliases: []string{"芝士"}},
+	{Code: "tofu", Name: "豆腐", Category: "legume", Allergens: []string{entity.AllergenSoy}, MinAgeMonths: 6, Aliases: []string{"嫩豆腐"}},
+	{Code: "peanut_butter", Name: "花生酱", Category: "nut", Allergens: []string{entity.AllergenPeanut}, MinAgeMonths: 6, Aliases: []string{"花生粉", "花生泥"}},
+	{Code: "almond_butter", Name: "杏仁酱", Category: "nut", Allergens: []string{entity.AllergenTreeNut}, MinAgeMonths: 6, Aliases: []string{"杏仁粉"}},
+	{Code: "walnut_powder", Name: "核桃粉", Category: "nut", Allergens: []string{entity.AllergenTreeNut}, MinAgeMonths: 6, Aliases: []string{"核桃泥"}},
+	{Code: "salmon", Name: "三文鱼", Category: "seafood", Allergens: []string{entity.AllergenFish}, MinAgeMonths: 6, Aliases: []string{"三文鱼泥"}},
+	{Code: "cod", Name: "鳕鱼", Category: "seafood", Allergens: []string{entity.AllergenFish}, MinAgeMonths: 6, Aliases: []string{"鳕鱼泥"}},
+	{Code: "shrimp", Name: "虾", Category: "seafood", Allergens: []string{entity.AllergenShellfish}, MinAgeMonths: 8, Aliases: []string{"虾仁", "虾泥"}},
+	{Code: "sesame_paste", Name: "芝麻酱", Category: "seed", Allergens: []string{entity.AllergenSesame}, MinAgeMonths: 6, Aliases: []string{"芝麻糊", "芝麻粉"}},
+}
+
+// allergenLabels 过敏原中文名称
+var allergenLabels = map[string]string{
+	entity.AllergenPeanut:    "花生",
+	entity.AllergenEgg:       "鸡蛋",
+	entity.AllergenDairy:     "牛奶",
+	entity.AllergenTreeNut:   "坚果",
+	entity.AllergenWheat:     "小麦",
+	entity.AllergenSoy:       "大豆",
+	entity.AllergenFish:      "鱼类",
+	entity.AllergenShellfish: "虾蟹",
+	entity.AllergenSesame:    "芝麻",
+}
+
+// allergenKeywords 目录外食物按名称关键词推断过敏原
+var allergenKeywords = map[string][]string{
+	entity.AllergenEgg:       {"蛋", "egg"},
+	entity.AllergenPeanut:    {"花生", "peanut"},
+	entity.AllergenDairy:     {"牛奶", "酸奶", "奶酪", "芝士", "黄油", "奶油", "乳酪", "yogurt", "cheese", "butter"},
+	entity.AllergenWheat:     {"小麦", "面条", "面包", "面粉", "馒头", "饺子", "馄饨", "wheat", "bread", "pasta", "noodle"},
+	entity.AllergenSoy:       {"豆腐", "黄豆", "豆浆", "大豆", "毛豆", "腐竹", "soy", "tofu"},
+	entity.AllergenFish:      {"鱼", "fish", "salmon", "cod"},
+	entity.AllergenShellfish: {"虾", "蟹", "贝", "shrimp", "prawn", "crab", "lobster"},
+	entity.AllergenTreeNut:   {"核桃", "杏仁", "腰果", "榛子", "开心果", "碧根果", "夏威夷果", "松子", "almond", "walnut", "cashew", "pecan", "hazelnut", "pistachio"},
+	entity.AllergenSesame:    {"芝麻", "麻酱", "sesame", "tahini"},
+}
+
+// matchedFood 食物名称匹配目录后的结果
+type matchedFood struct {
+	Key       string
+	Name      string
+	Category  string
+	Allergens []string
+}
+
+// normalizeFoodName 归一化食物名称(去空白、转小写)
+func normalizeFoodName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), ""))
+}
+
+// matchFood 将记录中的食物名称匹配到目录条目
+// 目录外的食物以 "custom:" + 归一化名称作为标识, 过敏原由关键词推断并合并用户手动标注
+func matchFood(foodName string, taggedAllergens []string) *matchedFood {
+	normalized := normalizeFoodName(foodName)
+	if normalized == "" {
+		return nil
+	}
+
+	for i := range foodCatalog {
+		entry := &foodCatalog[i]
+		if normalizeFoodName(entry.Name) == normalized || entry.Code == normalized || containsNormalized(entry.Aliases, normalized) {
+			return &matchedFood{
+				Key:       entry.Code,
+				Name:      entry.Name,
+				Category:  entry.Category,
+				Allergens: mergeAllergens(entry.Allergens, taggedAllergens),
+			}
+		}
+	}
+
+	var inferred []string
+	for _, allergen := range entity.TopAllergens {
+		for _, keyword := range allergenKeywords[allergen] {
+			if strings.Contains(normalized, keyword) {
+				inferred = append(inferred, allergen)
+				break
+			}
+		}
+	}
+
+	// 食物标识和名称都以 varchar(64) 存储
+	key := "custom:" + truncateRunes(normalized, 57)
+	return &matchedFood{
+		Key:       key,
+		Name:      truncateRunes(strings.TrimSpace(foodName), 64),
+		Allergens: mergeAllergens(inferred, taggedAllergens),
+	}
+}
+
+// containsNormalized 判断别名列表中是否包含归一化名称
+func containsNormalized(aliases []string, normalized string) bool {
+	for _, alias := range aliases {
+		if normalizeFoodName(alias) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeAllergens 合并过敏原标签, 忽略未知标签并按 TopAllergens 顺序输出
+func mergeAllergens(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, allergen := range list {
+			seen[strings.ToLower(strings.TrimSpace(allergen))] = true
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for _, allergen := range entity.TopAllergens {
+		if seen[allergen] {
+			result = append(result, allergen)
+		}
+	}
+	return result
+}
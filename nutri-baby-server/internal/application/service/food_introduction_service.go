@@ -0,0 +1,488 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 辅食引入状态
+const (
+	FoodStatusNotIntroduced = "not_introduced" // 未引入
+	FoodStatusTrying        = "trying"         // 观察中(喂食次数不足)
+	FoodStatusInDiet        = "in_diet"        // 已纳入日常饮食
+	FoodStatusLapsed        = "lapsed"         // 超过两周未喂, 已中断
+	FoodStatusReaction      = "reaction"       // 出现中度及以上反应
+)
+
+const (
+	foodPageSize          = 500
+	foodInDietMinOffers   = 3                   // 至少喂过3次才算纳入日常饮食
+	foodInDietWindow      = 14 * 24 * time.Hour // 两周内喂过才算仍在日常饮食中
+	allergenMaintainEvery = 7 * 24 * time.Hour  // 已引入的过敏原建议每周至少喂1次以维持耐受
+	newFoodWaitDays       = 3                   // 普通新食物之间的观察期
+	allergenWaitDays      = 5                   // 过敏原食物的观察期(迟发型反应可能在2-3天后出现)
+	reactionPauseDays     = 7                   // 出现中度及以上反应后暂停引入新食物的天数
+	minSolidFoodAgeMonths = 4                   // 最早可添加辅食月龄(通常建议满6月龄)
+	maxSuggestedAllergens = 3
+)
+
+// FoodIntroductionService 辅食引入与过敏原跟踪服务
+// 引入历史由辅食喂养记录实时推导, 仅食物反应单独持久化
+type FoodIntroductionService struct {
+	*BaseRecordService
+	feedingRecordRepo repository.FeedingRecordRepository
+	foodReactionRepo  repository.FoodReactionRepository
+}
+
+// NewFoodIntroductionService 创建辅食引入服务
+func NewFoodIntroductionService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	feedingRecordRepo repository.FeedingRecordRepository,
+	foodReactionRepo repository.FoodReactionRepository,
+	logger *zap.Logger,
+) *FoodIntroductionService {
+	return &FoodIntroductionService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		feedingRecordRepo: feedingRecordRepo,
+		foodReactionRepo:  foodReactionRepo,
+	}
+}
+
+// GetFoodCatalog 获取内置食物目录
+func (s *FoodIntroductionService) GetFoodCatalog() []*dto.FoodCatalogItemDTO {
+	items := make([]*dto.FoodCatalogItemDTO, 0, len(foodCatalog))
+	for _, entry := range foodCatalog {
+		allergens := entry.Allergens
+		if allergens == nil {
+			allergens = []string{}
+		}
+		items = append(items, &dto.FoodCatalogItemDTO{
+			Code:         entry.Code,
+			Name:         entry.Name,
+			Category:     entry.Category,
+			Allergens:    allergens,
+			MinAgeMonths: entry.MinAgeMonths,
+		})
+	}
+	return items
+}
+
+// GetFoodIntroductions 获取宝宝的辅食引入历史
+func (s *FoodIntroductionService) GetFoodIntroductions(ctx context.Context, openID, babyID string) ([]*dto.FoodIntroductionDTO, error) {
+	intros, _, err := s.loadFoodHistory(ctx, openID, babyID)
+	if err != nil {
+		return nil, err
+	}
+	return intros, nil
+}
+
+// GetNewFoodGuidance 获取新食物引入等待期建议
+func (s *FoodIntroductionService) GetNewFoodGuidance(ctx context.Context, openID, babyID string) (*dto.NewFoodGuidanceResponse, error) {
+	intros, reactions, err := s.loadFoodHistory(ctx, openID, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, _ := strconv.ParseInt(babyID, 10, 64)
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, baby.Location())
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "宝宝出生日期格式错误")
+	}
+
+	return buildNewFoodGuidance(s.RequesterLocale(ctx, openID), intros, reactions, birthDate, time.Now()), nil
+}
+
+// GetAllergenReport 获取重点过敏原引入报告
+func (s *FoodIntroductionService) GetAllergenReport(ctx context.Context, openID, babyID string) (*dto.AllergenReportResponse, error) {
+	intros, _, err := s.loadFoodHistory(ctx, openID, babyID)
+	if err != nil {
+		return nil, err
+	}
+	return buildAllergenReport(intros, time.Now()), nil
+}
+
+// CreateFoodReaction 记录食物反应
+func (s *FoodIntroductionService) CreateFoodReaction(ctx context.Context, openID, babyID string, req *dto.CreateFoodReactionRequest) (*dto.FoodReactionDTO, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	food := matchFood(req.FoodName, nil)
+	if food == nil {
+		return nil, errors.New(errors.ParamError, "食物名称不能为空")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &entity.FoodReaction{
+		BabyID:     babyIDInt64,
+		FoodKey:    food.Key,
+		FoodName:   food.Name,
+		Severity:   req.Severity,
+		Symptoms:   req.Symptoms,
+		Note:       req.Note,
+		OccurredAt: req.OccurredAt,
+		CreatedBy:  user.ID,
+	}
+	if reaction.OccurredAt == 0 {
+		reaction.OccurredAt = time.Now().UnixMilli()
+	}
+
+	if req.FeedingRecordID != nil && *req.FeedingRecordID != "" {
+		recordID, err := strconv.ParseInt(*req.FeedingRecordID, 10, 64)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "无效的喂养记录ID格式")
+		}
+		record, err := s.feedingRecordRepo.FindByID(ctx, recordID)
+		if err != nil {
+			return nil, err
+		}
+		if record.BabyID != babyIDInt64 || record.FeedingType != entity.FeedingTypeFood {
+			return nil, errors.New(errors.ParamError, "喂养记录不是该宝宝的辅食记录")
+		}
+		reaction.FeedingRecordID = &recordID
+	}
+
+	if err := s.foodReactionRepo.Create(ctx, reaction); err != nil {
+		s.logger.Error("保存食物反应失败",
+			zap.String("babyID", babyID),
+			zap.String("foodKey", food.Key),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return toFoodReactionDTO(reaction), nil
+}
+
+// DeleteFoodReaction 删除食物反应
+func (s *FoodIntroductionService) DeleteFoodReaction(ctx context.Context, openID, babyID, reactionID string) error {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+	reactionIDInt64, err := strconv.ParseInt(reactionID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的反应记录ID格式")
+	}
+
+	reaction, err := s.foodReactionRepo.FindByID(ctx, reactionIDInt64)
+	if err != nil {
+		return err
+	}
+	if reaction.BabyID != babyIDInt64 {
+		return errors.New(errors.PermissionDenied, "您没有权限删除该记录")
+	}
+
+	return s.foodReactionRepo.Delete(ctx, reactionIDInt64)
+}
+
+// loadFoodHistory 校验权限并加载宝宝的辅食引入历史和反应记录
+func (s *FoodIntroductionService) loadFoodHistory(ctx context.Context, openID, babyID string) ([]*dto.FoodIntroductionDTO, []*entity.FoodReaction, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	var records []*entity.FeedingRecord
+	for page := 1; ; page++ {
+		batch, total, err := s.feedingRecordRepo.FindByBabyIDAndType(ctx, babyIDInt64, entity.FeedingTypeFood, 0, 0, page, foodPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, batch...)
+		if len(batch) < foodPageSize || int64(len(records)) >= total {
+			break
+		}
+	}
+
+	reactions, err := s.foodReactionRepo.FindByBabyID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buildFoodIntroductions(records, reactions, time.Now()), reactions, nil
+}
+
+// buildFoodIntroductions 由辅食喂养记录和反应记录推导每种食物的引入历史(按首次尝试时间倒序)
+func buildFoodIntroductions(records []*entity.FeedingRecord, reactions []*entity.FoodReaction, now time.Time) []*dto.FoodIntroductionDTO {
+	byKey := make(map[string]*dto.FoodIntroductionDTO)
+
+	for _, record := range records {
+		var detail dto.FeedingDetail
+		if record.Detail != nil {
+			if detailBytes, err := json.Marshal(record.Detail); err == nil {
+				_ = json.Unmarshal(detailBytes, &detail)
+			}
+		}
+		food := matchFood(detail.FoodName, detail.Allergens)
+		if food == nil {
+			continue
+		}
+
+		intro, ok := byKey[food.Key]
+		if !ok {
+			intro = &dto.FoodIntroductionDTO{
+				FoodKey:      food.Key,
+				FoodName:     food.Name,
+				Category:     food.Category,
+				Allergens:    food.Allergens,
+				FirstTriedAt: record.Time,
+				Reactions:    []*dto.FoodReactionDTO{},
+			}
+			byKey[food.Key] = intro
+		}
+		intro.TimesOffered++
+		intro.Allergens = mergeAllergens(intro.Allergens, food.Allergens)
+		if record.Time < intro.FirstTriedAt {
+			intro.FirstTriedAt = record.Time
+		}
+		if record.Time > intro.LastOfferedAt {
+			intro.LastOfferedAt = record.Time
+		}
+	}
+
+	// 反应记录对应的食物可能没有喂养记录(例如补记), 仍需展示
+	maxSeverity := make(map[string]string)
+	for _, reaction := range reactions {
+		intro, ok := byKey[reaction.FoodKey]
+		if !ok {
+			food := matchFood(reaction.FoodName, nil)
+			if food == nil {
+				continue
+			}
+			intro = &dto.FoodIntroductionDTO{
+				FoodKey:       reaction.FoodKey,
+				FoodName:      reaction.FoodName,
+				Category:      food.Category,
+				Allergens:     food.Allergens,
+				FirstTriedAt:  reaction.OccurredAt,
+				LastOfferedAt: reaction.OccurredAt,
+				Reactions:     []*dto.FoodReactionDTO{},
+			}
+			byKey[reaction.FoodKey] = intro
+		}
+		intro.Reactions = append(intro.Reactions, toFoodReactionDTO(reaction))
+		if severityRank(reaction.Severity) > severityRank(maxSeverity[reaction.FoodKey]) {
+			maxSeverity[reaction.FoodKey] = reaction.Severity
+		}
+	}
+
+	intros := make([]*dto.FoodIntroductionDTO, 0, len(byKey))
+	for key, intro := range byKey {
+		intro.Status = foodStatus(intro.TimesOffered, intro.LastOfferedAt, maxSeverity[key], now)
+		intros = append(intros, intro)
+	}
+	sort.Slice(intros, func(i, j int) bool {
+		if intros[i].FirstTriedAt != intros[j].FirstTriedAt {
+			return intros[i].FirstTriedAt > intros[j].FirstTriedAt
+		}
+		return intros[i].FoodKey < intros[j].FoodKey
+	})
+	return intros
+}
+
+// buildNewFoodGuidance 根据最近引入的新食物和反应情况计算下一次可尝试新食物的时间，建议原因按 locale 生成
+func buildNewFoodGuidance(locale i18n.Locale, intros []*dto.FoodIntroductionDTO, reactions []*entity.FoodReaction, birthDate, now time.Time) *dto.NewFoodGuidanceResponse {
+	guidance := &dto.NewFoodGuidanceResponse{
+		WaitDays:           newFoodWaitDays,
+		SuggestedAllergens: []string{},
+		MaintenanceDue:     []string{},
+	}
+	nextAt := now
+	reason := locale.T("food.guidance.ready", newFoodWaitDays)
+
+	earliest := birthDate.AddDate(0, minSolidFoodAgeMonths, 0)
+	if now.Before(earliest) {
+		nextAt = earliest
+		reason = locale.T("food.guidance.too_young", minSolidFoodAgeMonths)
+	}
+
+	// 最近一次引入的新食物
+	var lastNew *dto.FoodIntroductionDTO
+	for _, intro := range intros {
+		if intro.TimesOffered > 0 && (lastNew == nil || intro.FirstTriedAt > lastNew.FirstTriedAt) {
+			lastNew = intro
+		}
+	}
+	if lastNew != nil {
+		guidance.LastNewFood = lastNew
+		if len(lastNew.Allergens) > 0 {
+			guidance.WaitDays = allergenWaitDays
+		}
+		waitUntil := time.UnixMilli(lastNew.FirstTriedAt).Add(time.Duration(guidance.WaitDays) * 24 * time.Hour)
+		if waitUntil.After(nextAt) {
+			nextAt = waitUntil
+			reason = locale.T("food.guidance.observing", lastNew.FoodName)
+		}
+	}
+
+	// 中度及以上反应后暂停引入新食物; 严重反应后先就医评估再引入其他过敏原
+	hadSevere := false
+	for _, reaction := range reactions {
+		if reaction.Severity == entity.FoodReactionSeveritySevere {
+			hadSevere = true
+		}
+		if severityRank(reaction.Severity) < severityRank(entity.FoodReactionSeverityModerate) {
+			continue
+		}
+		pauseUntil := time.UnixMilli(reaction.OccurredAt).AddDate(0, 0, reactionPauseDays)
+		if pauseUntil.After(nextAt) {
+			nextAt = pauseUntil
+			guidance.WaitDays = reactionPauseDays
+			reason = locale.T("food.guidance.reaction", reaction.FoodName)
+		}
+	}
+
+	guidance.NextNewFoodAt = nextAt.UnixMilli()
+	guidance.CanIntroduceNew = !nextAt.After(now)
+	guidance.Reason = reason
+
+	report := buildAllergenReport(intros, now)
+	for _, item := range report.Items {
+		switch item.Status {
+		case FoodStatusNotIntroduced:
+			if !hadSevere && len(guidance.SuggestedAllergens) < maxSuggestedAllergens {
+				guidance.SuggestedAllergens = append(guidance.SuggestedAllergens, item.Allergen)
+			}
+		case FoodStatusTrying, FoodStatusInDiet, FoodStatusLapsed:
+			if now.Sub(time.UnixMilli(item.LastOfferedAt)) > allergenMaintainEvery {
+				guidance.MaintenanceDue = append(guidance.MaintenanceDue, item.Allergen)
+			}
+		}
+	}
+	if hadSevere {
+		guidance.Reason += locale.T("food.guidance.severe_history")
+	}
+
+	return guidance
+}
+
+// buildAllergenReport 汇总重点过敏原的引入与维持情况
+func buildAllergenReport(intros []*dto.FoodIntroductionDTO, now time.Time) *dto.AllergenReportResponse {
+	report := &dto.AllergenReportResponse{
+		TotalCount: len(entity.TopAllergens),
+		Items:      make([]*dto.AllergenStatusItem, 0, len(entity.TopAllergens)),
+	}
+
+	for _, allergen := range entity.TopAllergens {
+		item := &dto.AllergenStatusItem{
+			Allergen: allergen,
+			Label:    allergenLabels[allergen],
+			Foods:    []string{},
+		}
+		for _, intro := range intros {
+			if !contains(intro.Allergens, allergen) {
+				continue
+			}
+			item.Foods = append(item.Foods, intro.FoodName)
+			item.TimesOffered += intro.TimesOffered
+			if item.FirstTriedAt == 0 || intro.FirstTriedAt < item.FirstTriedAt {
+				item.FirstTriedAt = intro.FirstTriedAt
+			}
+			if intro.LastOfferedAt > item.LastOfferedAt {
+				item.LastOfferedAt = intro.LastOfferedAt
+			}
+			for _, reaction := range intro.Reactions {
+				item.ReactionCount++
+				if severityRank(reaction.Severity) > severityRank(item.MaxSeverity) {
+					item.MaxSeverity = reaction.Severity
+				}
+			}
+		}
+
+		if item.TimesOffered == 0 && item.ReactionCount == 0 {
+			item.Status = FoodStatusNotIntroduced
+		} else {
+			item.Status = foodStatus(item.TimesOffered, item.LastOfferedAt, item.MaxSeverity, now)
+			report.IntroducedCount++
+			if item.Status == FoodStatusInDiet {
+				report.KeptInDietCount++
+			}
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	return report
+}
+
+// foodStatus 计算食物(或过敏原)的引入状态
+func foodStatus(timesOffered int, lastOfferedAt int64, maxSeverity string, now time.Time) string {
+	if severityRank(maxSeverity) >= severityRank(entity.FoodReactionSeverityModerate) {
+		return FoodStatusReaction
+	}
+	if now.Sub(time.UnixMilli(lastOfferedAt)) > foodInDietWindow {
+		return FoodStatusLapsed
+	}
+	if timesOffered < foodInDietMinOffers {
+		return FoodStatusTrying
+	}
+	return FoodStatusInDiet
+}
+
+// severityRank 反应严重程度排序值
+func severityRank(severity string) int {
+	switch severity {
+	case entity.FoodReactionSeveritySevere:
+		return 3
+	case entity.FoodReactionSeverityModerate:
+		return 2
+	case entity.FoodReactionSeverityMild:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toFoodReactionDTO 转换食物反应为DTO
+func toFoodReactionDTO(reaction *entity.FoodReaction) *dto.FoodReactionDTO {
+	result := &dto.FoodReactionDTO{
+		ReactionID: strconv.FormatInt(reaction.ID, 10),
+		FoodKey:    reaction.FoodKey,
+		FoodName:   reaction.FoodName,
+		Severity:   reaction.Severity,
+		Symptoms:   reaction.Symptoms,
+		Note:       reaction.Note,
+		OccurredAt: reaction.OccurredAt,
+	}
+	if result.Symptoms == nil {
+		result.Symptoms = []string{}
+	}
+	if reaction.FeedingRecordID != nil {
+		recordID := strconv.FormatInt(*reaction.FeedingRecordID, 10)
+		result.FeedingRecordID = &recordID
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+func TestFoodIntroductionsAndAllergenReport(t *testing.T) {
+	now := time.Date(2024, 9, 20, 12, 0, 0, 0, time.Local)
+	birth := now.AddDate(0, -7, 0)
+	day := 24 * time.Hour
+
+	food := func(id int64, ago time.Duration, name string, allergens ...interface{}) *entity.FeedingRecord {
+		detail := entity.FeedingDetail{"type": "food", "foodName": name}
+		if len(allergens) > 0 {
+			detail["allergens"] = allergens
+		}
+		return &entity.FeedingRecord{ID: id, FeedingType: entity.FeedingTypeFood, Time: now.Add(-ago).UnixMilli(), Detail: detail}
+	}
+	records := []*entity.FeedingRecord{
+		food(1, 30*day, "米粉"),
+		food(2, 20*day, "蛋黄"),
+		food(3, 10*day, "鸡蛋黄"),
+		food(4, 3*day, "蒸蛋"),
+		food(5, 25*day, "鳕鱼泥"),
+		food(6, 2*day, "自制虾滑"),
+		food(7, 1*day, "奶奶做的馄饨", "sesame", "unknown"),
+	}
+	reactions := []*entity.FoodReaction{
+		{ID: 1, FoodKey: "custom:自制虾滑", FoodName: "自制虾滑", Severity: entity.FoodReactionSeverityModerate, OccurredAt: now.Add(-2 * day).UnixMilli()},
+	}
+
+	intros := buildFoodIntroductions(records, reactions, now)
+	assert.Len(t, intros, 6)
+	assert.Equal(t, "custom:奶奶做的馄饨", intros[0].FoodKey)
+	assert.Equal(t, []string{entity.AllergenWheat, entity.AllergenSesame}, intros[0].Allergens)
+	assert.Equal(t, FoodStatusReaction, intros[1].Status)
+
+	report := buildAllergenReport(intros, now)
+	items := make(map[string]string)
+	for _, item := range report.Items {
+		items[item.Allergen] = item.Status
+	}
+	assert.Equal(t, FoodStatusInDiet, items[entity.AllergenEgg]) // 蛋黄+全蛋 共3次, 3天前仍在喂
+	assert.Equal(t, FoodStatusLapsed, items[entity.AllergenFish])
+	assert.Equal(t, FoodStatusReaction, items[entity.AllergenShellfish])
+	assert.Equal(t, FoodStatusNotIntroduced, items[entity.AllergenPeanut])
+	assert.Equal(t, 5, report.IntroducedCount)
+	assert.Equal(t, 1, report.KeptInDietCount)
+
+	// 中度反应后暂停7天, 优先于最近一次新食物的观察期
+	guidance := buildNewFoodGuidance(i18n.ZhCN, intros, reactions, birth, now)
+	assert.False(t, guidance.CanIntroduceNew)
+	assert.Equal(t, reactionPauseDays, guidance.WaitDays)
+	assert.Equal(t, now.Add(5*day).UnixMilli(), guidance.NextNewFoodAt)
+	assert.Equal(t, []string{entity.AllergenPeanut, entity.AllergenDairy, entity.AllergenSoy}, guidance.SuggestedAllergens)
+	assert.Contains(t, guidance.MaintenanceDue, entity.AllergenFish)
+	assert.Contains(t, guidance.Reason, "出现过敏反应")
+
+	guidance = buildNewFoodGuidance(i18n.EnUS, intros, reactions, birth, now)
+	assert.Contains(t, guidance.Reason, "caused an allergic reaction")
+}
+
+func TestMatchFood_TruncatesLongCustomName(t *testing.T) {
+	name := strings.Repeat("自制南瓜胡萝卜小米粥", 10) // 100个字符
+	food := matchFood(name, nil)
+
+	assert.Equal(t, 64, utf8.RuneCountInString(food.Name))
+	assert.LessOrEqual(t, utf8.RuneCountInString(food.Key), 64)
+	assert.True(t, strings.HasPrefix(name, food.Name))
+}
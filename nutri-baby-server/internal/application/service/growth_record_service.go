@@ -10,12 +10,14 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 )
 
 // GrowthRecordService 成长记录服务
 type GrowthRecordService struct {
 	*BaseRecordService
 	growthRecordRepo repository.GrowthRecordRepository
+	dataCache        *cache.AnalysisDataCache
 }
 
 // NewGrowthRecordService 创建成长记录服务
@@ -24,11 +26,13 @@ func NewGrowthRecordService(
 	collaboratorRepo repository.BabyCollaboratorRepository,
 	userRepo repository.UserRepository,
 	growthRecordRepo repository.GrowthRecordRepository,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *GrowthRecordService {
 	return &GrowthRecordService{
 		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
 		growthRecordRepo:  growthRecordRepo,
+		dataCache:         dataCache,
 	}
 }
 
@@ -93,6 +97,7 @@ func (s *GrowthRecordService) CreateGrowthRecord(ctx context.Context, openID str
 	if err := s.growthRecordRepo.Create(ctx, record); err != nil {
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	resultNote := ""
 	if record.Note != nil {
@@ -263,6 +268,7 @@ func (s *GrowthRecordService) UpdateGrowthRecord(ctx context.Context, openID, re
 			zap.Error(err))
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("生长记录更新成功",
 		zap.String("recordID", strconv.FormatInt(record.ID, 10)),
@@ -301,6 +307,7 @@ func (s *GrowthRecordService) DeleteGrowthRecord(ctx context.Context, openID, re
 			zap.Error(err))
 		return err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("生长记录删除成功",
 		zap.String("recordID", recordID),
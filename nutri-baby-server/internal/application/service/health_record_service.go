@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+// HealthRecordService 体温与症状记录服务
+type HealthRecordService struct {
+	*BaseRecordService
+	temperatureRecordRepo repository.TemperatureRecordRepository
+	symptomRecordRepo     repository.SymptomRecordRepository
+	illnessEpisodeRepo    repository.IllnessEpisodeRepository
+	feverScreeningService *FeverScreeningService
+	dataCache             *cache.AnalysisDataCache
+}
+
+// NewHealthRecordService 创建体温与症状记录服务
+func NewHealthRecordService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	temperatureRecordRepo repository.TemperatureRecordRepository,
+	symptomRecordRepo repository.SymptomRecordRepository,
+	illnessEpisodeRepo repository.IllnessEpisodeRepository,
+	feverScreeningService *FeverScreeningService,
+	dataCache *cache.AnalysisDataCache,
+	logger *zap.Logger,
+) *HealthRecordService {
+	return &HealthRecordService{
+		BaseRecordService:     NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		temperatureRecordRepo: temperatureRecordRepo,
+		symptomRecordRepo:     symptomRecordRepo,
+		illnessEpisodeRepo:    illnessEpisodeRepo,
+		feverScreeningService: feverScreeningService,
+		dataCache:             dataCache,
+	}
+}
+
+// CreateTemperatureRecord 创建体温记录，发热时异步执行按月龄的发热筛查
+func (s *HealthRecordService) CreateTemperatureRecord(ctx context.Context, openID string, req *dto.CreateTemperatureRecordRequest) (*dto.TemperatureRecordDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordTime := req.Time
+	if recordTime == 0 {
+		recordTime = time.Now().UnixMilli()
+	}
+	episodeID, err := s.resolveEpisode(ctx, babyIDInt64, req.EpisodeID, recordTime)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entity.TemperatureRecord{
+		BabyID:          babyIDInt64,
+		EpisodeID:       episodeID,
+		Time:            recordTime,
+		Temperature:     req.Temperature,
+		Site:            req.Site,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	record.Fever = record.IsFever()
+	if err := s.temperatureRecordRepo.Create(ctx, record); err != nil {
+		s.logger.Error("保存体温记录失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+
+	if record.Fever {
+		s.screenAsync(record.BabyID)
+	}
+
+	return toTemperatureRecordDTO(record), nil
+}
+
+// GetTemperatureRecords 获取体温记录列表
+func (s *HealthRecordService) GetTemperatureRecords(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.TemperatureRecordDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	records, total, err := s.temperatureRecordRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*dto.TemperatureRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toTemperatureRecordDTO(record))
+	}
+	return result, total, nil
+}
+
+// DeleteTemperatureRecord 删除体温记录
+func (s *HealthRecordService) DeleteTemperatureRecord(ctx context.Context, openID, recordID string) error {
+	recordIDInt64, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	record, err := s.temperatureRecordRepo.FindByID(ctx, recordIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(record.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	if err := s.temperatureRecordRepo.Delete(ctx, recordIDInt64); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+	return nil
+}
+
+// CreateSymptomRecord 创建症状记录
+func (s *HealthRecordService) CreateSymptomRecord(ctx context.Context, openID string, req *dto.CreateSymptomRecordRequest) (*dto.SymptomRecordDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordTime := req.Time
+	if recordTime == 0 {
+		recordTime = time.Now().UnixMilli()
+	}
+	episodeID, err := s.resolveEpisode(ctx, babyIDInt64, req.EpisodeID, recordTime)
+	if err != nil {
+		return nil, err
+	}
+	severity := req.Severity
+	if severity == "" {
+		severity = entity.SymptomSeverityMild
+	}
+
+	record := &entity.SymptomRecord{
+		BabyID:          babyIDInt64,
+		EpisodeID:       episodeID,
+		Time:            recordTime,
+		Symptom:         req.Symptom,
+		Severity:        severity,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	if err := s.symptomRecordRepo.Create(ctx, record); err != nil {
+		s.logger.Error("保存症状记录失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+
+	return toSymptomRecordDTO(record), nil
+}
+
+// GetSymptomRecords 获取症状记录列表
+func (s *HealthRecordService) GetSymptomRecords(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.SymptomRecordDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	records, total, err := s.symptomRecordRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*dto.SymptomRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toSymptomRecordDTO(record))
+	}
+	return result, total, nil
+}
+
+// DeleteSymptomRecord 删除症状记录
+func (s *HealthRecordService) DeleteSymptomRecord(ctx context.Context, openID, recordID string) error {
+	recordIDInt64, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	record, err := s.symptomRecordRepo.FindByID(ctx, recordIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(record.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	if err := s.symptomRecordRepo.Delete(ctx, recordIDInt64); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+	return nil
+}
+
+// resolveEpisode 确定记录所属的患病经过
+// 指定了患病经过时校验其属于该宝宝；未指定时自动归入记录时间所在的唯一一段患病经过
+func (s *HealthRecordService) resolveEpisode(ctx context.Context, babyID int64, episodeID string, recordTime int64) (*int64, error) {
+	if episodeID != "" {
+		episodeIDInt64, err := strconv.ParseInt(episodeID, 10, 64)
+		if err != nil {
+			return nil, errors.New(errors.ParamError, "无效的患病经过ID格式")
+		}
+		episode, err := s.illnessEpisodeRepo.FindByID(ctx, episodeIDInt64)
+		if err != nil {
+			return nil, err
+		}
+		if episode.BabyID != babyID {
+			return nil, errors.New(errors.ParamError, "患病经过不属于该宝宝")
+		}
+		return &episode.ID, nil
+	}
+
+	episodes, _, err := s.illnessEpisodeRepo.FindByBabyID(ctx, babyID, recordTime, recordTime, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(episodes) != 1 {
+		return nil, nil
+	}
+	return &episodes[0].ID, nil
+}
+
+// screenAsync 异步执行发热健康筛查(不影响记录写入结果)
+func (s *HealthRecordService) screenAsync(babyID int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.feverScreeningService.ScreenAndNotify(ctx, babyID); err != nil {
+			s.logger.Warn("发热健康筛查失败", zap.Int64("babyID", babyID), zap.Error(err))
+		}
+	}()
+}
+
+// formatEpisodeID 转换可选的患病经过ID
+func formatEpisodeID(episodeID *int64) string {
+	if episodeID == nil {
+		return ""
+	}
+	return strconv.FormatInt(*episodeID, 10)
+}
+
+// toTemperatureRecordDTO 转换体温记录DTO
+func toTemperatureRecordDTO(record *entity.TemperatureRecord) *dto.TemperatureRecordDTO {
+	return &dto.TemperatureRecordDTO{
+		RecordID:    strconv.FormatInt(record.ID, 10),
+		BabyID:      strconv.FormatInt(record.BabyID, 10),
+		EpisodeID:   formatEpisodeID(record.EpisodeID),
+		Time:        record.Time,
+		Temperature: record.Temperature,
+		Site:        record.Site,
+		Fever:       record.Fever,
+		Note:        utils.DerefString(record.Note),
+		CreateBy:    strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime:  record.CreatedAt,
+	}
+}
+
+// toSymptomRecordDTO 转换症状记录DTO
+func toSymptomRecordDTO(record *entity.SymptomRecord) *dto.SymptomRecordDTO {
+	return &dto.SymptomRecordDTO{
+		RecordID:   strconv.FormatInt(record.ID, 10),
+		BabyID:     strconv.FormatInt(record.BabyID, 10),
+		EpisodeID:  formatEpisodeID(record.EpisodeID),
+		Time:       record.Time,
+		Symptom:    record.Symptom,
+		Severity:   record.Severity,
+		Note:       utils.DerefString(record.Note),
+		CreateBy:   strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime: record.CreatedAt,
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+// IllnessEpisodeService 患病经过服务
+type IllnessEpisodeService struct {
+	*BaseRecordService
+	illnessEpisodeRepo    repository.IllnessEpisodeRepository
+	temperatureRecordRepo repository.TemperatureRecordRepository
+	symptomRecordRepo     repository.SymptomRecordRepository
+	dataCache             *cache.AnalysisDataCache
+}
+
+// NewIllnessEpisodeService 创建患病经过服务
+func NewIllnessEpisodeService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	illnessEpisodeRepo repository.IllnessEpisodeRepository,
+	temperatureRecordRepo repository.TemperatureRecordRepository,
+	symptomRecordRepo repository.SymptomRecordRepository,
+	dataCache *cache.AnalysisDataCache,
+	logger *zap.Logger,
+) *IllnessEpisodeService {
+	return &IllnessEpisodeService{
+		BaseRecordService:     NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		illnessEpisodeRepo:    illnessEpisodeRepo,
+		temperatureRecordRepo: temperatureRecordRepo,
+		symptomRecordRepo:     symptomRecordRepo,
+		dataCache:             dataCache,
+	}
+}
+
+// CreateIllnessEpisode 创建患病经过
+func (s *IllnessEpisodeService) CreateIllnessEpisode(ctx context.Context, openID string, req *dto.CreateIllnessEpisodeRequest) (*dto.IllnessEpisodeDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	startTime := req.StartTime
+	if startTime == 0 {
+		startTime = time.Now().UnixMilli()
+	}
+	if req.EndTime != nil && *req.EndTime < startTime {
+		return nil, errors.New(errors.ParamError, "结束时间不能早于开始时间")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	episode := &entity.IllnessEpisode{
+		BabyID:          babyIDInt64,
+		Name:            req.Name,
+		StartTime:       startTime,
+		EndTime:         req.EndTime,
+		SawDoctor:       req.SawDoctor,
+		Diagnosis:       req.Diagnosis,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	if err := s.illnessEpisodeRepo.Create(ctx, episode); err != nil {
+		s.logger.Error("保存患病经过失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, episode.BabyID)
+
+	return toIllnessEpisodeDTO(episode), nil
+}
+
+// GetIllnessEpisodes 获取患病经过列表，返回与查询时间范围有交集的记录
+func (s *IllnessEpisodeService) GetIllnessEpisodes(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.IllnessEpisodeDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	episodes, total, err := s.illnessEpisodeRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*dto.IllnessEpisodeDTO, 0, len(episodes))
+	for _, episode := range episodes {
+		result = append(result, toIllnessEpisodeDTO(episode))
+	}
+	return result, total, nil
+}
+
+// GetIllnessEpisode 获取患病经过详情，包含归入该经过的体温和症状记录
+func (s *IllnessEpisodeService) GetIllnessEpisode(ctx context.Context, openID, episodeID string) (*dto.IllnessEpisodeDetailDTO, error) {
+	episodeIDInt64, err := strconv.ParseInt(episodeID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	episode, err := s.illnessEpisodeRepo.FindByID(ctx, episodeIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(episode.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+
+	temperatures, err := s.temperatureRecordRepo.FindByEpisodeID(ctx, episodeIDInt64)
+	if err != nil {
+		return nil, err
+	}
+	symptoms, err := s.symptomRecordRepo.FindByEpisodeID(ctx, episodeIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &dto.IllnessEpisodeDetailDTO{
+		IllnessEpisodeDTO: toIllnessEpisodeDTO(episode),
+		Temperatures:      make([]*dto.TemperatureRecordDTO, 0, len(temperatures)),
+		Symptoms:          make([]*dto.SymptomRecordDTO, 0, len(symptoms)),
+	}
+	for _, record := range temperatures {
+		if detail.MaxTemperature == nil || record.Temperature > *detail.MaxTemperature {
+			maxTemperature := record.Temperature
+			detail.MaxTemperature = &maxTemperature
+		}
+		detail.Temperatures = append(detail.Temperatures, toTemperatureRecordDTO(record))
+	}
+	for _, record := range symptoms {
+		detail.Symptoms = append(detail.Symptoms, toSymptomRecordDTO(record))
+	}
+	return detail, nil
+}
+
+// UpdateIllnessEpisode 更新患病经过(如标记痊愈、补充诊断)
+func (s *IllnessEpisodeService) UpdateIllnessEpisode(ctx context.Context, openID, episodeID string, req *dto.UpdateIllnessEpisodeRequest) (*dto.IllnessEpisodeDTO, error) {
+	episodeIDInt64, err := strconv.ParseInt(episodeID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	episode, err := s.illnessEpisodeRepo.FindByID(ctx, episodeIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(episode.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		episode.Name = *req.Name
+	}
+	if req.StartTime != nil {
+		episode.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		if *req.EndTime == 0 {
+			episode.EndTime = nil
+		} else {
+			endTime := *req.EndTime
+			episode.EndTime = &endTime
+		}
+	}
+	if req.SawDoctor != nil {
+		episode.SawDoctor = *req.SawDoctor
+	}
+	if req.Diagnosis != nil {
+		episode.Diagnosis = req.Diagnosis
+	}
+	if req.Note != nil {
+		episode.Note = req.Note
+	}
+	if episode.EndTime != nil && *episode.EndTime < episode.StartTime {
+		return nil, errors.New(errors.ParamError, "结束时间不能早于开始时间")
+	}
+
+	if err := s.illnessEpisodeRepo.Update(ctx, episode); err != nil {
+		s.logger.Error("更新患病经过失败", zap.String("episodeID", episodeID), zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, episode.BabyID)
+
+	return toIllnessEpisodeDTO(episode), nil
+}
+
+// DeleteIllnessEpisode 删除患病经过
+func (s *IllnessEpisodeService) DeleteIllnessEpisode(ctx context.Context, openID, episodeID string) error {
+	episodeIDInt64, err := strconv.ParseInt(episodeID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	episode, err := s.illnessEpisodeRepo.FindByID(ctx, episodeIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(episode.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	if err := s.illnessEpisodeRepo.Delete(ctx, episodeIDInt64); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, episode.BabyID)
+	return nil
+}
+
+// toIllnessEpisodeDTO 转换患病经过DTO
+func toIllnessEpisodeDTO(episode *entity.IllnessEpisode) *dto.IllnessEpisodeDTO {
+	return &dto.IllnessEpisodeDTO{
+		EpisodeID:  strconv.FormatInt(episode.ID, 10),
+		BabyID:     strconv.FormatInt(episode.BabyID, 10),
+		Name:       episode.Name,
+		StartTime:  episode.StartTime,
+		EndTime:    episode.EndTime,
+		Ongoing:    episode.IsOngoing(),
+		SawDoctor:  episode.SawDoctor,
+		Diagnosis:  utils.DerefString(episode.Diagnosis),
+		Note:       utils.DerefString(episode.Note),
+		CreateBy:   strconv.FormatInt(episode.CreatedBy, 10),
+		CreateTime: episode.CreatedAt,
+	}
+}
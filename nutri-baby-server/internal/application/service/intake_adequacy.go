@@ -0,0 +1,183 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// mlPerOunce 1液量盎司对应的毫升数
+	mlPerOunce = 29.5735
+	// intakeBelowRangeTolerance 低于参考下限超过该比例才标记为偏低，避免单日波动误报
+	intakeBelowRangeTolerance = 0.15
+)
+
+// intakeReference 按月龄的奶量参考范围
+type intakeReference struct {
+	maxAgeDays int     // 适用的最大日龄(不含)
+	minMlPerKg float64 // 每公斤每日最少奶量(ml)，0 表示不评估
+	maxMlPerKg float64 // 每公斤每日最多奶量(ml)
+	minFeeds   int     // 每日最少喂奶次数
+	maxFeeds   int     // 每日最多喂奶次数
+}
+
+// intakeReferences 奶量参考范围(参考常见儿科喂养指南，随月龄增加辅食后逐步下降)
+var intakeReferences = []intakeReference{
+	{maxAgeDays: 7, minMlPerKg: 60, maxMlPerKg: 150, minFeeds: 8, maxFeeds: 12},
+	{maxAgeDays: 30, minMlPerKg: 150, maxMlPerKg: 200, minFeeds: 8, maxFeeds: 12},
+	{maxAgeDays: 90, minMlPerKg: 150, maxMlPerKg: 200, minFeeds: 6, maxFeeds: 10},
+	{maxAgeDays: 180, minMlPerKg: 120, maxMlPerKg: 180, minFeeds: 5, maxFeeds: 8},
+	{maxAgeDays: 365, minMlPerKg: 90, maxMlPerKg: 150, minFeeds: 4, maxFeeds: 6},
+	{maxAgeDays: math.MaxInt32, minMlPerKg: 0, maxMlPerKg: 0, minFeeds: 3, maxFeeds: 5},
+}
+
+// intakeReferenceForAge 获取日龄对应的参考范围
+func intakeReferenceForAge(ageDays int) intakeReference {
+	for _, ref := range intakeReferences {
+		if ageDays < ref.maxAgeDays {
+			return ref
+		}
+	}
+	return intakeReferences[len(intakeReferences)-1]
+}
+
+// weightPoint 体重测量点
+type weightPoint struct {
+	time   int64   // 测量时间(毫秒时间戳)
+	weight float64 // 体重 kg
+}
+
+// buildWeightPoints 从成长记录中提取体重点(按时间升序)，无测量记录时回退到宝宝档案体重
+func buildWeightPoints(records []*entity.GrowthRecord, baby *entity.Baby) []weightPoint {
+	points := make([]weightPoint, 0, len(records))
+	for _, record := range records {
+		if record.Weight != nil && *record.Weight > 0 {
+			points = append(points, weightPoint{time: record.Time, weight: *record.Weight})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].time < points[j].time })
+
+	if len(points) == 0 && baby != nil && baby.Weight > 0 {
+		points = append(points, weightPoint{time: 0, weight: baby.Weight})
+	}
+	return points
+}
+
+// interpolateWeight 计算指定时间的体重：两次测量之间线性插值，超出范围取最近一次测量值
+func interpolateWeight(points []weightPoint, at int64) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	if at <= points[0].time {
+		return points[0].weight
+	}
+	for i := 1; i < len(points); i++ {
+		if at <= points[i].time {
+			prev, next := points[i-1], points[i]
+			if next.time == prev.time {
+				return next.weight
+			}
+			ratio := float64(at-prev.time) / float64(next.time-prev.time)
+			return prev.weight + (next.weight-prev.weight)*ratio
+		}
+	}
+	return points[len(points)-1].weight
+}
+
+// bottleAmountMl 获取奶瓶喂养的奶量(ml)，兼容以盎司记录的数据
+func bottleAmountMl(record *entity.FeedingRecord) float64 {
+	amount := float64(record.Amount)
+	if amount == 0 {
+		if v, ok := record.Detail["amount"].(float64); ok {
+			amount = v
+		}
+	}
+	if unit, ok := record.Detail["unit"].(string); ok && unit == "oz" {
+		amount *= mlPerOunce
+	}
+	return amount
+}
+
+// buildIntakeSeries 按天计算奶量充足度
+func buildIntakeSeries(records []*entity.FeedingRecord, points []weightPoint, birthDate time.Time, startDate, endDate int64, loc *time.Location) []*dto.DailyIntakeStatsItem {
+	dayIndex := make(map[string]*dto.DailyIntakeStatsItem)
+	series := make([]*dto.DailyIntakeStatsItem, 0)
+
+	start := time.UnixMilli(startDate).In(loc)
+	end := time.UnixMilli(endDate).In(loc)
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); !day.After(end); day = day.AddDate(0, 0, 1) {
+		item := &dto.DailyIntakeStatsItem{Date: day.Format("2006-01-02")}
+		dayIndex[item.Date] = item
+		series = append(series, item)
+	}
+
+	bottleMl := make(map[string]float64)
+	for _, record := range records {
+		date := time.UnixMilli(record.Time).In(loc).Format("2006-01-02")
+		item, ok := dayIndex[date]
+		if !ok {
+			continue
+		}
+		switch record.FeedingType {
+		case entity.FeedingTypeBottle:
+			item.BottleCount++
+			bottleMl[date] += bottleAmountMl(record)
+		case entity.FeedingTypeBreast:
+			item.BreastCount++
+		}
+	}
+
+	for _, item := range series {
+		day, _ := time.ParseInLocation("2006-01-02", item.Date, loc)
+		midday := day.Add(12 * time.Hour)
+
+		item.FeedCount = item.BottleCount + item.BreastCount
+		item.BottleMl = int64(math.Round(bottleMl[item.Date]))
+		item.WeightKg = roundToOneDecimal(interpolateWeight(points, midday.UnixMilli()))
+
+		ref := intakeReferenceForAge(int(midday.Sub(birthDate).Hours() / 24))
+		item.MinFeeds = ref.minFeeds
+		item.MaxFeeds = ref.maxFeeds
+		if ref.minMlPerKg > 0 {
+			minMl, maxMl := ref.minMlPerKg, ref.maxMlPerKg
+			item.MinMlPerKg = &minMl
+			item.MaxMlPerKg = &maxMl
+		}
+
+		if item.WeightKg > 0 && item.BottleMl > 0 {
+			mlPerKg := roundToOneDecimal(float64(item.BottleMl) / item.WeightKg)
+			item.MlPerKg = &mlPerKg
+		}
+
+		evaluateIntakeDay(item)
+	}
+
+	return series
+}
+
+// evaluateIntakeDay 判断当天奶量和喂养次数是否明显低于参考范围
+func evaluateIntakeDay(item *dto.DailyIntakeStatsItem) {
+	item.Flags = make([]string, 0)
+	if item.FeedCount == 0 {
+		// 当天没有任何记录，视为未记录而不是摄入不足
+		return
+	}
+
+	// 纯奶瓶喂养的日子才评估 ml/kg，母乳量无法计量
+	if item.BreastCount == 0 && item.MlPerKg != nil && item.MinMlPerKg != nil {
+		if *item.MlPerKg < *item.MinMlPerKg*(1-intakeBelowRangeTolerance) {
+			item.Flags = append(item.Flags, dto.IntakeFlagLowVolume)
+		}
+	}
+
+	minFeeds := float64(item.MinFeeds) * (1 - intakeBelowRangeTolerance)
+	if float64(item.FeedCount) < math.Floor(minFeeds) {
+		item.Flags = append(item.Flags, dto.IntakeFlagLowFeedCount)
+	}
+
+	item.BelowRange = len(item.Flags) > 0
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func TestBuildIntakeSeries_InterpolatesWeightAndFlagsLowDays(t *testing.T) {
+	loc := time.UTC
+	birth := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+	day1 := time.Date(2024, 2, 10, 0, 0, 0, 0, loc) // 约40日龄: 150-200 ml/kg, 6-10次
+	hour := int64(time.Hour / time.Millisecond)
+
+	w1, w2 := 4.0, 5.0
+	points := buildWeightPoints([]*entity.GrowthRecord{
+		{Time: day1.Add(-9*24*time.Hour + 12*time.Hour).UnixMilli(), Weight: &w1},
+		{Time: day1.Add(9*24*time.Hour + 12*time.Hour).UnixMilli(), Weight: &w2},
+	}, nil)
+
+	var records []*entity.FeedingRecord
+	// 第一天: 8次 x 100ml，约 178 ml/kg，处于范围内
+	for i := 0; i < 8; i++ {
+		records = append(records, &entity.FeedingRecord{
+			Time: day1.UnixMilli() + int64(i)*3*hour, FeedingType: entity.FeedingTypeBottle, Amount: 100,
+		})
+	}
+	// 第二天: 4次 x 2oz，奶量和次数都明显偏低
+	for i := 0; i < 4; i++ {
+		records = append(records, &entity.FeedingRecord{
+			Time: day1.UnixMilli() + 24*hour + int64(i)*3*hour, FeedingType: entity.FeedingTypeBottle, Amount: 2,
+			Detail: entity.FeedingDetail{"unit": "oz"},
+		})
+	}
+
+	series := buildIntakeSeries(records, points, birth, day1.UnixMilli(), day1.UnixMilli()+47*hour, loc)
+
+	assert.Len(t, series, 2)
+	assert.Equal(t, 4.5, series[0].WeightKg)
+	assert.Equal(t, int64(800), series[0].BottleMl)
+	assert.False(t, series[0].BelowRange)
+
+	assert.Equal(t, int64(237), series[1].BottleMl)
+	assert.True(t, series[1].BelowRange)
+	assert.ElementsMatch(t, []string{dto.IntakeFlagLowVolume, dto.IntakeFlagLowFeedCount}, series[1].Flags)
+}
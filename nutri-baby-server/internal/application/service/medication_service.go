@@ -0,0 +1,834 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+const (
+	// medicationTemplateType 给药提醒订阅消息模板类型
+	medicationTemplateType = "medication_reminder"
+	// medicationReminderGrace 计划给药时间过去超过该时长不再补发提醒(如服务重启)
+	medicationReminderGrace = 30 * time.Minute
+	// medicationDoseLead 计划时间前该时长内已记录给药时视为已按时给药，不再提醒
+	medicationDoseLead = time.Hour
+	// medicationDoseWindow 每日剂量上限的统计窗口
+	medicationDoseWindow = 24 * time.Hour
+)
+
+// MedicationService 用药与补充剂服务(药品、用药计划、给药记录和给药提醒)
+type MedicationService struct {
+	*BaseRecordService
+	medicationRepo   repository.MedicationRepository
+	doseRepo         repository.MedicationDoseRepository
+	scheduleRepo     repository.MedicationScheduleRepository
+	growthRecordRepo repository.GrowthRecordRepository
+	notifier         *BabyNotifier
+}
+
+// NewMedicationService 创建用药服务
+func NewMedicationService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	medicationRepo repository.MedicationRepository,
+	doseRepo repository.MedicationDoseRepository,
+	scheduleRepo repository.MedicationScheduleRepository,
+	growthRecordRepo repository.GrowthRecordRepository,
+	notifier *BabyNotifier,
+	logger *zap.Logger,
+) *MedicationService {
+	return &MedicationService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		medicationRepo:    medicationRepo,
+		doseRepo:          doseRepo,
+		scheduleRepo:      scheduleRepo,
+		growthRecordRepo:  growthRecordRepo,
+		notifier:          notifier,
+	}
+}
+
+// CreateMedication 添加药品/补充剂
+func (s *MedicationService) CreateMedication(ctx context.Context, openID string, req *dto.CreateMedicationRequest) (*dto.MedicationDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	medication := &entity.Medication{
+		BabyID:           babyIDInt64,
+		Name:             req.Name,
+		Kind:             req.Kind,
+		DoseUnit:         req.DoseUnit,
+		DefaultDose:      req.DefaultDose,
+		Strength:         req.Strength,
+		MaxDoseMgPerKg:   req.MaxDoseMgPerKg,
+		MaxDailyMgPerKg:  req.MaxDailyMgPerKg,
+		MaxDailyMg:       req.MaxDailyMg,
+		MaxDailyDoses:    req.MaxDailyDoses,
+		MinIntervalHours: req.MinIntervalHours,
+		Active:           true,
+		Note:             req.Note,
+		CreatedBy:        user.ID,
+		CreatedByName:    user.NickName,
+		CreatedByAvatar:  user.AvatarURL,
+	}
+	if err := s.medicationRepo.Create(ctx, medication); err != nil {
+		s.logger.Error("保存药品失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+
+	return toMedicationDTO(medication, nil, nil), nil
+}
+
+// GetMedications 获取宝宝的药品列表(含用药计划和最近一次给药时间)
+func (s *MedicationService) GetMedications(ctx context.Context, openID string, query *dto.MedicationListQuery) ([]*dto.MedicationDTO, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	medications, err := s.medicationRepo.FindByBabyID(ctx, babyIDInt64, !query.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.scheduleRepo.FindByBabyID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+	byMedication := make(map[int64][]*entity.MedicationSchedule)
+	for _, schedule := range schedules {
+		byMedication[schedule.MedicationID] = append(byMedication[schedule.MedicationID], schedule)
+	}
+
+	result := make([]*dto.MedicationDTO, 0, len(medications))
+	for _, medication := range medications {
+		lastDose, err := s.doseRepo.FindLatestByMedicationID(ctx, medication.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, toMedicationDTO(medication, byMedication[medication.ID], lastDose))
+	}
+	return result, nil
+}
+
+// UpdateMedication 更新药品，停用时同时关闭该药品的给药提醒
+func (s *MedicationService) UpdateMedication(ctx context.Context, openID, medicationID string, req *dto.UpdateMedicationRequest) (*dto.MedicationDTO, error) {
+	medication, err := s.loadMedication(ctx, openID, medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		medication.Name = *req.Name
+	}
+	if req.DefaultDose != nil {
+		medication.DefaultDose = *req.DefaultDose
+	}
+	if req.Strength != nil {
+		medication.Strength = *req.Strength
+	}
+	if req.MaxDoseMgPerKg != nil {
+		medication.MaxDoseMgPerKg = *req.MaxDoseMgPerKg
+	}
+	if req.MaxDailyMgPerKg != nil {
+		medication.MaxDailyMgPerKg = *req.MaxDailyMgPerKg
+	}
+	if req.MaxDailyMg != nil {
+		medication.MaxDailyMg = *req.MaxDailyMg
+	}
+	if req.MaxDailyDoses != nil {
+		medication.MaxDailyDoses = *req.MaxDailyDoses
+	}
+	if req.MinIntervalHours != nil {
+		medication.MinIntervalHours = *req.MinIntervalHours
+	}
+	if req.Active != nil {
+		medication.Active = *req.Active
+	}
+	if req.Note != nil {
+		medication.Note = req.Note
+	}
+
+	if err := s.medicationRepo.Update(ctx, medication); err != nil {
+		s.logger.Error("更新药品失败", zap.String("medicationID", medicationID), zap.Error(err))
+		return nil, err
+	}
+
+	schedules, err := s.findSchedules(ctx, medication)
+	if err != nil {
+		return nil, err
+	}
+	if !medication.Active {
+		for _, schedule := range schedules {
+			if !schedule.Enabled {
+				continue
+			}
+			schedule.Enabled = false
+			if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	lastDose, err := s.doseRepo.FindLatestByMedicationID(ctx, medication.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toMedicationDTO(medication, schedules, lastDose), nil
+}
+
+// DeleteMedication 删除药品及其用药计划(保留历史给药记录)
+func (s *MedicationService) DeleteMedication(ctx context.Context, openID, medicationID string) error {
+	medication, err := s.loadMedication(ctx, openID, medicationID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.scheduleRepo.DeleteByMedicationID(ctx, medication.ID); err != nil {
+		return err
+	}
+	return s.medicationRepo.Delete(ctx, medication.ID)
+}
+
+// CreateMedicationSchedule 为药品添加用药计划
+func (s *MedicationService) CreateMedicationSchedule(ctx context.Context, openID, medicationID string, req *dto.CreateMedicationScheduleRequest) (*dto.MedicationScheduleDTO, error) {
+	medication, err := s.loadMedication(ctx, openID, medicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !medication.Active {
+		return nil, errors.New(errors.ParamError, "药品已停用，无法添加用药计划")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := req.StartDate
+	if startDate == "" {
+		startDate = time.Now().Format(time.DateOnly)
+	}
+	schedule := &entity.MedicationSchedule{
+		BabyID:       medication.BabyID,
+		MedicationID: medication.ID,
+		Mode:         req.Mode,
+		StartDate:    startDate,
+		EndDate:      req.EndDate,
+		Enabled:      true,
+		CreatedBy:    user.ID,
+	}
+	switch req.Mode {
+	case entity.MedicationScheduleTimes:
+		timesOfDay, err := normalizeTimesOfDay(req.TimesOfDay)
+		if err != nil {
+			return nil, err
+		}
+		schedule.TimesOfDay = timesOfDay
+	case entity.MedicationScheduleInterval:
+		if req.IntervalHours <= 0 {
+			return nil, errors.New(errors.ParamError, "请填写给药间隔")
+		}
+		schedule.IntervalHours = req.IntervalHours
+	}
+	if schedule.EndDate != nil && *schedule.EndDate < schedule.StartDate {
+		return nil, errors.New(errors.ParamError, "结束日期不能早于开始日期")
+	}
+
+	if err := s.scheduleRepo.Create(ctx, schedule); err != nil {
+		s.logger.Error("保存用药计划失败", zap.String("medicationID", medicationID), zap.Error(err))
+		return nil, err
+	}
+
+	return toMedicationScheduleDTO(schedule), nil
+}
+
+// UpdateMedicationSchedule 更新用药计划(调整时间、结束日期或开关提醒)
+func (s *MedicationService) UpdateMedicationSchedule(ctx context.Context, openID, scheduleID string, req *dto.UpdateMedicationScheduleRequest) (*dto.MedicationScheduleDTO, error) {
+	schedule, err := s.loadSchedule(ctx, openID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TimesOfDay != nil && schedule.Mode == entity.MedicationScheduleTimes {
+		timesOfDay, err := normalizeTimesOfDay(req.TimesOfDay)
+		if err != nil {
+			return nil, err
+		}
+		schedule.TimesOfDay = timesOfDay
+	}
+	if req.IntervalHours != nil && schedule.Mode == entity.MedicationScheduleInterval {
+		schedule.IntervalHours = *req.IntervalHours
+	}
+	if req.EndDate != nil {
+		if *req.EndDate == "" {
+			schedule.EndDate = nil
+		} else {
+			if _, err := time.Parse(time.DateOnly, *req.EndDate); err != nil {
+				return nil, errors.New(errors.ParamError, "无效的日期格式")
+			}
+			endDate := *req.EndDate
+			schedule.EndDate = &endDate
+		}
+	}
+	if req.Enabled != nil {
+		schedule.Enabled = *req.Enabled
+	}
+	if schedule.EndDate != nil && *schedule.EndDate < schedule.StartDate {
+		return nil, errors.New(errors.ParamError, "结束日期不能早于开始日期")
+	}
+
+	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+		s.logger.Error("更新用药计划失败", zap.String("scheduleID", scheduleID), zap.Error(err))
+		return nil, err
+	}
+
+	return toMedicationScheduleDTO(schedule), nil
+}
+
+// DeleteMedicationSchedule 删除用药计划
+func (s *MedicationService) DeleteMedicationSchedule(ctx context.Context, openID, scheduleID string) error {
+	schedule, err := s.loadSchedule(ctx, openID, scheduleID)
+	if err != nil {
+		return err
+	}
+	return s.scheduleRepo.Delete(ctx, schedule.ID)
+}
+
+// CheckMedicationDose 给药前校验剂量(不保存记录)
+func (s *MedicationService) CheckMedicationDose(ctx context.Context, openID string, req *dto.CreateMedicationDoseRequest) (*dto.MedicationDoseCheckDTO, error) {
+	medication, err := s.loadMedication(ctx, openID, req.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, doseTime := resolveDose(medication, req)
+	if amount <= 0 {
+		return nil, errors.New(errors.ParamError, "请填写给药剂量")
+	}
+	return s.checkDose(ctx, openID, medication, amount, doseTime)
+}
+
+// CreateMedicationDose 记录给药，违反最短间隔或剂量上限时仍保存，同时返回并保存警告
+func (s *MedicationService) CreateMedicationDose(ctx context.Context, openID string, req *dto.CreateMedicationDoseRequest) (*dto.MedicationDoseDTO, error) {
+	medication, err := s.loadMedication(ctx, openID, req.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, doseTime := resolveDose(medication, req)
+	if amount <= 0 {
+		return nil, errors.New(errors.ParamError, "请填写给药剂量")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := s.checkDose(ctx, openID, medication, amount, doseTime)
+	if err != nil {
+		return nil, err
+	}
+
+	dose := &entity.MedicationDose{
+		BabyID:          medication.BabyID,
+		MedicationID:    medication.ID,
+		Time:            doseTime,
+		Amount:          amount,
+		Warnings:        check.Warnings,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	if err := s.doseRepo.Create(ctx, dose); err != nil {
+		s.logger.Error("保存给药记录失败", zap.String("medicationID", req.MedicationID), zap.Error(err))
+		return nil, err
+	}
+
+	if len(check.Warnings) > 0 {
+		s.logger.Info("给药记录存在剂量警告",
+			zap.Int64("doseID", dose.ID),
+			zap.Int64("medicationID", medication.ID),
+			zap.Strings("warnings", check.Warnings))
+	}
+
+	return toMedicationDoseDTO(dose, medication), nil
+}
+
+// GetMedicationDoses 获取给药记录列表
+func (s *MedicationService) GetMedicationDoses(ctx context.Context, openID string, query *dto.MedicationDoseListQuery) ([]*dto.MedicationDoseDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	var medicationIDInt64 int64
+	if query.MedicationID != "" {
+		medicationIDInt64, err = strconv.ParseInt(query.MedicationID, 10, 64)
+		if err != nil {
+			return nil, 0, errors.New(errors.ParamError, "无效的药品ID格式")
+		}
+	}
+
+	doses, total, err := s.doseRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		medicationIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	medications, err := s.medicationRepo.FindByBabyID(ctx, babyIDInt64, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[int64]*entity.Medication, len(medications))
+	for _, medication := range medications {
+		byID[medication.ID] = medication
+	}
+
+	result := make([]*dto.MedicationDoseDTO, 0, len(doses))
+	for _, dose := range doses {
+		result = append(result, toMedicationDoseDTO(dose, byID[dose.MedicationID]))
+	}
+	return result, total, nil
+}
+
+// DeleteMedicationDose 删除给药记录
+func (s *MedicationService) DeleteMedicationDose(ctx context.Context, openID, doseID string) error {
+	doseIDInt64, err := strconv.ParseInt(doseID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	dose, err := s.doseRepo.FindByID(ctx, doseIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(dose.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	return s.doseRepo.Delete(ctx, doseIDInt64)
+}
+
+// SendDueReminders 为到期的用药计划向宝宝的协作者推送给药提醒，返回提醒的计划数
+func (s *MedicationService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := s.scheduleRepo.FindEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, schedule := range schedules {
+		medication, err := s.medicationRepo.FindByID(ctx, schedule.MedicationID)
+		if err != nil {
+			s.logger.Warn("获取药品失败", zap.Int64("scheduleID", schedule.ID), zap.Error(err))
+			continue
+		}
+		if !medication.Active {
+			continue
+		}
+
+		lastDose, err := s.doseRepo.FindLatestByMedicationID(ctx, medication.ID)
+		if err != nil {
+			s.logger.Warn("获取最近给药记录失败", zap.Int64("medicationID", medication.ID), zap.Error(err))
+			continue
+		}
+
+		baby, err := s.babyRepo.FindByID(ctx, schedule.BabyID)
+		if err != nil {
+			s.logger.Warn("获取宝宝信息失败", zap.Int64("babyID", schedule.BabyID), zap.Error(err))
+			continue
+		}
+
+		// 每天的给药时间按宝宝所在时区计算
+		localNow := now.In(baby.Location())
+		dueAt := medicationDueAt(schedule, lastDose, localNow)
+		if dueAt == 0 {
+			continue
+		}
+
+		amount := strconv.FormatFloat(medication.DefaultDose, 'f', -1, 64)
+		data := func(locale i18n.Locale) map[string]any {
+			tip := locale.T("notify.medication.tip")
+			if medication.DefaultDose > 0 {
+				tip = locale.T("notify.medication.dose", amount, medication.DoseUnit)
+			}
+			return map[string]any{
+				"thing1": truncateRunes(locale.T("notify.medication.title", baby.Name, medication.Name), 20), // 提醒事项
+				"time2":  time.UnixMilli(dueAt).In(baby.Location()).Format(time.DateTime),                    // 计划给药时间
+				"thing3": truncateRunes(tip, 20),                                                             // 温馨提示
+			}
+		}
+		s.notifier.NotifyCaregivers(ctx, baby.ID, medicationTemplateType, data, "pages/index/index")
+
+		// 无论是否成功推送都标记, 避免同一次计划给药重复提醒
+		if err := s.scheduleRepo.MarkReminded(ctx, schedule.ID, dueAt); err != nil {
+			s.logger.Warn("标记给药提醒失败", zap.Int64("scheduleID", schedule.ID), zap.Error(err))
+		}
+		reminded++
+	}
+
+	return reminded, nil
+}
+
+// checkDose 拉取前后24小时的给药记录和最新体重，校验本次给药
+func (s *MedicationService) checkDose(ctx context.Context, openID string, medication *entity.Medication, amount float64, doseTime int64) (*dto.MedicationDoseCheckDTO, error) {
+	window := medicationDoseWindow.Milliseconds()
+	doses, err := s.doseRepo.FindByMedicationID(ctx, medication.ID, doseTime-window, doseTime+window)
+	if err != nil {
+		return nil, err
+	}
+
+	var weightKg float64
+	if medication.MaxDoseMgPerKg > 0 || medication.MaxDailyMgPerKg > 0 {
+		weightKg = s.latestWeightKg(ctx, medication.BabyID, doseTime)
+	}
+
+	return checkMedicationDose(s.RequesterLocale(ctx, openID), medication, doses, amount, doseTime, weightKg), nil
+}
+
+// latestWeightKg 给药时间前最近一次测量的体重(kg)，没有成长记录时使用宝宝档案体重
+func (s *MedicationService) latestWeightKg(ctx context.Context, babyID int64, at int64) float64 {
+	baby, err := s.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		s.logger.Warn("获取宝宝信息失败", zap.Int64("babyID", babyID), zap.Error(err))
+		return 0
+	}
+	records, _, err := s.growthRecordRepo.FindByBabyID(ctx, babyID, 0, at, 1, 20)
+	if err != nil {
+		s.logger.Warn("获取成长记录失败", zap.Int64("babyID", babyID), zap.Error(err))
+	}
+
+	points := buildWeightPoints(records, baby)
+	if len(points) == 0 {
+		return 0
+	}
+	return points[len(points)-1].weight
+}
+
+// loadMedication 校验权限并查找药品
+func (s *MedicationService) loadMedication(ctx context.Context, openID, medicationID string) (*entity.Medication, error) {
+	medicationIDInt64, err := strconv.ParseInt(medicationID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的药品ID格式")
+	}
+
+	medication, err := s.medicationRepo.FindByID(ctx, medicationIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(medication.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+	return medication, nil
+}
+
+// loadSchedule 校验权限并查找用药计划
+func (s *MedicationService) loadSchedule(ctx context.Context, openID, scheduleID string) (*entity.MedicationSchedule, error) {
+	scheduleIDInt64, err := strconv.ParseInt(scheduleID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	schedule, err := s.scheduleRepo.FindByID(ctx, scheduleIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(schedule.BabyID, 10), openID); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// findSchedules 查找药品的用药计划
+func (s *MedicationService) findSchedules(ctx context.Context, medication *entity.Medication) ([]*entity.MedicationSchedule, error) {
+	schedules, err := s.scheduleRepo.FindByBabyID(ctx, medication.BabyID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.MedicationSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.MedicationID == medication.ID {
+			result = append(result, schedule)
+		}
+	}
+	return result, nil
+}
+
+// resolveDose 确定本次给药的剂量和时间，未填写时分别取常用剂量和当前时间
+func resolveDose(medication *entity.Medication, req *dto.CreateMedicationDoseRequest) (float64, int64) {
+	amount := req.Amount
+	if amount == 0 {
+		amount = medication.DefaultDose
+	}
+	doseTime := req.Time
+	if doseTime == 0 {
+		doseTime = time.Now().UnixMilli()
+	}
+	return amount, doseTime
+}
+
+// checkMedicationDose 剂量校验规则：最短给药间隔、24小时给药次数、按体重的单次和24小时剂量上限
+// doses 为本次给药前后24小时内已记录的给药，补录历史给药时也会检查与之后记录的间隔
+func checkMedicationDose(locale i18n.Locale, medication *entity.Medication, doses []*entity.MedicationDose, amount float64, at int64, weightKg float64) *dto.MedicationDoseCheckDTO {
+	result := &dto.MedicationDoseCheckDTO{
+		Amount:   amount,
+		WeightKg: math.Round(weightKg*100) / 100,
+		Warnings: make([]string, 0),
+	}
+	window := medicationDoseWindow.Milliseconds()
+
+	// 1. 最短给药间隔(取时间最近的一次给药，不区分前后)
+	var prior *entity.MedicationDose
+	nearest := int64(-1)
+	for _, dose := range doses {
+		gap := at - dose.Time
+		if gap >= 0 && (prior == nil || dose.Time > prior.Time) {
+			prior = dose
+		}
+		if gap < 0 {
+			gap = -gap
+		}
+		if nearest < 0 || gap < nearest {
+			nearest = gap
+		}
+	}
+	if medication.MinIntervalHours > 0 {
+		minInterval := int64(medication.MinIntervalHours * float64(time.Hour.Milliseconds()))
+		if nearest >= 0 && nearest < minInterval {
+			hours := float64(nearest) / float64(time.Hour.Milliseconds())
+			result.Warnings = append(result.Warnings, locale.T("medication.warning.min_interval", hours, medication.MinIntervalHours))
+		}
+		if prior != nil && prior.Time+minInterval > at {
+			result.NextDoseTime = prior.Time + minInterval
+		}
+	}
+
+	// 2. 24小时给药次数(本次之前24小时内，含本次)
+	var dailyAmount float64
+	result.DailyDoses = 1
+	for _, dose := range doses {
+		if dose.Time <= at && at-dose.Time < window {
+			result.DailyDoses++
+			dailyAmount += dose.Amount
+		}
+	}
+	if medication.MaxDailyDoses > 0 && result.DailyDoses > medication.MaxDailyDoses {
+		result.Warnings = append(result.Warnings, locale.T("medication.warning.max_daily_doses", result.DailyDoses, medication.MaxDailyDoses))
+	}
+
+	// 3. 按毫克的剂量上限
+	usesMg := medication.MaxDoseMgPerKg > 0 || medication.MaxDailyMgPerKg > 0 || medication.MaxDailyMg > 0
+	doseMg, ok := medication.DoseMg(amount)
+	if !usesMg {
+		if ok {
+			result.AmountMg = doseMg
+		}
+		return result
+	}
+	if !ok {
+		result.Warnings = append(result.Warnings, locale.T("medication.warning.no_strength"))
+		return result
+	}
+	result.AmountMg = doseMg
+	dailyMg, _ := medication.DoseMg(dailyAmount + amount)
+	result.DailyTotalMg = math.Round(dailyMg*10) / 10
+
+	needsWeight := medication.MaxDoseMgPerKg > 0 || medication.MaxDailyMgPerKg > 0
+	if needsWeight && weightKg <= 0 {
+		result.Warnings = append(result.Warnings, locale.T("medication.warning.no_weight"))
+	}
+	if medication.MaxDoseMgPerKg > 0 && weightKg > 0 {
+		limit := medication.MaxDoseMgPerKg * weightKg
+		if doseMg > limit {
+			result.Warnings = append(result.Warnings, locale.T("medication.warning.max_dose", doseMg, limit, medication.MaxDoseMgPerKg, weightKg))
+		}
+	}
+
+	var dailyLimit float64
+	if medication.MaxDailyMgPerKg > 0 && weightKg > 0 {
+		dailyLimit = medication.MaxDailyMgPerKg * weightKg
+	}
+	if medication.MaxDailyMg > 0 && (dailyLimit == 0 || medication.MaxDailyMg < dailyLimit) {
+		dailyLimit = medication.MaxDailyMg
+	}
+	if dailyLimit > 0 && dailyMg > dailyLimit {
+		result.Warnings = append(result.Warnings, locale.T("medication.warning.max_daily", dailyMg, dailyLimit))
+	}
+
+	return result
+}
+
+// medicationDueAt 计算用药计划在 now 时需要提醒的计划给药时间，无需提醒时返回 0
+// 固定时间计划: 取今天已到且未超过补发窗口的最近一个时间点，计划时间前1小时内已给药则不提醒;
+// 间隔计划: 从最近一次给药起算，尚未给过药时不提醒
+func medicationDueAt(schedule *entity.MedicationSchedule, lastDose *entity.MedicationDose, now time.Time) int64 {
+	today := now.Format(time.DateOnly)
+	if !schedule.Enabled || today < schedule.StartDate || (schedule.EndDate != nil && today > *schedule.EndDate) {
+		return 0
+	}
+
+	var dueAt int64
+	switch schedule.Mode {
+	case entity.MedicationScheduleTimes:
+		for _, hhmm := range strings.Split(schedule.TimesOfDay, ",") {
+			due, err := time.ParseInLocation("2006-01-02 15:04", today+" "+hhmm, now.Location())
+			if err != nil || due.After(now) || now.Sub(due) > medicationReminderGrace {
+				continue
+			}
+			dueAt = max(dueAt, due.UnixMilli())
+		}
+		if dueAt > 0 && lastDose != nil && lastDose.Time >= dueAt-medicationDoseLead.Milliseconds() {
+			return 0
+		}
+	case entity.MedicationScheduleInterval:
+		if lastDose == nil || schedule.IntervalHours <= 0 {
+			return 0
+		}
+		due := time.UnixMilli(lastDose.Time).Add(time.Duration(schedule.IntervalHours * float64(time.Hour)))
+		if due.After(now) || now.Sub(due) > medicationReminderGrace {
+			return 0
+		}
+		dueAt = due.UnixMilli()
+	}
+
+	if dueAt <= schedule.LastRemindedAt {
+		return 0
+	}
+	return dueAt
+}
+
+// normalizeTimesOfDay 校验并排序每天的给药时间(HH:mm)
+func normalizeTimesOfDay(times []string) (string, error) {
+	if len(times) == 0 {
+		return "", errors.New(errors.ParamError, "请填写每天的给药时间")
+	}
+	seen := make(map[string]bool, len(times))
+	result := make([]string, 0, len(times))
+	for _, hhmm := range times {
+		if _, err := time.Parse("15:04", hhmm); err != nil {
+			return "", errors.New(errors.ParamError, "无效的给药时间格式")
+		}
+		if !seen[hhmm] {
+			seen[hhmm] = true
+			result = append(result, hhmm)
+		}
+	}
+	sort.Strings(result)
+	return strings.Join(result, ","), nil
+}
+
+// toMedicationDTO 转换药品DTO
+func toMedicationDTO(medication *entity.Medication, schedules []*entity.MedicationSchedule, lastDose *entity.MedicationDose) *dto.MedicationDTO {
+	result := &dto.MedicationDTO{
+		MedicationID:     strconv.FormatInt(medication.ID, 10),
+		BabyID:           strconv.FormatInt(medication.BabyID, 10),
+		Name:             medication.Name,
+		Kind:             medication.Kind,
+		DoseUnit:         medication.DoseUnit,
+		DefaultDose:      medication.DefaultDose,
+		Strength:         medication.Strength,
+		MaxDoseMgPerKg:   medication.MaxDoseMgPerKg,
+		MaxDailyMgPerKg:  medication.MaxDailyMgPerKg,
+		MaxDailyMg:       medication.MaxDailyMg,
+		MaxDailyDoses:    medication.MaxDailyDoses,
+		MinIntervalHours: medication.MinIntervalHours,
+		Active:           medication.Active,
+		Note:             utils.DerefString(medication.Note),
+		Schedules:        make([]*dto.MedicationScheduleDTO, 0, len(schedules)),
+		CreateBy:         strconv.FormatInt(medication.CreatedBy, 10),
+		CreateTime:       medication.CreatedAt,
+	}
+	for _, schedule := range schedules {
+		result.Schedules = append(result.Schedules, toMedicationScheduleDTO(schedule))
+	}
+	if lastDose != nil {
+		result.LastDoseTime = lastDose.Time
+	}
+	return result
+}
+
+// toMedicationScheduleDTO 转换用药计划DTO
+func toMedicationScheduleDTO(schedule *entity.MedicationSchedule) *dto.MedicationScheduleDTO {
+	timesOfDay := make([]string, 0)
+	if schedule.TimesOfDay != "" {
+		timesOfDay = strings.Split(schedule.TimesOfDay, ",")
+	}
+	return &dto.MedicationScheduleDTO{
+		ScheduleID:     strconv.FormatInt(schedule.ID, 10),
+		MedicationID:   strconv.FormatInt(schedule.MedicationID, 10),
+		Mode:           schedule.Mode,
+		TimesOfDay:     timesOfDay,
+		IntervalHours:  schedule.IntervalHours,
+		StartDate:      schedule.StartDate,
+		EndDate:        utils.DerefString(schedule.EndDate),
+		Enabled:        schedule.Enabled,
+		LastRemindedAt: schedule.LastRemindedAt,
+	}
+}
+
+// toMedicationDoseDTO 转换给药记录DTO，药品已删除时不返回名称
+func toMedicationDoseDTO(dose *entity.MedicationDose, medication *entity.Medication) *dto.MedicationDoseDTO {
+	result := &dto.MedicationDoseDTO{
+		DoseID:       strconv.FormatInt(dose.ID, 10),
+		BabyID:       strconv.FormatInt(dose.BabyID, 10),
+		MedicationID: strconv.FormatInt(dose.MedicationID, 10),
+		Time:         dose.Time,
+		Amount:       dose.Amount,
+		Warnings:     dose.Warnings,
+		Note:         utils.DerefString(dose.Note),
+		CreateBy:     strconv.FormatInt(dose.CreatedBy, 10),
+		CreateTime:   dose.CreatedAt,
+	}
+	if result.Warnings == nil {
+		result.Warnings = make([]string, 0)
+	}
+	if medication != nil {
+		result.MedicationName = medication.Name
+		result.DoseUnit = medication.DoseUnit
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+func TestCheckMedicationDose(t *testing.T) {
+	now := time.Date(2024, 3, 20, 20, 0, 0, 0, time.UTC)
+	hour := time.Hour
+
+	// 对乙酰氨基酚混悬液 160mg/5ml: 单次15mg/kg, 24小时60mg/kg, 间隔至少4小时
+	paracetamol := &entity.Medication{
+		ID:               1,
+		DoseUnit:         entity.DoseUnitMl,
+		Strength:         32,
+		MaxDoseMgPerKg:   15,
+		MaxDailyMgPerKg:  60,
+		MinIntervalHours: 4,
+		MaxDailyDoses:    4,
+	}
+	doses := []*entity.MedicationDose{
+		{Time: now.Add(-22 * hour).UnixMilli(), Amount: 4},
+		{Time: now.Add(-16 * hour).UnixMilli(), Amount: 4},
+		{Time: now.Add(-10 * hour).UnixMilli(), Amount: 4},
+		{Time: now.Add(-3 * hour).UnixMilli(), Amount: 4},
+	}
+
+	// 8kg: 单次上限120mg, 24小时上限480mg; 第5次给药(共640mg)且距上次仅3小时
+	result := checkMedicationDose(i18n.ZhCN, paracetamol, doses, 4, now.UnixMilli(), 8)
+	assert.Equal(t, 5, result.DailyDoses)
+	assert.Equal(t, 128.0, result.AmountMg)
+	assert.Equal(t, 640.0, result.DailyTotalMg)
+	assert.Equal(t, now.Add(hour).UnixMilli(), result.NextDoseTime)
+	assert.Len(t, result.Warnings, 4)
+	assert.Contains(t, result.Warnings[0], "3.0小时")
+
+	// 间隔足够、剂量在范围内时没有警告
+	result = checkMedicationDose(i18n.ZhCN, paracetamol, doses[:1], 3.5, now.UnixMilli(), 8)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, 2, result.DailyDoses)
+
+	// 没有体重记录时提示无法按体重校验
+	result = checkMedicationDose(i18n.ZhCN, paracetamol, nil, 3.5, now.UnixMilli(), 0)
+	assert.Equal(t, []string{i18n.ZhCN.T("medication.warning.no_weight")}, result.Warnings)
+
+	// 补录的给药与之后的记录间隔过近同样提示
+	result = checkMedicationDose(i18n.ZhCN, paracetamol, doses[3:], 3.5, now.Add(-5*hour).UnixMilli(), 8)
+	assert.Len(t, result.Warnings, 1)
+}
+
+func TestMedicationDueAt(t *testing.T) {
+	now := time.Date(2024, 3, 20, 20, 10, 0, 0, time.Local)
+	at := func(hh, mm int) int64 {
+		return time.Date(2024, 3, 20, hh, mm, 0, 0, time.Local).UnixMilli()
+	}
+
+	// 抗生素每天 08:00、20:00
+	twiceDaily := &entity.MedicationSchedule{
+		Mode:       entity.MedicationScheduleTimes,
+		TimesOfDay: "08:00,20:00",
+		StartDate:  "2024-03-18",
+		Enabled:    true,
+	}
+	assert.Equal(t, at(20, 0), medicationDueAt(twiceDaily, &entity.MedicationDose{Time: at(8, 5)}, now))
+	// 计划时间前1小时内已给药、已提醒过或超过补发窗口时不提醒
+	assert.Zero(t, medicationDueAt(twiceDaily, &entity.MedicationDose{Time: at(19, 30)}, now))
+	assert.Zero(t, medicationDueAt(&entity.MedicationSchedule{
+		Mode: entity.MedicationScheduleTimes, TimesOfDay: "08:00,20:00", StartDate: "2024-03-18", Enabled: true, LastRemindedAt: at(20, 0),
+	}, nil, now))
+	assert.Zero(t, medicationDueAt(twiceDaily, nil, now.Add(time.Hour)))
+
+	// 发热期间每6小时: 从最近一次给药起算
+	everySixHours := &entity.MedicationSchedule{
+		Mode:          entity.MedicationScheduleInterval,
+		IntervalHours: 6,
+		StartDate:     "2024-03-20",
+		Enabled:       true,
+	}
+	assert.Equal(t, at(20, 0), medicationDueAt(everySixHours, &entity.MedicationDose{Time: at(14, 0)}, now))
+	assert.Zero(t, medicationDueAt(everySixHours, &entity.MedicationDose{Time: at(15, 0)}, now))
+	assert.Zero(t, medicationDueAt(everySixHours, nil, now))
+
+	// 计划已结束
+	endDate := "2024-03-19"
+	twiceDaily.EndDate = &endDate
+	assert.Zero(t, medicationDueAt(twiceDaily, nil, now))
+}
+
+func TestMedicationDueAt_BabyTimezone(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+
+	// 服务器在 UTC: 2024-03-20 12:05 UTC 即上海 20:05
+	serverNow := time.Date(2024, 3, 20, 12, 5, 0, 0, time.UTC)
+	schedule := &entity.MedicationSchedule{
+		Mode:       entity.MedicationScheduleTimes,
+		TimesOfDay: "08:00,20:00",
+		StartDate:  "2024-03-20",
+		Enabled:    true,
+	}
+
+	want := time.Date(2024, 3, 20, 20, 0, 0, 0, shanghai).UnixMilli()
+	assert.Equal(t, want, medicationDueAt(schedule, nil, serverNow.In(shanghai)))
+	assert.Equal(t, "2024-03-20 20:00:00", time.UnixMilli(want).In(shanghai).Format(time.DateTime))
+
+	// 按服务器时区计算时 20:00 还没到, 不会提醒
+	assert.Zero(t, medicationDueAt(schedule, nil, serverNow))
+
+	// UTC 3月19日 23:30 在上海已是 3月20日 07:30, 开始日期按宝宝当地日期判断
+	morning := &entity.MedicationSchedule{
+		Mode:       entity.MedicationScheduleTimes,
+		TimesOfDay: "07:00",
+		StartDate:  "2024-03-20",
+		Enabled:    true,
+	}
+	serverNow = time.Date(2024, 3, 19, 23, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 20, 7, 0, 0, 0, shanghai).UnixMilli(), medicationDueAt(morning, nil, serverNow.In(shanghai)))
+	assert.Zero(t, medicationDueAt(morning, nil, serverNow))
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+// milestonePageSize 生成清单时读取里程碑记录的上限(目录条目数远小于该值)
+const milestonePageSize = 200
+
+// milestoneDelayedRuleCode 超过常见月龄仍未达成的提醒规则编码
+const milestoneDelayedRuleCode = "milestone_delayed"
+
+// MilestoneService 发育里程碑服务
+// 里程碑目录内置在 entity.MilestoneCatalog，仅达成记录单独持久化
+type MilestoneService struct {
+	*BaseRecordService
+	milestoneRecordRepo repository.MilestoneRecordRepository
+	dataCache           *cache.AnalysisDataCache
+}
+
+// NewMilestoneService 创建发育里程碑服务
+func NewMilestoneService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	milestoneRecordRepo repository.MilestoneRecordRepository,
+	dataCache *cache.AnalysisDataCache,
+	logger *zap.Logger,
+) *MilestoneService {
+	return &MilestoneService{
+		BaseRecordService:   NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		milestoneRecordRepo: milestoneRecordRepo,
+		dataCache:           dataCache,
+	}
+}
+
+// GetMilestoneCatalog 获取内置里程碑目录
+func (s *MilestoneService) GetMilestoneCatalog(ctx context.Context, openID string) []*dto.MilestoneCatalogItemDTO {
+	locale := s.RequesterLocale(ctx, openID)
+	items := make([]*dto.MilestoneCatalogItemDTO, 0, len(entity.MilestoneCatalog))
+	for i := range entity.MilestoneCatalog {
+		items = append(items, toMilestoneCatalogItemDTO(locale, &entity.MilestoneCatalog[i]))
+	}
+	return items
+}
+
+// GetMilestoneChecklist 获取宝宝按月龄的里程碑清单
+func (s *MilestoneService) GetMilestoneChecklist(ctx context.Context, openID, babyID string) (*dto.MilestoneChecklistResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	baby, birthDate, err := s.loadBaby(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, _, err := s.milestoneRecordRepo.FindByBabyID(ctx, baby.ID, 0, 0, 1, milestonePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMilestoneChecklist(s.RequesterLocale(ctx, openID), records, birthDate, time.Now()), nil
+}
+
+// GetMilestoneRecords 获取宝宝的里程碑达成记录(时间线使用)
+func (s *MilestoneService) GetMilestoneRecords(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.MilestoneRecordDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	baby, birthDate, err := s.loadBaby(ctx, query.BabyID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records, total, err := s.milestoneRecordRepo.FindByBabyID(
+		ctx,
+		baby.ID,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	locale := s.RequesterLocale(ctx, openID)
+	result := make([]*dto.MilestoneRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toMilestoneRecordDTO(locale, record, birthDate))
+	}
+	return result, total, nil
+}
+
+// CreateMilestoneRecord 记录里程碑达成
+func (s *MilestoneService) CreateMilestoneRecord(ctx context.Context, openID, babyID string, req *dto.CreateMilestoneRecordRequest) (*dto.MilestoneRecordDTO, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	if entity.FindMilestone(req.MilestoneCode) == nil {
+		return nil, errors.New(errors.ParamError, "未知的里程碑")
+	}
+
+	baby, birthDate, err := s.loadBaby(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	achievedAt := req.AchievedAt
+	if achievedAt == 0 {
+		achievedAt = time.Now().UnixMilli()
+	}
+	if err := validateMilestoneTime(achievedAt, birthDate, time.Now()); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.milestoneRecordRepo.FindByBabyAndCode(ctx, baby.ID, req.MilestoneCode)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New(errors.Conflict, "该里程碑已记录")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entity.MilestoneRecord{
+		BabyID:          baby.ID,
+		MilestoneCode:   req.MilestoneCode,
+		AchievedAt:      achievedAt,
+		Note:            req.Note,
+		PhotoURL:        req.PhotoURL,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+
+	if err := s.milestoneRecordRepo.Create(ctx, record); err != nil {
+		s.logger.Error("保存里程碑记录失败",
+			zap.String("babyID", babyID),
+			zap.String("milestoneCode", req.MilestoneCode),
+			zap.Error(err))
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+
+	return toMilestoneRecordDTO(s.RequesterLocale(ctx, openID), record, birthDate), nil
+}
+
+// UpdateMilestoneRecord 更新里程碑记录(达成时间、备注、照片)
+func (s *MilestoneService) UpdateMilestoneRecord(ctx context.Context, openID, babyID, recordID string, req *dto.UpdateMilestoneRecordRequest) (*dto.MilestoneRecordDTO, error) {
+	record, err := s.findBabyRecord(ctx, openID, babyID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, birthDate, err := s.loadBaby(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AchievedAt != nil {
+		if err := validateMilestoneTime(*req.AchievedAt, birthDate, time.Now()); err != nil {
+			return nil, err
+		}
+		record.AchievedAt = *req.AchievedAt
+	}
+	if req.Note != nil {
+		record.Note = req.Note
+	}
+	if req.PhotoURL != nil {
+		if *req.PhotoURL == "" {
+			record.PhotoURL = nil
+		} else {
+			record.PhotoURL = req.PhotoURL
+		}
+	}
+
+	if err := s.milestoneRecordRepo.Update(ctx, record); err != nil {
+		return nil, err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+
+	return toMilestoneRecordDTO(s.RequesterLocale(ctx, openID), record, birthDate), nil
+}
+
+// DeleteMilestoneRecord 删除里程碑记录(恢复为未达成)
+func (s *MilestoneService) DeleteMilestoneRecord(ctx context.Context, openID, babyID, recordID string) error {
+	record, err := s.findBabyRecord(ctx, openID, babyID, recordID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.milestoneRecordRepo.Delete(ctx, record.ID); err != nil {
+		return err
+	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
+	return nil
+}
+
+// findBabyRecord 校验权限并查找属于该宝宝的里程碑记录
+func (s *MilestoneService) findBabyRecord(ctx context.Context, openID, babyID, recordID string) (*entity.MilestoneRecord, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+	recordIDInt64, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	record, err := s.milestoneRecordRepo.FindByID(ctx, recordIDInt64)
+	if err != nil {
+		return nil, err
+	}
+	if record.BabyID != babyIDInt64 {
+		return nil, errors.New(errors.PermissionDenied, "您没有权限访问该宝宝的记录")
+	}
+	return record, nil
+}
+
+// loadBaby 查询宝宝及其出生日期(宝宝所在时区的零点)
+func (s *MilestoneService) loadBaby(ctx context.Context, babyID string) (*entity.Baby, time.Time, error) {
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, time.Time{}, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, baby.Location())
+	if err != nil {
+		return nil, time.Time{}, errors.New(errors.ParamError, "宝宝出生日期格式错误")
+	}
+	return baby, birthDate, nil
+}
+
+// validateMilestoneTime 达成时间需在出生之后且不晚于当前时间
+func validateMilestoneTime(achievedAt int64, birthDate, now time.Time) error {
+	if achievedAt < birthDate.UnixMilli() {
+		return errors.New(errors.ParamError, "达成时间不能早于出生日期")
+	}
+	if achievedAt > now.UnixMilli() {
+		return errors.New(errors.ParamError, "达成时间不能晚于当前时间")
+	}
+	return nil
+}
+
+// buildMilestoneChecklist 按当前月龄生成里程碑清单，月龄按出生日期所在时区计算
+// 超过常见月龄仍未记录的里程碑生成 info 级别的软提醒，不触发通知
+func buildMilestoneChecklist(locale i18n.Locale, records []*entity.MilestoneRecord, birthDate, now time.Time) *dto.MilestoneChecklistResponse {
+	byCode := make(map[string]*entity.MilestoneRecord, len(records))
+	for _, record := range records {
+		byCode[record.MilestoneCode] = record
+	}
+
+	ageMonths := entity.AgeInMonths(birthDate, now.In(birthDate.Location()))
+	resp := &dto.MilestoneChecklistResponse{
+		AgeMonths: ageMonths,
+		Items:     make([]*dto.MilestoneChecklistItemDTO, 0, len(entity.MilestoneCatalog)),
+		Alerts:    make([]*dto.HealthAlertDTO, 0),
+	}
+	for i := range entity.MilestoneCatalog {
+		def := &entity.MilestoneCatalog[i]
+		record := byCode[def.Code]
+		item := &dto.MilestoneChecklistItemDTO{
+			MilestoneCatalogItemDTO: *toMilestoneCatalogItemDTO(locale, def),
+			Status:                  def.StatusAt(ageMonths, record != nil),
+		}
+		switch item.Status {
+		case entity.MilestoneStatusAchieved:
+			item.Record = toMilestoneRecordDTO(locale, record, birthDate)
+			resp.Achieved++
+		case entity.MilestoneStatusDelayed:
+			resp.Alerts = append(resp.Alerts, &dto.HealthAlertDTO{
+				Source:      entity.HealthAlertSourceMilestone,
+				RuleCode:    milestoneDelayedRuleCode,
+				Level:       entity.HealthAlertLevelInfo,
+				Title:       locale.T("milestone.alert.title", item.Name),
+				Message:     locale.T("milestone.alert.message", item.Name, def.EndMonths),
+				TriggeredAt: now.UnixMilli(),
+			})
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
+// toMilestoneCatalogItemDTO 转换里程碑目录条目DTO
+func toMilestoneCatalogItemDTO(locale i18n.Locale, def *entity.MilestoneDefinition) *dto.MilestoneCatalogItemDTO {
+	return &dto.MilestoneCatalogItemDTO{
+		Code:        def.Code,
+		Name:        def.Name(locale),
+		Domain:      def.Domain,
+		StartMonths: def.StartMonths,
+		EndMonths:   def.EndMonths,
+	}
+}
+
+// toMilestoneRecordDTO 转换里程碑记录DTO
+func toMilestoneRecordDTO(locale i18n.Locale, record *entity.MilestoneRecord, birthDate time.Time) *dto.MilestoneRecordDTO {
+	result := &dto.MilestoneRecordDTO{
+		RecordID:      strconv.FormatInt(record.ID, 10),
+		BabyID:        strconv.FormatInt(record.BabyID, 10),
+		MilestoneCode: record.MilestoneCode,
+		MilestoneName: record.MilestoneCode,
+		AchievedAt:    record.AchievedAt,
+		AgeMonths:     entity.AgeInMonths(birthDate, time.UnixMilli(record.AchievedAt).In(birthDate.Location())),
+		Note:          utils.DerefString(record.Note),
+		PhotoURL:      utils.DerefString(record.PhotoURL),
+		CreateBy:      strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime:    record.CreatedAt,
+	}
+	if def := entity.FindMilestone(record.MilestoneCode); def != nil {
+		result.MilestoneName = def.Name(locale)
+		result.Domain = def.Domain
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+func TestBuildMilestoneChecklist(t *testing.T) {
+	birthDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 7, 20, 10, 0, 0, 0, time.UTC) // 满6月龄
+
+	records := []*entity.MilestoneRecord{
+		{ID: 1, BabyID: 1, MilestoneCode: "head_up_prone", AchievedAt: time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC).UnixMilli()},
+		{ID: 2, BabyID: 1, MilestoneCode: "social_smile", AchievedAt: time.Date(2024, 2, 28, 9, 0, 0, 0, time.UTC).UnixMilli()},
+	}
+
+	resp := buildMilestoneChecklist(i18n.ZhCN, records, birthDate, now)
+	assert.Equal(t, 6, resp.AgeMonths)
+	assert.Equal(t, 2, resp.Achieved)
+	assert.Len(t, resp.Items, len(entity.MilestoneCatalog))
+
+	status := make(map[string]string)
+	for _, item := range resp.Items {
+		status[item.Code] = item.Status
+	}
+	assert.Equal(t, entity.MilestoneStatusAchieved, status["head_up_prone"])
+	assert.Equal(t, entity.MilestoneStatusInWindow, status["sit_without_support"])
+	assert.Equal(t, entity.MilestoneStatusUpcoming, status["walk_alone"])
+	// 满6月龄仍未记录的翻身为软提醒
+	assert.Equal(t, entity.MilestoneStatusDelayed, status["roll_over"])
+
+	// 翻身、咕咕声、笑出声、认识亲近的人、追视、够玩具
+	assert.Len(t, resp.Alerts, 6)
+	titles := make([]string, 0, len(resp.Alerts))
+	for _, alert := range resp.Alerts {
+		assert.Equal(t, entity.HealthAlertLevelInfo, alert.Level)
+		assert.Equal(t, entity.HealthAlertSourceMilestone, alert.Source)
+		titles = append(titles, alert.Title)
+	}
+	assert.Contains(t, titles, i18n.ZhCN.T("milestone.alert.title", i18n.ZhCN.T("milestone.roll_over")))
+
+	// 达成记录带达成时的月龄
+	for _, item := range resp.Items {
+		if item.Code == "head_up_prone" {
+			assert.Equal(t, 2, item.Record.AgeMonths)
+		}
+	}
+}
+
+func TestValidateMilestoneTime(t *testing.T) {
+	birthDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 7, 20, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, validateMilestoneTime(now.UnixMilli(), birthDate, now))
+	assert.Error(t, validateMilestoneTime(birthDate.Add(-time.Hour).UnixMilli(), birthDate, now))
+	assert.Error(t, validateMilestoneTime(now.Add(time.Hour).UnixMilli(), birthDate, now))
+}
+
+func TestBuildMilestoneChecklist_UsesBirthDateZone(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	birthDate := time.Date(2024, 1, 15, 0, 0, 0, 0, loc)
+	// 宝宝所在时区已是7月15日和3月15日，UTC 仍是前一天
+	now := time.Date(2024, 7, 14, 16, 30, 0, 0, time.UTC)
+	records := []*entity.MilestoneRecord{
+		{ID: 1, BabyID: 1, MilestoneCode: "head_up_prone", AchievedAt: time.Date(2024, 3, 14, 16, 30, 0, 0, time.UTC).UnixMilli()},
+	}
+
+	resp := buildMilestoneChecklist(i18n.ZhCN, records, birthDate, now)
+	assert.Equal(t, 6, resp.AgeMonths)
+	for _, item := range resp.Items {
+		if item.Code == "head_up_prone" {
+			assert.Equal(t, 2, item.Record.AgeMonths)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+const (
+	// milkExpiringWindow 临期提醒窗口
+	milkExpiringWindow = 24 * time.Hour
+	// milkStashTemplateType 母乳临期订阅消息模板类型
+	milkStashTemplateType = "milk_stash_expiry"
+)
+
+// MilkStashService 吸奶记录与母乳库存服务
+type MilkStashService struct {
+	*BaseRecordService
+	pumpingRecordRepo repository.PumpingRecordRepository
+	milkStashRepo     repository.MilkStashRepository
+	notifier          *BabyNotifier
+}
+
+// NewMilkStashService 创建母乳库存服务
+func NewMilkStashService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	pumpingRecordRepo repository.PumpingRecordRepository,
+	milkStashRepo repository.MilkStashRepository,
+	notifier *BabyNotifier,
+	logger *zap.Logger,
+) *MilkStashService {
+	return &MilkStashService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		pumpingRecordRepo: pumpingRecordRepo,
+		milkStashRepo:     milkStashRepo,
+		notifier:          notifier,
+	}
+}
+
+// CreatePumpingRecord 创建吸奶记录，指定储存位置时同时入库
+func (s *MilkStashService) CreatePumpingRecord(ctx context.Context, openID string, req *dto.CreatePumpingRecordRequest) (*dto.PumpingRecordDTO, error) {
+	if err := s.CheckBabyAccess(ctx, req.BabyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(req.BabyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	totalVolume := req.LeftVolume + req.RightVolume
+	var bagTotal int64
+	for _, bag := range req.Bags {
+		bagTotal += bag.Volume
+	}
+	if bagTotal > totalVolume {
+		return nil, errors.New(errors.ParamError, "分装奶量不能超过本次吸奶总量")
+	}
+	if req.StorageLocation != "" && totalVolume == 0 {
+		return nil, errors.New(errors.ParamError, "吸奶量为0，无法入库")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	pumpingTime := req.PumpingTime
+	if pumpingTime == 0 {
+		pumpingTime = time.Now().UnixMilli()
+	}
+
+	record := &entity.PumpingRecord{
+		BabyID:          babyIDInt64,
+		Time:            pumpingTime,
+		Duration:        req.Duration,
+		LeftVolume:      req.LeftVolume,
+		RightVolume:     req.RightVolume,
+		TotalVolume:     totalVolume,
+		Note:            req.Note,
+		CreatedBy:       user.ID,
+		CreatedByName:   user.NickName,
+		CreatedByAvatar: user.AvatarURL,
+	}
+	if err := s.pumpingRecordRepo.Create(ctx, record); err != nil {
+		s.logger.Error("保存吸奶记录失败", zap.String("babyID", req.BabyID), zap.Error(err))
+		return nil, err
+	}
+
+	var items []*entity.MilkStashItem
+	if req.StorageLocation != "" {
+		bags := req.Bags
+		if len(bags) == 0 {
+			bags = []dto.MilkStashBagInput{{Volume: totalVolume}}
+		}
+		for _, bag := range bags {
+			items = append(items, newMilkStashItem(babyIDInt64, &record.ID, bag.Volume, pumpingTime, req.StorageLocation, bag.Note, user.ID))
+		}
+		if err := s.milkStashRepo.Create(ctx, items); err != nil {
+			s.logger.Error("母乳入库失败", zap.Int64("pumpingRecordID", record.ID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	s.logger.Info("吸奶记录创建成功",
+		zap.Int64("recordID", record.ID),
+		zap.String("babyID", req.BabyID),
+		zap.Int64("totalVolume", totalVolume),
+		zap.Int("stashItems", len(items)))
+
+	result := toPumpingRecordDTO(record)
+	now := time.Now()
+	for _, item := range items {
+		result.StashItems = append(result.StashItems, toMilkStashItemDTO(item, now))
+	}
+	return result, nil
+}
+
+// GetPumpingRecords 获取吸奶记录列表
+func (s *MilkStashService) GetPumpingRecords(ctx context.Context, openID string, query *dto.RecordListQuery) ([]*dto.PumpingRecordDTO, int64, error) {
+	if err := s.CheckBabyAccess(ctx, query.BabyID, openID); err != nil {
+		return nil, 0, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(query.BabyID, 10, 64)
+	if err != nil {
+		return nil, 0, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	records, total, err := s.pumpingRecordRepo.FindByBabyID(
+		ctx,
+		babyIDInt64,
+		query.StartTime,
+		query.EndTime,
+		query.GetPageWithDefault(),
+		query.GetPageSizeWithDefault(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*dto.PumpingRecordDTO, 0, len(records))
+	for _, record := range records {
+		result = append(result, toPumpingRecordDTO(record))
+	}
+	return result, total, nil
+}
+
+// DeletePumpingRecord 删除吸奶记录，同时删除其尚未被消耗的库存
+func (s *MilkStashService) DeletePumpingRecord(ctx context.Context, openID, recordID string) error {
+	recordIDInt64, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return errors.New(errors.ParamError, "无效的记录ID格式")
+	}
+
+	record, err := s.pumpingRecordRepo.FindByID(ctx, recordIDInt64)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CheckBabyAccess(ctx, strconv.FormatInt(record.BabyID, 10), openID); err != nil {
+		return err
+	}
+
+	if err := s.milkStashRepo.DeleteUnusedByPumpingRecordID(ctx, recordIDInt64); err != nil {
+		return err
+	}
+
+	return s.pumpingRecordRepo.Delete(ctx, recordIDInt64)
+}
+
+// GetMilkStash 获取母乳库存, includeHistory 为 true 时包含已喝完、过期和丢弃的库存
+func (s *MilkStashService) GetMilkStash(ctx context.Context, openID, babyID string, includeHistory bool) (*dto.MilkStashResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	var statuses []string
+	if !includeHistory {
+		statuses = []string{entity.MilkStashStatusAvailable}
+	}
+	items, err := s.milkStashRepo.FindByBabyID(ctx, babyIDInt64, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMilkStashResponse(items, time.Now()), nil
+}
+
+// AddMilkStashItem 手动入库
+func (s *MilkStashService) AddMilkStashItem(ctx context.Context, openID, babyID string, req *dto.CreateMilkStashItemRequest) (*dto.MilkStashItemDTO, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	user, err := s.userRepo.FindByOpenID(ctx, openID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := newMilkStashItem(babyIDInt64, nil, req.Volume, req.PumpedAt, req.StorageLocation, req.Note, user.ID)
+	now := time.Now()
+	if item.ExpiresAt <= now.UnixMilli() {
+		return nil, errors.New(errors.ParamError, "该母乳已超过储存期限，不能入库")
+	}
+
+	if err := s.milkStashRepo.Create(ctx, []*entity.MilkStashItem{item}); err != nil {
+		return nil, err
+	}
+
+	return toMilkStashItemDTO(item, now), nil
+}
+
+// MoveMilkStashItem 转移储存位置: 冷藏/室温转冷冻, 或冷冻解冻为冷藏
+func (s *MilkStashService) MoveMilkStashItem(ctx context.Context, openID, babyID, itemID string, req *dto.MoveMilkStashItemRequest) (*dto.MilkStashItemDTO, error) {
+	item, err := s.findAvailableItem(ctx, openID, babyID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case item.StorageLocation == req.StorageLocation:
+		return toMilkStashItemDTO(item, now), nil
+	case req.StorageLocation == entity.MilkStorageFridge && item.StorageLocation == entity.MilkStorageFreezer:
+		item.Thaw(now.UnixMilli())
+	case req.StorageLocation == entity.MilkStorageFreezer && item.ThawedAt != nil:
+		return nil, errors.New(errors.ParamError, "解冻后的母乳不能再次冷冻")
+	default:
+		item.StorageLocation = req.StorageLocation
+		item.ExpiresAt = item.ComputeExpiresAt()
+	}
+	// 位置变化后过期时间可能改变, 需要重新判断临期提醒
+	item.ExpiryAlertSentAt = nil
+
+	if err := s.milkStashRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return toMilkStashItemDTO(item, now), nil
+}
+
+// DiscardMilkStashItem 丢弃库存
+func (s *MilkStashService) DiscardMilkStashItem(ctx context.Context, openID, babyID, itemID string) error {
+	item, err := s.findAvailableItem(ctx, openID, babyID, itemID)
+	if err != nil {
+		return err
+	}
+
+	item.Status = entity.MilkStashStatusDiscarded
+	return s.milkStashRepo.Update(ctx, item)
+}
+
+// ConsumeForFeeding 母乳奶瓶喂养按先进先出扣减库存, 其他喂养类型忽略
+// 库存不足时只扣减现有部分(可能是现吸现喂), 不视为错误
+func (s *MilkStashService) ConsumeForFeeding(ctx context.Context, record *entity.FeedingRecord) error {
+	draw := milkStashDrawOf(record)
+	if draw.volume <= 0 {
+		return nil
+	}
+
+	consumed, err := s.milkStashRepo.ConsumeFIFO(ctx, record.BabyID, record.ID, draw.volume, draw.at)
+	if err != nil {
+		return err
+	}
+
+	if consumed < draw.volume {
+		s.logger.Info("母乳库存不足，仅部分扣减",
+			zap.Int64("feedingRecordID", record.ID),
+			zap.Int64("volume", draw.volume),
+			zap.Int64("consumed", consumed))
+	}
+	return nil
+}
+
+// RestoreForFeeding 撤销喂养记录对库存的扣减(喂养记录删除或修改时调用)
+func (s *MilkStashService) RestoreForFeeding(ctx context.Context, feedingRecordID int64) error {
+	_, err := s.milkStashRepo.RestoreByFeedingRecordID(ctx, feedingRecordID)
+	return err
+}
+
+// ProcessExpiringStash 标记过期库存，并向管理员推送24小时内即将过期的库存提醒
+func (s *MilkStashService) ProcessExpiringStash(ctx context.Context) error {
+	now := time.Now()
+
+	expired, err := s.milkStashRepo.MarkExpired(ctx, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+	if expired > 0 {
+		s.logger.Info("母乳库存已过期", zap.Int64("count", expired))
+	}
+
+	items, err := s.milkStashRepo.FindExpiringUnalerted(ctx, now.Add(milkExpiringWindow).UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	byBaby := make(map[int64][]*entity.MilkStashItem)
+	var babyIDs []int64
+	for _, item := range items {
+		if _, ok := byBaby[item.BabyID]; !ok {
+			babyIDs = append(babyIDs, item.BabyID)
+		}
+		byBaby[item.BabyID] = append(byBaby[item.BabyID], item)
+	}
+
+	for _, babyID := range babyIDs {
+		babyItems := byBaby[babyID]
+		baby, err := s.babyRepo.FindByID(ctx, babyID)
+		if err != nil {
+			s.logger.Warn("获取宝宝信息失败", zap.Int64("babyID", babyID), zap.Error(err))
+			continue
+		}
+
+		var volume int64
+		ids := make([]int64, 0, len(babyItems))
+		for _, item := range babyItems {
+			volume += item.RemainingVolume
+			ids = append(ids, item.ID)
+		}
+
+		count, expiresAt := len(babyItems), babyItems[0].ExpiresAt
+		data := func(locale i18n.Locale) map[string]any {
+			return map[string]any{
+				"thing1": truncateRunes(locale.T("notify.milk_stash.title", baby.Name), 20),   // 提醒事项
+				"time2":  time.UnixMilli(expiresAt).Format(time.DateTime),                     // 最早过期时间
+				"thing3": truncateRunes(locale.T("notify.milk_stash.tip", count, volume), 20), // 温馨提示
+			}
+		}
+		s.notifier.NotifyAdmins(ctx, babyID, milkStashTemplateType, data, "pages/record/feeding/feeding")
+
+		// 无论是否成功推送都标记, 避免每小时重复提醒; 前端库存页仍会展示临期标记
+		if err := s.milkStashRepo.MarkExpiryAlerted(ctx, ids, now.UnixMilli()); err != nil {
+			s.logger.Warn("标记母乳临期提醒失败", zap.Int64("babyID", babyID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// findAvailableItem 校验权限并查找宝宝的可用库存
+func (s *MilkStashService) findAvailableItem(ctx context.Context, openID, babyID, itemID string) (*entity.MilkStashItem, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+	itemIDInt64, err := strconv.ParseInt(itemID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的库存ID格式")
+	}
+
+	item, err := s.milkStashRepo.FindByID(ctx, itemIDInt64)
+	if err != nil {
+		return nil, err
+	}
+	if item.BabyID != babyIDInt64 {
+		return nil, errors.New(errors.PermissionDenied, "您没有权限操作该库存")
+	}
+	if item.Status != entity.MilkStashStatusAvailable {
+		return nil, errors.New(errors.ParamError, "该母乳已不在库存中")
+	}
+	return item, nil
+}
+
+// newMilkStashItem 创建库存实体并按储存位置计算过期时间
+func newMilkStashItem(babyID int64, pumpingRecordID *int64, volume, pumpedAt int64, location string, note *string, createdBy int64) *entity.MilkStashItem {
+	item := &entity.MilkStashItem{
+		BabyID:          babyID,
+		PumpingRecordID: pumpingRecordID,
+		Volume:          volume,
+		RemainingVolume: volume,
+		PumpedAt:        pumpedAt,
+		StorageLocation: location,
+		Status:          entity.MilkStashStatusAvailable,
+		Note:            note,
+		CreatedBy:       createdBy,
+	}
+	item.ExpiresAt = item.ComputeExpiresAt()
+	return item
+}
+
+// milkStashDraw 喂养记录对母乳库存的扣减(奶量和扣减时间), 非奶瓶母乳喂养时为零值
+type milkStashDraw struct {
+	volume int64
+	at     int64
+}
+
+// milkStashDrawOf 计算喂养记录需要从库存扣减的奶量
+func milkStashDrawOf(record *entity.FeedingRecord) milkStashDraw {
+	if !isBreastMilkBottle(record) {
+		return milkStashDraw{}
+	}
+	volume := int64(math.Round(bottleAmountMl(record)))
+	if volume <= 0 {
+		return milkStashDraw{}
+	}
+	return milkStashDraw{volume: volume, at: record.Time}
+}
+
+// isBreastMilkBottle 判断是否为母乳奶瓶喂养
+func isBreastMilkBottle(record *entity.FeedingRecord) bool {
+	if record.FeedingType != entity.FeedingTypeBottle {
+		return false
+	}
+	bottleType, _ := record.Detail["bottleType"].(string)
+	if bottleType == "" {
+		bottleType, _ = record.Detail["formulaType"].(string)
+	}
+	return bottleType == "breast-milk"
+}
+
+// buildMilkStashResponse 汇总库存
+func buildMilkStashResponse(items []*entity.MilkStashItem, now time.Time) *dto.MilkStashResponse {
+	resp := &dto.MilkStashResponse{
+		Items: make([]*dto.MilkStashItemDTO, 0, len(items)),
+	}
+	for _, item := range items {
+		itemDTO := toMilkStashItemDTO(item, now)
+		resp.Items = append(resp.Items, itemDTO)
+
+		if item.Status != entity.MilkStashStatusAvailable || item.ExpiresAt <= now.UnixMilli() {
+			continue
+		}
+		resp.Summary.ItemCount++
+		resp.Summary.TotalVolume += item.RemainingVolume
+		if item.StorageLocation == entity.MilkStorageFreezer {
+			resp.Summary.FreezerVolume += item.RemainingVolume
+		} else {
+			resp.Summary.FridgeVolume += item.RemainingVolume
+		}
+		if itemDTO.ExpiringSoon {
+			resp.Summary.ExpiringSoonCount++
+		}
+	}
+	return resp
+}
+
+// toPumpingRecordDTO 转换吸奶记录为DTO
+func toPumpingRecordDTO(record *entity.PumpingRecord) *dto.PumpingRecordDTO {
+	return &dto.PumpingRecordDTO{
+		RecordID:    strconv.FormatInt(record.ID, 10),
+		BabyID:      strconv.FormatInt(record.BabyID, 10),
+		PumpingTime: record.Time,
+		Duration:    record.Duration,
+		LeftVolume:  record.LeftVolume,
+		RightVolume: record.RightVolume,
+		TotalVolume: record.TotalVolume,
+		Note:        utils.DerefString(record.Note),
+		CreateBy:    strconv.FormatInt(record.CreatedBy, 10),
+		CreateTime:  record.CreatedAt,
+	}
+}
+
+// toMilkStashItemDTO 转换库存为DTO
+func toMilkStashItemDTO(item *entity.MilkStashItem, now time.Time) *dto.MilkStashItemDTO {
+	result := &dto.MilkStashItemDTO{
+		ItemID:          strconv.FormatInt(item.ID, 10),
+		Volume:          item.Volume,
+		RemainingVolume: item.RemainingVolume,
+		PumpedAt:        item.PumpedAt,
+		StorageLocation: item.StorageLocation,
+		ThawedAt:        item.ThawedAt,
+		ExpiresAt:       item.ExpiresAt,
+		Status:          item.Status,
+		Note:            utils.DerefString(item.Note),
+	}
+	if item.PumpingRecordID != nil {
+		result.PumpingRecordID = strconv.FormatInt(*item.PumpingRecordID, 10)
+	}
+	if item.Status == entity.MilkStashStatusAvailable {
+		remaining := time.UnixMilli(item.ExpiresAt).Sub(now)
+		result.ExpiringSoon = remaining > 0 && remaining <= milkExpiringWindow
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+func newStashItem(id int64, location string, pumpedAt time.Time, volume int64) *entity.MilkStashItem {
+	item := &entity.MilkStashItem{
+		ID:              id,
+		BabyID:          1,
+		Volume:          volume,
+		RemainingVolume: volume,
+		PumpedAt:        pumpedAt.UnixMilli(),
+		StorageLocation: location,
+		Status:          entity.MilkStashStatusAvailable,
+	}
+	item.ExpiresAt = item.ComputeExpiresAt()
+	return item
+}
+
+func TestDrawMilkStash(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		items         func() []*entity.MilkStashItem
+		volume        int64
+		wantDrawn     []int64 // 按扣减顺序的库存ID
+		wantVolumes   []int64
+		wantRemaining map[int64]int64
+	}{
+		{
+			name: "冷藏优先于冷冻且同类先进先出",
+			items: func() []*entity.MilkStashItem {
+				return []*entity.MilkStashItem{
+					newStashItem(1, entity.MilkStorageFreezer, now.Add(-30*24*time.Hour), 100),
+					newStashItem(2, entity.MilkStorageFridge, now.Add(-24*time.Hour), 60),
+					newStashItem(3, entity.MilkStorageFridge, now.Add(-48*time.Hour), 60),
+				}
+			},
+			volume:        150,
+			wantDrawn:     []int64{3, 2, 1},
+			wantVolumes:   []int64{60, 60, 30},
+			wantRemaining: map[int64]int64{1: 70, 2: 0, 3: 0},
+		},
+		{
+			name: "部分扣减只动第一袋",
+			items: func() []*entity.MilkStashItem {
+				return []*entity.MilkStashItem{
+					newStashItem(1, entity.MilkStorageFridge, now.Add(-24*time.Hour), 120),
+					newStashItem(2, entity.MilkStorageFridge, now.Add(-12*time.Hour), 120),
+				}
+			},
+			volume:        90,
+			wantDrawn:     []int64{1},
+			wantVolumes:   []int64{90},
+			wantRemaining: map[int64]int64{1: 30, 2: 120},
+		},
+		{
+			name: "库存不足时扣完为止",
+			items: func() []*entity.MilkStashItem {
+				return []*entity.MilkStashItem{
+					newStashItem(1, entity.MilkStorageRoom, now.Add(-time.Hour), 40),
+				}
+			},
+			volume:        100,
+			wantDrawn:     []int64{1},
+			wantVolumes:   []int64{40},
+			wantRemaining: map[int64]int64{1: 0},
+		},
+		{
+			name: "过期、未吸出和不可用的库存不参与扣减",
+			items: func() []*entity.MilkStashItem {
+				expired := newStashItem(1, entity.MilkStorageRoom, now.Add(-5*time.Hour), 50)
+				future := newStashItem(2, entity.MilkStorageFridge, now.Add(time.Hour), 50)
+				discarded := newStashItem(3, entity.MilkStorageFridge, now.Add(-time.Hour), 50)
+				discarded.Status = entity.MilkStashStatusDiscarded
+				return []*entity.MilkStashItem{expired, future, discarded}
+			},
+			volume:        50,
+			wantRemaining: map[int64]int64{1: 50, 2: 50, 3: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := tt.items()
+			drawn, usages := entity.DrawMilkStash(items, tt.volume, now.UnixMilli())
+
+			var drawnIDs, volumes []int64
+			for i, item := range drawn {
+				drawnIDs = append(drawnIDs, item.ID)
+				volumes = append(volumes, usages[i].Volume)
+				assert.Equal(t, item.ID, usages[i].StashItemID)
+			}
+			assert.Equal(t, tt.wantDrawn, drawnIDs)
+			assert.Equal(t, tt.wantVolumes, volumes)
+			for _, item := range items {
+				assert.Equal(t, tt.wantRemaining[item.ID], item.RemainingVolume, "item %d", item.ID)
+				if item.RemainingVolume == 0 {
+					assert.Equal(t, entity.MilkStashStatusConsumed, item.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestMilkStashExpiry(t *testing.T) {
+	pumpedAt := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		location string
+		thawedAt *time.Time
+		want     time.Time
+	}{
+		{"室温4小时", entity.MilkStorageRoom, nil, pumpedAt.Add(4 * time.Hour)},
+		{"冷藏4天", entity.MilkStorageFridge, nil, pumpedAt.Add(4 * 24 * time.Hour)},
+		{"冷冻6个月", entity.MilkStorageFreezer, nil, pumpedAt.Add(180 * 24 * time.Hour)},
+		{"解冻后24小时", entity.MilkStorageFridge, ptrTime(pumpedAt.Add(30 * 24 * time.Hour)), pumpedAt.Add(31 * 24 * time.Hour)},
+		{"解冻不超过冷冻期限", entity.MilkStorageFridge, ptrTime(pumpedAt.Add(180*24*time.Hour - time.Hour)), pumpedAt.Add(180 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.MilkStashItem{PumpedAt: pumpedAt.UnixMilli(), StorageLocation: tt.location}
+			if tt.thawedAt != nil {
+				thawedAt := tt.thawedAt.UnixMilli()
+				item.ThawedAt = &thawedAt
+			}
+			assert.Equal(t, tt.want.UnixMilli(), item.ComputeExpiresAt())
+		})
+	}
+}
+
+func TestMilkStashUsageRevert(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	frozenExpiresAt := newStashItem(1, entity.MilkStorageFreezer, now.Add(-30*24*time.Hour), 100).ExpiresAt
+
+	tests := []struct {
+		name         string
+		restoreState bool
+		wantLocation string
+		wantExpires  int64
+		wantThawed   bool
+	}{
+		{"没有其他扣减时撤销解冻", true, entity.MilkStorageFreezer, frozenExpiresAt, false},
+		{"还有其他扣减时保持解冻", false, entity.MilkStorageFridge, now.Add(24 * time.Hour).UnixMilli(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newStashItem(1, entity.MilkStorageFreezer, now.Add(-30*24*time.Hour), 100)
+			_, usages := entity.DrawMilkStash([]*entity.MilkStashItem{item}, 100, now.UnixMilli())
+			assert.Len(t, usages, 1)
+			assert.Equal(t, entity.MilkStashStatusConsumed, item.Status)
+			assert.Equal(t, entity.MilkStorageFridge, item.StorageLocation)
+
+			usages[0].Revert(item, tt.restoreState)
+
+			assert.Equal(t, int64(100), item.RemainingVolume)
+			assert.Equal(t, entity.MilkStashStatusAvailable, item.Status)
+			assert.Equal(t, tt.wantLocation, item.StorageLocation)
+			assert.Equal(t, tt.wantExpires, item.ExpiresAt)
+			assert.Equal(t, tt.wantThawed, item.ThawedAt != nil)
+		})
+	}
+}
+
+func TestMilkStashDrawOf_SkipsUnchangedEdits(t *testing.T) {
+	record := &entity.FeedingRecord{
+		FeedingType: entity.FeedingTypeBottle,
+		Amount:      120,
+		Time:        1714550400000,
+		Detail:      entity.FeedingDetail{"type": "bottle", "bottleType": "breast-milk", "unit": "ml"},
+	}
+	before := milkStashDrawOf(record)
+	assert.Equal(t, milkStashDraw{volume: 120, at: 1714550400000}, before)
+
+	// 只修改备注不影响库存扣减
+	record.Detail["note"] = "喝得很好"
+	assert.Equal(t, before, milkStashDrawOf(record))
+
+	record.Amount = 90
+	assert.NotEqual(t, before, milkStashDrawOf(record))
+
+	// 改为配方奶后不再扣减库存
+	record.Detail["bottleType"] = "formula"
+	assert.Equal(t, milkStashDraw{}, milkStashDrawOf(record))
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
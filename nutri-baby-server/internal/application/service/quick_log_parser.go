@@ -0,0 +1,697 @@
+package service
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+)
+
+// quickLogFutureTolerance 钟点解析时允许晚于当前时间的误差(客户端与服务器时钟偏差)
+const quickLogFutureTolerance = 5 * time.Minute
+
+// 规则解析使用的正则(输入已转为小写)
+var (
+	quickLogSegmentSeparator = regexp.MustCompile(`[,，;；。、\n]+|然后|\s+then\s+`)
+	quickLogAboutPattern     = regexp.MustCompile(`(\d|点|分|钟|时|半|ml|g|克)\s*左右`)
+
+	quickLogRelativePattern = regexp.MustCompile(`(\d+(?:\.\d+)?|半)\s*(?:个)?\s*(半)?\s*(小时|钟头|hours|hour|hrs|hr|h|分钟|minutes|minute|mins|min)\s*(?:以前|之前|前|ago)`)
+	quickLogJustNowPattern  = regexp.MustCompile(`刚才|刚刚|just now|刚`)
+	quickLogColonPattern    = regexp.MustCompile(`(凌晨|早上|早晨|上午|中午|下午|傍晚|晚上|夜里|半夜)?\s*(\d{1,2})[:：](\d{2})\s*(a\.m\.|p\.m\.|am|pm)?`)
+	quickLogMeridiemPattern = regexp.MustCompile(`\b(\d{1,2})\s*(a\.m\.|p\.m\.|am|pm)`)
+	quickLogDianPattern     = regexp.MustCompile(`(凌晨|早上|早晨|上午|中午|下午|傍晚|晚上|夜里|半夜)?\s*(\d{1,2})\s*点\s*(半|一刻|三刻|(\d{1,2})\s*分?)?`)
+	quickLogAtPattern       = regexp.MustCompile(`\bat\s+(\d{1,2})\b`)
+
+	quickLogHalfHoursPattern = regexp.MustCompile(`(\d+)\s*个?半\s*(?:小时|钟头)`)
+	quickLogHoursPattern     = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:个)?\s*(?:小时|钟头|hours|hour|hrs|hr|h)(?:[^a-z]|$)`)
+	quickLogHalfHourPattern  = regexp.MustCompile(`半\s*(?:个)?\s*(?:小时|钟头)|half an hour|half hour`)
+	quickLogMinutesPattern   = regexp.MustCompile(`(\d+)\s*(?:分钟|minutes|minute|mins|min|m\b)`)
+
+	quickLogMlPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:ml|毫升|cc)`)
+	quickLogOzPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:oz|盎司)`)
+
+	quickLogSideMinutesPattern    = regexp.MustCompile(`(left|right|左|右)[^\d,]{0,12}?(\d+)\s*(?:分钟|分|minutes|minute|mins|min|m\b)`)
+	quickLogMinutesSidePattern    = regexp.MustCompile(`(\d+)\s*(?:minutes|minute|mins|min)\s*(?:on\s+)?(?:the\s+)?(left|right)`)
+	quickLogNursingPattern        = regexp.MustCompile(`breast|nurs|boob|亲喂|吃了?奶|喂了?奶`)
+	quickLogSideKeywordPattern    = regexp.MustCompile(`left|right|左|右|母乳`)
+	quickLogBreastMilkPattern     = regexp.MustCompile(`breast\s*milk|breastmilk|expressed|pumped|母乳|奶水`)
+	quickLogFormulaPattern        = regexp.MustCompile(`formula|奶粉|配方`)
+	quickLogWeightPattern         = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(kg|公斤|千克|斤|lbs|lb|磅)`)
+	quickLogGramWeightPattern     = regexp.MustCompile(`(?:体重|weight|weighs|weighed)[^\d]{0,6}(\d+(?:\.\d+)?)\s*(?:g|克)`)
+	quickLogLengthPattern         = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:cm|厘米|公分)`)
+	quickLogHeadKeywordPattern    = regexp.MustCompile(`头围|head`)
+	quickLogDiaperKeywordPattern  = regexp.MustCompile(`尿布|尿不湿|纸尿裤|diaper|nappy`)
+	quickLogPeePattern            = regexp.MustCompile(`pee|wet|urine|尿|嘘嘘|小便`)
+	quickLogPoopPattern           = regexp.MustCompile(`poop|poo|dirty|stool|\bbm\b|便|拉|屎|粑粑|臭臭`)
+	quickLogBothPattern           = regexp.MustCompile(`\bboth\b|都有|又拉又尿|又尿又拉`)
+	quickLogSleepKeywordPattern   = regexp.MustCompile(`sleep|slept|nap|asleep|睡|觉`)
+	quickLogWakeKeywordPattern    = regexp.MustCompile(`woke|wake|awake|醒`)
+	quickLogWakeContextPattern    = regexp.MustCompile(`woke|wake|醒|到|至|until|till`)
+	quickLogNightKeywordPattern   = regexp.MustCompile(`night|bedtime|夜|晚上|昨晚`)
+	quickLogNapKeywordPattern     = regexp.MustCompile(`nap|午睡|小睡|午觉`)
+	quickLogFoodKeywordPattern    = regexp.MustCompile(`辅食|solids|solid food|吃了|\bate\b`)
+	quickLogFoodNoisePattern      = regexp.MustCompile(`辅食|solids|solid food|吃了|喂了|\bate\b|\bfed\b|一些|一点|\bsome\b|\bof\b|\bat\b|(\d+(?:\.\d+)?)\s*(?:g|克|勺|spoons|spoon)`)
+	quickLogFoodNameTrimCharacter = " :：.!！?？\"'“”"
+)
+
+// quickLogPooColors 大便颜色关键词(按匹配优先级排列)
+var quickLogPooColors = []struct{ keyword, color string }{
+	{"灰白", "white"}, {"white", "white"}, {"白", "white"},
+	{"yellow", "yellow"}, {"黄", "yellow"},
+	{"green", "green"}, {"绿", "green"},
+	{"brown", "brown"}, {"棕", "brown"}, {"褐", "brown"}, {"咖啡", "brown"},
+	{"black", "black"}, {"黑", "black"},
+	{"red", "red"}, {"blood", "red"}, {"红", "red"}, {"血", "red"},
+}
+
+// quickLogPooTextures 大便性状关键词(按匹配优先级排列)
+var quickLogPooTextures = []struct{ keyword, texture string }{
+	{"watery", "watery"}, {"水样", "watery"}, {"稀水", "watery"}, {"水便", "watery"},
+	{"runny", "loose"}, {"loose", "loose"}, {"稀", "loose"},
+	{"mushy", "paste"}, {"paste", "paste"}, {"糊", "paste"},
+	{"soft", "soft"}, {"软", "soft"},
+	{"formed", "formed"}, {"成形", "formed"}, {"成型", "formed"},
+	{"hard", "hard"}, {"硬", "hard"}, {"干", "hard"},
+}
+
+// quickLogDraft 规则解析出的记录草稿
+type quickLogDraft struct {
+	entry    chain.QuickLogEntry
+	at       time.Time
+	timed    bool     // 文本中明确给出了时间
+	warnings []string // 提示的消息键
+}
+
+// parseQuickLogByRules 规则解析常见说法，未配置AI提供商或模型解析失败时使用
+// now 为宝宝所在时区的当前时间；返回记录草稿和未能识别的片段
+func parseQuickLogByRules(text string, now time.Time) ([]quickLogDraft, []string) {
+	var (
+		drafts   []quickLogDraft
+		unparsed []string
+	)
+
+	for _, raw := range quickLogSegmentSeparator.Split(text, -1) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		seg := normalizeQuickLogText(raw)
+		times, rest := extractQuickLogTimes(seg, now)
+
+		draft, ok := classifyQuickLogSegment(rest, seg, times, now)
+		if !ok {
+			unparsed = append(unparsed, raw)
+			continue
+		}
+
+		// 未给出时间的片段沿用上一条记录的时间(同一次描述中的连续事件)
+		if !draft.timed {
+			if len(drafts) > 0 {
+				draft.at = drafts[len(drafts)-1].at
+			} else {
+				draft.at = now
+				draft.warnings = append(draft.warnings, "quick_log.warning.no_time")
+			}
+		}
+
+		// "左边10分钟，右边8分钟"、"换尿布，黄色稀便" 合并为同一条记录
+		if len(drafts) > 0 && !draft.timed && mergeQuickLogDraft(&drafts[len(drafts)-1], draft) {
+			continue
+		}
+		drafts = append(drafts, draft)
+	}
+
+	for i := range drafts {
+		drafts[i].entry.Time = drafts[i].at.Format(chain.QuickLogTimeLayout)
+		if drafts[i].entry.Type == "diaper" && drafts[i].entry.DiaperType == "" {
+			drafts[i].entry.DiaperType = "pee"
+			drafts[i].warnings = append(drafts[i].warnings, "quick_log.warning.default_pee")
+		}
+	}
+	return drafts, unparsed
+}
+
+// normalizeQuickLogText 统一大小写和常见中文数字写法
+func normalizeQuickLogText(text string) string {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("两个", "2个", "两小时", "2小时", "两点", "2点", "两分钟", "2分钟", "十分钟", "10分钟").Replace(text)
+	return quickLogAboutPattern.ReplaceAllString(text, "$1")
+}
+
+// classifyQuickLogSegment 按关键词和单位判断片段对应的记录类型
+// rest 为去除时间表达后的文本，seg 为完整文本(用于判断上下午、夜间等语境)
+func classifyQuickLogSegment(rest, seg string, times []quickLogTime, now time.Time) (quickLogDraft, bool) {
+	draft := quickLogDraft{}
+	if len(times) > 0 {
+		draft.at = times[0].at
+		draft.timed = true
+	}
+
+	// 生长测量: 体重/身高/头围带单位
+	if entry, ok := parseQuickLogGrowth(rest); ok {
+		draft.entry = entry
+		return draft, true
+	}
+
+	// 奶瓶: 带奶量单位
+	if amount, ok := parseQuickLogAmountMl(rest); ok {
+		bottleType := "formula"
+		if quickLogBreastMilkPattern.MatchString(rest) && !quickLogFormulaPattern.MatchString(rest) {
+			bottleType = "breast-milk"
+		} else if !quickLogFormulaPattern.MatchString(rest) {
+			draft.warnings = append(draft.warnings, "quick_log.warning.default_formula")
+		}
+		draft.entry = chain.QuickLogEntry{Type: "feeding", FeedingType: "bottle", AmountMl: amount, BottleType: bottleType}
+		return draft, true
+	}
+
+	// 亲喂: 亲喂关键词，或提到左右侧且与尿布、睡眠无关
+	isNursing := quickLogNursingPattern.MatchString(rest)
+	if !isNursing && quickLogSideKeywordPattern.MatchString(rest) {
+		isNursing = !quickLogDiaperKeywordPattern.MatchString(rest) && !quickLogSleepKeywordPattern.MatchString(rest)
+	}
+	if isNursing {
+		draft.entry = parseQuickLogBreast(rest)
+		return draft, true
+	}
+
+	// 尿布
+	if entry, ok := parseQuickLogDiaper(rest); ok {
+		draft.entry = entry
+		return draft, true
+	}
+
+	// 睡眠
+	if quickLogSleepKeywordPattern.MatchString(rest) || quickLogWakeKeywordPattern.MatchString(rest) {
+		return parseQuickLogSleep(rest, seg, times, now)
+	}
+
+	// 辅食
+	if quickLogFoodKeywordPattern.MatchString(rest) {
+		name := strings.Trim(strings.Join(strings.Fields(quickLogFoodNoisePattern.ReplaceAllString(rest, " ")), " "), quickLogFoodNameTrimCharacter)
+		if name == "" {
+			return draft, false
+		}
+		draft.entry = chain.QuickLogEntry{Type: "feeding", FeedingType: "food", FoodName: name}
+		return draft, true
+	}
+
+	return draft, false
+}
+
+// parseQuickLogGrowth 解析体重、身高、头围
+func parseQuickLogGrowth(text string) (chain.QuickLogEntry, bool) {
+	entry := chain.QuickLogEntry{Type: "growth"}
+
+	if m := quickLogWeightPattern.FindStringSubmatch(text); m != nil {
+		value, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "斤":
+			value /= 2
+		case "lb", "lbs", "磅":
+			value *= 0.4536
+		}
+		entry.WeightKg = roundToTwoDecimals(value)
+	} else if m := quickLogGramWeightPattern.FindStringSubmatch(text); m != nil {
+		value, _ := strconv.ParseFloat(m[1], 64)
+		entry.WeightKg = roundToTwoDecimals(value / 1000)
+	}
+
+	// 数值前若有头围关键词则为头围，否则为身高
+	for _, loc := range quickLogLengthPattern.FindAllStringSubmatchIndex(text, -1) {
+		value, _ := strconv.ParseFloat(text[loc[2]:loc[3]], 64)
+		prefix := []rune(text[:loc[0]])
+		if len(prefix) > 8 {
+			prefix = prefix[len(prefix)-8:]
+		}
+		if quickLogHeadKeywordPattern.MatchString(string(prefix)) {
+			entry.HeadCircumference = value
+		} else {
+			entry.HeightCm = value
+		}
+	}
+
+	return entry, entry.WeightKg > 0 || entry.HeightCm > 0 || entry.HeadCircumference > 0
+}
+
+// parseQuickLogAmountMl 解析奶量，盎司换算为毫升
+func parseQuickLogAmountMl(text string) (float64, bool) {
+	if m := quickLogMlPattern.FindStringSubmatch(text); m != nil {
+		value, _ := strconv.ParseFloat(m[1], 64)
+		return value, value > 0
+	}
+	if m := quickLogOzPattern.FindStringSubmatch(text); m != nil {
+		value, _ := strconv.ParseFloat(m[1], 64)
+		return math.Round(value * mlPerOunce), value > 0
+	}
+	return 0, false
+}
+
+// parseQuickLogBreast 解析亲喂侧别和时长
+func parseQuickLogBreast(text string) chain.QuickLogEntry {
+	entry := chain.QuickLogEntry{Type: "feeding", FeedingType: "breast"}
+
+	addSide := func(side string, minutes int) {
+		switch side {
+		case "left", "左":
+			entry.LeftMinutes += minutes
+		case "right", "右":
+			entry.RightMinutes += minutes
+		}
+	}
+	for _, m := range quickLogSideMinutesPattern.FindAllStringSubmatch(text, -1) {
+		minutes, _ := strconv.Atoi(m[2])
+		addSide(m[1], minutes)
+	}
+	if entry.LeftMinutes == 0 && entry.RightMinutes == 0 {
+		for _, m := range quickLogMinutesSidePattern.FindAllStringSubmatch(text, -1) {
+			minutes, _ := strconv.Atoi(m[1])
+			addSide(m[2], minutes)
+		}
+	}
+
+	hasLeft := entry.LeftMinutes > 0 || strings.Contains(text, "left") || strings.Contains(text, "左")
+	hasRight := entry.RightMinutes > 0 || strings.Contains(text, "right") || strings.Contains(text, "右")
+	switch {
+	case hasLeft && hasRight || strings.Contains(text, "both") || strings.Contains(text, "两边") || strings.Contains(text, "两侧"):
+		entry.Side = "both"
+	case hasLeft:
+		entry.Side = "left"
+	case hasRight:
+		entry.Side = "right"
+	}
+
+	// 未区分左右的时长: 单侧时归到该侧，否则记为总时长
+	if entry.LeftMinutes == 0 && entry.RightMinutes == 0 {
+		if minutes, ok := parseQuickLogMinutes(text); ok {
+			switch entry.Side {
+			case "left":
+				entry.LeftMinutes = minutes
+			case "right":
+				entry.RightMinutes = minutes
+			default:
+				entry.DurationMinutes = minutes
+			}
+		}
+	}
+	return entry
+}
+
+// mergeQuickLogDraft 将补充说明上一条记录的片段合并进去
+// 亲喂: 上一条只记录了一侧，本片段记录另一侧；尿布: 其中一条未说明大小便
+func mergeQuickLogDraft(prev *quickLogDraft, next quickLogDraft) bool {
+	p, n := &prev.entry, next.entry
+	if p.Type == "diaper" && n.Type == "diaper" {
+		if p.DiaperType != "" && n.DiaperType != "" {
+			return false
+		}
+		if p.DiaperType == "" {
+			p.DiaperType, p.PooColor, p.PooTexture = n.DiaperType, n.PooColor, n.PooTexture
+		}
+		return true
+	}
+
+	if p.FeedingType != "breast" || n.FeedingType != "breast" || p.DurationMinutes > 0 || n.DurationMinutes > 0 {
+		return false
+	}
+	switch {
+	case p.Side == "left" && n.Side == "right" && p.RightMinutes == 0:
+		p.RightMinutes = n.RightMinutes
+	case p.Side == "right" && n.Side == "left" && p.LeftMinutes == 0:
+		p.LeftMinutes = n.LeftMinutes
+	default:
+		return false
+	}
+	p.Side = "both"
+	return true
+}
+
+// parseQuickLogDiaper 解析大小便类型、颜色和性状
+func parseQuickLogDiaper(text string) (chain.QuickLogEntry, bool) {
+	isDiaper := quickLogDiaperKeywordPattern.MatchString(text)
+	stripped := quickLogDiaperKeywordPattern.ReplaceAllString(text, " ")
+
+	pee := quickLogPeePattern.MatchString(stripped)
+	poop := quickLogPoopPattern.MatchString(strings.ReplaceAll(stripped, "小便", " "))
+	if !isDiaper && !pee && !poop {
+		return chain.QuickLogEntry{}, false
+	}
+
+	entry := chain.QuickLogEntry{Type: "diaper"}
+	switch {
+	case quickLogBothPattern.MatchString(stripped) || pee && poop:
+		entry.DiaperType = "both"
+	case poop:
+		entry.DiaperType = "poop"
+	case pee:
+		entry.DiaperType = "pee"
+	}
+
+	if entry.DiaperType == "poop" || entry.DiaperType == "both" {
+		for _, c := range quickLogPooColors {
+			if strings.Contains(stripped, c.keyword) {
+				entry.PooColor = c.color
+				break
+			}
+		}
+		for _, t := range quickLogPooTextures {
+			if strings.Contains(stripped, t.keyword) {
+				entry.PooTexture = t.texture
+				break
+			}
+		}
+	}
+	return entry, true
+}
+
+// parseQuickLogSleep 解析睡眠时间段
+// 两个钟点视为入睡和醒来；一个钟点按上下文("睡到"、"醒")判断是入睡还是醒来时间；只有时长时视为刚刚醒来
+func parseQuickLogSleep(rest, seg string, times []quickLogTime, now time.Time) (quickLogDraft, bool) {
+	draft := quickLogDraft{entry: chain.QuickLogEntry{Type: "sleep"}}
+	minutes, hasMinutes := parseQuickLogMinutes(rest)
+	duration := time.Duration(minutes) * time.Minute
+
+	var start, end time.Time
+	switch {
+	case len(times) >= 2:
+		start, end = times[0].at, times[1].at
+		if !end.After(start) {
+			start = start.AddDate(0, 0, -1)
+		}
+	case len(times) == 1 && isQuickLogWakeTime(seg, times[0]):
+		if !hasMinutes {
+			// 只知道醒来时间，无法确定入睡时间
+			return draft, false
+		}
+		end = times[0].at
+		start = end.Add(-duration)
+	case len(times) == 1:
+		start = times[0].at
+		if hasMinutes {
+			end = start.Add(duration)
+		}
+	case hasMinutes:
+		end = now
+		start = end.Add(-duration)
+	}
+
+	if !start.IsZero() {
+		draft.at = start
+		draft.timed = true
+	}
+	if !end.IsZero() {
+		draft.entry.EndTime = end.Format(chain.QuickLogTimeLayout)
+		draft.entry.DurationMinutes = int(end.Sub(start).Minutes())
+	}
+
+	switch {
+	case quickLogNapKeywordPattern.MatchString(seg):
+		draft.entry.SleepType = "nap"
+	case quickLogNightKeywordPattern.MatchString(seg):
+		draft.entry.SleepType = "night"
+	}
+	return draft, true
+}
+
+// isQuickLogWakeTime 判断钟点是否为醒来时间，如 "睡到6点"、"6点醒"、"woke at 6"
+func isQuickLogWakeTime(seg string, t quickLogTime) bool {
+	before := []rune(seg[:t.pos])
+	if len(before) > 6 {
+		before = before[len(before)-6:]
+	}
+	after := strings.TrimSpace(seg[t.end:])
+	return quickLogWakeContextPattern.MatchString(string(before)) || strings.HasPrefix(after, "醒") || strings.HasPrefix(after, "woke")
+}
+
+// parseQuickLogMinutes 解析时长(分钟)，如 "1小时20分钟"、"1个半小时"、"1h30m"、"45 min"
+func parseQuickLogMinutes(text string) (int, bool) {
+	total := 0.0
+	found := false
+
+	if m := quickLogHalfHoursPattern.FindStringSubmatch(text); m != nil {
+		hours, _ := strconv.Atoi(m[1])
+		total += float64(hours)*60 + 30
+		found = true
+	} else if m := quickLogHoursPattern.FindStringSubmatch(text); m != nil {
+		hours, _ := strconv.ParseFloat(m[1], 64)
+		total += hours * 60
+		found = true
+	} else if quickLogHalfHourPattern.MatchString(text) {
+		total += 30
+		found = true
+	}
+
+	if m := quickLogMinutesPattern.FindStringSubmatch(text); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		total += float64(minutes)
+		found = true
+	}
+
+	return int(math.Round(total)), found && total > 0
+}
+
+// quickLogTime 文本中识别出的时间
+type quickLogTime struct {
+	at  time.Time
+	pos int // 在文本中的起止位置，用于保持书写顺序和判断上下文
+	end int
+}
+
+// quickLogClock 文本中的钟点，尚未换算为具体时间
+type quickLogClock struct {
+	start, end   int
+	hour, minute int
+	meridiem     string // am, pm
+	period       string // 上午、下午、晚上等时段
+}
+
+// quickLogDayMarker 日期/时段标记，如 "昨晚"、"今天"、"this morning"
+type quickLogDayMarker struct {
+	pos       int
+	dayOffset int
+	explicit  bool // 是否指明了日期
+	period    string
+	used      bool
+}
+
+// quickLogDayMarkerPattern 日期/时段标记
+var quickLogDayMarkerPattern = regexp.MustCompile(`前天|昨天|昨晚|昨夜|今天|今晚|今早|yesterday|last night|today|tonight|this morning|morning|afternoon|evening`)
+
+// newQuickLogDayMarker 解析日期/时段标记
+func newQuickLogDayMarker(pos int, word string) quickLogDayMarker {
+	marker := quickLogDayMarker{pos: pos, explicit: true}
+	switch word {
+	case "前天":
+		marker.dayOffset = -2
+	case "昨天", "yesterday":
+		marker.dayOffset = -1
+	case "昨晚", "昨夜", "last night":
+		marker.dayOffset, marker.period = -1, "晚上"
+	case "今晚", "tonight":
+		marker.period = "晚上"
+	case "今早", "this morning":
+		marker.period = "早上"
+	case "morning":
+		marker.explicit, marker.period = false, "早上"
+	case "afternoon":
+		marker.explicit, marker.period = false, "下午"
+	case "evening":
+		marker.explicit, marker.period = false, "晚上"
+	}
+	return marker
+}
+
+// extractQuickLogTimes 识别文本中的时间表达(相对时间和钟点)，返回按书写顺序排列的时间和去除时间表达后的文本
+// "昨晚"、"今天" 等标记只作用于其后(没有时再找其前)最近的一个钟点，如 "昨晚9点睡到6点半" 中的 6点半 不受 "昨晚" 影响
+func extractQuickLogTimes(text string, now time.Time) ([]quickLogTime, string) {
+	var times []quickLogTime
+	rest := []byte(text)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			rest[i] = ' '
+		}
+	}
+
+	// 相对时间: "半小时前"、"20 min ago"
+	for _, loc := range quickLogRelativePattern.FindAllStringSubmatchIndex(text, -1) {
+		amount := 0.5
+		if value := text[loc[2]:loc[3]]; value != "半" {
+			amount, _ = strconv.ParseFloat(value, 64)
+		}
+		if loc[4] >= 0 {
+			amount += 0.5
+		}
+		unit := time.Minute
+		switch text[loc[6]:loc[7]] {
+		case "小时", "钟头", "hours", "hour", "hrs", "hr", "h":
+			unit = time.Hour
+		}
+		times = append(times, quickLogTime{at: now.Add(-time.Duration(amount * float64(unit))), pos: loc[0], end: loc[1]})
+		blank(loc[0], loc[1])
+	}
+
+	// 钟点: "3:10"、"下午3点半"、"3pm"、"at 3"
+	var clocks []quickLogClock
+	addClock := func(clock quickLogClock) {
+		if clock.hour > 24 || clock.minute > 59 {
+			return
+		}
+		clocks = append(clocks, clock)
+		blank(clock.start, clock.end)
+	}
+	for _, loc := range quickLogColonPattern.FindAllSubmatchIndex(rest, -1) {
+		hour, _ := strconv.Atoi(string(rest[loc[4]:loc[5]]))
+		minute, _ := strconv.Atoi(string(rest[loc[6]:loc[7]]))
+		addClock(quickLogClock{start: loc[0], end: loc[1], hour: hour, minute: minute,
+			meridiem: submatch(rest, loc, 4), period: submatch(rest, loc, 1)})
+	}
+	for _, loc := range quickLogDianPattern.FindAllSubmatchIndex(rest, -1) {
+		hour, _ := strconv.Atoi(string(rest[loc[4]:loc[5]]))
+		minute := 0
+		switch suffix := submatch(rest, loc, 3); {
+		case suffix == "半":
+			minute = 30
+		case suffix == "一刻":
+			minute = 15
+		case suffix == "三刻":
+			minute = 45
+		case loc[8] >= 0:
+			minute, _ = strconv.Atoi(string(rest[loc[8]:loc[9]]))
+		}
+		addClock(quickLogClock{start: loc[0], end: loc[1], hour: hour, minute: minute, period: submatch(rest, loc, 1)})
+	}
+	for _, loc := range quickLogMeridiemPattern.FindAllSubmatchIndex(rest, -1) {
+		hour, _ := strconv.Atoi(string(rest[loc[2]:loc[3]]))
+		addClock(quickLogClock{start: loc[0], end: loc[1], hour: hour, meridiem: submatch(rest, loc, 2)})
+	}
+	for _, loc := range quickLogAtPattern.FindAllSubmatchIndex(rest, -1) {
+		hour, _ := strconv.Atoi(string(rest[loc[2]:loc[3]]))
+		addClock(quickLogClock{start: loc[0], end: loc[1], hour: hour})
+	}
+	sort.Slice(clocks, func(i, j int) bool { return clocks[i].start < clocks[j].start })
+
+	var markers []quickLogDayMarker
+	for _, loc := range quickLogDayMarkerPattern.FindAllStringIndex(text, -1) {
+		markers = append(markers, newQuickLogDayMarker(loc[0], text[loc[0]:loc[1]]))
+	}
+
+	for i, clock := range clocks {
+		// 优先使用钟点之前最近的标记，其次是之后(且中间没有其他钟点)的标记
+		var marker *quickLogDayMarker
+		for j := range markers {
+			if !markers[j].used && markers[j].pos < clock.start {
+				marker = &markers[j]
+			}
+		}
+		if marker == nil {
+			for j := range markers {
+				if !markers[j].used && markers[j].pos >= clock.end && (i+1 == len(clocks) || markers[j].pos < clocks[i+1].start) {
+					marker = &markers[j]
+					break
+				}
+			}
+		}
+
+		dayOffset, dayExplicit := 0, false
+		if marker != nil {
+			marker.used = true
+			dayOffset, dayExplicit = marker.dayOffset, marker.explicit
+			if clock.period == "" {
+				clock.period = marker.period
+			}
+		}
+		times = append(times, quickLogTime{
+			at:  resolveQuickLogClock(now, clock.hour, clock.minute, clock.meridiem, clock.period, dayOffset, dayExplicit),
+			pos: clock.start,
+			end: clock.end,
+		})
+	}
+
+	// 只有 "刚才" 之类的说法时使用当前时间
+	if len(times) == 0 {
+		if loc := quickLogJustNowPattern.FindIndex(rest); loc != nil {
+			times = append(times, quickLogTime{at: now, pos: loc[0], end: loc[1]})
+			blank(loc[0], loc[1])
+		}
+	}
+
+	sort.SliceStable(times, func(i, j int) bool { return times[i].pos < times[j].pos })
+	return times, string(rest)
+}
+
+// submatch 返回第 n 个分组的文本，未匹配时为空
+func submatch(text []byte, loc []int, n int) string {
+	if loc[2*n] < 0 {
+		return ""
+	}
+	return string(text[loc[2*n]:loc[2*n+1]])
+}
+
+// resolveQuickLogClock 将钟点换算为具体时间
+// 没有上下午标记的 1-12 点同时考虑上午和下午，未指明日期时同时考虑今天和昨天，取不晚于当前时间的最近一次
+func resolveQuickLogClock(now time.Time, hour, minute int, meridiem, period string, dayOffset int, dayExplicit bool) time.Time {
+	hours := []int{hour % 24}
+	switch {
+	case meridiem == "pm" || meridiem == "p.m.":
+		if hour < 12 {
+			hours = []int{hour + 12}
+		}
+	case meridiem == "am" || meridiem == "a.m.":
+		hours = []int{hour % 12}
+	case period == "下午" || period == "傍晚" || period == "晚上":
+		if hour < 12 && !(hour <= 4 && period == "晚上") {
+			hours = []int{hour + 12}
+		}
+	case period == "中午":
+		if hour < 11 {
+			hours = []int{hour + 12}
+		}
+	case period == "凌晨" || period == "早上" || period == "早晨" || period == "上午":
+		hours = []int{hour % 12}
+	case period == "夜里" || period == "半夜":
+		if hour >= 6 && hour < 12 {
+			hours = []int{hour + 12}
+		}
+	case hour >= 1 && hour < 12:
+		hours = append(hours, hour+12)
+	case hour == 12:
+		hours = append(hours, 0)
+	}
+
+	days := []int{0, -1}
+	if dayExplicit {
+		// "昨晚2点" 指的是今天凌晨
+		if period == "晚上" && hour <= 4 && dayOffset < 0 {
+			dayOffset++
+		}
+		days = []int{dayOffset}
+	}
+
+	var best, earliest time.Time
+	latest := now.Add(quickLogFutureTolerance)
+	for _, d := range days {
+		for _, h := range hours {
+			candidate := time.Date(now.Year(), now.Month(), now.Day()+d, h, minute, 0, 0, now.Location())
+			if !candidate.After(latest) && candidate.After(best) {
+				best = candidate
+			}
+			if earliest.IsZero() || candidate.Before(earliest) {
+				earliest = candidate
+			}
+		}
+	}
+	if best.IsZero() {
+		return earliest
+	}
+	return best
+}
+
+// roundToTwoDecimals 保留两位小数
+func roundToTwoDecimals(value float64) float64 {
+	return math.Round(value*100) / 100
+}
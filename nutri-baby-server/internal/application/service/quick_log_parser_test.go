@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+func TestParseQuickLogByRules(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	now := time.Date(2024, 3, 20, 16, 0, 0, 0, loc)
+
+	// 无上下午标记的钟点取最近一次; 未给时间的片段沿用上一条的时间
+	drafts, unparsed := parseQuickLogByRules("fed 120ml formula at 3:10, left breast 12 min", now)
+	require.Len(t, drafts, 2)
+	assert.Empty(t, unparsed)
+	assert.Equal(t, "bottle", drafts[0].entry.FeedingType)
+	assert.Equal(t, 120.0, drafts[0].entry.AmountMl)
+	assert.Equal(t, "formula", drafts[0].entry.BottleType)
+	assert.Equal(t, "2024-03-20 15:10", drafts[0].entry.Time)
+	assert.Equal(t, "breast", drafts[1].entry.FeedingType)
+	assert.Equal(t, "left", drafts[1].entry.Side)
+	assert.Equal(t, 12, drafts[1].entry.LeftMinutes)
+	assert.Equal(t, "2024-03-20 15:10", drafts[1].entry.Time)
+
+	// 左右两侧合并为一次亲喂
+	drafts, _ = parseQuickLogByRules("左边10分钟，右边8分钟", now)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "both", drafts[0].entry.Side)
+	assert.Equal(t, 10, drafts[0].entry.LeftMinutes)
+	assert.Equal(t, 8, drafts[0].entry.RightMinutes)
+	assert.NotEmpty(t, drafts[0].warnings)
+
+	// 相对时间与大便性状
+	drafts, _ = parseQuickLogByRules("半小时前换尿布，黄色稀便", now)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "poop", drafts[0].entry.DiaperType)
+	assert.Equal(t, "yellow", drafts[0].entry.PooColor)
+	assert.Equal(t, "loose", drafts[0].entry.PooTexture)
+	assert.Equal(t, "2024-03-20 15:30", drafts[0].entry.Time)
+
+	// 跨天睡眠: "昨晚" 只作用于入睡时间
+	drafts, _ = parseQuickLogByRules("昨晚9点睡到6点半", now)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "2024-03-19 21:00", drafts[0].entry.Time)
+	assert.Equal(t, "2024-03-20 06:30", drafts[0].entry.EndTime)
+	assert.Equal(t, 570, drafts[0].entry.DurationMinutes)
+	assert.Equal(t, "night", drafts[0].entry.SleepType)
+
+	// 生长测量与无法识别的片段
+	drafts, unparsed = parseQuickLogByRules("体重6.2kg 身高62cm 头围40cm; 今天天气不错", now)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, 6.2, drafts[0].entry.WeightKg)
+	assert.Equal(t, 62.0, drafts[0].entry.HeightCm)
+	assert.Equal(t, 40.0, drafts[0].entry.HeadCircumference)
+	assert.Equal(t, []string{"今天天气不错"}, unparsed)
+}
+
+func TestBuildQuickLogProposal(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	now := time.Date(2024, 3, 20, 16, 0, 0, 0, loc)
+
+	drafts, _ := parseQuickLogByRules("nap 1h30m", now)
+	require.Len(t, drafts, 1)
+
+	proposal, reason := buildQuickLogProposal(i18n.ZhCN, "42", drafts[0], now)
+	require.NotNil(t, proposal, reason)
+	require.NotNil(t, proposal.Sleep)
+	assert.Equal(t, "/v1/sleep-records", proposal.Endpoint)
+	assert.Equal(t, now.Add(-90*time.Minute).UnixMilli(), proposal.Sleep.StartTime)
+	assert.Equal(t, now.UnixMilli(), proposal.Sleep.EndTime)
+	assert.Equal(t, 5400, proposal.Sleep.Duration)
+	assert.Equal(t, "nap", proposal.Sleep.SleepType)
+	assert.Contains(t, proposal.Summary, "1小时30分钟")
+
+	proposal, _ = buildQuickLogProposal(i18n.EnUS, "42", drafts[0], now)
+	require.NotNil(t, proposal)
+	assert.Equal(t, "03-20 14:30 Sleep: nap until 16:00, 1h 30 min total", proposal.Summary)
+}
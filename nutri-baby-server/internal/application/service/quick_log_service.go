@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 快速记录解析器标识
+const (
+	QuickLogParserAI   = "ai"
+	QuickLogParserRule = "rule"
+)
+
+// quickLogParseTimeout 模型解析超时，超时后降级为规则解析
+const quickLogParseTimeout = 20 * time.Second
+
+// quickLogEndpoints 确认后提交的创建接口
+var quickLogEndpoints = map[string]string{
+	"feeding": "/v1/feeding-records",
+	"sleep":   "/v1/sleep-records",
+	"diaper":  "/v1/diaper-records",
+	"growth":  "/v1/growth-records",
+}
+
+// 预览文案中各取值名称的消息键
+var (
+	quickLogBottleTypeNames = quickLogNameKeys("quick_log.bottle.", "formula", "breast-milk")
+	quickLogSideNames       = quickLogNameKeys("quick_log.side.", "left", "right", "both")
+	quickLogDiaperTypeNames = quickLogNameKeys("quick_log.diaper.", "pee", "poop", "both")
+	quickLogSleepTypeNames  = quickLogNameKeys("quick_log.sleep.", "nap", "night")
+	quickLogPooColorNames   = quickLogNameKeys("quick_log.poo_color.", "yellow", "green", "brown", "black", "red", "white")
+	quickLogPooTextureNames = quickLogNameKeys("quick_log.poo_texture.", "watery", "loose", "paste", "soft", "formed", "hard")
+)
+
+// quickLogNameKeys 取值到消息键的映射
+func quickLogNameKeys(prefix string, values ...string) map[string]string {
+	keys := make(map[string]string, len(values))
+	for _, value := range values {
+		keys[value] = prefix + value
+	}
+	return keys
+}
+
+// QuickLogService 自然语言快速记录服务
+// 只生成待确认的记录草稿，用户确认后由客户端调用各记录的创建接口写入
+type QuickLogService struct {
+	*BaseRecordService
+	chainBuilder *chain.AnalysisChainBuilder
+	usageService *AIUsageService
+	cfg          *config.Config
+}
+
+// NewQuickLogService 创建快速记录服务
+func NewQuickLogService(
+	babyRepo repository.BabyRepository,
+	collaboratorRepo repository.BabyCollaboratorRepository,
+	userRepo repository.UserRepository,
+	chainBuilder *chain.AnalysisChainBuilder,
+	usageService *AIUsageService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *QuickLogService {
+	return &QuickLogService{
+		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
+		chainBuilder:      chainBuilder,
+		usageService:      usageService,
+		cfg:               cfg,
+	}
+}
+
+// Parse 将自然语言解析为记录草稿并生成确认预览
+// 相对时间按宝宝所在时区换算；未配置AI提供商或模型解析失败时使用规则解析
+func (s *QuickLogService) Parse(ctx context.Context, openID, babyID string, req *dto.QuickLogParseRequest) (*dto.QuickLogParseResponse, error) {
+	if err := s.CheckBabyAccess(ctx, babyID, openID); err != nil {
+		return nil, err
+	}
+
+	babyIDInt64, err := strconv.ParseInt(babyID, 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.ParamError, "无效的宝宝ID格式")
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, errors.New(errors.ParamError, "记录内容不能为空")
+	}
+
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	// 优先使用宝宝设置的时区，其次是客户端时区
+	loc := baby.Location()
+	if baby.Timezone == "" && req.Timezone != "" {
+		if err := validateTimezone(req.Timezone); err != nil {
+			return nil, err
+		}
+		loc, _ = time.LoadLocation(req.Timezone)
+	}
+	now := time.Now().In(loc)
+	locale := s.RequesterLocale(ctx, openID)
+
+	resp := &dto.QuickLogParseResponse{
+		Parser:    QuickLogParserRule,
+		Timezone:  loc.String(),
+		Proposals: make([]*dto.QuickLogProposal, 0),
+	}
+
+	// 配额用完时不报错，直接使用规则解析
+	useAI := s.aiEnabled()
+	if useAI {
+		if err := s.usageService.CheckQuota(ctx, openID, babyIDInt64, 1); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.QuotaExceeded {
+				resp.Warnings = append(resp.Warnings, locale.T("quick_log.warning.quota_exceeded"))
+			} else {
+				s.logger.Warn("检查AI配额失败，降级为规则解析", zap.Error(err))
+				resp.Warnings = append(resp.Warnings, locale.T("quick_log.warning.ai_unavailable"))
+			}
+			useAI = false
+		}
+	}
+
+	var drafts []quickLogDraft
+	if useAI {
+		parseCtx, cancel := context.WithTimeout(ctx, quickLogParseTimeout)
+		parseCtx = chain.WithUsageScope(parseCtx, NewUsageScope(entity.AIUsageOperationQuickLog, openID, babyIDInt64))
+		entries, err := s.chainBuilder.ParseQuickLog(parseCtx, text, now)
+		cancel()
+		switch {
+		case err != nil:
+			s.logger.Warn("快速记录模型解析失败，降级为规则解析",
+				zap.Int64("baby_id", babyIDInt64),
+				zap.Error(err),
+			)
+			resp.Warnings = append(resp.Warnings, locale.T("quick_log.warning.ai_unavailable"))
+		case len(entries) > 0:
+			resp.Parser = QuickLogParserAI
+			for _, entry := range entries {
+				drafts = append(drafts, quickLogDraft{entry: entry})
+			}
+		}
+	}
+
+	if resp.Parser == QuickLogParserRule {
+		drafts, resp.Unparsed = parseQuickLogByRules(text, now)
+	}
+
+	for _, draft := range drafts {
+		proposal, reason := buildQuickLogProposal(locale, babyID, draft, now)
+		if proposal == nil {
+			resp.Warnings = append(resp.Warnings, reason)
+			continue
+		}
+		resp.Proposals = append(resp.Proposals, proposal)
+	}
+
+	if len(resp.Proposals) == 0 {
+		resp.Preview = locale.T("quick_log.preview.empty")
+	} else {
+		lines := make([]string, 0, len(resp.Proposals))
+		for _, proposal := range resp.Proposals {
+			lines = append(lines, proposal.Summary)
+		}
+		resp.Preview = locale.T("quick_log.preview.list", len(lines), strings.Join(lines, "\n"))
+	}
+
+	return resp, nil
+}
+
+// aiEnabled 是否配置了真实的AI提供商(mock 视为未配置)
+func (s *QuickLogService) aiEnabled() bool {
+	for _, provider := range s.cfg.AI.ProviderChain() {
+		if provider != "mock" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildQuickLogProposal 将解析结果转换为对应记录的创建请求
+// 返回 nil 时第二个返回值为无法生成草稿的原因；预览和提示按 locale 生成
+func buildQuickLogProposal(locale i18n.Locale, babyID string, draft quickLogDraft, now time.Time) (*dto.QuickLogProposal, string) {
+	entry := draft.entry
+	proposal := &dto.QuickLogProposal{
+		RecordType: entry.Type,
+		Endpoint:   quickLogEndpoints[entry.Type],
+	}
+	for _, key := range draft.warnings {
+		proposal.Warnings = append(proposal.Warnings, locale.T(key))
+	}
+
+	at, err := time.ParseInLocation(chain.QuickLogTimeLayout, entry.Time, now.Location())
+	if err != nil {
+		at = now
+		proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.no_time"))
+	} else if at.After(now.Add(quickLogFutureTolerance)) {
+		proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.future_time"))
+	}
+	timeLabel := at.Format("01-02 15:04")
+
+	var note *string
+	if n := strings.TrimSpace(entry.Note); n != "" {
+		note = &n
+	}
+
+	switch entry.Type {
+	case "feeding":
+		req := &dto.CreateFeedingRecordRequest{
+			BabyID:      babyID,
+			FeedingType: entry.FeedingType,
+			Note:        note,
+			FeedingTime: at.UnixMilli(),
+		}
+		switch entry.FeedingType {
+		case "bottle":
+			amount := int64(math.Round(entry.AmountMl))
+			if amount <= 0 {
+				return nil, locale.T("quick_log.reason.no_amount")
+			}
+			bottleType := entry.BottleType
+			if _, ok := quickLogBottleTypeNames[bottleType]; !ok {
+				bottleType = "formula"
+			}
+			req.Amount = &amount
+			req.Detail = map[string]any{"type": "bottle", "bottleType": bottleType, "amount": amount, "unit": "ml"}
+			proposal.Summary = locale.T("quick_log.summary.bottle", timeLabel, locale.T(quickLogBottleTypeNames[bottleType]), amount)
+		case "breast":
+			left, right := entry.LeftMinutes*60, entry.RightMinutes*60
+			total := left + right
+			if total == 0 {
+				total = entry.DurationMinutes * 60
+			}
+			side := entry.Side
+			switch {
+			case left > 0 && right > 0:
+				side = "both"
+			case left > 0:
+				side = "left"
+			case right > 0:
+				side = "right"
+			}
+			detail := map[string]any{"type": "breast", "side": side, "duration": total}
+			if left > 0 {
+				detail["leftDuration"] = left
+			}
+			if right > 0 {
+				detail["rightDuration"] = right
+			}
+			req.Detail = detail
+			if total > 0 {
+				req.Duration = &total
+			} else {
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.no_breast_duration"))
+			}
+			sideName, ok := quickLogSideNames[side]
+			if !ok {
+				sideName = "quick_log.side.unknown"
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.no_side"))
+			}
+			proposal.Summary = locale.T("quick_log.summary.breast", timeLabel, locale.T(sideName))
+			if total > 0 {
+				proposal.Summary += locale.T("quick_log.summary.breast_minutes", total/60)
+			}
+		case "food":
+			foodName := strings.TrimSpace(entry.FoodName)
+			if foodName == "" {
+				return nil, locale.T("quick_log.reason.no_food_name")
+			}
+			req.Detail = map[string]any{"type": "food", "foodName": foodName}
+			proposal.Summary = locale.T("quick_log.summary.food", timeLabel, foodName)
+		default:
+			return nil, locale.T("quick_log.reason.unknown_feeding_type", entry.FeedingType)
+		}
+		proposal.Feeding = req
+
+	case "sleep":
+		req := &dto.CreateSleepRecordRequest{
+			BabyID:    babyID,
+			StartTime: at.UnixMilli(),
+			SleepType: entry.SleepType,
+			Note:      strings.TrimSpace(entry.Note),
+		}
+		end, err := time.ParseInLocation(chain.QuickLogTimeLayout, entry.EndTime, now.Location())
+		if err != nil && entry.DurationMinutes > 0 {
+			end, err = at.Add(time.Duration(entry.DurationMinutes)*time.Minute), nil
+		}
+		if err == nil {
+			if end.After(at) {
+				req.EndTime = end.UnixMilli()
+				req.Duration = int(end.Sub(at).Seconds())
+			} else {
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.wake_before_sleep"))
+			}
+		}
+		if _, ok := quickLogSleepTypeNames[req.SleepType]; !ok {
+			// 未说明类型时按入睡时间推断: 19点至次日5点入睡视为夜间睡眠
+			req.SleepType = "nap"
+			if hour := at.Hour(); hour >= 19 || hour < 5 {
+				req.SleepType = "night"
+			}
+		}
+		proposal.Sleep = req
+		if req.EndTime > 0 {
+			proposal.Summary = locale.T("quick_log.summary.sleep", timeLabel, locale.T(quickLogSleepTypeNames[req.SleepType]),
+				end.Format("15:04"), formatQuickLogMinutes(locale, req.Duration/60))
+		} else {
+			proposal.Summary = locale.T("quick_log.summary.sleep_ongoing", timeLabel, locale.T(quickLogSleepTypeNames[req.SleepType]))
+		}
+
+	case "diaper":
+		if _, ok := quickLogDiaperTypeNames[entry.DiaperType]; !ok {
+			return nil, locale.T("quick_log.reason.unknown_diaper_type", entry.DiaperType)
+		}
+		req := &dto.CreateDiaperRecordRequest{
+			BabyID:     babyID,
+			DiaperType: entry.DiaperType,
+			Note:       strings.TrimSpace(entry.Note),
+			ChangeTime: at.UnixMilli(),
+		}
+		separator := locale.T("quick_log.summary.separator")
+		summary := locale.T("quick_log.summary.diaper", timeLabel, locale.T(quickLogDiaperTypeNames[entry.DiaperType]))
+		if name, ok := quickLogPooColorNames[entry.PooColor]; ok && entry.DiaperType != "pee" {
+			req.PooColor = entry.PooColor
+			summary += separator + locale.T(name)
+		}
+		if name, ok := quickLogPooTextureNames[entry.PooTexture]; ok && entry.DiaperType != "pee" {
+			req.PooTexture = entry.PooTexture
+			summary += separator + locale.T(name)
+		}
+		proposal.Diaper = req
+		proposal.Summary = summary
+
+	case "growth":
+		if entry.WeightKg <= 0 && entry.HeightCm <= 0 && entry.HeadCircumference <= 0 {
+			return nil, locale.T("quick_log.reason.no_measurement")
+		}
+		req := &dto.CreateGrowthRecordRequest{
+			BabyID:            babyID,
+			Height:            entry.HeightCm,
+			Weight:            entry.WeightKg,
+			HeadCircumference: entry.HeadCircumference,
+			Note:              strings.TrimSpace(entry.Note),
+			MeasureTime:       at.UnixMilli(),
+		}
+		var parts []string
+		if req.Weight > 0 {
+			parts = append(parts, locale.T("quick_log.summary.weight", req.Weight))
+			if req.Weight < 0.5 || req.Weight > 40 {
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.weight_range"))
+			}
+		}
+		if req.Height > 0 {
+			parts = append(parts, locale.T("quick_log.summary.height", req.Height))
+			if req.Height < 30 || req.Height > 150 {
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.height_range"))
+			}
+		}
+		if req.HeadCircumference > 0 {
+			parts = append(parts, locale.T("quick_log.summary.head", req.HeadCircumference))
+			if req.HeadCircumference < 20 || req.HeadCircumference > 60 {
+				proposal.Warnings = append(proposal.Warnings, locale.T("quick_log.warning.head_range"))
+			}
+		}
+		proposal.Growth = req
+		proposal.Summary = locale.T("quick_log.summary.growth", timeLabel, strings.Join(parts, locale.T("quick_log.summary.separator")))
+
+	default:
+		return nil, locale.T("quick_log.reason.unknown_type", entry.Type)
+	}
+
+	return proposal, ""
+}
+
+// formatQuickLogMinutes 格式化时长，如 "1小时20分钟"
+func formatQuickLogMinutes(locale i18n.Locale, minutes int) string {
+	switch {
+	case minutes < 60:
+		return locale.T("quick_log.duration.minutes", minutes)
+	case minutes%60 == 0:
+		return locale.T("quick_log.duration.hours", minutes/60)
+	default:
+		return locale.T("quick_log.duration.hours_minutes", minutes/60, minutes%60)
+	}
+}
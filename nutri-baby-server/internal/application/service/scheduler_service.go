@@ -11,6 +11,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 	"go.uber.org/zap"
 )
 
@@ -24,6 +25,10 @@ type SchedulerService struct {
 	collaboratorRepo    repository.BabyCollaboratorRepository // 协作者仓储
 	subscribeService    *SubscribeService
 	aiAnalysisService   AIAnalysisService // 新增: AI分析服务
+	diaperScreening     *DiaperScreeningService
+	milkStashService    *MilkStashService
+	aiDigestService     *AIDigestService
+	medicationService   *MedicationService
 	strategyFactory     *FeedingReminderStrategyFactory
 	logger              *zap.Logger
 }
@@ -37,6 +42,10 @@ func NewSchedulerService(
 	collaboratorRepo repository.BabyCollaboratorRepository, // 协作者仓储
 	subscribeService *SubscribeService,
 	aiAnalysisService AIAnalysisService, // 新增: AI分析服务
+	diaperScreening *DiaperScreeningService, // 排泄健康筛查服务
+	milkStashService *MilkStashService, // 母乳库存服务
+	aiDigestService *AIDigestService, // AI周期报告服务
+	medicationService *MedicationService, // 用药服务
 	cfg *config.Config,
 	logger *zap.Logger,
 ) *SchedulerService {
@@ -52,6 +61,10 @@ func NewSchedulerService(
 		collaboratorRepo:    collaboratorRepo,
 		subscribeService:    subscribeService,
 		aiAnalysisService:   aiAnalysisService,
+		diaperScreening:     diaperScreening,
+		milkStashService:    milkStashService,
+		aiDigestService:     aiDigestService,
+		medicationService:   medicationService,
 		strategyFactory:     NewFeedingReminderStrategyFactory(cfg),
 		logger:              logger,
 	}
@@ -62,22 +75,62 @@ func (s *SchedulerService) Start() {
 	// 启动调度器(用于一次性定时任务)
 	s.scheduler.StartAsync()
 
-	// 新增: 每5分钟自动处理一次待分析的AI任务
-	_, err := s.scheduler.Every(5).Minutes().Do(s.processAIAnalysisTasks)
-	if err != nil {
-		s.logger.Error("添加AI分析定时任务失败", zap.Error(err))
-	} else {
-		s.logger.Info("AI分析自动处理任务已启用 (每5分钟一次)")
-	}
+	// AI分析任务由 AIJobRunner 按租约调度执行，不再在此轮询
 
 	// 新增: 每天凌晨 00:00 自动生成活跃用户的每日建议
-	_, err = s.scheduler.Every(1).Day().At("00:00").Do(s.generateDailyTipsForActiveBabies)
+	_, err := s.scheduler.Every(1).Day().At("00:00").Do(s.generateDailyTipsForActiveBabies)
 	if err != nil {
 		s.logger.Error("添加每日建议生成任务失败", zap.Error(err))
 	} else {
 		s.logger.Info("每日建议自动生成任务已启用 (每天 00:00)")
 	}
 
+	// 每小时对活跃宝宝执行排泄健康筛查(小便次数、排便间隔等时间窗口规则)
+	_, err = s.scheduler.Every(1).Hour().Do(s.screenDiaperHealthForActiveBabies)
+	if err != nil {
+		s.logger.Error("添加排泄健康筛查任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("排泄健康筛查任务已启用 (每小时一次)")
+	}
+
+	// 每小时处理母乳库存: 标记过期并推送24小时内临期提醒
+	_, err = s.scheduler.Every(1).Hour().Do(s.processMilkStashExpiry)
+	if err != nil {
+		s.logger.Error("添加母乳库存临期任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("母乳库存临期提醒任务已启用 (每小时一次)")
+	}
+
+	// 每周一 07:00 为活跃宝宝生成上周的AI周报，每月1日 07:00 生成上月的月报
+	_, err = s.scheduler.Every(1).Week().Monday().At("07:00").Do(s.createDigests, entity.AIDigestPeriodWeekly)
+	if err != nil {
+		s.logger.Error("添加AI周报生成任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("AI周报生成任务已启用 (每周一 07:00)")
+	}
+	_, err = s.scheduler.Every(1).Month(1).At("07:00").Do(s.createDigests, entity.AIDigestPeriodMonthly)
+	if err != nil {
+		s.logger.Error("添加AI月报生成任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("AI月报生成任务已启用 (每月1日 07:00)")
+	}
+
+	// 每10分钟汇总分析已结束的周期报告并推送给协作者
+	_, err = s.scheduler.Every(10).Minutes().Do(s.finalizeDigests)
+	if err != nil {
+		s.logger.Error("添加AI周期报告汇总任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("AI周期报告汇总任务已启用 (每10分钟一次)")
+	}
+
+	// 每5分钟检查用药计划并推送到期的给药提醒
+	_, err = s.scheduler.Every(5).Minutes().Do(s.sendMedicationReminders)
+	if err != nil {
+		s.logger.Error("添加给药提醒任务失败", zap.Error(err))
+	} else {
+		s.logger.Info("给药提醒任务已启用 (每5分钟一次)")
+	}
+
 	s.logger.Info("Scheduler service started with auto-processing enabled")
 }
 
@@ -87,20 +140,6 @@ func (s *SchedulerService) Stop() {
 	s.logger.Info("Scheduler service stopped")
 }
 
-// processAIAnalysisTasks 处理待分析的AI任务（定时任务回调）
-// 每5分钟自动调用一次，批量处理待处理的分析任务
-func (s *SchedulerService) processAIAnalysisTasks() {
-	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Minute)
-	defer cancel()
-
-	if err := s.aiAnalysisService.ProcessPendingAnalyses(ctx); err != nil {
-		s.logger.Error("自动处理待分析AI任务失败", zap.Error(err))
-		return
-	}
-
-	s.logger.Info("自动处理待分析AI任务成功")
-}
-
 // generateDailyTipsForActiveBabies 生成活跃用户的每日建议
 func (s *SchedulerService) generateDailyTipsForActiveBabies() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
@@ -133,7 +172,7 @@ func (s *SchedulerService) generateDailyTipsForActiveBabies() {
 
 		// GenerateDailyTips 内部会检查是否已存在，如果已存在则直接返回
 		// 如果不存在，则调用AI生成
-		_, err := s.aiAnalysisService.GenerateDailyTips(ctx, babyIDStr, date)
+		_, err := s.aiAnalysisService.GenerateScheduledDailyTips(ctx, babyIDStr, date)
 		if err != nil {
 			s.logger.Error("生成每日建议失败",
 				zap.String("babyID", babyIDStr),
@@ -150,6 +189,85 @@ func (s *SchedulerService) generateDailyTipsForActiveBabies() {
 	s.logger.Info("活跃用户每日建议生成完成")
 }
 
+// screenDiaperHealthForActiveBabies 对活跃宝宝执行排泄健康筛查
+func (s *SchedulerService) screenDiaperHealthForActiveBabies() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	activeSince := time.Now().AddDate(0, 0, -7).UnixMilli()
+	activeBabies, err := s.babyRepo.FindActiveBabies(ctx, activeSince)
+	if err != nil {
+		s.logger.Error("查找活跃宝宝失败", zap.Error(err))
+		return
+	}
+
+	for _, baby := range activeBabies {
+		select {
+		case <-ctx.Done():
+			s.logger.Warn("排泄健康筛查任务超时或取消")
+			return
+		default:
+		}
+
+		if err := s.diaperScreening.ScreenAndNotify(ctx, baby.ID); err != nil {
+			s.logger.Error("排泄健康筛查失败", zap.Int64("babyID", baby.ID), zap.Error(err))
+		}
+	}
+}
+
+// processMilkStashExpiry 处理母乳库存过期与临期提醒
+func (s *SchedulerService) processMilkStashExpiry() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := s.milkStashService.ProcessExpiringStash(ctx); err != nil {
+		s.logger.Error("处理母乳库存临期提醒失败", zap.Error(err))
+	}
+}
+
+// createDigests 为活跃宝宝创建上一周期的AI报告
+func (s *SchedulerService) createDigests(period entity.AIDigestPeriod) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	created, err := s.aiDigestService.CreateScheduledDigests(ctx, period, time.Now())
+	if err != nil {
+		s.logger.Error("创建AI周期报告失败", zap.String("period", string(period)), zap.Error(err))
+		return
+	}
+	s.logger.Info("AI周期报告已创建", zap.String("period", string(period)), zap.Int("count", created))
+}
+
+// finalizeDigests 汇总分析已结束的AI周期报告并推送
+func (s *SchedulerService) finalizeDigests() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	finalized, err := s.aiDigestService.FinalizePendingDigests(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("汇总AI周期报告失败", zap.Error(err))
+		return
+	}
+	if finalized > 0 {
+		s.logger.Info("AI周期报告已汇总", zap.Int("count", finalized))
+	}
+}
+
+// sendMedicationReminders 推送到期的给药提醒
+func (s *SchedulerService) sendMedicationReminders() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	reminded, err := s.medicationService.SendDueReminders(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("推送给药提醒失败", zap.Error(err))
+		return
+	}
+	if reminded > 0 {
+		s.logger.Info("给药提醒已推送", zap.Int("count", reminded))
+	}
+}
+
 // CheckVaccineReminders 检查疫苗提醒(使用新的 BabyVaccineSchedule 架构)
 func (s *SchedulerService) CheckVaccineReminders() error {
 	// ctx := context.Background()
@@ -291,7 +409,6 @@ func (s *SchedulerService) executeFeedingReminder(ctx context.Context, record *e
 
 	lastFeedingTime := time.UnixMilli(record.Time)
 	hoursSince := time.Since(lastFeedingTime).Hours()
-	messageData := strategy.BuildMessageData(record, lastFeedingTime, hoursSince)
 
 	// 4. 遍历所有协作者，分别发送消息
 	var sentCount, failCount int
@@ -334,7 +451,7 @@ func (s *SchedulerService) executeFeedingReminder(ctx context.Context, record *e
 		sendReq := &dto.SendMessageRequest{
 			OpenID:     user.OpenID,
 			TemplateID: strategy.GetTemplateID(),
-			Data:       messageData,
+			Data:       strategy.BuildMessageData(record, lastFeedingTime, hoursSince, i18n.Normalize(user.Locale)),
 			Page:       "pages/record/feeding/feeding",
 		}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +15,7 @@ import (
 type SleepRecordService struct {
 	*BaseRecordService
 	sleepRecordRepo repository.SleepRecordRepository
+	dataCache       *cache.AnalysisDataCache
 }
 
 // NewSleepRecordService 创建睡眠记录服务
@@ -22,11 +24,13 @@ func NewSleepRecordService(
 	collaboratorRepo repository.BabyCollaboratorRepository,
 	userRepo repository.UserRepository,
 	sleepRecordRepo repository.SleepRecordRepository,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *SleepRecordService {
 	return &SleepRecordService{
 		BaseRecordService: NewBaseRecordService(babyRepo, collaboratorRepo, userRepo, logger),
 		sleepRecordRepo:   sleepRecordRepo,
+		dataCache:         dataCache,
 	}
 }
 
@@ -75,6 +79,7 @@ func (s *SleepRecordService) CreateSleepRecord(ctx context.Context, openID strin
 	if err := s.sleepRecordRepo.Create(ctx, record); err != nil {
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	resultEndTime := int64(0)
 	if record.EndTime != nil {
@@ -262,6 +267,7 @@ func (s *SleepRecordService) UpdateSleepRecord(ctx context.Context, openID, reco
 			zap.Error(err))
 		return nil, err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("睡眠记录更新成功",
 		zap.String("recordID", strconv.FormatInt(record.ID, 10)),
@@ -300,6 +306,7 @@ func (s *SleepRecordService) DeleteSleepRecord(ctx context.Context, openID, reco
 			zap.Error(err))
 		return err
 	}
+	s.dataCache.InvalidateCache(ctx, record.BabyID)
 
 	s.logger.Info("睡眠记录删除成功",
 		zap.String("recordID", recordID),
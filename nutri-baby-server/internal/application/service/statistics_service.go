@@ -9,6 +9,7 @@ import (
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +21,7 @@ type StatisticsService struct {
 	sleepRecordRepo   repository.SleepRecordRepository
 	diaperRecordRepo  repository.DiaperRecordRepository
 	growthRecordRepo  repository.GrowthRecordRepository
+	temperatureRepo   repository.TemperatureRecordRepository
 	userRepo          repository.UserRepository
 	logger            *zap.Logger
 }
@@ -32,6 +34,7 @@ func NewStatisticsService(
 	sleepRecordRepo repository.SleepRecordRepository,
 	diaperRecordRepo repository.DiaperRecordRepository,
 	growthRecordRepo repository.GrowthRecordRepository,
+	temperatureRepo repository.TemperatureRecordRepository,
 	userRepo repository.UserRepository,
 	logger *zap.Logger,
 ) *StatisticsService {
@@ -42,6 +45,7 @@ func NewStatisticsService(
 		sleepRecordRepo:   sleepRecordRepo,
 		diaperRecordRepo:  diaperRecordRepo,
 		growthRecordRepo:  growthRecordRepo,
+		temperatureRepo:   temperatureRepo,
 		userRepo:          userRepo,
 		logger:            logger,
 	}
@@ -56,7 +60,7 @@ func (s *StatisticsService) GetBabyStatistics(ctx context.Context, babyID, openI
 	}
 
 	// 2. 验证权限（检查用户是否有权访问该宝宝的数据）
-	_, err = s.babyRepo.FindByID(ctx, babyIDInt64)
+	baby, err := s.babyRepo.FindByID(ctx, babyIDInt64)
 	if err != nil {
 		return nil, errors.Wrap(errors.DatabaseError, "查询宝宝信息失败", err)
 	}
@@ -95,9 +99,25 @@ func (s *StatisticsService) GetBabyStatistics(ctx context.Context, babyID, openI
 		return nil, err
 	}
 
+	// 5. 排泄和发热健康筛查提醒(失败不影响统计结果)
+	alerts := make([]*dto.HealthAlertDTO, 0)
+	diaperAlerts, err := screenBabyDiapers(ctx, s.diaperRecordRepo, baby, now)
+	if err != nil {
+		s.logger.Warn("排泄健康筛查失败", zap.String("babyId", babyID), zap.Error(err))
+	}
+	feverAlerts, err := screenBabyTemperatures(ctx, s.temperatureRepo, baby, now)
+	if err != nil {
+		s.logger.Warn("发热健康筛查失败", zap.String("babyId", babyID), zap.Error(err))
+	}
+	locale := i18n.FromContext(ctx)
+	for _, alert := range append(feverAlerts, diaperAlerts...) {
+		alerts = append(alerts, toHealthAlertDTO(alert, locale))
+	}
+
 	return &dto.BabyStatisticsResponse{
 		Today:  *todayStats,
 		Weekly: *weeklyStats,
+		Alerts: alerts,
 	}, nil
 }
 
@@ -395,6 +415,70 @@ func (s *StatisticsService) getWeeklyGrowthStats(ctx context.Context, babyID int
 	return stats, nil
 }
 
+// periodRecordLimit 周期统计单次查询的记录上限(一个月的记录量远低于此值)
+const periodRecordLimit = 2000
+
+// GetPeriodStatistics 统计 [start, end) 时间段内的喂养、睡眠、排泄和成长数据
+// 不校验调用者权限，供周期报告等系统任务使用
+func (s *StatisticsService) GetPeriodStatistics(ctx context.Context, babyID int64, start, end time.Time) (*dto.PeriodStatistics, error) {
+	startTime, endTime := start.UnixMilli(), end.UnixMilli()-1
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+	if days < 1 {
+		days = 1
+	}
+	stats := &dto.PeriodStatistics{Days: days}
+
+	feedings, _, err := s.feedingRecordRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, periodRecordLimit)
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询喂养记录失败", err)
+	}
+	stats.FeedingCount = len(feedings)
+	for _, record := range feedings {
+		if record.FeedingType == "bottle" {
+			stats.BottleMl += record.Amount
+		}
+	}
+
+	sleeps, _, err := s.sleepRecordRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, periodRecordLimit)
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询睡眠记录失败", err)
+	}
+	for _, record := range sleeps {
+		if record.Duration != nil {
+			stats.SleepMinutes += int((*record.Duration + 59) / 60)
+		}
+	}
+
+	diapers, _, err := s.diaperRecordRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, periodRecordLimit)
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询换尿布记录失败", err)
+	}
+	stats.DiaperCount = len(diapers)
+	for _, record := range diapers {
+		if record.Type == "poop" || record.Type == "both" {
+			stats.PoopCount++
+		}
+	}
+
+	growthStats, err := s.getWeeklyGrowthStats(ctx, babyID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	stats.WeightGain = growthStats.WeightGain
+	growth, _, err := s.growthRecordRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, 1)
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询成长记录失败", err)
+	}
+	if len(growth) > 0 {
+		stats.LatestWeight = growth[0].Weight
+	}
+
+	stats.FeedingPerDay = roundToOneDecimal(float64(stats.FeedingCount) / float64(days))
+	stats.SleepMinutesPerDay = roundToOneDecimal(float64(stats.SleepMinutes) / float64(days))
+	stats.DiaperPerDay = roundToOneDecimal(float64(stats.DiaperCount) / float64(days))
+	return stats, nil
+}
+
 // checkBabyAccess 检查用户是否有权访问宝宝
 func (s *StatisticsService) checkBabyAccess(ctx context.Context, babyID int64, openID string) (bool, error) {
 	baby, err := s.babyRepo.FindByID(ctx, babyID)
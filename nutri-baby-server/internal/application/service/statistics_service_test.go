@@ -94,6 +94,7 @@ func TestGetTodayFeedingStats_Accumulation(t *testing.T) {
 		nil, // sleepRecordRepo
 		nil, // diaperRecordRepo
 		nil, // growthRecordRepo
+		nil, // temperatureRepo
 		nil, // userRepo
 		logger,
 	)
@@ -15,10 +15,12 @@ import (
 // TimelineService 时间线服务
 type TimelineService struct {
 	*BaseRecordService
-	feedingService *FeedingRecordService
-	sleepService   *SleepRecordService
-	diaperService  *DiaperRecordService
-	growthService  *GrowthRecordService
+	feedingService   *FeedingRecordService
+	sleepService     *SleepRecordService
+	diaperService    *DiaperRecordService
+	growthService    *GrowthRecordService
+	healthService    *HealthRecordService
+	milestoneService *MilestoneService
 }
 
 // NewTimelineService 创建时间线服务
@@ -30,6 +32,8 @@ func NewTimelineService(
 	sleepService *SleepRecordService,
 	diaperService *DiaperRecordService,
 	growthService *GrowthRecordService,
+	healthService *HealthRecordService,
+	milestoneService *MilestoneService,
 	logger *zap.Logger,
 ) *TimelineService {
 	return &TimelineService{
@@ -38,6 +42,8 @@ func NewTimelineService(
 		sleepService:      sleepService,
 		diaperService:     diaperService,
 		growthService:     growthService,
+		healthService:     healthService,
+		milestoneService:  milestoneService,
 	}
 }
 
@@ -70,6 +76,9 @@ func (s *TimelineService) GetTimeline(ctx context.Context, openID string, query
 	querySleep := recordType == "" || recordType == "sleep"
 	queryDiaper := recordType == "" || recordType == "diaper"
 	queryGrowth := recordType == "" || recordType == "growth"
+	queryTemperature := recordType == "" || recordType == "temperature"
+	querySymptom := recordType == "" || recordType == "symptom"
+	queryMilestone := recordType == "" || recordType == "milestone"
 
 	// 计算需要查询的类型数量
 	queryCount := 0
@@ -85,16 +94,28 @@ func (s *TimelineService) GetTimeline(ctx context.Context, openID string, query
 	if queryGrowth {
 		queryCount++
 	}
+	if queryTemperature {
+		queryCount++
+	}
+	if querySymptom {
+		queryCount++
+	}
+	if queryMilestone {
+		queryCount++
+	}
 
 	// 并发查询所需类型的记录
 	var (
-		feedingRecords []dto.FeedingRecordDTO
-		sleepRecords   []dto.SleepRecordDTO
-		diaperRecords  []dto.DiaperRecordDTO
-		growthRecords  []dto.GrowthRecordDTO
-		wg             sync.WaitGroup
-		mu             sync.Mutex
-		errs           []error
+		feedingRecords     []dto.FeedingRecordDTO
+		sleepRecords       []dto.SleepRecordDTO
+		diaperRecords      []dto.DiaperRecordDTO
+		growthRecords      []dto.GrowthRecordDTO
+		temperatureRecords []*dto.TemperatureRecordDTO
+		symptomRecords     []*dto.SymptomRecordDTO
+		milestoneRecords   []*dto.MilestoneRecordDTO
+		wg                 sync.WaitGroup
+		mu                 sync.Mutex
+		errs               []error
 	)
 
 	wg.Add(queryCount)
@@ -163,6 +184,54 @@ func (s *TimelineService) GetTimeline(ctx context.Context, openID string, query
 		}()
 	}
 
+	// 查询体温记录
+	if queryTemperature {
+		go func() {
+			defer wg.Done()
+			records, _, err := s.healthService.GetTemperatureRecords(ctx, openID, recordQuery)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				s.logger.Warn("获取体温记录失败", zap.Error(err))
+				return
+			}
+			temperatureRecords = records
+		}()
+	}
+
+	// 查询症状记录
+	if querySymptom {
+		go func() {
+			defer wg.Done()
+			records, _, err := s.healthService.GetSymptomRecords(ctx, openID, recordQuery)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				s.logger.Warn("获取症状记录失败", zap.Error(err))
+				return
+			}
+			symptomRecords = records
+		}()
+	}
+
+	// 查询里程碑记录
+	if queryMilestone {
+		go func() {
+			defer wg.Done()
+			records, _, err := s.milestoneService.GetMilestoneRecords(ctx, openID, recordQuery)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				s.logger.Warn("获取里程碑记录失败", zap.Error(err))
+				return
+			}
+			milestoneRecords = records
+		}()
+	}
+
 	wg.Wait()
 
 	// 如果所有查询都失败,返回错误
@@ -233,6 +302,51 @@ func (s *TimelineService) GetTimeline(ctx context.Context, openID string, query
 		items = append(items, item)
 	}
 
+	// 转换体温记录
+	for _, record := range temperatureRecords {
+		item := dto.TimelineItem{
+			RecordType: "temperature",
+			RecordID:   record.RecordID,
+			BabyID:     record.BabyID,
+			EventTime:  record.Time,
+			Detail:     record,
+			CreateBy:   record.CreateBy,
+			CreateTime: record.CreateTime,
+		}
+		s.enrichTimelineItem(ctx, &item)
+		items = append(items, item)
+	}
+
+	// 转换症状记录
+	for _, record := range symptomRecords {
+		item := dto.TimelineItem{
+			RecordType: "symptom",
+			RecordID:   record.RecordID,
+			BabyID:     record.BabyID,
+			EventTime:  record.Time,
+			Detail:     record,
+			CreateBy:   record.CreateBy,
+			CreateTime: record.CreateTime,
+		}
+		s.enrichTimelineItem(ctx, &item)
+		items = append(items, item)
+	}
+
+	// 转换里程碑记录
+	for _, record := range milestoneRecords {
+		item := dto.TimelineItem{
+			RecordType: "milestone",
+			RecordID:   record.RecordID,
+			BabyID:     record.BabyID,
+			EventTime:  record.AchievedAt,
+			Detail:     record,
+			CreateBy:   record.CreateBy,
+			CreateTime: record.CreateTime,
+		}
+		s.enrichTimelineItem(ctx, &item)
+		items = append(items, item)
+	}
+
 	// 按 eventTime 倒序排序 (最新的在前面)
 	sort.Slice(items, func(i, j int) bool {
 		return items[i].EventTime > items[j].EventTime
@@ -28,23 +28,47 @@ const (
 	AIAnalysisStatusAnalyzing AIAnalysisStatus = "analyzing" // 分析中
 	AIAnalysisStatusCompleted AIAnalysisStatus = "completed" // 已完成
 	AIAnalysisStatusFailed    AIAnalysisStatus = "failed"    // 分析失败
+	AIAnalysisStatusCancelled AIAnalysisStatus = "cancelled" // 已取消
 )
 
 // AIAnalysis AI分析结果实体
 type AIAnalysis struct {
 	ID           int64            `json:"id" gorm:"primaryKey;autoIncrement"`
 	BabyID       int64            `json:"baby_id" gorm:"index;not null"`
+	OpenID       string           `json:"-" gorm:"column:openid;type:varchar(64);index"` // 发起用户(用于用量归属和配额)
 	AnalysisType AIAnalysisType   `json:"analysis_type" gorm:"type:varchar(20);not null"`
 	Status       AIAnalysisStatus `json:"status" gorm:"type:varchar(20);not null;default:pending"`
 	StartDate    time.Time        `json:"start_date" gorm:"not null"`
 	EndDate      time.Time        `json:"end_date" gorm:"not null"`
 	InputData    string           `json:"input_data" gorm:"type:text"`              // 输入数据JSON
 	Result       string           `json:"result" gorm:"type:text"`                  // 分析结果JSON
-	Score        *float64         `json:"score,omitempty" gorm:"type:numeric(3,2)"` // 评分(0-100)
+	Score        *float64         `json:"score,omitempty" gorm:"type:numeric(5,2)"` // 评分(0-100)
 	Insights     []string         `json:"insights" gorm:"type:text"`                // 洞察建议
 	Alerts       []string         `json:"alerts" gorm:"type:text"`                  // 异常警告
 	CreatedAt    time.Time        `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// 任务执行状态(租约 + 重试)
+	Provider       string     `json:"provider,omitempty" gorm:"type:varchar(32)"` // 实际产出分析结果的AI提供商(回退链中成功的那个)
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`         // 已尝试次数
+	MaxAttempts    int        `json:"max_attempts" gorm:"not null;default:1"`     // 最大尝试次数
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" gorm:"index"`     // 下次可执行时间(重试退避)
+	LeaseOwner     string     `json:"-" gorm:"type:varchar(64)"`                  // 租约持有者(worker标识)
+	LeaseExpiresAt *time.Time `json:"-" gorm:"index"`                             // 租约到期时间，过期后可被其他worker接管
+	HeartbeatAt    *time.Time `json:"-"`                                          // 最近一次心跳时间
+	FailureReason  string     `json:"failure_reason,omitempty" gorm:"type:text"`  // 最近一次失败原因
+
+	// 结构化输出校验记录(JSON)，模型输出经修复或最终未通过 schema 校验时保存，用于排查
+	ValidationErrors string `json:"validation_errors,omitempty" gorm:"type:text"`
+	// 医疗安全护栏干预记录(JSON)，红旗规则、内容过滤等干预发生时保存
+	GuardrailLog string `json:"guardrail_log,omitempty" gorm:"type:text"`
+
+	// 输入指纹(提示词版本 + 记录数据的摘要)，指纹相同的已完成分析直接复用结果
+	Fingerprint  string `json:"fingerprint,omitempty" gorm:"type:varchar(64);index"`
+	ReusedFromID *int64 `json:"reused_from_id,omitempty"` // 复用结果的来源分析ID，为空表示调用了模型
+
+	// 结果语言(发起用户的语言偏好)，模型按该语言输出面向用户的文本
+	Locale string `json:"locale" gorm:"type:varchar(16);not null;default:'zh-CN'"`
 }
 
 // TableName 表名
@@ -64,6 +88,13 @@ type AIAnalysisResult struct {
 	Predictions  []AIPrediction         `json:"predictions"`
 	Metadata     map[string]interface{} `json:"metadata"`
 	UserFriendly *UserFriendlyResult    `json:"user_friendly,omitempty"` // 用户友好结果
+	Provider     string                 `json:"provider,omitempty"`      // 产出结果的AI提供商
+	Disclaimer   string                 `json:"disclaimer,omitempty"`    // 免责声明
+
+	// 结构化输出校验记录，经修复后通过时非空，随分析任务保存
+	ValidationErrors string `json:"-"`
+	// 医疗安全护栏干预记录，有干预时非空，随分析任务保存
+	GuardrailLog string `json:"-"`
 }
 
 // UserFriendlyResult 用户友好的分析结果
@@ -151,6 +182,18 @@ type DailyTips struct {
 	Tips      datatypes.JSONSlice[DailyTip] `json:"tips" gorm:"type:jsonb"`
 	ExpiredAt time.Time                     `json:"expired_at" gorm:"not null"`
 	CreatedAt time.Time                     `json:"created_at" gorm:"autoCreateTime"`
+
+	// 免责声明
+	Disclaimer string `json:"disclaimer" gorm:"type:text"`
+
+	// 结构化输出校验记录(JSON)，模型输出经修复后通过时保存
+	ValidationErrors string `json:"-" gorm:"type:text"`
+	// 医疗安全护栏干预记录(JSON)
+	GuardrailLog string `json:"-" gorm:"type:text"`
+	// 输入指纹(提示词版本 + 记录数据的摘要)
+	Fingerprint string `json:"-" gorm:"type:varchar(64);index"`
+	// 建议语言，同一天每种语言各生成一份
+	Locale string `json:"locale" gorm:"type:varchar(16);not null;default:'zh-CN'"`
 }
 
 // DailyTip 单个建议
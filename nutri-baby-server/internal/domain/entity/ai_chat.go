@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/plugin/soft_delete"
+)
+
+// AI对话消息角色常量
+const (
+	AIChatRoleUser      = "user"      // 用户提问
+	AIChatRoleAssistant = "assistant" // 助手回答
+)
+
+// AIChatCitation 回答引用的记录(均来自本轮工具调用实际查到的数据)
+type AIChatCitation struct {
+	RecordType string `json:"recordType"` // 记录类型: feeding, sleep, diaper, growth, vaccine, illness, crying, temperature, symptom, milestone
+	RecordID   string `json:"recordId"`   // 记录ID
+	Time       int64  `json:"time"`       // 记录时间(毫秒时间戳)
+}
+
+// AIChatMessage AI助手对话消息(按宝宝+用户隔离的多轮记忆)
+type AIChatMessage struct {
+	ID        int64                               `gorm:"primaryKey;column:id" json:"id"`                                                        // 雪花ID主键
+	BabyID    int64                               `gorm:"column:baby_id;not null;index:idx_baby_openid_created" json:"babyId"`                   // 宝宝ID (引用Baby.ID)
+	OpenID    string                              `gorm:"column:openid;type:varchar(64);not null;index:idx_baby_openid_created" json:"-"`        // 提问用户
+	Role      string                              `gorm:"column:role;type:varchar(16);not null" json:"role"`                                     // 角色: user, assistant
+	Content   string                              `gorm:"column:content;type:text;not null" json:"content"`                                      // 消息内容
+	Citations datatypes.JSONSlice[AIChatCitation] `gorm:"column:citations;type:jsonb" json:"citations"`                                          // 引用的记录(仅助手消息)
+	Tools     datatypes.JSONSlice[string]         `gorm:"column:tools;type:jsonb" json:"tools"`                                                  // 本轮调用的数据工具(仅助手消息)
+	CreatedAt int64                               `gorm:"column:created_at;autoCreateTime:milli;index:idx_baby_openid_created" json:"createdAt"` // 创建时间(毫秒时间戳)
+	DeletedAt soft_delete.DeletedAt               `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`                           // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (AIChatMessage) TableName() string {
+	return "ai_chat_messages"
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AIDigestPeriod 周期报告类型
+type AIDigestPeriod string
+
+const (
+	AIDigestPeriodWeekly  AIDigestPeriod = "weekly"  // 周报
+	AIDigestPeriodMonthly AIDigestPeriod = "monthly" // 月报
+)
+
+// AIDigestStatus 周期报告状态
+type AIDigestStatus string
+
+const (
+	AIDigestStatusPending   AIDigestStatus = "pending"   // 等待各项分析完成
+	AIDigestStatusCompleted AIDigestStatus = "completed" // 已汇总
+	AIDigestStatusFailed    AIDigestStatus = "failed"    // 提交分析任务失败，不再汇总
+)
+
+// AIDigest AI周期报告(周报/月报)
+// 由定时任务为活跃宝宝批量创建喂养、睡眠、成长等分析，全部结束后汇总为一份报告并推送给协作者
+type AIDigest struct {
+	ID          int64                                `json:"id" gorm:"primaryKey;autoIncrement"`
+	BabyID      int64                                `json:"baby_id" gorm:"not null;uniqueIndex:idx_ai_digest_period"`
+	Period      AIDigestPeriod                       `json:"period" gorm:"type:varchar(16);not null;uniqueIndex:idx_ai_digest_period"`
+	StartDate   time.Time                            `json:"start_date" gorm:"not null;uniqueIndex:idx_ai_digest_period"`
+	EndDate     time.Time                            `json:"end_date" gorm:"not null"`
+	Status      AIDigestStatus                       `json:"status" gorm:"type:varchar(16);not null;default:pending;index"`
+	AnalysisIDs datatypes.JSONSlice[int64]           `json:"analysis_ids" gorm:"type:jsonb"` // 本期批量分析任务
+	Summary     string                               `json:"summary" gorm:"type:text"`       // 总结
+	Score       *float64                             `json:"score,omitempty"`                // 各项分析平均分
+	Sections    datatypes.JSONSlice[AIDigestSection] `json:"sections" gorm:"type:jsonb"`     // 各类型分析摘要
+	Trends      datatypes.JSONSlice[AIDigestTrend]   `json:"trends" gorm:"type:jsonb"`       // 与上一周期对比的统计趋势
+	Milestones  datatypes.JSONSlice[AIDigestItem]    `json:"milestones" gorm:"type:jsonb"`   // 本期亮点
+	Concerns    datatypes.JSONSlice[AIDigestItem]    `json:"concerns" gorm:"type:jsonb"`     // 需要关注的问题
+	NotifiedAt  *time.Time                           `json:"notified_at,omitempty"`          // 推送时间
+	CompletedAt *time.Time                           `json:"completed_at,omitempty"`
+	CreatedAt   time.Time                            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time                            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 表名
+func (AIDigest) TableName() string {
+	return "ai_digests"
+}
+
+// AIDigestSection 一项分析在报告中的摘要
+type AIDigestSection struct {
+	AnalysisType  AIAnalysisType   `json:"analysis_type"`
+	AnalysisID    int64            `json:"analysis_id"`
+	Status        AIAnalysisStatus `json:"status"`
+	Score         *float64         `json:"score,omitempty"`
+	PreviousScore *float64         `json:"previous_score,omitempty"` // 上一期报告中同类分析的评分
+	Summary       string           `json:"summary,omitempty"`
+}
+
+// AIDigestTrend 统计指标与上一周期的对比
+type AIDigestTrend struct {
+	Metric   string  `json:"metric"` // feeding_per_day/sleep_minutes_per_day/diaper_per_day/weight_gain
+	Label    string  `json:"label"`
+	Unit     string  `json:"unit"`
+	Current  float64 `json:"current"`
+	Previous float64 `json:"previous"`
+	Change   float64 `json:"change"` // 变化百分比，上一周期为 0 时为 0
+}
+
+// AIDigestItem 报告中的亮点或关注项
+type AIDigestItem struct {
+	Type        string `json:"type"` // 来源: feeding/sleep/growth/health/behavior/trend
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Level       string `json:"level,omitempty"` // 关注项级别(critical/warning)
+}
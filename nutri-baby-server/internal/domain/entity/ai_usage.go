@@ -0,0 +1,34 @@
+package entity
+
+// AI调用用途常量
+const (
+	AIUsageOperationAnalysis  = "analysis"   // 数据分析
+	AIUsageOperationDailyTips = "daily_tips" // 每日建议
+	AIUsageOperationChat      = "chat"       // 育儿助手对话
+	AIUsageOperationQuickLog  = "quick_log"  // 快速记录解析
+)
+
+// AIUsageRecord AI模型调用用量(每次模型调用一条)
+// 一次业务请求(如一次分析)会包含多轮模型调用，通过 RequestID 归并
+type AIUsageRecord struct {
+	ID               int64   `gorm:"primaryKey;column:id" json:"id"`
+	RequestID        string  `gorm:"column:request_id;type:varchar(64);not null;index" json:"requestId"`                                                              // 业务请求ID
+	Operation        string  `gorm:"column:operation;type:varchar(32);not null" json:"operation"`                                                                     // 用途: analysis, daily_tips, chat, quick_log
+	OpenID           string  `gorm:"column:openid;type:varchar(64);not null;default:'';index:idx_ai_usage_openid_created" json:"openid"`                              // 发起用户，定时任务为空
+	BabyID           int64   `gorm:"column:baby_id;not null;default:0;index:idx_ai_usage_baby_created" json:"babyId"`                                                 // 宝宝ID
+	Provider         string  `gorm:"column:provider;type:varchar(32);not null" json:"provider"`                                                                       // AI提供商
+	Model            string  `gorm:"column:model;type:varchar(64)" json:"model"`                                                                                      // 模型名称
+	PromptTokens     int     `gorm:"column:prompt_tokens;not null;default:0" json:"promptTokens"`                                                                     // 输入token数
+	CompletionTokens int     `gorm:"column:completion_tokens;not null;default:0" json:"completionTokens"`                                                             // 输出token数
+	TotalTokens      int     `gorm:"column:total_tokens;not null;default:0" json:"totalTokens"`                                                                       // 总token数
+	Cost             float64 `gorm:"column:cost;type:numeric(12,6);not null;default:0" json:"cost"`                                                                   // 按调用时价格估算的费用(美元)
+	LatencyMs        int64   `gorm:"column:latency_ms;not null;default:0" json:"latencyMs"`                                                                           // 调用耗时(毫秒)
+	Success          bool    `gorm:"column:success;not null" json:"success"`                                                                                          // 是否调用成功
+	ErrorMessage     string  `gorm:"column:error_message;type:text" json:"errorMessage,omitempty"`                                                                    // 失败原因
+	CreatedAt        int64   `gorm:"column:created_at;autoCreateTime:milli;index;index:idx_ai_usage_openid_created;index:idx_ai_usage_baby_created" json:"createdAt"` // 调用时间(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (AIUsageRecord) TableName() string {
+	return "ai_usage_records"
+}
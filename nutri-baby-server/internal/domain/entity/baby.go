@@ -18,6 +18,7 @@ type Baby struct {
 	Weight      float64               `gorm:"column:weight;type:decimal(10,2)" json:"weight"`                // 体重 kg
 	UserID      int64                 `gorm:"column:user_id;index" json:"userId"`                            // 创建者用户ID (引用User.ID)
 	FamilyGroup string                `gorm:"column:family_group;type:varchar(64);index" json:"familyGroup"` // 可选的家庭分组名称
+	Timezone    string                `gorm:"column:timezone;type:varchar(64)" json:"timezone"`              // 时区(IANA名称，如 Asia/Shanghai)，为空时使用服务器时区
 	CreatedAt   int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`       // 创建时间(毫秒时间戳)
 	UpdatedAt   int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`       // 更新时间(毫秒时间戳)
 	DeletedAt   soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`   // 软删除(毫秒时间戳)
@@ -31,6 +32,18 @@ func (Baby) TableName() string {
 	return "babies"
 }
 
+// Location 宝宝所在时区，未设置或无效时使用服务器时区
+func (b *Baby) Location() *time.Location {
+	if b.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
 // BabyFamilyMember 宝宝亲友团成员实体 (原 BabyCollaborator)
 type BabyCollaborator struct {
 	ID           int64                 `gorm:"primaryKey;column:id" json:"id"`                                            // 雪花ID主键
@@ -0,0 +1,50 @@
+package entity
+
+import "gorm.io/plugin/soft_delete"
+
+// 哭闹类型常量
+const (
+	CryingTypeCrying = "crying" // 哭闹
+	CryingTypeFussy  = "fussy"  // 烦躁/闹觉
+)
+
+// 哭闹强度常量
+const (
+	CryingIntensityMild     = "mild"     // 轻度: 很快能安抚
+	CryingIntensityModerate = "moderate" // 中度: 需要一段时间安抚
+	CryingIntensityIntense  = "intense"  // 剧烈: 难以安抚
+)
+
+// 哭闹诱因常量
+const (
+	CryingTriggerHunger     = "hunger"     // 饥饿
+	CryingTriggerTired      = "tired"      // 困倦
+	CryingTriggerDiscomfort = "discomfort" // 不适(尿布、冷热等)
+	CryingTriggerGas        = "gas"        // 胀气
+	CryingTriggerTeething   = "teething"   // 出牙
+	CryingTriggerUnknown    = "unknown"    // 不明原因
+)
+
+// CryingRecord 哭闹记录实体
+type CryingRecord struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	StartTime       int64                 `gorm:"column:start_time;index" json:"startTime"`                          // 开始时间(毫秒时间戳)
+	Duration        int                   `gorm:"column:duration" json:"duration"`                                   // 时长(秒)
+	Type            string                `gorm:"column:type;type:varchar(16)" json:"type"`                          // crying, fussy
+	Intensity       string                `gorm:"column:intensity;type:varchar(16)" json:"intensity"`                // mild, moderate, intense
+	Trigger         string                `gorm:"column:trigger;type:varchar(16)" json:"trigger"`                    // hunger, tired, discomfort, gas, teething, unknown
+	Soothing        *string               `gorm:"column:soothing;type:varchar(64)" json:"soothing"`                  // 有效的安抚方式
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (CryingRecord) TableName() string {
+	return "crying_records"
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/plugin/soft_delete"
+)
+
+// 常见过敏原常量
+const (
+	AllergenPeanut    = "peanut"    // 花生
+	AllergenEgg       = "egg"       // 鸡蛋
+	AllergenDairy     = "dairy"     // 牛奶及奶制品
+	AllergenTreeNut   = "tree_nut"  // 坚果(核桃、杏仁、腰果等)
+	AllergenWheat     = "wheat"     // 小麦
+	AllergenSoy       = "soy"       // 大豆
+	AllergenFish      = "fish"      // 鱼类
+	AllergenShellfish = "shellfish" // 甲壳类(虾、蟹)
+	AllergenSesame    = "sesame"    // 芝麻
+)
+
+// TopAllergens 重点关注的常见过敏原(按建议引入顺序)
+var TopAllergens = []string{
+	AllergenEgg,
+	AllergenPeanut,
+	AllergenDairy,
+	AllergenWheat,
+	AllergenSoy,
+	AllergenFish,
+	AllergenShellfish,
+	AllergenTreeNut,
+	AllergenSesame,
+}
+
+// 食物反应严重程度常量
+const (
+	FoodReactionSeverityMild     = "mild"     // 轻微: 局部皮疹、口周发红
+	FoodReactionSeverityModerate = "moderate" // 中度: 全身荨麻疹、呕吐、腹泻
+	FoodReactionSeveritySevere   = "severe"   // 严重: 呼吸困难、面部肿胀、精神萎靡
+)
+
+// FoodReaction 食物反应记录
+type FoodReaction struct {
+	ID              int64                       `gorm:"primaryKey;column:id" json:"id"`                                 // 雪花ID主键
+	BabyID          int64                       `gorm:"column:baby_id;not null;index" json:"babyId"`                    // 宝宝ID (引用Baby.ID)
+	FoodKey         string                      `gorm:"column:food_key;type:varchar(64);not null;index" json:"foodKey"` // 食物标识(目录编码或归一化名称)
+	FoodName        string                      `gorm:"column:food_name;type:varchar(64);not null" json:"foodName"`     // 食物名称
+	FeedingRecordID *int64                      `gorm:"column:feeding_record_id" json:"feedingRecordId,omitempty"`      // 关联的喂养记录ID(可选)
+	Severity        string                      `gorm:"column:severity;type:varchar(16);not null" json:"severity"`      // 严重程度: mild, moderate, severe
+	Symptoms        datatypes.JSONSlice[string] `gorm:"column:symptoms;type:jsonb" json:"symptoms"`                     // 症状: rash, hives, vomiting, diarrhea, swelling, wheezing...
+	Note            string                      `gorm:"column:note;type:text" json:"note"`                              // 备注
+	OccurredAt      int64                       `gorm:"column:occurred_at;not null" json:"occurredAt"`                  // 发生时间(毫秒时间戳)
+	CreatedBy       int64                       `gorm:"column:created_by;not null" json:"createdBy"`                    // 记录人(引用User.ID)
+	CreatedAt       int64                       `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`        // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                       `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`        // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt       `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`    // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (FoodReaction) TableName() string {
+	return "food_reactions"
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"gorm.io/plugin/soft_delete"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 健康提醒级别常量
+const (
+	HealthAlertLevelCritical = "critical" // 需尽快就医
+	HealthAlertLevelWarning  = "warning"  // 建议联系医生
+	HealthAlertLevelInfo     = "info"     // 提示关注
+)
+
+// 健康提醒来源常量
+const (
+	HealthAlertSourceDiaper      = "diaper"      // 排泄记录筛查
+	HealthAlertSourceTemperature = "temperature" // 体温记录筛查
+	HealthAlertSourceMilestone   = "milestone"   // 发育里程碑(仅清单中展示，不持久化)
+)
+
+// HealthAlert 健康提醒(规则引擎触发的记录，用于去重和通知管理员)
+type HealthAlert struct {
+	ID          int64                 `gorm:"primaryKey;column:id" json:"id"`                                                      // 雪花ID主键
+	BabyID      int64                 `gorm:"column:baby_id;not null;uniqueIndex:idx_baby_dedup_key" json:"babyId"`                // 宝宝ID (引用Baby.ID)
+	Source      string                `gorm:"column:source;type:varchar(16);not null;index" json:"source"`                         // 来源: diaper, temperature
+	RuleCode    string                `gorm:"column:rule_code;type:varchar(32);not null" json:"ruleCode"`                          // 触发规则
+	Level       string                `gorm:"column:level;type:varchar(16);not null" json:"level"`                                 // 级别: critical, warning, info
+	Title       string                `gorm:"column:title;type:varchar(64);not null" json:"title"`                                 // 标题
+	Message     string                `gorm:"column:message;type:text;not null" json:"message"`                                    // 提醒内容
+	RecordID    *int64                `gorm:"column:record_id" json:"recordId,omitempty"`                                          // 触发的记录ID(可选)
+	DedupKey    string                `gorm:"column:dedup_key;type:varchar(128);not null;uniqueIndex:idx_baby_dedup_key" json:"-"` // 去重键
+	TriggeredAt int64                 `gorm:"column:triggered_at;not null;index" json:"triggeredAt"`                               // 触发时间(毫秒时间戳)
+	NotifiedAt  *int64                `gorm:"column:notified_at" json:"notifiedAt,omitempty"`                                      // 通知发送时间(毫秒时间戳)
+	CreatedAt   int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`                             // 创建时间(毫秒时间戳)
+	UpdatedAt   int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`                             // 更新时间(毫秒时间戳)
+	DeletedAt   soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`                         // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (HealthAlert) TableName() string {
+	return "health_alerts"
+}
+
+// LocalizedText 按语言返回标题和内容
+// 规则触发时保存的是中文(含具体数值)，其他语言有该规则的译文时使用译文，否则返回保存的原文
+func (a *HealthAlert) LocalizedText(locale i18n.Locale) (title, message string) {
+	title, message = a.Title, a.Message
+	if key := "health_alert." + a.RuleCode + ".title"; !locale.IsDefault() && locale.Has(key) {
+		title = locale.T(key)
+	}
+	if key := "health_alert." + a.RuleCode + ".message"; !locale.IsDefault() && locale.Has(key) {
+		message = locale.T(key)
+	}
+	return title, message
+}
@@ -0,0 +1,103 @@
+package entity
+
+import "gorm.io/plugin/soft_delete"
+
+// 体温测量部位常量
+const (
+	TemperatureSiteAxillary = "axillary" // 腋下
+	TemperatureSiteOral     = "oral"     // 口腔
+	TemperatureSiteRectal   = "rectal"   // 肛门
+	TemperatureSiteEar      = "ear"      // 耳温
+	TemperatureSiteForehead = "forehead" // 额温
+)
+
+// coreFeverThreshold 肛温/耳温的发热标准(℃)，其他部位按差值换算
+const coreFeverThreshold = 38.0
+
+// 症状严重程度常量
+const (
+	SymptomSeverityMild     = "mild"     // 轻度: 不影响吃睡
+	SymptomSeverityModerate = "moderate" // 中度: 影响吃睡
+	SymptomSeveritySevere   = "severe"   // 重度: 精神差或明显痛苦
+)
+
+// 常见症状常量(也可以记录其他自定义症状)
+const (
+	SymptomCough      = "cough"      // 咳嗽
+	SymptomRunnyNose  = "runny_nose" // 流涕
+	SymptomCongestion = "congestion" // 鼻塞
+	SymptomVomiting   = "vomiting"   // 呕吐
+	SymptomDiarrhea   = "diarrhea"   // 腹泻
+	SymptomRash       = "rash"       // 皮疹
+	SymptomPoorFeed   = "poor_feed"  // 吃奶差
+	SymptomLethargy   = "lethargy"   // 精神差
+	SymptomWheezing   = "wheezing"   // 喘息
+)
+
+// TemperatureFeverThreshold 按测量部位返回发热标准(℃)
+// 腋温、额温比核心体温低约0.5℃，口温低约0.2℃
+func TemperatureFeverThreshold(site string) float64 {
+	switch site {
+	case TemperatureSiteAxillary, TemperatureSiteForehead:
+		return 37.5
+	case TemperatureSiteOral:
+		return 37.8
+	default:
+		return coreFeverThreshold
+	}
+}
+
+// TemperatureRecord 体温记录实体
+type TemperatureRecord struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	EpisodeID       *int64                `gorm:"column:episode_id;index" json:"episodeId"`                          // 所属患病经过ID (引用IllnessEpisode.ID)
+	Time            int64                 `gorm:"column:time;index" json:"time"`                                     // 测量时间(毫秒时间戳)
+	Temperature     float64               `gorm:"column:temperature" json:"temperature"`                             // 体温(℃)
+	Site            string                `gorm:"column:site;type:varchar(16)" json:"site"`                          // axillary, oral, rectal, ear, forehead
+	Fever           bool                  `gorm:"column:fever;default:false" json:"fever"`                           // 是否达到该部位的发热标准
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (TemperatureRecord) TableName() string {
+	return "temperature_records"
+}
+
+// IsFever 是否达到该测量部位的发热标准
+func (r *TemperatureRecord) IsFever() bool {
+	return r.Temperature >= TemperatureFeverThreshold(r.Site)
+}
+
+// CoreEquivalent 换算为肛温/耳温等效值，便于按统一阈值判断高热
+func (r *TemperatureRecord) CoreEquivalent() float64 {
+	return r.Temperature + coreFeverThreshold - TemperatureFeverThreshold(r.Site)
+}
+
+// SymptomRecord 症状记录实体
+type SymptomRecord struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	EpisodeID       *int64                `gorm:"column:episode_id;index" json:"episodeId"`                          // 所属患病经过ID (引用IllnessEpisode.ID)
+	Time            int64                 `gorm:"column:time;index" json:"time"`                                     // 出现时间(毫秒时间戳)
+	Symptom         string                `gorm:"column:symptom;type:varchar(32);not null" json:"symptom"`           // 症状, 如 cough, vomiting
+	Severity        string                `gorm:"column:severity;type:varchar(16)" json:"severity"`                  // mild, moderate, severe
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (SymptomRecord) TableName() string {
+	return "symptom_records"
+}
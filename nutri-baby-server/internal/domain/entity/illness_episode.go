@@ -0,0 +1,31 @@
+package entity
+
+import "gorm.io/plugin/soft_delete"
+
+// IllnessEpisode 患病经过实体(一次感冒、发热、腹泻等从开始到痊愈的过程)
+type IllnessEpisode struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	Name            string                `gorm:"column:name;type:varchar(64);not null" json:"name"`                 // 名称, 如"感冒"、"幼儿急疹"
+	StartTime       int64                 `gorm:"column:start_time;index" json:"startTime"`                          // 开始时间(毫秒时间戳)
+	EndTime         *int64                `gorm:"column:end_time" json:"endTime"`                                    // 痊愈时间(毫秒时间戳), 为空表示尚未痊愈
+	SawDoctor       bool                  `gorm:"column:saw_doctor;default:false" json:"sawDoctor"`                  // 是否就医
+	Diagnosis       *string               `gorm:"column:diagnosis;type:varchar(128)" json:"diagnosis"`               // 医生诊断
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (IllnessEpisode) TableName() string {
+	return "illness_episodes"
+}
+
+// IsOngoing 是否尚未痊愈
+func (e *IllnessEpisode) IsOngoing() bool {
+	return e.EndTime == nil
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/plugin/soft_delete"
+)
+
+// 药品类型常量
+const (
+	MedicationKindMedicine   = "medicine"   // 药物(退热药、抗生素等)
+	MedicationKindSupplement = "supplement" // 营养补充剂(维生素D、铁剂等)
+)
+
+// 剂量单位常量
+const (
+	DoseUnitMl     = "ml"     // 毫升(口服液、滴剂)
+	DoseUnitMg     = "mg"     // 毫克
+	DoseUnitDrop   = "drop"   // 滴
+	DoseUnitTablet = "tablet" // 片
+	DoseUnitSachet = "sachet" // 袋
+	DoseUnitIU     = "iu"     // 国际单位
+)
+
+// 用药计划类型常量
+const (
+	MedicationScheduleTimes    = "times"    // 每天固定时间(如 08:00,20:00)
+	MedicationScheduleInterval = "interval" // 距上次给药固定间隔(如发热期间每6小时)
+)
+
+// Medication 宝宝的药品/补充剂
+// 剂量限制均为可选，0 表示不校验；按毫克校验时需要填写规格(每单位含多少毫克)
+type Medication struct {
+	ID               int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID           int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	Name             string                `gorm:"column:name;type:varchar(64);not null" json:"name"`                 // 名称, 如"维生素D滴剂"、"对乙酰氨基酚混悬液"
+	Kind             string                `gorm:"column:kind;type:varchar(16);not null" json:"kind"`                 // 类型: medicine, supplement
+	DoseUnit         string                `gorm:"column:dose_unit;type:varchar(16);not null" json:"doseUnit"`        // 剂量单位: ml, mg, drop, tablet, sachet, iu
+	DefaultDose      float64               `gorm:"column:default_dose" json:"defaultDose"`                            // 常用剂量(按剂量单位)
+	Strength         float64               `gorm:"column:strength" json:"strength"`                                   // 规格: 每剂量单位含多少毫克(如 160mg/5ml 为 32)
+	MaxDoseMgPerKg   float64               `gorm:"column:max_dose_mg_per_kg" json:"maxDoseMgPerKg"`                   // 单次最大剂量(mg/kg)
+	MaxDailyMgPerKg  float64               `gorm:"column:max_daily_mg_per_kg" json:"maxDailyMgPerKg"`                 // 24小时最大剂量(mg/kg)
+	MaxDailyMg       float64               `gorm:"column:max_daily_mg" json:"maxDailyMg"`                             // 24小时最大剂量(mg, 不论体重)
+	MaxDailyDoses    int                   `gorm:"column:max_daily_doses" json:"maxDailyDoses"`                       // 24小时最多给药次数
+	MinIntervalHours float64               `gorm:"column:min_interval_hours" json:"minIntervalHours"`                 // 两次给药最短间隔(小时)
+	Active           bool                  `gorm:"column:active;default:true" json:"active"`                          // 是否在用(停药后保留历史记录)
+	Note             *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注(如医嘱)
+	CreatedBy        int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName    string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar  string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt        int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt        int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt        soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (Medication) TableName() string {
+	return "medications"
+}
+
+// DoseMg 将剂量换算为毫克，未填写规格时无法换算
+func (m *Medication) DoseMg(amount float64) (float64, bool) {
+	if m.DoseUnit == DoseUnitMg {
+		return amount, true
+	}
+	if m.Strength <= 0 {
+		return 0, false
+	}
+	return amount * m.Strength, true
+}
+
+// MedicationDose 给药记录
+type MedicationDose struct {
+	ID              int64                       `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                       `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	MedicationID    int64                       `gorm:"column:medication_id;index" json:"medicationId"`                    // 药品ID (引用Medication.ID)
+	Time            int64                       `gorm:"column:time;index" json:"time"`                                     // 给药时间(毫秒时间戳)
+	Amount          float64                     `gorm:"column:amount" json:"amount"`                                       // 剂量(按药品剂量单位)
+	Warnings        datatypes.JSONSlice[string] `gorm:"column:warnings;type:jsonb" json:"warnings"`                        // 记录时的剂量校验警告
+	Note            *string                     `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                       `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                      `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                      `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                       `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                       `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt       `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (MedicationDose) TableName() string {
+	return "medication_doses"
+}
+
+// MedicationSchedule 用药计划(驱动给药提醒)
+type MedicationSchedule struct {
+	ID             int64                 `gorm:"primaryKey;column:id" json:"id"`                               // 雪花ID主键
+	BabyID         int64                 `gorm:"column:baby_id;index" json:"babyId"`                           // 宝宝ID (引用Baby.ID)
+	MedicationID   int64                 `gorm:"column:medication_id;index" json:"medicationId"`               // 药品ID (引用Medication.ID)
+	Mode           string                `gorm:"column:mode;type:varchar(16);not null" json:"mode"`            // 计划类型: times, interval
+	TimesOfDay     string                `gorm:"column:times_of_day;type:varchar(128)" json:"timesOfDay"`      // 每天给药时间, 逗号分隔(如 "08:00,20:00"), mode=times 时有效
+	IntervalHours  float64               `gorm:"column:interval_hours" json:"intervalHours"`                   // 给药间隔(小时), mode=interval 时有效
+	StartDate      string                `gorm:"column:start_date;type:varchar(10);not null" json:"startDate"` // 开始日期(YYYY-MM-DD)
+	EndDate        *string               `gorm:"column:end_date;type:varchar(10)" json:"endDate"`              // 结束日期(YYYY-MM-DD), 为空表示长期
+	Enabled        bool                  `gorm:"column:enabled;default:true;index" json:"enabled"`             // 是否启用提醒
+	LastRemindedAt int64                 `gorm:"column:last_reminded_at;default:0" json:"lastRemindedAt"`      // 最近一次提醒对应的计划给药时间(毫秒时间戳)
+	CreatedBy      int64                 `gorm:"column:created_by" json:"createdBy"`                           // 创建者用户ID (引用User.ID)
+	CreatedAt      int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`      // 创建时间(毫秒时间戳)
+	UpdatedAt      int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`      // 更新时间(毫秒时间戳)
+	DeletedAt      soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`  // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (MedicationSchedule) TableName() string {
+	return "medication_schedules"
+}
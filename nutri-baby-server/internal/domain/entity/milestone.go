@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/plugin/soft_delete"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 里程碑领域常量
+const (
+	MilestoneDomainMotor     = "motor"     // 大运动与精细动作
+	MilestoneDomainLanguage  = "language"  // 语言
+	MilestoneDomainSocial    = "social"    // 社交与情绪
+	MilestoneDomainCognitive = "cognitive" // 认知
+)
+
+// 里程碑状态常量
+const (
+	MilestoneStatusAchieved = "achieved"  // 已达成
+	MilestoneStatusUpcoming = "upcoming"  // 尚未到常见月龄
+	MilestoneStatusInWindow = "in_window" // 处于常见月龄范围内
+	MilestoneStatusDelayed  = "delayed"   // 超过常见月龄仍未达成(软提醒)
+)
+
+// MilestoneDefinition 发育里程碑定义
+// 月龄范围为大多数宝宝出现该能力的时间，[StartMonths, EndMonths) 按满月计算
+type MilestoneDefinition struct {
+	Code        string // 里程碑编码, 名称通过 i18n 键 milestone.<code> 查询
+	Domain      string // 领域: motor, language, social, cognitive
+	StartMonths int    // 常见最早月龄
+	EndMonths   int    // 常见最晚月龄, 满该月龄仍未达成时提示关注
+}
+
+// MilestoneCatalog 内置发育里程碑目录(按领域、常见月龄排序)
+// 参考儿童保健手册和 WHO/CDC 发育里程碑，仅用于提示关注，不作为发育评估结论
+var MilestoneCatalog = []MilestoneDefinition{
+	// 大运动与精细动作
+	{Code: "head_up_prone", Domain: MilestoneDomainMotor, StartMonths: 1, EndMonths: 4},
+	{Code: "roll_over", Domain: MilestoneDomainMotor, StartMonths: 3, EndMonths: 6},
+	{Code: "sit_without_support", Domain: MilestoneDomainMotor, StartMonths: 5, EndMonths: 9},
+	{Code: "crawl", Domain: MilestoneDomainMotor, StartMonths: 6, EndMonths: 11},
+	{Code: "pull_to_stand", Domain: MilestoneDomainMotor, StartMonths: 6, EndMonths: 11},
+	{Code: "walk_with_support", Domain: MilestoneDomainMotor, StartMonths: 7, EndMonths: 13},
+	{Code: "pincer_grasp", Domain: MilestoneDomainMotor, StartMonths: 8, EndMonths: 12},
+	{Code: "stand_alone", Domain: MilestoneDomainMotor, StartMonths: 7, EndMonths: 17},
+	{Code: "walk_alone", Domain: MilestoneDomainMotor, StartMonths: 8, EndMonths: 18},
+	{Code: "run", Domain: MilestoneDomainMotor, StartMonths: 14, EndMonths: 24},
+	// 语言
+	{Code: "coo", Domain: MilestoneDomainLanguage, StartMonths: 1, EndMonths: 4},
+	{Code: "laugh", Domain: MilestoneDomainLanguage, StartMonths: 3, EndMonths: 6},
+	{Code: "babble", Domain: MilestoneDomainLanguage, StartMonths: 5, EndMonths: 10},
+	{Code: "respond_name", Domain: MilestoneDomainLanguage, StartMonths: 6, EndMonths: 10},
+	{Code: "first_word", Domain: MilestoneDomainLanguage, StartMonths: 9, EndMonths: 15},
+	{Code: "three_words", Domain: MilestoneDomainLanguage, StartMonths: 12, EndMonths: 18},
+	{Code: "two_word_phrase", Domain: MilestoneDomainLanguage, StartMonths: 18, EndMonths: 30},
+	// 社交与情绪
+	{Code: "social_smile", Domain: MilestoneDomainSocial, StartMonths: 1, EndMonths: 3},
+	{Code: "recognize_caregivers", Domain: MilestoneDomainSocial, StartMonths: 3, EndMonths: 6},
+	{Code: "wave_bye", Domain: MilestoneDomainSocial, StartMonths: 9, EndMonths: 14},
+	{Code: "point_to_show", Domain: MilestoneDomainSocial, StartMonths: 12, EndMonths: 18},
+	{Code: "pretend_play", Domain: MilestoneDomainSocial, StartMonths: 18, EndMonths: 30},
+	// 认知
+	{Code: "track_objects", Domain: MilestoneDomainCognitive, StartMonths: 1, EndMonths: 3},
+	{Code: "reach_for_toys", Domain: MilestoneDomainCognitive, StartMonths: 3, EndMonths: 6},
+	{Code: "object_permanence", Domain: MilestoneDomainCognitive, StartMonths: 8, EndMonths: 12},
+	{Code: "imitate_actions", Domain: MilestoneDomainCognitive, StartMonths: 9, EndMonths: 14},
+	{Code: "follow_one_step", Domain: MilestoneDomainCognitive, StartMonths: 12, EndMonths: 18},
+	{Code: "stack_blocks", Domain: MilestoneDomainCognitive, StartMonths: 15, EndMonths: 24},
+}
+
+// FindMilestone 按编码查找里程碑定义, 未找到时返回 nil
+func FindMilestone(code string) *MilestoneDefinition {
+	for i := range MilestoneCatalog {
+		if MilestoneCatalog[i].Code == code {
+			return &MilestoneCatalog[i]
+		}
+	}
+	return nil
+}
+
+// Name 按语言返回里程碑名称
+func (d *MilestoneDefinition) Name(locale i18n.Locale) string {
+	return locale.T("milestone." + d.Code)
+}
+
+// StatusAt 按满月月龄判断里程碑状态
+func (d *MilestoneDefinition) StatusAt(ageMonths int, achieved bool) string {
+	switch {
+	case achieved:
+		return MilestoneStatusAchieved
+	case ageMonths < d.StartMonths:
+		return MilestoneStatusUpcoming
+	case ageMonths < d.EndMonths:
+		return MilestoneStatusInWindow
+	default:
+		return MilestoneStatusDelayed
+	}
+}
+
+// AgeInMonths 计算 at 时刻的满月月龄
+func AgeInMonths(birthDate, at time.Time) int {
+	months := (at.Year()-birthDate.Year())*12 + int(at.Month()) - int(birthDate.Month())
+	if at.Day() < birthDate.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	return months
+}
+
+// MilestoneRecord 里程碑达成记录(每个宝宝每个里程碑一条)
+type MilestoneRecord struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                       // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;not null;index" json:"babyId"`                          // 宝宝ID (引用Baby.ID)
+	MilestoneCode   string                `gorm:"column:milestone_code;type:varchar(32);not null" json:"milestoneCode"` // 里程碑编码(引用 MilestoneCatalog)
+	AchievedAt      int64                 `gorm:"column:achieved_at;not null;index" json:"achievedAt"`                  // 达成时间(毫秒时间戳)
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                    // 备注
+	PhotoURL        *string               `gorm:"column:photo_url;type:varchar(512)" json:"photoUrl"`                   // 照片(可选)
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                   // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`         // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"`    // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`              // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`              // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`          // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (MilestoneRecord) TableName() string {
+	return "milestone_records"
+}
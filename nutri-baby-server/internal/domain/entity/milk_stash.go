@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/plugin/soft_delete"
+)
+
+// 母乳储存位置常量
+const (
+	MilkStorageRoom    = "room"    // 室温
+	MilkStorageFridge  = "fridge"  // 冷藏
+	MilkStorageFreezer = "freezer" // 冷冻
+)
+
+// 母乳库存状态常量
+const (
+	MilkStashStatusAvailable = "available" // 可用
+	MilkStashStatusConsumed  = "consumed"  // 已喝完
+	MilkStashStatusExpired   = "expired"   // 已过期
+	MilkStashStatusDiscarded = "discarded" // 已丢弃
+)
+
+// 母乳储存期限(参考 CDC 母乳储存指南)
+const (
+	MilkShelfLifeRoom    = 4 * time.Hour        // 室温(≤25℃)4小时
+	MilkShelfLifeFridge  = 4 * 24 * time.Hour   // 冷藏(4℃)4天
+	MilkShelfLifeFreezer = 180 * 24 * time.Hour // 冷冻(-18℃)6个月内最佳
+	MilkShelfLifeThawed  = 24 * time.Hour       // 解冻后冷藏24小时, 不可再次冷冻
+)
+
+// PumpingRecord 吸奶记录实体
+type PumpingRecord struct {
+	ID              int64                 `gorm:"primaryKey;column:id" json:"id"`                                    // 雪花ID主键
+	BabyID          int64                 `gorm:"column:baby_id;index" json:"babyId"`                                // 宝宝ID (引用Baby.ID)
+	Time            int64                 `gorm:"column:time;index" json:"time"`                                     // 吸奶时间(毫秒时间戳)
+	Duration        int                   `gorm:"column:duration" json:"duration"`                                   // 时长(秒)
+	LeftVolume      int64                 `gorm:"column:left_volume" json:"leftVolume"`                              // 左侧奶量(ml)
+	RightVolume     int64                 `gorm:"column:right_volume" json:"rightVolume"`                            // 右侧奶量(ml)
+	TotalVolume     int64                 `gorm:"column:total_volume" json:"totalVolume"`                            // 总奶量(ml)
+	Note            *string               `gorm:"column:note;type:text" json:"note"`                                 // 备注
+	CreatedBy       int64                 `gorm:"column:created_by" json:"createdBy"`                                // 创建者用户ID (引用User.ID)
+	CreatedByName   string                `gorm:"column:created_by_name;type:varchar(64)" json:"createdByName"`      // 冗余:创建者昵称
+	CreatedByAvatar string                `gorm:"column:created_by_avatar;type:varchar(512)" json:"createdByAvatar"` // 冗余:创建者头像
+	CreatedAt       int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`           // 创建时间(毫秒时间戳)
+	UpdatedAt       int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`           // 更新时间(毫秒时间戳)
+	DeletedAt       soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`       // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (PumpingRecord) TableName() string {
+	return "pumping_records"
+}
+
+// MilkStashItem 母乳库存(一袋/一瓶)
+type MilkStashItem struct {
+	ID                int64                 `gorm:"primaryKey;column:id" json:"id"`                                                         // 雪花ID主键
+	BabyID            int64                 `gorm:"column:baby_id;not null;index:idx_milk_stash_baby_status" json:"babyId"`                 // 宝宝ID (引用Baby.ID)
+	PumpingRecordID   *int64                `gorm:"column:pumping_record_id;index" json:"pumpingRecordId,omitempty"`                        // 来源吸奶记录ID(手动入库时为空)
+	Volume            int64                 `gorm:"column:volume;not null" json:"volume"`                                                   // 入库奶量(ml)
+	RemainingVolume   int64                 `gorm:"column:remaining_volume;not null" json:"remainingVolume"`                                // 剩余奶量(ml)
+	PumpedAt          int64                 `gorm:"column:pumped_at;not null" json:"pumpedAt"`                                              // 吸奶时间(毫秒时间戳)
+	StorageLocation   string                `gorm:"column:storage_location;type:varchar(16);not null" json:"storageLocation"`               // 储存位置: room, fridge, freezer
+	ThawedAt          *int64                `gorm:"column:thawed_at" json:"thawedAt,omitempty"`                                             // 解冻时间(毫秒时间戳)
+	ExpiresAt         int64                 `gorm:"column:expires_at;not null;index" json:"expiresAt"`                                      // 过期时间(毫秒时间戳)
+	Status            string                `gorm:"column:status;type:varchar(16);not null;index:idx_milk_stash_baby_status" json:"status"` // 状态: available, consumed, expired, discarded
+	ExpiryAlertSentAt *int64                `gorm:"column:expiry_alert_sent_at" json:"expiryAlertSentAt,omitempty"`                         // 临期提醒发送时间(毫秒时间戳)
+	Note              *string               `gorm:"column:note;type:text" json:"note"`                                                      // 备注
+	CreatedBy         int64                 `gorm:"column:created_by" json:"createdBy"`                                                     // 创建者用户ID (引用User.ID)
+	CreatedAt         int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`                                // 创建时间(毫秒时间戳)
+	UpdatedAt         int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`                                // 更新时间(毫秒时间戳)
+	DeletedAt         soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`                            // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (MilkStashItem) TableName() string {
+	return "milk_stash_items"
+}
+
+// ComputeExpiresAt 根据储存位置和解冻时间计算过期时间
+func (m *MilkStashItem) ComputeExpiresAt() int64 {
+	pumpedAt := time.UnixMilli(m.PumpedAt)
+	if m.ThawedAt != nil {
+		// 解冻后24小时内食用, 且不超过冷冻期限
+		thawed := time.UnixMilli(*m.ThawedAt).Add(MilkShelfLifeThawed)
+		frozen := pumpedAt.Add(MilkShelfLifeFreezer)
+		if frozen.Before(thawed) {
+			return frozen.UnixMilli()
+		}
+		return thawed.UnixMilli()
+	}
+
+	switch m.StorageLocation {
+	case MilkStorageRoom:
+		return pumpedAt.Add(MilkShelfLifeRoom).UnixMilli()
+	case MilkStorageFreezer:
+		return pumpedAt.Add(MilkShelfLifeFreezer).UnixMilli()
+	default:
+		return pumpedAt.Add(MilkShelfLifeFridge).UnixMilli()
+	}
+}
+
+// Thaw 将冷冻母乳标记为解冻(转为冷藏)并重新计算过期时间
+func (m *MilkStashItem) Thaw(at int64) {
+	if m.StorageLocation != MilkStorageFreezer {
+		return
+	}
+	m.StorageLocation = MilkStorageFridge
+	m.ThawedAt = &at
+	m.ExpiresAt = m.ComputeExpiresAt()
+}
+
+// MilkStashUsage 母乳库存消耗明细(奶瓶喂养按先进先出扣减)
+type MilkStashUsage struct {
+	ID                  int64                 `gorm:"primaryKey;column:id" json:"id"`                                           // 雪花ID主键
+	BabyID              int64                 `gorm:"column:baby_id;not null;index" json:"babyId"`                              // 宝宝ID (引用Baby.ID)
+	StashItemID         int64                 `gorm:"column:stash_item_id;not null;index" json:"stashItemId"`                   // 库存ID (引用MilkStashItem.ID)
+	FeedingRecordID     int64                 `gorm:"column:feeding_record_id;not null;index" json:"feedingRecordId"`           // 喂养记录ID (引用FeedingRecord.ID)
+	Volume              int64                 `gorm:"column:volume;not null" json:"volume"`                                     // 扣减奶量(ml)
+	ConsumedAt          int64                 `gorm:"column:consumed_at;not null" json:"consumedAt"`                            // 消耗时间(毫秒时间戳)
+	PrevStorageLocation string                `gorm:"column:prev_storage_location;type:varchar(16)" json:"prevStorageLocation"` // 扣减前储存位置(撤销时恢复解冻)
+	PrevThawedAt        *int64                `gorm:"column:prev_thawed_at" json:"prevThawedAt,omitempty"`                      // 扣减前解冻时间(毫秒时间戳)
+	PrevExpiresAt       int64                 `gorm:"column:prev_expires_at" json:"prevExpiresAt"`                              // 扣减前过期时间(毫秒时间戳)
+	CreatedAt           int64                 `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`                  // 创建时间(毫秒时间戳)
+	UpdatedAt           int64                 `gorm:"column:updated_at;autoUpdateTime:milli" json:"updatedAt"`                  // 更新时间(毫秒时间戳)
+	DeletedAt           soft_delete.DeletedAt `gorm:"column:deleted_at;softDelete:milli;index;default:0" json:"-"`              // 软删除(毫秒时间戳)
+}
+
+// TableName 指定表名
+func (MilkStashUsage) TableName() string {
+	return "milk_stash_usages"
+}
+
+// Revert 撤销本次扣减, 将奶量归还到库存
+// restoreState 为 true 时(该袋没有其他扣减)同时恢复扣减前的储存位置、解冻时间和过期时间, 撤销取奶时的解冻
+func (u *MilkStashUsage) Revert(item *MilkStashItem, restoreState bool) {
+	item.RemainingVolume += u.Volume
+	if item.Status == MilkStashStatusConsumed {
+		item.Status = MilkStashStatusAvailable
+	}
+	if restoreState && u.PrevStorageLocation != "" {
+		item.StorageLocation = u.PrevStorageLocation
+		item.ThawedAt = u.PrevThawedAt
+		item.ExpiresAt = u.PrevExpiresAt
+	}
+}
+
+// DrawMilkStash 在 at 时刻从库存中按先进先出扣减 volume 毫升, 返回被扣减的库存及对应的消耗明细
+// 冷藏/室温/已解冻的母乳优先于冷冻母乳, 同类按吸奶时间先后; 未吸出、已过期或不可用的库存不参与扣减
+// 从冷冻库存取奶视为解冻, 剩余部分需在24小时内喝完; items 会被原地修改, 由调用方持久化
+func DrawMilkStash(items []*MilkStashItem, volume, at int64) ([]*MilkStashItem, []*MilkStashUsage) {
+	candidates := make([]*MilkStashItem, 0, len(items))
+	for _, item := range items {
+		if item.Status == MilkStashStatusAvailable && item.RemainingVolume > 0 && item.PumpedAt <= at && item.ExpiresAt > at {
+			candidates = append(candidates, item)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		fi, fj := candidates[i].StorageLocation == MilkStorageFreezer, candidates[j].StorageLocation == MilkStorageFreezer
+		if fi != fj {
+			return fj
+		}
+		if candidates[i].PumpedAt != candidates[j].PumpedAt {
+			return candidates[i].PumpedAt < candidates[j].PumpedAt
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	var drawn []*MilkStashItem
+	var usages []*MilkStashUsage
+	remaining := volume
+	for _, item := range candidates {
+		if remaining <= 0 {
+			break
+		}
+
+		take := min(item.RemainingVolume, remaining)
+		usage := &MilkStashUsage{
+			BabyID:              item.BabyID,
+			StashItemID:         item.ID,
+			Volume:              take,
+			ConsumedAt:          at,
+			PrevStorageLocation: item.StorageLocation,
+			PrevThawedAt:        item.ThawedAt,
+			PrevExpiresAt:       item.ExpiresAt,
+		}
+
+		item.Thaw(at)
+		item.RemainingVolume -= take
+		if item.RemainingVolume == 0 {
+			item.Status = MilkStashStatusConsumed
+		}
+
+		drawn = append(drawn, item)
+		usages = append(usages, usage)
+		remaining -= take
+	}
+	return drawn, usages
+}
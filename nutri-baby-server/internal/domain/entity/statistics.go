@@ -28,3 +28,16 @@ type DailyGrowthItem struct {
 	LatestHeadCircumference *int64 // 最新头围（cm）
 	RecordCount             int64  // 当日记录数
 }
+
+type DailyTemperatureItem struct {
+	Date           string  // 日期，格式 YYYY-MM-DD
+	MaxTemperature float64 // 当日最高体温（℃）
+	FeverCount     int64   // 达到发热标准的次数
+	TotalCount     int64   // 测量次数
+}
+
+type DailySymptomItem struct {
+	Date       string // 日期，格式 YYYY-MM-DD
+	Symptom    string // 症状
+	TotalCount int64  // 记录次数
+}
@@ -9,6 +9,7 @@ type User struct {
 	NickName      string                `gorm:"column:nick_name;type:varchar(64)" json:"nickName"`                 // 昵称
 	AvatarURL     string                `gorm:"column:avatar_url;type:varchar(512)" json:"avatarUrl"`              // 头像URL
 	DefaultBabyID int64                 `gorm:"column:default_baby_id;default:0" json:"defaultBabyId"`             // 默认宝宝ID (int64)
+	Locale        string                `gorm:"column:locale;type:varchar(16);default:''" json:"locale"`           // 语言偏好(zh-CN/en-US)，为空表示跟随客户端
 	LastLoginTime int64                 `gorm:"column:last_login_time" json:"lastLoginTime"`                       // 最后登录时间(毫秒时间戳)
 	CreatedAt     int64                 `gorm:"column:created_at;autoCreateTime:milli;default:0" json:"createdAt"` // 创建时间(毫秒时间戳)
 	UpdatedAt     int64                 `gorm:"column:updated_at;autoUpdateTime:milli;default:0" json:"updatedAt"` // 更新时间(毫秒时间戳)
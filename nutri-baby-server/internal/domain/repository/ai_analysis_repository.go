@@ -33,8 +33,35 @@ type AIAnalysisRepository interface {
 	// 删除分析记录
 	Delete(ctx context.Context, id int64) error
 
-	// 获取宝宝的分析历史统计
-	GetAnalysisStats(ctx context.Context, babyID int64) (*AnalysisStats, error)
+	// 获取宝宝的分析历史统计，since 非零时只统计该时间之后创建的分析
+	GetAnalysisStats(ctx context.Context, babyID int64, since time.Time) (*AnalysisStats, error)
+
+	// 按条件分页查询分析记录(按创建时间倒序)，同时返回总数
+	FindByParams(ctx context.Context, params AIAnalysisParams) ([]*entity.AIAnalysis, int64, error)
+
+	// ClaimPending 抢占可执行的任务并加租约: 待执行且已到重试时间的任务，或租约已过期的分析中任务
+	ClaimPending(ctx context.Context, owner, provider string, leaseUntil time.Time, limit int) ([]*entity.AIAnalysis, error)
+
+	// RenewLease 心跳续约，租约已丢失(任务被取消或被其他worker接管)时返回 false
+	RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error)
+
+	// CompleteJob 持有租约时写入结果并标记完成
+	CompleteJob(ctx context.Context, id int64, owner string, completion AIAnalysisCompletion) (bool, error)
+
+	// FailJob 持有租约时记录失败原因; failure.RetryAt 非空时退回待执行等待重试，否则标记失败
+	FailJob(ctx context.Context, id int64, owner string, failure AIAnalysisFailure) (bool, error)
+
+	// ReleaseJob 释放租约并退回待执行，本次尝试不计数(用于服务停止)
+	ReleaseJob(ctx context.Context, id int64, owner string) error
+
+	// CountQueued 统计已创建但尚未开始执行的任务数，openID/babyID 为空值时不作为条件
+	CountQueued(ctx context.Context, openID string, babyID int64) (int64, error)
+
+	// Cancel 取消待执行或分析中的任务，任务已结束时返回 false
+	Cancel(ctx context.Context, id int64) (bool, error)
+
+	// FindCompletedByFingerprint 查找输入指纹相同的最近一次已完成分析(不含 excludeID)，不存在时返回 nil
+	FindCompletedByFingerprint(ctx context.Context, babyID int64, analysisType entity.AIAnalysisType, fingerprint string, excludeID int64) (*entity.AIAnalysis, error)
 }
 
 // DailyTipsRepository 每日建议仓储接口
@@ -42,8 +69,8 @@ type DailyTipsRepository interface {
 	// 创建每日建议
 	Create(ctx context.Context, tips *entity.DailyTips) error
 
-	// 根据宝宝ID和日期获取每日建议
-	GetByBabyIDAndDate(ctx context.Context, babyID int64, date time.Time) (*entity.DailyTips, error)
+	// 根据宝宝ID、日期和语言获取每日建议
+	GetByBabyIDAndDate(ctx context.Context, babyID int64, date time.Time, locale string) (*entity.DailyTips, error)
 
 	// 获取宝宝的最新每日建议
 	GetLatestByBabyID(ctx context.Context, babyID int64) (*entity.DailyTips, error)
@@ -54,6 +81,9 @@ type DailyTipsRepository interface {
 	// 更新每日建议
 	Update(ctx context.Context, tips *entity.DailyTips) error
 
+	// FindByFingerprint 查找输入指纹相同的最近一次每日建议，不存在时返回 nil
+	FindByFingerprint(ctx context.Context, babyID int64, fingerprint string) (*entity.DailyTips, error)
+
 	// 删除过期的每日建议
 	DeleteExpired(ctx context.Context, before time.Time) error
 }
@@ -65,10 +95,31 @@ type AnalysisStats struct {
 	FailedAnalyses     int64                    `json:"failed_analyses"`
 	AverageScore       *float64                 `json:"average_score"`
 	AnalysisTypeCounts map[string]int64         `json:"analysis_type_counts"`
+	StatusCounts       map[string]int64         `json:"status_counts"`
 	RecentAnalyses     []*entity.AIAnalysis     `json:"recent_analyses"`
 }
 
+// AIAnalysisCompletion 分析任务的执行结果
+type AIAnalysisCompletion struct {
+	Result   string   // 结果JSON
+	Score    *float64 // 评分，单独落库用于历史趋势统计
+	Provider string   // 实际产出结果的AI提供商
+
+	ValidationErrors string // 结构化输出校验记录，经修复后通过时非空
+	GuardrailLog     string // 医疗安全护栏干预记录，有干预时非空
+	Fingerprint      string // 输入指纹
+	ReusedFromID     *int64 // 复用结果的来源分析ID
+}
+
+// AIAnalysisFailure 分析任务的失败信息
+type AIAnalysisFailure struct {
+	Reason           string     // 失败原因
+	RetryAt          *time.Time // 下次重试时间，为空表示不再重试
+	ValidationErrors string     // 结构化输出校验记录，因输出未通过校验失败时非空
+}
+
 // AIAnalysisParams 分析查询参数
+// StartDate/EndDate 按创建时间过滤，区间为 [StartDate, EndDate)
 type AIAnalysisParams struct {
 	BabyID       int64
 	AnalysisType *entity.AIAnalysisType
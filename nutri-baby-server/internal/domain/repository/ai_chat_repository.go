@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// AIChatMessageRepository AI助手对话消息仓储接口
+type AIChatMessageRepository interface {
+	// CreateBatch 批量保存消息(一问一答在同一事务中写入)
+	CreateBatch(ctx context.Context, messages []*entity.AIChatMessage) error
+	// FindRecent 查找宝宝+用户会话中 before 之前的最近消息(按时间正序返回)，before 为0表示不限
+	FindRecent(ctx context.Context, babyID int64, openID string, before int64, limit int) ([]*entity.AIChatMessage, error)
+	// DeleteConversation 清空宝宝+用户的会话(软删除)
+	DeleteConversation(ctx context.Context, babyID int64, openID string) error
+}
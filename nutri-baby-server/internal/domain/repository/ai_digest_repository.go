@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// AIDigestRepository AI周期报告仓储接口
+type AIDigestRepository interface {
+	// CreateIfNotExists 创建报告，同一宝宝同一周期已存在时不创建并返回 false
+	CreateIfNotExists(ctx context.Context, digest *entity.AIDigest) (bool, error)
+	Update(ctx context.Context, digest *entity.AIDigest) error
+	// CompletePending 写入汇总结果并标记完成，仅当报告仍为等待汇总时生效，返回是否由本次调用完成
+	CompletePending(ctx context.Context, digest *entity.AIDigest) (bool, error)
+	GetByID(ctx context.Context, id int64) (*entity.AIDigest, error)
+	// FindPrevious 查找 before 之前开始的最近一期已完成报告，不存在时返回 nil
+	FindPrevious(ctx context.Context, babyID int64, period entity.AIDigestPeriod, before time.Time) (*entity.AIDigest, error)
+	// FindPending 查找等待汇总的报告
+	FindPending(ctx context.Context, limit int) ([]*entity.AIDigest, error)
+	// ListByBabyID 分页查询宝宝的已完成报告(按开始日期倒序)，period 为空表示不限
+	ListByBabyID(ctx context.Context, babyID int64, period entity.AIDigestPeriod, page, pageSize int) ([]*entity.AIDigest, int64, error)
+}
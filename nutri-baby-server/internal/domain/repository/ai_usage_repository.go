@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// AIUsageFilter 用量查询条件，时间为毫秒时间戳，区间为 [Since, Until)，零值表示不限
+type AIUsageFilter struct {
+	OpenID string
+	BabyID int64
+	Since  int64
+	Until  int64
+}
+
+// AIUsageTotals 用量汇总
+type AIUsageTotals struct {
+	Requests         int64   // 业务请求数(按 request_id 去重)
+	Calls            int64   // 模型调用次数
+	FailedCalls      int64   // 失败的模型调用次数
+	PromptTokens     int64   // 输入token数
+	CompletionTokens int64   // 输出token数
+	TotalTokens      int64   // 总token数
+	Cost             float64 // 估算费用(美元)
+	AvgLatencyMs     float64 // 平均调用耗时(毫秒)
+}
+
+// AIUsageDailyRow 按天+提供商+模型聚合的用量
+type AIUsageDailyRow struct {
+	AIUsageTotals
+	DayStart int64 // 当天零点(毫秒时间戳)
+	Provider string
+	Model    string
+}
+
+// AIUsageConsumerRow 按用户或宝宝聚合的用量
+type AIUsageConsumerRow struct {
+	AIUsageTotals
+	OpenID string
+	BabyID int64
+}
+
+// AIUsageRepository AI调用用量仓储接口
+type AIUsageRepository interface {
+	// Create 记录一次模型调用
+	Create(ctx context.Context, record *entity.AIUsageRecord) error
+	// SumUsage 汇总满足条件的用量
+	SumUsage(ctx context.Context, filter AIUsageFilter) (*AIUsageTotals, error)
+	// DailyUsage 按天+提供商+模型聚合，utcOffsetSeconds 为划分自然日使用的时区偏移
+	DailyUsage(ctx context.Context, filter AIUsageFilter, utcOffsetSeconds int) ([]*AIUsageDailyRow, error)
+	// TopUsers 按token用量降序返回用户(不含定时任务)
+	TopUsers(ctx context.Context, filter AIUsageFilter, limit int) ([]*AIUsageConsumerRow, error)
+	// TopBabies 按token用量降序返回宝宝
+	TopBabies(ctx context.Context, filter AIUsageFilter, limit int) ([]*AIUsageConsumerRow, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// CryingRecordRepository 哭闹记录仓储接口
+type CryingRecordRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, record *entity.CryingRecord) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, recordID int64) (*entity.CryingRecord, error)
+	// FindByBabyID 查找宝宝的哭闹记录(分页)
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.CryingRecord, int64, error)
+	// Delete 删除记录
+	Delete(ctx context.Context, recordID int64) error
+}
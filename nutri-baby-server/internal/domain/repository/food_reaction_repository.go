@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// FoodReactionRepository 食物反应仓储接口
+type FoodReactionRepository interface {
+	// Create 创建食物反应记录
+	Create(ctx context.Context, reaction *entity.FoodReaction) error
+	// Delete 删除食物反应记录(软删除)
+	Delete(ctx context.Context, reactionID int64) error
+	// FindByID 根据ID查找食物反应记录
+	FindByID(ctx context.Context, reactionID int64) (*entity.FoodReaction, error)
+	// FindByBabyID 查找宝宝的全部食物反应记录(按发生时间倒序)
+	FindByBabyID(ctx context.Context, babyID int64) ([]*entity.FoodReaction, error)
+}
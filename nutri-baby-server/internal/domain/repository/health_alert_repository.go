@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// HealthAlertRepository 健康提醒仓储接口
+type HealthAlertRepository interface {
+	// CreateIfNotExists 按去重键创建提醒，已存在时返回 false
+	CreateIfNotExists(ctx context.Context, alert *entity.HealthAlert) (bool, error)
+	// MarkNotified 标记提醒已通知
+	MarkNotified(ctx context.Context, alertID int64, notifiedAt int64) error
+	// FindByBabyID 查找宝宝在指定时间之后触发的提醒(按触发时间倒序)
+	FindByBabyID(ctx context.Context, babyID int64, since int64, limit int) ([]*entity.HealthAlert, error)
+}
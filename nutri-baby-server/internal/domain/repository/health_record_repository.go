@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// TemperatureRecordRepository 体温记录仓储接口
+type TemperatureRecordRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, record *entity.TemperatureRecord) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, recordID int64) (*entity.TemperatureRecord, error)
+	// FindByBabyID 查找宝宝的体温记录(分页)
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.TemperatureRecord, int64, error)
+	// FindByEpisodeID 查找患病经过下的体温记录(按时间正序)
+	FindByEpisodeID(ctx context.Context, episodeID int64) ([]*entity.TemperatureRecord, error)
+	// Delete 删除记录
+	Delete(ctx context.Context, recordID int64) error
+	// GetDailyStats 获取指定时间范围的每日统计数据
+	GetDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*entity.DailyTemperatureItem, error)
+}
+
+// SymptomRecordRepository 症状记录仓储接口
+type SymptomRecordRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, record *entity.SymptomRecord) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, recordID int64) (*entity.SymptomRecord, error)
+	// FindByBabyID 查找宝宝的症状记录(分页)
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.SymptomRecord, int64, error)
+	// FindByEpisodeID 查找患病经过下的症状记录(按时间正序)
+	FindByEpisodeID(ctx context.Context, episodeID int64) ([]*entity.SymptomRecord, error)
+	// Delete 删除记录
+	Delete(ctx context.Context, recordID int64) error
+	// GetDailyStats 获取指定时间范围的每日统计数据
+	GetDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*entity.DailySymptomItem, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// IllnessEpisodeRepository 患病经过仓储接口
+type IllnessEpisodeRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, episode *entity.IllnessEpisode) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, episodeID int64) (*entity.IllnessEpisode, error)
+	// FindByBabyID 查找与时间范围有交集的患病经过(分页), 尚未痊愈的视为持续到现在
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.IllnessEpisode, int64, error)
+	// Update 更新记录
+	Update(ctx context.Context, episode *entity.IllnessEpisode) error
+	// Delete 删除记录
+	Delete(ctx context.Context, episodeID int64) error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// MedicationRepository 药品仓储接口
+type MedicationRepository interface {
+	// Create 创建药品
+	Create(ctx context.Context, medication *entity.Medication) error
+	// FindByID 根据ID查找药品
+	FindByID(ctx context.Context, medicationID int64) (*entity.Medication, error)
+	// FindByBabyID 查找宝宝的药品, activeOnly 为 true 时只返回在用的药品
+	FindByBabyID(ctx context.Context, babyID int64, activeOnly bool) ([]*entity.Medication, error)
+	// Update 更新药品
+	Update(ctx context.Context, medication *entity.Medication) error
+	// Delete 删除药品
+	Delete(ctx context.Context, medicationID int64) error
+}
+
+// MedicationDoseRepository 给药记录仓储接口
+type MedicationDoseRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, dose *entity.MedicationDose) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, doseID int64) (*entity.MedicationDose, error)
+	// FindByBabyID 查找宝宝的给药记录(分页), medicationID 为 0 时返回全部药品
+	FindByBabyID(ctx context.Context, babyID, medicationID int64, startTime, endTime int64, page, pageSize int) ([]*entity.MedicationDose, int64, error)
+	// FindByMedicationID 查找药品在时间范围内的给药记录(按时间正序)
+	FindByMedicationID(ctx context.Context, medicationID int64, startTime, endTime int64) ([]*entity.MedicationDose, error)
+	// FindLatestByMedicationID 查找药品最近一次给药记录, 没有记录时返回 nil
+	FindLatestByMedicationID(ctx context.Context, medicationID int64) (*entity.MedicationDose, error)
+	// Delete 删除记录
+	Delete(ctx context.Context, doseID int64) error
+}
+
+// MedicationScheduleRepository 用药计划仓储接口
+type MedicationScheduleRepository interface {
+	// Create 创建计划
+	Create(ctx context.Context, schedule *entity.MedicationSchedule) error
+	// FindByID 根据ID查找计划
+	FindByID(ctx context.Context, scheduleID int64) (*entity.MedicationSchedule, error)
+	// FindByBabyID 查找宝宝的全部用药计划
+	FindByBabyID(ctx context.Context, babyID int64) ([]*entity.MedicationSchedule, error)
+	// FindEnabled 查找全部启用提醒的计划
+	FindEnabled(ctx context.Context) ([]*entity.MedicationSchedule, error)
+	// Update 更新计划
+	Update(ctx context.Context, schedule *entity.MedicationSchedule) error
+	// MarkReminded 记录已提醒的计划给药时间
+	MarkReminded(ctx context.Context, scheduleID int64, dueAt int64) error
+	// Delete 删除计划
+	Delete(ctx context.Context, scheduleID int64) error
+	// DeleteByMedicationID 删除药品的全部计划
+	DeleteByMedicationID(ctx context.Context, medicationID int64) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// MilestoneRecordRepository 里程碑达成记录仓储接口
+type MilestoneRecordRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, record *entity.MilestoneRecord) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, recordID int64) (*entity.MilestoneRecord, error)
+	// FindByBabyID 查找宝宝的里程碑记录(分页, 按达成时间倒序)
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.MilestoneRecord, int64, error)
+	// FindByBabyAndCode 查找宝宝某个里程碑的记录, 没有记录时返回 nil
+	FindByBabyAndCode(ctx context.Context, babyID int64, code string) (*entity.MilestoneRecord, error)
+	// Update 更新记录
+	Update(ctx context.Context, record *entity.MilestoneRecord) error
+	// Delete 删除记录
+	Delete(ctx context.Context, recordID int64) error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// PumpingRecordRepository 吸奶记录仓储接口
+type PumpingRecordRepository interface {
+	// Create 创建记录
+	Create(ctx context.Context, record *entity.PumpingRecord) error
+	// FindByID 根据ID查找记录
+	FindByID(ctx context.Context, recordID int64) (*entity.PumpingRecord, error)
+	// FindByBabyID 查找宝宝的吸奶记录(分页)
+	FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.PumpingRecord, int64, error)
+	// Delete 删除记录
+	Delete(ctx context.Context, recordID int64) error
+}
+
+// MilkStashRepository 母乳库存仓储接口
+type MilkStashRepository interface {
+	// Create 批量创建库存
+	Create(ctx context.Context, items []*entity.MilkStashItem) error
+	// FindByID 根据ID查找库存
+	FindByID(ctx context.Context, itemID int64) (*entity.MilkStashItem, error)
+	// Update 更新库存
+	Update(ctx context.Context, item *entity.MilkStashItem) error
+	// FindByBabyID 查找宝宝指定状态的库存(按吸奶时间升序), statuses 为空时返回全部
+	FindByBabyID(ctx context.Context, babyID int64, statuses []string) ([]*entity.MilkStashItem, error)
+	// DeleteUnusedByPumpingRecordID 删除吸奶记录产生且尚未被消耗的库存
+	DeleteUnusedByPumpingRecordID(ctx context.Context, pumpingRecordID int64) error
+	// ConsumeFIFO 为喂养记录按先进先出扣减库存, 返回实际扣减的奶量
+	ConsumeFIFO(ctx context.Context, babyID, feedingRecordID, volume, consumedAt int64) (int64, error)
+	// RestoreByFeedingRecordID 撤销喂养记录的扣减, 返回恢复的奶量
+	RestoreByFeedingRecordID(ctx context.Context, feedingRecordID int64) (int64, error)
+	// MarkExpired 将已过期的可用库存标记为过期, 返回更新数量
+	MarkExpired(ctx context.Context, now int64) (int64, error)
+	// FindExpiringUnalerted 查找在指定时间前过期且尚未发送临期提醒的可用库存
+	FindExpiringUnalerted(ctx context.Context, before int64) ([]*entity.MilkStashItem, error)
+	// MarkExpiryAlerted 标记库存已发送临期提醒
+	MarkExpiryAlerted(ctx context.Context, itemIDs []int64, alertedAt int64) error
+}
@@ -20,6 +20,8 @@ type UserRepository interface {
 	UpdateLastLoginTime(ctx context.Context, openID string) error
 	// UpdateDefaultBabyID 更新默认宝宝ID
 	UpdateDefaultBabyID(ctx context.Context, openID string, babyID int64) error
+	// UpdateLocale 更新语言偏好
+	UpdateLocale(ctx context.Context, openID string, locale string) error
 }
 
 // BabyRepository 宝宝仓储接口 (去家庭化架构)
@@ -30,6 +30,8 @@ type ServerConfig struct {
 	ReadTimeout  int    `mapstructure:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout"`
 	BaseURL      string `mapstructure:"base_url"` // 服务器基础 URL，用于生成完整资源访问地址
+
+	InternalToken string `mapstructure:"internal_token"` // 内部接口令牌(后台任务等服务间调用，请求头 X-Internal-Token)，为空时禁用内部接口
 }
 
 // DatabaseConfig 数据库配置
@@ -136,6 +138,66 @@ type AIConfig struct {
 	Analysis AnalysisConfig `mapstructure:"analysis"`
 	Gemini   GeminiConfig   `mapstructure:"gemini"`
 	Doubao   DoubaoConfig   `mapstructure:"doubao"`
+	Quota    AIQuotaConfig  `mapstructure:"quota"`
+
+	// Pricing 各提供商每百万token价格(美元)，用于估算调用费用，未配置的提供商按0计
+	Pricing map[string]AIPricingConfig `mapstructure:"pricing"`
+
+	// Providers 按优先级排列的提供商列表，首选不可用时依次回退；为空时只使用 Provider
+	Providers        []string                    `mapstructure:"providers"`
+	ProviderPolicies map[string]AIProviderPolicy `mapstructure:"provider_policies"` // 各提供商的超时与限流
+	CircuitBreaker   AICircuitBreakerConfig      `mapstructure:"circuit_breaker"`
+}
+
+// ProviderChain 返回按优先级排列的提供商列表(去重)
+func (c AIConfig) ProviderChain() []string {
+	names := c.Providers
+	if len(names) == 0 {
+		names = []string{c.Provider}
+	}
+
+	seen := make(map[string]bool, len(names))
+	chain := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			name = "mock"
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		chain = append(chain, name)
+	}
+	return chain
+}
+
+// AIProviderPolicy 单个提供商的调用策略，0 表示不限制
+type AIProviderPolicy struct {
+	Timeout   int `mapstructure:"timeout"`    // 单次模型调用超时(秒)，超时后回退到下一个提供商
+	RateLimit int `mapstructure:"rate_limit"` // 每分钟最多调用次数，超出时直接使用下一个提供商
+}
+
+// AICircuitBreakerConfig 提供商熔断配置
+type AICircuitBreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"` // 连续失败多少次后熔断
+	Cooldown         int `mapstructure:"cooldown"`          // 熔断持续时间(秒)，之后放行一次探测调用
+}
+
+// AIQuotaConfig AI调用每日配额(按服务器时区的自然日)，0 表示不限制
+// 请求数指一次分析/建议/对话/快速记录解析，token数为当日所有模型调用之和
+type AIQuotaConfig struct {
+	Enabled           bool  `mapstructure:"enabled"`
+	UserDailyRequests int   `mapstructure:"user_daily_requests"`
+	UserDailyTokens   int64 `mapstructure:"user_daily_tokens"`
+	BabyDailyRequests int   `mapstructure:"baby_daily_requests"`
+	BabyDailyTokens   int64 `mapstructure:"baby_daily_tokens"`
+}
+
+// AIPricingConfig 模型调用价格
+type AIPricingConfig struct {
+	PromptPerMillion     float64 `mapstructure:"prompt_per_million"`
+	CompletionPerMillion float64 `mapstructure:"completion_per_million"`
 }
 
 type GeminiConfig struct {
@@ -151,6 +213,11 @@ type OpenAIConfig struct {
 	Model       string  `mapstructure:"model"`
 	MaxTokens   int     `mapstructure:"max_tokens"`
 	Temperature float64 `mapstructure:"temperature"`
+
+	// Headers 额外请求头，可覆盖默认认证头(用于网关或自建服务的自定义鉴权)
+	Headers map[string]string `mapstructure:"headers"`
+	// ResponseFormat 结构化输出方式: json_schema(默认)、json_object、none(服务不支持 response_format 时使用)
+	ResponseFormat string `mapstructure:"response_format"`
 }
 
 // ClaudeConfig Claude配置
@@ -160,6 +227,9 @@ type ClaudeConfig struct {
 	Model       string  `mapstructure:"model"`
 	MaxTokens   int     `mapstructure:"max_tokens"`
 	Temperature float64 `mapstructure:"temperature"`
+
+	// Headers 额外请求头，可覆盖默认认证头(用于网关或自建服务的自定义鉴权)
+	Headers map[string]string `mapstructure:"headers"`
 }
 
 // ERNIEConfig ERNIE配置
@@ -191,6 +261,14 @@ type AnalysisConfig struct {
 	BatchSize  int               `mapstructure:"batch_size"`
 	CacheTTL   int               `mapstructure:"cache_ttl"`
 	Prompts    map[string]string `mapstructure:"prompts"`
+
+	// 任务执行器配置
+	Workers             int            `mapstructure:"workers"`              // 分析任务worker数(全局并发上限)
+	LeaseSeconds        int            `mapstructure:"lease_seconds"`        // 任务租约时长(秒)，心跳间隔为其1/3
+	PollInterval        int            `mapstructure:"poll_interval"`        // 轮询待执行任务的间隔(秒)
+	RetryBackoff        int            `mapstructure:"retry_backoff"`        // 重试退避基数(秒)，每次失败翻倍
+	RetryBackoffMax     int            `mapstructure:"retry_backoff_max"`    // 重试退避上限(秒)
+	ProviderConcurrency map[string]int `mapstructure:"provider_concurrency"` // 各AI提供商的最大并发数，未配置时不超过 workers
 }
 
 // Load 加载配置
@@ -404,10 +482,15 @@ func GetDefaultAIConfig() AIConfig {
 		},
 		Doubao: GetDefaultDoubaoConfig(),
 		Analysis: AnalysisConfig{
-			Timeout:    120,
-			RetryCount: 3,
-			BatchSize:  10,
-			CacheTTL:   3600,
+			Timeout:         120,
+			RetryCount:      3,
+			BatchSize:       10,
+			CacheTTL:        3600,
+			Workers:         4,
+			LeaseSeconds:    60,
+			PollInterval:    5,
+			RetryBackoff:    30,
+			RetryBackoffMax: 1800,
 			Prompts: map[string]string{
 				"feeding":  "分析以下宝宝的喂养数据，提供专业的营养建议：",
 				"sleep":    "分析以下宝宝的睡眠数据，提供改善建议：",
@@ -416,6 +499,17 @@ func GetDefaultAIConfig() AIConfig {
 				"behavior": "分析以下宝宝的行为模式：",
 			},
 		},
+		CircuitBreaker: AICircuitBreakerConfig{
+			FailureThreshold: 3,
+			Cooldown:         60,
+		},
+		Quota: AIQuotaConfig{
+			Enabled:           true,
+			UserDailyRequests: 50,
+			UserDailyTokens:   500000,
+			BabyDailyRequests: 80,
+			BabyDailyTokens:   800000,
+		},
 	}
 }
 
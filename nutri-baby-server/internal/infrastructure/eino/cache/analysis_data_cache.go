@@ -2,242 +2,143 @@ package cache
 
 import (
 	"context"
-	"sync"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 )
 
-// AnalysisDataCache 分析数据缓存
-type AnalysisDataCache struct {
-	mu           sync.RWMutex
-	babyCache    map[int64]*babyCacheEntry
-	cacheTTL     time.Duration
-	maxCacheSize int
-}
+const (
+	// analysisDataTTL 缓存数据的有效期
+	analysisDataTTL = 5 * time.Minute
+	// analysisDataVersionTTL 版本号的有效期，需远长于数据有效期，过期重置后旧数据早已失效
+	analysisDataVersionTTL = 24 * time.Hour
+)
 
-// babyCacheEntry 宝宝数据缓存条目
-type babyCacheEntry struct {
-	babyInfo      *entity.Baby
-	feedingData   []entity.FeedingRecord
-	sleepData     []entity.SleepRecord
-	growthData    []entity.GrowthRecord
-	diaperData    []entity.DiaperRecord
-	expiresAt     time.Time
-	lastAccessed  time.Time
+// AnalysisDataCache AI分析数据缓存
+// 数据缓存在 Redis 中供多个实例共享，键包含宝宝的数据版本号:
+// ai:data:{babyID}:v{version}:{查询}，记录写入时递增版本号即可使该宝宝的全部缓存失效
+// Redis 不可用时直接查询数据库
+type AnalysisDataCache struct {
+	client *redis.Client
+	logger *zap.Logger
 }
 
 // NewAnalysisDataCache 创建数据缓存
-func NewAnalysisDataCache(ttl time.Duration, maxSize int) *AnalysisDataCache {
-	cache := &AnalysisDataCache{
-		babyCache:    make(map[int64]*babyCacheEntry),
-		cacheTTL:     ttl,
-		maxCacheSize: maxSize,
-	}
-	
-	// 启动定期清理过期缓存的goroutine
-	go cache.cleanupExpiredEntries()
-	
-	return cache
-}
-
-// GetBabyInfo 获取宝宝信息（带缓存）
-func (c *AnalysisDataCache) GetBabyInfo(ctx context.Context, babyID int64, fetcher func(context.Context, int64) (*entity.Baby, error)) (*entity.Baby, error) {
-	c.mu.RLock()
-	entry, exists := c.babyCache[babyID]
-	if exists && entry.babyInfo != nil && time.Now().Before(entry.expiresAt) {
-		entry.lastAccessed = time.Now()
-		c.mu.RUnlock()
-		return entry.babyInfo, nil
+func NewAnalysisDataCache(client *redis.Client, logger *zap.Logger) *AnalysisDataCache {
+	return &AnalysisDataCache{
+		client: client,
+		logger: logger,
 	}
-	c.mu.RUnlock()
+}
 
-	// 缓存未命中或已过期，重新获取
-	baby, err := fetcher(ctx, babyID)
-	if err != nil {
-		return nil, err
-	}
+// GetBabyInfo 获取宝宝信息(带缓存)
+func (c *AnalysisDataCache) GetBabyInfo(ctx context.Context, babyID int64, fetcher func(context.Context) (*entity.Baby, error)) (*entity.Baby, error) {
+	return load(ctx, c, babyID, "baby", fetcher)
+}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	
-	if entry == nil {
-		entry = &babyCacheEntry{
-			expiresAt:    time.Now().Add(c.cacheTTL),
-			lastAccessed: time.Now(),
-		}
-		c.babyCache[babyID] = entry
-	}
-	
-	entry.babyInfo = baby
-	entry.expiresAt = time.Now().Add(c.cacheTTL)
-	
-	return baby, nil
-}
-
-// GetFeedingData 获取喂养数据（带缓存）
-func (c *AnalysisDataCache) GetFeedingData(ctx context.Context, babyID int64, startDate, endDate time.Time, fetcher func(context.Context, int64, time.Time, time.Time) ([]entity.FeedingRecord, error)) ([]entity.FeedingRecord, error) {
-	c.mu.RLock()
-	entry, exists := c.babyCache[babyID]
-	if exists && entry.feedingData != nil && time.Now().Before(entry.expiresAt) {
-		entry.lastAccessed = time.Now()
-		c.mu.RUnlock()
-		return entry.feedingData, nil
-	}
-	c.mu.RUnlock()
+// GetFeedingRecords 获取喂养记录(带缓存)
+func (c *AnalysisDataCache) GetFeedingRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.FeedingRecord, error)) ([]*entity.FeedingRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("feeding", startTime, endTime, limit), fetcher)
+}
 
-	// 缓存未命中或已过期，重新获取
-	data, err := fetcher(ctx, babyID, startDate, endDate)
-	if err != nil {
-		return nil, err
-	}
+// GetSleepRecords 获取睡眠记录(带缓存)
+func (c *AnalysisDataCache) GetSleepRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.SleepRecord, error)) ([]*entity.SleepRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("sleep", startTime, endTime, limit), fetcher)
+}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	
-	if entry == nil {
-		entry = &babyCacheEntry{
-			expiresAt:    time.Now().Add(c.cacheTTL),
-			lastAccessed: time.Now(),
-		}
-		c.babyCache[babyID] = entry
-	}
-	
-	entry.feedingData = data
-	entry.expiresAt = time.Now().Add(c.cacheTTL)
-	
-	return data, nil
+// GetGrowthRecords 获取成长记录(带缓存)
+func (c *AnalysisDataCache) GetGrowthRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.GrowthRecord, error)) ([]*entity.GrowthRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("growth", startTime, endTime, limit), fetcher)
 }
 
-// GetSleepData 获取睡眠数据（带缓存）
-func (c *AnalysisDataCache) GetSleepData(ctx context.Context, babyID int64, startDate, endDate time.Time, fetcher func(context.Context, int64, time.Time, time.Time) ([]entity.SleepRecord, error)) ([]entity.SleepRecord, error) {
-	c.mu.RLock()
-	entry, exists := c.babyCache[babyID]
-	if exists && entry.sleepData != nil && time.Now().Before(entry.expiresAt) {
-		entry.lastAccessed = time.Now()
-		c.mu.RUnlock()
-		return entry.sleepData, nil
-	}
-	c.mu.RUnlock()
+// GetDiaperRecords 获取尿布记录(带缓存)
+func (c *AnalysisDataCache) GetDiaperRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.DiaperRecord, error)) ([]*entity.DiaperRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("diaper", startTime, endTime, limit), fetcher)
+}
 
-	data, err := fetcher(ctx, babyID, startDate, endDate)
-	if err != nil {
-		return nil, err
-	}
+// GetCryingRecords 获取哭闹记录(带缓存)
+func (c *AnalysisDataCache) GetCryingRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.CryingRecord, error)) ([]*entity.CryingRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("crying", startTime, endTime, limit), fetcher)
+}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	
-	if entry == nil {
-		entry = &babyCacheEntry{
-			expiresAt:    time.Now().Add(c.cacheTTL),
-			lastAccessed: time.Now(),
-		}
-		c.babyCache[babyID] = entry
+// GetIllnessEpisodes 获取患病经过(带缓存)
+func (c *AnalysisDataCache) GetIllnessEpisodes(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.IllnessEpisode, error)) ([]*entity.IllnessEpisode, error) {
+	return load(ctx, c, babyID, rangeQuery("illness", startTime, endTime, limit), fetcher)
+}
+
+// GetTemperatureRecords 获取体温记录(带缓存)
+func (c *AnalysisDataCache) GetTemperatureRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.TemperatureRecord, error)) ([]*entity.TemperatureRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("temperature", startTime, endTime, limit), fetcher)
+}
+
+// GetSymptomRecords 获取症状记录(带缓存)
+func (c *AnalysisDataCache) GetSymptomRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.SymptomRecord, error)) ([]*entity.SymptomRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("symptom", startTime, endTime, limit), fetcher)
+}
+
+// GetMilestoneRecords 获取里程碑达成记录(带缓存)
+func (c *AnalysisDataCache) GetMilestoneRecords(ctx context.Context, babyID, startTime, endTime int64, limit int, fetcher func(context.Context) ([]*entity.MilestoneRecord, error)) ([]*entity.MilestoneRecord, error) {
+	return load(ctx, c, babyID, rangeQuery("milestone", startTime, endTime, limit), fetcher)
+}
+
+// InvalidateCache 使宝宝的全部缓存失效(记录或宝宝信息写入后调用)
+func (c *AnalysisDataCache) InvalidateCache(ctx context.Context, babyID int64) {
+	if c == nil || c.client == nil {
+		return
+	}
+	key := versionKey(babyID)
+	pipe := c.client.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, analysisDataVersionTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Warn("AI分析数据缓存失效失败", zap.Int64("baby_id", babyID), zap.Error(err))
 	}
-	
-	entry.sleepData = data
-	entry.expiresAt = time.Now().Add(c.cacheTTL)
-	
-	return data, nil
 }
 
-// GetGrowthData 获取成长数据（带缓存）
-func (c *AnalysisDataCache) GetGrowthData(ctx context.Context, babyID int64, startDate, endDate time.Time, fetcher func(context.Context, int64, time.Time, time.Time) ([]entity.GrowthRecord, error)) ([]entity.GrowthRecord, error) {
-	c.mu.RLock()
-	entry, exists := c.babyCache[babyID]
-	if exists && entry.growthData != nil && time.Now().Before(entry.expiresAt) {
-		entry.lastAccessed = time.Now()
-		c.mu.RUnlock()
-		return entry.growthData, nil
+// load 按宝宝当前数据版本读取缓存，未命中时查询并写入
+// 查询期间版本号被递增时，写入的数据挂在旧版本下不会再被读取
+func load[T any](ctx context.Context, c *AnalysisDataCache, babyID int64, query string, fetcher func(context.Context) (T, error)) (T, error) {
+	if c == nil || c.client == nil {
+		return fetcher(ctx)
 	}
-	c.mu.RUnlock()
 
-	data, err := fetcher(ctx, babyID, startDate, endDate)
-	if err != nil {
-		return nil, err
+	version, err := c.client.Get(ctx, versionKey(babyID)).Int64()
+	if err != nil && err != redis.Nil {
+		c.logger.Warn("读取AI分析数据缓存版本失败", zap.Int64("baby_id", babyID), zap.Error(err))
+		return fetcher(ctx)
 	}
+	key := fmt.Sprintf("ai:data:%d:v%d:%s", babyID, version, query)
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	
-	if entry == nil {
-		entry = &babyCacheEntry{
-			expiresAt:    time.Now().Add(c.cacheTTL),
-			lastAccessed: time.Now(),
+	if raw, err := c.client.Get(ctx, key).Bytes(); err == nil {
+		var cached T
+		if err := json.Unmarshal(raw, &cached); err == nil {
+			return cached, nil
 		}
-		c.babyCache[babyID] = entry
+	} else if err != redis.Nil {
+		c.logger.Warn("读取AI分析数据缓存失败", zap.String("key", key), zap.Error(err))
 	}
-	
-	entry.growthData = data
-	entry.expiresAt = time.Now().Add(c.cacheTTL)
-	
-	return data, nil
-}
 
-// InvalidateCache 使缓存失效
-func (c *AnalysisDataCache) InvalidateCache(babyID int64) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	delete(c.babyCache, babyID)
-}
-
-// InvalidateAll 清空所有缓存
-func (c *AnalysisDataCache) InvalidateAll() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.babyCache = make(map[int64]*babyCacheEntry)
-}
-
-// cleanupExpiredEntries 定期清理过期缓存
-func (c *AnalysisDataCache) cleanupExpiredEntries() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-	
-	for range ticker.C {
-		c.mu.Lock()
-		now := time.Now()
-		for babyID, entry := range c.babyCache {
-			if now.After(entry.expiresAt) {
-				delete(c.babyCache, babyID)
-			}
-		}
-		
-		// 如果缓存超过最大大小，清理最久未访问的条目
-		if len(c.babyCache) > c.maxCacheSize {
-			c.evictLRU()
+	data, err := fetcher(ctx)
+	if err != nil {
+		return data, err
+	}
+	if raw, err := json.Marshal(data); err == nil {
+		if err := c.client.Set(ctx, key, raw, analysisDataTTL).Err(); err != nil {
+			c.logger.Warn("写入AI分析数据缓存失败", zap.String("key", key), zap.Error(err))
 		}
-		c.mu.Unlock()
 	}
+	return data, nil
 }
 
-// evictLRU 清理最久未访问的缓存条目
-func (c *AnalysisDataCache) evictLRU() {
-	var oldestID int64
-	var oldestTime time.Time
-	first := true
-	
-	for babyID, entry := range c.babyCache {
-		if first || entry.lastAccessed.Before(oldestTime) {
-			oldestID = babyID
-			oldestTime = entry.lastAccessed
-			first = false
-		}
-	}
-	
-	if !first {
-		delete(c.babyCache, oldestID)
-	}
+func versionKey(babyID int64) string {
+	return fmt.Sprintf("ai:data:%d:version", babyID)
 }
 
-// GetCacheStats 获取缓存统计信息
-func (c *AnalysisDataCache) GetCacheStats() map[string]interface{} {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	
-	return map[string]interface{}{
-		"total_entries": len(c.babyCache),
-		"max_size":      c.maxCacheSize,
-		"ttl_seconds":   c.cacheTTL.Seconds(),
-	}
+func rangeQuery(kind string, startTime, endTime int64, limit int) string {
+	return fmt.Sprintf("%s:%d:%d:%d", kind, startTime, endTime, limit)
 }
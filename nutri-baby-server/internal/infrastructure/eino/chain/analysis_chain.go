@@ -2,9 +2,12 @@ package chain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,9 +15,11 @@ import (
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
-	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/guardrail"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 	"go.uber.org/zap"
 )
 
@@ -23,7 +28,7 @@ type AnalysisChainBuilder struct {
 	chatModel      model.ToolCallingChatModel
 	dataTools      *tools.DataQueryTools
 	batchDataTools *tools.BatchDataTools
-	dataCache      *cache.AnalysisDataCache
+	guard          *guardrail.Guard
 	logger         *zap.Logger
 	enableParallel bool // 是否启用并行工具调用
 }
@@ -33,16 +38,14 @@ func NewAnalysisChainBuilder(
 	chatModel model.ToolCallingChatModel,
 	dataTools *tools.DataQueryTools,
 	batchDataTools *tools.BatchDataTools,
+	guard *guardrail.Guard,
 	logger *zap.Logger,
 ) *AnalysisChainBuilder {
-	// 创建数据缓存（5分钟TTL，最多缓存100个宝宝的数据）
-	dataCache := cache.NewAnalysisDataCache(5*time.Minute, 100)
-
 	return &AnalysisChainBuilder{
 		chatModel:      chatModel,
 		dataTools:      dataTools,
 		batchDataTools: batchDataTools,
-		dataCache:      dataCache,
+		guard:          guard,
 		logger:         logger,
 		enableParallel: true, // 默认启用并行优化
 	}
@@ -50,14 +53,38 @@ func NewAnalysisChainBuilder(
 
 // Analyze 执行AI分析
 func (b *AnalysisChainBuilder) Analyze(ctx context.Context, analysis *entity.AIAnalysis) (*entity.AIAnalysisResult, error) {
+	return b.AnalyzeWithProgress(ctx, analysis, nil)
+}
+
+// AnalyzeWithProgress 执行AI分析并回调过程事件(推理轮次、工具调用、增量文本)
+// onProgress 非空时优先使用流式输出，不支持流式的提供商自动降级为一次性生成
+func (b *AnalysisChainBuilder) AnalyzeWithProgress(ctx context.Context, analysis *entity.AIAnalysis, onProgress ProgressFunc) (*entity.AIAnalysisResult, error) {
+	emit := func(event ProgressEvent) {
+		if onProgress != nil {
+			onProgress(event)
+		}
+	}
+	streaming := onProgress != nil
+	locale := i18n.Normalize(analysis.Locale)
+	ctx, tracker := withProviderTracker(ctx)
+	ctx = i18n.WithLocale(ctx, locale)
+	ctx = withAnalysisTask(ctx, AnalysisTask{
+		Kind:         AnalysisTaskAnalysis,
+		BabyID:       analysis.BabyID,
+		AnalysisType: analysis.AnalysisType,
+		StartDate:    analysis.StartDate,
+		EndDate:      analysis.EndDate,
+		Locale:       locale,
+	})
+
 	// 绑定数据查询工具
-	toolBoundModel, err := b.chatModel.WithTools(b.dataTools.GetToolInfos())
+	toolBoundModel, err := b.chatModel.WithTools(b.dataTools.ToolInfosFor(analysis.AnalysisType))
 	if err != nil {
 		return nil, errors.Wrap(errors.InternalError, "绑定工具失败", err)
 	}
 
 	// 构建系统提示
-	systemPrompt := b.buildSystemPrompt(analysis.AnalysisType)
+	systemPrompt := b.buildSystemPrompt(analysis.AnalysisType) + languageInstruction(locale)
 
 	// 构建用户提示
 	userPrompt := b.buildUserPrompt(analysis)
@@ -67,10 +94,15 @@ func (b *AnalysisChainBuilder) Analyze(ctx context.Context, analysis *entity.AIA
 		schema.UserMessage(userPrompt),
 	}
 
+	// 要求支持原生结构化输出的提供商按 schema 返回
+	responseFormat := structured.WithResponseSchema(structured.AnalysisResult)
+
 	// 开始对话循环，处理工具调用
 	maxIterations := 10 // 防止无限循环
 	for i := 0; i < maxIterations; i++ {
-		response, err := toolBoundModel.Generate(ctx, messages)
+		emit(ProgressEvent{Type: ProgressEventThinking, Iteration: i + 1})
+
+		response, err := b.generate(ctx, toolBoundModel, messages, &streaming, i+1, onProgress, responseFormat)
 		if err != nil {
 			return nil, errors.Wrap(errors.InternalError, "AI分析失败", err)
 		}
@@ -80,14 +112,25 @@ func (b *AnalysisChainBuilder) Analyze(ctx context.Context, analysis *entity.AIA
 		// 检查是否有工具调用
 		if len(response.ToolCalls) == 0 {
 			// 没有工具调用，说明分析完成
-			result, err := b.parseAnalysisResponse(response.Content, analysis.AnalysisType, analysis.BabyID)
+			result, err := b.parseAnalysisResponse(ctx, response.Content, analysis.AnalysisType, analysis.BabyID)
 			if err != nil {
 				return nil, err
 			}
+			result.Provider = tracker.Provider()
+			result.GuardrailLog = b.guard.ApplyAnalysis(ctx, result, analysis.StartDate, analysis.EndDate).String()
 
 			return result, nil
 		}
 
+		for _, toolCall := range response.ToolCalls {
+			emit(ProgressEvent{
+				Type:      ProgressEventToolCall,
+				Iteration: i + 1,
+				Tool:      toolCall.Function.Name,
+				ToolLabel: ToolLabel(locale, toolCall.Function.Name),
+			})
+		}
+
 		// 处理工具调用（支持并行执行）
 		if b.enableParallel && len(response.ToolCalls) > 1 {
 			// 并行执行多个工具调用
@@ -118,15 +161,19 @@ func (b *AnalysisChainBuilder) Analyze(ctx context.Context, analysis *entity.AIA
 	return nil, errors.New(errors.InternalError, "分析超时，达到最大迭代次数")
 }
 
-// GenerateDailyTips 生成每日建议
-func (b *AnalysisChainBuilder) GenerateDailyTips(ctx context.Context, baby *entity.Baby, date time.Time) ([]entity.DailyTip, error) {
+// GenerateDailyTips 按指定语言生成每日建议
+// 返回的 DailyTips 只填充建议内容与校验记录，宝宝、日期、语言等由调用方补全
+func (b *AnalysisChainBuilder) GenerateDailyTips(ctx context.Context, baby *entity.Baby, date time.Time, locale i18n.Locale) (*entity.DailyTips, error) {
+	ctx = i18n.WithLocale(ctx, locale)
+	ctx = withAnalysisTask(ctx, AnalysisTask{Kind: AnalysisTaskDailyTips, BabyID: baby.ID, StartDate: date, EndDate: date, Locale: locale})
+
 	// 绑定数据查询工具
 	toolBoundModel, err := b.chatModel.WithTools(b.dataTools.GetToolInfos())
 	if err != nil {
 		return nil, errors.Wrap(errors.InternalError, "绑定工具失败", err)
 	}
 
-	systemPrompt := b.buildDailyTipsSystemPrompt()
+	systemPrompt := b.buildDailyTipsSystemPrompt() + languageInstruction(locale)
 	userPrompt := b.buildDailyTipsUserPrompt(baby, date)
 
 	messages := []*schema.Message{
@@ -134,10 +181,12 @@ func (b *AnalysisChainBuilder) GenerateDailyTips(ctx context.Context, baby *enti
 		schema.UserMessage(userPrompt),
 	}
 
+	responseFormat := structured.WithResponseSchema(structured.DailyTips)
+
 	// 对话循环处理工具调用
 	maxIterations := 10
 	for i := 0; i < maxIterations; i++ {
-		response, err := toolBoundModel.Generate(ctx, messages)
+		response, err := toolBoundModel.Generate(ctx, messages, responseFormat)
 		if err != nil {
 			return nil, errors.Wrap(errors.InternalError, "生成每日建议失败", err)
 		}
@@ -147,7 +196,12 @@ func (b *AnalysisChainBuilder) GenerateDailyTips(ctx context.Context, baby *enti
 		// 检查是否有工具调用
 		if len(response.ToolCalls) == 0 {
 			// 没有工具调用，解析建议
-			return b.parseDailyTipsResponse(response.Content)
+			tips, err := b.parseDailyTipsResponse(ctx, response.Content)
+			if err != nil {
+				return nil, err
+			}
+			tips.GuardrailLog = b.guard.ApplyDailyTips(ctx, tips, baby.ID, date).String()
+			return tips, nil
 		}
 
 		// 处理工具调用
@@ -231,7 +285,7 @@ func (b *AnalysisChainBuilder) buildSystemPrompt(analysisType entity.AIAnalysisT
 - get_growth_data: 获取成长记录
 - get_diaper_data: 获取尿布记录
 - get_vaccine_data: 获取疫苗记录
-
+` + analysisToolsPrompt(analysisType) + `
 请根据分析类型，主动调用相关工具获取数据，然后进行专业分析。
 
 **重要：最终必须只返回纯JSON格式的分析结果，不要包含任何解释文字或其他内容。**
@@ -282,6 +336,8 @@ JSON格式要求：
 每个预测包含：prediction_type(string), value(string), confidence(float64), time_frame(string), reason(string)
 
 注意：
+- score、insights、alerts、patterns、predictions 为必填字段，没有内容时返回空数组
+- priority 取值 high/medium/low，level 取值 critical/warning/info，difficulty 取值 easy/medium/hard
 - confidence字段必须是0-1之间的浮点数
 - timestamp字段使用ISO 8601格式的时间字符串
 - time_range对象格式：{"start": "2024-01-01T00:00:00Z", "end": "2024-01-02T00:00:00Z"}`
@@ -294,14 +350,55 @@ JSON格式要求：
 	case entity.AIAnalysisTypeGrowth:
 		return basePrompt + "\n\n专业领域：婴幼儿生长发育分析。重点关注身高体重增长、发育里程碑、WHO标准对比等方面。"
 	case entity.AIAnalysisTypeHealth:
-		return basePrompt + "\n\n专业领域：婴幼儿综合健康分析。需要综合多种数据进行整体健康评估。"
+		return basePrompt + "\n\n专业领域：婴幼儿综合健康分析。重点关注排泄与水分、患病经过、疫苗接种反应和生长情况，需要综合多种数据进行整体健康评估。\n\n" + healthScoringRubric
 	case entity.AIAnalysisTypeBehavior:
-		return basePrompt + "\n\n专业领域：婴幼儿行为模式分析。重点关注行为发展、习惯养成、个性特征等方面。"
+		return basePrompt + "\n\n专业领域：婴幼儿行为模式分析。重点关注哭闹和烦躁的总量、发生时段、安抚效果、诱因与作息的关系及变化趋势。\n\n" + behaviorScoringRubric
 	default:
 		return basePrompt
 	}
 }
 
+// analysisToolsPrompt 分析类型专用工具的说明，与 DataQueryTools.ToolInfosFor 绑定的工具一致
+func analysisToolsPrompt(analysisType entity.AIAnalysisType) string {
+	switch analysisType {
+	case entity.AIAnalysisTypeHealth:
+		return `- get_diaper_patterns: 获取按天统计的排泄规律(小便/大便次数、最长小便间隔、大便颜色和性状)
+- get_vaccine_reactions: 获取分析期间完成的疫苗接种及接种反应
+- get_illness_episodes: 获取分析期间的患病经过
+- get_temperature_data: 获取体温测量(含测量部位)和症状记录，以及按天的最高体温和发热次数
+`
+	case entity.AIAnalysisTypeBehavior:
+		return `- get_crying_data: 获取哭闹和烦躁记录，以及按天、按时段的统计
+`
+	case entity.AIAnalysisTypeGrowth:
+		return `- get_milestone_data: 获取按领域划分的发育里程碑清单(已达成、常见月龄内、超过常见月龄仍未达成)；超过常见月龄的里程碑只提示家长多练习、体检时咨询医生，不要据此得出发育迟缓的结论
+`
+	default:
+		return ""
+	}
+}
+
+// scoreBandsPrompt 评分分数段说明
+const scoreBandsPrompt = `分数段：90-100 表现很好；75-89 总体良好，有少量需要留意的地方；60-74 有明显需要改善或关注的问题；60以下 存在需要尽快咨询医生的情况。`
+
+// healthScoringRubric 健康分析评分标准
+const healthScoringRubric = `评分标准(满分100，按以下维度分别评分后相加)：
+1. 排泄与水分(30分)：出生第6天起每天小便不少于6次、最长小便间隔不超过8小时为满分；小便次数偏少或间隔过长时酌情扣分；大便出现灰白/陶土色、胎便期后的黑色或红色时扣分，并给出 critical 或 warning 级别的警告。
+2. 患病情况(30分)：分析期间没有患病和发热为满分；按患病天数、发热天数和症状严重程度扣分，持续超过7天未痊愈、短期内反复患病或发热未就医时需重点提示。体温需结合测量部位判断(腋温、额温≥37.5℃，口温≥37.8℃，肛温、耳温≥38℃为发热)：3月龄以下发热、任何年龄≥40℃时给出 critical 级别的警告；3-6月龄≥39℃、2岁以下发热超过24小时或2岁以上超过72小时时给出 warning 级别的警告。
+3. 疫苗反应(15分)：没有反应，或只有接种部位红肿、低热等常见的轻微反应为满分；出现高热、持续哭闹超过3小时、抽搐等严重反应时扣分并建议就医。
+4. 生长情况(25分)：体重、身长沿自身百分位曲线增长为满分；体重不增或跨越两条主百分位线时扣分。
+` + scoreBandsPrompt + `
+某个维度没有数据时不扣分，但需要在 score_explanation 中说明评分依据了哪些数据。`
+
+// behaviorScoringRubric 行为分析评分标准
+const behaviorScoringRubric = `评分标准(满分100，按以下维度分别评分后相加)：
+1. 哭闹总量(35分)：对照月龄参考值评估，哭闹通常在6周左右达到高峰(每天约2小时)，3个月后多数宝宝每天少于1小时；符合肠绞痛"3-3-3"经验法则(每天哭闹超过3小时、每周超过3天、持续超过3周)时明显扣分，并给出 warning 级别的警告。
+2. 安抚效果(25分)：剧烈(难以安抚)哭闹占比低于20%为满分，占比越高扣分越多；总结有效的安抚方式。
+3. 诱因与作息(25分)：结合喂养和睡眠记录，说明饥饿、困倦等诱因与作息规律的关系；不明原因的哭闹占比高或集中在傍晚时段时扣分，并给出调整作息的建议。
+4. 变化趋势(15分)：哭闹时长保持稳定或逐步减少为满分，持续增加时扣分。
+` + scoreBandsPrompt + `
+突然出现持续尖声哭闹，或伴有发热、拒奶、精神差时，必须给出 critical 级别的警告并建议就医。没有哭闹记录时结合睡眠和喂养记录评估作息规律，并在 score_explanation 中说明缺少哭闹记录。`
+
 // buildUserPrompt 构建用户提示
 func (b *AnalysisChainBuilder) buildUserPrompt(analysis *entity.AIAnalysis) string {
 	return fmt.Sprintf(`请对宝宝ID %d 在 %s 至 %s 期间的 %s 数据进行专业分析。
@@ -320,17 +417,19 @@ func (b *AnalysisChainBuilder) buildDailyTipsSystemPrompt() string {
 
 你可以使用工具获取宝宝的各项数据，然后基于这些数据生成实用的育儿建议。
 
-请生成3-5条实用、具体的育儿建议，以JSON数组格式返回：
-[
-  {
-    "id": "唯一标识",
-    "title": "建议标题（不超过10个字）",
-    "description": "详细描述",
-    "type": "类型(feeding/sleep/growth/health/behavior)",
-    "priority": "优先级(high/medium/low)",
-    "action_url": "相关页面链接(可选)"
-  }
-]
+请生成3-5条实用、具体的育儿建议，以JSON对象格式返回：
+{
+  "tips": [
+    {
+      "id": "唯一标识",
+      "title": "建议标题（不超过10个字）",
+      "description": "详细描述",
+      "type": "类型(feeding/sleep/growth/health/behavior)",
+      "priority": "优先级(high/medium/low)",
+      "action_url": "相关页面链接(可选)"
+    }
+  ]
+}
 
 建议应该：
 1. 基于实际数据，具有针对性
@@ -343,7 +442,7 @@ func (b *AnalysisChainBuilder) buildDailyTipsSystemPrompt() string {
 - 响应必须是纯JSON格式，不要使用任何代码块标记（如` + "`" + `json或` + "`" + `）
 - 不要添加任何前缀文字、后缀文字或解释说明
 - 不要使用反引号、星号或其他Markdown格式符号
-- 直接返回JSON数组，确保可以被JSON.parse()正确解析
+- 直接返回JSON对象，确保可以被JSON.parse()正确解析
 - 字符串值中避免使用特殊字符，如需要可以使用转义字符
 - title 字段不超过10个字
 - type 类型必须与内容相符
@@ -360,6 +459,78 @@ func (b *AnalysisChainBuilder) buildDailyTipsUserPrompt(baby *entity.Baby, date
 	)
 }
 
+// languageInstruction 非默认语言时附加到系统提示末尾的输出语言要求，默认语言不附加(保持原提示词不变)
+func languageInstruction(locale i18n.Locale) string {
+	if locale.IsDefault() {
+		return ""
+	}
+	return fmt.Sprintf("\n\n输出语言：所有面向用户的文本(标题、描述、建议、总结、鼓励话语等)必须使用%s(%s)书写；"+
+		"JSON字段名以及 level、priority、type、difficulty 等取值保持上述英文取值不变。", locale.Name(), locale)
+}
+
+// PromptVersion 分析任务的提示词版本，提示词、输出语言、输出 schema 或工具定义变化时随之变化
+// 与输入数据一起组成结果指纹，用于复用相同输入的已完成结果
+func (b *AnalysisChainBuilder) PromptVersion(analysis *entity.AIAnalysis) string {
+	systemPrompt := b.buildSystemPrompt(analysis.AnalysisType) + languageInstruction(i18n.Normalize(analysis.Locale))
+	return promptDigest(b.dataTools.ToolInfosFor(analysis.AnalysisType), systemPrompt, b.buildUserPrompt(analysis), string(structured.AnalysisResult.JSON()))
+}
+
+// DailyTipsPromptVersion 每日建议的提示词版本，用户提示中的日期不参与计算
+func (b *AnalysisChainBuilder) DailyTipsPromptVersion(baby *entity.Baby, locale i18n.Locale) string {
+	systemPrompt := b.buildDailyTipsSystemPrompt() + languageInstruction(locale)
+	return promptDigest(b.dataTools.GetToolInfos(), systemPrompt, b.buildDailyTipsUserPrompt(baby, time.Time{}), string(structured.DailyTips.JSON()))
+}
+
+// promptDigest 计算提示词和绑定工具定义的摘要
+func promptDigest(toolInfos []*schema.ToolInfo, parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	for _, tool := range toolInfos {
+		h.Write([]byte(tool.Name + "\x00" + tool.Desc + "\x00"))
+		if params, err := tool.ParamsOneOf.ToJSONSchema(); err == nil {
+			h.Write(canonicalSchemaJSON(params))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalSchemaJSON 参数定义的规范化JSON
+// 参数由 map 生成，属性和 required 的顺序每次都可能不同，需排序后再参与摘要
+func canonicalSchemaJSON(params any) []byte {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return raw
+	}
+	sortRequired(value)
+	raw, _ = json.Marshal(value)
+	return raw
+}
+
+// sortRequired 递归排序 JSON Schema 中的 required 列表
+func sortRequired(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if list, ok := child.([]any); ok && key == "required" {
+				sort.Slice(list, func(i, j int) bool { return fmt.Sprint(list[i]) < fmt.Sprint(list[j]) })
+				continue
+			}
+			sortRequired(child)
+		}
+	case []any:
+		for _, child := range v {
+			sortRequired(child)
+		}
+	}
+}
+
 // getAnalysisTypeName 获取分析类型名称
 func (b *AnalysisChainBuilder) getAnalysisTypeName(analysisType entity.AIAnalysisType) string {
 	switch analysisType {
@@ -378,20 +549,11 @@ func (b *AnalysisChainBuilder) getAnalysisTypeName(analysisType entity.AIAnalysi
 	}
 }
 
-// parseAnalysisResponse 解析分析响应
-func (b *AnalysisChainBuilder) parseAnalysisResponse(content string, analysisType entity.AIAnalysisType, babyID int64) (*entity.AIAnalysisResult, error) {
+// parseAnalysisResponse 按 schema 校验并解析分析响应，未通过时请求模型修复
+func (b *AnalysisChainBuilder) parseAnalysisResponse(ctx context.Context, content string, analysisType entity.AIAnalysisType, babyID int64) (*entity.AIAnalysisResult, error) {
 	// 记录原始响应用于调试
 	b.logger.Debug("原始AI响应", zap.String("content", content))
 
-	// 清理和提取JSON
-	jsonContent := b.extractJSON(content)
-	if jsonContent == "" {
-		b.logger.Error("无法从响应中提取JSON", zap.String("content", content))
-		return nil, errors.New(errors.InternalError, "响应中未找到有效的JSON格式")
-	}
-
-	b.logger.Debug("提取的JSON", zap.String("json", jsonContent))
-
 	var result struct {
 		Score        float64                    `json:"score"`
 		Insights     []entity.AIInsight         `json:"insights"`
@@ -401,42 +563,39 @@ func (b *AnalysisChainBuilder) parseAnalysisResponse(content string, analysisTyp
 		UserFriendly *entity.UserFriendlyResult `json:"user_friendly"`
 	}
 
-	if err := json.Unmarshal([]byte(jsonContent), &result); err != nil {
-		b.logger.Error("JSON解析失败",
-			zap.String("json", jsonContent),
-			zap.Error(err),
-		)
-		return nil, errors.Wrap(errors.InternalError, "解析分析响应失败", err)
+	report, err := b.decodeStructured(ctx, structured.AnalysisResult, content, &result)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "AI分析结果校验失败", err)
 	}
 
 	return &entity.AIAnalysisResult{
-		BabyID:       babyID,
-		AnalysisType: analysisType,
-		Score:        result.Score,
-		Insights:     result.Insights,
-		Alerts:       result.Alerts,
-		Patterns:     result.Patterns,
-		Predictions:  result.Predictions,
-		UserFriendly: result.UserFriendly,
+		BabyID:           babyID,
+		AnalysisType:     analysisType,
+		Score:            result.Score,
+		Insights:         result.Insights,
+		Alerts:           result.Alerts,
+		Patterns:         result.Patterns,
+		Predictions:      result.Predictions,
+		UserFriendly:     result.UserFriendly,
+		ValidationErrors: report.String(),
 	}, nil
 }
 
-// parseDailyTipsResponse 解析每日建议响应
-func (b *AnalysisChainBuilder) parseDailyTipsResponse(content string) ([]entity.DailyTip, error) {
-	// 清理响应内容，移除可能的代码块标记和其他格式化字符
-	cleanContent := b.cleanJSONResponse(content)
-
-	var tips []entity.DailyTip
-	if err := json.Unmarshal([]byte(cleanContent), &tips); err != nil {
-		// 记录原始内容以便调试
-		b.logger.Error("JSON解析失败",
-			zap.String("original_content", content),
-			zap.String("cleaned_content", cleanContent),
-			zap.Error(err),
-		)
-		return nil, errors.Wrap(errors.InternalError, "解析建议响应失败", err)
-	}
-	return tips, nil
+// parseDailyTipsResponse 按 schema 校验并解析每日建议响应，未通过时请求模型修复
+func (b *AnalysisChainBuilder) parseDailyTipsResponse(ctx context.Context, content string) (*entity.DailyTips, error) {
+	var result struct {
+		Tips []entity.DailyTip `json:"tips"`
+	}
+
+	report, err := b.decodeStructured(ctx, structured.DailyTips, content, &result)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "每日建议校验失败", err)
+	}
+
+	return &entity.DailyTips{
+		Tips:             result.Tips,
+		ValidationErrors: report.String(),
+	}, nil
 }
 
 // cleanJSONResponse 清理AI响应中的格式化字符，提取纯JSON
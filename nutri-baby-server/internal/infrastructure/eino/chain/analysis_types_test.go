@@ -0,0 +1,280 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+)
+
+const testBabyID = 7
+
+// recordingModel 包装模拟模型，记录绑定的工具和返回给模型的工具结果
+type recordingModel struct {
+	inner   model.ToolCallingChatModel
+	bound   *[]string
+	results map[string]string
+}
+
+func (m *recordingModel) Generate(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	for _, msg := range messages {
+		if msg.Role == schema.Tool {
+			m.results[msg.ToolCallID] = msg.Content
+		}
+	}
+	return m.inner.Generate(ctx, messages, opts...)
+}
+
+func (m *recordingModel) Stream(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return m.inner.Stream(ctx, messages, opts...)
+}
+
+func (m *recordingModel) WithTools(infos []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	inner, err := m.inner.WithTools(infos)
+	if err != nil {
+		return nil, err
+	}
+	*m.bound = (*m.bound)[:0]
+	for _, info := range infos {
+		*m.bound = append(*m.bound, info.Name)
+	}
+	return &recordingModel{inner: inner, bound: m.bound, results: m.results}, nil
+}
+
+type testBabyRepo struct {
+	repository.BabyRepository
+}
+
+func (testBabyRepo) FindByID(ctx context.Context, babyID int64) (*entity.Baby, error) {
+	return &entity.Baby{ID: babyID, Name: "小满", BirthDate: "2026-08-20", Timezone: "Asia/Shanghai"}, nil
+}
+
+type testDiaperRepo struct {
+	repository.DiaperRecordRepository
+	records []*entity.DiaperRecord
+}
+
+func (r testDiaperRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.DiaperRecord, int64, error) {
+	return r.records, int64(len(r.records)), nil
+}
+
+type testVaccineRepo struct {
+	repository.BabyVaccineScheduleRepository
+	schedules []*entity.BabyVaccineSchedule
+}
+
+func (r testVaccineRepo) FindByBabyID(ctx context.Context, babyID int64, page, pageSize int) ([]*entity.BabyVaccineSchedule, error) {
+	return r.schedules, nil
+}
+
+type testIllnessRepo struct {
+	repository.IllnessEpisodeRepository
+	episodes []*entity.IllnessEpisode
+}
+
+func (r testIllnessRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.IllnessEpisode, int64, error) {
+	return r.episodes, int64(len(r.episodes)), nil
+}
+
+type testCryingRepo struct {
+	repository.CryingRecordRepository
+	records []*entity.CryingRecord
+}
+
+func (r testCryingRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.CryingRecord, int64, error) {
+	return r.records, int64(len(r.records)), nil
+}
+
+type testTemperatureRepo struct {
+	repository.TemperatureRecordRepository
+	records []*entity.TemperatureRecord
+}
+
+func (r testTemperatureRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.TemperatureRecord, int64, error) {
+	return r.records, int64(len(r.records)), nil
+}
+
+type testSymptomRepo struct {
+	repository.SymptomRecordRepository
+	records []*entity.SymptomRecord
+}
+
+func (r testSymptomRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.SymptomRecord, int64, error) {
+	return r.records, int64(len(r.records)), nil
+}
+
+// newHealthBehaviorBuilder 用一周的合成数据创建分析链(宝宝时区 Asia/Shanghai)
+func newHealthBehaviorBuilder(t *testing.T, bound *[]string, results map[string]string) *AnalysisChainBuilder {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	at := func(day, hour int) int64 {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, loc).UnixMilli()
+	}
+	yellow, seedy := "yellow", "seedy"
+	reaction := "接种部位轻微红肿，低热37.8℃，一天后消退"
+	vaccineDate := at(6, 10)
+	endTime := at(9, 8)
+	soothing := "抱着走动"
+
+	diapers := testDiaperRepo{records: []*entity.DiaperRecord{
+		{BabyID: testBabyID, Time: at(5, 2), Type: "pee"},
+		{BabyID: testBabyID, Time: at(5, 12), Type: "both", PoopColor: &yellow, PoopTexture: &seedy},
+		{BabyID: testBabyID, Time: at(5, 15), Type: "pee"},
+	}}
+	vaccines := testVaccineRepo{schedules: []*entity.BabyVaccineSchedule{
+		{BabyID: testBabyID, VaccineName: "百白破疫苗", DoseNumber: 1, VaccinationStatus: entity.VaccinationStatusCompleted, VaccineDate: &vaccineDate, Reaction: &reaction},
+		{BabyID: testBabyID, VaccineName: "脊灰疫苗", DoseNumber: 2, VaccinationStatus: entity.VaccinationStatusPending},
+	}}
+	illness := testIllnessRepo{episodes: []*entity.IllnessEpisode{
+		{BabyID: testBabyID, Name: "感冒", StartTime: at(7, 20), EndTime: &endTime},
+	}}
+	crying := testCryingRepo{records: []*entity.CryingRecord{
+		{BabyID: testBabyID, StartTime: at(6, 18), Duration: 100 * 60, Type: entity.CryingTypeCrying, Intensity: entity.CryingIntensityIntense, Trigger: entity.CryingTriggerGas, Soothing: &soothing},
+		{BabyID: testBabyID, StartTime: at(6, 21), Duration: 90 * 60, Type: entity.CryingTypeCrying, Intensity: entity.CryingIntensityModerate, Trigger: entity.CryingTriggerUnknown},
+		{BabyID: testBabyID, StartTime: at(7, 19), Duration: 30 * 60, Type: entity.CryingTypeFussy, Intensity: entity.CryingIntensityMild, Trigger: entity.CryingTriggerTired},
+	}}
+	temperatures := testTemperatureRepo{records: []*entity.TemperatureRecord{
+		{ID: 1, BabyID: testBabyID, Time: at(7, 21), Temperature: 38.6, Site: entity.TemperatureSiteAxillary},
+		{ID: 2, BabyID: testBabyID, Time: at(8, 9), Temperature: 38.2, Site: entity.TemperatureSiteEar},
+		{ID: 3, BabyID: testBabyID, Time: at(8, 21), Temperature: 37.2, Site: entity.TemperatureSiteAxillary},
+	}}
+	symptoms := testSymptomRepo{records: []*entity.SymptomRecord{
+		{BabyID: testBabyID, Time: at(7, 20), Symptom: entity.SymptomRunnyNose, Severity: entity.SymptomSeverityMild},
+		{BabyID: testBabyID, Time: at(8, 8), Symptom: entity.SymptomCough, Severity: entity.SymptomSeverityModerate},
+	}}
+
+	logger := zap.NewNop()
+	dataTools := tools.NewDataQueryTools(nil, nil, diapers, nil, vaccines, crying, illness, temperatures, symptoms, nil, testBabyRepo{}, nil, logger)
+	dataTools.SetClock(func() time.Time { return time.Date(2026, 10, 12, 9, 0, 0, 0, loc) })
+	chatModel := &recordingModel{inner: NewToolCallingMockChatModel(logger), bound: bound, results: results}
+	return NewAnalysisChainBuilder(chatModel, dataTools, nil, nil, logger)
+}
+
+func newTestAnalysis(analysisType entity.AIAnalysisType) *entity.AIAnalysis {
+	return &entity.AIAnalysis{
+		BabyID:       testBabyID,
+		AnalysisType: analysisType,
+		StartDate:    time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAnalyze_HealthUsesDedicatedTools(t *testing.T) {
+	var bound []string
+	results := map[string]string{}
+	builder := newHealthBehaviorBuilder(t, &bound, results)
+
+	result, err := builder.Analyze(context.Background(), newTestAnalysis(entity.AIAnalysisTypeHealth))
+	require.NoError(t, err)
+	assert.Equal(t, 82.0, result.Score)
+	assert.Subset(t, bound, []string{"get_diaper_patterns", "get_vaccine_reactions", "get_illness_episodes", "get_temperature_data"})
+	assert.NotContains(t, bound, "get_crying_data")
+
+	var diaper struct {
+		Days []struct {
+			Date  string `json:"date"`
+			Wet   int    `json:"wet"`
+			Dirty int    `json:"dirty"`
+		} `json:"days"`
+		LongestWetGap float64        `json:"longest_wet_gap_hours"`
+		StoolColors   map[string]int `json:"stool_colors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(results["call_diaper_patterns"]), &diaper))
+	require.Len(t, diaper.Days, 1)
+	assert.Equal(t, "2026-10-05", diaper.Days[0].Date)
+	assert.Equal(t, 3, diaper.Days[0].Wet)
+	assert.Equal(t, 1, diaper.Days[0].Dirty)
+	assert.Equal(t, 10.0, diaper.LongestWetGap)
+	assert.Equal(t, map[string]int{"yellow": 1}, diaper.StoolColors)
+
+	var vaccines struct {
+		Count        int `json:"count"`
+		WithReaction int `json:"with_reaction"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(results["call_vaccine_reactions"]), &vaccines))
+	assert.Equal(t, 1, vaccines.Count)
+	assert.Equal(t, 1, vaccines.WithReaction)
+
+	var illness struct {
+		SickDays int `json:"sick_days"`
+		Episodes []struct {
+			DurationDays float64 `json:"duration_days"`
+			Ongoing      bool    `json:"ongoing"`
+		} `json:"episodes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(results["call_illness_episodes"]), &illness))
+	assert.Equal(t, 3, illness.SickDays)
+	require.Len(t, illness.Episodes, 1)
+	assert.Equal(t, 1.5, illness.Episodes[0].DurationDays)
+	assert.False(t, illness.Episodes[0].Ongoing)
+
+	var temperature struct {
+		FeverReadings int `json:"fever_readings"`
+		FeverDays     int `json:"fever_days"`
+		Days          []struct {
+			Date           string  `json:"date"`
+			MaxTemperature float64 `json:"max_temperature"`
+		} `json:"days"`
+		Peak struct {
+			Temperature float64 `json:"temperature"`
+			Site        string  `json:"site"`
+		} `json:"peak"`
+		Symptoms map[string]int `json:"symptom_distribution"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(results["call_temperature_data"]), &temperature))
+	assert.Equal(t, 2, temperature.FeverReadings)
+	assert.Equal(t, 2, temperature.FeverDays)
+	require.Len(t, temperature.Days, 2)
+	assert.Equal(t, 38.6, temperature.Days[0].MaxTemperature)
+	// 腋温38.6℃相当于肛温39.1℃，高于耳温38.2℃
+	assert.Equal(t, entity.TemperatureSiteAxillary, temperature.Peak.Site)
+	assert.Equal(t, map[string]int{entity.SymptomRunnyNose: 1, entity.SymptomCough: 1}, temperature.Symptoms)
+}
+
+func TestAnalyze_BehaviorUsesCryingData(t *testing.T) {
+	var bound []string
+	results := map[string]string{}
+	builder := newHealthBehaviorBuilder(t, &bound, results)
+
+	result, err := builder.Analyze(context.Background(), newTestAnalysis(entity.AIAnalysisTypeBehavior))
+	require.NoError(t, err)
+	assert.Equal(t, 74.0, result.Score)
+	assert.Contains(t, bound, "get_crying_data")
+	assert.NotContains(t, bound, "get_diaper_patterns")
+
+	var crying struct {
+		TotalMinutes float64        `json:"total_minutes"`
+		DaysOver3h   int            `json:"days_over_3h"`
+		Hours        []int          `json:"hour_distribution"`
+		Intensities  map[string]int `json:"intensity_distribution"`
+		Soothing     map[string]int `json:"effective_soothing"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(results["call_crying_data"]), &crying))
+	assert.Equal(t, 220.0, crying.TotalMinutes)
+	assert.Equal(t, 1, crying.DaysOver3h)
+	assert.Equal(t, 1, crying.Hours[18])
+	assert.Equal(t, 1, crying.Intensities[entity.CryingIntensityIntense])
+	assert.Equal(t, map[string]int{"抱着走动": 1}, crying.Soothing)
+}
+
+func TestToolInfosFor_FeedingKeepsCommonTools(t *testing.T) {
+	dataTools := &tools.DataQueryTools{}
+	assert.Equal(t, dataTools.GetToolInfos(), dataTools.ToolInfosFor(entity.AIAnalysisTypeFeeding))
+
+	builder := &AnalysisChainBuilder{dataTools: dataTools}
+	assert.NotContains(t, builder.buildSystemPrompt(entity.AIAnalysisTypeFeeding), "get_crying_data")
+	assert.Contains(t, builder.buildSystemPrompt(entity.AIAnalysisTypeBehavior), "3-3-3")
+	assert.NotEqual(t,
+		builder.PromptVersion(newTestAnalysis(entity.AIAnalysisTypeHealth)),
+		builder.PromptVersion(newTestAnalysis(entity.AIAnalysisTypeBehavior)))
+}
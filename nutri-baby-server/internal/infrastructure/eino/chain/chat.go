@@ -0,0 +1,123 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+// chatMaxIterations 对话场景单轮回答最多的推理轮次
+const chatMaxIterations = 6
+
+// ToolExecutor 工具执行器，由调用方注入以便在每次工具调用时校验权限
+type ToolExecutor func(ctx context.Context, toolName string, params map[string]interface{}) (string, error)
+
+// ChatTurn 历史对话消息
+type ChatTurn struct {
+	Role    string // entity.AIChatRoleUser / entity.AIChatRoleAssistant
+	Content string
+}
+
+// ChatReply 对话回答
+type ChatReply struct {
+	Answer string   // 回答内容(可能包含 [feeding#123] 形式的记录引用标记)
+	Tools  []string // 本轮调用过的工具(按调用顺序，去重)
+}
+
+// Chat 围绕单个宝宝的多轮问答，回答基于工具查询到的真实记录，使用 locale 指定的语言回答
+func (b *AnalysisChainBuilder) Chat(ctx context.Context, baby *entity.Baby, history []ChatTurn, question string, locale i18n.Locale, execute ToolExecutor) (*ChatReply, error) {
+	ctx = i18n.WithLocale(ctx, locale)
+
+	toolBoundModel, err := b.chatModel.WithTools(b.dataTools.AllToolInfos())
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "绑定工具失败", err)
+	}
+
+	messages := []*schema.Message{schema.SystemMessage(b.buildChatSystemPrompt(baby, time.Now(), locale) + languageInstruction(locale))}
+	for _, turn := range history {
+		if turn.Role == entity.AIChatRoleAssistant {
+			messages = append(messages, schema.AssistantMessage(turn.Content, nil))
+		} else {
+			messages = append(messages, schema.UserMessage(turn.Content))
+		}
+	}
+	messages = append(messages, schema.UserMessage(question))
+
+	reply := &ChatReply{}
+	used := make(map[string]bool)
+
+	for i := 0; i < chatMaxIterations; i++ {
+		response, err := toolBoundModel.Generate(ctx, messages)
+		if err != nil {
+			return nil, errors.Wrap(errors.InternalError, "AI助手回答失败", err)
+		}
+		messages = append(messages, response)
+
+		if len(response.ToolCalls) == 0 {
+			reply.Answer = strings.TrimSpace(response.Content)
+			return reply, nil
+		}
+
+		for _, toolCall := range response.ToolCalls {
+			if !used[toolCall.Function.Name] {
+				used[toolCall.Function.Name] = true
+				reply.Tools = append(reply.Tools, toolCall.Function.Name)
+			}
+
+			var params map[string]interface{}
+			toolResult := ""
+			if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &params); err != nil {
+				toolResult = fmt.Sprintf("工具调用失败: 解析工具参数失败: %v", err)
+			} else if toolResult, err = execute(ctx, toolCall.Function.Name, params); err != nil {
+				b.logger.Warn("AI助手工具调用失败",
+					zap.String("tool_name", toolCall.Function.Name),
+					zap.Error(err),
+				)
+				toolResult = fmt.Sprintf("工具调用失败: %v", err)
+			}
+
+			messages = append(messages, &schema.Message{
+				Role:       schema.Tool,
+				Content:    toolResult,
+				ToolCallID: toolCall.ID,
+			})
+		}
+	}
+
+	return nil, errors.New(errors.InternalError, "AI助手回答超时，达到最大迭代次数")
+}
+
+// buildChatSystemPrompt 构建对话系统提示
+func (b *AnalysisChainBuilder) buildChatSystemPrompt(baby *entity.Baby, now time.Time, locale i18n.Locale) string {
+	return fmt.Sprintf(`你是一个耐心、专业的育儿助手，正在回答宝宝照护者关于宝宝 %s (宝宝ID %d，出生日期 %s) 的问题。今天是 %s。
+
+你可以使用以下工具查询这个宝宝的真实记录，参数中的 baby_id 必须是 %d：
+- get_baby_info: 获取宝宝基本信息
+- get_feeding_data: 获取喂养记录
+- get_sleep_data: 获取睡眠记录
+- get_growth_data: 获取成长记录
+- get_diaper_data: 获取尿布记录
+- get_vaccine_data: 获取疫苗记录
+- get_diaper_patterns: 获取按天统计的排泄规律(小便/大便次数、最长小便间隔、大便颜色和性状)
+- get_vaccine_reactions: 获取完成的疫苗接种及接种反应
+- get_illness_episodes: 获取患病经过
+- get_temperature_data: 获取体温测量和症状记录，以及按天的最高体温和发热次数
+- get_crying_data: 获取哭闹和烦躁记录，以及按天、按时段的统计
+- get_milestone_data: 获取发育里程碑清单(已达成、常见月龄内、超过常见月龄仍未达成)
+
+回答要求：
+1. 涉及宝宝具体情况的问题，必须先调用工具查询相关时间段的记录，不要凭空推测；数据不足时直接说明。
+2. 引用具体记录时，在句末用 [类型#记录ID] 标注来源，类型为 feeding、sleep、diaper、growth、vaccine、illness、crying、temperature、symptom、milestone 之一，例如 [sleep#1234567890]。只引用工具返回过的记录。
+3. 用简洁、温和的%s回答，先给结论，再给依据和可操作的建议，不超过300字，不要使用Markdown表格。
+4. 你不是医生，不做诊断；出现发热、精神差、呼吸困难、持续拒奶、尿量明显减少等情况时，提醒及时就医。`,
+		baby.Name, baby.ID, baby.BirthDate, now.Format("2006-01-02"), baby.ID, locale.Name(),
+	)
+}
@@ -0,0 +1,120 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+// 分析过程事件类型
+const (
+	ProgressEventThinking = "thinking"  // 模型开始新一轮推理(同一轮次重复推送表示流式中断，之前的增量文本作废)
+	ProgressEventToolCall = "tool_call" // 正在调用数据工具
+	ProgressEventDelta    = "delta"     // 模型输出的增量文本
+)
+
+// ProgressEvent 分析过程事件
+type ProgressEvent struct {
+	Type      string `json:"type"`
+	Iteration int    `json:"iteration"`            // 第几轮推理(从1开始)
+	Tool      string `json:"tool,omitempty"`       // 工具名
+	ToolLabel string `json:"tool_label,omitempty"` // 工具的描述(按分析语言)
+	Text      string `json:"text,omitempty"`       // 增量文本
+}
+
+// ProgressFunc 分析过程回调
+type ProgressFunc func(event ProgressEvent)
+
+// ToolLabel 获取工具的描述(用于进度展示)，描述在 i18n 消息目录中以 "tool.工具名" 为键，未知工具返回工具名
+func ToolLabel(locale i18n.Locale, toolName string) string {
+	key := "tool." + toolName
+	if !i18n.Default.Has(key) {
+		return toolName
+	}
+	return locale.T(key)
+}
+
+// generate 生成一轮模型响应
+// streaming 为 true 时使用流式输出并回调增量文本；提供商不支持流式或流中断时降级为一次性生成，
+// 并将 streaming 置为 false，后续轮次不再尝试流式；若中断前已推送过增量文本，
+// 先以同一轮次再推送一次 thinking 事件，通知消费方丢弃这部分不完整的文本
+func (b *AnalysisChainBuilder) generate(
+	ctx context.Context,
+	chatModel model.ToolCallingChatModel,
+	messages []*schema.Message,
+	streaming *bool,
+	iteration int,
+	onProgress ProgressFunc,
+	opts ...model.Option,
+) (*schema.Message, error) {
+	if *streaming && onProgress != nil {
+		emitted := false
+		response, err := b.streamGenerate(ctx, chatModel, messages, iteration, func(event ProgressEvent) {
+			emitted = true
+			onProgress(event)
+		}, opts...)
+		if err == nil {
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		b.logger.Warn("流式生成失败，降级为非流式生成",
+			zap.Int("iteration", iteration),
+			zap.Error(err),
+		)
+		*streaming = false
+		if emitted {
+			onProgress(ProgressEvent{Type: ProgressEventThinking, Iteration: iteration})
+		}
+	}
+
+	return chatModel.Generate(ctx, messages, opts...)
+}
+
+// streamGenerate 流式生成并拼接为完整消息(包括分片的工具调用)
+func (b *AnalysisChainBuilder) streamGenerate(
+	ctx context.Context,
+	chatModel model.ToolCallingChatModel,
+	messages []*schema.Message,
+	iteration int,
+	onProgress ProgressFunc,
+	opts ...model.Option,
+) (*schema.Message, error) {
+	reader, err := chatModel.Stream(ctx, messages, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var chunks []*schema.Message
+	for {
+		chunk, err := reader.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			continue
+		}
+
+		chunks = append(chunks, chunk)
+		if chunk.Content != "" {
+			onProgress(ProgressEvent{Type: ProgressEventDelta, Iteration: iteration, Text: chunk.Content})
+		}
+	}
+
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("流式响应为空")
+	}
+	return schema.ConcatMessages(chunks)
+}
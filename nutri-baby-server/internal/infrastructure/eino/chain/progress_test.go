@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+// streamStubModel 按片段流式返回固定内容；streamErr 非空时模拟不支持流式的提供商，
+// recvErr 非空时推送第一个片段后中断
+type streamStubModel struct {
+	chunks    []string
+	streamErr error
+	recvErr   error
+	generated int
+}
+
+func (m *streamStubModel) Generate(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.generated++
+	content := ""
+	for _, chunk := range m.chunks {
+		content += chunk
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (m *streamStubModel) Stream(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	if m.streamErr != nil {
+		return nil, m.streamErr
+	}
+	if m.recvErr != nil {
+		reader, writer := schema.Pipe[*schema.Message](2)
+		writer.Send(schema.AssistantMessage(m.chunks[0], nil), nil)
+		writer.Send(nil, m.recvErr)
+		writer.Close()
+		return reader, nil
+	}
+	msgs := make([]*schema.Message, 0, len(m.chunks))
+	for _, chunk := range m.chunks {
+		msgs = append(msgs, schema.AssistantMessage(chunk, nil))
+	}
+	return schema.StreamReaderFromArray(msgs), nil
+}
+
+func (m *streamStubModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func TestAnalyzeWithProgress(t *testing.T) {
+	analysis := &entity.AIAnalysis{
+		ID:           1,
+		BabyID:       7,
+		AnalysisType: entity.AIAnalysisTypeSleep,
+		StartDate:    time.Now().AddDate(0, 0, -7),
+		EndDate:      time.Now(),
+	}
+	chunks := []string{`{"score": 80,`, ` "insights": [], "alerts": [],`, ` "patterns": [], "predictions": []}`}
+
+	newBuilder := func(m model.ToolCallingChatModel) *AnalysisChainBuilder {
+		return &AnalysisChainBuilder{
+			chatModel: m,
+			dataTools: &tools.DataQueryTools{},
+			logger:    zap.NewNop(),
+		}
+	}
+
+	// 流式提供商: 推送增量文本
+	streaming := &streamStubModel{chunks: chunks}
+	var deltas []string
+	result, err := newBuilder(streaming).AnalyzeWithProgress(context.Background(), analysis, func(event ProgressEvent) {
+		if event.Type == ProgressEventDelta {
+			deltas = append(deltas, event.Text)
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, result.Score)
+	assert.Equal(t, chunks, deltas)
+	assert.Zero(t, streaming.generated)
+
+	// 不支持流式的提供商: 降级为一次性生成，仍然得到结果
+	fallback := &streamStubModel{chunks: chunks, streamErr: fmt.Errorf("stream not supported")}
+	var types []string
+	result, err = newBuilder(fallback).AnalyzeWithProgress(context.Background(), analysis, func(event ProgressEvent) {
+		types = append(types, event.Type)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, result.Score)
+	assert.Equal(t, []string{ProgressEventThinking}, types)
+	assert.Equal(t, 1, fallback.generated)
+
+	// 流式中途失败: 同一轮次重新推送 thinking，通知丢弃已推送的部分文本
+	broken := &streamStubModel{chunks: chunks, recvErr: fmt.Errorf("connection reset")}
+	var events []ProgressEvent
+	result, err = newBuilder(broken).AnalyzeWithProgress(context.Background(), analysis, func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, result.Score)
+	assert.Equal(t, []ProgressEvent{
+		{Type: ProgressEventThinking, Iteration: 1},
+		{Type: ProgressEventDelta, Iteration: 1, Text: chunks[0]},
+		{Type: ProgressEventThinking, Iteration: 1},
+	}, events)
+	assert.Equal(t, 1, broken.generated)
+}
+
+func TestToolLabel(t *testing.T) {
+	assert.Equal(t, "查询睡眠记录", ToolLabel(i18n.ZhCN, "get_sleep_data"))
+	assert.Equal(t, "Checking sleep records", ToolLabel(i18n.EnUS, "get_sleep_data"))
+	assert.Equal(t, "unknown_tool", ToolLabel(i18n.EnUS, "unknown_tool"))
+
+	// 所有工具(包括各分析类型专用工具)都有描述
+	for _, info := range (&tools.DataQueryTools{}).AllToolInfos() {
+		assert.NotEqual(t, info.Name, ToolLabel(i18n.ZhCN, info.Name), info.Name)
+		assert.NotEqual(t, info.Name, ToolLabel(i18n.EnUS, info.Name), info.Name)
+	}
+}
@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// QuickLogTimeLayout 快速记录中时间字段的格式(宝宝所在时区的本地时间)
+const QuickLogTimeLayout = "2006-01-02 15:04"
+
+// QuickLogEntry 模型从自然语言中提取出的一条记录
+// 时间为宝宝所在时区的本地时间，由调用方按时区解析
+type QuickLogEntry struct {
+	Type string `json:"type"` // feeding, sleep, diaper, growth
+	Time string `json:"time"` // 发生时间(睡眠为入睡时间)，格式 2006-01-02 15:04
+
+	// 喂养
+	FeedingType  string  `json:"feedingType,omitempty"` // breast, bottle, food
+	AmountMl     float64 `json:"amountMl,omitempty"`    // 奶量(ml)
+	BottleType   string  `json:"bottleType,omitempty"`  // formula, breast-milk
+	Side         string  `json:"side,omitempty"`        // left, right, both
+	LeftMinutes  int     `json:"leftMinutes,omitempty"`
+	RightMinutes int     `json:"rightMinutes,omitempty"`
+	FoodName     string  `json:"foodName,omitempty"`
+
+	// 睡眠
+	EndTime         string `json:"endTime,omitempty"`         // 醒来时间，格式同 time
+	DurationMinutes int    `json:"durationMinutes,omitempty"` // 睡眠时长，亲喂未区分左右时为总时长
+	SleepType       string `json:"sleepType,omitempty"`       // nap, night
+
+	// 尿布
+	DiaperType string `json:"diaperType,omitempty"` // pee, poop, both
+	PooColor   string `json:"pooColor,omitempty"`
+	PooTexture string `json:"pooTexture,omitempty"`
+
+	// 生长
+	WeightKg          float64 `json:"weightKg,omitempty"`
+	HeightCm          float64 `json:"heightCm,omitempty"`
+	HeadCircumference float64 `json:"headCircumference,omitempty"`
+
+	Note string `json:"note,omitempty"`
+}
+
+// ParseQuickLog 将照护者的自然语言描述解析为结构化记录
+// now 为宝宝所在时区的当前时间，模型据此换算"刚才"、"半小时前"等相对时间
+func (b *AnalysisChainBuilder) ParseQuickLog(ctx context.Context, text string, now time.Time) ([]QuickLogEntry, error) {
+	messages := []*schema.Message{
+		schema.SystemMessage(b.buildQuickLogSystemPrompt(now)),
+		schema.UserMessage(text),
+	}
+
+	response, err := b.chatModel.Generate(ctx, messages)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "解析快速记录失败", err)
+	}
+
+	var payload struct {
+		Entries []QuickLogEntry `json:"entries"`
+	}
+	content := b.extractJSON(b.cleanJSONResponse(response.Content))
+	if err := json.Unmarshal([]byte(content), &payload); err != nil {
+		b.logger.Warn("快速记录响应解析失败",
+			zap.String("content", response.Content),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(errors.InternalError, "解析快速记录响应失败", err)
+	}
+
+	for i := range payload.Entries {
+		payload.Entries[i].Type = strings.ToLower(strings.TrimSpace(payload.Entries[i].Type))
+	}
+	return payload.Entries, nil
+}
+
+// buildQuickLogSystemPrompt 构建快速记录解析提示
+func (b *AnalysisChainBuilder) buildQuickLogSystemPrompt(now time.Time) string {
+	return fmt.Sprintf(`你是育儿记录助手，负责把照护者随手写下的一句话拆解成结构化记录。当前本地时间是 %s (%s)。
+
+只输出一个JSON对象，不要输出任何其他文字，格式如下：
+{"entries": [{"type": "feeding", "time": "2006-01-02 15:04", ...}]}
+
+字段说明：
+- type: feeding(喂养)、sleep(睡眠)、diaper(换尿布)、growth(生长测量) 之一
+- time: 发生时间(睡眠为入睡时间)，本地时间，格式 YYYY-MM-DD HH:mm；"刚才"、"半小时前"、"昨晚"等相对时间按当前本地时间换算；未提及时间时使用当前时间；没有上下午标记的钟点取不晚于当前时间的最近一次
+- 喂养: feedingType 为 breast(亲喂)、bottle(奶瓶)、food(辅食)；奶瓶填 amountMl(盎司换算为毫升)和 bottleType(formula 配方奶 / breast-milk 母乳)；亲喂填 side(left/right/both)、leftMinutes、rightMinutes(分不清左右时长时填 durationMinutes)；辅食填 foodName
+- 睡眠: endTime(醒来时间，格式同 time，可省略)、durationMinutes、sleepType(nap 小睡 / night 夜间长睡)
+- 尿布: diaperType(pee 尿 / poop 便 / both 都有)、pooColor(yellow, green, brown, black, red, white)、pooTexture(watery, loose, paste, soft, formed, hard)
+- 生长: weightKg、heightCm、headCircumference(头围 cm)
+- note: 无法归入以上字段但有价值的信息
+
+要求：
+1. 一句话中提到多件事时拆成多条记录；同一次亲喂的左右两侧合并为一条。
+2. 只提取明确提到的信息，不要编造数值；无法识别的内容忽略。`,
+		now.Format(QuickLogTimeLayout), now.Format("Monday"),
+	)
+}
@@ -0,0 +1,69 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
+	"go.uber.org/zap"
+)
+
+// maxRepairAttempts 结构化输出未通过校验时请求模型修复的次数
+const maxRepairAttempts = 1
+
+// repairSystemPrompt 结构化输出修复提示
+const repairSystemPrompt = `你是JSON格式修复助手。请根据给定的JSON Schema和校验错误修正输出：
+- 保持原有内容的含义不变，只修正结构、字段名、字段类型和取值范围
+- 缺失的必填字段根据已有内容合理补全
+- 只返回修正后的JSON，不要包含任何解释文字或代码块标记`
+
+// decodeStructured 按 schema 校验模型输出并解析到 out
+// 未通过校验时把错误回传给模型修复，修复后仍未通过返回 *structured.ValidationError；
+// 返回的校验记录在经过修复时非空，随结果保存用于排查
+func (b *AnalysisChainBuilder) decodeStructured(ctx context.Context, s *structured.Schema, content string, out any) (*structured.Report, error) {
+	report := structured.NewReport(s)
+	for attempt := 0; ; attempt++ {
+		errs := s.Validate(s.Normalize(content), out)
+		if len(errs) == 0 {
+			if attempt > 0 {
+				report.Repaired = true
+				b.logger.Info("AI结构化输出经修复后通过校验", zap.String("schema", s.Name), zap.Int("attempts", attempt+1))
+			}
+			return report, nil
+		}
+
+		report.AddFailure(content, errs)
+		b.logger.Warn("AI结构化输出未通过校验",
+			zap.String("schema", s.Name),
+			zap.Int("attempt", attempt+1),
+			zap.Strings("errors", errs),
+		)
+		if attempt >= maxRepairAttempts {
+			return report, &structured.ValidationError{Report: report}
+		}
+
+		repaired, err := b.repairStructured(ctx, s, content, errs)
+		if err != nil {
+			b.logger.Error("请求模型修复结构化输出失败", zap.String("schema", s.Name), zap.Error(err))
+			return report, &structured.ValidationError{Report: report}
+		}
+		content = repaired
+	}
+}
+
+// repairStructured 把校验错误回传给模型，请求输出修正后的JSON(不绑定工具)
+func (b *AnalysisChainBuilder) repairStructured(ctx context.Context, s *structured.Schema, content string, errs []string) (string, error) {
+	messages := []*schema.Message{
+		schema.SystemMessage(repairSystemPrompt),
+		schema.UserMessage(fmt.Sprintf("JSON Schema:\n%s\n\n校验错误:\n- %s\n\n待修复的输出:\n%s",
+			s.JSON(), strings.Join(errs, "\n- "), content)),
+	}
+
+	response, err := b.chatModel.Generate(ctx, messages, structured.WithResponseSchema(s))
+	if err != nil {
+		return "", err
+	}
+	return response.Content, nil
+}
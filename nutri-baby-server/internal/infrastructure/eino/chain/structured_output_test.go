@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+	"go.uber.org/zap"
+)
+
+// scriptedModel 按顺序返回预设内容，并记录每次调用是否要求结构化输出
+type scriptedModel struct {
+	replies []string
+	calls   int
+	schemas []*structured.Schema
+}
+
+func (m *scriptedModel) Generate(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.schemas = append(m.schemas, structured.ResponseSchemaFrom(opts...))
+	reply := m.replies[m.calls]
+	m.calls++
+	return schema.AssistantMessage(reply, nil), nil
+}
+
+func (m *scriptedModel) Stream(ctx context.Context, messages []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("stream not supported")
+}
+
+func (m *scriptedModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func TestAnalyzeRepairsInvalidOutput(t *testing.T) {
+	analysis := &entity.AIAnalysis{
+		ID:           1,
+		BabyID:       7,
+		AnalysisType: entity.AIAnalysisTypeFeeding,
+		StartDate:    time.Now().AddDate(0, 0, -7),
+		EndDate:      time.Now(),
+	}
+	newBuilder := func(m model.ToolCallingChatModel) *AnalysisChainBuilder {
+		return &AnalysisChainBuilder{chatModel: m, dataTools: &tools.DataQueryTools{}, logger: zap.NewNop()}
+	}
+	invalid := `{"score": "良好", "insights": []}`
+	valid := `{"score": 88, "insights": [], "alerts": [], "patterns": [], "predictions": []}`
+
+	// 首次输出未通过校验，修复后通过，校验记录随结果返回
+	m := &scriptedModel{replies: []string{invalid, valid}}
+	result, err := newBuilder(m).Analyze(context.Background(), analysis)
+	require.NoError(t, err)
+	assert.Equal(t, 88.0, result.Score)
+	assert.Equal(t, 2, m.calls)
+	assert.Equal(t, []*structured.Schema{structured.AnalysisResult, structured.AnalysisResult}, m.schemas)
+	assert.Contains(t, result.ValidationErrors, `"repaired":true`)
+
+	// 首次即通过时不保存校验记录
+	m = &scriptedModel{replies: []string{valid}}
+	result, err = newBuilder(m).Analyze(context.Background(), analysis)
+	require.NoError(t, err)
+	assert.Empty(t, result.ValidationErrors)
+
+	// 修复后仍未通过: 返回可识别的校验错误
+	m = &scriptedModel{replies: []string{invalid, invalid}}
+	_, err = newBuilder(m).Analyze(context.Background(), analysis)
+	var validationErr *structured.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Report.Attempts, 2)
+	assert.Equal(t, 2, m.calls)
+}
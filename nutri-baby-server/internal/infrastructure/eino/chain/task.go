@@ -0,0 +1,39 @@
+package chain
+
+import (
+	"context"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 分析任务类型
+const (
+	AnalysisTaskAnalysis  = "analysis"   // 数据分析
+	AnalysisTaskDailyTips = "daily_tips" // 每日建议
+)
+
+// AnalysisTask 当前模型调用对应的分析任务，由分析链写入 context
+// 不依赖提示词的提供商(如离线分析引擎)据此直接读取数据生成结果
+type AnalysisTask struct {
+	Kind         string // 任务类型，见 AnalysisTask* 常量
+	BabyID       int64
+	AnalysisType entity.AIAnalysisType // 仅数据分析任务
+	StartDate    time.Time             // 数据分析为分析区间开始日期，每日建议为建议日期
+	EndDate      time.Time             // 分析区间结束日期(含)，每日建议与 StartDate 相同
+	Locale       i18n.Locale           // 面向用户文本的输出语言
+}
+
+type analysisTaskKey struct{}
+
+// withAnalysisTask 返回携带分析任务的 context
+func withAnalysisTask(ctx context.Context, task AnalysisTask) context.Context {
+	return context.WithValue(ctx, analysisTaskKey{}, task)
+}
+
+// AnalysisTaskFrom 读取 context 中的分析任务，对话、快速记录等非分析调用没有任务
+func AnalysisTaskFrom(ctx context.Context) (AnalysisTask, bool) {
+	task, ok := ctx.Value(analysisTaskKey{}).(AnalysisTask)
+	return task, ok
+}
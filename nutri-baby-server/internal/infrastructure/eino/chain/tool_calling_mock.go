@@ -82,6 +82,16 @@ func (m *ToolCallingMockChatModel) shouldCallTool(content string) bool {
 	return len(m.tools) > 0 && (strings.Contains(content, "分析") || strings.Contains(content, "建议"))
 }
 
+// hasTool 检查是否绑定了指定工具
+func (m *ToolCallingMockChatModel) hasTool(name string) bool {
+	for _, tool := range m.tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // hasToolResults 检查消息历史中是否有工具调用结果
 func (m *ToolCallingMockChatModel) hasToolResults(messages []*schema.Message) bool {
 	for _, msg := range messages {
@@ -145,6 +155,36 @@ func (m *ToolCallingMockChatModel) generateToolCallResponse(content string) *sch
 				},
 			})
 		}
+
+		// 健康和行为分析调用专用工具(仅在工具已绑定时)
+		rangeArgs := `{"baby_id": ` + babyID + `, "start_date": "` + startDate + `", "end_date": "` + endDate + `"}`
+		if strings.Contains(content, "健康") {
+			for _, name := range []string{"get_diaper_patterns", "get_illness_episodes", "get_vaccine_reactions", "get_temperature_data"} {
+				if m.hasTool(name) {
+					toolCalls = append(toolCalls, schema.ToolCall{
+						ID:       "call_" + strings.TrimPrefix(name, "get_"),
+						Type:     "function",
+						Function: schema.FunctionCall{Name: name, Arguments: rangeArgs},
+					})
+				}
+			}
+		}
+
+		if strings.Contains(content, "行为") && m.hasTool("get_crying_data") {
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:       "call_crying_data",
+				Type:     "function",
+				Function: schema.FunctionCall{Name: "get_crying_data", Arguments: rangeArgs},
+			})
+		}
+
+		if strings.Contains(content, "成长") && m.hasTool("get_milestone_data") {
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:       "call_milestone_data",
+				Type:     "function",
+				Function: schema.FunctionCall{Name: "get_milestone_data", Arguments: `{"baby_id": ` + babyID + `}`},
+			})
+		}
 	}
 
 	return &schema.Message{
@@ -157,7 +197,7 @@ func (m *ToolCallingMockChatModel) generateToolCallResponse(content string) *sch
 // generateFinalAnalysis 生成最终分析
 func (m *ToolCallingMockChatModel) generateFinalAnalysis(messages []*schema.Message) *schema.Message {
 	// 分析工具调用结果
-	var hasFeeding, hasSleep, hasGrowth bool
+	var hasFeeding, hasSleep, hasGrowth, hasHealth, hasBehavior bool
 	
 	for _, msg := range messages {
 		if msg.Role == schema.Tool {
@@ -170,13 +210,51 @@ func (m *ToolCallingMockChatModel) generateFinalAnalysis(messages []*schema.Mess
 			if strings.Contains(msg.Content, "growth_data") {
 				hasGrowth = true
 			}
+			if strings.Contains(msg.Content, "diaper_patterns") || strings.Contains(msg.Content, "illness_episodes") {
+				hasHealth = true
+			}
+			if strings.Contains(msg.Content, "crying_data") {
+				hasBehavior = true
+			}
 		}
 	}
 
 	// 根据获取到的数据类型生成相应的分析结果
 	var analysisResult string
 	
-	if hasFeeding {
+	if hasHealth {
+		analysisResult = `{
+			"score": 82,
+			"insights": [
+				{
+					"type": "health",
+					"title": "排泄规律正常",
+					"description": "基于获取的排泄规律和患病经过分析，宝宝每天小便次数充足，近期患病已痊愈",
+					"priority": "medium",
+					"category": "排泄与水分"
+				}
+			],
+			"alerts": [],
+			"patterns": [],
+			"predictions": []
+		}`
+	} else if hasBehavior {
+		analysisResult = `{
+			"score": 74,
+			"insights": [
+				{
+					"type": "behavior",
+					"title": "傍晚哭闹较集中",
+					"description": "基于获取的哭闹数据分析，哭闹多集中在傍晚时段，抱着走动的安抚效果较好",
+					"priority": "medium",
+					"category": "哭闹时段"
+				}
+			],
+			"alerts": [],
+			"patterns": [],
+			"predictions": []
+		}`
+	} else if hasFeeding {
 		analysisResult = `{
 			"score": 85,
 			"insights": [
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+	"sync"
+)
+
+// UsageScope 模型调用的用量归属，由发起调用的业务方写入 context
+type UsageScope struct {
+	RequestID string // 业务请求ID，同一次分析/对话的多轮模型调用共用
+	Operation string // 用途，见 entity.AIUsageOperation*
+	OpenID    string // 发起用户，定时任务为空
+	BabyID    int64
+}
+
+type usageScopeKey struct{}
+
+// WithUsageScope 返回携带用量归属的 context
+func WithUsageScope(ctx context.Context, scope UsageScope) context.Context {
+	return context.WithValue(ctx, usageScopeKey{}, scope)
+}
+
+// UsageScopeFrom 读取 context 中的用量归属
+func UsageScopeFrom(ctx context.Context) (UsageScope, bool) {
+	scope, ok := ctx.Value(usageScopeKey{}).(UsageScope)
+	return scope, ok
+}
+
+// providerTracker 记录一次业务调用中最后一次成功响应的提供商
+type providerTracker struct {
+	mu       sync.Mutex
+	provider string
+}
+
+type providerTrackerKey struct{}
+
+// withProviderTracker 返回可记录响应提供商的 context
+func withProviderTracker(ctx context.Context) (context.Context, *providerTracker) {
+	tracker := &providerTracker{}
+	return context.WithValue(ctx, providerTrackerKey{}, tracker), tracker
+}
+
+// ReportProvider 由模型实现在生成成功后调用，记录实际产出响应的提供商
+func ReportProvider(ctx context.Context, provider string) {
+	if tracker, ok := ctx.Value(providerTrackerKey{}).(*providerTracker); ok {
+		tracker.mu.Lock()
+		tracker.provider = provider
+		tracker.mu.Unlock()
+	}
+}
+
+// Provider 返回最后一次成功响应的提供商
+func (t *providerTracker) Provider() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.provider
+}
@@ -0,0 +1,235 @@
+package golden
+
+import (
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// syntheticZone 合成宝宝所在时区(东八区)
+var syntheticZone = time.FixedZone("CST", 8*3600)
+
+// syntheticRecordedAt 合成用例的录制时间，固定取值保证工具输出可复现
+var syntheticRecordedAt = time.Date(2026, 10, 8, 10, 0, 0, 0, syntheticZone)
+
+// SyntheticCases 内置的合成宝宝用例(未录制)，覆盖不同月龄和分析类型
+// 记录按确定的规则生成，录制后连同数据一起保存，修改这里不影响已录制的用例
+func SyntheticCases() []*Case {
+	return []*Case{
+		newbornFeedingCase(),
+		infantShortSleepCase(),
+		infantSlowGrowthCase(),
+		toddlerDailyTipsCase(),
+	}
+}
+
+// FindSyntheticCase 按名称查找内置用例
+func FindSyntheticCase(name string) (*Case, bool) {
+	for _, c := range SyntheticCases() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// syntheticBaby 合成宝宝
+func syntheticBaby(id int64, nickname, gender, birthDate string) entity.Baby {
+	return entity.Baby{
+		ID:        id,
+		Name:      nickname,
+		Nickname:  nickname,
+		BirthDate: birthDate,
+		Gender:    gender,
+		UserID:    1,
+		Timezone:  "Asia/Shanghai",
+	}
+}
+
+// day 2026年10月第 d 天的 hour:minute(东八区)
+func day(d, hour, minute int) time.Time {
+	return time.Date(2026, 10, d, hour, minute, 0, 0, syntheticZone)
+}
+
+// jitter 确定性的分钟抖动，范围 [-spread, spread]
+func jitter(seed, spread int) time.Duration {
+	return time.Duration((seed*37)%(2*spread+1)-spread) * time.Minute
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// newbornFeedingCase 3周大的新生儿，混合喂养约每3小时一次
+func newbornFeedingCase() *Case {
+	baby := syntheticBaby(900001, "小禾", "female", "2026-09-15")
+	c := &Case{
+		Name:        "newborn_feeding",
+		Description: "3周新生儿，混合喂养约每3小时一次，分析一周喂养",
+		RecordedAt:  syntheticRecordedAt,
+		Task:        Task{Kind: TaskAnalysis, AnalysisType: entity.AIAnalysisTypeFeeding, StartDate: "2026-10-01", EndDate: "2026-10-07"},
+		Baby:        baby,
+	}
+
+	id := baby.ID * 1000
+	for d := 1; d <= 7; d++ {
+		for i := 0; i < 8; i++ {
+			id++
+			at := day(d, 0, 30).Add(time.Duration(i)*3*time.Hour + jitter(d*8+i, 20))
+			record := &entity.FeedingRecord{ID: id, BabyID: baby.ID, Time: at.UnixMilli(), FeedingType: "breast", Duration: 900}
+			if i%2 == 1 {
+				record.FeedingType = "bottle"
+				record.Duration = 0
+				record.Amount = 80 + int64(d)*2
+			}
+			c.Records.Feedings = append(c.Records.Feedings, record)
+		}
+	}
+	c.Records.Growth = []*entity.GrowthRecord{
+		{ID: id + 1, BabyID: baby.ID, Time: day(1, 9, 0).UnixMilli(), Weight: floatPtr(3.6), Height: floatPtr(52.0)},
+		{ID: id + 2, BabyID: baby.ID, Time: day(7, 9, 0).UnixMilli(), Weight: floatPtr(3.82), Height: floatPtr(52.6)},
+	}
+	return c
+}
+
+// infantShortSleepCase 5个月婴儿，夜睡晚、总睡眠明显不足
+func infantShortSleepCase() *Case {
+	baby := syntheticBaby(900002, "豆豆", "male", "2026-05-10")
+	c := &Case{
+		Name:        "infant_short_sleep",
+		Description: "5个月婴儿，入睡晚且每天总睡眠约10小时，低于月龄参考",
+		RecordedAt:  syntheticRecordedAt,
+		Task:        Task{Kind: TaskAnalysis, AnalysisType: entity.AIAnalysisTypeSleep, StartDate: "2026-10-01", EndDate: "2026-10-07"},
+		Baby:        baby,
+	}
+
+	id := baby.ID * 1000
+	addSleep := func(start time.Time, duration time.Duration, sleepType string) {
+		id++
+		end := start.Add(duration).UnixMilli()
+		c.Records.Sleeps = append(c.Records.Sleeps, &entity.SleepRecord{
+			ID:        id,
+			BabyID:    baby.ID,
+			StartTime: start.UnixMilli(),
+			EndTime:   int64Ptr(end),
+			Duration:  intPtr(int(duration.Seconds())),
+			Type:      sleepType,
+		})
+	}
+	for d := 1; d <= 7; d++ {
+		addSleep(day(d, 9, 30).Add(jitter(d, 15)), 50*time.Minute, "nap")
+		addSleep(day(d, 14, 0).Add(jitter(d+3, 15)), 70*time.Minute, "nap")
+		addSleep(day(d, 22, 30).Add(jitter(d+5, 40)), 8*time.Hour-time.Duration(d%3)*20*time.Minute, "night")
+	}
+	return c
+}
+
+// infantSlowGrowthCase 6个月婴儿，近两个月体重增长偏慢
+func infantSlowGrowthCase() *Case {
+	baby := syntheticBaby(900003, "糖糖", "female", "2026-04-01")
+	c := &Case{
+		Name:        "infant_slow_growth",
+		Description: "6个月婴儿，月度测量显示近两个月体重增长偏慢",
+		RecordedAt:  syntheticRecordedAt,
+		Task:        Task{Kind: TaskAnalysis, AnalysisType: entity.AIAnalysisTypeGrowth, StartDate: "2026-09-08", EndDate: "2026-10-07"},
+		Baby:        baby,
+	}
+
+	measurements := []struct {
+		at     time.Time
+		weight float64
+		height float64
+	}{
+		{time.Date(2026, 4, 2, 10, 0, 0, 0, syntheticZone), 3.2, 50.0},
+		{time.Date(2026, 5, 1, 10, 0, 0, 0, syntheticZone), 4.3, 54.2},
+		{time.Date(2026, 6, 1, 10, 0, 0, 0, syntheticZone), 5.3, 57.8},
+		{time.Date(2026, 7, 1, 10, 0, 0, 0, syntheticZone), 6.0, 60.6},
+		{time.Date(2026, 8, 1, 10, 0, 0, 0, syntheticZone), 6.4, 62.5},
+		{time.Date(2026, 9, 1, 10, 0, 0, 0, syntheticZone), 6.55, 63.9},
+		{time.Date(2026, 10, 1, 10, 0, 0, 0, syntheticZone), 6.7, 65.0},
+	}
+	id := baby.ID * 1000
+	for _, m := range measurements {
+		id++
+		c.Records.Growth = append(c.Records.Growth, &entity.GrowthRecord{
+			ID:     id,
+			BabyID: baby.ID,
+			Time:   m.at.UnixMilli(),
+			Weight: floatPtr(m.weight),
+			Height: floatPtr(m.height),
+		})
+	}
+	return c
+}
+
+// toddlerDailyTipsCase 1岁半幼儿，三餐加奶、规律午睡，生成每日建议
+func toddlerDailyTipsCase() *Case {
+	baby := syntheticBaby(900004, "乐乐", "male", "2025-03-20")
+	c := &Case{
+		Name:        "toddler_daily_tips",
+		Description: "1岁半幼儿，三餐两奶、规律午睡，生成当天的每日建议",
+		RecordedAt:  syntheticRecordedAt,
+		Task:        Task{Kind: TaskDailyTips, StartDate: "2026-10-07"},
+		Baby:        baby,
+	}
+
+	id := baby.ID * 1000
+	for d := 1; d <= 7; d++ {
+		for _, meal := range []struct {
+			hour, minute int
+			feedingType  string
+			amount       int64
+		}{
+			{7, 0, "bottle", 200},
+			{8, 0, "food", 0},
+			{12, 0, "food", 0},
+			{18, 0, "food", 0},
+			{20, 30, "bottle", 180},
+		} {
+			id++
+			c.Records.Feedings = append(c.Records.Feedings, &entity.FeedingRecord{
+				ID:          id,
+				BabyID:      baby.ID,
+				Time:        day(d, meal.hour, meal.minute).Add(jitter(d*5+meal.hour, 15)).UnixMilli(),
+				FeedingType: meal.feedingType,
+				Amount:      meal.amount,
+			})
+		}
+
+		id++
+		napStart := day(d, 12, 45).Add(jitter(d, 20))
+		c.Records.Sleeps = append(c.Records.Sleeps, &entity.SleepRecord{
+			ID: id, BabyID: baby.ID, StartTime: napStart.UnixMilli(),
+			EndTime: int64Ptr(napStart.Add(100 * time.Minute).UnixMilli()), Duration: intPtr(6000), Type: "nap",
+		})
+		id++
+		nightStart := day(d, 21, 0).Add(jitter(d+2, 20))
+		c.Records.Sleeps = append(c.Records.Sleeps, &entity.SleepRecord{
+			ID: id, BabyID: baby.ID, StartTime: nightStart.UnixMilli(),
+			EndTime: int64Ptr(nightStart.Add(10 * time.Hour).UnixMilli()), Duration: intPtr(36000), Type: "night",
+		})
+
+		for _, hour := range []int{8, 11, 15, 19, 22} {
+			id++
+			diaperType := "pee"
+			if hour == 8 {
+				diaperType = "both"
+			}
+			c.Records.Diapers = append(c.Records.Diapers, &entity.DiaperRecord{
+				ID: id, BabyID: baby.ID, Time: day(d, hour, 10).UnixMilli(), Type: diaperType,
+			})
+		}
+	}
+	c.Records.Growth = []*entity.GrowthRecord{
+		{ID: id + 1, BabyID: baby.ID, Time: time.Date(2026, 9, 20, 10, 0, 0, 0, syntheticZone).UnixMilli(), Weight: floatPtr(10.9), Height: floatPtr(81.5)},
+	}
+	return c
+}
@@ -0,0 +1,159 @@
+// Package golden 分析链的录制/回放评测工具
+//
+// 每个评测用例是一个合成宝宝: 宝宝信息、记录数据、分析任务，以及一次真实运行录制下的完整模型对话
+// (每轮模型调用的输入消息与输出消息，工具调用和工具结果都包含在其中)和当时的分析结果。
+// 回放时不访问网络: 用录制的模型输出驱动当前代码的分析链，工具仍在内存数据上实际执行，
+// 再与录制内容对比，报告提示词、工具和分析结果(结构与评分)的差异。
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 任务类型
+const (
+	TaskAnalysis  = "analysis"
+	TaskDailyTips = "daily_tips"
+)
+
+// dateLayout 任务日期格式
+const dateLayout = "2006-01-02"
+
+// Case 一个评测用例
+type Case struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	RecordedAt  time.Time   `json:"recorded_at"`        // 录制时间，工具计算月龄时作为当前时间
+	Provider    string      `json:"provider,omitempty"` // 录制时使用的提供商
+	Task        Task        `json:"task"`
+	Baby        entity.Baby `json:"baby"`
+	Records     Records     `json:"records"`
+
+	Tools      []ToolSpec `json:"tools,omitempty"`      // 录制时绑定的工具
+	Transcript []Turn     `json:"transcript,omitempty"` // 录制的模型对话
+	Expected   *Outcome   `json:"expected,omitempty"`   // 录制时的运行结果
+}
+
+// Task 分析任务
+type Task struct {
+	Kind         string                `json:"kind"` // analysis/daily_tips
+	AnalysisType entity.AIAnalysisType `json:"analysis_type,omitempty"`
+	StartDate    string                `json:"start_date"`
+	EndDate      string                `json:"end_date,omitempty"`
+	Locale       i18n.Locale           `json:"locale,omitempty"` // 为空时使用默认语言
+}
+
+// Records 合成宝宝的记录数据
+type Records struct {
+	Feedings     []*entity.FeedingRecord       `json:"feedings,omitempty"`
+	Sleeps       []*entity.SleepRecord         `json:"sleeps,omitempty"`
+	Diapers      []*entity.DiaperRecord        `json:"diapers,omitempty"`
+	Growth       []*entity.GrowthRecord        `json:"growth,omitempty"`
+	Vaccines     []*entity.BabyVaccineSchedule `json:"vaccines,omitempty"`
+	Crying       []*entity.CryingRecord        `json:"crying,omitempty"`
+	Illness      []*entity.IllnessEpisode      `json:"illness,omitempty"`
+	Temperatures []*entity.TemperatureRecord   `json:"temperatures,omitempty"`
+	Symptoms     []*entity.SymptomRecord       `json:"symptoms,omitempty"`
+	Milestones   []*entity.MilestoneRecord     `json:"milestones,omitempty"`
+}
+
+// ToolSpec 工具名称与描述
+type ToolSpec struct {
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+}
+
+// Turn 一轮模型调用
+type Turn struct {
+	Request  []*schema.Message `json:"request"`
+	Response *schema.Message   `json:"response"`
+}
+
+// Outcome 分析链的运行结果
+type Outcome struct {
+	Analysis *entity.AIAnalysisResult `json:"analysis,omitempty"`
+	Tips     []entity.DailyTip        `json:"tips,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// dates 解析任务日期
+func (t Task) dates() (time.Time, time.Time, error) {
+	start, err := time.Parse(dateLayout, t.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Wrap(errors.ParamError, "任务开始日期格式错误", err)
+	}
+	if t.EndDate == "" {
+		return start, start, nil
+	}
+	end, err := time.Parse(dateLayout, t.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Wrap(errors.ParamError, "任务结束日期格式错误", err)
+	}
+	return start, end, nil
+}
+
+// toolSpecs 提取工具名称与描述，按名称排序
+func toolSpecs(infos []*schema.ToolInfo) []ToolSpec {
+	specs := make([]ToolSpec, 0, len(infos))
+	for _, info := range infos {
+		specs = append(specs, ToolSpec{Name: info.Name, Desc: info.Desc})
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs
+}
+
+// LoadCases 读取目录下的全部用例(*.json)，按名称排序
+func LoadCases(dir string) ([]*Case, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "查找评测用例失败", err)
+	}
+	sort.Strings(paths)
+
+	cases := make([]*Case, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(errors.InternalError, "读取评测用例失败: "+path, err)
+		}
+		var c Case
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, errors.Wrap(errors.InternalError, "解析评测用例失败: "+path, err)
+		}
+		if c.Name == "" {
+			c.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		cases = append(cases, &c)
+	}
+	return cases, nil
+}
+
+// SaveCase 把用例写入目录，文件名为用例名
+func SaveCase(dir string, c *Case) error {
+	// 提示词中的 <、> 等字符保持原样，便于直接阅读和比较录制内容
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return errors.Wrap(errors.InternalError, "序列化评测用例失败", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(errors.InternalError, "创建评测用例目录失败", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, c.Name+".json"), buf.Bytes(), 0o644); err != nil {
+		return errors.Wrap(errors.InternalError, "写入评测用例失败", err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package golden
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// 差异类型
+const (
+	DiffPrompt     = "prompt"      // 系统或用户提示词变化
+	DiffTools      = "tools"       // 绑定的工具变化
+	DiffToolResult = "tool_result" // 相同数据上的工具输出变化
+	DiffTranscript = "transcript"  // 模型调用轮次或消息序列变化
+	DiffStructure  = "structure"   // 分析结果结构变化
+	DiffScore      = "score"       // 分析评分变化
+)
+
+// excerptRunes 差异摘录在第一个不同字符前后保留的字符数
+const excerptRunes = 40
+
+// Diff 一处差异
+type Diff struct {
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Recorded string `json:"recorded"`
+	Current  string `json:"current"`
+}
+
+// Report 一个用例的回放报告
+type Report struct {
+	Case       string   `json:"case"`
+	Diffs      []Diff   `json:"diffs"`
+	ScoreDelta float64  `json:"score_delta"` // 当前评分 - 录制评分
+	Outcome    *Outcome `json:"-"`
+}
+
+func (r *Report) add(diffs ...Diff) {
+	r.Diffs = append(r.Diffs, diffs...)
+}
+
+// ResultChanged 分析结果(结构或评分)是否与录制时不同
+// 提示词和工具的变化只说明录制的模型输出可能已过时，需要重新录制，不算结果变化
+func (r *Report) ResultChanged() bool {
+	for _, diff := range r.Diffs {
+		if diff.Kind == DiffStructure || diff.Kind == DiffScore {
+			return true
+		}
+	}
+	return false
+}
+
+// String 可读的报告文本
+func (r *Report) String() string {
+	var sb strings.Builder
+	if len(r.Diffs) == 0 {
+		fmt.Fprintf(&sb, "%s: 与录制一致\n", r.Case)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%s: %d 处差异", r.Case, len(r.Diffs))
+	if r.ScoreDelta != 0 {
+		fmt.Fprintf(&sb, "，评分变化 %+.1f", r.ScoreDelta)
+	}
+	sb.WriteString("\n")
+	for _, diff := range r.Diffs {
+		fmt.Fprintf(&sb, "  [%s] %s\n    录制: %s\n    当前: %s\n", diff.Kind, diff.Path, diff.Recorded, diff.Current)
+	}
+	return sb.String()
+}
+
+// compareTools 对比绑定的工具
+func compareTools(recorded, current []ToolSpec) []Diff {
+	recordedByName := make(map[string]string, len(recorded))
+	for _, tool := range recorded {
+		recordedByName[tool.Name] = tool.Desc
+	}
+
+	var diffs []Diff
+	for _, tool := range current {
+		desc, ok := recordedByName[tool.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, Diff{Kind: DiffTools, Path: "tools." + tool.Name, Recorded: "(无)", Current: tool.Desc})
+		case desc != tool.Desc:
+			r, c := excerpt(desc, tool.Desc)
+			diffs = append(diffs, Diff{Kind: DiffTools, Path: "tools." + tool.Name + ".desc", Recorded: r, Current: c})
+		}
+		delete(recordedByName, tool.Name)
+	}
+	for _, tool := range recorded {
+		if _, ok := recordedByName[tool.Name]; ok {
+			diffs = append(diffs, Diff{Kind: DiffTools, Path: "tools." + tool.Name, Recorded: tool.Desc, Current: "(无)"})
+		}
+	}
+	return diffs
+}
+
+// compareRequest 对比一轮模型调用的输入消息
+// 助手消息来自录制的输出，不同说明分析链组装消息的方式变了
+func compareRequest(turn int, recorded, current []*schema.Message) []Diff {
+	var diffs []Diff
+	if len(recorded) != len(current) {
+		diffs = append(diffs, Diff{
+			Kind:     DiffTranscript,
+			Path:     fmt.Sprintf("transcript[%d].request", turn),
+			Recorded: fmt.Sprintf("%d 条消息", len(recorded)),
+			Current:  fmt.Sprintf("%d 条消息", len(current)),
+		})
+	}
+
+	for i := 0; i < len(recorded) && i < len(current); i++ {
+		path := fmt.Sprintf("transcript[%d].request[%d]", turn, i)
+		want, got := recorded[i], current[i]
+		if want.Role != got.Role {
+			diffs = append(diffs, Diff{Kind: DiffTranscript, Path: path + ".role", Recorded: string(want.Role), Current: string(got.Role)})
+			continue
+		}
+		if want.Content == got.Content {
+			continue
+		}
+
+		kind := DiffTranscript
+		switch want.Role {
+		case schema.System, schema.User:
+			kind = DiffPrompt
+		case schema.Tool:
+			kind = DiffToolResult
+			path += "(" + want.ToolCallID + ")"
+		}
+		r, c := excerpt(want.Content, got.Content)
+		diffs = append(diffs, Diff{Kind: kind, Path: path, Recorded: r, Current: c})
+	}
+	return diffs
+}
+
+// compareOutcome 对比运行结果的结构与评分
+func (r *Report) compareOutcome(recorded, current *Outcome) {
+	if recorded == nil {
+		r.add(Diff{Kind: DiffStructure, Path: "expected", Recorded: "(未录制)", Current: "(有结果)"})
+		return
+	}
+	if recorded.Error != current.Error {
+		r.add(Diff{Kind: DiffStructure, Path: "error", Recorded: orNone(recorded.Error), Current: orNone(current.Error)})
+	}
+	r.compareAnalysis(recorded.Analysis, current.Analysis)
+	r.compareList("tips", tipKeys(recorded.Tips), tipKeys(current.Tips))
+}
+
+func (r *Report) compareAnalysis(recorded, current *entity.AIAnalysisResult) {
+	if recorded == nil || current == nil {
+		if (recorded == nil) != (current == nil) {
+			r.add(Diff{Kind: DiffStructure, Path: "analysis", Recorded: presence(recorded != nil), Current: presence(current != nil)})
+		}
+		return
+	}
+
+	if math.Abs(recorded.Score-current.Score) > 1e-9 {
+		r.ScoreDelta = current.Score - recorded.Score
+		r.add(Diff{Kind: DiffScore, Path: "analysis.score", Recorded: fmt.Sprintf("%g", recorded.Score), Current: fmt.Sprintf("%g", current.Score)})
+	}
+
+	var recordedInsights, currentInsights []string
+	for _, insight := range recorded.Insights {
+		recordedInsights = append(recordedInsights, insight.Type+"/"+insight.Priority+"/"+insight.Title)
+	}
+	for _, insight := range current.Insights {
+		currentInsights = append(currentInsights, insight.Type+"/"+insight.Priority+"/"+insight.Title)
+	}
+	r.compareList("analysis.insights", recordedInsights, currentInsights)
+
+	var recordedAlerts, currentAlerts []string
+	for _, alert := range recorded.Alerts {
+		recordedAlerts = append(recordedAlerts, alert.Level+"/"+alert.Type+"/"+alert.Title)
+	}
+	for _, alert := range current.Alerts {
+		currentAlerts = append(currentAlerts, alert.Level+"/"+alert.Type+"/"+alert.Title)
+	}
+	r.compareList("analysis.alerts", recordedAlerts, currentAlerts)
+
+	var recordedPatterns, currentPatterns []string
+	for _, pattern := range recorded.Patterns {
+		recordedPatterns = append(recordedPatterns, pattern.PatternType)
+	}
+	for _, pattern := range current.Patterns {
+		currentPatterns = append(currentPatterns, pattern.PatternType)
+	}
+	r.compareList("analysis.patterns", recordedPatterns, currentPatterns)
+
+	var recordedPredictions, currentPredictions []string
+	for _, prediction := range recorded.Predictions {
+		recordedPredictions = append(recordedPredictions, prediction.PredictionType)
+	}
+	for _, prediction := range current.Predictions {
+		currentPredictions = append(currentPredictions, prediction.PredictionType)
+	}
+	r.compareList("analysis.predictions", recordedPredictions, currentPredictions)
+
+	if (recorded.UserFriendly == nil) != (current.UserFriendly == nil) {
+		r.add(Diff{
+			Kind:     DiffStructure,
+			Path:     "analysis.user_friendly",
+			Recorded: presence(recorded.UserFriendly != nil),
+			Current:  presence(current.UserFriendly != nil),
+		})
+	}
+}
+
+// compareList 对比有序列表，长度不同时整体报告，否则逐项报告
+func (r *Report) compareList(path string, recorded, current []string) {
+	if len(recorded) != len(current) {
+		r.add(Diff{
+			Kind:     DiffStructure,
+			Path:     path,
+			Recorded: fmt.Sprintf("%d 项 %s", len(recorded), strings.Join(recorded, "; ")),
+			Current:  fmt.Sprintf("%d 项 %s", len(current), strings.Join(current, "; ")),
+		})
+		return
+	}
+	for i := range recorded {
+		if recorded[i] != current[i] {
+			r.add(Diff{Kind: DiffStructure, Path: fmt.Sprintf("%s[%d]", path, i), Recorded: recorded[i], Current: current[i]})
+		}
+	}
+}
+
+// tipKeys 每日建议的结构摘要
+func tipKeys(tips []entity.DailyTip) []string {
+	keys := make([]string, 0, len(tips))
+	for _, tip := range tips {
+		keys = append(keys, tip.Type+"/"+tip.Priority+"/"+tip.Title)
+	}
+	return keys
+}
+
+// excerpt 截取两段文本第一个不同字符附近的内容
+func excerpt(a, b string) (string, string) {
+	ra, rb := []rune(a), []rune(b)
+	i := 0
+	for i < len(ra) && i < len(rb) && ra[i] == rb[i] {
+		i++
+	}
+	return window(ra, i), window(rb, i)
+}
+
+func window(runes []rune, at int) string {
+	start, end := at-excerptRunes, at+excerptRunes
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+	if start > end {
+		start = end
+	}
+	return prefix + strings.ReplaceAll(string(runes[start:end]), "\n", "⏎") + suffix
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(无)"
+	}
+	return s
+}
+
+func presence(ok bool) string {
+	if ok {
+		return "(有)"
+	}
+	return "(无)"
+}
@@ -0,0 +1,82 @@
+package golden
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestGoldenReplay 回放录制的用例，分析结果的结构或评分变化视为回归
+// 提示词和工具的变化只输出报告，提示需要重新录制(go run ./cmd/golden -record)
+func TestGoldenReplay(t *testing.T) {
+	cases, err := LoadCases("testdata")
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			report, err := Replay(context.Background(), c, zap.NewNop())
+			require.NoError(t, err)
+			if len(report.Diffs) > 0 {
+				t.Log(report.String())
+			}
+			assert.False(t, report.ResultChanged(), "分析结果与录制不一致")
+		})
+	}
+}
+
+func TestReplayReportsDiffs(t *testing.T) {
+	cases, err := LoadCases("testdata")
+	require.NoError(t, err)
+	var c *Case
+	for _, candidate := range cases {
+		if candidate.Name == "newborn_feeding" {
+			c = candidate
+		}
+	}
+	require.NotNil(t, c)
+
+	// 模拟录制后提示词、工具输出和评分都发生了变化
+	first := c.Transcript[0].Request
+	first[0].Content += "\n旧版提示词"
+	last := c.Transcript[len(c.Transcript)-1]
+	for _, msg := range last.Request {
+		if msg.ToolCallID == "call_feeding_data" {
+			msg.Content = strings.Replace(msg.Content, `"count":`, `"total":`, 1)
+		}
+	}
+	c.Expected.Analysis.Score += 5
+	c.Tools = c.Tools[1:]
+
+	report, err := Replay(context.Background(), c, zap.NewNop())
+	require.NoError(t, err)
+
+	kinds := make(map[string]bool)
+	for _, diff := range report.Diffs {
+		kinds[diff.Kind] = true
+	}
+	assert.True(t, kinds[DiffPrompt])
+	assert.True(t, kinds[DiffToolResult])
+	assert.True(t, kinds[DiffTools])
+	assert.True(t, kinds[DiffScore])
+	assert.Equal(t, float64(-5), report.ScoreDelta)
+	assert.True(t, report.ResultChanged())
+}
+
+func TestSyntheticCasesMatchRecordedData(t *testing.T) {
+	cases, err := LoadCases("testdata")
+	require.NoError(t, err)
+
+	for _, recorded := range cases {
+		synthetic, ok := FindSyntheticCase(recorded.Name)
+		require.True(t, ok, recorded.Name)
+		assert.Equal(t, synthetic.Task, recorded.Task, recorded.Name)
+		assert.Equal(t, len(synthetic.Records.Feedings), len(recorded.Records.Feedings), recorded.Name)
+		assert.Equal(t, len(synthetic.Records.Sleeps), len(recorded.Records.Sleeps), recorded.Name)
+		assert.Equal(t, len(synthetic.Records.Growth), len(recorded.Records.Growth), recorded.Name)
+	}
+}
@@ -0,0 +1,195 @@
+package golden
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/guardrail"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+// Run 用给定模型在用例数据上运行分析链
+// 分析链本身的失败记录在 Outcome.Error 中，返回的 error 只表示用例无法运行
+func Run(ctx context.Context, c *Case, chatModel model.ToolCallingChatModel, logger *zap.Logger) (*Outcome, error) {
+	startDate, endDate, err := c.Task.dates()
+	if err != nil {
+		return nil, err
+	}
+
+	store := NewStore(c)
+	dataTools := tools.NewDataQueryTools(store.Feedings, store.Sleeps, store.Diapers, store.Growth, store.Vaccines, store.Crying, store.Illness, store.Temperatures, store.Symptoms, store.Milestones, store.Babies, nil, logger)
+	recordedAt := c.RecordedAt
+	dataTools.SetClock(func() time.Time { return recordedAt })
+	guard := guardrail.NewGuard(store.Babies, store.Diapers, store.Growth, store.HealthAlerts, logger)
+	guard.SetClock(func() time.Time { return recordedAt })
+	builder := chain.NewAnalysisChainBuilder(chatModel, dataTools, nil, guard, logger)
+
+	outcome := &Outcome{}
+	switch c.Task.Kind {
+	case TaskAnalysis:
+		outcome.Analysis, err = builder.Analyze(ctx, &entity.AIAnalysis{
+			BabyID:       c.Baby.ID,
+			AnalysisType: c.Task.AnalysisType,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			Locale:       string(i18n.Normalize(string(c.Task.Locale))),
+		})
+	case TaskDailyTips:
+		baby := c.Baby
+		var tips *entity.DailyTips
+		if tips, err = builder.GenerateDailyTips(ctx, &baby, startDate, i18n.Normalize(string(c.Task.Locale))); err == nil {
+			outcome.Tips = tips.Tips
+		}
+	default:
+		return nil, errors.New(errors.ParamError, "未知的任务类型: "+c.Task.Kind)
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+	return outcome, nil
+}
+
+// Record 用真实模型运行用例，录制模型对话和运行结果
+func Record(ctx context.Context, c *Case, chatModel model.ToolCallingChatModel, logger *zap.Logger) error {
+	log := &transcriptLog{}
+	outcome, err := Run(ctx, c, &recordingChatModel{inner: chatModel, log: log}, logger)
+	if err != nil {
+		return err
+	}
+	c.Tools = log.tools
+	c.Transcript = log.turns
+	c.Expected = outcome
+	return nil
+}
+
+// Replay 用录制的模型输出重新运行用例，与录制内容对比
+func Replay(ctx context.Context, c *Case, logger *zap.Logger) (*Report, error) {
+	if len(c.Transcript) == 0 {
+		return nil, errors.New(errors.ParamError, "用例 "+c.Name+" 没有录制的对话，请先录制")
+	}
+
+	state := &replayState{turns: c.Transcript}
+	outcome, err := Run(ctx, c, &replayChatModel{state: state}, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Case: c.Name, Outcome: outcome}
+	report.add(compareTools(c.Tools, state.tools)...)
+	report.add(state.diffs...)
+	if unused := len(state.turns) - state.next; unused > 0 {
+		report.add(Diff{
+			Kind:     DiffTranscript,
+			Path:     "transcript",
+			Recorded: fmt.Sprintf("%d 轮", len(state.turns)),
+			Current:  fmt.Sprintf("%d 轮", state.next),
+		})
+	}
+	report.compareOutcome(c.Expected, outcome)
+	return report, nil
+}
+
+// transcriptLog 录制中的对话
+type transcriptLog struct {
+	mu    sync.Mutex
+	tools []ToolSpec
+	turns []Turn
+}
+
+// recordingChatModel 记录每轮调用输入输出的模型装饰器
+type recordingChatModel struct {
+	inner model.ToolCallingChatModel
+	log   *transcriptLog
+}
+
+func (m *recordingChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	response, err := m.inner.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	m.log.mu.Lock()
+	defer m.log.mu.Unlock()
+	m.log.turns = append(m.log.turns, Turn{Request: append([]*schema.Message(nil), input...), Response: response})
+	return response, nil
+}
+
+// Stream 录制时统一使用一次性生成，保证每轮输出完整
+func (m *recordingChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	response, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{response}), nil
+}
+
+func (m *recordingChatModel) WithTools(infos []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	bound, err := m.inner.WithTools(infos)
+	if err != nil {
+		return nil, err
+	}
+	m.log.mu.Lock()
+	m.log.tools = toolSpecs(infos)
+	m.log.mu.Unlock()
+	return &recordingChatModel{inner: bound, log: m.log}, nil
+}
+
+// replayState 回放进度与过程中发现的差异
+type replayState struct {
+	mu    sync.Mutex
+	turns []Turn
+	next  int
+	tools []ToolSpec
+	diffs []Diff
+}
+
+// replayChatModel 按顺序返回录制的模型输出，同时对比本次的输入与录制时的输入
+type replayChatModel struct {
+	state *replayState
+}
+
+func (m *replayChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	s := m.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= len(s.turns) {
+		s.diffs = append(s.diffs, Diff{
+			Kind:     DiffTranscript,
+			Path:     fmt.Sprintf("transcript[%d]", s.next),
+			Recorded: fmt.Sprintf("%d 轮", len(s.turns)),
+			Current:  "需要更多轮模型调用",
+		})
+		s.next++
+		return nil, errors.New(errors.InternalError, "录制的对话已用完")
+	}
+
+	turn := s.turns[s.next]
+	s.diffs = append(s.diffs, compareRequest(s.next, turn.Request, input)...)
+	s.next++
+	response := *turn.Response
+	return &response, nil
+}
+
+func (m *replayChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	response, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{response}), nil
+}
+
+func (m *replayChatModel) WithTools(infos []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	m.state.mu.Lock()
+	m.state.tools = toolSpecs(infos)
+	m.state.mu.Unlock()
+	return m, nil
+}
@@ -0,0 +1,268 @@
+package golden
+
+import (
+	"context"
+	"sort"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// Store 用例数据的内存仓储，只实现分析工具和离线分析引擎用到的查询
+// 嵌入的仓储接口为 nil，调用未实现的方法会直接 panic，便于发现评测覆盖不到的新依赖
+type Store struct {
+	Babies       babyStore
+	Feedings     feedingStore
+	Sleeps       sleepStore
+	Diapers      diaperStore
+	Growth       growthStore
+	Vaccines     vaccineStore
+	Crying       cryingStore
+	Illness      illnessStore
+	Temperatures temperatureStore
+	Symptoms     symptomStore
+	Milestones   milestoneStore
+	HealthAlerts healthAlertStore
+	Usage        usageStore
+}
+
+// NewStore 用用例的宝宝和记录创建内存仓储
+func NewStore(c *Case) *Store {
+	baby := c.Baby
+	return &Store{
+		Babies:       babyStore{baby: &baby},
+		Feedings:     feedingStore{records: c.Records.Feedings},
+		Sleeps:       sleepStore{records: c.Records.Sleeps},
+		Diapers:      diaperStore{records: c.Records.Diapers},
+		Growth:       growthStore{records: c.Records.Growth},
+		Vaccines:     vaccineStore{schedules: c.Records.Vaccines},
+		Crying:       cryingStore{records: c.Records.Crying},
+		Illness:      illnessStore{episodes: c.Records.Illness},
+		Temperatures: temperatureStore{records: c.Records.Temperatures},
+		Symptoms:     symptomStore{records: c.Records.Symptoms},
+		Milestones:   milestoneStore{records: c.Records.Milestones},
+	}
+}
+
+// inRange 与数据库实现一致: 时间为 0 表示不限制，区间两端都包含
+func inRange(t, startTime, endTime int64) bool {
+	return (startTime <= 0 || t >= startTime) && (endTime <= 0 || t <= endTime)
+}
+
+// page 分页，page 从1开始
+func page[T any](items []T, pageNum, pageSize int) []T {
+	if pageNum < 1 || pageSize <= 0 {
+		return items
+	}
+	offset := (pageNum - 1) * pageSize
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := offset + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+type babyStore struct {
+	repository.BabyRepository
+	baby *entity.Baby
+}
+
+func (s babyStore) FindByID(ctx context.Context, babyID int64) (*entity.Baby, error) {
+	if s.baby == nil || s.baby.ID != babyID {
+		return nil, errors.New(errors.NotFound, "baby not found")
+	}
+	baby := *s.baby
+	return &baby, nil
+}
+
+type feedingStore struct {
+	repository.FeedingRecordRepository
+	records []*entity.FeedingRecord
+}
+
+func (s feedingStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.FeedingRecord, int64, error) {
+	var matched []*entity.FeedingRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.Time, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time > matched[j].Time })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type sleepStore struct {
+	repository.SleepRecordRepository
+	records []*entity.SleepRecord
+}
+
+func (s sleepStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.SleepRecord, int64, error) {
+	var matched []*entity.SleepRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.StartTime, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].StartTime > matched[j].StartTime })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type diaperStore struct {
+	repository.DiaperRecordRepository
+	records []*entity.DiaperRecord
+}
+
+func (s diaperStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.DiaperRecord, int64, error) {
+	var matched []*entity.DiaperRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.Time, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time > matched[j].Time })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type growthStore struct {
+	repository.GrowthRecordRepository
+	records []*entity.GrowthRecord
+}
+
+func (s growthStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.GrowthRecord, int64, error) {
+	var matched []*entity.GrowthRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.Time, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time > matched[j].Time })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type vaccineStore struct {
+	repository.BabyVaccineScheduleRepository
+	schedules []*entity.BabyVaccineSchedule
+}
+
+func (s vaccineStore) FindByBabyID(ctx context.Context, babyID int64, pageNum, pageSize int) ([]*entity.BabyVaccineSchedule, error) {
+	var matched []*entity.BabyVaccineSchedule
+	for _, schedule := range s.schedules {
+		if schedule.BabyID == babyID {
+			matched = append(matched, schedule)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].AgeInMonths != matched[j].AgeInMonths {
+			return matched[i].AgeInMonths < matched[j].AgeInMonths
+		}
+		return matched[i].DoseNumber < matched[j].DoseNumber
+	})
+	return page(matched, pageNum, pageSize), nil
+}
+
+type cryingStore struct {
+	repository.CryingRecordRepository
+	records []*entity.CryingRecord
+}
+
+func (s cryingStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.CryingRecord, int64, error) {
+	var matched []*entity.CryingRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.StartTime, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].StartTime > matched[j].StartTime })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type illnessStore struct {
+	repository.IllnessEpisodeRepository
+	episodes []*entity.IllnessEpisode
+}
+
+// FindByBabyID 与数据库实现一致，按区间交集过滤
+func (s illnessStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.IllnessEpisode, int64, error) {
+	var matched []*entity.IllnessEpisode
+	for _, episode := range s.episodes {
+		if episode.BabyID != babyID || (endTime > 0 && episode.StartTime > endTime) {
+			continue
+		}
+		if startTime > 0 && episode.EndTime != nil && *episode.EndTime < startTime {
+			continue
+		}
+		matched = append(matched, episode)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].StartTime > matched[j].StartTime })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type temperatureStore struct {
+	repository.TemperatureRecordRepository
+	records []*entity.TemperatureRecord
+}
+
+func (s temperatureStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.TemperatureRecord, int64, error) {
+	var matched []*entity.TemperatureRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.Time, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time > matched[j].Time })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type symptomStore struct {
+	repository.SymptomRecordRepository
+	records []*entity.SymptomRecord
+}
+
+func (s symptomStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.SymptomRecord, int64, error) {
+	var matched []*entity.SymptomRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.Time, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time > matched[j].Time })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+type milestoneStore struct {
+	repository.MilestoneRecordRepository
+	records []*entity.MilestoneRecord
+}
+
+func (s milestoneStore) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, pageNum, pageSize int) ([]*entity.MilestoneRecord, int64, error) {
+	var matched []*entity.MilestoneRecord
+	for _, record := range s.records {
+		if record.BabyID == babyID && inRange(record.AchievedAt, startTime, endTime) {
+			matched = append(matched, record)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].AchievedAt > matched[j].AchievedAt })
+	return page(matched, pageNum, pageSize), int64(len(matched)), nil
+}
+
+// healthAlertStore 合成宝宝没有规则筛查产生的健康提醒
+type healthAlertStore struct {
+	repository.HealthAlertRepository
+}
+
+func (healthAlertStore) FindByBabyID(ctx context.Context, babyID int64, since int64, limit int) ([]*entity.HealthAlert, error) {
+	return []*entity.HealthAlert{}, nil
+}
+
+// usageStore 录制时不记录模型用量
+type usageStore struct {
+	repository.AIUsageRepository
+}
+
+func (usageStore) Create(ctx context.Context, record *entity.AIUsageRecord) error {
+	return nil
+}
@@ -0,0 +1,177 @@
+package guardrail
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 不安全内容类别
+const (
+	CategoryDosing         = "dosing"          // 药物及剂量建议
+	CategoryDiscourageCare = "discourage_care" // 劝阻就医
+	CategoryUnsafePractice = "unsafe_practice" // 不适合婴幼儿的做法
+	CategoryDiagnosis      = "diagnosis"       // 下诊断结论
+)
+
+// category 一类不安全内容：匹配的句子被删除，并在该字段末尾补充一次替代说明
+type category struct {
+	name  string
+	match func(sentence string, ageDays int) bool
+}
+
+// replacement 替代说明(消息目录 guardrail.replace.<类别>)
+func (c category) replacement(locale i18n.Locale) string {
+	return locale.T("guardrail.replace." + c.name)
+}
+
+var (
+	// drugPattern 常见儿童药物(不含维生素D、铁剂等营养补充剂)
+	drugPattern = regexp.MustCompile(`(?i)布洛芬|美林|对乙酰氨基酚|扑热息痛|泰诺林|退烧药|退热药|抗生素|阿莫西林|头孢|阿奇霉素|止泻药|止咳药|感冒药|ibuprofen|paracetamol|acetaminophen|amoxicillin`)
+	// dosePattern 剂量或给药用语
+	dosePattern = regexp.MustCompile(`(?i)\d+(\.\d+)?\s*(mg|ml|毫克|毫升|片|粒|滴|袋|支)|剂量|每次|每隔|一天\d+次|每天\d+次|服用|吃|喂|给宝宝用|口服|\b(dose|dosage|every \d+ hours|give|take)\b`)
+	// deferToDoctorPattern 已明确交由医生决定的用药表述
+	deferToDoctorPattern = regexp.MustCompile(`(?i)遵医嘱|医生指导|咨询医生|医生建议|由医生|请医生|(doctor|pediatrician)('s)? (advice|instructions|directs|recommends)|(ask|consult|check with) (a|your) (doctor|pediatrician)`)
+	// discourageCarePattern 劝阻或推迟就医
+	discourageCarePattern = regexp.MustCompile(`(?i)(不需要|无需|不用|不必|没必要)(去)?(就医|就诊|看医生|去医院|看急诊|咨询医生)|(no need|not necessary|unnecessary|don't need) to (see|visit|call|consult) (a |the )?(doctor|pediatrician|hospital|emergency)`)
+	// unsafePractices 不适合婴幼儿的做法，ageLimit 为适用的最大日龄(0 表示任何年龄)
+	unsafePractices = []struct {
+		pattern  *regexp.Regexp
+		ageLimit int
+	}{
+		{regexp.MustCompile(`(?i)蜂蜜|\bhoney\b`), 365},
+		{regexp.MustCompile(`(?i)趴睡|俯卧睡|侧睡|(on (the|their|his|her) (stomach|tummy|side)) to sleep|stomach sleeping|side sleeping`), 365},
+		{regexp.MustCompile(`(?i)(酒精|白酒)(擦|搓)|捂汗|阿司匹林|aspirin|alcohol (rub|bath|sponge)`), 0},
+	}
+	// negationPattern 否定、劝阻类表述，如"1岁前不要喂蜂蜜"本身是安全建议
+	negationPattern = regexp.MustCompile(`(?i)不要|不能|不宜|不应|避免|禁止|切勿|勿|不可|不建议|\b(do not|don't|never|avoid|should not|shouldn't|must not|not recommended)\b`)
+	// diagnosisPattern 断言性诊断
+	diagnosisPattern = regexp.MustCompile(`(?i)确诊|诊断为|可以确定.{0,8}(患有|得了)|肯定是.{0,8}(病|症|炎)|\b(is diagnosed with|definitely has|certainly has)\b`)
+)
+
+var categories = []category{
+	{
+		name: CategoryDosing,
+		match: func(sentence string, _ int) bool {
+			return drugPattern.MatchString(sentence) && dosePattern.MatchString(sentence) &&
+				!negationPattern.MatchString(sentence) && !deferToDoctorPattern.MatchString(sentence)
+		},
+	},
+	{
+		name: CategoryDiscourageCare,
+		match: func(sentence string, _ int) bool {
+			return discourageCarePattern.MatchString(sentence)
+		},
+	},
+	{
+		name: CategoryUnsafePractice,
+		match: func(sentence string, ageDays int) bool {
+			if negationPattern.MatchString(sentence) {
+				return false
+			}
+			for _, practice := range unsafePractices {
+				if practice.ageLimit > 0 && ageDays >= practice.ageLimit {
+					continue
+				}
+				if practice.pattern.MatchString(sentence) {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name: CategoryDiagnosis,
+		match: func(sentence string, _ int) bool {
+			return diagnosisPattern.MatchString(sentence)
+		},
+	},
+}
+
+// removal 一次删除的内容
+type removal struct {
+	category string
+	sentence string
+}
+
+// filterText 删除文本中命中不安全类别的句子，并为每个命中的类别补充一次替代说明(使用 locale 语言)
+// ageDays 为宝宝日龄，未知时传 0(按最严格的规则处理)
+func filterText(text string, ageDays int, locale i18n.Locale) (string, []removal) {
+	if text == "" {
+		return text, nil
+	}
+
+	var kept strings.Builder
+	var removed []removal
+	replaced := make(map[string]bool)
+	var replacements []string
+	for _, sentence := range splitSentences(text) {
+		hit := ""
+		for _, c := range categories {
+			if c.match(sentence, ageDays) {
+				hit = c.name
+				if !replaced[c.name] {
+					replaced[c.name] = true
+					replacements = append(replacements, c.replacement(locale))
+				}
+				break
+			}
+		}
+		if hit == "" {
+			kept.WriteString(sentence)
+			continue
+		}
+		removed = append(removed, removal{category: hit, sentence: strings.TrimSpace(sentence)})
+	}
+	if len(removed) == 0 {
+		return text, nil
+	}
+
+	result := strings.TrimSpace(kept.String())
+	for _, replacement := range replacements {
+		result = appendSentence(result, replacement)
+	}
+	return result, removed
+}
+
+// appendSentence 在文本末尾追加一句，英文句子之间补空格
+func appendSentence(text, sentence string) string {
+	if text == "" || sentence == "" {
+		return text + sentence
+	}
+	last, _ := utf8.DecodeLastRuneInString(text)
+	first, _ := utf8.DecodeRuneInString(sentence)
+	if last < utf8.RuneSelf && first < utf8.RuneSelf && last != ' ' {
+		return text + " " + sentence
+	}
+	return text + sentence
+}
+
+// splitSentences 按中英文句末标点和换行切分，保留标点
+// 英文句号只在后面跟空白时切分，避免拆开 3.5kg 这样的小数
+func splitSentences(text string) []string {
+	var sentences []string
+	var current strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		current.WriteRune(r)
+		switch r {
+		case '.':
+			if i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+				continue
+			}
+		case '。', '！', '？', '；', '!', '?', ';', '\n':
+		default:
+			continue
+		}
+		sentences = append(sentences, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		sentences = append(sentences, current.String())
+	}
+	return sentences
+}
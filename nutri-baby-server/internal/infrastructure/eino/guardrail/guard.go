@@ -0,0 +1,387 @@
+// Package guardrail AI输出的医疗安全护栏
+//
+// 在分析结果和每日建议解析完成后执行：
+//   - 基于记录数据的确定性红旗规则，命中时总是输出 critical 级别警告，不依赖模型是否发现
+//   - 删除模型文本中的用药剂量、劝阻就医、不适合婴幼儿的做法和诊断结论
+//   - 附加免责声明，存在紧急情况时附加就医提示
+//
+// 追加和替换的文本使用 context 中的语言(i18n.WithLocale)，默认中文。
+// 每次干预都会写日志，并随结果保存为干预记录便于排查。
+package guardrail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxRecords 单次评估每类记录的最大读取数量
+	maxRecords = 2000
+	// dailyTipsLookback 每日建议评估红旗规则的回看时长
+	dailyTipsLookback = 48 * time.Hour
+	// maxInterventionRunes 干预记录中保留的原文长度
+	maxInterventionRunes = 200
+	// maxDailyTips 每日建议条数上限(与 daily_tips schema 一致)
+	maxDailyTips = 5
+)
+
+// DisclaimerFor 指定语言的免责声明，emergency 为 true 时前面附加就医提示
+func DisclaimerFor(locale i18n.Locale, emergency bool) string {
+	if emergency {
+		return appendSentence(locale.T("guardrail.emergency_disclaimer"), locale.T("guardrail.disclaimer"))
+	}
+	return locale.T("guardrail.disclaimer")
+}
+
+// 干预类型
+const (
+	KindRedFlag            = "red_flag"            // 红旗规则追加的紧急警告
+	KindContentFilter      = "content_filter"      // 删除的不安全内容
+	KindCriticalEscalation = "critical_escalation" // 为模型给出的紧急警告补充就医建议
+)
+
+// seekCarePattern 紧急警告的建议中应包含的就医表述
+var seekCarePattern = regexp.MustCompile(`(?i)就医|医生|医院|急诊|120|doctor|hospital|emergency|pediatrician`)
+
+// Intervention 一次干预
+type Intervention struct {
+	Kind     string `json:"kind"`
+	Code     string `json:"code"`            // 规则编码或内容类别
+	Field    string `json:"field,omitempty"` // 被修改的字段
+	Original string `json:"original,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Report 一次输出的干预记录
+type Report struct {
+	Interventions []Intervention `json:"interventions"`
+}
+
+// String 序列化为JSON，没有干预时为空串
+func (r *Report) String() string {
+	if r == nil || len(r.Interventions) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+func (r *Report) add(intervention Intervention) {
+	if runes := []rune(intervention.Original); len(runes) > maxInterventionRunes {
+		intervention.Original = string(runes[:maxInterventionRunes]) + "..."
+	}
+	r.Interventions = append(r.Interventions, intervention)
+}
+
+// Guard 医疗安全护栏
+type Guard struct {
+	babyRepo        repository.BabyRepository
+	diaperRepo      repository.DiaperRecordRepository
+	growthRepo      repository.GrowthRecordRepository
+	healthAlertRepo repository.HealthAlertRepository
+	rules           []Rule
+	logger          *zap.Logger
+	now             func() time.Time
+}
+
+// NewGuard 创建医疗安全护栏，使用内置红旗规则
+func NewGuard(
+	babyRepo repository.BabyRepository,
+	diaperRepo repository.DiaperRecordRepository,
+	growthRepo repository.GrowthRecordRepository,
+	healthAlertRepo repository.HealthAlertRepository,
+	logger *zap.Logger,
+) *Guard {
+	return &Guard{
+		babyRepo:        babyRepo,
+		diaperRepo:      diaperRepo,
+		growthRepo:      growthRepo,
+		healthAlertRepo: healthAlertRepo,
+		rules:           DefaultRules(),
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// SetClock 替换当前时间来源(用于回放评测等需要固定时间的场景)
+func (g *Guard) SetClock(now func() time.Time) {
+	g.now = now
+}
+
+// ApplyAnalysis 对分析结果执行护栏，直接修改 result 并返回干预记录
+// 红旗规则读取数据失败时只记录日志，内容过滤和免责声明照常执行
+func (g *Guard) ApplyAnalysis(ctx context.Context, result *entity.AIAnalysisResult, startDate, endDate time.Time) *Report {
+	if g == nil || result == nil {
+		return nil
+	}
+	report := &Report{}
+	locale := i18n.FromContext(ctx)
+	logFields := []zap.Field{zap.Int64("baby_id", result.BabyID), zap.String("analysis_type", string(result.AnalysisType))}
+
+	snapshot, err := g.snapshot(ctx, result.BabyID, func(loc *time.Location, now time.Time) (time.Time, time.Time) {
+		start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+		end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+		return start, end
+	})
+	if err != nil {
+		g.logger.Warn("红旗规则读取数据失败", append(logFields, zap.Error(err))...)
+	}
+	ageDays := snapshot.ageOrZero()
+
+	// 1. 红旗规则：总是追加为 critical 警告，排在模型警告之前
+	var redFlags []entity.AIAlert
+	for _, finding := range g.evaluate(snapshot) {
+		redFlags = append(redFlags, entity.AIAlert{
+			Level:       entity.HealthAlertLevelCritical,
+			Type:        finding.Type,
+			Title:       finding.Title,
+			Description: finding.Description,
+			Suggestion:  finding.Suggestion,
+			Timestamp:   finding.At,
+		})
+		report.add(Intervention{Kind: KindRedFlag, Code: finding.Rule, Field: "alerts", Detail: finding.Title})
+	}
+
+	// 2. 模型给出的紧急警告必须包含就医建议
+	for i := range result.Alerts {
+		alert := &result.Alerts[i]
+		if alert.Level == entity.HealthAlertLevelCritical && !seekCarePattern.MatchString(alert.Suggestion) {
+			alert.Suggestion = appendSentence(alert.Suggestion, locale.T("guardrail.seek_care"))
+			report.add(Intervention{Kind: KindCriticalEscalation, Code: alert.Type, Field: fmt.Sprintf("alerts[%d].suggestion", len(redFlags)+i), Detail: alert.Title})
+		}
+	}
+	result.Alerts = append(redFlags, result.Alerts...)
+
+	// 3. 过滤模型文本
+	for _, field := range analysisTextFields(result) {
+		g.filterField(report, field, ageDays, locale)
+	}
+
+	// 4. 免责声明
+	emergency := false
+	for _, alert := range result.Alerts {
+		if alert.Level == entity.HealthAlertLevelCritical {
+			emergency = true
+			break
+		}
+	}
+	result.Disclaimer = DisclaimerFor(locale, emergency)
+
+	g.log(report, logFields)
+	return report
+}
+
+// ApplyDailyTips 对每日建议执行护栏，直接修改 tips 并返回干预记录
+// 红旗规则命中时以高优先级健康建议置顶，超出条数上限时去掉末尾的建议
+func (g *Guard) ApplyDailyTips(ctx context.Context, tips *entity.DailyTips, babyID int64, date time.Time) *Report {
+	if g == nil || tips == nil {
+		return nil
+	}
+	report := &Report{}
+	locale := i18n.FromContext(ctx)
+	logFields := []zap.Field{zap.Int64("baby_id", babyID), zap.String("date", date.Format("2006-01-02"))}
+
+	snapshot, err := g.snapshot(ctx, babyID, func(loc *time.Location, now time.Time) (time.Time, time.Time) {
+		end := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+		if now.Before(end) {
+			end = now
+		}
+		return end.Add(-dailyTipsLookback), end
+	})
+	if err != nil {
+		g.logger.Warn("红旗规则读取数据失败", append(logFields, zap.Error(err))...)
+	}
+	ageDays := snapshot.ageOrZero()
+
+	var redFlags []entity.DailyTip
+	for i, finding := range g.evaluate(snapshot) {
+		redFlags = append(redFlags, entity.DailyTip{
+			ID:          fmt.Sprintf("red_flag_%d", i+1),
+			Icon:        "🚨",
+			Title:       finding.Title,
+			Description: appendSentence(finding.Description, finding.Suggestion),
+			Type:        finding.Type,
+			Priority:    "high",
+		})
+		report.add(Intervention{Kind: KindRedFlag, Code: finding.Rule, Field: "tips", Detail: finding.Title})
+	}
+	if len(redFlags) > 0 {
+		tips.Tips = append(redFlags, tips.Tips...)
+		if len(tips.Tips) > maxDailyTips {
+			tips.Tips = tips.Tips[:maxDailyTips]
+		}
+	}
+
+	for i := range tips.Tips {
+		tip := &tips.Tips[i]
+		g.filterField(report, textField{fmt.Sprintf("tips[%d].title", i), &tip.Title}, ageDays, locale)
+		g.filterField(report, textField{fmt.Sprintf("tips[%d].description", i), &tip.Description}, ageDays, locale)
+	}
+
+	tips.Disclaimer = DisclaimerFor(locale, len(redFlags) > 0)
+
+	g.log(report, logFields)
+	return report
+}
+
+// evaluate 执行全部红旗规则，快照为空(读取数据失败)时不产生结果
+func (g *Guard) evaluate(s *Snapshot) []Finding {
+	if s == nil {
+		return nil
+	}
+	var findings []Finding
+	for _, rule := range g.rules {
+		findings = append(findings, rule.Evaluate(s)...)
+	}
+	return findings
+}
+
+// filterField 过滤一个文本字段并记录删除的内容
+func (g *Guard) filterField(report *Report, field textField, ageDays int, locale i18n.Locale) {
+	filtered, removed := filterText(*field.value, ageDays, locale)
+	if len(removed) == 0 {
+		return
+	}
+	*field.value = filtered
+	for _, r := range removed {
+		report.add(Intervention{Kind: KindContentFilter, Code: r.category, Field: field.path, Original: r.sentence})
+	}
+}
+
+// log 逐条记录干预
+func (g *Guard) log(report *Report, fields []zap.Field) {
+	for _, intervention := range report.Interventions {
+		g.logger.Info("AI输出安全护栏干预", append(fields,
+			zap.String("kind", intervention.Kind),
+			zap.String("code", intervention.Code),
+			zap.String("field", intervention.Field),
+			zap.String("original", intervention.Original),
+			zap.String("detail", intervention.Detail),
+		)...)
+	}
+}
+
+// snapshot 读取评估区间内的数据，window 根据宝宝时区和当前时间给出区间 [start, end)
+// 区间结束晚于当前时间时截止到当前时间
+func (g *Guard) snapshot(ctx context.Context, babyID int64, window func(loc *time.Location, now time.Time) (time.Time, time.Time)) (*Snapshot, error) {
+	baby, err := g.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := baby.Location()
+	now := g.now()
+	start, end := window(loc, now)
+	if now.Before(end) {
+		end = now
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	s := &Snapshot{Baby: baby, Start: start, AsOf: end, Locale: i18n.FromContext(ctx)}
+	if birth, err := time.ParseInLocation("2006-01-02", baby.BirthDate, loc); err == nil {
+		s.Birth = birth
+	}
+
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if s.Diapers, _, err = g.diaperRepo.FindByBabyID(ctx, babyID, startMs, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if s.Growth, _, err = g.growthRepo.FindByBabyID(ctx, babyID, 0, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	alerts, err := g.healthAlertRepo.FindByBabyID(ctx, babyID, startMs, maxRecords)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		if alert.TriggeredAt <= endMs {
+			s.HealthAlerts = append(s.HealthAlerts, alert)
+		}
+	}
+
+	sort.Slice(s.Diapers, func(i, j int) bool { return s.Diapers[i].Time < s.Diapers[j].Time })
+	sort.Slice(s.Growth, func(i, j int) bool { return s.Growth[i].Time < s.Growth[j].Time })
+	sort.Slice(s.HealthAlerts, func(i, j int) bool { return s.HealthAlerts[i].TriggeredAt < s.HealthAlerts[j].TriggeredAt })
+	return s, nil
+}
+
+// ageOrZero 日龄，未知时为 0(内容过滤按最严格的规则处理)
+func (s *Snapshot) ageOrZero() int {
+	if s == nil {
+		return 0
+	}
+	age, _ := s.AgeDays()
+	return age
+}
+
+// textField 可过滤的文本字段
+type textField struct {
+	path  string
+	value *string
+}
+
+// analysisTextFields 分析结果中由模型生成的全部文本字段
+func analysisTextFields(result *entity.AIAnalysisResult) []textField {
+	var fields []textField
+	add := func(path string, value *string) {
+		fields = append(fields, textField{path, value})
+	}
+	for i := range result.Insights {
+		insight := &result.Insights[i]
+		add(fmt.Sprintf("insights[%d].title", i), &insight.Title)
+		add(fmt.Sprintf("insights[%d].description", i), &insight.Description)
+	}
+	for i := range result.Alerts {
+		alert := &result.Alerts[i]
+		add(fmt.Sprintf("alerts[%d].title", i), &alert.Title)
+		add(fmt.Sprintf("alerts[%d].description", i), &alert.Description)
+		add(fmt.Sprintf("alerts[%d].suggestion", i), &alert.Suggestion)
+	}
+	for i := range result.Patterns {
+		add(fmt.Sprintf("patterns[%d].description", i), &result.Patterns[i].Description)
+	}
+	for i := range result.Predictions {
+		prediction := &result.Predictions[i]
+		add(fmt.Sprintf("predictions[%d].value", i), &prediction.Value)
+		add(fmt.Sprintf("predictions[%d].reason", i), &prediction.Reason)
+	}
+
+	uf := result.UserFriendly
+	if uf == nil {
+		return fields
+	}
+	add("user_friendly.overall_summary", &uf.OverallSummary)
+	add("user_friendly.score_explanation", &uf.ScoreExplanation)
+	add("user_friendly.encouraging_words", &uf.EncouragingWords)
+	for i := range uf.KeyHighlights {
+		highlight := &uf.KeyHighlights[i]
+		add(fmt.Sprintf("user_friendly.key_highlights[%d].title", i), &highlight.Title)
+		add(fmt.Sprintf("user_friendly.key_highlights[%d].description", i), &highlight.Description)
+	}
+	for i := range uf.ImprovementAreas {
+		area := &uf.ImprovementAreas[i]
+		add(fmt.Sprintf("user_friendly.improvement_areas[%d].issue", i), &area.Issue)
+		add(fmt.Sprintf("user_friendly.improvement_areas[%d].suggestion", i), &area.Suggestion)
+	}
+	for i := range uf.NextStepActions {
+		action := &uf.NextStepActions[i]
+		add(fmt.Sprintf("user_friendly.next_step_actions[%d].action", i), &action.Action)
+		add(fmt.Sprintf("user_friendly.next_step_actions[%d].benefit", i), &action.Benefit)
+		add(fmt.Sprintf("user_friendly.next_step_actions[%d].how_to", i), &action.HowTo)
+	}
+	return fields
+}
@@ -0,0 +1,310 @@
+package guardrail
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+var shanghai = time.FixedZone("CST", 8*3600)
+
+// newSnapshot 出生于 birthDate 的宝宝，评估截止到 asOf
+func newSnapshot(birthDate string, asOf time.Time) *Snapshot {
+	birth, _ := time.ParseInLocation("2006-01-02", birthDate, shanghai)
+	return &Snapshot{
+		Baby:  &entity.Baby{ID: 1, BirthDate: birthDate, Timezone: "Asia/Shanghai"},
+		Birth: birth,
+		Start: asOf.Add(-48 * time.Hour),
+		AsOf:  asOf,
+	}
+}
+
+func diaper(at time.Time, diaperType string) *entity.DiaperRecord {
+	return &entity.DiaperRecord{BabyID: 1, Time: at.UnixMilli(), Type: diaperType}
+}
+
+func weight(at time.Time, kg float64) *entity.GrowthRecord {
+	return &entity.GrowthRecord{BabyID: 1, Time: at.UnixMilli(), Weight: &kg}
+}
+
+func TestNoWetDiaper_FlagsGapBetweenWetDiapers(t *testing.T) {
+	asOf := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	s := newSnapshot("2026-09-01", asOf)
+	s.Diapers = []*entity.DiaperRecord{
+		diaper(asOf.Add(-12*time.Hour), "pee"),
+		diaper(asOf.Add(-3*time.Hour), "both"),
+	}
+
+	findings := noWetDiaper(s)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleNoWetDiaper, findings[0].Rule)
+	assert.Contains(t, findings[0].Description, "9小时")
+}
+
+func TestNoWetDiaper_IgnoresUnloggedTail(t *testing.T) {
+	asOf := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	s := newSnapshot("2026-09-01", asOf)
+	// 最后一次小便后没有任何记录，可能只是没记，不判定为无尿
+	s.Diapers = []*entity.DiaperRecord{diaper(asOf.Add(-12*time.Hour), "pee")}
+	assert.Empty(t, noWetDiaper(s))
+
+	// 之后有大便记录，说明家长仍在记录
+	s.Diapers = append(s.Diapers, diaper(asOf.Add(-2*time.Hour), "poop"))
+	assert.Len(t, noWetDiaper(s), 1)
+}
+
+func TestNoWetDiaper_SkipsFirstDaysAndOlderBabies(t *testing.T) {
+	asOf := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	diapers := []*entity.DiaperRecord{
+		diaper(asOf.Add(-12*time.Hour), "pee"),
+		diaper(asOf.Add(-1*time.Hour), "pee"),
+	}
+
+	newborn := newSnapshot("2026-10-17", asOf)
+	newborn.Diapers = diapers
+	assert.Empty(t, noWetDiaper(newborn))
+
+	toddler := newSnapshot("2025-06-01", asOf)
+	toddler.Diapers = diapers
+	assert.Empty(t, noWetDiaper(toddler))
+}
+
+func TestNewbornWeightLoss(t *testing.T) {
+	asOf := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	s := newSnapshot("2026-10-12", asOf)
+	s.Growth = []*entity.GrowthRecord{
+		weight(s.Birth.Add(2*time.Hour), 3.5),
+		weight(asOf.Add(-time.Hour), 3.3),
+	}
+	assert.Empty(t, newbornWeightLoss(s), "下降约6%属于生理性体重下降")
+
+	s.Growth = append(s.Growth, weight(asOf, 3.1))
+	findings := newbornWeightLoss(s)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleNewbornWeight, findings[0].Rule)
+	assert.Contains(t, findings[0].Description, "11%")
+}
+
+func TestCriticalHealthAlerts_OnlyCritical(t *testing.T) {
+	asOf := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	s := newSnapshot("2026-09-01", asOf)
+	s.HealthAlerts = []*entity.HealthAlert{
+		{RuleCode: "pale_stool", Level: entity.HealthAlertLevelCritical, Title: "灰白色大便", TriggeredAt: asOf.UnixMilli()},
+		{RuleCode: "green_stool", Level: entity.HealthAlertLevelInfo, Title: "绿色大便", TriggeredAt: asOf.UnixMilli()},
+	}
+
+	findings := criticalHealthAlerts(s)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "critical_health_alert:pale_stool", findings[0].Rule)
+}
+
+func TestFilterText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		ageDays  int
+		want     string
+		category string
+	}{
+		{
+			name:     "删除自行用药剂量",
+			text:     "宝宝有点发热。可以给宝宝吃布洛芬5ml。注意多喝水。",
+			want:     "宝宝有点发热。注意多喝水。" + categories[0].replacement(i18n.Default),
+			category: CategoryDosing,
+		},
+		{name: "遵医嘱的用药表述保留", text: "退烧药请遵医嘱服用。"},
+		{
+			name:     "劝阻就医",
+			text:     "这种情况不需要就医，观察即可。",
+			want:     categories[1].replacement(i18n.Default),
+			category: CategoryDiscourageCare,
+		},
+		{
+			name:     "1岁内喂蜂蜜",
+			text:     "咳嗽时可以喂一点蜂蜜水。",
+			ageDays:  200,
+			want:     categories[2].replacement(i18n.Default),
+			category: CategoryUnsafePractice,
+		},
+		{name: "1岁后蜂蜜不过滤", text: "咳嗽时可以喂一点蜂蜜水。", ageDays: 500},
+		{name: "劝阻性表述保留", text: "1岁以内不要喂蜂蜜。", ageDays: 200},
+		{
+			name:     "诊断结论",
+			text:     "根据记录可以确定宝宝患有过敏。建议记录饮食。",
+			want:     "建议记录饮食。" + categories[3].replacement(i18n.Default),
+			category: CategoryDiagnosis,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, removed := filterText(tt.text, tt.ageDays, i18n.Default)
+			if tt.category == "" {
+				assert.Equal(t, tt.text, got)
+				assert.Empty(t, removed)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			require.Len(t, removed, 1)
+			assert.Equal(t, tt.category, removed[0].category)
+		})
+	}
+}
+
+// 测试用内存仓储，只实现护栏用到的查询
+type fakeBabyRepo struct {
+	repository.BabyRepository
+	baby *entity.Baby
+}
+
+func (r fakeBabyRepo) FindByID(ctx context.Context, babyID int64) (*entity.Baby, error) {
+	return r.baby, nil
+}
+
+type fakeDiaperRepo struct {
+	repository.DiaperRecordRepository
+	records []*entity.DiaperRecord
+}
+
+func (r fakeDiaperRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.DiaperRecord, int64, error) {
+	var records []*entity.DiaperRecord
+	for _, record := range r.records {
+		if record.Time >= startTime && record.Time <= endTime {
+			records = append(records, record)
+		}
+	}
+	return records, int64(len(records)), nil
+}
+
+type fakeGrowthRepo struct {
+	repository.GrowthRecordRepository
+}
+
+func (fakeGrowthRepo) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.GrowthRecord, int64, error) {
+	return nil, 0, nil
+}
+
+type fakeHealthAlertRepo struct {
+	repository.HealthAlertRepository
+}
+
+func (fakeHealthAlertRepo) FindByBabyID(ctx context.Context, babyID int64, since int64, limit int) ([]*entity.HealthAlert, error) {
+	return nil, nil
+}
+
+func newTestGuard(now time.Time, diapers ...*entity.DiaperRecord) *Guard {
+	baby := &entity.Baby{ID: 1, BirthDate: "2026-09-01", Timezone: "Asia/Shanghai"}
+	g := NewGuard(fakeBabyRepo{baby: baby}, fakeDiaperRepo{records: diapers}, fakeGrowthRepo{}, fakeHealthAlertRepo{}, zap.NewNop())
+	g.SetClock(func() time.Time { return now })
+	return g
+}
+
+func TestApplyAnalysis_AddsRedFlagAndEscalates(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	g := newTestGuard(now,
+		diaper(now.Add(-14*time.Hour), "pee"),
+		diaper(now.Add(-2*time.Hour), "poop"),
+	)
+	result := &entity.AIAnalysisResult{
+		BabyID:       1,
+		AnalysisType: entity.AIAnalysisTypeHealth,
+		Alerts: []entity.AIAlert{
+			{Level: entity.HealthAlertLevelCritical, Title: "大便异常", Suggestion: "继续观察。"},
+		},
+		Insights: []entity.AIInsight{{Title: "体温", Description: "可以给宝宝吃退烧药，每次5ml。"}},
+	}
+
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	report := g.ApplyAnalysis(context.Background(), result, day.AddDate(0, 0, -1), day)
+
+	require.Len(t, result.Alerts, 2)
+	assert.Equal(t, "超过8小时没有小便", result.Alerts[0].Title)
+	assert.Equal(t, entity.HealthAlertLevelCritical, result.Alerts[0].Level)
+	assert.Contains(t, result.Alerts[1].Suggestion, "医生")
+	assert.Equal(t, categories[0].replacement(i18n.Default), result.Insights[0].Description)
+	assert.Equal(t, DisclaimerFor(i18n.Default, true), result.Disclaimer)
+
+	var logged Report
+	require.NoError(t, json.Unmarshal([]byte(report.String()), &logged))
+	var kinds []string
+	for _, intervention := range logged.Interventions {
+		kinds = append(kinds, intervention.Kind)
+	}
+	assert.Equal(t, []string{KindRedFlag, KindCriticalEscalation, KindContentFilter}, kinds)
+}
+
+func TestApplyDailyTips_PrependsRedFlagWithinLimit(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	g := newTestGuard(now,
+		diaper(now.Add(-14*time.Hour), "pee"),
+		diaper(now.Add(-2*time.Hour), "poop"),
+	)
+	tips := &entity.DailyTips{}
+	for i := 0; i < maxDailyTips; i++ {
+		tips.Tips = append(tips.Tips, entity.DailyTip{Title: "建议", Type: "feeding", Priority: "medium"})
+	}
+
+	report := g.ApplyDailyTips(context.Background(), tips, 1, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	require.Len(t, tips.Tips, maxDailyTips)
+	assert.Equal(t, "high", tips.Tips[0].Priority)
+	assert.Equal(t, "health", tips.Tips[0].Type)
+	assert.Equal(t, DisclaimerFor(i18n.Default, true), tips.Disclaimer)
+	assert.NotEmpty(t, report.String())
+}
+
+func TestApply_NoInterventionStillAddsDisclaimer(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	g := newTestGuard(now)
+	result := &entity.AIAnalysisResult{BabyID: 1, Insights: []entity.AIInsight{{Title: "喂养规律", Description: "每天喂奶8次左右。"}}}
+
+	report := g.ApplyAnalysis(context.Background(), result, now, now)
+
+	assert.Equal(t, DisclaimerFor(i18n.Default, false), result.Disclaimer)
+	assert.Empty(t, report.String())
+
+	var nilGuard *Guard
+	assert.Empty(t, nilGuard.ApplyAnalysis(context.Background(), result, now, now).String())
+}
+
+func TestFilterText_English(t *testing.T) {
+	got, removed := filterText("Baby has a mild fever. Give ibuprofen 5ml every 6 hours. Keep baby hydrated.", 200, i18n.EnUS)
+	assert.Equal(t, "Baby has a mild fever. Keep baby hydrated. "+categories[0].replacement(i18n.EnUS), got)
+	require.Len(t, removed, 1)
+	assert.Equal(t, CategoryDosing, removed[0].category)
+
+	// 否定句和小数不受影响
+	text := "Do not give honey before age one. Weight is 3.5kg."
+	got, removed = filterText(text, 200, i18n.EnUS)
+	assert.Equal(t, text, got)
+	assert.Empty(t, removed)
+}
+
+func TestApplyAnalysis_UsesContextLocale(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, shanghai)
+	g := newTestGuard(now,
+		diaper(now.Add(-14*time.Hour), "pee"),
+		diaper(now.Add(-2*time.Hour), "poop"),
+	)
+	result := &entity.AIAnalysisResult{
+		BabyID: 1,
+		Alerts: []entity.AIAlert{{Level: entity.HealthAlertLevelCritical, Title: "Stool change", Suggestion: "Keep watching."}},
+	}
+
+	ctx := i18n.WithLocale(context.Background(), i18n.EnUS)
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	g.ApplyAnalysis(ctx, result, day.AddDate(0, 0, -1), day)
+
+	require.Len(t, result.Alerts, 2)
+	assert.Equal(t, "No wet diaper for over 8 hours", result.Alerts[0].Title)
+	assert.Equal(t, "Keep watching. "+i18n.EnUS.T("guardrail.seek_care"), result.Alerts[1].Suggestion)
+	assert.Equal(t, DisclaimerFor(i18n.EnUS, true), result.Disclaimer)
+}
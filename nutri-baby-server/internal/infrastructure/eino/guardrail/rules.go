@@ -0,0 +1,184 @@
+package guardrail
+
+import (
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// 红旗规则编码
+const (
+	RuleNoWetDiaper    = "no_wet_diaper_8h"      // 8小时以上没有小便
+	RuleNewbornWeight  = "newborn_weight_loss"   // 新生儿体重较出生下降超过10%
+	RuleCriticalHealth = "critical_health_alert" // 规则引擎已触发的紧急健康提醒(排泄筛查、体温等)
+)
+
+const (
+	// wetDiaperGap 1岁以内两次小便的最长间隔
+	wetDiaperGap        = 8 * time.Hour
+	wetDiaperMaxAgeDays = 365
+	// wetDiaperMinAgeDays 出生头几天尿量本就少，不评估小便间隔
+	wetDiaperMinAgeDays = 5
+	// newbornMaxAgeDays 评估新生儿体重下降的日龄上限
+	newbornMaxAgeDays = 14
+	// newbornBirthWeightDays 出生后多少天内的测量可作为出生体重
+	newbornBirthWeightDays = 3
+	newbornMaxWeightLoss   = 0.10
+)
+
+// Snapshot 红旗规则评估使用的数据，记录均按时间升序
+type Snapshot struct {
+	Baby   *entity.Baby
+	Birth  time.Time   // 出生日期，未知时为零值
+	Start  time.Time   // 评估区间开始
+	AsOf   time.Time   // 评估区间结束(不晚于当前时间)
+	Locale i18n.Locale // 输出文本的语言
+
+	Diapers      []*entity.DiaperRecord // 区间内的排泄记录
+	Growth       []*entity.GrowthRecord // 截至区间结束的全部测量
+	HealthAlerts []*entity.HealthAlert  // 区间内触发的健康提醒
+}
+
+// AgeDays 区间结束时的日龄，出生日期未知时返回 false
+func (s *Snapshot) AgeDays() (int, bool) {
+	if s.Birth.IsZero() {
+		return 0, false
+	}
+	days := int(s.AsOf.Sub(s.Birth).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+	return days, true
+}
+
+// Finding 一条红旗规则命中，总是以 critical 级别输出
+type Finding struct {
+	Rule        string
+	Type        string // 对应的分析/建议类型: feeding/sleep/growth/health
+	Title       string
+	Description string
+	Suggestion  string
+	At          time.Time
+}
+
+// Rule 确定性的红旗规则，只基于记录数据判断，不依赖模型输出
+type Rule struct {
+	Code     string
+	Evaluate func(s *Snapshot) []Finding
+}
+
+// DefaultRules 内置红旗规则
+func DefaultRules() []Rule {
+	return []Rule{
+		{Code: RuleNoWetDiaper, Evaluate: noWetDiaper},
+		{Code: RuleNewbornWeight, Evaluate: newbornWeightLoss},
+		{Code: RuleCriticalHealth, Evaluate: criticalHealthAlerts},
+	}
+}
+
+// noWetDiaper 1岁以内连续8小时以上没有小便
+// 只统计两次小便之间、以及最后一次小便到之后最后一条排泄记录之间的间隔，避免把"没记录"当成"没小便"
+func noWetDiaper(s *Snapshot) []Finding {
+	age, ok := s.AgeDays()
+	if !ok || age < wetDiaperMinAgeDays || age >= wetDiaperMaxAgeDays {
+		return nil
+	}
+
+	var lastWet, lastAny int64
+	var longest time.Duration
+	var longestEnd int64
+	for _, record := range s.Diapers {
+		lastAny = record.Time
+		if !isWet(record) {
+			continue
+		}
+		if lastWet > 0 {
+			if gap := time.Duration(record.Time-lastWet) * time.Millisecond; gap > longest {
+				longest, longestEnd = gap, record.Time
+			}
+		}
+		lastWet = record.Time
+	}
+	// 最后一次小便后仍有大便记录，说明家长在持续记录，这段时间确实没有小便
+	if lastWet > 0 && lastAny > lastWet {
+		if gap := time.Duration(lastAny-lastWet) * time.Millisecond; gap > longest {
+			longest, longestEnd = gap, lastAny
+		}
+	}
+	if longest < wetDiaperGap {
+		return nil
+	}
+
+	return []Finding{{
+		Rule:        RuleNoWetDiaper,
+		Type:        string(entity.AIAnalysisTypeHealth),
+		Title:       s.Locale.T("guardrail.no_wet_diaper.title"),
+		Description: s.Locale.T("guardrail.no_wet_diaper.description", int(longest.Hours())),
+		Suggestion:  s.Locale.T("guardrail.no_wet_diaper.suggestion"),
+		At:          time.UnixMilli(longestEnd),
+	}}
+}
+
+// newbornWeightLoss 出生两周内体重较出生体重下降超过10%
+// 以出生3天内的第一次测量作为出生体重
+func newbornWeightLoss(s *Snapshot) []Finding {
+	age, ok := s.AgeDays()
+	if !ok || age > newbornMaxAgeDays {
+		return nil
+	}
+
+	var birthWeight float64
+	var latest *entity.GrowthRecord
+	birthCutoff := s.Birth.AddDate(0, 0, newbornBirthWeightDays).UnixMilli()
+	for _, record := range s.Growth {
+		if record.Weight == nil || *record.Weight <= 0 {
+			continue
+		}
+		if birthWeight == 0 && record.Time < birthCutoff {
+			birthWeight = *record.Weight
+			continue
+		}
+		latest = record
+	}
+	if birthWeight == 0 || latest == nil {
+		return nil
+	}
+
+	loss := (birthWeight - *latest.Weight) / birthWeight
+	if loss <= newbornMaxWeightLoss {
+		return nil
+	}
+	return []Finding{{
+		Rule:        RuleNewbornWeight,
+		Type:        string(entity.AIAnalysisTypeGrowth),
+		Title:       s.Locale.T("guardrail.newborn_weight.title"),
+		Description: s.Locale.T("guardrail.newborn_weight.description", *latest.Weight, birthWeight, loss*100),
+		Suggestion:  s.Locale.T("guardrail.newborn_weight.suggestion"),
+		At:          time.UnixMilli(latest.Time),
+	}}
+}
+
+// criticalHealthAlerts 区间内已触发的紧急健康提醒(如排泄筛查的无尿、灰白便)原样作为红旗
+func criticalHealthAlerts(s *Snapshot) []Finding {
+	var findings []Finding
+	for _, alert := range s.HealthAlerts {
+		if alert.Level != entity.HealthAlertLevelCritical {
+			continue
+		}
+		title, message := alert.LocalizedText(s.Locale)
+		findings = append(findings, Finding{
+			Rule:        RuleCriticalHealth + ":" + alert.RuleCode,
+			Type:        string(entity.AIAnalysisTypeHealth),
+			Title:       title,
+			Description: message,
+			Suggestion:  s.Locale.T("guardrail.seek_care"),
+			At:          time.UnixMilli(alert.TriggeredAt),
+		})
+	}
+	return findings
+}
+
+func isWet(record *entity.DiaperRecord) bool {
+	return record.Type == "pee" || record.Type == "both"
+}
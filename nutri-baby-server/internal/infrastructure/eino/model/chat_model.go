@@ -8,67 +8,47 @@ import (
 	"github.com/cloudwego/eino/components/model"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/genai"
 )
 
 // NewToolCallingChatModel 创建支持工具调用的聊天模型实例
-func NewToolCallingChatModel(cfg *config.Config, logger *zap.Logger) (model.ToolCallingChatModel, error) {
-	// 使用配置文件中的AI配置
-	aiConfig := cfg.AI
+// 返回按优先级回退的提供商链，链上每个模型都会记录调用用量(token、耗时、费用)
+func NewToolCallingChatModel(providers *ProviderChain) model.ToolCallingChatModel {
+	return providers
+}
 
-	// 默认使用支持工具调用的mock模式进行开发测试
-	if aiConfig.Provider == "mock" || aiConfig.Provider == "" {
+// newProviderChatModel 按提供商名称创建聊天模型，同时返回使用的模型名
+func newProviderChatModel(aiConfig config.AIConfig, provider string, offlineAnalyzer *offline.Analyzer, logger *zap.Logger) (model.ToolCallingChatModel, string, error) {
+	switch provider {
+	case "offline":
+		logger.Info("使用离线规则分析引擎，只处理数据分析和每日建议")
+		return offline.NewChatModel(offlineAnalyzer), "offline-rules", nil
+	case "mock":
 		logger.Info("使用支持工具调用的模拟AI模型进行开发测试")
-		return chain.NewToolCallingMockChatModel(logger), nil
-	}
-
-	switch aiConfig.Provider {
+		return chain.NewToolCallingMockChatModel(logger), "mock", nil
 	case "openai":
-		return NewOpenAIToolCallingChatModel(aiConfig.OpenAI, logger)
+		cm, err := NewOpenAIToolCallingChatModel(aiConfig.OpenAI, logger)
+		return cm, aiConfig.OpenAI.Model, err
 	case "claude":
-		return NewClaudeToolCallingChatModel(aiConfig.Claude, logger)
+		cm, err := NewClaudeToolCallingChatModel(aiConfig.Claude, logger)
+		return cm, aiConfig.Claude.Model, err
 	case "gemini":
-		return NewGeminiToolCallingChatModel(aiConfig.Gemini, logger)
+		cm, err := NewGeminiToolCallingChatModel(aiConfig.Gemini, logger)
+		return cm, aiConfig.Gemini.Model, err
 	case "deepseek":
 		cm, err := NewDeepSeekToolCallingChatModel(aiConfig.DeepSeek, logger)
-		if err != nil || cm == nil {
-			logger.Warn("DeepSeek 模型不可用，回退到支持工具调用的 Mock",
-				zap.Error(err),
-				zap.String("provider", aiConfig.Provider),
-			)
-			return chain.NewToolCallingMockChatModel(logger), nil
-		}
-		return cm, nil
+		return cm, aiConfig.DeepSeek.Model, err
 	case "doubao":
 		cm, err := NewDoubaoToolCallingChatModel(aiConfig.Doubao, logger)
-		if err != nil || cm == nil {
-			logger.Warn("Doubao 模型不可用，回退到支持工具调用的 Mock",
-				zap.Error(err),
-				zap.String("provider", aiConfig.Provider),
-			)
-			return chain.NewToolCallingMockChatModel(logger), nil
-		}
-		return cm, nil
+		return cm, aiConfig.Doubao.Model, err
 	default:
-		logger.Warn("未知的AI模型提供商，使用支持工具调用的模拟模型", zap.String("provider", aiConfig.Provider))
-		return chain.NewToolCallingMockChatModel(logger), nil
+		return nil, "", errors.New(errors.InternalError, "未知的AI模型提供商: "+provider)
 	}
 }
 
-// NewOpenAIToolCallingChatModel 创建OpenAI工具调用聊天模型
-func NewOpenAIToolCallingChatModel(config config.OpenAIConfig, logger *zap.Logger) (model.ToolCallingChatModel, error) {
-	// 暂时返回错误，等待 eino-ext 更新支持 ToolCallingChatModel
-	return nil, errors.New(errors.InternalError, "OpenAI ToolCallingChatModel 暂未实现")
-}
-
-// NewClaudeToolCallingChatModel 创建Claude工具调用聊天模型
-func NewClaudeToolCallingChatModel(cfg config.ClaudeConfig, logger *zap.Logger) (model.ToolCallingChatModel, error) {
-	// 暂时返回错误，等待 eino-ext 更新支持 ToolCallingChatModel
-	return nil, errors.New(errors.InternalError, "Claude ToolCallingChatModel 暂未实现")
-}
-
 // NewDeepSeekToolCallingChatModel 创建DeepSeek工具调用聊天模型
 func NewDeepSeekToolCallingChatModel(cfg config.DeepSeekConfig, logger *zap.Logger) (model.ToolCallingChatModel, error) {
 	// 暂时返回错误，等待 eino-ext 更新支持 ToolCallingChatModel
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// 熔断器状态
+const (
+	CircuitClosed   = "closed"    // 正常放行
+	CircuitOpen     = "open"      // 熔断中，拒绝调用
+	CircuitHalfOpen = "half_open" // 冷却结束，放行一次探测调用
+)
+
+// circuitBreaker 提供商熔断器
+// 连续失败达到阈值后熔断，冷却期结束后只放行一次探测调用，成功则恢复，失败则重新熔断
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	state          string
+	failures       int // 连续失败次数
+	openedAt       time.Time
+	probing        bool // 半开状态下是否已有探测调用
+	lastError      string
+	lastFailureAt  time.Time
+	lastSuccessAt  time.Time
+	totalFailures  int64
+	totalSuccesses int64
+}
+
+// newCircuitBreaker 创建熔断器，failureThreshold<=0 时不熔断
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+		state:            CircuitClosed,
+	}
+}
+
+// Allow 判断是否放行本次调用
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success 记录一次成功调用
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+	b.lastSuccessAt = b.now()
+	b.totalSuccesses++
+}
+
+// Failure 记录一次失败调用
+func (b *circuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.totalFailures++
+	b.lastFailureAt = b.now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == CircuitHalfOpen || (b.failureThreshold > 0 && b.failures >= b.failureThreshold) {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// Release 放弃本次已放行的调用(调用方取消等与提供商无关的原因)，不计成功或失败
+func (b *circuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+}
+
+// snapshot 返回熔断器状态快照
+func (b *circuitBreaker) snapshot() ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := ProviderHealth{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		TotalSuccesses:      b.totalSuccesses,
+		TotalFailures:       b.totalFailures,
+		LastError:           b.lastError,
+	}
+	if !b.lastSuccessAt.IsZero() {
+		health.LastSuccessAt = b.lastSuccessAt.UnixMilli()
+	}
+	if !b.lastFailureAt.IsZero() {
+		health.LastFailureAt = b.lastFailureAt.UnixMilli()
+	}
+	if b.state == CircuitOpen {
+		health.OpenUntil = b.openedAt.Add(b.cooldown).UnixMilli()
+	}
+	return health
+}
+
+// rateLimiter 令牌桶限流，每分钟最多 perMinute 次，perMinute<=0 时不限流
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	tokens    float64
+	updatedAt time.Time
+	now       func() time.Time
+}
+
+// newRateLimiter 创建限流器，初始令牌桶为满
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		perMinute: perMinute,
+		tokens:    float64(perMinute),
+		updatedAt: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow 尝试取一个令牌，不等待
+func (l *rateLimiter) Allow() bool {
+	if l.perMinute <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens += now.Sub(l.updatedAt).Minutes() * float64(l.perMinute)
+	if l.tokens > float64(l.perMinute) {
+		l.tokens = float64(l.perMinute)
+	}
+	l.updatedAt = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
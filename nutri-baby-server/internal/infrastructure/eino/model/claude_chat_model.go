@@ -0,0 +1,367 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Anthropic Messages API 默认参数
+const (
+	defaultClaudeBaseURL   = "https://api.anthropic.com"
+	defaultClaudeVersion   = "2023-06-01"
+	defaultClaudeMaxTokens = 4096 // Messages API 要求必须指定 max_tokens
+)
+
+// claudeChatModel Anthropic Messages API 兼容接口的工具调用聊天模型
+type claudeChatModel struct {
+	cfg     config.ClaudeConfig
+	baseURL string
+	client  *httpChatClient
+	tools   []*schema.ToolInfo
+}
+
+// NewClaudeToolCallingChatModel 创建Claude工具调用聊天模型
+// 使用 Anthropic Messages API(/v1/messages)，base_url 可指向兼容该接口的代理或网关
+func NewClaudeToolCallingChatModel(cfg config.ClaudeConfig, logger *zap.Logger) (model.ToolCallingChatModel, error) {
+	if cfg.Model == "" {
+		return nil, errors.New(errors.InternalError, "Claude 模型未配置 model")
+	}
+
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultClaudeBaseURL
+	}
+
+	headers := map[string]string{"anthropic-version": defaultClaudeVersion}
+	if cfg.APIKey != "" {
+		headers["x-api-key"] = cfg.APIKey
+	}
+	for key, value := range cfg.Headers {
+		headers[key] = value
+	}
+
+	logger.Info("创建Claude聊天模型", zap.String("base_url", baseURL), zap.String("model", cfg.Model))
+	return &claudeChatModel{
+		cfg:     cfg,
+		baseURL: baseURL,
+		client:  newHTTPChatClient("Claude", headers),
+	}, nil
+}
+
+// claudeRequest Messages API 请求
+type claudeRequest struct {
+	Model         string            `json:"model"`
+	MaxTokens     int               `json:"max_tokens"`
+	System        string            `json:"system,omitempty"`
+	Messages      []claudeMessage   `json:"messages"`
+	Tools         []claudeTool      `json:"tools,omitempty"`
+	ToolChoice    *claudeToolChoice `json:"tool_choice,omitempty"`
+	Temperature   *float32          `json:"temperature,omitempty"`
+	TopP          *float32          `json:"top_p,omitempty"`
+	StopSequences []string          `json:"stop_sequences,omitempty"`
+	Stream        bool              `json:"stream,omitempty"`
+}
+
+type claudeMessage struct {
+	Role    string               `json:"role"`
+	Content []claudeContentBlock `json:"content"`
+}
+
+// claudeContentBlock 内容块: text / tool_use / tool_result
+type claudeContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+}
+
+type claudeTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+type claudeToolChoice struct {
+	Type string `json:"type"`
+}
+
+type claudeResponse struct {
+	Content    []claudeContentBlock `json:"content"`
+	StopReason string               `json:"stop_reason"`
+	Usage      claudeUsage          `json:"usage"`
+}
+
+type claudeUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// claudeStreamEvent 流式事件
+type claudeStreamEvent struct {
+	Type         string              `json:"type"`
+	Index        int                 `json:"index"`
+	Message      *claudeResponse     `json:"message"`
+	ContentBlock *claudeContentBlock `json:"content_block"`
+	Delta        *struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *claudeUsage `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Generate 一次性生成
+func (m *claudeChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	req, err := m.buildRequest(input, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.post(ctx, m.baseURL+"/v1/messages", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body claudeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(errors.InternalError, "Claude 响应解析失败", err)
+	}
+
+	msg := &schema.Message{Role: schema.Assistant}
+	var text strings.Builder
+	for _, block := range body.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "tool_use":
+			arguments := string(block.Input)
+			if arguments == "" {
+				arguments = "{}"
+			}
+			msg.ToolCalls = append(msg.ToolCalls, schema.ToolCall{
+				ID:       block.ID,
+				Type:     "function",
+				Function: schema.FunctionCall{Name: block.Name, Arguments: arguments},
+			})
+		}
+	}
+	msg.Content = text.String()
+	msg.ResponseMeta = &schema.ResponseMeta{
+		FinishReason: body.StopReason,
+		Usage:        body.Usage.toTokenUsage(),
+	}
+	return msg, nil
+}
+
+// Stream 流式生成
+// 输入token数在 message_start 中返回，输出token数在 message_delta 中返回，合并后随最后一个分片发出
+func (m *claudeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	req, err := m.buildRequest(input, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.post(ctx, m.baseURL+"/v1/messages", req)
+	if err != nil {
+		return nil, err
+	}
+
+	out, writer := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer resp.Body.Close()
+		defer writer.Close()
+
+		var usage claudeUsage
+		err := readSSE(resp.Body, func(_ string, data string) (bool, error) {
+			var event claudeStreamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				return false, errors.Wrap(errors.InternalError, "Claude 流式事件解析失败", err)
+			}
+
+			var msg *schema.Message
+			switch event.Type {
+			case "message_start":
+				if event.Message != nil {
+					usage.InputTokens = event.Message.Usage.InputTokens
+				}
+			case "content_block_start":
+				if event.ContentBlock != nil && event.ContentBlock.Type == "tool_use" {
+					index := event.Index
+					msg = &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{{
+						Index:    &index,
+						ID:       event.ContentBlock.ID,
+						Type:     "function",
+						Function: schema.FunctionCall{Name: event.ContentBlock.Name},
+					}}}
+				}
+			case "content_block_delta":
+				if event.Delta == nil {
+					break
+				}
+				switch event.Delta.Type {
+				case "text_delta":
+					msg = &schema.Message{Role: schema.Assistant, Content: event.Delta.Text}
+				case "input_json_delta":
+					index := event.Index
+					msg = &schema.Message{Role: schema.Assistant, ToolCalls: []schema.ToolCall{{
+						Index:    &index,
+						Function: schema.FunctionCall{Arguments: event.Delta.PartialJSON},
+					}}}
+				}
+			case "message_delta":
+				if event.Usage != nil {
+					usage.OutputTokens = event.Usage.OutputTokens
+				}
+				meta := &schema.ResponseMeta{Usage: usage.toTokenUsage()}
+				if event.Delta != nil {
+					meta.FinishReason = event.Delta.StopReason
+				}
+				msg = &schema.Message{Role: schema.Assistant, ResponseMeta: meta}
+			case "message_stop":
+				return true, nil
+			case "error":
+				message := "未知错误"
+				if event.Error != nil {
+					message = event.Error.Type + ": " + event.Error.Message
+				}
+				return false, errors.New(errors.InternalError, "Claude 流式响应错误: "+message)
+			}
+
+			if msg == nil {
+				return false, nil
+			}
+			return writer.Send(msg, nil), nil
+		})
+		if err != nil {
+			writer.Send(nil, err)
+		}
+	}()
+
+	return out, nil
+}
+
+// WithTools 返回绑定工具的新模型实例
+func (m *claudeChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	bound := *m
+	bound.tools = tools
+	return &bound, nil
+}
+
+// buildRequest 合并配置与调用选项生成请求
+// system 消息合并为顶层 system 字段，工具结果作为 user 消息的 tool_result 块，相邻同角色消息合并
+func (m *claudeChatModel) buildRequest(input []*schema.Message, opts []model.Option, stream bool) (*claudeRequest, error) {
+	maxTokens := m.cfg.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultClaudeMaxTokens
+	}
+	options := model.GetCommonOptions(&model.Options{
+		Model:     &m.cfg.Model,
+		MaxTokens: &maxTokens,
+		Tools:     m.tools,
+	}, opts...)
+
+	req := &claudeRequest{
+		Model:         *options.Model,
+		MaxTokens:     *options.MaxTokens,
+		Messages:      make([]claudeMessage, 0, len(input)),
+		TopP:          options.TopP,
+		StopSequences: options.Stop,
+		Stream:        stream,
+	}
+	req.Temperature = options.Temperature
+	if req.Temperature == nil && m.cfg.Temperature > 0 {
+		temperature := float32(m.cfg.Temperature)
+		req.Temperature = &temperature
+	}
+
+	var system []string
+	for _, msg := range input {
+		var role string
+		var blocks []claudeContentBlock
+		switch msg.Role {
+		case schema.System:
+			if msg.Content != "" {
+				system = append(system, msg.Content)
+			}
+			continue
+		case schema.Tool:
+			role = "user"
+			blocks = []claudeContentBlock{{Type: "tool_result", ToolUseID: msg.ToolCallID, Content: msg.Content}}
+		case schema.Assistant:
+			role = "assistant"
+			if msg.Content != "" {
+				blocks = append(blocks, claudeContentBlock{Type: "text", Text: msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				arguments := json.RawMessage(call.Function.Arguments)
+				if !json.Valid(arguments) {
+					arguments = json.RawMessage("{}")
+				}
+				blocks = append(blocks, claudeContentBlock{Type: "tool_use", ID: call.ID, Name: call.Function.Name, Input: arguments})
+			}
+		default:
+			role = "user"
+			if msg.Content != "" {
+				blocks = []claudeContentBlock{{Type: "text", Text: msg.Content}}
+			}
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+
+		if last := len(req.Messages) - 1; last >= 0 && req.Messages[last].Role == role {
+			req.Messages[last].Content = append(req.Messages[last].Content, blocks...)
+			continue
+		}
+		req.Messages = append(req.Messages, claudeMessage{Role: role, Content: blocks})
+	}
+	req.System = strings.Join(system, "\n\n")
+
+	for _, tool := range options.Tools {
+		params, err := toolParameters(tool)
+		if err != nil {
+			return nil, err
+		}
+		req.Tools = append(req.Tools, claudeTool{
+			Name:        tool.Name,
+			Description: tool.Desc,
+			InputSchema: params,
+		})
+	}
+	if len(req.Tools) > 0 && options.ToolChoice != nil {
+		switch *options.ToolChoice {
+		case schema.ToolChoiceForbidden:
+			req.ToolChoice = &claudeToolChoice{Type: "none"}
+		case schema.ToolChoiceAllowed:
+			req.ToolChoice = &claudeToolChoice{Type: "auto"}
+		case schema.ToolChoiceForced:
+			req.ToolChoice = &claudeToolChoice{Type: "any"}
+		}
+	}
+	return req, nil
+}
+
+// toTokenUsage 转换用量
+func (u claudeUsage) toTokenUsage() *schema.TokenUsage {
+	return &schema.TokenUsage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.InputTokens + u.OutputTokens,
+	}
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+)
+
+func TestClaudeChatModelGenerate(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "sk-ant-test", r.Header.Get("x-api-key"))
+		assert.Equal(t, defaultClaudeVersion, r.Header.Get("anthropic-version"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"content": [
+				{"type": "text", "text": "我先查一下记录。"},
+				{"type": "tool_use", "id": "toolu_1", "name": "get_feeding_records", "input": {"days": 7}}
+			],
+			"stop_reason": "tool_use",
+			"usage": {"input_tokens": 40, "output_tokens": 12}
+		}`)
+	}))
+	defer server.Close()
+
+	cm, err := NewClaudeToolCallingChatModel(config.ClaudeConfig{
+		APIKey:  "sk-ant-test",
+		BaseURL: server.URL,
+		Model:   "claude-sonnet",
+	}, zap.NewNop())
+	require.NoError(t, err)
+	bound, err := cm.WithTools([]*schema.ToolInfo{feedingRecordsTool})
+	require.NoError(t, err)
+
+	msg, err := bound.Generate(context.Background(), []*schema.Message{
+		schema.SystemMessage("你是育儿助手"),
+		schema.UserMessage("最近一周喂养情况"),
+		{Role: schema.Assistant, ToolCalls: []schema.ToolCall{
+			{ID: "toolu_a", Function: schema.FunctionCall{Name: "noop", Arguments: "{}"}},
+			{ID: "toolu_b", Function: schema.FunctionCall{Name: "noop", Arguments: "{}"}},
+		}},
+		schema.ToolMessage("[]", "toolu_a"),
+		schema.ToolMessage("[]", "toolu_b"),
+	})
+	require.NoError(t, err)
+
+	// system 提升为顶层字段，相邻的工具结果合并为一条 user 消息
+	assert.Equal(t, "你是育儿助手", got["system"])
+	assert.EqualValues(t, defaultClaudeMaxTokens, got["max_tokens"])
+	messages := got["messages"].([]any)
+	require.Len(t, messages, 3)
+	toolResults := messages[2].(map[string]any)
+	assert.Equal(t, "user", toolResults["role"])
+	require.Len(t, toolResults["content"], 2)
+	assert.Equal(t, "tool_result", toolResults["content"].([]any)[0].(map[string]any)["type"])
+	tools := got["tools"].([]any)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "object", tools[0].(map[string]any)["input_schema"].(map[string]any)["type"])
+
+	assert.Equal(t, "我先查一下记录。", msg.Content)
+	require.Len(t, msg.ToolCalls, 1)
+	assert.Equal(t, "toolu_1", msg.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"days":7}`, msg.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, "tool_use", msg.ResponseMeta.FinishReason)
+	assert.Equal(t, 52, msg.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestClaudeChatModelStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []struct{ name, data string }{
+			{"message_start", `{"type":"message_start","message":{"content":[],"usage":{"input_tokens":25,"output_tokens":1}}}`},
+			{"content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`},
+			{"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"好的"}}`},
+			{"ping", `{"type":"ping"}`},
+			{"content_block_start", `{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"get_feeding_records","input":{}}}`},
+			{"content_block_delta", `{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"days\":"}}`},
+			{"content_block_delta", `{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"3}"}}`},
+			{"content_block_stop", `{"type":"content_block_stop","index":1}`},
+			{"message_delta", `{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":15}}`},
+			{"message_stop", `{"type":"message_stop"}`},
+		} {
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data)
+		}
+	}))
+	defer server.Close()
+
+	cm, err := NewClaudeToolCallingChatModel(config.ClaudeConfig{BaseURL: server.URL, Model: "claude-haiku"}, zap.NewNop())
+	require.NoError(t, err)
+
+	reader, err := cm.Stream(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var chunks []*schema.Message
+	for {
+		chunk, err := reader.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	msg, err := schema.ConcatMessages(chunks)
+	require.NoError(t, err)
+	assert.Equal(t, "好的", msg.Content)
+	require.Len(t, msg.ToolCalls, 1)
+	assert.Equal(t, "toolu_1", msg.ToolCalls[0].ID)
+	assert.Equal(t, "get_feeding_records", msg.ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"days":3}`, msg.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, "tool_use", msg.ResponseMeta.FinishReason)
+	assert.Equal(t, 40, msg.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestClaudeChatModelStreamErrorEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer server.Close()
+
+	cm, err := NewClaudeToolCallingChatModel(config.ClaudeConfig{BaseURL: server.URL, Model: "claude-haiku"}, zap.NewNop())
+	require.NoError(t, err)
+
+	reader, err := cm.Stream(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	_, err = reader.Recv()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overloaded_error: Overloaded")
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// maxErrorBodyBytes 读取错误响应体的上限
+const maxErrorBodyBytes = 4096
+
+// httpChatClient OpenAI 兼容与 Anthropic 聊天接口共用的 HTTP 客户端
+// 不设置整体超时，超时由调用方 context 控制(流式响应可能持续较长时间)
+type httpChatClient struct {
+	provider string // 用于错误信息
+	client   *http.Client
+	headers  map[string]string
+}
+
+func newHTTPChatClient(provider string, headers map[string]string) *httpChatClient {
+	return &httpChatClient{
+		provider: provider,
+		client:   &http.Client{},
+		headers:  headers,
+	}
+}
+
+// post 发送 JSON 请求，非 2xx 响应转换为错误，调用方负责关闭返回的响应体
+func (c *httpChatClient) post(ctx context.Context, url string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, c.provider+" 请求序列化失败", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, c.provider+" 请求创建失败", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, c.provider+" 请求失败", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, errors.New(errors.InternalError,
+			fmt.Sprintf("%s 接口返回错误(HTTP %d): %s", c.provider, resp.StatusCode, apiErrorMessage(raw)))
+	}
+	return resp, nil
+}
+
+// apiErrorMessage 提取 {"error":{"message":"..."}} 格式的错误信息，无法解析时返回原始内容
+func apiErrorMessage(raw []byte) string {
+	var body struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &body); err == nil && body.Error.Message != "" {
+		if body.Error.Type != "" {
+			return body.Error.Type + ": " + body.Error.Message
+		}
+		return body.Error.Message
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+// readSSE 逐个读取 Server-Sent Events，handle 返回 true 时停止读取
+func readSSE(r io.Reader, handle func(event, data string) (bool, error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var event string
+	var data []string
+	dispatch := func() (bool, error) {
+		if len(data) == 0 {
+			event = ""
+			return false, nil
+		}
+		stop, err := handle(event, strings.Join(data, "\n"))
+		event, data = "", data[:0]
+		return stop, err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if stop, err := dispatch(); stop || err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, ":"):
+			// 注释行(心跳)
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	_, err := dispatch()
+	return err
+}
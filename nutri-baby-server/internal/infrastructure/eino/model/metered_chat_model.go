@@ -0,0 +1,171 @@
+package model
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/snowflake"
+	"go.uber.org/zap"
+)
+
+// usageWriteTimeout 写入用量记录的超时时间
+const usageWriteTimeout = 5 * time.Second
+
+// meteredChatModel 记录每次模型调用用量(token、耗时、费用)的装饰器
+// 用量归属从 context 中的 chain.UsageScope 读取，写入失败只记日志，不影响调用结果
+type meteredChatModel struct {
+	inner     model.ToolCallingChatModel
+	provider  string
+	modelName string
+	pricing   config.AIPricingConfig
+	usageRepo repository.AIUsageRepository
+	logger    *zap.Logger
+}
+
+// NewMeteredChatModel 为聊天模型包装用量记录
+func NewMeteredChatModel(
+	inner model.ToolCallingChatModel,
+	provider, modelName string,
+	pricing config.AIPricingConfig,
+	usageRepo repository.AIUsageRepository,
+	logger *zap.Logger,
+) model.ToolCallingChatModel {
+	return &meteredChatModel{
+		inner:     inner,
+		provider:  provider,
+		modelName: modelName,
+		pricing:   pricing,
+		usageRepo: usageRepo,
+		logger:    logger,
+	}
+}
+
+// Generate 一次性生成并记录用量
+func (m *meteredChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	start := time.Now()
+	response, err := m.inner.Generate(ctx, input, opts...)
+
+	var usage *schema.TokenUsage
+	if response != nil && response.ResponseMeta != nil {
+		usage = response.ResponseMeta.Usage
+	}
+	m.record(ctx, start, usage, err)
+	return response, err
+}
+
+// Stream 流式生成，流读取结束(或被调用方关闭)后记录用量
+// 提供商一般只在最后一个分片中返回用量，这里取最后一次出现的用量
+func (m *meteredChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	start := time.Now()
+	reader, err := m.inner.Stream(ctx, input, opts...)
+	if err != nil {
+		m.record(ctx, start, nil, err)
+		return nil, err
+	}
+
+	out, writer := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer reader.Close()
+		defer writer.Close()
+
+		var usage *schema.TokenUsage
+		var streamErr error
+		for {
+			chunk, err := reader.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				streamErr = err
+				writer.Send(nil, err)
+				break
+			}
+			if chunk != nil && chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil {
+				usage = chunk.ResponseMeta.Usage
+			}
+			if closed := writer.Send(chunk, nil); closed {
+				break
+			}
+		}
+		m.record(ctx, start, usage, streamErr)
+	}()
+
+	return out, nil
+}
+
+// WithTools 绑定工具后的模型同样记录用量
+func (m *meteredChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	inner, err := m.inner.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return NewMeteredChatModel(inner, m.provider, m.modelName, m.pricing, m.usageRepo, m.logger), nil
+}
+
+// record 写入一次模型调用的用量
+func (m *meteredChatModel) record(ctx context.Context, start time.Time, usage *schema.TokenUsage, callErr error) {
+	// 离线引擎跳过的请求没有实际调用，不记录
+	if errors.Is(callErr, offline.ErrNotApplicable) {
+		return
+	}
+	scope, ok := chain.UsageScopeFrom(ctx)
+	if !ok {
+		scope.Operation = "unknown"
+	}
+	if scope.RequestID == "" {
+		scope.RequestID = strconv.FormatInt(snowflake.Generate(), 10)
+	}
+
+	record := &entity.AIUsageRecord{
+		RequestID: scope.RequestID,
+		Operation: scope.Operation,
+		OpenID:    scope.OpenID,
+		BabyID:    scope.BabyID,
+		Provider:  m.provider,
+		Model:     m.modelName,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Success:   callErr == nil,
+	}
+	if usage != nil {
+		record.PromptTokens = usage.PromptTokens
+		record.CompletionTokens = usage.CompletionTokens
+		record.TotalTokens = usage.TotalTokens
+		if record.TotalTokens == 0 {
+			record.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		}
+		record.Cost = estimateCost(m.pricing, usage.PromptTokens, usage.CompletionTokens)
+	}
+	if callErr != nil {
+		record.ErrorMessage = callErr.Error()
+		if runes := []rune(record.ErrorMessage); len(runes) > 500 {
+			record.ErrorMessage = string(runes[:500])
+		}
+	}
+
+	// 调用方 context 可能已取消(超时、客户端断开)，但已产生的费用仍需记录
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), usageWriteTimeout)
+	defer cancel()
+	if err := m.usageRepo.Create(writeCtx, record); err != nil {
+		m.logger.Error("记录AI调用用量失败",
+			zap.String("request_id", record.RequestID),
+			zap.String("operation", record.Operation),
+			zap.Int("total_tokens", record.TotalTokens),
+			zap.Error(err),
+		)
+	}
+}
+
+// estimateCost 按每百万token价格估算费用
+func estimateCost(pricing config.AIPricingConfig, promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*pricing.PromptPerMillion + float64(completionTokens)*pricing.CompletionPerMillion) / 1e6
+}
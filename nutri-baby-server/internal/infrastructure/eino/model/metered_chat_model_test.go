@@ -0,0 +1,109 @@
+package model
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+)
+
+// fakeUsageRepo 只记录写入的用量
+type fakeUsageRepo struct {
+	repository.AIUsageRepository
+	mu      sync.Mutex
+	records []*entity.AIUsageRecord
+}
+
+func (r *fakeUsageRepo) Create(_ context.Context, record *entity.AIUsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *fakeUsageRepo) snapshot() []*entity.AIUsageRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*entity.AIUsageRecord(nil), r.records...)
+}
+
+// fakeChatModel 返回固定用量的模型
+type fakeChatModel struct {
+	usage *schema.TokenUsage
+}
+
+func (m *fakeChatModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return &schema.Message{
+		Role:         schema.Assistant,
+		Content:      "ok",
+		ResponseMeta: &schema.ResponseMeta{Usage: m.usage},
+	}, nil
+}
+
+func (m *fakeChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return schema.StreamReaderFromArray([]*schema.Message{
+		{Role: schema.Assistant, Content: "o"},
+		{Role: schema.Assistant, Content: "k", ResponseMeta: &schema.ResponseMeta{Usage: m.usage}},
+	}), nil
+}
+
+func (m *fakeChatModel) WithTools(_ []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func TestMeteredChatModel(t *testing.T) {
+	repo := &fakeUsageRepo{}
+	inner := &fakeChatModel{usage: &schema.TokenUsage{PromptTokens: 1000, CompletionTokens: 200}}
+	pricing := config.AIPricingConfig{PromptPerMillion: 1, CompletionPerMillion: 4}
+	metered := NewMeteredChatModel(inner, "gemini", "gemini-1.5-flash", pricing, repo, zap.NewNop())
+
+	bound, err := metered.WithTools(nil)
+	require.NoError(t, err)
+
+	scope := chain.UsageScope{RequestID: "req-1", Operation: entity.AIUsageOperationChat, OpenID: "user-1", BabyID: 42}
+	ctx := chain.WithUsageScope(context.Background(), scope)
+
+	_, err = bound.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+
+	// 流式调用在读取结束后记录用量
+	reader, err := bound.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	var content string
+	for {
+		chunk, err := reader.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content += chunk.Content
+	}
+	reader.Close()
+	assert.Equal(t, "ok", content)
+
+	require.Eventually(t, func() bool { return len(repo.snapshot()) == 2 }, time.Second, 10*time.Millisecond)
+	for _, record := range repo.snapshot() {
+		assert.Equal(t, "req-1", record.RequestID)
+		assert.Equal(t, entity.AIUsageOperationChat, record.Operation)
+		assert.Equal(t, "user-1", record.OpenID)
+		assert.Equal(t, int64(42), record.BabyID)
+		assert.Equal(t, "gemini", record.Provider)
+		assert.Equal(t, 1000, record.PromptTokens)
+		assert.Equal(t, 200, record.CompletionTokens)
+		assert.Equal(t, 1200, record.TotalTokens) // 提供商未返回总数时按输入+输出计算
+		assert.InDelta(t, 0.0018, record.Cost, 1e-9)
+		assert.True(t, record.Success)
+	}
+}
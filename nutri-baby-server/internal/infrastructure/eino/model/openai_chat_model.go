@@ -0,0 +1,370 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// defaultOpenAIBaseURL OpenAI 官方接口地址，自建服务(vLLM、Ollama 等)通过 base_url 覆盖
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// openAIChatModel OpenAI Chat Completions 兼容接口的工具调用聊天模型
+type openAIChatModel struct {
+	cfg     config.OpenAIConfig
+	baseURL string
+	client  *httpChatClient
+	tools   []*schema.ToolInfo
+}
+
+// NewOpenAIToolCallingChatModel 创建OpenAI兼容接口的工具调用聊天模型
+// 适用于 OpenAI 官方接口及 vLLM、Ollama 等兼容 /chat/completions 的服务，api_key 为空时不发送认证头
+func NewOpenAIToolCallingChatModel(cfg config.OpenAIConfig, logger *zap.Logger) (model.ToolCallingChatModel, error) {
+	if cfg.Model == "" {
+		return nil, errors.New(errors.InternalError, "OpenAI 兼容模型未配置 model")
+	}
+
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+
+	headers := make(map[string]string, len(cfg.Headers)+1)
+	if cfg.APIKey != "" {
+		headers["Authorization"] = "Bearer " + cfg.APIKey
+	}
+	for key, value := range cfg.Headers {
+		headers[key] = value
+	}
+
+	logger.Info("创建OpenAI兼容聊天模型", zap.String("base_url", baseURL), zap.String("model", cfg.Model))
+	return &openAIChatModel{
+		cfg:     cfg,
+		baseURL: baseURL,
+		client:  newHTTPChatClient("OpenAI", headers),
+	}, nil
+}
+
+// openAIRequest Chat Completions 请求
+type openAIRequest struct {
+	Model         string               `json:"model"`
+	Messages      []openAIMessage      `json:"messages"`
+	Tools         []openAITool         `json:"tools,omitempty"`
+	ToolChoice    string               `json:"tool_choice,omitempty"`
+	MaxTokens     int                  `json:"max_tokens,omitempty"`
+	Temperature   *float32             `json:"temperature,omitempty"`
+	TopP          *float32             `json:"top_p,omitempty"`
+	Stop          []string             `json:"stop,omitempty"`
+	Stream        bool                 `json:"stream,omitempty"`
+	StreamOptions *openAIStreamOptions `json:"stream_options,omitempty"`
+
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIJSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIMessage struct {
+	Role             string           `json:"role,omitempty"`
+	Content          string           `json:"content"`
+	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	Name             string           `json:"name,omitempty"`
+	ToolCalls        []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID       string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	Index    *int               `json:"index,omitempty"`
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function openAIFunctionCall `json:"function"`
+}
+
+type openAIFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type openAITool struct {
+	Type     string             `json:"type"`
+	Function openAIToolFunction `json:"function"`
+}
+
+type openAIToolFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// openAIResponse 一次性响应与流式分片共用
+type openAIResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		Delta        openAIMessage `json:"delta"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Generate 一次性生成
+func (m *openAIChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	req, err := m.buildRequest(input, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.post(ctx, m.baseURL+"/chat/completions", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(errors.InternalError, "OpenAI 响应解析失败", err)
+	}
+	if len(body.Choices) == 0 {
+		return nil, errors.New(errors.InternalError, "OpenAI 响应中没有候选结果")
+	}
+
+	choice := body.Choices[0]
+	msg := fromOpenAIMessage(choice.Message)
+	msg.ResponseMeta = &schema.ResponseMeta{
+		FinishReason: choice.FinishReason,
+		Usage:        body.Usage.toTokenUsage(),
+	}
+	return msg, nil
+}
+
+// Stream 流式生成，用量在最后一个分片中返回
+func (m *openAIChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	req, err := m.buildRequest(input, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.post(ctx, m.baseURL+"/chat/completions", req)
+	if err != nil {
+		return nil, err
+	}
+
+	out, writer := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer resp.Body.Close()
+		defer writer.Close()
+
+		err := readSSE(resp.Body, func(_ string, data string) (bool, error) {
+			if data == "[DONE]" {
+				return true, nil
+			}
+			var chunk openAIResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				return false, errors.Wrap(errors.InternalError, "OpenAI 流式分片解析失败", err)
+			}
+
+			msg := &schema.Message{Role: schema.Assistant}
+			if len(chunk.Choices) > 0 {
+				choice := chunk.Choices[0]
+				msg = fromOpenAIMessage(choice.Delta)
+				if choice.FinishReason != "" {
+					msg.ResponseMeta = &schema.ResponseMeta{FinishReason: choice.FinishReason}
+				}
+			}
+			if usage := chunk.Usage.toTokenUsage(); usage != nil {
+				if msg.ResponseMeta == nil {
+					msg.ResponseMeta = &schema.ResponseMeta{}
+				}
+				msg.ResponseMeta.Usage = usage
+			}
+			return writer.Send(msg, nil), nil
+		})
+		if err != nil {
+			writer.Send(nil, err)
+		}
+	}()
+
+	return out, nil
+}
+
+// WithTools 返回绑定工具的新模型实例
+func (m *openAIChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	bound := *m
+	bound.tools = tools
+	return &bound, nil
+}
+
+// buildRequest 合并配置与调用选项生成请求
+func (m *openAIChatModel) buildRequest(input []*schema.Message, opts []model.Option, stream bool) (*openAIRequest, error) {
+	options := model.GetCommonOptions(&model.Options{
+		Model:     &m.cfg.Model,
+		MaxTokens: &m.cfg.MaxTokens,
+		Tools:     m.tools,
+	}, opts...)
+
+	req := &openAIRequest{
+		Model:    *options.Model,
+		Messages: make([]openAIMessage, 0, len(input)),
+		TopP:     options.TopP,
+		Stop:     options.Stop,
+		Stream:   stream,
+	}
+	if options.MaxTokens != nil {
+		req.MaxTokens = *options.MaxTokens
+	}
+	req.Temperature = options.Temperature
+	if req.Temperature == nil && m.cfg.Temperature > 0 {
+		temperature := float32(m.cfg.Temperature)
+		req.Temperature = &temperature
+	}
+	if stream {
+		req.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+	if schema := structured.ResponseSchemaFrom(opts...); schema != nil {
+		req.ResponseFormat = m.responseFormat(schema)
+	}
+
+	for _, msg := range input {
+		req.Messages = append(req.Messages, toOpenAIMessage(msg))
+	}
+
+	for _, tool := range options.Tools {
+		params, err := toolParameters(tool)
+		if err != nil {
+			return nil, err
+		}
+		req.Tools = append(req.Tools, openAITool{
+			Type: "function",
+			Function: openAIToolFunction{
+				Name:        tool.Name,
+				Description: tool.Desc,
+				Parameters:  params,
+			},
+		})
+	}
+	if len(req.Tools) > 0 && options.ToolChoice != nil {
+		switch *options.ToolChoice {
+		case schema.ToolChoiceForbidden:
+			req.ToolChoice = "none"
+		case schema.ToolChoiceAllowed:
+			req.ToolChoice = "auto"
+		case schema.ToolChoiceForced:
+			req.ToolChoice = "required"
+		}
+	}
+	return req, nil
+}
+
+// responseFormat 按配置生成结构化输出约束
+// 非严格模式: 严格模式要求所有字段必填且禁止额外字段，与可选字段的 schema 不兼容
+func (m *openAIChatModel) responseFormat(schema *structured.Schema) *openAIResponseFormat {
+	switch m.cfg.ResponseFormat {
+	case "none":
+		return nil
+	case "json_object":
+		return &openAIResponseFormat{Type: "json_object"}
+	default:
+		return &openAIResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &openAIJSONSchema{Name: schema.Name, Schema: schema.JSON()},
+		}
+	}
+}
+
+// toOpenAIMessage 转换为 OpenAI 消息格式
+func toOpenAIMessage(msg *schema.Message) openAIMessage {
+	out := openAIMessage{
+		Role:       string(msg.Role),
+		Content:    msg.Content,
+		Name:       msg.Name,
+		ToolCallID: msg.ToolCallID,
+	}
+	for _, call := range msg.ToolCalls {
+		callType := call.Type
+		if callType == "" {
+			callType = "function"
+		}
+		out.ToolCalls = append(out.ToolCalls, openAIToolCall{
+			ID:   call.ID,
+			Type: callType,
+			Function: openAIFunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
+		})
+	}
+	return out
+}
+
+// fromOpenAIMessage 转换 OpenAI 响应消息(或流式增量)
+func fromOpenAIMessage(msg openAIMessage) *schema.Message {
+	out := &schema.Message{
+		Role:             schema.Assistant,
+		Content:          msg.Content,
+		ReasoningContent: msg.ReasoningContent,
+	}
+	for _, call := range msg.ToolCalls {
+		out.ToolCalls = append(out.ToolCalls, schema.ToolCall{
+			Index: call.Index,
+			ID:    call.ID,
+			Type:  call.Type,
+			Function: schema.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
+		})
+	}
+	return out
+}
+
+// toTokenUsage 转换用量，未返回用量时为 nil
+func (u *openAIUsage) toTokenUsage() *schema.TokenUsage {
+	if u == nil {
+		return nil
+	}
+	return &schema.TokenUsage{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
+
+// toolParameters 将工具参数转换为 JSON Schema，无参数时为空对象
+func toolParameters(tool *schema.ToolInfo) (json.RawMessage, error) {
+	if tool.ParamsOneOf == nil {
+		return json.RawMessage(`{"type":"object","properties":{}}`), nil
+	}
+	sc, err := tool.ParamsOneOf.ToJSONSchema()
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "工具参数转换失败: "+tool.Name, err)
+	}
+	raw, err := json.Marshal(sc)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "工具参数序列化失败: "+tool.Name, err)
+	}
+	return raw, nil
+}
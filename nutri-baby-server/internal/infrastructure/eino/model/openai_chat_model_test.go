@@ -0,0 +1,148 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+)
+
+var feedingRecordsTool = &schema.ToolInfo{
+	Name: "get_feeding_records",
+	Desc: "查询喂养记录",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"days": {Type: schema.Integer, Desc: "天数", Required: true},
+	}),
+}
+
+func TestOpenAIChatModelGenerate(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		assert.Equal(t, "tenant-1", r.Header.Get("X-Tenant"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{
+			"choices": [{
+				"message": {"role": "assistant", "content": null, "tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "get_feeding_records", "arguments": "{\"days\":7}"}}
+				]},
+				"finish_reason": "tool_calls"
+			}],
+			"usage": {"prompt_tokens": 30, "completion_tokens": 8, "total_tokens": 38}
+		}`)
+	}))
+	defer server.Close()
+
+	cm, err := NewOpenAIToolCallingChatModel(config.OpenAIConfig{
+		APIKey:    "sk-test",
+		BaseURL:   server.URL + "/v1/",
+		Model:     "qwen2.5-7b-instruct",
+		MaxTokens: 512,
+		Headers:   map[string]string{"X-Tenant": "tenant-1"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	bound, err := cm.WithTools([]*schema.ToolInfo{feedingRecordsTool})
+	require.NoError(t, err)
+
+	msg, err := bound.Generate(context.Background(), []*schema.Message{
+		schema.SystemMessage("你是育儿助手"),
+		schema.UserMessage("最近一周喂养情况"),
+		{Role: schema.Assistant, ToolCalls: []schema.ToolCall{{ID: "call_0", Function: schema.FunctionCall{Name: "noop", Arguments: "{}"}}}},
+		schema.ToolMessage("[]", "call_0"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "qwen2.5-7b-instruct", got["model"])
+	assert.EqualValues(t, 512, got["max_tokens"])
+	messages := got["messages"].([]any)
+	require.Len(t, messages, 4)
+	assert.Equal(t, "tool", messages[3].(map[string]any)["role"])
+	assert.Equal(t, "call_0", messages[3].(map[string]any)["tool_call_id"])
+	tools := got["tools"].([]any)
+	require.Len(t, tools, 1)
+	function := tools[0].(map[string]any)["function"].(map[string]any)
+	assert.Equal(t, "get_feeding_records", function["name"])
+	assert.Equal(t, "object", function["parameters"].(map[string]any)["type"])
+
+	require.Len(t, msg.ToolCalls, 1)
+	assert.Equal(t, "call_1", msg.ToolCalls[0].ID)
+	assert.Equal(t, `{"days":7}`, msg.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, "tool_calls", msg.ResponseMeta.FinishReason)
+	assert.Equal(t, 38, msg.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestOpenAIChatModelStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, true, req["stream"])
+		assert.Equal(t, map[string]any{"include_usage": true}, req["stream_options"])
+		// 自建服务未配置 api_key 时不发送认证头
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"choices":[{"delta":{"role":"assistant","content":"宝宝"}}]}`,
+			`{"choices":[{"delta":{"content":"状态良好"},"finish_reason":"stop"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":5,"total_tokens":17}}`,
+			`[DONE]`,
+		} {
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+	}))
+	defer server.Close()
+
+	cm, err := NewOpenAIToolCallingChatModel(config.OpenAIConfig{BaseURL: server.URL, Model: "llama3"}, zap.NewNop())
+	require.NoError(t, err)
+
+	reader, err := cm.Stream(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var chunks []*schema.Message
+	for {
+		chunk, err := reader.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	msg, err := schema.ConcatMessages(chunks)
+	require.NoError(t, err)
+	assert.Equal(t, "宝宝状态良好", msg.Content)
+	assert.Equal(t, "stop", msg.ResponseMeta.FinishReason)
+	assert.Equal(t, 17, msg.ResponseMeta.Usage.TotalTokens)
+}
+
+func TestOpenAIChatModelErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = io.WriteString(w, `{"error":{"type":"rate_limit_error","message":"slow down"}}`)
+	}))
+	defer server.Close()
+
+	cm, err := NewOpenAIToolCallingChatModel(config.OpenAIConfig{BaseURL: server.URL, Model: "gpt-4o-mini"}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = cm.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 429")
+	assert.Contains(t, err.Error(), "rate_limit_error: slow down")
+
+	_, err = NewOpenAIToolCallingChatModel(config.OpenAIConfig{BaseURL: server.URL}, zap.NewNop())
+	assert.Error(t, err)
+}
@@ -0,0 +1,255 @@
+package model
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ProviderHealth 提供商健康状态快照
+type ProviderHealth struct {
+	Provider            string
+	Model               string
+	State               string
+	ConsecutiveFailures int
+	TotalSuccesses      int64
+	TotalFailures       int64
+	LastError           string
+	LastSuccessAt       int64 // 毫秒时间戳，0 表示从未成功
+	LastFailureAt       int64 // 毫秒时间戳，0 表示从未失败
+	OpenUntil           int64 // 熔断结束时间(毫秒时间戳)，未熔断时为0
+	TimeoutSeconds      int
+	RateLimitPerMinute  int
+}
+
+// providerEntry 提供商链中的一个提供商
+// breaker/limiter 在 WithTools 产生的副本间共享，保证健康状态全局一致
+type providerEntry struct {
+	name      string
+	modelName string
+	model     model.ToolCallingChatModel
+	policy    config.AIProviderPolicy
+	breaker   *circuitBreaker
+	limiter   *rateLimiter
+}
+
+// ProviderChain 按优先级回退的多提供商聊天模型
+// 依次尝试各提供商: 熔断中或超出限流的提供商直接跳过，调用失败或超时则回退到下一个
+type ProviderChain struct {
+	entries []*providerEntry
+	logger  *zap.Logger
+}
+
+// NewProviderChain 按配置的提供商顺序创建提供商链，每个提供商都包装用量记录
+// 创建失败的提供商会被跳过，全部不可用时回退到 Mock 模型
+func NewProviderChain(cfg *config.Config, usageRepo repository.AIUsageRepository, offlineAnalyzer *offline.Analyzer, logger *zap.Logger) *ProviderChain {
+	aiConfig := cfg.AI
+	breakerCfg := aiConfig.CircuitBreaker
+	cooldown := time.Duration(breakerCfg.Cooldown) * time.Second
+
+	entries := make([]*providerEntry, 0, len(aiConfig.Providers)+1)
+	for _, name := range aiConfig.ProviderChain() {
+		cm, modelName, err := newProviderChatModel(aiConfig, name, offlineAnalyzer, logger)
+		if err != nil || cm == nil {
+			logger.Warn("AI模型提供商不可用，已从提供商链中跳过", zap.String("provider", name), zap.Error(err))
+			continue
+		}
+		policy := aiConfig.ProviderPolicies[name]
+		entries = append(entries, &providerEntry{
+			name:      name,
+			modelName: modelName,
+			model:     NewMeteredChatModel(cm, name, modelName, aiConfig.Pricing[name], usageRepo, logger),
+			policy:    policy,
+			breaker:   newCircuitBreaker(breakerCfg.FailureThreshold, cooldown),
+			limiter:   newRateLimiter(policy.RateLimit),
+		})
+	}
+
+	if len(entries) == 0 {
+		logger.Warn("没有可用的AI模型提供商，回退到支持工具调用的 Mock")
+		entries = append(entries, &providerEntry{
+			name:      "mock",
+			modelName: "mock",
+			model:     NewMeteredChatModel(chain.NewToolCallingMockChatModel(logger), "mock", "mock", aiConfig.Pricing["mock"], usageRepo, logger),
+			breaker:   newCircuitBreaker(breakerCfg.FailureThreshold, cooldown),
+			limiter:   newRateLimiter(0),
+		})
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	logger.Info("AI模型提供商链已就绪", zap.Strings("providers", names))
+
+	return &ProviderChain{entries: entries, logger: logger}
+}
+
+// Generate 依次尝试各提供商，返回第一个成功的结果
+func (c *ProviderChain) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	var lastErr error
+	for _, entry := range c.entries {
+		if !entry.acquire() {
+			continue
+		}
+
+		callCtx, cancel := entry.withTimeout(ctx)
+		response, err := entry.model.Generate(callCtx, input, opts...)
+		cancel()
+		if err == nil {
+			entry.breaker.Success()
+			chain.ReportProvider(ctx, entry.name)
+			return response, nil
+		}
+
+		// 调用方已取消时不再回退，也不计入提供商失败
+		if ctx.Err() != nil {
+			entry.breaker.Release()
+			return nil, err
+		}
+		// 离线引擎不处理该请求时直接交给下一个提供商，不计入失败
+		if errors.Is(err, offline.ErrNotApplicable) {
+			entry.breaker.Release()
+			lastErr = err
+			continue
+		}
+		entry.breaker.Failure(err)
+		c.logger.Warn("AI提供商调用失败，尝试下一个提供商", zap.String("provider", entry.name), zap.Error(err))
+		lastErr = err
+	}
+	return nil, c.exhaustedError(lastErr)
+}
+
+// Stream 依次尝试建立流，返回第一个成功建立的流
+// 流建立后不再回退，读取过程中的错误计入该提供商的失败
+func (c *ProviderChain) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	var lastErr error
+	for _, entry := range c.entries {
+		if !entry.acquire() {
+			continue
+		}
+
+		callCtx, cancel := entry.withTimeout(ctx)
+		reader, err := entry.model.Stream(callCtx, input, opts...)
+		if err != nil {
+			cancel()
+			if ctx.Err() != nil {
+				entry.breaker.Release()
+				return nil, err
+			}
+			if errors.Is(err, offline.ErrNotApplicable) {
+				entry.breaker.Release()
+				lastErr = err
+				continue
+			}
+			entry.breaker.Failure(err)
+			c.logger.Warn("AI提供商建立流失败，尝试下一个提供商", zap.String("provider", entry.name), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		chain.ReportProvider(ctx, entry.name)
+
+		// 超时覆盖整个流的读取过程，流结束后再释放
+		out, writer := schema.Pipe[*schema.Message](1)
+		go func(entry *providerEntry) {
+			defer cancel()
+			defer reader.Close()
+			defer writer.Close()
+
+			var streamErr error
+			for {
+				chunk, err := reader.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					streamErr = err
+					writer.Send(nil, err)
+					break
+				}
+				if closed := writer.Send(chunk, nil); closed {
+					break
+				}
+			}
+
+			switch {
+			case streamErr == nil:
+				entry.breaker.Success()
+			case ctx.Err() != nil:
+				entry.breaker.Release()
+			default:
+				entry.breaker.Failure(streamErr)
+				c.logger.Warn("AI提供商流式响应中断", zap.String("provider", entry.name), zap.Error(streamErr))
+			}
+		}(entry)
+
+		return out, nil
+	}
+	return nil, c.exhaustedError(lastErr)
+}
+
+// WithTools 为链上每个提供商绑定工具，健康状态与原链共享
+func (c *ProviderChain) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	entries := make([]*providerEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		bound, err := entry.model.WithTools(tools)
+		if err != nil {
+			return nil, err
+		}
+		copied := *entry
+		copied.model = bound
+		entries = append(entries, &copied)
+	}
+	return &ProviderChain{entries: entries, logger: c.logger}, nil
+}
+
+// Health 返回各提供商的健康状态，顺序与回退顺序一致
+func (c *ProviderChain) Health() []ProviderHealth {
+	health := make([]ProviderHealth, 0, len(c.entries))
+	for _, entry := range c.entries {
+		item := entry.breaker.snapshot()
+		item.Provider = entry.name
+		item.Model = entry.modelName
+		item.TimeoutSeconds = entry.policy.Timeout
+		item.RateLimitPerMinute = entry.policy.RateLimit
+		health = append(health, item)
+	}
+	return health
+}
+
+// exhaustedError 所有提供商都不可用时的错误
+func (c *ProviderChain) exhaustedError(lastErr error) error {
+	if lastErr != nil {
+		return errors.Wrap(errors.InternalError, "所有AI模型提供商调用失败", lastErr)
+	}
+	return errors.New(errors.InternalError, "AI模型提供商均处于熔断或限流中，请稍后再试")
+}
+
+// acquire 判断提供商能否接受本次调用
+func (e *providerEntry) acquire() bool {
+	if !e.breaker.Allow() {
+		return false
+	}
+	if !e.limiter.Allow() {
+		e.breaker.Release()
+		return false
+	}
+	return true
+}
+
+// withTimeout 应用提供商的调用超时
+func (e *providerEntry) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.policy.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(e.policy.Timeout)*time.Second)
+}
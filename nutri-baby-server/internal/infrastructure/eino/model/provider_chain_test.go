@@ -0,0 +1,195 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
+)
+
+// scriptedChatModel 按设定失败或阻塞，并统计调用次数
+type scriptedChatModel struct {
+	content string
+	fail    atomic.Bool
+	block   bool // 阻塞直到 context 结束
+	calls   atomic.Int32
+}
+
+func (m *scriptedChatModel) Generate(ctx context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.calls.Add(1)
+	if m.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if m.fail.Load() {
+		return nil, errors.New(m.content + " unavailable")
+	}
+	return schema.AssistantMessage(m.content, nil), nil
+}
+
+func (m *scriptedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func (m *scriptedChatModel) WithTools(_ []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
+
+func newTestProviderChain(models map[string]*scriptedChatModel, order []string, policies map[string]config.AIProviderPolicy) *ProviderChain {
+	entries := make([]*providerEntry, 0, len(order))
+	for _, name := range order {
+		entries = append(entries, &providerEntry{
+			name:      name,
+			modelName: name + "-model",
+			model:     models[name],
+			policy:    policies[name],
+			breaker:   newCircuitBreaker(2, time.Minute),
+			limiter:   newRateLimiter(policies[name].RateLimit),
+		})
+	}
+	return &ProviderChain{entries: entries, logger: zap.NewNop()}
+}
+
+func TestProviderChainFailoverAndCircuitBreaker(t *testing.T) {
+	primary := &scriptedChatModel{content: "primary"}
+	backup := &scriptedChatModel{content: "backup"}
+	primary.fail.Store(true)
+	providers := newTestProviderChain(map[string]*scriptedChatModel{"primary": primary, "backup": backup},
+		[]string{"primary", "backup"}, nil)
+
+	// 连续两次失败后熔断，之后不再调用首选提供商
+	for i := 0; i < 3; i++ {
+		msg, err := providers.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+		require.NoError(t, err)
+		assert.Equal(t, "backup", msg.Content)
+	}
+	assert.Equal(t, int32(2), primary.calls.Load())
+	assert.Equal(t, int32(3), backup.calls.Load())
+
+	health := providers.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, CircuitOpen, health[0].State)
+	assert.Equal(t, 2, health[0].ConsecutiveFailures)
+	assert.Equal(t, "primary unavailable", health[0].LastError)
+	assert.NotZero(t, health[0].OpenUntil)
+	assert.Equal(t, CircuitClosed, health[1].State)
+
+	// 冷却期结束后放行一次探测调用，成功则恢复
+	breaker := providers.entries[0].breaker
+	breaker.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	primary.fail.Store(false)
+
+	bound, err := providers.WithTools(nil)
+	require.NoError(t, err)
+	msg, err := bound.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	assert.Equal(t, "primary", msg.Content)
+	assert.Equal(t, CircuitClosed, providers.Health()[0].State)
+}
+
+func TestProviderChainHalfOpenProbeFailureReopens(t *testing.T) {
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.Failure(errors.New("boom"))
+	assert.False(t, breaker.Allow())
+
+	breaker.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	assert.True(t, breaker.Allow())
+	assert.False(t, breaker.Allow(), "半开状态只放行一次探测")
+
+	breaker.Failure(errors.New("still down"))
+	breaker.now = time.Now
+	assert.False(t, breaker.Allow())
+	assert.Equal(t, CircuitOpen, breaker.snapshot().State)
+}
+
+func TestProviderChainTimeoutAndRateLimit(t *testing.T) {
+	slow := &scriptedChatModel{content: "slow", block: true}
+	limited := &scriptedChatModel{content: "limited"}
+	backup := &scriptedChatModel{content: "backup"}
+	providers := newTestProviderChain(
+		map[string]*scriptedChatModel{"slow": slow, "limited": limited, "backup": backup},
+		[]string{"slow", "limited", "backup"},
+		map[string]config.AIProviderPolicy{
+			"slow":    {Timeout: 1},
+			"limited": {RateLimit: 1},
+		},
+	)
+
+	// 首选超时后回退
+	msg, err := providers.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	assert.Equal(t, "limited", msg.Content)
+
+	// 限流额度用完后直接跳到下一个提供商
+	reader, err := providers.Stream(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+	require.NoError(t, err)
+	chunk, err := reader.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "backup", chunk.Content)
+	reader.Close()
+
+	assert.Equal(t, int32(1), limited.calls.Load())
+	assert.Equal(t, int32(2), slow.calls.Load())
+}
+
+func TestProviderChainCallerCancelDoesNotTripBreaker(t *testing.T) {
+	slow := &scriptedChatModel{content: "slow", block: true}
+	backup := &scriptedChatModel{content: "backup"}
+	providers := newTestProviderChain(map[string]*scriptedChatModel{"slow": slow, "backup": backup},
+		[]string{"slow", "backup"}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := providers.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	require.Error(t, err)
+	assert.Zero(t, backup.calls.Load())
+	assert.Zero(t, providers.Health()[0].ConsecutiveFailures)
+}
+
+func TestProviderChainExhausted(t *testing.T) {
+	only := &scriptedChatModel{content: "only"}
+	only.fail.Store(true)
+	providers := newTestProviderChain(map[string]*scriptedChatModel{"only": only}, []string{"only"}, nil)
+
+	_, err := providers.Generate(context.Background(), nil)
+	assert.ErrorContains(t, err, "所有AI模型提供商调用失败")
+	_, _ = providers.Generate(context.Background(), nil)
+	_, err = providers.Generate(context.Background(), nil)
+	assert.ErrorContains(t, err, "熔断或限流")
+}
+
+func TestProviderChainSkipsOfflineForNonAnalysisCalls(t *testing.T) {
+	backup := &scriptedChatModel{content: "backup"}
+	providers := newTestProviderChain(map[string]*scriptedChatModel{"backup": backup}, []string{"backup"}, nil)
+	providers.entries = append([]*providerEntry{{
+		name:      "offline",
+		modelName: "offline-rules",
+		model:     offline.NewChatModel(nil),
+		breaker:   newCircuitBreaker(1, time.Minute),
+		limiter:   newRateLimiter(0),
+	}}, providers.entries...)
+
+	// 对话请求没有分析任务，离线引擎跳过且不计入熔断
+	for i := 0; i < 2; i++ {
+		msg, err := providers.Generate(context.Background(), []*schema.Message{schema.UserMessage("hi")})
+		require.NoError(t, err)
+		assert.Equal(t, "backup", msg.Content)
+	}
+	health := providers.Health()
+	assert.Equal(t, CircuitClosed, health[0].State)
+	assert.Zero(t, health[0].ConsecutiveFailures)
+}
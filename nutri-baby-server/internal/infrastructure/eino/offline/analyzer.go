@@ -0,0 +1,207 @@
+// Package offline 基于规则与统计的离线分析引擎
+//
+// 直接读取仓储中的记录，按月龄参考范围计算评分、模式、警告和预测，
+// 产出与大模型分析相同结构的 AIAnalysisResult，数据不离开本服务。
+package offline
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// maxRecords 单次分析每类记录的最大读取数量
+const maxRecords = 5000
+
+// ErrNotApplicable 离线分析引擎无法处理本次调用(非分析类请求、不支持的分析类型或缺少数据)
+// 提供商链遇到该错误时直接尝试下一个提供商，不计入熔断失败
+var ErrNotApplicable = errors.New(errors.InternalError, "离线分析引擎无法处理该请求")
+
+// Analyzer 离线分析引擎
+type Analyzer struct {
+	babyRepo        repository.BabyRepository
+	feedingRepo     repository.FeedingRecordRepository
+	sleepRepo       repository.SleepRecordRepository
+	diaperRepo      repository.DiaperRecordRepository
+	growthRepo      repository.GrowthRecordRepository
+	vaccineRepo     repository.BabyVaccineScheduleRepository
+	healthAlertRepo repository.HealthAlertRepository
+	logger          *zap.Logger
+	now             func() time.Time
+}
+
+// NewAnalyzer 创建离线分析引擎
+func NewAnalyzer(
+	babyRepo repository.BabyRepository,
+	feedingRepo repository.FeedingRecordRepository,
+	sleepRepo repository.SleepRecordRepository,
+	diaperRepo repository.DiaperRecordRepository,
+	growthRepo repository.GrowthRecordRepository,
+	vaccineRepo repository.BabyVaccineScheduleRepository,
+	healthAlertRepo repository.HealthAlertRepository,
+	logger *zap.Logger,
+) *Analyzer {
+	return &Analyzer{
+		babyRepo:        babyRepo,
+		feedingRepo:     feedingRepo,
+		sleepRepo:       sleepRepo,
+		diaperRepo:      diaperRepo,
+		growthRepo:      growthRepo,
+		vaccineRepo:     vaccineRepo,
+		healthAlertRepo: healthAlertRepo,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// Analyze 分析宝宝在 [startDate, endDate] (按日，含结束日) 期间的数据
+func (a *Analyzer) Analyze(ctx context.Context, babyID int64, analysisType entity.AIAnalysisType, startDate, endDate time.Time) (*entity.AIAnalysisResult, error) {
+	if !supports(analysisType) {
+		return nil, errors.Wrap(errors.InternalError, "离线分析引擎不支持分析类型 "+string(analysisType), ErrNotApplicable)
+	}
+
+	d, err := a.load(ctx, babyID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := analyze(d, analysisType)
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("离线分析完成",
+		zap.Int64("baby_id", babyID),
+		zap.String("analysis_type", string(analysisType)),
+		zap.Float64("score", result.Score),
+	)
+	return result, nil
+}
+
+// supports 离线分析引擎支持的分析类型
+func supports(analysisType entity.AIAnalysisType) bool {
+	switch analysisType {
+	case entity.AIAnalysisTypeFeeding, entity.AIAnalysisTypeSleep,
+		entity.AIAnalysisTypeGrowth, entity.AIAnalysisTypeHealth:
+		return true
+	default:
+		return false
+	}
+}
+
+// dataset 一次离线分析使用的数据，记录均按时间升序
+type dataset struct {
+	baby    *entity.Baby
+	loc     *time.Location
+	birth   time.Time // 出生日期，未知时为零值
+	start   time.Time // 分析区间开始(含)
+	end     time.Time // 分析区间结束(不含)，不晚于当前时间
+	elapsed int       // 分析区间内已经过的天数(不足一天按一天)
+
+	feedings     []*entity.FeedingRecord
+	sleeps       []*entity.SleepRecord
+	diapers      []*entity.DiaperRecord
+	growth       []*entity.GrowthRecord // 截至区间结束的全部测量，用于计算增速
+	vaccines     []*entity.BabyVaccineSchedule
+	healthAlerts []*entity.HealthAlert
+}
+
+// newDataset 按宝宝时区把日期区间换算为时间区间
+func newDataset(baby *entity.Baby, startDate, endDate, now time.Time) *dataset {
+	loc := baby.Location()
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	if now.Before(end) {
+		end = now
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	elapsed := int(end.Sub(start).Hours()/24 + 0.999)
+	if elapsed < 1 {
+		elapsed = 1
+	}
+
+	d := &dataset{baby: baby, loc: loc, start: start, end: end, elapsed: elapsed}
+	if birth, err := time.ParseInLocation("2006-01-02", baby.BirthDate, loc); err == nil {
+		d.birth = birth
+	}
+	return d
+}
+
+// ageDays 区间结束时的日龄，出生日期未知时返回 false
+func (d *dataset) ageDays() (int, bool) {
+	if d.birth.IsZero() {
+		return 0, false
+	}
+	days := int(d.end.Sub(d.birth).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+	return days, true
+}
+
+// dayKey 记录所在的本地日期
+func (d *dataset) dayKey(ms int64) string {
+	return time.UnixMilli(ms).In(d.loc).Format("2006-01-02")
+}
+
+// load 读取分析所需的全部数据
+func (a *Analyzer) load(ctx context.Context, babyID int64, startDate, endDate time.Time) (*dataset, error) {
+	baby, err := a.babyRepo.FindByID(ctx, babyID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDataset(baby, startDate, endDate, a.now())
+	startMs, endMs := d.start.UnixMilli(), d.end.UnixMilli()-1
+
+	if d.feedings, _, err = a.feedingRepo.FindByBabyID(ctx, babyID, startMs, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if d.sleeps, _, err = a.sleepRepo.FindByBabyID(ctx, babyID, startMs, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if d.diapers, _, err = a.diaperRepo.FindByBabyID(ctx, babyID, startMs, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if d.growth, _, err = a.growthRepo.FindByBabyID(ctx, babyID, 0, endMs, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if d.vaccines, err = a.vaccineRepo.FindByBabyID(ctx, babyID, 1, maxRecords); err != nil {
+		return nil, err
+	}
+	if d.healthAlerts, err = a.healthAlertRepo.FindByBabyID(ctx, babyID, startMs, maxRecords); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(d.feedings, func(i, j int) bool { return d.feedings[i].Time < d.feedings[j].Time })
+	sort.Slice(d.sleeps, func(i, j int) bool { return d.sleeps[i].StartTime < d.sleeps[j].StartTime })
+	sort.Slice(d.diapers, func(i, j int) bool { return d.diapers[i].Time < d.diapers[j].Time })
+	sort.Slice(d.growth, func(i, j int) bool { return d.growth[i].Time < d.growth[j].Time })
+	return d, nil
+}
+
+// analyze 按分析类型生成结果
+func analyze(d *dataset, analysisType entity.AIAnalysisType) (*entity.AIAnalysisResult, error) {
+	var r *report
+	switch analysisType {
+	case entity.AIAnalysisTypeFeeding:
+		r = analyzeFeeding(d)
+	case entity.AIAnalysisTypeSleep:
+		r = analyzeSleep(d)
+	case entity.AIAnalysisTypeGrowth:
+		r = analyzeGrowth(d)
+	case entity.AIAnalysisTypeHealth:
+		r = analyzeHealth(d)
+	}
+	if r == nil {
+		return nil, errors.Wrap(errors.InternalError, "分析区间内没有可用于离线分析的记录", ErrNotApplicable)
+	}
+	return r.result(d, analysisType), nil
+}
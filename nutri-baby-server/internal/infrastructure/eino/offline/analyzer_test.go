@@ -0,0 +1,157 @@
+package offline
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/structured"
+)
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// newTestDataset 出生约两个月的宝宝，分析 2026-10-01 至 2026-10-07
+func newTestDataset() *dataset {
+	baby := &entity.Baby{ID: 1, Nickname: "小满", BirthDate: "2026-08-01", Timezone: "Asia/Shanghai"}
+	return newDataset(baby,
+		time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC),
+		testNow)
+}
+
+// addBottleFeeds 每天按固定间隔添加瓶喂记录
+func addBottleFeeds(d *dataset, perDay int, amount int64) {
+	interval := 24 * time.Hour / time.Duration(perDay)
+	for day := 0; day < d.elapsed; day++ {
+		for i := 0; i < perDay; i++ {
+			at := d.start.AddDate(0, 0, day).Add(time.Duration(i) * interval)
+			d.feedings = append(d.feedings, &entity.FeedingRecord{Time: at.UnixMilli(), FeedingType: "bottle", Amount: amount})
+		}
+	}
+}
+
+func addWeight(d *dataset, at time.Time, kg float64) {
+	d.growth = append(d.growth, &entity.GrowthRecord{Time: at.UnixMilli(), Weight: &kg})
+}
+
+func TestNewDataset_UsesBabyTimezoneAndInclusiveEnd(t *testing.T) {
+	d := newTestDataset()
+
+	assert.Equal(t, "2026-10-01T00:00:00+08:00", d.start.Format(time.RFC3339))
+	assert.Equal(t, "2026-10-08T00:00:00+08:00", d.end.Format(time.RFC3339))
+	assert.Equal(t, 7, d.elapsed)
+	ageDays, ok := d.ageDays()
+	require.True(t, ok)
+	assert.Equal(t, 68, ageDays)
+}
+
+func TestAnalyzeFeeding_WithinReference(t *testing.T) {
+	d := newTestDataset()
+	addBottleFeeds(d, 8, 120)
+	addWeight(d, d.start.AddDate(0, 0, -20), 5.0)
+	addWeight(d, d.start, 5.5)
+
+	result, err := analyze(d, entity.AIAnalysisTypeFeeding)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(100), result.Score)
+	assert.Empty(t, result.Alerts)
+	assert.NotEmpty(t, result.UserFriendly.KeyHighlights)
+}
+
+func TestAnalyzeFeeding_TooFewFeeds(t *testing.T) {
+	d := newTestDataset()
+	addBottleFeeds(d, 3, 120)
+
+	result, err := analyze(d, entity.AIAnalysisTypeFeeding)
+	require.NoError(t, err)
+
+	assert.Less(t, result.Score, float64(80))
+	require.NotEmpty(t, result.Alerts)
+	assert.Equal(t, "feeding", result.Alerts[0].Type)
+	assert.NotEmpty(t, result.UserFriendly.ImprovementAreas)
+}
+
+func TestAnalyzeGrowth_WeightLoss(t *testing.T) {
+	d := newTestDataset()
+	addWeight(d, d.start.AddDate(0, 0, -14), 5.6)
+	addWeight(d, d.start.AddDate(0, 0, 3), 5.4)
+
+	result, err := analyze(d, entity.AIAnalysisTypeGrowth)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, result.Alerts)
+	assert.Equal(t, "体重下降", result.Alerts[0].Title)
+	assert.Equal(t, float64(75), result.Score)
+}
+
+func TestAnalyze_NoRecordsIsNotApplicable(t *testing.T) {
+	_, err := analyze(newTestDataset(), entity.AIAnalysisTypeHealth)
+
+	assert.True(t, stderrors.Is(err, ErrNotApplicable))
+}
+
+func TestVelocityPair(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []measurement{
+		{at: base, value: 4.0},
+		{at: base.AddDate(0, 0, 30), value: 5.0},
+		{at: base.AddDate(0, 0, 80), value: 6.0},
+		{at: base.AddDate(0, 0, 85), value: 6.1},
+	}
+
+	from, to, ok := velocityPair(points)
+	require.True(t, ok)
+	assert.Equal(t, 5.0, from.value, "取回看窗口内最早的一次")
+	assert.Equal(t, 6.1, to.value)
+
+	_, _, ok = velocityPair(points[2:])
+	assert.False(t, ok, "间隔不足一周时不计算增速")
+}
+
+func TestOutputMatchesStructuredSchemas(t *testing.T) {
+	d := newTestDataset()
+	addBottleFeeds(d, 3, 90)
+	addWeight(d, d.start.AddDate(0, 0, -14), 5.6)
+	addWeight(d, d.start.AddDate(0, 0, 3), 5.4)
+	for day := 0; day < d.elapsed; day++ {
+		at := d.start.AddDate(0, 0, day).Add(20 * time.Hour)
+		d.diapers = append(d.diapers, &entity.DiaperRecord{Time: at.UnixMilli(), Type: "pee"})
+	}
+
+	result, err := analyze(d, entity.AIAnalysisTypeHealth)
+	require.NoError(t, err)
+	raw, err := json.Marshal(analysisOutput{
+		Score:        result.Score,
+		Insights:     result.Insights,
+		Alerts:       result.Alerts,
+		Patterns:     result.Patterns,
+		Predictions:  result.Predictions,
+		UserFriendly: result.UserFriendly,
+	})
+	require.NoError(t, err)
+	var parsed entity.AIAnalysisResult
+	assert.Empty(t, structured.AnalysisResult.Validate(raw, &parsed))
+
+	tips := dailyTips(d)
+	require.NotEmpty(t, tips)
+	assert.LessOrEqual(t, len(tips), maxDailyTips)
+	assert.Equal(t, priorityMedium, tips[0].Priority, "警告排在表现良好的方面之前")
+	raw, err = json.Marshal(map[string][]entity.DailyTip{"tips": tips})
+	require.NoError(t, err)
+	var parsedTips struct {
+		Tips []entity.DailyTip `json:"tips"`
+	}
+	assert.Empty(t, structured.DailyTips.Validate(raw, &parsedTips))
+}
+
+func TestDailyTips_AlwaysReturnsOne(t *testing.T) {
+	tips := dailyTips(newTestDataset())
+
+	require.Len(t, tips, 1)
+	assert.Equal(t, "offline_1", tips[0].ID)
+}
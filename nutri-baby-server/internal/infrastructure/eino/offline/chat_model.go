@@ -0,0 +1,86 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// ChatModel 把离线分析引擎适配为聊天模型，作为提供商链中的 offline 提供商
+// 不理解提示词也不调用工具: 从 context 读取分析链写入的任务，直接返回符合结构化输出 schema 的结果JSON
+type ChatModel struct {
+	analyzer *Analyzer
+}
+
+// NewChatModel 创建离线分析聊天模型
+func NewChatModel(analyzer *Analyzer) *ChatModel {
+	return &ChatModel{analyzer: analyzer}
+}
+
+// analysisOutput 分析结果中属于结构化输出 schema 的部分
+type analysisOutput struct {
+	Score        float64                    `json:"score"`
+	Insights     []entity.AIInsight         `json:"insights"`
+	Alerts       []entity.AIAlert           `json:"alerts"`
+	Patterns     []entity.AIPattern         `json:"patterns"`
+	Predictions  []entity.AIPrediction      `json:"predictions"`
+	UserFriendly *entity.UserFriendlyResult `json:"user_friendly,omitempty"`
+}
+
+// Generate 执行 context 中的分析任务，没有任务(对话、快速记录等)时返回 ErrNotApplicable
+func (m *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	task, ok := chain.AnalysisTaskFrom(ctx)
+	if !ok {
+		return nil, errors.Wrap(errors.InternalError, "离线分析引擎只支持数据分析和每日建议", ErrNotApplicable)
+	}
+
+	var output any
+	switch task.Kind {
+	case chain.AnalysisTaskAnalysis:
+		result, err := m.analyzer.Analyze(ctx, task.BabyID, task.AnalysisType, task.StartDate, task.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		output = analysisOutput{
+			Score:        result.Score,
+			Insights:     result.Insights,
+			Alerts:       result.Alerts,
+			Patterns:     result.Patterns,
+			Predictions:  result.Predictions,
+			UserFriendly: result.UserFriendly,
+		}
+	case chain.AnalysisTaskDailyTips:
+		tips, err := m.analyzer.DailyTips(ctx, task.BabyID, task.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		output = map[string][]entity.DailyTip{"tips": tips}
+	default:
+		return nil, errors.Wrap(errors.InternalError, "离线分析引擎不支持任务类型 "+task.Kind, ErrNotApplicable)
+	}
+
+	content, err := json.Marshal(output)
+	if err != nil {
+		return nil, errors.Wrap(errors.InternalError, "序列化离线分析结果失败", err)
+	}
+	return schema.AssistantMessage(string(content), nil), nil
+}
+
+// Stream 离线结果一次性生成，以单个分片返回
+func (m *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	message, err := m.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{message}), nil
+}
+
+// WithTools 离线引擎直接读取仓储，不使用工具
+func (m *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return m, nil
+}
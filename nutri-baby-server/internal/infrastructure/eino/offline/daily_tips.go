@@ -0,0 +1,110 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// dailyTipsLookbackDays 生成每日建议时回看的天数(含当天)
+	dailyTipsLookbackDays = 7
+	// maxDailyTips 每日建议的最大条数
+	maxDailyTips = 5
+	// minDailyTips 警告不足时用表现良好的方面补足到该条数
+	minDailyTips = 3
+)
+
+// tipIcons 各类建议的图标
+var tipIcons = map[string]string{
+	string(entity.AIAnalysisTypeFeeding): "🍼",
+	string(entity.AIAnalysisTypeSleep):   "😴",
+	string(entity.AIAnalysisTypeGrowth):  "📏",
+	string(entity.AIAnalysisTypeHealth):  "🩺",
+}
+
+// tipActionURLs 各类建议的跳转页面
+var tipActionURLs = map[string]string{
+	string(entity.AIAnalysisTypeFeeding): "/pages/record/feeding/index",
+	string(entity.AIAnalysisTypeSleep):   "/pages/record/sleep/index",
+}
+
+// DailyTips 根据最近7天的数据生成每日建议
+func (a *Analyzer) DailyTips(ctx context.Context, babyID int64, date time.Time) ([]entity.DailyTip, error) {
+	d, err := a.load(ctx, babyID, date.AddDate(0, 0, 1-dailyTipsLookbackDays), date)
+	if err != nil {
+		return nil, err
+	}
+	return dailyTips(d), nil
+}
+
+// dailyTips 警告按严重程度转为建议，不足时补充表现良好的方面，始终至少返回一条
+func dailyTips(d *dataset) []entity.DailyTip {
+	var tips, positives []entity.DailyTip
+	for _, r := range []*report{analyzeFeeding(d), analyzeSleep(d), analyzeGrowth(d)} {
+		if r == nil {
+			continue
+		}
+		for _, alert := range r.alerts {
+			tips = append(tips, entity.DailyTip{
+				Icon:        tipIcons[r.kind],
+				Title:       alert.Title,
+				Description: alert.Description + "。" + alert.Suggestion,
+				Type:        r.kind,
+				Priority:    levelPriority(alert.Level),
+				ActionURL:   tipActionURLs[r.kind],
+			})
+		}
+		for _, highlight := range r.highlights {
+			positives = append(positives, entity.DailyTip{
+				Icon:        tipIcons[r.kind],
+				Title:       highlight.Title,
+				Description: highlight.Description + "，继续保持",
+				Type:        r.kind,
+				Priority:    priorityLow,
+				ActionURL:   tipActionURLs[r.kind],
+			})
+		}
+	}
+
+	sort.SliceStable(tips, func(i, j int) bool {
+		return priorityRank(tips[i].Priority) < priorityRank(tips[j].Priority)
+	})
+	for _, tip := range positives {
+		if len(tips) >= minDailyTips {
+			break
+		}
+		tips = append(tips, tip)
+	}
+	if len(tips) == 0 {
+		tips = append(tips, entity.DailyTip{
+			Icon:        tipIcons[string(entity.AIAnalysisTypeHealth)],
+			Title:       "坚持记录",
+			Description: "最近记录较少，坚持记录喂养、睡眠和成长数据，才能给出更有针对性的建议",
+			Type:        string(entity.AIAnalysisTypeHealth),
+			Priority:    priorityMedium,
+		})
+	}
+	if len(tips) > maxDailyTips {
+		tips = tips[:maxDailyTips]
+	}
+	for i := range tips {
+		tips[i].ID = fmt.Sprintf("offline_%d", i+1)
+	}
+	return tips
+}
+
+// priorityRank 优先级排序(高的在前)
+func priorityRank(priority string) int {
+	switch priority {
+	case priorityHigh:
+		return 0
+	case priorityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
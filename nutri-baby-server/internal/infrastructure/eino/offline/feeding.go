@@ -0,0 +1,177 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// maxCountedGapHours 超过该间隔视为漏记，不参与间隔统计
+	maxCountedGapHours = 12
+	// regularIntervalCV 喂奶间隔波动系数不超过该值视为规律
+	regularIntervalCV = 0.35
+	// intakeTolerance 奶量超出参考范围该比例以上才提示，避免单日波动误报
+	intakeTolerance = 0.15
+	// solidsMinAgeDays 最早建议添加辅食的日龄(约4月龄)
+	solidsMinAgeDays = 120
+)
+
+// analyzeFeeding 喂养分析: 喂奶次数、奶量、间隔规律、夜奶和辅食
+func analyzeFeeding(d *dataset) *report {
+	if len(d.feedings) == 0 {
+		return nil
+	}
+	r := newReport(entity.AIAnalysisTypeFeeding, "喂养")
+	ageDays, ageKnown := d.ageDays()
+	ref := feedingReferenceFor(ageDays)
+
+	var milk []*entity.FeedingRecord
+	solids, bottleFeeds, nightFeeds := 0, 0, 0
+	recordedDays := make(map[string]bool)
+	milkDays := make(map[string]int)
+	bottleMl := make(map[string]float64)
+	for _, record := range d.feedings {
+		day := d.dayKey(record.Time)
+		recordedDays[day] = true
+		if record.FeedingType == entity.FeedingTypeFood {
+			solids++
+			continue
+		}
+		milk = append(milk, record)
+		milkDays[day]++
+		if record.FeedingType == entity.FeedingTypeBottle && record.Amount > 0 {
+			bottleFeeds++
+			bottleMl[day] += float64(record.Amount)
+		}
+		if hour := time.UnixMilli(record.Time).In(d.loc).Hour(); hour >= 22 || hour < 6 {
+			nightFeeds++
+		}
+	}
+	r.checkCoverage(d, len(recordedDays))
+
+	avgFeeds := 0.0
+	if len(milkDays) > 0 {
+		avgFeeds = float64(len(milk)) / float64(len(milkDays))
+	}
+	r.insight("统计", "喂养概况",
+		fmt.Sprintf("共记录%d次奶类喂养、%d次辅食，有记录的日子平均每天喂奶%.1f次", len(milk), solids, avgFeeds), priorityLow)
+
+	// 喂奶次数
+	if len(milk) > 0 && ageKnown {
+		rangeText := fmt.Sprintf("%d-%d次", ref.minFeeds, ref.maxFeeds)
+		switch {
+		case avgFeeds < float64(ref.minFeeds):
+			r.deduct(math.Min(20, (float64(ref.minFeeds)-avgFeeds)*5), "喂奶次数偏少")
+			r.alert(levelWarning, "喂奶次数偏少",
+				fmt.Sprintf("平均每天喂奶%.1f次，低于该月龄建议的%s", avgFeeds, rangeText),
+				"按需喂养，留意觅食、吸吮手指等饥饿信号，可适当缩短喂奶间隔", d.end)
+		case avgFeeds > float64(ref.maxFeeds):
+			r.deduct(math.Min(10, (avgFeeds-float64(ref.maxFeeds))*3), "喂奶次数偏多")
+			r.alert(levelInfo, "喂奶次数偏多",
+				fmt.Sprintf("平均每天喂奶%.1f次，多于该月龄常见的%s", avgFeeds, rangeText),
+				"观察每次是否吃饱，避免把安抚当作喂奶", d.end)
+		default:
+			r.highlight("喂奶次数合适", fmt.Sprintf("平均每天喂奶%.1f次，在该月龄建议的%s范围内", avgFeeds, rangeText), "🍼")
+		}
+	}
+
+	// 奶量: 以奶瓶喂养为主且有体重数据时按每公斤奶量评估
+	weight := latestWeight(d)
+	if ref.minMlPerKg > 0 && weight > 0 && len(bottleMl) > 0 && float64(bottleFeeds) >= 0.8*float64(len(milk)) {
+		total := 0.0
+		for _, ml := range bottleMl {
+			total += ml
+		}
+		avgMl := total / float64(len(bottleMl))
+		perKg := avgMl / weight
+		rangeText := fmt.Sprintf("每公斤%.0f-%.0fml", ref.minMlPerKg, ref.maxMlPerKg)
+		switch {
+		case perKg < ref.minMlPerKg*(1-intakeTolerance):
+			r.deduct(math.Min(20, (ref.minMlPerKg-perKg)/ref.minMlPerKg*60), "奶量偏少")
+			r.alert(levelWarning, "奶量偏少",
+				fmt.Sprintf("平均每天奶量约%.0fml(每公斤%.0fml)，低于参考的%s", avgMl, perKg, rangeText),
+				"可少量多次增加奶量；若同时尿量减少或体重增长慢，请咨询医生", d.end)
+		case perKg > ref.maxMlPerKg*(1+intakeTolerance):
+			r.deduct(math.Min(10, (perKg-ref.maxMlPerKg)/ref.maxMlPerKg*30), "奶量偏多")
+			r.alert(levelInfo, "奶量偏多",
+				fmt.Sprintf("平均每天奶量约%.0fml(每公斤%.0fml)，高于参考的%s", avgMl, perKg, rangeText),
+				"留意是否有吐奶、腹胀，避免过度喂养", d.end)
+		default:
+			r.highlight("奶量充足", fmt.Sprintf("平均每天奶量约%.0fml(每公斤%.0fml)，符合参考的%s", avgMl, perKg, rangeText), "🥛")
+		}
+		r.predict("daily_intake", fmt.Sprintf("约%.0fml", avgMl), 0.7, "未来一周", "按近期平均每日奶量估算")
+	}
+
+	// 喂奶间隔
+	var intervals []float64
+	maxGap, maxGapAt := 0.0, int64(0)
+	for i := 1; i < len(milk); i++ {
+		gap := float64(milk[i].Time-milk[i-1].Time) / float64(time.Hour/time.Millisecond)
+		if gap <= 0 || gap > maxCountedGapHours {
+			continue
+		}
+		intervals = append(intervals, gap)
+		if gap > maxGap {
+			maxGap, maxGapAt = gap, milk[i-1].Time
+		}
+	}
+	if len(intervals) >= 3 {
+		mid := median(intervals)
+		cv := stddev(intervals) / mean(intervals)
+		confidence := clamp(1-cv, 0.3, 0.95)
+		if cv <= regularIntervalCV {
+			r.highlight("喂养间隔规律", fmt.Sprintf("喂奶间隔集中在%.1f小时左右，节奏稳定", mid), "⏰")
+			r.pattern("regular_feeding", fmt.Sprintf("喂奶间隔较规律，中位数约%.1f小时", mid), confidence, "每天", d.start, d.end)
+		} else {
+			r.deduct(math.Min(15, (cv-regularIntervalCV)*30), "喂奶间隔不规律")
+			r.insight("规律性", "喂奶间隔不太规律",
+				fmt.Sprintf("喂奶间隔中位数约%.1f小时，但前后波动较大，可尝试在相对固定的时段喂奶，逐步建立规律", mid), priorityMedium)
+			r.pattern("irregular_feeding", fmt.Sprintf("喂奶间隔波动较大(中位数约%.1f小时，波动系数%.2f)", mid, cv), confidence, "每天", d.start, d.end)
+		}
+
+		last := milk[len(milk)-1]
+		next := time.UnixMilli(last.Time).Add(time.Duration(mid * float64(time.Hour))).In(d.loc)
+		r.predict("next_feeding", "约 "+next.Format("2006-01-02 15:04"), confidence, "下一次喂奶",
+			fmt.Sprintf("按近期喂奶间隔中位数%.1f小时推算", mid))
+
+		if ageKnown && ref.maxGapHour > 0 && maxGap > ref.maxGapHour {
+			r.deduct(10, "喂奶间隔过长")
+			r.alert(levelWarning, "喂奶间隔过长",
+				fmt.Sprintf("%s 起出现约%.1f小时未喂奶，超过该月龄建议的%.0f小时",
+					time.UnixMilli(maxGapAt).In(d.loc).Format("01-02 15:04"), maxGap, ref.maxGapHour),
+				"小月龄宝宝不宜长时间不进食，夜间也需按时喂奶", time.UnixMilli(maxGapAt))
+		}
+	}
+
+	if len(milkDays) > 0 && nightFeeds > 0 {
+		r.pattern("night_feeding", fmt.Sprintf("平均每晚(22点至次日6点)喂奶%.1f次", float64(nightFeeds)/float64(len(milkDays))),
+			0.8, "每晚", d.start, d.end)
+	}
+
+	// 辅食
+	if solids > 0 {
+		if ageKnown && ageDays < solidsMinAgeDays {
+			r.deduct(10, "辅食添加过早")
+			r.alert(levelWarning, "辅食添加过早",
+				fmt.Sprintf("宝宝%d日龄已记录%d次辅食", ageDays, solids),
+				"一般建议满6月龄(最早不早于4月龄)再添加辅食，请先咨询儿科医生", d.end)
+		} else {
+			r.insight("辅食", "已开始添加辅食", fmt.Sprintf("分析期间记录了%d次辅食，注意逐一引入新食物并观察过敏反应", solids), priorityLow)
+		}
+	}
+
+	return r
+}
+
+// latestWeight 区间结束前最近一次测量的体重(kg)，没有测量记录时使用宝宝档案体重
+func latestWeight(d *dataset) float64 {
+	for i := len(d.growth) - 1; i >= 0; i-- {
+		if w := d.growth[i].Weight; w != nil && *w > 0 {
+			return *w
+		}
+	}
+	return d.baby.Weight
+}
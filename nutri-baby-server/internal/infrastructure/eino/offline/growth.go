@@ -0,0 +1,161 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// velocityWindowDays 计算增速时回看的天数
+	velocityWindowDays = 60
+	// minVelocityDays 两次测量至少间隔的天数，间隔太短时测量误差会放大增速
+	minVelocityDays = 7
+	// newbornWeightLossDays 出生后该天数内的体重下降视为生理性下降
+	newbornWeightLossDays = 14
+)
+
+// measurement 一次测量值
+type measurement struct {
+	at    time.Time
+	value float64
+}
+
+// analyzeGrowth 生长发育分析: 体重增速、身长增速和测量频率
+func analyzeGrowth(d *dataset) *report {
+	if len(d.growth) == 0 {
+		return nil
+	}
+	r := newReport(entity.AIAnalysisTypeGrowth, "生长发育")
+	ageDays, ageKnown := d.ageDays()
+
+	var weights, heights []measurement
+	for _, record := range d.growth {
+		at := time.UnixMilli(record.Time).In(d.loc)
+		if record.Weight != nil && *record.Weight > 0 {
+			weights = append(weights, measurement{at: at, value: *record.Weight})
+		}
+		if record.Height != nil && *record.Height > 0 {
+			heights = append(heights, measurement{at: at, value: *record.Height})
+		}
+	}
+
+	latest := d.growth[len(d.growth)-1]
+	r.insight("统计", "最新测量", latestMeasurementText(latest, d.loc), priorityLow)
+
+	// 体重增速
+	if from, to, ok := velocityPair(weights); ok {
+		days := to.at.Sub(from.at).Hours() / 24
+		gramsPerDay := (to.value - from.value) * 1000 / days
+		confidence := 0.6
+		if len(weights) >= 3 {
+			confidence = 0.8
+		}
+		description := fmt.Sprintf("近%.0f天体重从%.2fkg变为%.2fkg，平均每天%+.0f克", days, from.value, to.value, gramsPerDay)
+		r.pattern("weight_velocity", description, confidence, "每天", from.at, to.at)
+
+		if ageKnown {
+			ref := growthReferenceFor(ageDays)
+			rangeText := fmt.Sprintf("%.0f-%.0f克", ref.minGramsPerDay, ref.maxGramsPerDay)
+			switch {
+			case gramsPerDay < 0 && ageDays < newbornWeightLossDays:
+				r.insight("体重", "生理性体重下降",
+					description+"。新生儿出生后一周内体重下降属常见现象，通常在两周内恢复到出生体重", priorityMedium)
+			case gramsPerDay < 0:
+				r.deduct(25, "体重下降")
+				r.alert(levelWarning, "体重下降", description,
+					"体重下降需要重视，请检查喂养和近期健康状况，并咨询医生", to.at)
+			case gramsPerDay < ref.minGramsPerDay*0.8:
+				r.deduct(math.Min(20, (ref.minGramsPerDay-gramsPerDay)/ref.minGramsPerDay*30), "体重增长偏慢")
+				r.alert(levelWarning, "体重增长偏慢",
+					fmt.Sprintf("%s，低于该月龄参考的每天%s", description, rangeText),
+					"评估奶量和辅食是否充足，1-2周后复测；持续偏慢请咨询医生", to.at)
+			case gramsPerDay > ref.maxGramsPerDay*1.3:
+				r.deduct(8, "体重增长偏快")
+				r.alert(levelInfo, "体重增长偏快",
+					fmt.Sprintf("%s，高于该月龄参考的每天%s", description, rangeText),
+					"避免过度喂养，按需喂养并保持均衡饮食", to.at)
+			default:
+				r.highlight("体重增长达标", fmt.Sprintf("%s，在该月龄参考的每天%s范围内", description, rangeText), "📈")
+			}
+		}
+
+		predicted := to.value + gramsPerDay*30/1000
+		r.predict("weight", fmt.Sprintf("约%.2fkg", predicted), confidence-0.1, "30天后", "按近期体重增速线性推算")
+	} else {
+		r.deduct(10, "体重测量次数不足")
+		r.insight("体重", "需要更多测量", "间隔一周以上的体重测量少于两次，暂时无法评估增长速度，建议定期测量", priorityMedium)
+	}
+
+	// 身长增速(身长测量误差较大，只作参考不计分)
+	if from, to, ok := velocityPair(heights); ok {
+		months := to.at.Sub(from.at).Hours() / 24 / 30.4
+		r.pattern("height_velocity",
+			fmt.Sprintf("身长从%.1fcm增长到%.1fcm，平均每月%.1fcm", from.value, to.value, (to.value-from.value)/months),
+			0.6, "每月", from.at, to.at)
+	}
+
+	// 测量频率
+	sinceLast := int(d.end.Sub(time.UnixMilli(latest.Time)).Hours() / 24)
+	expected := 90
+	if ageKnown && ageDays < 365 {
+		expected = 30
+	}
+	if sinceLast > expected {
+		r.deduct(5, "测量间隔较长")
+		r.alert(levelInfo, "测量间隔较长",
+			fmt.Sprintf("距离上次测量已%d天", sinceLast),
+			fmt.Sprintf("建议每%d天左右测量一次身长和体重，便于及时发现增长问题", expected), d.end)
+	}
+
+	return r
+}
+
+// velocityPair 选取计算增速的两次测量: 最近一次，以及回看窗口内最早的一次(窗口内没有时取窗口前最近的一次)
+// 与最近一次间隔不足 minVelocityDays 的测量不参与
+func velocityPair(points []measurement) (measurement, measurement, bool) {
+	if len(points) < 2 {
+		return measurement{}, measurement{}, false
+	}
+	to := points[len(points)-1]
+	windowStart := to.at.AddDate(0, 0, -velocityWindowDays)
+
+	var from *measurement
+	for i := len(points) - 2; i >= 0; i-- {
+		if to.at.Sub(points[i].at).Hours()/24 < minVelocityDays {
+			continue
+		}
+		if points[i].at.Before(windowStart) {
+			if from == nil {
+				from = &points[i]
+			}
+			break
+		}
+		from = &points[i]
+	}
+	if from == nil {
+		return measurement{}, measurement{}, false
+	}
+	return *from, to, true
+}
+
+// latestMeasurementText 最新一次测量的描述
+func latestMeasurementText(record *entity.GrowthRecord, loc *time.Location) string {
+	var parts []string
+	if record.Weight != nil && *record.Weight > 0 {
+		parts = append(parts, fmt.Sprintf("体重%.2fkg", *record.Weight))
+	}
+	if record.Height != nil && *record.Height > 0 {
+		parts = append(parts, fmt.Sprintf("身长%.1fcm", *record.Height))
+	}
+	if record.HeadCircumference != nil && *record.HeadCircumference > 0 {
+		parts = append(parts, fmt.Sprintf("头围%.1fcm", *record.HeadCircumference))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "未填写测量值")
+	}
+	return time.UnixMilli(record.Time).In(loc).Format("2006-01-02") + " " + strings.Join(parts, "、")
+}
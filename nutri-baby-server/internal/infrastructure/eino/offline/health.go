@@ -0,0 +1,168 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// vaccineOverdueGraceDays 超过建议接种月龄该天数仍未接种视为逾期
+	vaccineOverdueGraceDays = 30
+	// vaccineUpcomingDays 未来该天数内到期的疫苗给出预测提醒
+	vaccineUpcomingDays = 14
+	// maxHealthAlertDeduction 健康提醒最多扣分
+	maxHealthAlertDeduction = 30
+)
+
+// healthWeights 综合健康评分中各分项的权重
+var healthWeights = map[string]float64{
+	string(entity.AIAnalysisTypeFeeding): 0.35,
+	string(entity.AIAnalysisTypeSleep):   0.35,
+	string(entity.AIAnalysisTypeGrowth):  0.3,
+}
+
+// analyzeHealth 综合健康分析: 汇总喂养、睡眠、生长分项，并结合排泄、健康提醒和疫苗接种情况
+func analyzeHealth(d *dataset) *report {
+	var subs []*report
+	for _, sub := range []*report{analyzeFeeding(d), analyzeSleep(d), analyzeGrowth(d)} {
+		if sub != nil {
+			subs = append(subs, sub)
+		}
+	}
+	if len(subs) == 0 && len(d.diapers) == 0 && len(d.healthAlerts) == 0 {
+		return nil
+	}
+	r := newReport(entity.AIAnalysisTypeHealth, "综合健康")
+
+	// 分项加权
+	if len(subs) > 0 {
+		weighted, totalWeight := 0.0, 0.0
+		var parts []string
+		for _, sub := range subs {
+			sub.score = math.Max(0, math.Min(100, math.Round(sub.score)))
+			weighted += sub.score * healthWeights[sub.kind]
+			totalWeight += healthWeights[sub.kind]
+			parts = append(parts, fmt.Sprintf("%s%.0f分", sub.name, sub.score))
+
+			priority := priorityLow
+			switch {
+			case sub.score < 60:
+				priority = priorityHigh
+			case sub.score < 80:
+				priority = priorityMedium
+			}
+			r.insight("分项评分", sub.name+"评分", fmt.Sprintf("%s评分%.0f分", sub.name, sub.score), priority)
+			r.highlights = append(r.highlights, sub.highlights...)
+			r.alerts = append(r.alerts, sub.alerts...)
+			r.patterns = append(r.patterns, sub.patterns...)
+			r.predictions = append(r.predictions, sub.predictions...)
+		}
+		r.score = weighted / totalWeight
+		r.basis = "综合评分由分项评分加权得出(" + strings.Join(parts, "、") + ")，再按排泄、健康提醒和疫苗情况调整。"
+	}
+
+	analyzeDiapers(d, r)
+	applyHealthAlerts(d, r)
+	checkVaccines(d, r)
+	return r
+}
+
+// analyzeDiapers 排泄统计: 每日小便次数是否达到月龄下限
+func analyzeDiapers(d *dataset, r *report) {
+	if len(d.diapers) == 0 {
+		return
+	}
+	wetPerDay := make(map[string]int)
+	poops := 0
+	for _, record := range d.diapers {
+		day := d.dayKey(record.Time)
+		if _, ok := wetPerDay[day]; !ok {
+			wetPerDay[day] = 0
+		}
+		if record.Type == "pee" || record.Type == "both" {
+			wetPerDay[day]++
+		}
+		if record.Type == "poop" || record.Type == "both" {
+			poops++
+		}
+	}
+
+	days := float64(len(wetPerDay))
+	wet := 0
+	for _, count := range wetPerDay {
+		wet += count
+	}
+	avgWet := float64(wet) / days
+	r.pattern("diaper", fmt.Sprintf("有记录的日子平均每天换尿布%.1f次，其中小便%.1f次、大便%.1f次",
+		float64(len(d.diapers))/days, avgWet, float64(poops)/days), 0.8, "每天", d.start, d.end)
+
+	// 记录天数太少时不评估次数，避免漏记被误判为尿量不足
+	if ageDays, ok := d.ageDays(); ok && float64(len(wetPerDay)) >= 0.6*float64(d.elapsed) {
+		required := minWetDiapersFor(ageDays)
+		if avgWet < float64(required) {
+			r.deduct(10, "小便次数偏少")
+			r.alert(levelWarning, "小便次数偏少",
+				fmt.Sprintf("平均每天小便%.1f次，低于该月龄建议的至少%d次", avgWet, required),
+				"可能存在摄入不足，请关注喂养；如伴随精神差、口唇干燥、囟门凹陷请及时就医", d.end)
+		} else {
+			r.highlight("排尿正常", fmt.Sprintf("平均每天小便%.1f次，达到该月龄建议的%d次以上", avgWet, required), "💧")
+		}
+	}
+}
+
+// applyHealthAlerts 纳入规则筛查产生的健康提醒(如大便颜色异常)
+func applyHealthAlerts(d *dataset, r *report) {
+	deducted := 0.0
+	for _, alert := range d.healthAlerts {
+		at := time.UnixMilli(alert.TriggeredAt)
+		if at.Before(d.start) || !at.Before(d.end) {
+			continue
+		}
+		r.alert(alert.Level, alert.Title, alert.Message, "请按提醒内容处理，必要时及时就医", at)
+
+		points := 2.0
+		switch alert.Level {
+		case levelCritical:
+			points = 15
+		case levelWarning:
+			points = 8
+		}
+		points = math.Min(points, maxHealthAlertDeduction-deducted)
+		if points > 0 {
+			deducted += points
+			r.deduct(points, "健康提醒「"+alert.Title+"」")
+		}
+	}
+}
+
+// checkVaccines 检查必打疫苗是否逾期以及近期待接种的疫苗
+func checkVaccines(d *dataset, r *report) {
+	if d.birth.IsZero() || len(d.vaccines) == 0 {
+		return
+	}
+	var overdue []string
+	for _, vaccine := range d.vaccines {
+		if vaccine.VaccinationStatus != "pending" {
+			continue
+		}
+		due := d.birth.AddDate(0, vaccine.AgeInMonths, 0)
+		name := fmt.Sprintf("%s第%d剂", vaccine.VaccineName, vaccine.DoseNumber)
+		switch {
+		case vaccine.IsRequired && d.end.Sub(due) > vaccineOverdueGraceDays*24*time.Hour:
+			overdue = append(overdue, name)
+		case !due.Before(d.end) && due.Sub(d.end) <= vaccineUpcomingDays*24*time.Hour:
+			r.predict("upcoming_vaccine", name+"("+due.Format("2006-01-02")+")", 0.9, "未来两周", "按接种计划的建议月龄推算")
+		}
+	}
+	if len(overdue) == 0 {
+		return
+	}
+	r.deduct(math.Min(15, float64(5*len(overdue))), "疫苗接种逾期")
+	r.alert(levelWarning, "疫苗接种逾期",
+		fmt.Sprintf("有%d剂必打疫苗已超过建议接种月龄：%s", len(overdue), strings.Join(overdue, "、")),
+		"请联系接种门诊确认补种安排", d.end)
+}
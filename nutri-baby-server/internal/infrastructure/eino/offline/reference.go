@@ -0,0 +1,90 @@
+package offline
+
+import "math"
+
+// feedingReference 按日龄的喂养参考范围(参考常见儿科喂养指南)
+type feedingReference struct {
+	maxAgeDays int     // 适用的最大日龄(不含)
+	minFeeds   int     // 每日最少喂奶次数
+	maxFeeds   int     // 每日最多喂奶次数
+	minMlPerKg float64 // 每公斤每日最少奶量(ml)，0 表示不评估
+	maxMlPerKg float64 // 每公斤每日最多奶量(ml)
+	maxGapHour float64 // 两次喂奶的最长间隔(小时)，0 表示不评估
+}
+
+var feedingReferences = []feedingReference{
+	{maxAgeDays: 7, minFeeds: 8, maxFeeds: 12, minMlPerKg: 60, maxMlPerKg: 150, maxGapHour: 4},
+	{maxAgeDays: 30, minFeeds: 8, maxFeeds: 12, minMlPerKg: 150, maxMlPerKg: 200, maxGapHour: 4},
+	{maxAgeDays: 90, minFeeds: 6, maxFeeds: 10, minMlPerKg: 150, maxMlPerKg: 200, maxGapHour: 5},
+	{maxAgeDays: 180, minFeeds: 5, maxFeeds: 8, minMlPerKg: 120, maxMlPerKg: 180},
+	{maxAgeDays: 365, minFeeds: 4, maxFeeds: 6, minMlPerKg: 90, maxMlPerKg: 150},
+	{maxAgeDays: math.MaxInt32, minFeeds: 3, maxFeeds: 5},
+}
+
+// sleepReference 按日龄的每日总睡眠参考(小时，参考美国国家睡眠基金会建议)
+type sleepReference struct {
+	maxAgeDays int
+	minHours   float64
+	maxHours   float64
+}
+
+var sleepReferences = []sleepReference{
+	{maxAgeDays: 90, minHours: 14, maxHours: 17},
+	{maxAgeDays: 365, minHours: 12, maxHours: 15},
+	{maxAgeDays: 730, minHours: 11, maxHours: 14},
+	{maxAgeDays: 1826, minHours: 10, maxHours: 13},
+	{maxAgeDays: math.MaxInt32, minHours: 9, maxHours: 12},
+}
+
+// growthReference 按日龄的体重增长参考(克/天，参考WHO儿童生长标准中位数附近的增速)
+type growthReference struct {
+	maxAgeDays     int
+	minGramsPerDay float64
+	maxGramsPerDay float64
+}
+
+var growthReferences = []growthReference{
+	{maxAgeDays: 90, minGramsPerDay: 20, maxGramsPerDay: 40},
+	{maxAgeDays: 180, minGramsPerDay: 10, maxGramsPerDay: 25},
+	{maxAgeDays: 365, minGramsPerDay: 5, maxGramsPerDay: 15},
+	{maxAgeDays: 730, minGramsPerDay: 3, maxGramsPerDay: 9},
+	{maxAgeDays: math.MaxInt32, minGramsPerDay: 2, maxGramsPerDay: 7},
+}
+
+// feedingReferenceFor 日龄对应的喂养参考范围
+func feedingReferenceFor(ageDays int) feedingReference {
+	for _, ref := range feedingReferences {
+		if ageDays < ref.maxAgeDays {
+			return ref
+		}
+	}
+	return feedingReferences[len(feedingReferences)-1]
+}
+
+// sleepReferenceFor 日龄对应的睡眠参考范围
+func sleepReferenceFor(ageDays int) sleepReference {
+	for _, ref := range sleepReferences {
+		if ageDays < ref.maxAgeDays {
+			return ref
+		}
+	}
+	return sleepReferences[len(sleepReferences)-1]
+}
+
+// growthReferenceFor 日龄对应的体重增长参考范围
+func growthReferenceFor(ageDays int) growthReference {
+	for _, ref := range growthReferences {
+		if ageDays < ref.maxAgeDays {
+			return ref
+		}
+	}
+	return growthReferences[len(growthReferences)-1]
+}
+
+// minWetDiapersFor 日龄对应的每日最少小便次数(出生后第N天至少N次，满6天后至少6次)
+func minWetDiapersFor(ageDays int) int {
+	if ageDays+1 < 6 {
+		return ageDays + 1
+	}
+	return 6
+}
@@ -0,0 +1,310 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+// 警告级别
+const (
+	levelCritical = "critical"
+	levelWarning  = "warning"
+	levelInfo     = "info"
+)
+
+// 优先级
+const (
+	priorityHigh   = "high"
+	priorityMedium = "medium"
+	priorityLow    = "low"
+)
+
+// report 单项分析的中间结果: 评分从100分开始按规则扣分，同时累积各类结论
+type report struct {
+	kind        string // 分析领域(feeding/sleep/growth/health)，用作洞察和警告的类型
+	name        string // 分析领域中文名
+	score       float64
+	basis       string   // 评分依据的补充说明
+	deductions  []string // 扣分原因，用于评分说明
+	highlights  []entity.UserFriendlyHighlight
+	insights    []entity.AIInsight
+	alerts      []entity.AIAlert
+	patterns    []entity.AIPattern
+	predictions []entity.AIPrediction
+}
+
+func newReport(kind entity.AIAnalysisType, name string) *report {
+	return &report{
+		kind:        string(kind),
+		name:        name,
+		score:       100,
+		highlights:  []entity.UserFriendlyHighlight{},
+		insights:    []entity.AIInsight{},
+		alerts:      []entity.AIAlert{},
+		patterns:    []entity.AIPattern{},
+		predictions: []entity.AIPrediction{},
+	}
+}
+
+// deduct 扣分并记录原因
+func (r *report) deduct(points float64, reason string) {
+	points = math.Round(points)
+	if points <= 0 {
+		return
+	}
+	r.score -= points
+	r.deductions = append(r.deductions, fmt.Sprintf("%s(-%.0f)", reason, points))
+}
+
+// insight 添加洞察
+func (r *report) insight(category, title, description, priority string) {
+	r.insights = append(r.insights, entity.AIInsight{
+		Type:        r.kind,
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		Category:    category,
+	})
+}
+
+// highlight 添加表现良好的方面，同时作为低优先级洞察
+func (r *report) highlight(title, description, icon string) {
+	r.highlights = append(r.highlights, entity.UserFriendlyHighlight{Title: title, Description: description, Icon: icon})
+	r.insight("表现良好", title, description, priorityLow)
+}
+
+// alert 添加警告
+func (r *report) alert(level, title, description, suggestion string, at time.Time) {
+	r.alerts = append(r.alerts, entity.AIAlert{
+		Level:       level,
+		Type:        r.kind,
+		Title:       title,
+		Description: description,
+		Suggestion:  suggestion,
+		Timestamp:   at,
+	})
+}
+
+// pattern 添加识别到的模式
+func (r *report) pattern(patternType, description string, confidence float64, frequency string, start, end time.Time) {
+	r.patterns = append(r.patterns, entity.AIPattern{
+		PatternType: patternType,
+		Description: description,
+		Confidence:  round2(confidence),
+		Frequency:   frequency,
+		TimeRange:   entity.TimeRange{Start: start, End: end},
+	})
+}
+
+// predict 添加预测
+func (r *report) predict(predictionType, value string, confidence float64, timeFrame, reason string) {
+	r.predictions = append(r.predictions, entity.AIPrediction{
+		PredictionType: predictionType,
+		Value:          value,
+		Confidence:     round2(confidence),
+		TimeFrame:      timeFrame,
+		Reason:         reason,
+	})
+}
+
+// checkCoverage 记录天数明显少于分析天数时扣分并提示，避免数据缺失被误判为异常
+func (r *report) checkCoverage(d *dataset, recordedDays int) {
+	coverage := float64(recordedDays) / float64(d.elapsed)
+	if coverage >= 0.6 {
+		return
+	}
+	r.deduct(10, "记录天数偏少")
+	r.alert(levelInfo, "记录不完整",
+		fmt.Sprintf("分析期间%d天中只有%d天有%s记录，结论可能不够准确", d.elapsed, recordedDays, r.name),
+		fmt.Sprintf("每次%s后及时记录，分析会更准确", r.name), d.end)
+}
+
+// result 生成分析结果
+func (r *report) result(d *dataset, analysisType entity.AIAnalysisType) *entity.AIAnalysisResult {
+	r.score = math.Max(0, math.Min(100, math.Round(r.score)))
+	sort.SliceStable(r.alerts, func(i, j int) bool {
+		return levelRank(r.alerts[i].Level) < levelRank(r.alerts[j].Level)
+	})
+
+	return &entity.AIAnalysisResult{
+		BabyID:       d.baby.ID,
+		AnalysisType: analysisType,
+		Score:        r.score,
+		Insights:     r.insights,
+		Alerts:       r.alerts,
+		Patterns:     r.patterns,
+		Predictions:  r.predictions,
+		UserFriendly: r.userFriendly(d),
+	}
+}
+
+// userFriendly 生成面向家长的通俗结果
+func (r *report) userFriendly(d *dataset) *entity.UserFriendlyResult {
+	name := d.baby.Nickname
+	if name == "" {
+		name = d.baby.Name
+	}
+	if name == "" {
+		name = "宝宝"
+	}
+
+	var summary, encouraging string
+	switch {
+	case r.score >= 85:
+		summary = "情况很好，各项指标基本都在月龄参考范围内。"
+		encouraging = "你们把宝宝照顾得很好，继续保持现在的节奏就好！"
+	case r.score >= 70:
+		summary = "情况总体良好，有少数方面可以继续改进。"
+		encouraging = "整体做得不错，针对下面的小建议稍作调整，会更加从容。"
+	case r.score >= 50:
+		summary = "有几项指标偏离参考范围，值得重点关注。"
+		encouraging = "每个宝宝都有自己的节奏，按建议逐步调整，坚持记录就能看到变化。"
+	default:
+		summary = "多项指标偏离参考范围，建议尽快调整并咨询医生。"
+		encouraging = "照顾宝宝辛苦了，发现问题本身就是重要的一步，必要时请及时寻求医生的帮助。"
+	}
+
+	explanation := fmt.Sprintf("评分由离线分析引擎根据记录数据与月龄参考范围计算，满分100分，本次%.0f分。", r.score) + r.basis
+	if len(r.deductions) > 0 {
+		explanation += "扣分项：" + strings.Join(r.deductions, "；") + "。"
+	} else {
+		explanation += "本次没有明显的扣分项。"
+	}
+
+	improvements := make([]entity.UserFriendlyImprovement, 0, len(r.alerts))
+	actions := make([]entity.UserFriendlyAction, 0, 3)
+	for _, alert := range r.alerts {
+		improvements = append(improvements, entity.UserFriendlyImprovement{
+			Area:       r.name,
+			Issue:      alert.Description,
+			Suggestion: alert.Suggestion,
+			Priority:   levelPriority(alert.Level),
+			Difficulty: "medium",
+		})
+		if len(actions) < 3 {
+			actions = append(actions, entity.UserFriendlyAction{
+				Action:   alert.Suggestion,
+				Timeline: levelTimeline(alert.Level),
+				Benefit:  "改善「" + alert.Title + "」",
+				HowTo:    "按建议调整后继续记录，下次分析时对比变化",
+			})
+		}
+	}
+	if len(actions) == 0 {
+		actions = append(actions, entity.UserFriendlyAction{
+			Action:   "保持当前的照护习惯",
+			Timeline: "持续",
+			Benefit:  "维持良好的" + r.name + "状况",
+			HowTo:    "坚持记录，定期生成分析查看变化",
+		})
+	}
+
+	return &entity.UserFriendlyResult{
+		OverallSummary: fmt.Sprintf("%s至%s期间，%s的%s%s",
+			d.start.Format("2006-01-02"), d.end.Add(-time.Millisecond).Format("2006-01-02"), name, r.name, summary),
+		ScoreExplanation: explanation,
+		KeyHighlights:    r.highlights,
+		ImprovementAreas: improvements,
+		NextStepActions:  actions,
+		EncouragingWords: encouraging,
+	}
+}
+
+// levelRank 警告级别排序(严重的在前)
+func levelRank(level string) int {
+	switch level {
+	case levelCritical:
+		return 0
+	case levelWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levelPriority 警告级别对应的优先级
+func levelPriority(level string) string {
+	switch level {
+	case levelCritical:
+		return priorityHigh
+	case levelWarning:
+		return priorityMedium
+	default:
+		return priorityLow
+	}
+}
+
+// levelTimeline 警告级别对应的行动时间
+func levelTimeline(level string) string {
+	switch level {
+	case levelCritical:
+		return "尽快"
+	case levelWarning:
+		return "未来一周"
+	default:
+		return "持续关注"
+	}
+}
+
+// mean 平均值
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// median 中位数
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// stddev 总体标准差
+func stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// clamp 限制在 [lo, hi] 区间
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// round2 保留两位小数
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// clockText 把一天中的分钟数格式化为 HH:MM，超过24小时的部分回绕
+func clockText(minutes float64) string {
+	m := int(math.Round(minutes)) % (24 * 60)
+	if m < 0 {
+		m += 24 * 60
+	}
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
@@ -0,0 +1,144 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+)
+
+const (
+	// regularBedtimeMinutes 夜间入睡时间标准差不超过该值视为规律
+	regularBedtimeMinutes = 45
+	// maxSleepHours 超过该时长的单次睡眠视为漏记结束时间，不参与统计
+	maxSleepHours = 16
+)
+
+// sleepSession 已结束的一段睡眠
+type sleepSession struct {
+	start time.Time
+	hours float64
+	night bool
+}
+
+// analyzeSleep 睡眠分析: 每日总时长、夜间与小睡、入睡时间规律和最长连续睡眠
+func analyzeSleep(d *dataset) *report {
+	sessions := sleepSessions(d)
+	if len(sessions) == 0 {
+		return nil
+	}
+	r := newReport(entity.AIAnalysisTypeSleep, "睡眠")
+	ageDays, ageKnown := d.ageDays()
+	ref := sleepReferenceFor(ageDays)
+
+	perDay := make(map[string]float64)
+	nightHours, naps := 0.0, 0
+	var bedtimes []float64
+	longest := sessions[0]
+	for _, s := range sessions {
+		perDay[s.start.Format("2006-01-02")] += s.hours
+		if s.night {
+			nightHours += s.hours
+			// 入睡时间按分钟计，凌晨入睡加24小时，避免跨午夜时均值失真
+			minutes := float64(s.start.Hour()*60 + s.start.Minute())
+			if minutes < 12*60 {
+				minutes += 24 * 60
+			}
+			bedtimes = append(bedtimes, minutes)
+		} else {
+			naps++
+		}
+		if s.hours > longest.hours {
+			longest = s
+		}
+	}
+	r.checkCoverage(d, len(perDay))
+
+	days := float64(len(perDay))
+	avgHours := 0.0
+	for _, hours := range perDay {
+		avgHours += hours
+	}
+	avgHours /= days
+	r.insight("统计", "睡眠概况",
+		fmt.Sprintf("共记录%d段睡眠，有记录的日子平均每天睡%.1f小时，其中夜间%.1f小时、白天小睡%.1f次",
+			len(sessions), avgHours, nightHours/days, float64(naps)/days), priorityLow)
+
+	// 每日总睡眠
+	if ageKnown {
+		rangeText := fmt.Sprintf("%.0f-%.0f小时", ref.minHours, ref.maxHours)
+		switch {
+		case avgHours < ref.minHours:
+			shortfall := ref.minHours - avgHours
+			level := levelInfo
+			if shortfall >= 2 {
+				level = levelWarning
+			}
+			r.deduct(math.Min(25, shortfall*8), "睡眠时间偏少")
+			r.alert(level, "睡眠时间偏少",
+				fmt.Sprintf("平均每天睡眠%.1f小时，低于该月龄建议的%s", avgHours, rangeText),
+				"适当提前夜间入睡时间、保证白天小睡，营造安静昏暗的睡眠环境", d.end)
+		case avgHours > ref.maxHours+1:
+			r.deduct(math.Min(10, (avgHours-ref.maxHours)*3), "睡眠时间偏长")
+			r.alert(levelInfo, "睡眠时间偏长",
+				fmt.Sprintf("平均每天睡眠%.1f小时，多于该月龄常见的%s", avgHours, rangeText),
+				"留意清醒时的精神状态与吃奶情况，如伴随嗜睡、吃奶差请咨询医生", d.end)
+		default:
+			r.highlight("睡眠时长达标", fmt.Sprintf("平均每天睡眠%.1f小时，在该月龄建议的%s范围内", avgHours, rangeText), "😴")
+		}
+	}
+
+	// 入睡时间规律
+	if len(bedtimes) >= 3 {
+		avgBedtime := mean(bedtimes)
+		spread := stddev(bedtimes)
+		confidence := clamp(1-spread/180, 0.3, 0.95)
+		if spread <= regularBedtimeMinutes {
+			r.highlight("入睡时间规律", fmt.Sprintf("夜间入睡时间集中在%s左右", clockText(avgBedtime)), "🌙")
+			r.pattern("consistent_bedtime", fmt.Sprintf("夜间入睡时间集中在%s左右(前后约%.0f分钟)", clockText(avgBedtime), spread),
+				confidence, "每晚", d.start, d.end)
+		} else {
+			r.deduct(math.Min(15, (spread-regularBedtimeMinutes)/6), "入睡时间不固定")
+			r.insight("规律性", "入睡时间不固定",
+				fmt.Sprintf("夜间入睡时间前后相差约%.0f分钟，建议固定睡前程序(洗澡、换睡衣、讲故事)并在相近时间入睡", spread), priorityMedium)
+			r.pattern("irregular_bedtime", fmt.Sprintf("夜间入睡时间波动较大，平均约%s", clockText(avgBedtime)),
+				confidence, "每晚", d.start, d.end)
+		}
+		r.predict("bedtime", "约 "+clockText(avgBedtime), confidence, "今晚", "按近期夜间入睡时间的平均值推算")
+	}
+
+	r.pattern("longest_sleep", fmt.Sprintf("最长一次连续睡眠%.1f小时(%s开始)", longest.hours, longest.start.Format("01-02 15:04")),
+		0.9, "分析期间", d.start, d.end)
+	r.predict("daily_sleep", fmt.Sprintf("约%.1f小时", avgHours), 0.7, "未来一周", "按近期平均每日睡眠时长估算")
+
+	return r
+}
+
+// sleepSessions 整理已结束的睡眠，未标注类型时按入睡时刻区分夜间睡眠与小睡
+func sleepSessions(d *dataset) []sleepSession {
+	sessions := make([]sleepSession, 0, len(d.sleeps))
+	for _, record := range d.sleeps {
+		seconds := 0
+		switch {
+		case record.Duration != nil && *record.Duration > 0:
+			seconds = *record.Duration
+		case record.EndTime != nil && *record.EndTime > record.StartTime:
+			seconds = int((*record.EndTime - record.StartTime) / 1000)
+		default:
+			continue // 进行中
+		}
+		hours := float64(seconds) / 3600
+		if hours > maxSleepHours {
+			continue
+		}
+
+		start := time.UnixMilli(record.StartTime).In(d.loc)
+		night := record.Type == "night"
+		if record.Type == "" {
+			night = start.Hour() >= 19 || start.Hour() < 6
+		}
+		sessions = append(sessions, sleepSession{start: start, hours: hours, night: night})
+	}
+	return sessions
+}
@@ -0,0 +1,91 @@
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+)
+
+// maxReportOutputRunes 校验记录中保留的原始输出长度
+const maxReportOutputRunes = 2000
+
+// Report 一次结构化输出的校验记录，用于排查模型输出问题
+type Report struct {
+	Schema   string    `json:"schema"`
+	Repaired bool      `json:"repaired"` // 是否经修复后通过
+	Attempts []Attempt `json:"attempts"` // 未通过校验的各次输出
+}
+
+// Attempt 一次未通过校验的输出
+type Attempt struct {
+	Errors []string `json:"errors"`
+	Output string   `json:"output"` // 原始输出(截断)
+}
+
+// NewReport 创建校验记录
+func NewReport(schema *Schema) *Report {
+	return &Report{Schema: schema.Name}
+}
+
+// AddFailure 记录一次未通过校验的输出
+func (r *Report) AddFailure(output string, errs []string) {
+	if runes := []rune(output); len(runes) > maxReportOutputRunes {
+		output = string(runes[:maxReportOutputRunes]) + "..."
+	}
+	r.Attempts = append(r.Attempts, Attempt{Errors: errs, Output: output})
+}
+
+// Empty 首次输出即通过校验时没有需要保存的记录
+func (r *Report) Empty() bool {
+	return r == nil || len(r.Attempts) == 0
+}
+
+// String 序列化为JSON，无记录时为空串
+func (r *Report) String() string {
+	if r.Empty() {
+		return ""
+	}
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// ValidationError 修复后仍未通过校验
+type ValidationError struct {
+	Report *Report
+}
+
+// Error 实现 error 接口，只展示最后一次输出的前几条错误
+func (e *ValidationError) Error() string {
+	if e.Report.Empty() {
+		return "结构化输出校验失败"
+	}
+	errs := e.Report.Attempts[len(e.Report.Attempts)-1].Errors
+	if len(errs) > 3 {
+		errs = append(errs[:3:3], fmt.Sprintf("等%d个错误", len(errs)))
+	}
+	return fmt.Sprintf("%s 结构化输出校验失败(尝试%d次): %s",
+		e.Report.Schema, len(e.Report.Attempts), strings.Join(errs, "; "))
+}
+
+// responseFormatOptions 结构化输出的模型调用选项
+type responseFormatOptions struct {
+	schema *Schema
+}
+
+// WithResponseSchema 要求模型按 schema 输出JSON
+// 支持原生结构化输出的模型实现(如 OpenAI 兼容接口的 response_format)据此约束输出，其他实现忽略该选项
+func WithResponseSchema(schema *Schema) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *responseFormatOptions) {
+		o.schema = schema
+	})
+}
+
+// ResponseSchemaFrom 从调用选项中读取结构化输出 schema，未指定时为 nil
+func ResponseSchemaFrom(opts ...model.Option) *Schema {
+	return model.GetImplSpecificOptions(&responseFormatOptions{}, opts...).schema
+}
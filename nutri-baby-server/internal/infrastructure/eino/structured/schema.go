@@ -0,0 +1,131 @@
+// Package structured 定义AI结构化输出的 JSON Schema，并提供校验与输出格式选项
+package structured
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// 预定义的结构化输出
+var (
+	// AnalysisResult 分析结果(包含可选的 user_friendly)
+	AnalysisResult = mustLoadAnalysisResult()
+	// UserFriendlyResult 面向家长的通俗分析结果
+	UserFriendlyResult = mustLoad("user_friendly_result", "schemas/user_friendly_result.json", "")
+	// DailyTips 每日建议，根为 {"tips": [...]}，模型直接返回数组时自动包装
+	DailyTips = mustLoad("daily_tips", "schemas/daily_tips.json", "tips")
+)
+
+// Schema 一种结构化输出的 JSON Schema
+type Schema struct {
+	Name string // 名称，用作 response_format 中的 schema 名
+
+	schema    *openapi3.Schema
+	arrayRoot string // 非空时，根为数组的输出包装为 {arrayRoot: [...]} 后再校验
+}
+
+func mustLoad(name, file, arrayRoot string) *Schema {
+	raw, err := schemaFiles.ReadFile(file)
+	if err != nil {
+		panic(fmt.Sprintf("读取结构化输出 schema %s 失败: %v", file, err))
+	}
+	schema := openapi3.NewSchema()
+	if err := json.Unmarshal(raw, schema); err != nil {
+		panic(fmt.Sprintf("解析结构化输出 schema %s 失败: %v", file, err))
+	}
+	return &Schema{Name: name, schema: schema, arrayRoot: arrayRoot}
+}
+
+// mustLoadAnalysisResult 分析结果 schema 嵌入 user_friendly 子 schema
+func mustLoadAnalysisResult() *Schema {
+	s := mustLoad("analysis_result", "schemas/analysis_result.json", "")
+	userFriendly := mustLoad("user_friendly_result", "schemas/user_friendly_result.json", "")
+	s.schema.Properties["user_friendly"] = openapi3.NewSchemaRef("", userFriendly.schema)
+	return s
+}
+
+// JSON 返回完整的 JSON Schema 文档
+func (s *Schema) JSON() json.RawMessage {
+	raw, err := json.Marshal(s.schema)
+	if err != nil {
+		// schema 在加载时已校验，这里不会失败
+		panic(err)
+	}
+	return raw
+}
+
+// Normalize 去除首尾空白与 Markdown 代码块标记，按需包装数组根
+// 不做正文中的 JSON 搜索: 无法直接解析的输出交给修复流程处理
+func (s *Schema) Normalize(content string) []byte {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "```") {
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(strings.TrimSpace(content), "```")
+		content = strings.TrimSpace(content)
+	}
+
+	raw := []byte(content)
+	if s.arrayRoot != "" && bytes.HasPrefix(raw, []byte("[")) {
+		wrapped, err := json.Marshal(map[string]json.RawMessage{s.arrayRoot: raw})
+		if err == nil {
+			return wrapped
+		}
+	}
+	return raw
+}
+
+// Validate 校验输出并解析到 out，返回全部校验错误(为空表示通过)
+// 错误格式为 "JSON指针: 原因"，便于回传给模型修复
+func (s *Schema) Validate(raw []byte, out any) []string {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return []string{"输出不是有效的JSON: " + err.Error()}
+	}
+
+	err := s.schema.VisitJSON(value, openapi3.MultiErrors(), openapi3.EnableFormatValidation())
+	if err != nil {
+		return schemaErrors(err)
+	}
+
+	// schema 无法覆盖的类型约束(如时间格式细节)以实际解析为准
+	if out != nil {
+		// 修复重试时复用同一个 out，先清空上一次的部分解析结果
+		if v := reflect.ValueOf(out); v.Kind() == reflect.Pointer && !v.IsNil() {
+			v.Elem().SetZero()
+		}
+		if err := json.Unmarshal(raw, out); err != nil {
+			return []string{"结果解析失败: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// schemaErrors 展开校验错误
+func schemaErrors(err error) []string {
+	var messages []string
+	var walk func(error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			for _, inner := range e {
+				walk(inner)
+			}
+		case *openapi3.SchemaError:
+			messages = append(messages, "/"+strings.Join(e.JSONPointer(), "/")+": "+e.Reason)
+		default:
+			messages = append(messages, err.Error())
+		}
+	}
+	walk(err)
+	return messages
+}
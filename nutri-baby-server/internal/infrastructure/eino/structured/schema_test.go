@@ -0,0 +1,63 @@
+package structured
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisResultValidate(t *testing.T) {
+	var out struct {
+		Score    float64 `json:"score"`
+		Insights []struct {
+			Title string `json:"title"`
+		} `json:"insights"`
+	}
+
+	valid := "```json\n" + `{"score": 86, "insights": [{"type": "feeding", "title": "奶量稳定", "description": "每日奶量稳定", "priority": "low", "category": "nutrition"}],
+	"alerts": [], "patterns": [], "predictions": []}` + "\n```"
+	errs := AnalysisResult.Validate(AnalysisResult.Normalize(valid), &out)
+	require.Empty(t, errs)
+	assert.Equal(t, 86.0, out.Score)
+	assert.Equal(t, "奶量稳定", out.Insights[0].Title)
+
+	// 缺少必填字段、枚举越界、评分越界都应逐条报告
+	invalid := `{"score": 120, "insights": [{"type": "feeding", "title": "t", "description": "d", "priority": "urgent", "category": "c"}], "alerts": [], "patterns": []}`
+	errs = AnalysisResult.Validate(AnalysisResult.Normalize(invalid), &out)
+	assert.GreaterOrEqual(t, len(errs), 3)
+	assert.Contains(t, errs, "/score: number must be at most 100")
+
+	errs = AnalysisResult.Validate(AnalysisResult.Normalize("分析完成，宝宝状况良好"), nil)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "输出不是有效的JSON")
+}
+
+func TestDailyTipsNormalizeArrayRoot(t *testing.T) {
+	var out struct {
+		Tips []struct {
+			ID string `json:"id"`
+		} `json:"tips"`
+	}
+
+	content := `[{"id": "1", "title": "按时补充维D", "description": "每天400IU", "type": "health", "priority": "medium"}]`
+	errs := DailyTips.Validate(DailyTips.Normalize(content), &out)
+	require.Empty(t, errs)
+	require.Len(t, out.Tips, 1)
+	assert.Equal(t, "1", out.Tips[0].ID)
+
+	errs = DailyTips.Validate(DailyTips.Normalize(`{"tips": [{"id": "1", "title": "t", "description": "d", "type": "play", "priority": "medium"}]}`), &out)
+	assert.NotEmpty(t, errs)
+}
+
+func TestReport(t *testing.T) {
+	report := NewReport(DailyTips)
+	assert.True(t, report.Empty())
+	assert.Equal(t, "", report.String())
+
+	report.AddFailure("oops", []string{"/tips: property \"tips\" is missing", "b", "c", "d"})
+	assert.Contains(t, report.String(), `"schema":"daily_tips"`)
+
+	err := &ValidationError{Report: report}
+	assert.Contains(t, err.Error(), "等4个错误")
+}
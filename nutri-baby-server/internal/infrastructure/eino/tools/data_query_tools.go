@@ -4,22 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cloudwego/eino/schema"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"go.uber.org/zap"
 )
 
 // DataQueryTools 数据查询工具集
 type DataQueryTools struct {
-	feedingRepo repository.FeedingRecordRepository
-	sleepRepo   repository.SleepRecordRepository
-	diaperRepo  repository.DiaperRecordRepository
-	growthRepo  repository.GrowthRecordRepository
-	vaccineRepo repository.BabyVaccineScheduleRepository
-	babyRepo    repository.BabyRepository
-	logger      *zap.Logger
+	feedingRepo     repository.FeedingRecordRepository
+	sleepRepo       repository.SleepRecordRepository
+	diaperRepo      repository.DiaperRecordRepository
+	growthRepo      repository.GrowthRecordRepository
+	vaccineRepo     repository.BabyVaccineScheduleRepository
+	cryingRepo      repository.CryingRecordRepository
+	illnessRepo     repository.IllnessEpisodeRepository
+	temperatureRepo repository.TemperatureRecordRepository
+	symptomRepo     repository.SymptomRecordRepository
+	milestoneRepo   repository.MilestoneRecordRepository
+	babyRepo        repository.BabyRepository
+	dataCache       *cache.AnalysisDataCache
+	logger          *zap.Logger
+	now             func() time.Time
 }
 
 // NewDataQueryTools 创建数据查询工具集
@@ -29,20 +39,38 @@ func NewDataQueryTools(
 	diaperRepo repository.DiaperRecordRepository,
 	growthRepo repository.GrowthRecordRepository,
 	vaccineRepo repository.BabyVaccineScheduleRepository,
+	cryingRepo repository.CryingRecordRepository,
+	illnessRepo repository.IllnessEpisodeRepository,
+	temperatureRepo repository.TemperatureRecordRepository,
+	symptomRepo repository.SymptomRecordRepository,
+	milestoneRepo repository.MilestoneRecordRepository,
 	babyRepo repository.BabyRepository,
+	dataCache *cache.AnalysisDataCache,
 	logger *zap.Logger,
 ) *DataQueryTools {
 	return &DataQueryTools{
-		feedingRepo: feedingRepo,
-		sleepRepo:   sleepRepo,
-		diaperRepo:  diaperRepo,
-		growthRepo:  growthRepo,
-		vaccineRepo: vaccineRepo,
-		babyRepo:    babyRepo,
-		logger:      logger,
+		feedingRepo:     feedingRepo,
+		sleepRepo:       sleepRepo,
+		diaperRepo:      diaperRepo,
+		growthRepo:      growthRepo,
+		vaccineRepo:     vaccineRepo,
+		cryingRepo:      cryingRepo,
+		illnessRepo:     illnessRepo,
+		temperatureRepo: temperatureRepo,
+		symptomRepo:     symptomRepo,
+		milestoneRepo:   milestoneRepo,
+		babyRepo:        babyRepo,
+		dataCache:       dataCache,
+		logger:          logger,
+		now:             time.Now,
 	}
 }
 
+// SetClock 替换计算月龄使用的当前时间，回放录制的对话时用于保证工具结果可复现
+func (t *DataQueryTools) SetClock(now func() time.Time) {
+	t.now = now
+}
+
 // GetToolInfos 获取所有工具信息
 func (t *DataQueryTools) GetToolInfos() []*schema.ToolInfo {
 	return []*schema.ToolInfo{
@@ -55,6 +83,39 @@ func (t *DataQueryTools) GetToolInfos() []*schema.ToolInfo {
 	}
 }
 
+// AllToolInfos 获取通用工具和各分析类型专用工具的全部信息(对话场景按问题选择需要的工具)
+func (t *DataQueryTools) AllToolInfos() []*schema.ToolInfo {
+	return append(t.GetToolInfos(),
+		t.getDiaperPatternsToolInfo(),
+		t.getVaccineReactionsToolInfo(),
+		t.getIllnessEpisodesToolInfo(),
+		t.getTemperatureDataToolInfo(),
+		t.getCryingDataToolInfo(),
+		t.getMilestoneDataToolInfo(),
+	)
+}
+
+// ToolInfosFor 获取指定分析类型可用的工具信息
+// 在通用工具之外，健康分析增加排泄规律、疫苗反应、患病经过和体温症状工具，行为分析增加哭闹记录工具，
+// 成长分析增加发育里程碑工具
+func (t *DataQueryTools) ToolInfosFor(analysisType entity.AIAnalysisType) []*schema.ToolInfo {
+	infos := t.GetToolInfos()
+	switch analysisType {
+	case entity.AIAnalysisTypeHealth:
+		infos = append(infos,
+			t.getDiaperPatternsToolInfo(),
+			t.getVaccineReactionsToolInfo(),
+			t.getIllnessEpisodesToolInfo(),
+			t.getTemperatureDataToolInfo(),
+		)
+	case entity.AIAnalysisTypeBehavior:
+		infos = append(infos, t.getCryingDataToolInfo())
+	case entity.AIAnalysisTypeGrowth:
+		infos = append(infos, t.getMilestoneDataToolInfo())
+	}
+	return infos
+}
+
 // getFeedingDataToolInfo 获取喂养数据工具信息
 func (t *DataQueryTools) getFeedingDataToolInfo() *schema.ToolInfo {
 	return &schema.ToolInfo{
@@ -202,6 +263,18 @@ func (t *DataQueryTools) ExecuteTool(ctx context.Context, toolName string, param
 		return t.getVaccineData(ctx, params)
 	case "get_baby_info":
 		return t.getBabyInfo(ctx, params)
+	case "get_diaper_patterns":
+		return t.getDiaperPatterns(ctx, params)
+	case "get_vaccine_reactions":
+		return t.getVaccineReactions(ctx, params)
+	case "get_illness_episodes":
+		return t.getIllnessEpisodes(ctx, params)
+	case "get_crying_data":
+		return t.getCryingData(ctx, params)
+	case "get_temperature_data":
+		return t.getTemperatureData(ctx, params)
+	case "get_milestone_data":
+		return t.getMilestoneData(ctx, params)
 	default:
 		return "", fmt.Errorf("未知的工具: %s", toolName)
 	}
@@ -214,7 +287,10 @@ func (t *DataQueryTools) getFeedingData(ctx context.Context, params map[string]i
 		return "", err
 	}
 
-	records, _, err := t.feedingRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+	records, err := t.dataCache.GetFeedingRecords(ctx, babyID, startTime, endTime, limit, func(ctx context.Context) ([]*entity.FeedingRecord, error) {
+		records, _, err := t.feedingRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+		return records, err
+	})
 	if err != nil {
 		t.logger.Error("获取喂养数据失败", zap.Error(err))
 		return "", fmt.Errorf("获取喂养数据失败: %v", err)
@@ -241,7 +317,10 @@ func (t *DataQueryTools) getSleepData(ctx context.Context, params map[string]int
 		return "", err
 	}
 
-	records, _, err := t.sleepRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+	records, err := t.dataCache.GetSleepRecords(ctx, babyID, startTime, endTime, limit, func(ctx context.Context) ([]*entity.SleepRecord, error) {
+		records, _, err := t.sleepRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+		return records, err
+	})
 	if err != nil {
 		t.logger.Error("获取睡眠数据失败", zap.Error(err))
 		return "", fmt.Errorf("获取睡眠数据失败: %v", err)
@@ -268,7 +347,10 @@ func (t *DataQueryTools) getGrowthData(ctx context.Context, params map[string]in
 		return "", err
 	}
 
-	records, _, err := t.growthRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+	records, err := t.dataCache.GetGrowthRecords(ctx, babyID, startTime, endTime, limit, func(ctx context.Context) ([]*entity.GrowthRecord, error) {
+		records, _, err := t.growthRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+		return records, err
+	})
 	if err != nil {
 		t.logger.Error("获取成长数据失败", zap.Error(err))
 		return "", fmt.Errorf("获取成长数据失败: %v", err)
@@ -295,7 +377,10 @@ func (t *DataQueryTools) getDiaperData(ctx context.Context, params map[string]in
 		return "", err
 	}
 
-	records, _, err := t.diaperRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+	records, err := t.dataCache.GetDiaperRecords(ctx, babyID, startTime, endTime, limit, func(ctx context.Context) ([]*entity.DiaperRecord, error) {
+		records, _, err := t.diaperRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, limit)
+		return records, err
+	})
 	if err != nil {
 		t.logger.Error("获取尿布数据失败", zap.Error(err))
 		return "", fmt.Errorf("获取尿布数据失败: %v", err)
@@ -317,11 +402,10 @@ func (t *DataQueryTools) getDiaperData(ctx context.Context, params map[string]in
 
 // getVaccineData 获取疫苗数据
 func (t *DataQueryTools) getVaccineData(ctx context.Context, params map[string]interface{}) (string, error) {
-	babyIDFloat, ok := params["baby_id"].(float64)
-	if !ok {
-		return "", fmt.Errorf("无效的宝宝ID")
+	babyID, err := parseBabyID(params)
+	if err != nil {
+		return "", err
 	}
-	babyID := int64(babyIDFloat)
 
 	records, err := t.vaccineRepo.FindByBabyID(ctx, babyID, 1, 100)
 	if err != nil {
@@ -345,13 +429,14 @@ func (t *DataQueryTools) getVaccineData(ctx context.Context, params map[string]i
 
 // getBabyInfo 获取宝宝信息
 func (t *DataQueryTools) getBabyInfo(ctx context.Context, params map[string]interface{}) (string, error) {
-	babyIDFloat, ok := params["baby_id"].(float64)
-	if !ok {
-		return "", fmt.Errorf("无效的宝宝ID")
+	babyID, err := parseBabyID(params)
+	if err != nil {
+		return "", err
 	}
-	babyID := int64(babyIDFloat)
 
-	baby, err := t.babyRepo.FindByID(ctx, babyID)
+	baby, err := t.dataCache.GetBabyInfo(ctx, babyID, func(ctx context.Context) (*entity.Baby, error) {
+		return t.babyRepo.FindByID(ctx, babyID)
+	})
 	if err != nil {
 		t.logger.Error("获取宝宝信息失败", zap.Error(err))
 		return "", fmt.Errorf("获取宝宝信息失败: %v", err)
@@ -359,7 +444,7 @@ func (t *DataQueryTools) getBabyInfo(ctx context.Context, params map[string]inte
 
 	// 计算月龄
 	birthDate, _ := time.Parse("2006-01-02", baby.BirthDate)
-	now := time.Now()
+	now := t.now()
 	months := (now.Year()-birthDate.Year())*12 + int(now.Month()) - int(birthDate.Month())
 	if now.Day() < birthDate.Day() {
 		months--
@@ -385,12 +470,10 @@ func (t *DataQueryTools) getBabyInfo(ctx context.Context, params map[string]inte
 // parseCommonParams 解析通用参数
 func (t *DataQueryTools) parseCommonParams(params map[string]interface{}) (babyID int64, startTime, endTime int64, limit int, err error) {
 	// 解析宝宝ID
-	babyIDFloat, ok := params["baby_id"].(float64)
-	if !ok {
-		err = fmt.Errorf("无效的宝宝ID")
+	babyID, err = parseBabyID(params)
+	if err != nil {
 		return
 	}
-	babyID = int64(babyIDFloat)
 
 	// 解析开始日期
 	startDateStr, ok := params["start_date"].(string)
@@ -427,3 +510,21 @@ func (t *DataQueryTools) parseCommonParams(params map[string]interface{}) (babyI
 
 	return
 }
+
+// parseBabyID 解析宝宝ID
+// 模型返回的JSON数字会被解析为 float64，雪花ID超出其精确范围；
+// 调用方已知确切ID时可直接传入 int64 或字符串
+func parseBabyID(params map[string]interface{}) (int64, error) {
+	switch v := params["baby_id"].(type) {
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("无效的宝宝ID")
+	}
+}
@@ -0,0 +1,666 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/utils"
+)
+
+// patternRecordLimit 统计规律时每类记录的读取上限
+const patternRecordLimit = 1000
+
+// colicDailyMinutes 肠绞痛"3-3-3"经验法则中每天哭闹的分钟数
+const colicDailyMinutes = 180
+
+// getDiaperPatternsToolInfo 获取排泄规律工具信息
+func (t *DataQueryTools) getDiaperPatternsToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_diaper_patterns",
+		Desc: "按天统计宝宝指定时间范围内的小便和大便次数、最长小便间隔以及大便颜色和性状分布，用于评估水分摄入和消化情况",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+			"start_date": {
+				Type: "string",
+				Desc: "开始日期，格式：YYYY-MM-DD",
+			},
+			"end_date": {
+				Type: "string",
+				Desc: "结束日期(包含当天)，格式：YYYY-MM-DD",
+			},
+		}),
+	}
+}
+
+// getVaccineReactionsToolInfo 获取疫苗反应工具信息
+func (t *DataQueryTools) getVaccineReactionsToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_vaccine_reactions",
+		Desc: "获取宝宝在指定时间范围内完成的疫苗接种及记录的接种反应",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+			"start_date": {
+				Type: "string",
+				Desc: "开始日期，格式：YYYY-MM-DD",
+			},
+			"end_date": {
+				Type: "string",
+				Desc: "结束日期(包含当天)，格式：YYYY-MM-DD",
+			},
+		}),
+	}
+}
+
+// getIllnessEpisodesToolInfo 获取患病经过工具信息
+func (t *DataQueryTools) getIllnessEpisodesToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_illness_episodes",
+		Desc: "获取与指定时间范围有交集的患病经过，包括名称、起止时间、持续天数、是否就医和诊断，以及范围内的患病天数",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+			"start_date": {
+				Type: "string",
+				Desc: "开始日期，格式：YYYY-MM-DD",
+			},
+			"end_date": {
+				Type: "string",
+				Desc: "结束日期(包含当天)，格式：YYYY-MM-DD",
+			},
+		}),
+	}
+}
+
+// getCryingDataToolInfo 获取哭闹数据工具信息
+func (t *DataQueryTools) getCryingDataToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_crying_data",
+		Desc: "获取宝宝指定时间范围内的哭闹和烦躁记录，并按天统计哭闹时长，按小时统计发生时段，汇总强度、诱因和有效的安抚方式",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+			"start_date": {
+				Type: "string",
+				Desc: "开始日期，格式：YYYY-MM-DD",
+			},
+			"end_date": {
+				Type: "string",
+				Desc: "结束日期(包含当天)，格式：YYYY-MM-DD",
+			},
+		}),
+	}
+}
+
+// getTemperatureDataToolInfo 获取体温与症状工具信息
+func (t *DataQueryTools) getTemperatureDataToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_temperature_data",
+		Desc: "获取宝宝指定时间范围内的体温测量(含测量部位和是否达到该部位的发热标准)和症状记录，并按天统计最高体温和发热次数，汇总症状及严重程度",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+			"start_date": {
+				Type: "string",
+				Desc: "开始日期，格式：YYYY-MM-DD",
+			},
+			"end_date": {
+				Type: "string",
+				Desc: "结束日期(包含当天)，格式：YYYY-MM-DD",
+			},
+		}),
+	}
+}
+
+// diaperDay 单日排泄统计
+type diaperDay struct {
+	Date  string `json:"date"`
+	Wet   int    `json:"wet"`
+	Dirty int    `json:"dirty"`
+}
+
+// getDiaperPatterns 获取排泄规律
+func (t *DataQueryTools) getDiaperPatterns(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, baby, startTime, endTime, err := t.parseDayRange(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	records, err := t.dataCache.GetDiaperRecords(ctx, babyID, startTime, endTime, patternRecordLimit, func(ctx context.Context) ([]*entity.DiaperRecord, error) {
+		records, _, err := t.diaperRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, patternRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取排泄规律失败", zap.Error(err))
+		return "", fmt.Errorf("获取排泄规律失败: %v", err)
+	}
+
+	sorted := make([]*entity.DiaperRecord, len(records))
+	copy(sorted, records)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	loc := baby.Location()
+	days := make([]*diaperDay, 0)
+	byDate := make(map[string]*diaperDay)
+	colors := make(map[string]int)
+	textures := make(map[string]int)
+	var wetTotal, dirtyTotal int
+	var lastWet, longestWetGap int64
+	for _, record := range sorted {
+		date := time.UnixMilli(record.Time).In(loc).Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &diaperDay{Date: date}
+			byDate[date] = day
+			days = append(days, day)
+		}
+		if isWetDiaper(record) {
+			day.Wet++
+			wetTotal++
+			if lastWet > 0 && record.Time-lastWet > longestWetGap {
+				longestWetGap = record.Time - lastWet
+			}
+			lastWet = record.Time
+		}
+		if isDirtyDiaper(record) {
+			day.Dirty++
+			dirtyTotal++
+			if record.PoopColor != nil && *record.PoopColor != "" {
+				colors[*record.PoopColor]++
+			}
+			if record.PoopTexture != nil && *record.PoopTexture != "" {
+				textures[*record.PoopTexture]++
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"type":                  "diaper_patterns",
+		"count":                 len(sorted),
+		"days":                  days,
+		"range_days":            rangeDays(startTime, endTime, t.now().UnixMilli()),
+		"avg_wet_per_day":       perDay(wetTotal, len(days)),
+		"avg_dirty_per_day":     perDay(dirtyTotal, len(days)),
+		"longest_wet_gap_hours": roundTenth(float64(longestWetGap) / float64(time.Hour.Milliseconds())),
+		"stool_colors":          colors,
+		"stool_textures":        textures,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化排泄规律失败: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// vaccineReaction 单次接种及反应
+type vaccineReaction struct {
+	VaccineName string `json:"vaccine_name"`
+	DoseNumber  int    `json:"dose_number"`
+	VaccineDate string `json:"vaccine_date"`
+	Reaction    string `json:"reaction,omitempty"`
+}
+
+// getVaccineReactions 获取疫苗接种反应
+func (t *DataQueryTools) getVaccineReactions(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, baby, startTime, endTime, err := t.parseDayRange(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	schedules, err := t.vaccineRepo.FindByBabyID(ctx, babyID, 1, 100)
+	if err != nil {
+		t.logger.Error("获取疫苗反应失败", zap.Error(err))
+		return "", fmt.Errorf("获取疫苗反应失败: %v", err)
+	}
+
+	vaccinations := make([]*vaccineReaction, 0)
+	withReaction := 0
+	for _, schedule := range schedules {
+		if !schedule.IsCompleted() || schedule.VaccineDate == nil {
+			continue
+		}
+		if *schedule.VaccineDate < startTime || *schedule.VaccineDate > endTime {
+			continue
+		}
+		item := &vaccineReaction{
+			VaccineName: schedule.VaccineName,
+			DoseNumber:  schedule.DoseNumber,
+			VaccineDate: time.UnixMilli(*schedule.VaccineDate).In(baby.Location()).Format("2006-01-02"),
+		}
+		if schedule.Reaction != nil && *schedule.Reaction != "" {
+			item.Reaction = *schedule.Reaction
+			withReaction++
+		}
+		vaccinations = append(vaccinations, item)
+	}
+	sort.SliceStable(vaccinations, func(i, j int) bool { return vaccinations[i].VaccineDate < vaccinations[j].VaccineDate })
+
+	result := map[string]interface{}{
+		"type":          "vaccine_reactions",
+		"count":         len(vaccinations),
+		"with_reaction": withReaction,
+		"vaccinations":  vaccinations,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化疫苗反应失败: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// illnessEpisode 单次患病经过
+type illnessEpisode struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	StartTime    string  `json:"start_time"`
+	EndTime      string  `json:"end_time,omitempty"`
+	Ongoing      bool    `json:"ongoing"`
+	DurationDays float64 `json:"duration_days"`
+	SawDoctor    bool    `json:"saw_doctor"`
+	Diagnosis    string  `json:"diagnosis,omitempty"`
+	Note         string  `json:"note,omitempty"`
+}
+
+// getIllnessEpisodes 获取患病经过
+func (t *DataQueryTools) getIllnessEpisodes(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, baby, startTime, endTime, err := t.parseDayRange(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	records, err := t.dataCache.GetIllnessEpisodes(ctx, babyID, startTime, endTime, patternRecordLimit, func(ctx context.Context) ([]*entity.IllnessEpisode, error) {
+		records, _, err := t.illnessRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, patternRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取患病经过失败", zap.Error(err))
+		return "", fmt.Errorf("获取患病经过失败: %v", err)
+	}
+
+	loc := baby.Location()
+	nowMs := t.now().UnixMilli()
+	episodes := make([]*illnessEpisode, 0, len(records))
+	sickDates := make(map[string]bool)
+	ongoing := 0
+	for _, record := range records {
+		end := nowMs
+		item := &illnessEpisode{
+			ID:        record.ID,
+			Name:      record.Name,
+			StartTime: time.UnixMilli(record.StartTime).In(loc).Format("2006-01-02 15:04"),
+			Ongoing:   record.IsOngoing(),
+			SawDoctor: record.SawDoctor,
+			Diagnosis: utils.DerefString(record.Diagnosis),
+			Note:      utils.DerefString(record.Note),
+		}
+		if record.IsOngoing() {
+			ongoing++
+		} else {
+			end = *record.EndTime
+			item.EndTime = time.UnixMilli(end).In(loc).Format("2006-01-02 15:04")
+		}
+		item.DurationDays = roundTenth(float64(end-record.StartTime) / float64((24 * time.Hour).Milliseconds()))
+		episodes = append(episodes, item)
+
+		// 统计查询范围内的患病天数，多次患病重叠的日期只计一次
+		from, to := max(record.StartTime, startTime), min(end, endTime)
+		first := time.UnixMilli(from).In(loc)
+		for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.UnixMilli() <= to; day = day.AddDate(0, 0, 1) {
+			sickDates[day.Format("2006-01-02")] = true
+		}
+	}
+	sort.SliceStable(episodes, func(i, j int) bool { return episodes[i].StartTime < episodes[j].StartTime })
+
+	result := map[string]interface{}{
+		"type":       "illness_episodes",
+		"count":      len(episodes),
+		"ongoing":    ongoing,
+		"sick_days":  len(sickDates),
+		"range_days": rangeDays(startTime, endTime, nowMs),
+		"episodes":   episodes,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化患病经过失败: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// cryingDay 单日哭闹统计
+type cryingDay struct {
+	Date    string  `json:"date"`
+	Count   int     `json:"count"`
+	Minutes float64 `json:"minutes"`
+}
+
+// getCryingData 获取哭闹数据
+func (t *DataQueryTools) getCryingData(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, baby, startTime, endTime, err := t.parseDayRange(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	records, err := t.dataCache.GetCryingRecords(ctx, babyID, startTime, endTime, patternRecordLimit, func(ctx context.Context) ([]*entity.CryingRecord, error) {
+		records, _, err := t.cryingRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, patternRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取哭闹数据失败", zap.Error(err))
+		return "", fmt.Errorf("获取哭闹数据失败: %v", err)
+	}
+
+	sorted := make([]*entity.CryingRecord, len(records))
+	copy(sorted, records)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartTime < sorted[j].StartTime })
+
+	loc := baby.Location()
+	days := make([]*cryingDay, 0)
+	byDate := make(map[string]*cryingDay)
+	hours := make([]int, 24)
+	intensities := make(map[string]int)
+	triggers := make(map[string]int)
+	soothing := make(map[string]int)
+	var totalMinutes float64
+	for _, record := range sorted {
+		start := time.UnixMilli(record.StartTime).In(loc)
+		date := start.Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &cryingDay{Date: date}
+			byDate[date] = day
+			days = append(days, day)
+		}
+		minutes := float64(record.Duration) / 60
+		day.Count++
+		day.Minutes += minutes
+		totalMinutes += minutes
+		hours[start.Hour()]++
+		if record.Intensity != "" {
+			intensities[record.Intensity]++
+		}
+		if record.Trigger != "" {
+			triggers[record.Trigger]++
+		}
+		if record.Soothing != nil && *record.Soothing != "" {
+			soothing[*record.Soothing]++
+		}
+	}
+
+	daysOverColic := 0
+	for _, day := range days {
+		day.Minutes = roundTenth(day.Minutes)
+		if day.Minutes >= colicDailyMinutes {
+			daysOverColic++
+		}
+	}
+
+	result := map[string]interface{}{
+		"type":                   "crying_data",
+		"count":                  len(sorted),
+		"days":                   days,
+		"range_days":             rangeDays(startTime, endTime, t.now().UnixMilli()),
+		"total_minutes":          roundTenth(totalMinutes),
+		"avg_minutes_per_day":    roundTenth(totalMinutes / math.Max(float64(len(days)), 1)),
+		"days_over_3h":           daysOverColic,
+		"hour_distribution":      hours,
+		"intensity_distribution": intensities,
+		"trigger_distribution":   triggers,
+		"effective_soothing":     soothing,
+		"records":                sorted,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化哭闹数据失败: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// temperatureDay 单日体温统计
+type temperatureDay struct {
+	Date           string  `json:"date"`
+	Count          int     `json:"count"`
+	FeverCount     int     `json:"fever_count"`
+	MaxTemperature float64 `json:"max_temperature"`
+}
+
+// temperatureReading 单次体温测量
+type temperatureReading struct {
+	ID          int64   `json:"id"`
+	Time        string  `json:"time"`
+	Temperature float64 `json:"temperature"`
+	Site        string  `json:"site"`
+	Fever       bool    `json:"fever"`
+}
+
+// symptomItem 单条症状记录
+type symptomItem struct {
+	ID       int64  `json:"id"`
+	Time     string `json:"time"`
+	Symptom  string `json:"symptom"`
+	Severity string `json:"severity"`
+	Note     string `json:"note,omitempty"`
+}
+
+// getTemperatureData 获取体温与症状数据
+func (t *DataQueryTools) getTemperatureData(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, baby, startTime, endTime, err := t.parseDayRange(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	temperatures, err := t.dataCache.GetTemperatureRecords(ctx, babyID, startTime, endTime, patternRecordLimit, func(ctx context.Context) ([]*entity.TemperatureRecord, error) {
+		records, _, err := t.temperatureRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, patternRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取体温数据失败", zap.Error(err))
+		return "", fmt.Errorf("获取体温数据失败: %v", err)
+	}
+	symptoms, err := t.dataCache.GetSymptomRecords(ctx, babyID, startTime, endTime, patternRecordLimit, func(ctx context.Context) ([]*entity.SymptomRecord, error) {
+		records, _, err := t.symptomRepo.FindByBabyID(ctx, babyID, startTime, endTime, 1, patternRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取症状数据失败", zap.Error(err))
+		return "", fmt.Errorf("获取症状数据失败: %v", err)
+	}
+
+	sortedTemps := make([]*entity.TemperatureRecord, len(temperatures))
+	copy(sortedTemps, temperatures)
+	sort.Slice(sortedTemps, func(i, j int) bool { return sortedTemps[i].Time < sortedTemps[j].Time })
+
+	loc := baby.Location()
+	days := make([]*temperatureDay, 0)
+	byDate := make(map[string]*temperatureDay)
+	readings := make([]*temperatureReading, 0, len(sortedTemps))
+	feverReadings := 0
+	var peak *entity.TemperatureRecord
+	for _, record := range sortedTemps {
+		at := time.UnixMilli(record.Time).In(loc)
+		date := at.Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &temperatureDay{Date: date}
+			byDate[date] = day
+			days = append(days, day)
+		}
+		fever := record.IsFever()
+		day.Count++
+		day.MaxTemperature = math.Max(day.MaxTemperature, record.Temperature)
+		if fever {
+			day.FeverCount++
+			feverReadings++
+		}
+		if peak == nil || record.CoreEquivalent() > peak.CoreEquivalent() {
+			peak = record
+		}
+		readings = append(readings, &temperatureReading{
+			ID:          record.ID,
+			Time:        at.Format("2006-01-02 15:04"),
+			Temperature: record.Temperature,
+			Site:        record.Site,
+			Fever:       fever,
+		})
+	}
+	feverDays := 0
+	for _, day := range days {
+		if day.FeverCount > 0 {
+			feverDays++
+		}
+	}
+
+	sortedSymptoms := make([]*entity.SymptomRecord, len(symptoms))
+	copy(sortedSymptoms, symptoms)
+	sort.Slice(sortedSymptoms, func(i, j int) bool { return sortedSymptoms[i].Time < sortedSymptoms[j].Time })
+
+	symptomCounts := make(map[string]int)
+	severe := 0
+	symptomItems := make([]*symptomItem, 0, len(sortedSymptoms))
+	for _, record := range sortedSymptoms {
+		symptomCounts[record.Symptom]++
+		if record.Severity == entity.SymptomSeveritySevere {
+			severe++
+		}
+		symptomItems = append(symptomItems, &symptomItem{
+			ID:       record.ID,
+			Time:     time.UnixMilli(record.Time).In(loc).Format("2006-01-02 15:04"),
+			Symptom:  record.Symptom,
+			Severity: record.Severity,
+			Note:     utils.DerefString(record.Note),
+		})
+	}
+
+	result := map[string]interface{}{
+		"type":                 "temperature_data",
+		"count":                len(readings),
+		"fever_readings":       feverReadings,
+		"fever_days":           feverDays,
+		"range_days":           rangeDays(startTime, endTime, t.now().UnixMilli()),
+		"days":                 days,
+		"readings":             readings,
+		"symptom_count":        len(symptomItems),
+		"severe_symptoms":      severe,
+		"symptom_distribution": symptomCounts,
+		"symptoms":             symptomItems,
+	}
+	if peak != nil {
+		result["peak"] = map[string]interface{}{
+			"temperature": peak.Temperature,
+			"site":        peak.Site,
+			"time":        time.UnixMilli(peak.Time).In(loc).Format("2006-01-02 15:04"),
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化体温数据失败: %v", err)
+	}
+
+	return string(data), nil
+}
+
+// parseDayRange 解析宝宝ID和日期范围
+// 与通用工具不同，日期按宝宝所在时区解释，且结束日期包含当天，便于按天统计
+func (t *DataQueryTools) parseDayRange(ctx context.Context, params map[string]interface{}) (babyID int64, baby *entity.Baby, startTime, endTime int64, err error) {
+	babyID, err = parseBabyID(params)
+	if err != nil {
+		return
+	}
+
+	baby, err = t.dataCache.GetBabyInfo(ctx, babyID, func(ctx context.Context) (*entity.Baby, error) {
+		return t.babyRepo.FindByID(ctx, babyID)
+	})
+	if err != nil {
+		t.logger.Error("获取宝宝信息失败", zap.Error(err))
+		err = fmt.Errorf("获取宝宝信息失败: %v", err)
+		return
+	}
+	loc := baby.Location()
+
+	startDateStr, ok := params["start_date"].(string)
+	if !ok {
+		err = fmt.Errorf("无效的开始日期")
+		return
+	}
+	startDate, parseErr := time.ParseInLocation("2006-01-02", startDateStr, loc)
+	if parseErr != nil {
+		err = fmt.Errorf("开始日期格式错误: %v", parseErr)
+		return
+	}
+
+	endDateStr, ok := params["end_date"].(string)
+	if !ok {
+		err = fmt.Errorf("无效的结束日期")
+		return
+	}
+	endDate, parseErr := time.ParseInLocation("2006-01-02", endDateStr, loc)
+	if parseErr != nil {
+		err = fmt.Errorf("结束日期格式错误: %v", parseErr)
+		return
+	}
+
+	startTime = startDate.UnixMilli()
+	endTime = endDate.AddDate(0, 0, 1).UnixMilli() - 1
+	return
+}
+
+// rangeDays 查询范围内截至当前的天数
+func rangeDays(startTime, endTime, nowMs int64) int {
+	if nowMs < endTime {
+		endTime = nowMs
+	}
+	if endTime < startTime {
+		return 0
+	}
+	return int((endTime-startTime)/(24*time.Hour).Milliseconds()) + 1
+}
+
+// perDay 按有记录的天数计算日均值
+func perDay(total, days int) float64 {
+	if days == 0 {
+		return 0
+	}
+	return roundTenth(float64(total) / float64(days))
+}
+
+// roundTenth 保留一位小数
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// isWetDiaper 是否有小便
+func isWetDiaper(record *entity.DiaperRecord) bool {
+	return record.Type == "pee" || record.Type == "both"
+}
+
+// isDirtyDiaper 是否有大便
+func isDirtyDiaper(record *entity.DiaperRecord) bool {
+	return record.Type == "poop" || record.Type == "both"
+}
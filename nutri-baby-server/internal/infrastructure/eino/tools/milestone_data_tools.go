@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"go.uber.org/zap"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// milestoneRecordLimit 读取里程碑记录的上限(目录条目数远小于该值)
+const milestoneRecordLimit = 200
+
+// milestoneDomains 输出时的领域顺序
+var milestoneDomains = []string{
+	entity.MilestoneDomainMotor,
+	entity.MilestoneDomainLanguage,
+	entity.MilestoneDomainSocial,
+	entity.MilestoneDomainCognitive,
+}
+
+// getMilestoneDataToolInfo 获取发育里程碑工具信息
+func (t *DataQueryTools) getMilestoneDataToolInfo() *schema.ToolInfo {
+	return &schema.ToolInfo{
+		Name: "get_milestone_data",
+		Desc: "获取宝宝的发育里程碑清单，按大运动、语言、社交、认知领域列出已达成的里程碑(含达成日期和月龄)、当前处于常见月龄范围内的里程碑，以及超过常见月龄仍未记录达成的里程碑",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"baby_id": {
+				Type: "integer",
+				Desc: "宝宝ID",
+			},
+		}),
+	}
+}
+
+// achievedMilestone 已达成的里程碑
+type achievedMilestone struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	AchievedDate string `json:"achieved_date"`
+	AgeMonths    int    `json:"age_months"`
+	Note         string `json:"note,omitempty"`
+}
+
+// pendingMilestone 尚未达成的里程碑
+type pendingMilestone struct {
+	Name        string `json:"name"`
+	StartMonths int    `json:"start_months"`
+	EndMonths   int    `json:"end_months"`
+}
+
+// milestoneDomain 单个领域的里程碑情况
+type milestoneDomain struct {
+	Domain   string               `json:"domain"`
+	Achieved []*achievedMilestone `json:"achieved"`
+	InWindow []*pendingMilestone  `json:"in_window"` // 常见月龄范围内尚未达成
+	Delayed  []*pendingMilestone  `json:"delayed"`   // 超过常见月龄仍未达成
+}
+
+// getMilestoneData 获取发育里程碑清单
+func (t *DataQueryTools) getMilestoneData(ctx context.Context, params map[string]interface{}) (string, error) {
+	babyID, err := parseBabyID(params)
+	if err != nil {
+		return "", err
+	}
+
+	baby, err := t.dataCache.GetBabyInfo(ctx, babyID, func(ctx context.Context) (*entity.Baby, error) {
+		return t.babyRepo.FindByID(ctx, babyID)
+	})
+	if err != nil {
+		t.logger.Error("获取宝宝信息失败", zap.Error(err))
+		return "", fmt.Errorf("获取宝宝信息失败: %v", err)
+	}
+	loc := baby.Location()
+	birthDate, err := time.ParseInLocation("2006-01-02", baby.BirthDate, loc)
+	if err != nil {
+		return "", fmt.Errorf("宝宝出生日期格式错误: %v", err)
+	}
+
+	records, err := t.dataCache.GetMilestoneRecords(ctx, babyID, 0, 0, milestoneRecordLimit, func(ctx context.Context) ([]*entity.MilestoneRecord, error) {
+		records, _, err := t.milestoneRepo.FindByBabyID(ctx, babyID, 0, 0, 1, milestoneRecordLimit)
+		return records, err
+	})
+	if err != nil {
+		t.logger.Error("获取里程碑数据失败", zap.Error(err))
+		return "", fmt.Errorf("获取里程碑数据失败: %v", err)
+	}
+	byCode := make(map[string]*entity.MilestoneRecord, len(records))
+	for _, record := range records {
+		byCode[record.MilestoneCode] = record
+	}
+
+	ageMonths := entity.AgeInMonths(birthDate, t.now().In(loc))
+	domains := make([]*milestoneDomain, 0, len(milestoneDomains))
+	byDomain := make(map[string]*milestoneDomain, len(milestoneDomains))
+	for _, domain := range milestoneDomains {
+		item := &milestoneDomain{
+			Domain:   domain,
+			Achieved: make([]*achievedMilestone, 0),
+			InWindow: make([]*pendingMilestone, 0),
+			Delayed:  make([]*pendingMilestone, 0),
+		}
+		byDomain[domain] = item
+		domains = append(domains, item)
+	}
+
+	var achievedCount, delayedCount int
+	for i := range entity.MilestoneCatalog {
+		def := &entity.MilestoneCatalog[i]
+		domain := byDomain[def.Domain]
+		record := byCode[def.Code]
+		name := def.Name(i18n.ZhCN)
+		pending := &pendingMilestone{Name: name, StartMonths: def.StartMonths, EndMonths: def.EndMonths}
+
+		switch def.StatusAt(ageMonths, record != nil) {
+		case entity.MilestoneStatusAchieved:
+			achievedAt := time.UnixMilli(record.AchievedAt).In(loc)
+			item := &achievedMilestone{
+				ID:           record.ID,
+				Name:         name,
+				AchievedDate: achievedAt.Format("2006-01-02"),
+				AgeMonths:    entity.AgeInMonths(birthDate, achievedAt),
+			}
+			if record.Note != nil {
+				item.Note = *record.Note
+			}
+			domain.Achieved = append(domain.Achieved, item)
+			achievedCount++
+		case entity.MilestoneStatusInWindow:
+			domain.InWindow = append(domain.InWindow, pending)
+		case entity.MilestoneStatusDelayed:
+			domain.Delayed = append(domain.Delayed, pending)
+			delayedCount++
+		}
+	}
+
+	result := map[string]interface{}{
+		"type":           "milestone_data",
+		"age_months":     ageMonths,
+		"achieved_count": achievedCount,
+		"delayed_count":  delayedCount,
+		"domains":        domains,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("序列化里程碑数据失败: %v", err)
+	}
+
+	return string(data), nil
+}
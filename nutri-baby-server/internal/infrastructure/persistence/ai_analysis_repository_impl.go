@@ -123,64 +123,53 @@ func (r *aiAnalysisRepositoryImpl) Delete(ctx context.Context, id int64) error {
 }
 
 // GetAnalysisStats 获取宝宝的分析历史统计
-func (r *aiAnalysisRepositoryImpl) GetAnalysisStats(ctx context.Context, babyID int64) (*repository.AnalysisStats, error) {
-	var stats repository.AnalysisStats
-
-	// 总分析数
-	var totalCount int64
-	if err := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).Where("baby_id = ?", babyID).Count(&totalCount).Error; err != nil {
-		return nil, errors.Wrap(errors.DatabaseError, "统计总分析数失败", err)
+func (r *aiAnalysisRepositoryImpl) GetAnalysisStats(ctx context.Context, babyID int64, since time.Time) (*repository.AnalysisStats, error) {
+	scoped := func() *gorm.DB {
+		query := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).Where("baby_id = ?", babyID)
+		if !since.IsZero() {
+			query = query.Where("created_at >= ?", since)
+		}
+		return query
 	}
-	stats.TotalAnalyses = totalCount
 
-	// 完成数
-	var completedCount int64
-	if err := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
-		Where("baby_id = ? AND status = ?", babyID, entity.AIAnalysisStatusCompleted).
-		Count(&completedCount).Error; err != nil {
-		return nil, errors.Wrap(errors.DatabaseError, "统计完成分析数失败", err)
+	stats := repository.AnalysisStats{
+		AnalysisTypeCounts: make(map[string]int64),
+		StatusCounts:       make(map[string]int64),
 	}
-	stats.CompletedAnalyses = completedCount
 
-	// 失败数
-	stats.FailedAnalyses = totalCount - completedCount
+	// 按类型和状态分组计数，总数/完成数/失败数由分组结果汇总
+	var groups []struct {
+		AnalysisType string
+		Status       string
+		Count        int64
+	}
+	if err := scoped().
+		Select("analysis_type, status, COUNT(*) AS count").
+		Group("analysis_type, status").
+		Scan(&groups).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "统计分析数失败", err)
+	}
+	for _, group := range groups {
+		stats.TotalAnalyses += group.Count
+		stats.AnalysisTypeCounts[group.AnalysisType] += group.Count
+		stats.StatusCounts[group.Status] += group.Count
+	}
+	stats.CompletedAnalyses = stats.StatusCounts[string(entity.AIAnalysisStatusCompleted)]
+	stats.FailedAnalyses = stats.StatusCounts[string(entity.AIAnalysisStatusFailed)]
 
 	// 平均分
 	var avgScore *float64
-	if err := r.db.WithContext(ctx).
-		Model(&entity.AIAnalysis{}).
-		Where("baby_id = ? AND status = ? AND score IS NOT NULL", babyID, entity.AIAnalysisStatusCompleted).
+	if err := scoped().
+		Where("status = ? AND score IS NOT NULL", entity.AIAnalysisStatusCompleted).
 		Select("AVG(score)").
 		Scan(&avgScore).Error; err != nil {
 		return nil, errors.Wrap(errors.DatabaseError, "统计平均分失败", err)
 	}
 	stats.AverageScore = avgScore
 
-	// 各类型统计
-	typeCounts := make(map[string]int64)
-	var typeStats []struct {
-		AnalysisType string
-		Count        int64
-	}
-
-	if err := r.db.WithContext(ctx).
-		Model(&entity.AIAnalysis{}).
-		Where("baby_id = ?", babyID).
-		Select("analysis_type, COUNT(*) as count").
-		Group("analysis_type").
-		Scan(&typeStats).Error; err != nil {
-		return nil, errors.Wrap(errors.DatabaseError, "统计各类型分析数失败", err)
-	}
-
-	for _, stat := range typeStats {
-		typeCounts[stat.AnalysisType] = stat.Count
-	}
-	stats.AnalysisTypeCounts = typeCounts
-
 	// 最近分析记录
 	var recentAnalyses []*entity.AIAnalysis
-	if err := r.db.WithContext(ctx).
-		Where("baby_id = ?", babyID).
+	if err := scoped().
 		Order("created_at DESC").
 		Limit(5).
 		Find(&recentAnalyses).Error; err != nil {
@@ -191,6 +180,207 @@ func (r *aiAnalysisRepositoryImpl) GetAnalysisStats(ctx context.Context, babyID
 	return &stats, nil
 }
 
+// FindByParams 按条件分页查询分析记录
+func (r *aiAnalysisRepositoryImpl) FindByParams(ctx context.Context, params repository.AIAnalysisParams) ([]*entity.AIAnalysis, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).Where("baby_id = ?", params.BabyID)
+	if params.AnalysisType != nil {
+		query = query.Where("analysis_type = ?", *params.AnalysisType)
+	}
+	if params.Status != nil {
+		query = query.Where("status = ?", *params.Status)
+	}
+	if params.StartDate != nil {
+		query = query.Where("created_at >= ?", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query = query.Where("created_at < ?", *params.EndDate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "统计AI分析记录失败", err)
+	}
+
+	var analyses []*entity.AIAnalysis
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	}
+	if err := query.Order("created_at DESC, id DESC").Find(&analyses).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "查询AI分析记录失败", err)
+	}
+	return analyses, total, nil
+}
+
+// ClaimPending 抢占可执行的任务并加租约
+// 使用 FOR UPDATE SKIP LOCKED 保证多实例并发抢占时同一任务只会被一个worker拿到
+func (r *aiAnalysisRepositoryImpl) ClaimPending(ctx context.Context, owner, provider string, leaseUntil time.Time, limit int) ([]*entity.AIAnalysis, error) {
+	now := time.Now()
+	var ids []int64
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE ai_analyses
+		SET status = ?, lease_owner = ?, lease_expires_at = ?, heartbeat_at = ?, provider = ?,
+			attempts = attempts + 1, next_attempt_at = NULL, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM ai_analyses
+			WHERE (status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?))
+				OR (status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?))
+			ORDER BY created_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		entity.AIAnalysisStatusAnalyzing, owner, leaseUntil, now, provider, now,
+		entity.AIAnalysisStatusPending, now,
+		entity.AIAnalysisStatusAnalyzing, now,
+		limit,
+	).Scan(&ids).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "抢占AI分析任务失败", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var analyses []*entity.AIAnalysis
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("created_at ASC").Find(&analyses).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询已抢占的AI分析任务失败", err)
+	}
+	return analyses, nil
+}
+
+// RenewLease 心跳续约
+func (r *aiAnalysisRepositoryImpl) RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("id = ? AND lease_owner = ? AND status = ?", id, owner, entity.AIAnalysisStatusAnalyzing).
+		Updates(map[string]interface{}{
+			"lease_expires_at": leaseUntil,
+			"heartbeat_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "AI分析任务续约失败", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CompleteJob 写入结果并标记完成
+func (r *aiAnalysisRepositoryImpl) CompleteJob(ctx context.Context, id int64, owner string, completion repository.AIAnalysisCompletion) (bool, error) {
+	updates := map[string]interface{}{
+		"result":            completion.Result,
+		"score":             completion.Score,
+		"status":            entity.AIAnalysisStatusCompleted,
+		"failure_reason":    "",
+		"lease_owner":       "",
+		"lease_expires_at":  nil,
+		"validation_errors": completion.ValidationErrors,
+		"guardrail_log":     completion.GuardrailLog,
+		"fingerprint":       completion.Fingerprint,
+		"reused_from_id":    completion.ReusedFromID,
+	}
+	if completion.Provider != "" {
+		updates["provider"] = completion.Provider
+	}
+
+	res := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("id = ? AND lease_owner = ? AND status = ?", id, owner, entity.AIAnalysisStatusAnalyzing).
+		Updates(updates)
+	if res.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "更新AI分析结果失败", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// FailJob 记录失败原因，按需退回待执行等待重试
+func (r *aiAnalysisRepositoryImpl) FailJob(ctx context.Context, id int64, owner string, failure repository.AIAnalysisFailure) (bool, error) {
+	status := entity.AIAnalysisStatusFailed
+	if failure.RetryAt != nil {
+		status = entity.AIAnalysisStatusPending
+	}
+
+	res := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("id = ? AND lease_owner = ? AND status = ?", id, owner, entity.AIAnalysisStatusAnalyzing).
+		Updates(map[string]interface{}{
+			"status":            status,
+			"failure_reason":    failure.Reason,
+			"next_attempt_at":   failure.RetryAt,
+			"lease_owner":       "",
+			"lease_expires_at":  nil,
+			"validation_errors": failure.ValidationErrors,
+		})
+	if res.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "更新AI分析失败状态失败", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ReleaseJob 释放租约并退回待执行
+func (r *aiAnalysisRepositoryImpl) ReleaseJob(ctx context.Context, id int64, owner string) error {
+	err := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("id = ? AND lease_owner = ? AND status = ?", id, owner, entity.AIAnalysisStatusAnalyzing).
+		Updates(map[string]interface{}{
+			"status":           entity.AIAnalysisStatusPending,
+			"attempts":         gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"lease_owner":      "",
+			"lease_expires_at": nil,
+		}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "释放AI分析任务失败", err)
+	}
+	return nil
+}
+
+// CountQueued 统计已创建但尚未开始执行的任务数
+func (r *aiAnalysisRepositoryImpl) CountQueued(ctx context.Context, openID string, babyID int64) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("status = ? AND attempts = 0", entity.AIAnalysisStatusPending)
+	if openID != "" {
+		query = query.Where("openid = ?", openID)
+	}
+	if babyID != 0 {
+		query = query.Where("baby_id = ?", babyID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, errors.Wrap(errors.DatabaseError, "统计排队中的AI分析任务失败", err)
+	}
+	return count, nil
+}
+
+// Cancel 取消待执行或分析中的任务
+// 执行中的worker会在下次心跳时发现租约丢失并中止
+func (r *aiAnalysisRepositoryImpl) Cancel(ctx context.Context, id int64) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&entity.AIAnalysis{}).
+		Where("id = ? AND status IN ?", id, []entity.AIAnalysisStatus{entity.AIAnalysisStatusPending, entity.AIAnalysisStatusAnalyzing}).
+		Updates(map[string]interface{}{
+			"status":           entity.AIAnalysisStatusCancelled,
+			"next_attempt_at":  nil,
+			"lease_owner":      "",
+			"lease_expires_at": nil,
+		})
+	if res.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "取消AI分析任务失败", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// FindCompletedByFingerprint 查找输入指纹相同的最近一次已完成分析
+func (r *aiAnalysisRepositoryImpl) FindCompletedByFingerprint(ctx context.Context, babyID int64, analysisType entity.AIAnalysisType, fingerprint string, excludeID int64) (*entity.AIAnalysis, error) {
+	var analyses []*entity.AIAnalysis
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ? AND analysis_type = ? AND fingerprint = ? AND status = ? AND id <> ? AND result <> ''",
+			babyID, analysisType, fingerprint, entity.AIAnalysisStatusCompleted, excludeID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&analyses).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "按指纹查询AI分析记录失败", err)
+	}
+	if len(analyses) == 0 {
+		return nil, nil
+	}
+	return analyses[0], nil
+}
+
 // dailyTipsRepositoryImpl 每日建议仓储实现
 type dailyTipsRepositoryImpl struct {
 	db *gorm.DB
@@ -209,11 +399,11 @@ func (r *dailyTipsRepositoryImpl) Create(ctx context.Context, tips *entity.Daily
 	return nil
 }
 
-// GetByBabyIDAndDate 根据宝宝ID和日期获取每日建议
-func (r *dailyTipsRepositoryImpl) GetByBabyIDAndDate(ctx context.Context, babyID int64, date time.Time) (*entity.DailyTips, error) {
+// GetByBabyIDAndDate 根据宝宝ID、日期和语言获取每日建议
+func (r *dailyTipsRepositoryImpl) GetByBabyIDAndDate(ctx context.Context, babyID int64, date time.Time, locale string) (*entity.DailyTips, error) {
 	var tips entity.DailyTips
 	err := r.db.WithContext(ctx).
-		Where("baby_id = ? AND date = ?", babyID, date.Format("2006-01-02")).
+		Where("baby_id = ? AND date = ? AND locale = ?", babyID, date.Format("2006-01-02"), locale).
 		First(&tips).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -271,6 +461,23 @@ func (r *dailyTipsRepositoryImpl) Update(ctx context.Context, tips *entity.Daily
 	return nil
 }
 
+// FindByFingerprint 查找输入指纹相同的最近一次每日建议
+func (r *dailyTipsRepositoryImpl) FindByFingerprint(ctx context.Context, babyID int64, fingerprint string) (*entity.DailyTips, error) {
+	var tips []*entity.DailyTips
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ? AND fingerprint = ?", babyID, fingerprint).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&tips).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "按指纹查询每日建议失败", err)
+	}
+	if len(tips) == 0 {
+		return nil, nil
+	}
+	return tips[0], nil
+}
+
 // DeleteExpired 删除过期的每日建议
 func (r *dailyTipsRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) error {
 	err := r.db.WithContext(ctx).
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// aiChatMessageRepositoryImpl AI助手对话消息仓储实现
+type aiChatMessageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAIChatMessageRepository 创建AI助手对话消息仓储
+func NewAIChatMessageRepository(db *gorm.DB) repository.AIChatMessageRepository {
+	return &aiChatMessageRepositoryImpl{db: db}
+}
+
+func (r *aiChatMessageRepositoryImpl) CreateBatch(ctx context.Context, messages []*entity.AIChatMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(messages).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create ai chat messages", err)
+	}
+	return nil
+}
+
+func (r *aiChatMessageRepositoryImpl) FindRecent(ctx context.Context, babyID int64, openID string, before int64, limit int) ([]*entity.AIChatMessage, error) {
+	var messages []*entity.AIChatMessage
+	query := r.db.WithContext(ctx).
+		Where("baby_id = ? AND openid = ?", babyID, openID)
+	if before > 0 {
+		query = query.Where("created_at < ?", before)
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").Limit(limit).Find(&messages).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find ai chat messages", err)
+	}
+
+	// 倒序查询最近N条后翻转为时间正序
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+func (r *aiChatMessageRepositoryImpl) DeleteConversation(ctx context.Context, babyID int64, openID string) error {
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ? AND openid = ?", babyID, openID).
+		Delete(&entity.AIChatMessage{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete ai chat messages", err)
+	}
+	return nil
+}
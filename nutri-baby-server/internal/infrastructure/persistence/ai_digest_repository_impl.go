@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// aiDigestRepositoryImpl AI周期报告仓储实现
+type aiDigestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAIDigestRepository 创建AI周期报告仓储
+func NewAIDigestRepository(db *gorm.DB) repository.AIDigestRepository {
+	return &aiDigestRepositoryImpl{db: db}
+}
+
+func (r *aiDigestRepositoryImpl) CreateIfNotExists(ctx context.Context, digest *entity.AIDigest) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(digest)
+	if result.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "failed to create ai digest", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *aiDigestRepositoryImpl) Update(ctx context.Context, digest *entity.AIDigest) error {
+	if err := r.db.WithContext(ctx).Save(digest).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update ai digest", err)
+	}
+	return nil
+}
+
+func (r *aiDigestRepositoryImpl) CompletePending(ctx context.Context, digest *entity.AIDigest) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.AIDigest{}).
+		Where("id = ? AND status = ?", digest.ID, entity.AIDigestStatusPending).
+		Updates(map[string]interface{}{
+			"status":       digest.Status,
+			"summary":      digest.Summary,
+			"score":        digest.Score,
+			"sections":     digest.Sections,
+			"trends":       digest.Trends,
+			"milestones":   digest.Milestones,
+			"concerns":     digest.Concerns,
+			"completed_at": digest.CompletedAt,
+		})
+	if result.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "failed to complete ai digest", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *aiDigestRepositoryImpl) GetByID(ctx context.Context, id int64) (*entity.AIDigest, error) {
+	var digest entity.AIDigest
+	if err := r.db.WithContext(ctx).First(&digest, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.NotFound, "报告不存在")
+		}
+		return nil, errors.Wrap(errors.DatabaseError, "failed to get ai digest", err)
+	}
+	return &digest, nil
+}
+
+func (r *aiDigestRepositoryImpl) FindPrevious(ctx context.Context, babyID int64, period entity.AIDigestPeriod, before time.Time) (*entity.AIDigest, error) {
+	var digests []*entity.AIDigest
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ? AND period = ? AND status = ? AND start_date < ?", babyID, period, entity.AIDigestStatusCompleted, before).
+		Order("start_date DESC").
+		Limit(1).
+		Find(&digests).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find previous ai digest", err)
+	}
+	if len(digests) == 0 {
+		return nil, nil
+	}
+	return digests[0], nil
+}
+
+func (r *aiDigestRepositoryImpl) FindPending(ctx context.Context, limit int) ([]*entity.AIDigest, error) {
+	var digests []*entity.AIDigest
+	query := r.db.WithContext(ctx).
+		Where("status = ?", entity.AIDigestStatusPending).
+		Order("id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&digests).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find pending ai digests", err)
+	}
+	return digests, nil
+}
+
+func (r *aiDigestRepositoryImpl) ListByBabyID(ctx context.Context, babyID int64, period entity.AIDigestPeriod, page, pageSize int) ([]*entity.AIDigest, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.AIDigest{}).
+		Where("baby_id = ? AND status = ?", babyID, entity.AIDigestStatusCompleted)
+	if period != "" {
+		query = query.Where("period = ?", period)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count ai digests", err)
+	}
+
+	var digests []*entity.AIDigest
+	err := query.Order("start_date DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&digests).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to list ai digests", err)
+	}
+	return digests, total, nil
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// aiUsageTotalsColumns 用量汇总的聚合列
+const aiUsageTotalsColumns = `COUNT(DISTINCT request_id) AS requests,
+	COUNT(*) AS calls,
+	COALESCE(SUM(CASE WHEN success THEN 0 ELSE 1 END), 0) AS failed_calls,
+	COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+	COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+	COALESCE(SUM(total_tokens), 0) AS total_tokens,
+	COALESCE(SUM(cost), 0) AS cost,
+	COALESCE(AVG(latency_ms), 0) AS avg_latency_ms`
+
+// aiUsageRepositoryImpl AI调用用量仓储实现
+type aiUsageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAIUsageRepository 创建AI调用用量仓储
+func NewAIUsageRepository(db *gorm.DB) repository.AIUsageRepository {
+	return &aiUsageRepositoryImpl{db: db}
+}
+
+func (r *aiUsageRepositoryImpl) Create(ctx context.Context, record *entity.AIUsageRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create ai usage record", err)
+	}
+	return nil
+}
+
+func (r *aiUsageRepositoryImpl) SumUsage(ctx context.Context, filter repository.AIUsageFilter) (*repository.AIUsageTotals, error) {
+	var totals repository.AIUsageTotals
+	err := r.filtered(ctx, filter).
+		Select(aiUsageTotalsColumns).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to sum ai usage", err)
+	}
+	return &totals, nil
+}
+
+func (r *aiUsageRepositoryImpl) DailyUsage(ctx context.Context, filter repository.AIUsageFilter, utcOffsetSeconds int) ([]*repository.AIUsageDailyRow, error) {
+	offsetMs := int64(utcOffsetSeconds) * 1000
+	var rows []*repository.AIUsageDailyRow
+	err := r.filtered(ctx, filter).
+		Select("FLOOR((created_at + ?) / 86400000.0)::bigint * 86400000 - ? AS day_start, provider, model, "+aiUsageTotalsColumns, offsetMs, offsetMs).
+		Group("day_start, provider, model").
+		Order("day_start ASC, provider ASC, model ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to aggregate daily ai usage", err)
+	}
+	return rows, nil
+}
+
+func (r *aiUsageRepositoryImpl) TopUsers(ctx context.Context, filter repository.AIUsageFilter, limit int) ([]*repository.AIUsageConsumerRow, error) {
+	var rows []*repository.AIUsageConsumerRow
+	err := r.filtered(ctx, filter).
+		Where("openid <> ''").
+		Select("openid AS open_id, " + aiUsageTotalsColumns).
+		Group("openid").
+		Order("total_tokens DESC, calls DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to aggregate ai usage by user", err)
+	}
+	return rows, nil
+}
+
+func (r *aiUsageRepositoryImpl) TopBabies(ctx context.Context, filter repository.AIUsageFilter, limit int) ([]*repository.AIUsageConsumerRow, error) {
+	var rows []*repository.AIUsageConsumerRow
+	err := r.filtered(ctx, filter).
+		Where("baby_id <> 0").
+		Select("baby_id, " + aiUsageTotalsColumns).
+		Group("baby_id").
+		Order("total_tokens DESC, calls DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to aggregate ai usage by baby", err)
+	}
+	return rows, nil
+}
+
+// filtered 应用公共查询条件
+func (r *aiUsageRepositoryImpl) filtered(ctx context.Context, filter repository.AIUsageFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entity.AIUsageRecord{})
+	if filter.OpenID != "" {
+		query = query.Where("openid = ?", filter.OpenID)
+	}
+	if filter.BabyID != 0 {
+		query = query.Where("baby_id = ?", filter.BabyID)
+	}
+	if filter.Since > 0 {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if filter.Until > 0 {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	return query
+}
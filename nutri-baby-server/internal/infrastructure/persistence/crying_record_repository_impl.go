@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// cryingRecordRepositoryImpl 哭闹记录仓储实现
+type cryingRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCryingRecordRepository 创建哭闹记录仓储
+func NewCryingRecordRepository(db *gorm.DB) repository.CryingRecordRepository {
+	return &cryingRecordRepositoryImpl{db: db}
+}
+
+func (r *cryingRecordRepositoryImpl) Create(ctx context.Context, record *entity.CryingRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create crying record", err)
+	}
+	return nil
+}
+
+func (r *cryingRecordRepositoryImpl) FindByID(ctx context.Context, recordID int64) (*entity.CryingRecord, error) {
+	var record entity.CryingRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "crying record not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find crying record", err)
+	}
+
+	return &record, nil
+}
+
+func (r *cryingRecordRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.CryingRecord, int64, error) {
+	var records []*entity.CryingRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.CryingRecord{}).
+		Where("baby_id = ?", babyID)
+
+	if startTime > 0 {
+		query = query.Where("start_time >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("start_time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count crying records", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("start_time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find crying records", err)
+	}
+
+	return records, total, nil
+}
+
+func (r *cryingRecordRepositoryImpl) Delete(ctx context.Context, recordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		Delete(&entity.CryingRecord{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete crying record", err)
+	}
+	return nil
+}
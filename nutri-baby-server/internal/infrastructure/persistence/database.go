@@ -75,5 +75,21 @@ func autoMigrate(db *gorm.DB) error {
 		&entity.MessageSendQueue{},    // 订阅消息：消息发送队列
 		&entity.AIAnalysis{},          // AI分析
 		&entity.DailyTips{},           // 每日建议
+		&entity.HealthAlert{},         // 健康提醒(规则引擎)
+		&entity.FoodReaction{},        // 食物反应记录
+		&entity.PumpingRecord{},       // 吸奶记录
+		&entity.MilkStashItem{},       // 母乳库存
+		&entity.MilkStashUsage{},      // 母乳库存消耗明细
+		&entity.AIChatMessage{},       // AI助手对话消息
+		&entity.AIUsageRecord{},       // AI调用用量
+		&entity.AIDigest{},            // AI周期报告(周报/月报)
+		&entity.CryingRecord{},        // 哭闹记录
+		&entity.IllnessEpisode{},      // 患病经过
+		&entity.TemperatureRecord{},   // 体温记录
+		&entity.SymptomRecord{},       // 症状记录
+		&entity.Medication{},          // 药品/补充剂
+		&entity.MedicationDose{},      // 给药记录
+		&entity.MedicationSchedule{},  // 用药计划
+		&entity.MilestoneRecord{},     // 里程碑达成记录
 	)
 }
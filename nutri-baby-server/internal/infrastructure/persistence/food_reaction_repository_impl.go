@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// foodReactionRepositoryImpl 食物反应仓储实现
+type foodReactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFoodReactionRepository 创建食物反应仓储
+func NewFoodReactionRepository(db *gorm.DB) repository.FoodReactionRepository {
+	return &foodReactionRepositoryImpl{db: db}
+}
+
+func (r *foodReactionRepositoryImpl) Create(ctx context.Context, reaction *entity.FoodReaction) error {
+	if err := r.db.WithContext(ctx).Create(reaction).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create food reaction", err)
+	}
+	return nil
+}
+
+func (r *foodReactionRepositoryImpl) Delete(ctx context.Context, reactionID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", reactionID).
+		Delete(&entity.FoodReaction{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete food reaction", err)
+	}
+	return nil
+}
+
+func (r *foodReactionRepositoryImpl) FindByID(ctx context.Context, reactionID int64) (*entity.FoodReaction, error) {
+	var reaction entity.FoodReaction
+	err := r.db.WithContext(ctx).
+		Where("id = ?", reactionID).
+		First(&reaction).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "food reaction not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find food reaction", err)
+	}
+
+	return &reaction, nil
+}
+
+func (r *foodReactionRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64) ([]*entity.FoodReaction, error) {
+	var reactions []*entity.FoodReaction
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ?", babyID).
+		Order("occurred_at DESC").
+		Find(&reactions).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find food reactions", err)
+	}
+	return reactions, nil
+}
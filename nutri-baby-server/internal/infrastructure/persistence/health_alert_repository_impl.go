@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// healthAlertRepositoryImpl 健康提醒仓储实现
+type healthAlertRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewHealthAlertRepository 创建健康提醒仓储
+func NewHealthAlertRepository(db *gorm.DB) repository.HealthAlertRepository {
+	return &healthAlertRepositoryImpl{db: db}
+}
+
+func (r *healthAlertRepositoryImpl) CreateIfNotExists(ctx context.Context, alert *entity.HealthAlert) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	if result.Error != nil {
+		return false, errors.Wrap(errors.DatabaseError, "failed to create health alert", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *healthAlertRepositoryImpl) MarkNotified(ctx context.Context, alertID int64, notifiedAt int64) error {
+	err := r.db.WithContext(ctx).
+		Model(&entity.HealthAlert{}).
+		Where("id = ?", alertID).
+		Update("notified_at", notifiedAt).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to mark health alert notified", err)
+	}
+	return nil
+}
+
+func (r *healthAlertRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, since int64, limit int) ([]*entity.HealthAlert, error) {
+	var alerts []*entity.HealthAlert
+	query := r.db.WithContext(ctx).
+		Where("baby_id = ? AND triggered_at >= ?", babyID, since).
+		Order("triggered_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&alerts).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find health alerts", err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// illnessEpisodeRepositoryImpl 患病经过仓储实现
+type illnessEpisodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewIllnessEpisodeRepository 创建患病经过仓储
+func NewIllnessEpisodeRepository(db *gorm.DB) repository.IllnessEpisodeRepository {
+	return &illnessEpisodeRepositoryImpl{db: db}
+}
+
+func (r *illnessEpisodeRepositoryImpl) Create(ctx context.Context, episode *entity.IllnessEpisode) error {
+	if err := r.db.WithContext(ctx).Create(episode).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create illness episode", err)
+	}
+	return nil
+}
+
+func (r *illnessEpisodeRepositoryImpl) FindByID(ctx context.Context, episodeID int64) (*entity.IllnessEpisode, error) {
+	var episode entity.IllnessEpisode
+	err := r.db.WithContext(ctx).
+		Where("id = ?", episodeID).
+		First(&episode).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "illness episode not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find illness episode", err)
+	}
+
+	return &episode, nil
+}
+
+func (r *illnessEpisodeRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.IllnessEpisode, int64, error) {
+	var episodes []*entity.IllnessEpisode
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.IllnessEpisode{}).
+		Where("baby_id = ?", babyID)
+
+	// 按区间交集过滤，跨越查询起点的患病经过也需要返回
+	if startTime > 0 {
+		query = query.Where("(end_time IS NULL OR end_time >= ?)", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("start_time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count illness episodes", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("start_time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&episodes).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find illness episodes", err)
+	}
+
+	return episodes, total, nil
+}
+
+func (r *illnessEpisodeRepositoryImpl) Update(ctx context.Context, episode *entity.IllnessEpisode) error {
+	if err := r.db.WithContext(ctx).Save(episode).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update illness episode", err)
+	}
+	return nil
+}
+
+func (r *illnessEpisodeRepositoryImpl) Delete(ctx context.Context, episodeID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", episodeID).
+		Delete(&entity.IllnessEpisode{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete illness episode", err)
+	}
+	return nil
+}
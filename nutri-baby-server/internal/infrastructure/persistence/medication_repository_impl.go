@@ -0,0 +1,275 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// medicationRepositoryImpl 药品仓储实现
+type medicationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMedicationRepository 创建药品仓储
+func NewMedicationRepository(db *gorm.DB) repository.MedicationRepository {
+	return &medicationRepositoryImpl{db: db}
+}
+
+func (r *medicationRepositoryImpl) Create(ctx context.Context, medication *entity.Medication) error {
+	if err := r.db.WithContext(ctx).Create(medication).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create medication", err)
+	}
+	return nil
+}
+
+func (r *medicationRepositoryImpl) FindByID(ctx context.Context, medicationID int64) (*entity.Medication, error) {
+	var medication entity.Medication
+	err := r.db.WithContext(ctx).
+		Where("id = ?", medicationID).
+		First(&medication).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "medication not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medication", err)
+	}
+
+	return &medication, nil
+}
+
+func (r *medicationRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, activeOnly bool) ([]*entity.Medication, error) {
+	var medications []*entity.Medication
+	query := r.db.WithContext(ctx).Where("baby_id = ?", babyID)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Order("active DESC, created_at DESC").Find(&medications).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medications", err)
+	}
+	return medications, nil
+}
+
+func (r *medicationRepositoryImpl) Update(ctx context.Context, medication *entity.Medication) error {
+	if err := r.db.WithContext(ctx).Save(medication).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update medication", err)
+	}
+	return nil
+}
+
+func (r *medicationRepositoryImpl) Delete(ctx context.Context, medicationID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", medicationID).
+		Delete(&entity.Medication{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete medication", err)
+	}
+	return nil
+}
+
+// medicationDoseRepositoryImpl 给药记录仓储实现
+type medicationDoseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMedicationDoseRepository 创建给药记录仓储
+func NewMedicationDoseRepository(db *gorm.DB) repository.MedicationDoseRepository {
+	return &medicationDoseRepositoryImpl{db: db}
+}
+
+func (r *medicationDoseRepositoryImpl) Create(ctx context.Context, dose *entity.MedicationDose) error {
+	if err := r.db.WithContext(ctx).Create(dose).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create medication dose", err)
+	}
+	return nil
+}
+
+func (r *medicationDoseRepositoryImpl) FindByID(ctx context.Context, doseID int64) (*entity.MedicationDose, error) {
+	var dose entity.MedicationDose
+	err := r.db.WithContext(ctx).
+		Where("id = ?", doseID).
+		First(&dose).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "medication dose not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medication dose", err)
+	}
+
+	return &dose, nil
+}
+
+func (r *medicationDoseRepositoryImpl) FindByBabyID(ctx context.Context, babyID, medicationID int64, startTime, endTime int64, page, pageSize int) ([]*entity.MedicationDose, int64, error) {
+	var doses []*entity.MedicationDose
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.MedicationDose{}).
+		Where("baby_id = ?", babyID)
+
+	if medicationID > 0 {
+		query = query.Where("medication_id = ?", medicationID)
+	}
+	if startTime > 0 {
+		query = query.Where("time >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count medication doses", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&doses).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find medication doses", err)
+	}
+
+	return doses, total, nil
+}
+
+func (r *medicationDoseRepositoryImpl) FindByMedicationID(ctx context.Context, medicationID int64, startTime, endTime int64) ([]*entity.MedicationDose, error) {
+	var doses []*entity.MedicationDose
+	err := r.db.WithContext(ctx).
+		Where("medication_id = ? AND time >= ? AND time <= ?", medicationID, startTime, endTime).
+		Order("time ASC").
+		Find(&doses).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medication doses", err)
+	}
+	return doses, nil
+}
+
+func (r *medicationDoseRepositoryImpl) FindLatestByMedicationID(ctx context.Context, medicationID int64) (*entity.MedicationDose, error) {
+	var dose entity.MedicationDose
+	err := r.db.WithContext(ctx).
+		Where("medication_id = ?", medicationID).
+		Order("time DESC").
+		First(&dose).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find latest medication dose", err)
+	}
+
+	return &dose, nil
+}
+
+func (r *medicationDoseRepositoryImpl) Delete(ctx context.Context, doseID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", doseID).
+		Delete(&entity.MedicationDose{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete medication dose", err)
+	}
+	return nil
+}
+
+// medicationScheduleRepositoryImpl 用药计划仓储实现
+type medicationScheduleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMedicationScheduleRepository 创建用药计划仓储
+func NewMedicationScheduleRepository(db *gorm.DB) repository.MedicationScheduleRepository {
+	return &medicationScheduleRepositoryImpl{db: db}
+}
+
+func (r *medicationScheduleRepositoryImpl) Create(ctx context.Context, schedule *entity.MedicationSchedule) error {
+	if err := r.db.WithContext(ctx).Create(schedule).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create medication schedule", err)
+	}
+	return nil
+}
+
+func (r *medicationScheduleRepositoryImpl) FindByID(ctx context.Context, scheduleID int64) (*entity.MedicationSchedule, error) {
+	var schedule entity.MedicationSchedule
+	err := r.db.WithContext(ctx).
+		Where("id = ?", scheduleID).
+		First(&schedule).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "medication schedule not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medication schedule", err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *medicationScheduleRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64) ([]*entity.MedicationSchedule, error) {
+	var schedules []*entity.MedicationSchedule
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ?", babyID).
+		Order("created_at ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find medication schedules", err)
+	}
+	return schedules, nil
+}
+
+func (r *medicationScheduleRepositoryImpl) FindEnabled(ctx context.Context) ([]*entity.MedicationSchedule, error) {
+	var schedules []*entity.MedicationSchedule
+	err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("baby_id ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find enabled medication schedules", err)
+	}
+	return schedules, nil
+}
+
+func (r *medicationScheduleRepositoryImpl) Update(ctx context.Context, schedule *entity.MedicationSchedule) error {
+	if err := r.db.WithContext(ctx).Save(schedule).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update medication schedule", err)
+	}
+	return nil
+}
+
+func (r *medicationScheduleRepositoryImpl) MarkReminded(ctx context.Context, scheduleID int64, dueAt int64) error {
+	err := r.db.WithContext(ctx).
+		Model(&entity.MedicationSchedule{}).
+		Where("id = ?", scheduleID).
+		Update("last_reminded_at", dueAt).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to mark medication schedule reminded", err)
+	}
+	return nil
+}
+
+func (r *medicationScheduleRepositoryImpl) Delete(ctx context.Context, scheduleID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", scheduleID).
+		Delete(&entity.MedicationSchedule{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete medication schedule", err)
+	}
+	return nil
+}
+
+func (r *medicationScheduleRepositoryImpl) DeleteByMedicationID(ctx context.Context, medicationID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("medication_id = ?", medicationID).
+		Delete(&entity.MedicationSchedule{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete medication schedules", err)
+	}
+	return nil
+}
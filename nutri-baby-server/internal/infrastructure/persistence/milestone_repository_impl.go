@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// milestoneRecordRepositoryImpl 里程碑达成记录仓储实现
+type milestoneRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMilestoneRecordRepository 创建里程碑达成记录仓储
+func NewMilestoneRecordRepository(db *gorm.DB) repository.MilestoneRecordRepository {
+	return &milestoneRecordRepositoryImpl{db: db}
+}
+
+func (r *milestoneRecordRepositoryImpl) Create(ctx context.Context, record *entity.MilestoneRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create milestone record", err)
+	}
+	return nil
+}
+
+func (r *milestoneRecordRepositoryImpl) FindByID(ctx context.Context, recordID int64) (*entity.MilestoneRecord, error) {
+	var record entity.MilestoneRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "milestone record not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find milestone record", err)
+	}
+
+	return &record, nil
+}
+
+func (r *milestoneRecordRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.MilestoneRecord, int64, error) {
+	var records []*entity.MilestoneRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.MilestoneRecord{}).
+		Where("baby_id = ?", babyID)
+
+	if startTime > 0 {
+		query = query.Where("achieved_at >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("achieved_at <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count milestone records", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("achieved_at DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find milestone records", err)
+	}
+
+	return records, total, nil
+}
+
+func (r *milestoneRecordRepositoryImpl) FindByBabyAndCode(ctx context.Context, babyID int64, code string) (*entity.MilestoneRecord, error) {
+	var record entity.MilestoneRecord
+	err := r.db.WithContext(ctx).
+		Where("baby_id = ? AND milestone_code = ?", babyID, code).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find milestone record", err)
+	}
+
+	return &record, nil
+}
+
+func (r *milestoneRecordRepositoryImpl) Update(ctx context.Context, record *entity.MilestoneRecord) error {
+	if err := r.db.WithContext(ctx).Save(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update milestone record", err)
+	}
+	return nil
+}
+
+func (r *milestoneRecordRepositoryImpl) Delete(ctx context.Context, recordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		Delete(&entity.MilestoneRecord{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete milestone record", err)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// pumpingRecordRepositoryImpl 吸奶记录仓储实现
+type pumpingRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPumpingRecordRepository 创建吸奶记录仓储
+func NewPumpingRecordRepository(db *gorm.DB) repository.PumpingRecordRepository {
+	return &pumpingRecordRepositoryImpl{db: db}
+}
+
+// Create 创建吸奶记录
+func (r *pumpingRecordRepositoryImpl) Create(ctx context.Context, record *entity.PumpingRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "创建吸奶记录失败", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找吸奶记录
+func (r *pumpingRecordRepositoryImpl) FindByID(ctx context.Context, recordID int64) (*entity.PumpingRecord, error) {
+	var record entity.PumpingRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "吸奶记录不存在")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询吸奶记录失败", err)
+	}
+
+	return &record, nil
+}
+
+// FindByBabyID 分页查询宝宝的吸奶记录(按时间倒序)
+func (r *pumpingRecordRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.PumpingRecord, int64, error) {
+	var records []*entity.PumpingRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.PumpingRecord{}).
+		Where("baby_id = ?", babyID)
+
+	if startTime > 0 {
+		query = query.Where("time >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "统计吸奶记录数量失败", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "查询吸奶记录列表失败", err)
+	}
+
+	return records, total, nil
+}
+
+// Delete 删除吸奶记录
+func (r *pumpingRecordRepositoryImpl) Delete(ctx context.Context, recordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		Delete(&entity.PumpingRecord{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "删除吸奶记录失败", err)
+	}
+	return nil
+}
+
+// milkStashRepositoryImpl 母乳库存仓储实现
+type milkStashRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMilkStashRepository 创建母乳库存仓储
+func NewMilkStashRepository(db *gorm.DB) repository.MilkStashRepository {
+	return &milkStashRepositoryImpl{db: db}
+}
+
+// Create 批量创建库存
+func (r *milkStashRepositoryImpl) Create(ctx context.Context, items []*entity.MilkStashItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&items).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "创建母乳库存失败", err)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找库存
+func (r *milkStashRepositoryImpl) FindByID(ctx context.Context, itemID int64) (*entity.MilkStashItem, error) {
+	var item entity.MilkStashItem
+	err := r.db.WithContext(ctx).
+		Where("id = ?", itemID).
+		First(&item).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "库存不存在")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询母乳库存失败", err)
+	}
+
+	return &item, nil
+}
+
+// Update 更新库存
+func (r *milkStashRepositoryImpl) Update(ctx context.Context, item *entity.MilkStashItem) error {
+	if err := r.db.WithContext(ctx).Save(item).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "更新母乳库存失败", err)
+	}
+	return nil
+}
+
+// FindByBabyID 查找宝宝指定状态的库存(按吸奶时间升序)
+func (r *milkStashRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, statuses []string) ([]*entity.MilkStashItem, error) {
+	var items []*entity.MilkStashItem
+	query := r.db.WithContext(ctx).Where("baby_id = ?", babyID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Order("pumped_at ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询母乳库存列表失败", err)
+	}
+	return items, nil
+}
+
+// DeleteUnusedByPumpingRecordID 删除吸奶记录产生且尚未被消耗的库存, 已部分消耗的库存保留
+func (r *milkStashRepositoryImpl) DeleteUnusedByPumpingRecordID(ctx context.Context, pumpingRecordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("pumping_record_id = ? AND remaining_volume = volume AND status = ?", pumpingRecordID, entity.MilkStashStatusAvailable).
+		Delete(&entity.MilkStashItem{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "删除未使用的母乳库存失败", err)
+	}
+	return nil
+}
+
+// ConsumeFIFO 在事务中锁定宝宝的可用库存, 按先进先出扣减并记录消耗明细(含扣减前的储存状态)
+// 库存不足时只扣减现有部分, 返回实际扣减的奶量
+func (r *milkStashRepositoryImpl) ConsumeFIFO(ctx context.Context, babyID, feedingRecordID, volume, consumedAt int64) (int64, error) {
+	var consumed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []*entity.MilkStashItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("baby_id = ? AND status = ? AND remaining_volume > 0 AND pumped_at <= ? AND expires_at > ?",
+				babyID, entity.MilkStashStatusAvailable, consumedAt, consumedAt).
+			Order("pumped_at ASC, id ASC").
+			Find(&items).Error
+		if err != nil {
+			return err
+		}
+
+		drawn, usages := entity.DrawMilkStash(items, volume, consumedAt)
+		for i, item := range drawn {
+			if err := tx.Save(item).Error; err != nil {
+				return err
+			}
+
+			usage := usages[i]
+			usage.FeedingRecordID = feedingRecordID
+			if err := tx.Create(usage).Error; err != nil {
+				return err
+			}
+			consumed += usage.Volume
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(errors.DatabaseError, "扣减母乳库存失败", err)
+	}
+	return consumed, nil
+}
+
+// RestoreByFeedingRecordID 撤销喂养记录的全部扣减并删除消耗明细, 返回恢复的奶量
+// 该袋没有其他扣减时同时恢复取奶前的储存位置和过期时间, 撤销取奶时的解冻
+func (r *milkStashRepositoryImpl) RestoreByFeedingRecordID(ctx context.Context, feedingRecordID int64) (int64, error) {
+	var restored int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var usages []*entity.MilkStashUsage
+		if err := tx.Where("feeding_record_id = ?", feedingRecordID).Find(&usages).Error; err != nil {
+			return err
+		}
+		if len(usages) == 0 {
+			return nil
+		}
+
+		for _, usage := range usages {
+			var item entity.MilkStashItem
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", usage.StashItemID).
+				First(&item).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// 库存已被删除, 无需归还
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			// 解冻只发生在第一次从冷冻库存取奶时, 之后还有其他扣减则保持解冻状态
+			var others int64
+			err = tx.Model(&entity.MilkStashUsage{}).
+				Where("stash_item_id = ? AND feeding_record_id <> ?", usage.StashItemID, feedingRecordID).
+				Count(&others).Error
+			if err != nil {
+				return err
+			}
+
+			usage.Revert(&item, others == 0)
+			if err := tx.Save(&item).Error; err != nil {
+				return err
+			}
+			restored += usage.Volume
+		}
+
+		return tx.Where("feeding_record_id = ?", feedingRecordID).Delete(&entity.MilkStashUsage{}).Error
+	})
+	if err != nil {
+		return 0, errors.Wrap(errors.DatabaseError, "撤销母乳库存扣减失败", err)
+	}
+	return restored, nil
+}
+
+// MarkExpired 将已过期的可用库存标记为过期, 返回更新数量
+func (r *milkStashRepositoryImpl) MarkExpired(ctx context.Context, now int64) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&entity.MilkStashItem{}).
+		Where("status = ? AND expires_at <= ?", entity.MilkStashStatusAvailable, now).
+		Update("status", entity.MilkStashStatusExpired)
+	if result.Error != nil {
+		return 0, errors.Wrap(errors.DatabaseError, "标记母乳库存过期失败", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// FindExpiringUnalerted 查找在指定时间前过期且尚未发送临期提醒的可用库存
+func (r *milkStashRepositoryImpl) FindExpiringUnalerted(ctx context.Context, before int64) ([]*entity.MilkStashItem, error) {
+	var items []*entity.MilkStashItem
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND remaining_volume > 0 AND expires_at <= ? AND expiry_alert_sent_at IS NULL",
+			entity.MilkStashStatusAvailable, before).
+		Order("baby_id ASC, expires_at ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "查询临期母乳库存失败", err)
+	}
+	return items, nil
+}
+
+// MarkExpiryAlerted 标记库存已发送临期提醒
+func (r *milkStashRepositoryImpl) MarkExpiryAlerted(ctx context.Context, itemIDs []int64, alertedAt int64) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entity.MilkStashItem{}).
+		Where("id IN ?", itemIDs).
+		Update("expiry_alert_sent_at", alertedAt).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "标记母乳库存临期提醒失败", err)
+	}
+	return nil
+}
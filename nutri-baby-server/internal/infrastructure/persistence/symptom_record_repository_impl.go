@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// symptomRecordRepositoryImpl 症状记录仓储实现
+type symptomRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSymptomRecordRepository 创建症状记录仓储
+func NewSymptomRecordRepository(db *gorm.DB) repository.SymptomRecordRepository {
+	return &symptomRecordRepositoryImpl{db: db}
+}
+
+func (r *symptomRecordRepositoryImpl) Create(ctx context.Context, record *entity.SymptomRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create symptom record", err)
+	}
+	return nil
+}
+
+func (r *symptomRecordRepositoryImpl) FindByID(ctx context.Context, recordID int64) (*entity.SymptomRecord, error) {
+	var record entity.SymptomRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "symptom record not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find symptom record", err)
+	}
+
+	return &record, nil
+}
+
+func (r *symptomRecordRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.SymptomRecord, int64, error) {
+	var records []*entity.SymptomRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.SymptomRecord{}).
+		Where("baby_id = ?", babyID)
+
+	if startTime > 0 {
+		query = query.Where("time >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count symptom records", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find symptom records", err)
+	}
+
+	return records, total, nil
+}
+
+func (r *symptomRecordRepositoryImpl) FindByEpisodeID(ctx context.Context, episodeID int64) ([]*entity.SymptomRecord, error) {
+	var records []*entity.SymptomRecord
+	err := r.db.WithContext(ctx).
+		Where("episode_id = ?", episodeID).
+		Order("time ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find symptom records by episode", err)
+	}
+	return records, nil
+}
+
+func (r *symptomRecordRepositoryImpl) Delete(ctx context.Context, recordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		Delete(&entity.SymptomRecord{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete symptom record", err)
+	}
+	return nil
+}
+
+func (r *symptomRecordRepositoryImpl) GetDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*entity.DailySymptomItem, error) {
+	var records []*entity.DailySymptomItem
+	query := r.db.WithContext(ctx).
+		Model(&entity.SymptomRecord{}).
+		Select(`
+			to_char(to_timestamp(time / 1000), 'YYYY-MM-DD') AS date,
+			symptom,
+			COUNT(*) AS total_count`).
+		Where("baby_id = ? AND time BETWEEN ? AND ?", babyID, startDate, endDate).
+		Group("date, symptom").
+		Order("date ASC")
+
+	if err := query.Scan(&records).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to get daily symptom stats", err)
+	}
+
+	return records, nil
+}
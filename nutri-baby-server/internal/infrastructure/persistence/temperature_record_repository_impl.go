@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
+	"github.com/wxlbd/nutri-baby-server/internal/domain/repository"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+)
+
+// temperatureRecordRepositoryImpl 体温记录仓储实现
+type temperatureRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTemperatureRecordRepository 创建体温记录仓储
+func NewTemperatureRecordRepository(db *gorm.DB) repository.TemperatureRecordRepository {
+	return &temperatureRecordRepositoryImpl{db: db}
+}
+
+func (r *temperatureRecordRepositoryImpl) Create(ctx context.Context, record *entity.TemperatureRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to create temperature record", err)
+	}
+	return nil
+}
+
+func (r *temperatureRecordRepositoryImpl) FindByID(ctx context.Context, recordID int64) (*entity.TemperatureRecord, error) {
+	var record entity.TemperatureRecord
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		First(&record).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(errors.NotFound, "temperature record not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find temperature record", err)
+	}
+
+	return &record, nil
+}
+
+func (r *temperatureRecordRepositoryImpl) FindByBabyID(ctx context.Context, babyID int64, startTime, endTime int64, page, pageSize int) ([]*entity.TemperatureRecord, int64, error) {
+	var records []*entity.TemperatureRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&entity.TemperatureRecord{}).
+		Where("baby_id = ?", babyID)
+
+	if startTime > 0 {
+		query = query.Where("time >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("time <= ?", endTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to count temperature records", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("time DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.DatabaseError, "failed to find temperature records", err)
+	}
+
+	return records, total, nil
+}
+
+func (r *temperatureRecordRepositoryImpl) FindByEpisodeID(ctx context.Context, episodeID int64) ([]*entity.TemperatureRecord, error) {
+	var records []*entity.TemperatureRecord
+	err := r.db.WithContext(ctx).
+		Where("episode_id = ?", episodeID).
+		Order("time ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to find temperature records by episode", err)
+	}
+	return records, nil
+}
+
+func (r *temperatureRecordRepositoryImpl) Delete(ctx context.Context, recordID int64) error {
+	err := r.db.WithContext(ctx).
+		Where("id = ?", recordID).
+		Delete(&entity.TemperatureRecord{}).Error
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to delete temperature record", err)
+	}
+	return nil
+}
+
+func (r *temperatureRecordRepositoryImpl) GetDailyStats(ctx context.Context, babyID int64, startDate, endDate int64) ([]*entity.DailyTemperatureItem, error) {
+	var records []*entity.DailyTemperatureItem
+	query := r.db.WithContext(ctx).
+		Model(&entity.TemperatureRecord{}).
+		Select(`
+			to_char(to_timestamp(time / 1000), 'YYYY-MM-DD') AS date,
+			MAX(temperature) AS max_temperature,
+			SUM(CASE WHEN fever THEN 1 ELSE 0 END) AS fever_count,
+			COUNT(*) AS total_count`).
+		Where("baby_id = ? AND time BETWEEN ? AND ?", babyID, startDate, endDate).
+		Group("date").
+		Order("date ASC")
+
+	if err := query.Scan(&records).Error; err != nil {
+		return nil, errors.Wrap(errors.DatabaseError, "failed to get daily temperature stats", err)
+	}
+
+	return records, nil
+}
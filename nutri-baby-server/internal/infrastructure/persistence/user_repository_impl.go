@@ -103,3 +103,17 @@ func (r *userRepositoryImpl) UpdateDefaultBabyID(ctx context.Context, openID str
 
 	return nil
 }
+
+// UpdateLocale 更新语言偏好
+func (r *userRepositoryImpl) UpdateLocale(ctx context.Context, openID string, locale string) error {
+	err := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("openid = ?", openID).
+		Update("locale", locale).Error
+
+	if err != nil {
+		return errors.Wrap(errors.DatabaseError, "failed to update locale", err)
+	}
+
+	return nil
+}
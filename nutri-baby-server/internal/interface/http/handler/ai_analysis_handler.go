@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -9,7 +12,6 @@ import (
 
 	"github.com/wxlbd/nutri-baby-server/internal/application/service"
 	"github.com/wxlbd/nutri-baby-server/internal/domain/entity"
-	"github.com/wxlbd/nutri-baby-server/pkg/errors"
 	"github.com/wxlbd/nutri-baby-server/pkg/response"
 )
 
@@ -50,13 +52,9 @@ func (h *AIAnalysisHandler) CreateAnalysis(c *gin.Context) {
 		return
 	}
 
-	// 验证权限
-	if err := h.checkPermission(c, req.BabyID); err != nil {
-		response.Error(c, err)
-		return
-	}
+	openID := c.GetString("openid")
 
-	result, err := h.aiAnalysisService.CreateAnalysis(c.Request.Context(), &req)
+	result, err := h.aiAnalysisService.CreateAnalysis(c.Request.Context(), openID, &req)
 	if err != nil {
 		h.logger.Error("使用工具调用创建AI分析任务失败",
 			zap.Error(err),
@@ -85,7 +83,11 @@ func (h *AIAnalysisHandler) CreateAnalysis(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /api/ai/enhanced/daily-tips [post]
 func (h *AIAnalysisHandler) GenerateDailyTips(c *gin.Context) {
-	babyID, err := strconv.ParseInt(c.Query("baby_id"), 10, 64)
+	babyIDStr := c.Param("babyId")
+	if babyIDStr == "" {
+		babyIDStr = c.Query("baby_id")
+	}
+	babyID, err := strconv.ParseInt(babyIDStr, 10, 64)
 	if err != nil {
 		response.ErrorWithMessage(c, 1001, "无效的宝宝ID")
 		return
@@ -103,13 +105,9 @@ func (h *AIAnalysisHandler) GenerateDailyTips(c *gin.Context) {
 		date = time.Now()
 	}
 
-	// 验证权限
-	if err := h.checkPermission(c, babyID); err != nil {
-		response.Error(c, errors.ErrPermissionDenied)
-		return
-	}
+	openID := c.GetString("openid")
 
-	result, err := h.aiAnalysisService.GenerateDailyTips(c.Request.Context(), strconv.FormatInt(babyID, 10), date)
+	result, err := h.aiAnalysisService.GenerateDailyTips(c.Request.Context(), openID, strconv.FormatInt(babyID, 10), date)
 	if err != nil {
 		h.logger.Error("使用工具调用生成每日建议失败",
 			zap.Error(err),
@@ -163,11 +161,7 @@ func (h *AIAnalysisHandler) TestToolCalling(c *gin.Context) {
 		return
 	}
 
-	// 验证权限
-	if err := h.checkPermission(c, babyID); err != nil {
-		response.Error(c, err)
-		return
-	}
+	openID := c.GetString("openid")
 
 	// 创建一个测试分析请求
 	req := &service.CreateAnalysisRequest{
@@ -177,7 +171,7 @@ func (h *AIAnalysisHandler) TestToolCalling(c *gin.Context) {
 		EndDate:      service.CustomTime{Time: time.Now()},
 	}
 
-	result, err := h.aiAnalysisService.CreateAnalysis(c.Request.Context(), req)
+	result, err := h.aiAnalysisService.CreateAnalysis(c.Request.Context(), openID, req)
 	if err != nil {
 		h.logger.Error("工具调用测试失败",
 			zap.Error(err),
@@ -196,7 +190,9 @@ func (h *AIAnalysisHandler) TestToolCalling(c *gin.Context) {
 // GetAnalysisResult 获取分析结果
 func (h *AIAnalysisHandler) GetAnalysisResult(c *gin.Context) {
 	analysisID := c.Param("id")
-	result, err := h.aiAnalysisService.GetAnalysisResult(c.Request.Context(), analysisID)
+	openID := c.GetString("openid")
+
+	result, err := h.aiAnalysisService.GetAnalysisResult(c.Request.Context(), openID, analysisID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -220,8 +216,9 @@ func (h *AIAnalysisHandler) GetAnalysisResult(c *gin.Context) {
 // @Router /api/ai/analysis/{id}/status [get]
 func (h *AIAnalysisHandler) GetAnalysisStatus(c *gin.Context) {
 	analysisID := c.Param("id")
+	openID := c.GetString("openid")
 
-	status, err := h.aiAnalysisService.GetAnalysisStatus(c.Request.Context(), analysisID)
+	status, err := h.aiAnalysisService.GetAnalysisStatus(c.Request.Context(), openID, analysisID)
 	if err != nil {
 		h.logger.Error("获取分析状态失败",
 			zap.String("analysis_id", analysisID),
@@ -234,23 +231,98 @@ func (h *AIAnalysisHandler) GetAnalysisStatus(c *gin.Context) {
 	response.Success(c, status)
 }
 
-// GetLatestAnalysis 获取最新分析
-func (h *AIAnalysisHandler) GetLatestAnalysis(c *gin.Context) {
-	babyID := c.Param("babyId")
-	analysisType := entity.AIAnalysisType(c.Query("type"))
+// CancelAnalysis 取消分析任务
+// @Summary 取消分析任务
+// @Description 取消待执行或执行中的分析任务，执行中的任务会被中止且不再写回结果
+// @Tags AI分析
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path string true "分析任务ID"
+// @Success 200 {object} response.Response{data=service.AnalysisStatusResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /v1/ai-analysis/{id}/cancel [post]
+func (h *AIAnalysisHandler) CancelAnalysis(c *gin.Context) {
+	analysisID := c.Param("id")
+	openID := c.GetString("openid")
 
-	id, err := strconv.ParseInt(babyID, 10, 64)
+	status, err := h.aiAnalysisService.CancelAnalysis(c.Request.Context(), openID, analysisID)
 	if err != nil {
-		response.ErrorWithMessage(c, 1001, "无效的宝宝ID")
+		h.logger.Error("取消分析任务失败",
+			zap.String("analysis_id", analysisID),
+			zap.Error(err),
+		)
+		response.Error(c, err)
 		return
 	}
 
-	if err := h.checkPermission(c, id); err != nil {
+	response.Success(c, status)
+}
+
+// analysisStreamMaxDuration 单个SSE连接的最长时长，超时后客户端可重连继续订阅
+const analysisStreamMaxDuration = 10 * time.Minute
+
+// StreamAnalysis 以SSE推送分析进度
+// @Summary 订阅分析进度(SSE)
+// @Description 推送 status/thinking/tool_call/delta 事件，结束时推送 result 或 error 事件后关闭连接；不支持流式输出的模型只推送状态和工具调用事件；同一轮次重复推送 thinking 表示流式输出中断，客户端应丢弃该轮已收到的 delta 文本
+// @Tags AI分析
+// @Produce text/event-stream
+// @Param Authorization header string true "Bearer Token"
+// @Param id path string true "分析任务ID"
+// @Success 200 {object} service.AnalysisStreamEvent
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /v1/ai-analysis/{id}/stream [get]
+func (h *AIAnalysisHandler) StreamAnalysis(c *gin.Context) {
+	analysisID := c.Param("id")
+	openID := c.GetString("openid")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), analysisStreamMaxDuration)
+	defer cancel()
+
+	events, err := h.aiAnalysisService.StreamAnalysis(ctx, openID, analysisID)
+	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	result, err := h.aiAnalysisService.GetLatestAnalysis(c.Request.Context(), babyID, analysisType)
+	// SSE连接会超过服务器统一的写超时，单独放宽本连接的写截止时间
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(analysisStreamMaxDuration + 10*time.Second)); err != nil {
+		h.logger.Debug("设置SSE写超时失败", zap.Error(err))
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // 禁用nginx缓冲
+
+	keepalive := time.NewTicker(15 * time.Second)
+	defer keepalive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Event, event)
+			return true
+		case <-keepalive.C:
+			c.SSEvent("ping", gin.H{"timestamp": time.Now().UnixMilli()})
+			return true
+		}
+	})
+}
+
+// GetLatestAnalysis 获取最新分析
+func (h *AIAnalysisHandler) GetLatestAnalysis(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+	analysisType := entity.AIAnalysisType(c.Query("type"))
+
+	result, err := h.aiAnalysisService.GetLatestAnalysis(c.Request.Context(), openID, babyID, analysisType)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -261,20 +333,43 @@ func (h *AIAnalysisHandler) GetLatestAnalysis(c *gin.Context) {
 // GetAnalysisStats 获取分析统计
 func (h *AIAnalysisHandler) GetAnalysisStats(c *gin.Context) {
 	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
 	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
 
-	id, err := strconv.ParseInt(babyID, 10, 64)
+	result, err := h.aiAnalysisService.GetAnalysisStats(c.Request.Context(), openID, babyID, days)
 	if err != nil {
-		response.ErrorWithMessage(c, 1001, "无效的宝宝ID")
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// ListAnalyses 分页查询分析历史
+func (h *AIAnalysisHandler) ListAnalyses(c *gin.Context) {
+	var query service.AnalysisHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
 		return
 	}
 
-	if err := h.checkPermission(c, id); err != nil {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.aiAnalysisService.ListAnalyses(c.Request.Context(), openID, babyID, &query)
+	if err != nil {
 		response.Error(c, err)
 		return
 	}
+	response.Success(c, result)
+}
+
+// CompareAnalyses 对比两次分析
+func (h *AIAnalysisHandler) CompareAnalyses(c *gin.Context) {
+	analysisID := c.Param("id")
+	otherID := c.Param("otherId")
+	openID := c.GetString("openid")
 
-	result, err := h.aiAnalysisService.GetAnalysisStats(c.Request.Context(), babyID, days)
+	result, err := h.aiAnalysisService.CompareAnalyses(c.Request.Context(), openID, analysisID, otherID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -290,12 +385,9 @@ func (h *AIAnalysisHandler) BatchAnalyze(c *gin.Context) {
 		return
 	}
 
-	if err := h.checkPermission(c, req.BabyID); err != nil {
-		response.Error(c, err)
-		return
-	}
+	openID := c.GetString("openid")
 
-	result, err := h.aiAnalysisService.BatchAnalyze(c.Request.Context(), &req)
+	result, err := h.aiAnalysisService.BatchAnalyze(c.Request.Context(), openID, &req)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -314,18 +406,9 @@ func (h *AIAnalysisHandler) GetDailyTips(c *gin.Context) {
 		return
 	}
 
-	id, err := strconv.ParseInt(babyID, 10, 64)
-	if err != nil {
-		response.ErrorWithMessage(c, 1001, "无效的宝宝ID")
-		return
-	}
+	openID := c.GetString("openid")
 
-	if err := h.checkPermission(c, id); err != nil {
-		response.Error(c, err)
-		return
-	}
-
-	result, err := h.aiAnalysisService.GetDailyTips(c.Request.Context(), babyID, date)
+	result, err := h.aiAnalysisService.GetDailyTips(c.Request.Context(), openID, babyID, date)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -333,40 +416,22 @@ func (h *AIAnalysisHandler) GetDailyTips(c *gin.Context) {
 	response.Success(c, result)
 }
 
-// checkPermission 检查权限
-func (h *AIAnalysisHandler) checkPermission(c *gin.Context, babyID int64) error {
-	// 从上下文中获取当前用户的openid (由auth中间件设置)
-	openid, exists := c.Get("openid")
-	if !exists {
-		return errors.ErrUnauthorized
-	}
-
-	openidStr, ok := openid.(string)
-	if !ok || openidStr == "" {
-		return errors.ErrUnauthorized
-	}
-
-	// 检查用户是否有权限访问该宝宝的数据
-	// 这里需要调用权限检查服务，暂时直接返回nil
-	// TODO: 实现完整的权限检查逻辑
-	_ = openidStr
-	_ = babyID
-	return nil
-}
-
 // RegisterAIAnalysisRoutes 注册AI分析路由
 func RegisterAIAnalysisRoutes(router *gin.RouterGroup, handler *AIAnalysisHandler) {
 	aiGroup := router.Group("/ai")
 	{
 		aiGroup.POST("/analysis", handler.CreateAnalysis)
 		aiGroup.GET("/analysis/:id/status", handler.GetAnalysisStatus)
+		aiGroup.POST("/analysis/:id/cancel", handler.CancelAnalysis)
+		aiGroup.GET("/analysis/:id/stream", handler.StreamAnalysis)
 		aiGroup.GET("/analysis/:id", handler.GetAnalysisResult)
 		aiGroup.GET("/latest/:babyId", handler.GetLatestAnalysis)
 		aiGroup.GET("/stats/:babyId", handler.GetAnalysisStats)
+		aiGroup.GET("/history/:babyId", handler.ListAnalyses)
+		aiGroup.GET("/analysis/:id/compare/:otherId", handler.CompareAnalyses)
 		aiGroup.POST("/batch-analyze", handler.BatchAnalyze)
 		aiGroup.POST("/daily-tips", handler.GenerateDailyTips)
 		aiGroup.GET("/daily-tips/:babyId", handler.GetDailyTips)
-		aiGroup.POST("/process-pending", handler.ProcessPendingAnalyses)
 		aiGroup.GET("/test-tools", handler.TestToolCalling)
 	}
 }
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// AIChatHandler AI育儿助手对话处理器
+type AIChatHandler struct {
+	aiChatService *service.AIChatService
+}
+
+// NewAIChatHandler 创建AI育儿助手对话处理器
+func NewAIChatHandler(aiChatService *service.AIChatService) *AIChatHandler {
+	return &AIChatHandler{
+		aiChatService: aiChatService,
+	}
+}
+
+// Ask 向助手提问
+// @Router /v1/babies/:babyId/ai-chat [post]
+func (h *AIChatHandler) Ask(c *gin.Context) {
+	var req dto.AIChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.aiChatService.Ask(c.Request.Context(), openID, babyID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetHistory 获取对话历史
+// @Router /v1/babies/:babyId/ai-chat/messages [get]
+func (h *AIChatHandler) GetHistory(c *gin.Context) {
+	var query dto.AIChatHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	messages, err := h.aiChatService.GetHistory(c.Request.Context(), openID, babyID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items": messages,
+		"total": len(messages),
+	})
+}
+
+// ClearHistory 清空对话历史
+// @Router /v1/babies/:babyId/ai-chat/messages [delete]
+func (h *AIChatHandler) ClearHistory(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	if err := h.aiChatService.ClearHistory(c.Request.Context(), openID, babyID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
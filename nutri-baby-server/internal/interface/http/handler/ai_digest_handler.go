@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// AIDigestHandler AI周期报告(周报/月报)处理器
+type AIDigestHandler struct {
+	digestService *service.AIDigestService
+}
+
+// NewAIDigestHandler 创建AI周期报告处理器
+func NewAIDigestHandler(digestService *service.AIDigestService) *AIDigestHandler {
+	return &AIDigestHandler{
+		digestService: digestService,
+	}
+}
+
+// ListDigests 分页查询宝宝的周期报告
+// @Router /v1/ai-analysis/baby/{babyId}/digests [get]
+func (h *AIDigestHandler) ListDigests(c *gin.Context) {
+	var query service.AIDigestListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.digestService.ListDigests(c.Request.Context(), openID, babyID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// GetDigest 获取周期报告详情(订阅消息跳转的详情页使用)
+// @Router /v1/ai-analysis/digests/{id} [get]
+func (h *AIDigestHandler) GetDigest(c *gin.Context) {
+	digestID := c.Param("id")
+	openID := c.GetString("openid")
+
+	result, err := h.digestService.GetDigest(c.Request.Context(), openID, digestID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
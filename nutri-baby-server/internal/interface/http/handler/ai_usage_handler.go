@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// AIUsageHandler AI调用用量管理处理器(仅限内部令牌访问)
+type AIUsageHandler struct {
+	usageService *service.AIUsageService
+}
+
+// NewAIUsageHandler 创建AI调用用量管理处理器
+func NewAIUsageHandler(usageService *service.AIUsageService) *AIUsageHandler {
+	return &AIUsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetReport 获取AI用量报表(按天/提供商/模型汇总，及用户和宝宝用量排行)
+// @Router /v1/admin/ai-usage [get]
+func (h *AIUsageHandler) GetReport(c *gin.Context) {
+	var req dto.AIUsageReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	result, err := h.usageService.GetReport(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// GetProviderHealth 获取AI模型提供商的健康与熔断状态
+// @Router /v1/admin/ai-providers [get]
+func (h *AIUsageHandler) GetProviderHealth(c *gin.Context) {
+	response.Success(c, h.usageService.GetProviderHealth())
+}
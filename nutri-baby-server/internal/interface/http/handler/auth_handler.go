@@ -90,6 +90,27 @@ func (h *AuthHandler) SetDefaultBaby(c *gin.Context) {
 	response.Success(c, nil)
 }
 
+// SetLocale 设置语言偏好(AI分析、每日建议和通知使用的语言)
+// @Router /auth/locale [put]
+func (h *AuthHandler) SetLocale(c *gin.Context) {
+	// 从context获取当前用户openid
+	openID := c.GetString("openid")
+
+	var req dto.SetLocaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	userInfo, err := h.authService.SetLocale(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, userInfo)
+}
+
 // UpdateUserInfo 更新用户信息
 // @Router /auth/user-info [put]
 func (h *AuthHandler) UpdateUserInfo(c *gin.Context) {
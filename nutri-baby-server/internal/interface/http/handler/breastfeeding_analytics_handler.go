@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// BreastfeedingAnalyticsHandler 母乳喂养分析处理器
+type BreastfeedingAnalyticsHandler struct {
+	breastfeedingAnalyticsService *service.BreastfeedingAnalyticsService
+}
+
+// NewBreastfeedingAnalyticsHandler 创建母乳喂养分析处理器
+func NewBreastfeedingAnalyticsHandler(breastfeedingAnalyticsService *service.BreastfeedingAnalyticsService) *BreastfeedingAnalyticsHandler {
+	return &BreastfeedingAnalyticsHandler{
+		breastfeedingAnalyticsService: breastfeedingAnalyticsService,
+	}
+}
+
+// GetBreastfeedingAnalytics 获取母乳喂养分析(左右平衡、下次喂养侧、密集喂养、24小时热力图)
+// @Router /v1/babies/:babyId/breastfeeding-analytics [get]
+func (h *BreastfeedingAnalyticsHandler) GetBreastfeedingAnalytics(c *gin.Context) {
+	var req dto.BreastfeedingAnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	// 从路径参数获取 babyId
+	req.BabyID = c.Param("babyId")
+
+	openID := c.GetString("openid")
+
+	result, err := h.breastfeedingAnalyticsService.GetBreastfeedingAnalytics(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// CryingRecordHandler 哭闹记录处理器
+type CryingRecordHandler struct {
+	cryingRecordService *service.CryingRecordService
+}
+
+// NewCryingRecordHandler 创建哭闹记录处理器
+func NewCryingRecordHandler(cryingRecordService *service.CryingRecordService) *CryingRecordHandler {
+	return &CryingRecordHandler{
+		cryingRecordService: cryingRecordService,
+	}
+}
+
+// CreateCryingRecord 创建哭闹记录
+// @Router /crying-records [post]
+func (h *CryingRecordHandler) CreateCryingRecord(c *gin.Context) {
+	var req dto.CreateCryingRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	record, err := h.cryingRecordService.CreateCryingRecord(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, record)
+}
+
+// GetCryingRecords 获取哭闹记录列表
+// @Router /crying-records [get]
+func (h *CryingRecordHandler) GetCryingRecords(c *gin.Context) {
+	var query dto.RecordListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	records, total, err := h.cryingRecordService.GetCryingRecords(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  records,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// DeleteCryingRecord 删除哭闹记录
+// @Router /crying-records/:id [delete]
+func (h *CryingRecordHandler) DeleteCryingRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.cryingRecordService.DeleteCryingRecord(c.Request.Context(), openID, recordID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
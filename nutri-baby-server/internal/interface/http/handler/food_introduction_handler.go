@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// FoodIntroductionHandler 辅食引入与过敏原处理器
+type FoodIntroductionHandler struct {
+	foodIntroductionService *service.FoodIntroductionService
+}
+
+// NewFoodIntroductionHandler 创建辅食引入处理器
+func NewFoodIntroductionHandler(foodIntroductionService *service.FoodIntroductionService) *FoodIntroductionHandler {
+	return &FoodIntroductionHandler{
+		foodIntroductionService: foodIntroductionService,
+	}
+}
+
+// GetFoodCatalog 获取内置食物目录(含过敏原标签)
+// @Router /v1/food-catalog [get]
+func (h *FoodIntroductionHandler) GetFoodCatalog(c *gin.Context) {
+	response.Success(c, gin.H{
+		"items": h.foodIntroductionService.GetFoodCatalog(),
+	})
+}
+
+// GetFoodIntroductions 获取宝宝的辅食引入历史
+// @Router /v1/babies/:babyId/food-introductions [get]
+func (h *FoodIntroductionHandler) GetFoodIntroductions(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	intros, err := h.foodIntroductionService.GetFoodIntroductions(c.Request.Context(), openID, babyID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items": intros,
+		"total": len(intros),
+	})
+}
+
+// GetNewFoodGuidance 获取新食物引入等待期建议
+// @Router /v1/babies/:babyId/food-introductions/guidance [get]
+func (h *FoodIntroductionHandler) GetNewFoodGuidance(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.foodIntroductionService.GetNewFoodGuidance(c.Request.Context(), openID, babyID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAllergenReport 获取重点过敏原引入报告
+// @Router /v1/babies/:babyId/allergen-report [get]
+func (h *FoodIntroductionHandler) GetAllergenReport(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.foodIntroductionService.GetAllergenReport(c.Request.Context(), openID, babyID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateFoodReaction 记录食物反应
+// @Router /v1/babies/:babyId/food-reactions [post]
+func (h *FoodIntroductionHandler) CreateFoodReaction(c *gin.Context) {
+	var req dto.CreateFoodReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.foodIntroductionService.CreateFoodReaction(c.Request.Context(), openID, babyID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DeleteFoodReaction 删除食物反应
+// @Router /v1/babies/:babyId/food-reactions/:reactionId [delete]
+func (h *FoodIntroductionHandler) DeleteFoodReaction(c *gin.Context) {
+	babyID := c.Param("babyId")
+	reactionID := c.Param("reactionId")
+	openID := c.GetString("openid")
+
+	if err := h.foodIntroductionService.DeleteFoodReaction(c.Request.Context(), openID, babyID, reactionID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
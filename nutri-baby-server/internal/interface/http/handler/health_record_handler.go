@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// HealthRecordHandler 体温与症状记录处理器
+type HealthRecordHandler struct {
+	healthRecordService *service.HealthRecordService
+}
+
+// NewHealthRecordHandler 创建体温与症状记录处理器
+func NewHealthRecordHandler(healthRecordService *service.HealthRecordService) *HealthRecordHandler {
+	return &HealthRecordHandler{
+		healthRecordService: healthRecordService,
+	}
+}
+
+// CreateTemperatureRecord 创建体温记录
+// @Router /temperature-records [post]
+func (h *HealthRecordHandler) CreateTemperatureRecord(c *gin.Context) {
+	var req dto.CreateTemperatureRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	record, err := h.healthRecordService.CreateTemperatureRecord(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, record)
+}
+
+// GetTemperatureRecords 获取体温记录列表
+// @Router /temperature-records [get]
+func (h *HealthRecordHandler) GetTemperatureRecords(c *gin.Context) {
+	var query dto.RecordListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	records, total, err := h.healthRecordService.GetTemperatureRecords(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  records,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// DeleteTemperatureRecord 删除体温记录
+// @Router /temperature-records/:id [delete]
+func (h *HealthRecordHandler) DeleteTemperatureRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.healthRecordService.DeleteTemperatureRecord(c.Request.Context(), openID, recordID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// CreateSymptomRecord 创建症状记录
+// @Router /symptom-records [post]
+func (h *HealthRecordHandler) CreateSymptomRecord(c *gin.Context) {
+	var req dto.CreateSymptomRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	record, err := h.healthRecordService.CreateSymptomRecord(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, record)
+}
+
+// GetSymptomRecords 获取症状记录列表
+// @Router /symptom-records [get]
+func (h *HealthRecordHandler) GetSymptomRecords(c *gin.Context) {
+	var query dto.RecordListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	records, total, err := h.healthRecordService.GetSymptomRecords(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  records,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// DeleteSymptomRecord 删除症状记录
+// @Router /symptom-records/:id [delete]
+func (h *HealthRecordHandler) DeleteSymptomRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.healthRecordService.DeleteSymptomRecord(c.Request.Context(), openID, recordID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
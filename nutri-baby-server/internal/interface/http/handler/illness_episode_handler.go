@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// IllnessEpisodeHandler 患病经过处理器
+type IllnessEpisodeHandler struct {
+	illnessEpisodeService *service.IllnessEpisodeService
+}
+
+// NewIllnessEpisodeHandler 创建患病经过处理器
+func NewIllnessEpisodeHandler(illnessEpisodeService *service.IllnessEpisodeService) *IllnessEpisodeHandler {
+	return &IllnessEpisodeHandler{
+		illnessEpisodeService: illnessEpisodeService,
+	}
+}
+
+// CreateIllnessEpisode 创建患病经过
+// @Router /illness-episodes [post]
+func (h *IllnessEpisodeHandler) CreateIllnessEpisode(c *gin.Context) {
+	var req dto.CreateIllnessEpisodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	episode, err := h.illnessEpisodeService.CreateIllnessEpisode(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, episode)
+}
+
+// GetIllnessEpisodes 获取患病经过列表
+// @Router /illness-episodes [get]
+func (h *IllnessEpisodeHandler) GetIllnessEpisodes(c *gin.Context) {
+	var query dto.RecordListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	episodes, total, err := h.illnessEpisodeService.GetIllnessEpisodes(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  episodes,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// GetIllnessEpisode 获取患病经过详情(含体温和症状记录)
+// @Router /illness-episodes/:id [get]
+func (h *IllnessEpisodeHandler) GetIllnessEpisode(c *gin.Context) {
+	episodeID := c.Param("id")
+	openID := c.GetString("openid")
+
+	episode, err := h.illnessEpisodeService.GetIllnessEpisode(c.Request.Context(), openID, episodeID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, episode)
+}
+
+// UpdateIllnessEpisode 更新患病经过(如标记痊愈)
+// @Router /illness-episodes/:id [put]
+func (h *IllnessEpisodeHandler) UpdateIllnessEpisode(c *gin.Context) {
+	var req dto.UpdateIllnessEpisodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	episodeID := c.Param("id")
+	openID := c.GetString("openid")
+
+	episode, err := h.illnessEpisodeService.UpdateIllnessEpisode(c.Request.Context(), openID, episodeID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, episode)
+}
+
+// DeleteIllnessEpisode 删除患病经过
+// @Router /illness-episodes/:id [delete]
+func (h *IllnessEpisodeHandler) DeleteIllnessEpisode(c *gin.Context) {
+	episodeID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.illnessEpisodeService.DeleteIllnessEpisode(c.Request.Context(), openID, episodeID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
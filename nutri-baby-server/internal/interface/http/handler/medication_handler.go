@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// MedicationHandler 用药与补充剂处理器
+type MedicationHandler struct {
+	medicationService *service.MedicationService
+}
+
+// NewMedicationHandler 创建用药处理器
+func NewMedicationHandler(medicationService *service.MedicationService) *MedicationHandler {
+	return &MedicationHandler{
+		medicationService: medicationService,
+	}
+}
+
+// CreateMedication 添加药品/补充剂
+// @Router /medications [post]
+func (h *MedicationHandler) CreateMedication(c *gin.Context) {
+	var req dto.CreateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	medication, err := h.medicationService.CreateMedication(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, medication)
+}
+
+// GetMedications 获取药品列表
+// @Router /medications [get]
+func (h *MedicationHandler) GetMedications(c *gin.Context) {
+	var query dto.MedicationListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	medications, err := h.medicationService.GetMedications(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, medications)
+}
+
+// UpdateMedication 更新药品(含停药)
+// @Router /medications/:id [put]
+func (h *MedicationHandler) UpdateMedication(c *gin.Context) {
+	var req dto.UpdateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	medicationID := c.Param("id")
+	openID := c.GetString("openid")
+
+	medication, err := h.medicationService.UpdateMedication(c.Request.Context(), openID, medicationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, medication)
+}
+
+// DeleteMedication 删除药品
+// @Router /medications/:id [delete]
+func (h *MedicationHandler) DeleteMedication(c *gin.Context) {
+	medicationID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.medicationService.DeleteMedication(c.Request.Context(), openID, medicationID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// CreateMedicationSchedule 添加用药计划
+// @Router /medications/:id/schedules [post]
+func (h *MedicationHandler) CreateMedicationSchedule(c *gin.Context) {
+	var req dto.CreateMedicationScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	medicationID := c.Param("id")
+	openID := c.GetString("openid")
+
+	schedule, err := h.medicationService.CreateMedicationSchedule(c.Request.Context(), openID, medicationID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, schedule)
+}
+
+// UpdateMedicationSchedule 更新用药计划(含开关提醒)
+// @Router /medication-schedules/:id [put]
+func (h *MedicationHandler) UpdateMedicationSchedule(c *gin.Context) {
+	var req dto.UpdateMedicationScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	scheduleID := c.Param("id")
+	openID := c.GetString("openid")
+
+	schedule, err := h.medicationService.UpdateMedicationSchedule(c.Request.Context(), openID, scheduleID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, schedule)
+}
+
+// DeleteMedicationSchedule 删除用药计划
+// @Router /medication-schedules/:id [delete]
+func (h *MedicationHandler) DeleteMedicationSchedule(c *gin.Context) {
+	scheduleID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.medicationService.DeleteMedicationSchedule(c.Request.Context(), openID, scheduleID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// CheckMedicationDose 给药前校验剂量(不保存)
+// @Router /medication-doses/check [post]
+func (h *MedicationHandler) CheckMedicationDose(c *gin.Context) {
+	var req dto.CreateMedicationDoseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	result, err := h.medicationService.CheckMedicationDose(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateMedicationDose 记录给药
+// @Router /medication-doses [post]
+func (h *MedicationHandler) CreateMedicationDose(c *gin.Context) {
+	var req dto.CreateMedicationDoseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	dose, err := h.medicationService.CreateMedicationDose(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dose)
+}
+
+// GetMedicationDoses 获取给药记录列表
+// @Router /medication-doses [get]
+func (h *MedicationHandler) GetMedicationDoses(c *gin.Context) {
+	var query dto.MedicationDoseListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	doses, total, err := h.medicationService.GetMedicationDoses(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  doses,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// DeleteMedicationDose 删除给药记录
+// @Router /medication-doses/:id [delete]
+func (h *MedicationHandler) DeleteMedicationDose(c *gin.Context) {
+	doseID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.medicationService.DeleteMedicationDose(c.Request.Context(), openID, doseID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// MilestoneHandler 发育里程碑处理器
+type MilestoneHandler struct {
+	milestoneService *service.MilestoneService
+}
+
+// NewMilestoneHandler 创建发育里程碑处理器
+func NewMilestoneHandler(milestoneService *service.MilestoneService) *MilestoneHandler {
+	return &MilestoneHandler{
+		milestoneService: milestoneService,
+	}
+}
+
+// GetMilestoneCatalog 获取内置里程碑目录(按领域和常见月龄)
+// @Router /v1/milestone-catalog [get]
+func (h *MilestoneHandler) GetMilestoneCatalog(c *gin.Context) {
+	openID := c.GetString("openid")
+
+	response.Success(c, gin.H{
+		"items": h.milestoneService.GetMilestoneCatalog(c.Request.Context(), openID),
+	})
+}
+
+// GetMilestoneChecklist 获取宝宝按月龄的里程碑清单
+// @Router /v1/babies/:babyId/milestones [get]
+func (h *MilestoneHandler) GetMilestoneChecklist(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.milestoneService.GetMilestoneChecklist(c.Request.Context(), openID, babyID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateMilestoneRecord 记录里程碑达成
+// @Router /v1/babies/:babyId/milestones [post]
+func (h *MilestoneHandler) CreateMilestoneRecord(c *gin.Context) {
+	var req dto.CreateMilestoneRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.milestoneService.CreateMilestoneRecord(c.Request.Context(), openID, babyID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// UpdateMilestoneRecord 更新里程碑记录
+// @Router /v1/babies/:babyId/milestones/:recordId [put]
+func (h *MilestoneHandler) UpdateMilestoneRecord(c *gin.Context) {
+	var req dto.UpdateMilestoneRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	recordID := c.Param("recordId")
+	openID := c.GetString("openid")
+
+	result, err := h.milestoneService.UpdateMilestoneRecord(c.Request.Context(), openID, babyID, recordID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DeleteMilestoneRecord 删除里程碑记录
+// @Router /v1/babies/:babyId/milestones/:recordId [delete]
+func (h *MilestoneHandler) DeleteMilestoneRecord(c *gin.Context) {
+	babyID := c.Param("babyId")
+	recordID := c.Param("recordId")
+	openID := c.GetString("openid")
+
+	if err := h.milestoneService.DeleteMilestoneRecord(c.Request.Context(), openID, babyID, recordID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// MilkStashHandler 吸奶记录与母乳库存处理器
+type MilkStashHandler struct {
+	milkStashService *service.MilkStashService
+}
+
+// NewMilkStashHandler 创建母乳库存处理器
+func NewMilkStashHandler(milkStashService *service.MilkStashService) *MilkStashHandler {
+	return &MilkStashHandler{
+		milkStashService: milkStashService,
+	}
+}
+
+// CreatePumpingRecord 创建吸奶记录(可同时入库)
+// @Router /pumping-records [post]
+func (h *MilkStashHandler) CreatePumpingRecord(c *gin.Context) {
+	var req dto.CreatePumpingRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	record, err := h.milkStashService.CreatePumpingRecord(c.Request.Context(), openID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, record)
+}
+
+// GetPumpingRecords 获取吸奶记录列表
+// @Router /pumping-records [get]
+func (h *MilkStashHandler) GetPumpingRecords(c *gin.Context) {
+	var query dto.RecordListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	openID := c.GetString("openid")
+
+	records, total, err := h.milkStashService.GetPumpingRecords(c.Request.Context(), openID, &query)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"records":  records,
+		"total":    total,
+		"page":     query.GetPageWithDefault(),
+		"pageSize": query.GetPageSizeWithDefault(),
+	})
+}
+
+// DeletePumpingRecord 删除吸奶记录
+// @Router /pumping-records/:id [delete]
+func (h *MilkStashHandler) DeletePumpingRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	openID := c.GetString("openid")
+
+	if err := h.milkStashService.DeletePumpingRecord(c.Request.Context(), openID, recordID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// GetMilkStash 获取母乳库存
+// @Router /v1/babies/:babyId/milk-stash [get]
+func (h *MilkStashHandler) GetMilkStash(c *gin.Context) {
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+	includeHistory := c.Query("includeHistory") == "true"
+
+	result, err := h.milkStashService.GetMilkStash(c.Request.Context(), openID, babyID, includeHistory)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AddMilkStashItem 手动入库
+// @Router /v1/babies/:babyId/milk-stash [post]
+func (h *MilkStashHandler) AddMilkStashItem(c *gin.Context) {
+	var req dto.CreateMilkStashItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.milkStashService.AddMilkStashItem(c.Request.Context(), openID, babyID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// MoveMilkStashItem 转移储存位置(冷冻/解冻)
+// @Router /v1/babies/:babyId/milk-stash/:itemId/location [put]
+func (h *MilkStashHandler) MoveMilkStashItem(c *gin.Context) {
+	var req dto.MoveMilkStashItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	itemID := c.Param("itemId")
+	openID := c.GetString("openid")
+
+	result, err := h.milkStashService.MoveMilkStashItem(c.Request.Context(), openID, babyID, itemID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DiscardMilkStashItem 丢弃库存
+// @Router /v1/babies/:babyId/milk-stash/:itemId/discard [post]
+func (h *MilkStashHandler) DiscardMilkStashItem(c *gin.Context) {
+	babyID := c.Param("babyId")
+	itemID := c.Param("itemId")
+	openID := c.GetString("openid")
+
+	if err := h.milkStashService.DiscardMilkStashItem(c.Request.Context(), openID, babyID, itemID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/application/dto"
+	"github.com/wxlbd/nutri-baby-server/internal/application/service"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// QuickLogHandler 自然语言快速记录处理器
+type QuickLogHandler struct {
+	quickLogService *service.QuickLogService
+}
+
+// NewQuickLogHandler 创建自然语言快速记录处理器
+func NewQuickLogHandler(quickLogService *service.QuickLogService) *QuickLogHandler {
+	return &QuickLogHandler{
+		quickLogService: quickLogService,
+	}
+}
+
+// Parse 解析自然语言为待确认的记录草稿(不写入记录)
+// @Router /v1/babies/:babyId/quick-log/parse [post]
+func (h *QuickLogHandler) Parse(c *gin.Context) {
+	var req dto.QuickLogParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithMessage(c, 1001, "参数错误: "+err.Error())
+		return
+	}
+
+	babyID := c.Param("babyId")
+	openID := c.GetString("openid")
+
+	result, err := h.quickLogService.Parse(c.Request.Context(), openID, babyID, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
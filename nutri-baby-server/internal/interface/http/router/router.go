@@ -19,10 +19,22 @@ func NewRouter(
 	vaccineScheduleHandler *handler.VaccineScheduleHandler, // 新增
 	statisticsHandler *handler.StatisticsHandler,
 	dailyStatsHandler *handler.DailyStatsHandler, // 新增按日统计处理器
+	breastfeedingAnalyticsHandler *handler.BreastfeedingAnalyticsHandler, // 母乳喂养分析处理器
+	foodIntroductionHandler *handler.FoodIntroductionHandler, // 辅食引入与过敏原处理器
+	milkStashHandler *handler.MilkStashHandler, // 吸奶记录与母乳库存处理器
+	cryingRecordHandler *handler.CryingRecordHandler, // 哭闹记录处理器
+	illnessEpisodeHandler *handler.IllnessEpisodeHandler, // 患病经过处理器
+	healthRecordHandler *handler.HealthRecordHandler, // 体温与症状记录处理器
+	medicationHandler *handler.MedicationHandler, // 用药与补充剂处理器
+	milestoneHandler *handler.MilestoneHandler, // 发育里程碑处理器
 	subscribeHandler *handler.SubscribeHandler,
 	syncHandler *handler.SyncHandler,
 	uploadHandler *handler.UploadHandler,
 	aiAnalysisHandler *handler.AIAnalysisHandler, // AI分析处理器
+	aiChatHandler *handler.AIChatHandler, // AI育儿助手对话处理器
+	quickLogHandler *handler.QuickLogHandler, // 自然语言快速记录处理器
+	aiUsageHandler *handler.AIUsageHandler, // AI调用用量管理处理器
+	aiDigestHandler *handler.AIDigestHandler, // AI周期报告处理器
 	aiAnalysisService service.AIAnalysisService, // 添加AI分析服务依赖
 	logger *zap.Logger, // 添加logger依赖
 ) *gin.Engine {
@@ -34,6 +46,7 @@ func NewRouter(
 	// 全局中间件
 	r.Use(middleware.CORS())
 	r.Use(middleware.Logger())
+	r.Use(middleware.Locale())
 	r.Use(gin.Recovery())
 
 	// 静态文件服务 (用于小程序码、头像等上传文件)
@@ -65,10 +78,17 @@ func NewRouter(
 			authRequired.GET("/auth/user-info", authHandler.GetUserInfo)
 			authRequired.PUT("/auth/user-info", authHandler.UpdateUserInfo)
 			authRequired.PUT("/auth/default-baby", authHandler.SetDefaultBaby)
+			authRequired.PUT("/auth/locale", authHandler.SetLocale)
 
 			// 文件上传
 			authRequired.POST("/upload", uploadHandler.Upload)
 
+			// 辅食目录(含过敏原标签)
+			authRequired.GET("/food-catalog", foodIntroductionHandler.GetFoodCatalog)
+
+			// 发育里程碑目录
+			authRequired.GET("/milestone-catalog", milestoneHandler.GetMilestoneCatalog)
+
 			// 宝宝管理 (去家庭化架构)
 			babies := authRequired.Group("/babies")
 			{
@@ -103,6 +123,35 @@ func NewRouter(
 				babies.GET("/:babyId/statistics", statisticsHandler.GetBabyStatistics)
 				// 按日统计接口 (新增)
 				babies.GET("/:babyId/daily-stats", dailyStatsHandler.GetDailyStats)
+				// 母乳喂养分析接口
+				babies.GET("/:babyId/breastfeeding-analytics", breastfeedingAnalyticsHandler.GetBreastfeedingAnalytics)
+
+				// 辅食引入与过敏原
+				babies.GET("/:babyId/food-introductions", foodIntroductionHandler.GetFoodIntroductions)
+				babies.GET("/:babyId/food-introductions/guidance", foodIntroductionHandler.GetNewFoodGuidance)
+				babies.POST("/:babyId/food-reactions", foodIntroductionHandler.CreateFoodReaction)
+				babies.DELETE("/:babyId/food-reactions/:reactionId", foodIntroductionHandler.DeleteFoodReaction)
+				babies.GET("/:babyId/allergen-report", foodIntroductionHandler.GetAllergenReport)
+
+				// 发育里程碑
+				babies.GET("/:babyId/milestones", milestoneHandler.GetMilestoneChecklist)
+				babies.POST("/:babyId/milestones", milestoneHandler.CreateMilestoneRecord)
+				babies.PUT("/:babyId/milestones/:recordId", milestoneHandler.UpdateMilestoneRecord)
+				babies.DELETE("/:babyId/milestones/:recordId", milestoneHandler.DeleteMilestoneRecord)
+
+				// 母乳库存
+				babies.GET("/:babyId/milk-stash", milkStashHandler.GetMilkStash)
+				babies.POST("/:babyId/milk-stash", milkStashHandler.AddMilkStashItem)
+				babies.PUT("/:babyId/milk-stash/:itemId/location", milkStashHandler.MoveMilkStashItem)
+				babies.POST("/:babyId/milk-stash/:itemId/discard", milkStashHandler.DiscardMilkStashItem)
+
+				// AI育儿助手对话(按宝宝+用户隔离的多轮记忆)
+				babies.POST("/:babyId/ai-chat", aiChatHandler.Ask)
+				babies.GET("/:babyId/ai-chat/messages", aiChatHandler.GetHistory)
+				babies.DELETE("/:babyId/ai-chat/messages", aiChatHandler.ClearHistory)
+
+				// 自然语言快速记录(仅解析预览，确认后调用各记录创建接口)
+				babies.POST("/:babyId/quick-log/parse", quickLogHandler.Parse)
 			}
 
 			// 喂养记录
@@ -115,6 +164,14 @@ func NewRouter(
 				feedingRecords.DELETE("/:id", recordHandler.DeleteFeedingRecord)
 			}
 
+			// 吸奶记录
+			pumpingRecords := authRequired.Group("/pumping-records")
+			{
+				pumpingRecords.POST("", milkStashHandler.CreatePumpingRecord)
+				pumpingRecords.GET("", milkStashHandler.GetPumpingRecords)
+				pumpingRecords.DELETE("/:id", milkStashHandler.DeletePumpingRecord)
+			}
+
 			// 睡眠记录
 			sleepRecords := authRequired.Group("/sleep-records")
 			{
@@ -145,6 +202,64 @@ func NewRouter(
 				growthRecords.DELETE("/:id", recordHandler.DeleteGrowthRecord)
 			}
 
+			// 哭闹记录
+			cryingRecords := authRequired.Group("/crying-records")
+			{
+				cryingRecords.POST("", cryingRecordHandler.CreateCryingRecord)
+				cryingRecords.GET("", cryingRecordHandler.GetCryingRecords)
+				cryingRecords.DELETE("/:id", cryingRecordHandler.DeleteCryingRecord)
+			}
+
+			// 患病经过
+			illnessEpisodes := authRequired.Group("/illness-episodes")
+			{
+				illnessEpisodes.POST("", illnessEpisodeHandler.CreateIllnessEpisode)
+				illnessEpisodes.GET("", illnessEpisodeHandler.GetIllnessEpisodes)
+				illnessEpisodes.GET("/:id", illnessEpisodeHandler.GetIllnessEpisode)
+				illnessEpisodes.PUT("/:id", illnessEpisodeHandler.UpdateIllnessEpisode)
+				illnessEpisodes.DELETE("/:id", illnessEpisodeHandler.DeleteIllnessEpisode)
+			}
+
+			// 体温记录(发热时按月龄筛查并提醒)
+			temperatureRecords := authRequired.Group("/temperature-records")
+			{
+				temperatureRecords.POST("", healthRecordHandler.CreateTemperatureRecord)
+				temperatureRecords.GET("", healthRecordHandler.GetTemperatureRecords)
+				temperatureRecords.DELETE("/:id", healthRecordHandler.DeleteTemperatureRecord)
+			}
+
+			// 症状记录
+			symptomRecords := authRequired.Group("/symptom-records")
+			{
+				symptomRecords.POST("", healthRecordHandler.CreateSymptomRecord)
+				symptomRecords.GET("", healthRecordHandler.GetSymptomRecords)
+				symptomRecords.DELETE("/:id", healthRecordHandler.DeleteSymptomRecord)
+			}
+
+			// 药品/补充剂与用药计划
+			medications := authRequired.Group("/medications")
+			{
+				medications.POST("", medicationHandler.CreateMedication)
+				medications.GET("", medicationHandler.GetMedications)
+				medications.PUT("/:id", medicationHandler.UpdateMedication)
+				medications.DELETE("/:id", medicationHandler.DeleteMedication)
+				medications.POST("/:id/schedules", medicationHandler.CreateMedicationSchedule)
+			}
+			medicationSchedules := authRequired.Group("/medication-schedules")
+			{
+				medicationSchedules.PUT("/:id", medicationHandler.UpdateMedicationSchedule)
+				medicationSchedules.DELETE("/:id", medicationHandler.DeleteMedicationSchedule)
+			}
+
+			// 给药记录(按最短间隔和体重校验剂量)
+			medicationDoses := authRequired.Group("/medication-doses")
+			{
+				medicationDoses.POST("", medicationHandler.CreateMedicationDose)
+				medicationDoses.POST("/check", medicationHandler.CheckMedicationDose)
+				medicationDoses.GET("", medicationHandler.GetMedicationDoses)
+				medicationDoses.DELETE("/:id", medicationHandler.DeleteMedicationDose)
+			}
+
 			// 时间线聚合接口
 			authRequired.GET("record/timeline", recordHandler.GetTimeline)
 
@@ -163,8 +278,14 @@ func NewRouter(
 				aiAnalysis.POST("", aiAnalysisHandler.CreateAnalysis)
 				aiAnalysis.GET("/:id", aiAnalysisHandler.GetAnalysisResult)
 				aiAnalysis.GET("/:id/status", aiAnalysisHandler.GetAnalysisStatus) // 新增：获取分析状态（用于轮询）
+				aiAnalysis.POST("/:id/cancel", aiAnalysisHandler.CancelAnalysis)
+				aiAnalysis.GET("/:id/stream", aiAnalysisHandler.StreamAnalysis) // SSE推送分析进度
+				aiAnalysis.GET("/:id/compare/:otherId", aiAnalysisHandler.CompareAnalyses)
 				aiAnalysis.GET("/baby/:babyId/latest", aiAnalysisHandler.GetLatestAnalysis)
 				aiAnalysis.GET("/baby/:babyId/history", aiAnalysisHandler.GetAnalysisStats)
+				aiAnalysis.GET("/baby/:babyId/analyses", aiAnalysisHandler.ListAnalyses)
+				aiAnalysis.GET("/baby/:babyId/digests", aiDigestHandler.ListDigests) // 周报/月报列表
+				aiAnalysis.GET("/digests/:id", aiDigestHandler.GetDigest)            // 周报/月报详情
 				aiAnalysis.POST("/batch", aiAnalysisHandler.BatchAnalyze)
 				aiAnalysis.GET("/daily-tips/:babyId", aiAnalysisHandler.GetDailyTips)
 				aiAnalysis.POST("/daily-tips/:babyId/generate", aiAnalysisHandler.GenerateDailyTips)
@@ -173,8 +294,9 @@ func NewRouter(
 			// WebSocket同步
 			authRequired.GET("/sync", syncHandler.HandleSync)
 
-			// 后台任务（需要认证）
-			backgroundJobs := authRequired.Group("/background")
+			// 后台任务（仅限携带内部令牌的服务间调用）
+			backgroundJobs := v1.Group("/background")
+			backgroundJobs.Use(middleware.InternalAuth(cfg))
 			{
 				backgroundJobs.POST("/process-pending-analyses", func(c *gin.Context) {
 					if err := aiAnalysisService.ProcessPendingAnalyses(c.Request.Context()); err != nil {
@@ -185,6 +307,14 @@ func NewRouter(
 					c.JSON(200, gin.H{"message": "处理完成"})
 				})
 			}
+
+			// 管理接口（仅限携带内部令牌的调用）
+			admin := v1.Group("/admin")
+			admin.Use(middleware.InternalAuth(cfg))
+			{
+				admin.GET("/ai-usage", aiUsageHandler.GetReport)             // AI调用用量报表
+				admin.GET("/ai-providers", aiUsageHandler.GetProviderHealth) // AI模型提供商健康与熔断状态
+			}
 		}
 	}
 
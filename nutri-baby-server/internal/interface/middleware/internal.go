@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/response"
+)
+
+// InternalTokenHeader 内部接口令牌请求头
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalAuth 内部接口认证中间件
+// 只允许携带正确内部令牌的服务间调用；未配置令牌时内部接口一律拒绝
+func InternalAuth(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := cfg.Server.InternalToken
+		token := c.GetHeader(InternalTokenHeader)
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			response.Error(c, errors.ErrPermissionDenied)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+)
+
+func TestInternalAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		configured string
+		token      string
+		wantStatus int
+	}{
+		{"令牌正确", "secret", "secret", http.StatusOK},
+		{"未携带令牌", "secret", "", http.StatusForbidden},
+		{"令牌错误", "secret", "wrong", http.StatusForbidden},
+		{"未配置令牌时一律拒绝", "", "", http.StatusForbidden},
+		{"未配置令牌时携带任意令牌也拒绝", "", "anything", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Server.InternalToken = tt.configured
+
+			router := gin.New()
+			router.POST("/internal", InternalAuth(cfg), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/internal", nil)
+			if tt.token != "" {
+				req.Header.Set(InternalTokenHeader, tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
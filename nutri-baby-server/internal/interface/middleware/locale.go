@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
+)
+
+// LocaleHeader 客户端声明界面语言的请求头，优先于 Accept-Language
+const LocaleHeader = "X-Locale"
+
+// Locale 语言中间件
+// 按 X-Locale、Accept-Language 的顺序确定请求语言，写入 gin.Context 和请求 context，
+// 用于错误信息翻译；AI分析等服务优先使用用户保存的语言偏好
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale, ok := i18n.Parse(c.GetHeader(LocaleHeader))
+		if !ok {
+			if locale, ok = i18n.FromAcceptLanguage(c.GetHeader("Accept-Language")); !ok {
+				locale = i18n.Default
+			}
+		}
+
+		c.Set(i18n.GinKey, locale)
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", string(locale))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,13 @@
+-- 010_add_locale.sql
+-- 多语言：用户语言偏好，AI分析和每日建议记录生成语言
+
+-- 用户语言偏好，为空时按请求头确定语言
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(16) DEFAULT '';
+
+-- AI分析和每日建议的生成语言，已有数据均为中文
+ALTER TABLE ai_analyses ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT 'zh-CN';
+ALTER TABLE daily_tips ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT 'zh-CN';
+
+-- 同一天每种语言各保存一份每日建议
+ALTER TABLE daily_tips DROP CONSTRAINT IF EXISTS daily_tips_unique_baby_date;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_daily_tips_baby_date_locale ON daily_tips(baby_id, date, locale);
@@ -40,6 +40,7 @@ const (
 	FamilyNotFound    ErrorCode = 3005
 	InvalidInvitation ErrorCode = 3006
 	RecordNotFound    ErrorCode = 3007
+	QuotaExceeded     ErrorCode = 3010
 )
 
 // AppError 应用错误
@@ -57,6 +58,11 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("[%d] %s", e.Code, e.Message)
 }
 
+// Unwrap 返回被包装的原始错误，支持标准库 errors.Is/errors.As
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
 // New 创建新错误
 func New(code ErrorCode, message string) *AppError {
 	return &AppError{
@@ -90,4 +96,5 @@ var (
 	ErrFamilyNotFound    = New(FamilyNotFound, "家庭不存在")
 	ErrInvalidInvitation = New(InvalidInvitation, "邀请码无效或已过期")
 	ErrRecordNotFound    = New(RecordNotFound, "记录不存在")
+	ErrQuotaExceeded     = New(QuotaExceeded, "今日AI使用额度已用完")
 )
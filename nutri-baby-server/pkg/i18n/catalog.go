@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// catalogs 按键查询的消息目录
+var catalogs = map[Locale]map[string]string{
+	ZhCN: zhCNMessages,
+	EnUS: enUSMessages,
+}
+
+// translations 以中文原文为键的翻译表(错误信息等历史上直接写中文的文本)
+var translations = map[Locale]map[string]string{
+	EnUS: enUSTranslations,
+}
+
+// T 查询消息，args 非空时按 fmt.Sprintf 格式化
+// 当前语言缺少该消息时回退到默认语言，仍缺少时返回键本身
+func (l Locale) T(key string, args ...any) string {
+	format, ok := catalogs[l][key]
+	if !ok {
+		if format, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Has 当前语言是否有该消息(不回退)
+func (l Locale) Has(key string) bool {
+	_, ok := catalogs[l][key]
+	return ok
+}
+
+// Translate 将中文原文翻译为当前语言，默认语言原样返回
+// 没有整句翻译时，"前缀: 详情" 形式只翻译前缀，详情(通常是校验器或下游返回的信息)保持原样
+func (l Locale) Translate(source string) (string, bool) {
+	if l.IsDefault() {
+		return source, true
+	}
+	table := translations[l]
+	if text, ok := table[source]; ok {
+		return text, true
+	}
+	for _, sep := range []string{": ", "："} {
+		if prefix, detail, found := strings.Cut(source, sep); found {
+			if text, ok := table[prefix]; ok {
+				return text + ": " + detail, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ErrorMessage 错误响应中的信息：优先翻译原文，没有翻译时使用错误码的通用描述
+func (l Locale) ErrorMessage(code int, message string) string {
+	if text, ok := l.Translate(message); ok {
+		return text
+	}
+	if key := fmt.Sprintf("error.%d", code); l.Has(key) {
+		return l.T(key)
+	}
+	return l.T("error.2001")
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Locale
+		ok   bool
+	}{
+		{"zh-CN", ZhCN, true},
+		{"zh_Hans", ZhCN, true},
+		{"en", EnUS, true},
+		{"en-GB", EnUS, true},
+		{" EN_us ", EnUS, true},
+		{"fr-FR", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.tag)
+		assert.Equal(t, tt.want, got, tt.tag)
+		assert.Equal(t, tt.ok, ok, tt.tag)
+	}
+	assert.Equal(t, Default, Normalize("fr"))
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	locale, ok := FromAcceptLanguage("fr-FR,fr;q=0.9,en-US;q=0.8,zh-CN;q=0.7")
+	assert.True(t, ok)
+	assert.Equal(t, EnUS, locale)
+
+	locale, ok = FromAcceptLanguage("zh-CN,zh;q=0.9,en;q=0.8")
+	assert.True(t, ok)
+	assert.Equal(t, ZhCN, locale)
+
+	_, ok = FromAcceptLanguage("fr-FR,de;q=0.5")
+	assert.False(t, ok)
+}
+
+func TestT_FallsBackToDefault(t *testing.T) {
+	assert.Equal(t, "About 3 hours", EnUS.T("reminder.time_since.hours", 3))
+	assert.Equal(t, "约3小时", ZhCN.T("reminder.time_since.hours", 3))
+	assert.Equal(t, "本周", Locale("ja-JP").T("digest.period.weekly"))
+	assert.Equal(t, "missing.key", EnUS.T("missing.key"))
+}
+
+func TestErrorMessage(t *testing.T) {
+	assert.Equal(t, "结束日期不能早于开始日期", ZhCN.ErrorMessage(1001, "结束日期不能早于开始日期"))
+	assert.Equal(t, "End date cannot be before start date", EnUS.ErrorMessage(1001, "结束日期不能早于开始日期"))
+	// 只翻译前缀，保留校验器给出的详情
+	assert.Equal(t, "Invalid parameters: Key: 'babyId' Error", EnUS.ErrorMessage(1001, "参数错误: Key: 'babyId' Error"))
+	// 没有翻译时按错误码给出通用描述
+	assert.Equal(t, "Database error", EnUS.ErrorMessage(2002, "查询喂养记录失败"))
+	assert.Equal(t, "Internal server error", EnUS.ErrorMessage(9999, "未知错误"))
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for key := range zhCNMessages {
+		assert.True(t, EnUS.Has(key), "en-US 缺少消息 %s", key)
+	}
+}
@@ -0,0 +1,113 @@
+// Package i18n 服务端生成文本的多语言支持
+//
+// 提供语言偏好解析、按键查询的消息目录，以及将现有中文错误信息翻译为其他语言的查询表。
+// 中文(zh-CN)为默认语言，其他语言缺少的消息回退到中文。
+package i18n
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Locale 语言标识(BCP 47)
+type Locale string
+
+// 支持的语言
+const (
+	ZhCN Locale = "zh-CN"
+	EnUS Locale = "en-US"
+
+	// Default 默认语言，未设置偏好且请求未声明语言时使用
+	Default = ZhCN
+)
+
+// GinKey 语言在 gin.Context 中的键
+const GinKey = "locale"
+
+// Supported 全部支持的语言
+func Supported() []Locale {
+	return []Locale{ZhCN, EnUS}
+}
+
+// Parse 解析语言标识，支持 en、en_US、en-GB、zh、zh-Hans 等写法，不支持的语言返回 false
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	tag = strings.ReplaceAll(tag, "_", "-")
+	if tag == "" {
+		return "", false
+	}
+	primary, _, _ := strings.Cut(tag, "-")
+	switch primary {
+	case "zh":
+		return ZhCN, true
+	case "en":
+		return EnUS, true
+	}
+	return "", false
+}
+
+// Normalize 解析语言标识，不支持或为空时返回默认语言
+func Normalize(tag string) Locale {
+	if locale, ok := Parse(tag); ok {
+		return locale
+	}
+	return Default
+}
+
+// FromAcceptLanguage 按 Accept-Language 的权重选择第一个支持的语言
+func FromAcceptLanguage(header string) (Locale, bool) {
+	best, bestQ := Locale(""), -1.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			} else {
+				q = 0
+			}
+		}
+		if q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best, best != "" && bestQ > 0
+}
+
+type localeKey struct{}
+
+// WithLocale 返回携带语言的 context
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext 读取 context 中的语言，未设置时返回默认语言
+func FromContext(ctx context.Context) Locale {
+	if ctx == nil {
+		return Default
+	}
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok && locale != "" {
+		return locale
+	}
+	return Default
+}
+
+// Name 语言名称(用于提示词)
+func (l Locale) Name() string {
+	switch l {
+	case EnUS:
+		return "English"
+	default:
+		return "简体中文"
+	}
+}
+
+// IsDefault 是否为默认语言
+func (l Locale) IsDefault() bool {
+	return l == "" || l == Default
+}
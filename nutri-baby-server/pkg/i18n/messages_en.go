@@ -0,0 +1,322 @@
+package i18n
+
+// enUSMessages 英文消息目录
+var enUSMessages = map[string]string{
+	// 错误码通用描述
+	"error.1001": "Invalid parameters",
+	"error.1002": "Unauthorized",
+	"error.1003": "Resource not found",
+	"error.1004": "Data conflict",
+	"error.1005": "Permission denied",
+	"error.2001": "Internal server error",
+	"error.2002": "Database error",
+	"error.2003": "Cache error",
+	"error.3001": "User not found",
+	"error.3002": "Invalid token",
+	"error.3003": "Token expired",
+	"error.3004": "Baby not found",
+	"error.3005": "Family not found",
+	"error.3006": "Invitation code is invalid or has expired",
+	"error.3007": "Record not found",
+	"error.3010": "Today's AI quota has been used up",
+
+	// 喂养提醒(微信订阅消息 thing 字段不超过20个字符)
+	"reminder.time_since.minutes": "About %d min",
+	"reminder.time_since.hour":    "About 1 hour",
+	"reminder.time_since.hours":   "About %d hours",
+	"reminder.breast.tip":         "Time to feed baby",
+	"reminder.bottle.tip":         "Get the bottle ready",
+	"reminder.food.tip":           "Time for solid food",
+
+	// 系统通知
+	"notify.milk_stash.title": "%s: milk expiring",
+	"notify.milk_stash.tip":   "%d bags/%dml expire in 24h",
+	"notify.digest.title":     "%s %s report",
+	"notify.digest.summary":   "Tap to view the report",
+	"notify.health_alert.tip": "Tap to view details",
+	"notify.medication.title": "%s: %s due",
+	"notify.medication.dose":  "Dose: %s %s",
+	"notify.medication.tip":   "Remember to log the dose",
+	"digest.period.weekly":    "weekly",
+	"digest.period.monthly":   "monthly",
+
+	// 健康提醒(规则生成时保存中文，英文按规则编码展示)
+	"health_alert.low_wet_diapers.title":     "Fewer wet diapers",
+	"health_alert.low_wet_diapers.message":   "Fewer wet diapers were logged in the past 24 hours than recommended for this age, which may indicate low intake or dehydration. Keep an eye on feeding, and contact a doctor if it persists or the baby seems lethargic, has dry lips or a sunken soft spot.",
+	"health_alert.stool_gap.title":           "No stool for a long time",
+	"health_alert.stool_gap.message":         "No stool has been logged for longer than usual for this age. Contact a doctor if there is bloating, vomiting, refusal to feed or unusual fussiness.",
+	"health_alert.pale_stool.title":          "Pale stool",
+	"health_alert.pale_stool.message":        "A pale or clay-colored stool was logged. This is a warning color on stool color cards and may indicate a liver or bile duct problem such as biliary atresia. Contact a doctor as soon as possible and keep a photo of the stool.",
+	"health_alert.black_stool.title":         "Black stool",
+	"health_alert.black_stool.message":       "A black stool was logged after the meconium period, which may indicate bleeding in the digestive tract; iron supplements can also darken stool. Please check with a doctor.",
+	"health_alert.red_stool.title":           "Red stool",
+	"health_alert.red_stool.message":         "A red stool was logged and may contain blood. Check whether it could be related to recently eaten food, and contact a doctor as soon as possible.",
+	"health_alert.infant_fever.title":        "Fever under 3 months",
+	"health_alert.infant_fever.message":      "A fever was recorded in a baby under 3 months old. Fever at this age can be a sign of serious infection. Seek medical care right away and do not give fever medicine on your own.",
+	"health_alert.infant_high_fever.title":   "High temperature",
+	"health_alert.infant_high_fever.message": "A temperature of 39°C or higher was recorded in a baby under 6 months old. Contact a doctor for an assessment, and seek care immediately if the baby is lethargic, refuses to feed, breathes fast or develops a rash.",
+	"health_alert.high_fever.title":          "High fever",
+	"health_alert.high_fever.message":        "A temperature of 40°C or higher was recorded. See a doctor as soon as possible, keep the baby hydrated and watch their alertness. Seek care immediately if there are seizures, trouble breathing or unusual sleepiness.",
+	"health_alert.prolonged_fever.title":     "Prolonged fever",
+	"health_alert.prolonged_fever.message":   "The fever has lasted longer than recommended for this age. Take the baby to a doctor to find the cause, and keep recording temperatures.",
+
+	// 用药剂量校验
+	"medication.warning.min_interval":    "Only %.1fh from an adjacent dose, less than the %gh minimum interval",
+	"medication.warning.max_daily_doses": "This is dose %d within 24h, more than the %d allowed per day",
+	"medication.warning.no_strength":     "Medication strength is missing, so the dose cannot be checked in mg",
+	"medication.warning.no_weight":       "No weight on record, so the dose cannot be checked by weight",
+	"medication.warning.max_dose":        "Single dose of %.0fmg exceeds the weight-based limit of %.0fmg (%gmg/kg × %.1fkg)",
+	"medication.warning.max_daily":       "24h total of %.0fmg exceeds the %.0fmg limit",
+
+	// 发育里程碑
+	"milestone.head_up_prone":        "Lifts head when on tummy",
+	"milestone.roll_over":            "Rolls over",
+	"milestone.sit_without_support":  "Sits without support",
+	"milestone.crawl":                "Crawls",
+	"milestone.pull_to_stand":        "Pulls to stand",
+	"milestone.walk_with_support":    "Walks holding on to furniture",
+	"milestone.pincer_grasp":         "Picks things up with thumb and finger",
+	"milestone.stand_alone":          "Stands alone",
+	"milestone.walk_alone":           "Walks alone",
+	"milestone.run":                  "Runs",
+	"milestone.coo":                  "Coos",
+	"milestone.laugh":                "Laughs out loud",
+	"milestone.babble":               "Babbles (ba-ba, ma-ma)",
+	"milestone.respond_name":         "Responds to own name",
+	"milestone.first_word":           "Says first word with meaning",
+	"milestone.three_words":          "Says 3 or more words",
+	"milestone.two_word_phrase":      "Uses two-word phrases",
+	"milestone.social_smile":         "Smiles at people",
+	"milestone.recognize_caregivers": "Recognizes familiar people",
+	"milestone.wave_bye":             "Waves bye-bye",
+	"milestone.point_to_show":        "Points to show things",
+	"milestone.pretend_play":         "Plays pretend (e.g. feeds a doll)",
+	"milestone.track_objects":        "Follows moving things with eyes",
+	"milestone.reach_for_toys":       "Reaches for toys",
+	"milestone.object_permanence":    "Looks for hidden things",
+	"milestone.imitate_actions":      "Copies adult actions",
+	"milestone.follow_one_step":      "Follows simple one-step directions",
+	"milestone.stack_blocks":         "Stacks 2-3 blocks",
+	"milestone.alert.title":          "\"%s\" not yet recorded",
+	"milestone.alert.message":        "\"%s\" usually appears by %d months and has not been recorded yet. Every baby develops at their own pace, so keep practicing together in daily play; if there is no progress or you have other concerns, ask the doctor at the next checkup.",
+
+	// 医疗安全护栏
+	"guardrail.disclaimer":                 "This content was generated by AI from your logged data. It is for reference only and does not replace diagnosis or treatment by a doctor.",
+	"guardrail.emergency_disclaimer":       "Something needs immediate attention, please contact a doctor as soon as possible. If the baby is unusually sleepy, has trouble breathing, a persistent high fever, refuses to feed or has far fewer wet diapers, seek medical care immediately or call emergency services.",
+	"guardrail.seek_care":                  "Please contact a doctor or go to the hospital as soon as possible.",
+	"guardrail.no_wet_diaper.title":        "No wet diaper for over 8 hours",
+	"guardrail.no_wet_diaper.description":  "The records show no wet diaper for about %d hours. Going a long time without urinating can be a sign of dehydration in infants.",
+	"guardrail.no_wet_diaper.suggestion":   "Contact a doctor or go to the hospital right away, and watch for lethargy, dry lips or a sunken soft spot.",
+	"guardrail.newborn_weight.title":       "Newborn weight loss over 10%",
+	"guardrail.newborn_weight.description": "The latest weight of %[1].2fkg is about %[3].0f%% below the birth weight of %[2].2fkg, more than the normal newborn weight loss.",
+	"guardrail.newborn_weight.suggestion":  "Please contact a doctor soon to check feeding and hydration.",
+	"guardrail.replace.dosing":             "Medicines (including fever reducers and antibiotics) and their doses should follow a doctor's advice; do not medicate on your own.",
+	"guardrail.replace.discourage_care":    "If you have concerns, or symptoms persist or get worse, please consult a doctor promptly.",
+	"guardrail.replace.unsafe_practice":    "Some practices are not suitable for infants; please follow your doctor's advice.",
+	"guardrail.replace.diagnosis":          "Only a doctor can make a diagnosis after examining the baby.",
+
+	// 快速记录预览
+	"quick_log.preview.empty":               "Nothing to log was recognized, please try rephrasing",
+	"quick_log.preview.list":                "%d record(s) will be added:\n%s",
+	"quick_log.summary.bottle":              "%s Feeding: bottle, %s %dml",
+	"quick_log.summary.breast":              "%s Feeding: breastfeeding, %s",
+	"quick_log.summary.breast_minutes":      " %d min",
+	"quick_log.summary.food":                "%s Feeding: solids, %s",
+	"quick_log.summary.sleep":               "%s Sleep: %s until %s, %s total",
+	"quick_log.summary.sleep_ongoing":       "%s Sleep: %s started (ongoing)",
+	"quick_log.summary.diaper":              "%s Diaper: %s",
+	"quick_log.summary.growth":              "%s Growth: %s",
+	"quick_log.summary.separator":           ", ",
+	"quick_log.summary.weight":              "weight %gkg",
+	"quick_log.summary.height":              "height %gcm",
+	"quick_log.summary.head":                "head %gcm",
+	"quick_log.duration.minutes":            "%d min",
+	"quick_log.duration.hours":              "%dh",
+	"quick_log.duration.hours_minutes":      "%dh %d min",
+	"quick_log.bottle.formula":              "formula",
+	"quick_log.bottle.breast-milk":          "breast milk",
+	"quick_log.side.left":                   "left side",
+	"quick_log.side.right":                  "right side",
+	"quick_log.side.both":                   "both sides",
+	"quick_log.side.unknown":                "side not specified",
+	"quick_log.diaper.pee":                  "wet",
+	"quick_log.diaper.poop":                 "dirty",
+	"quick_log.diaper.both":                 "wet and dirty",
+	"quick_log.sleep.nap":                   "nap",
+	"quick_log.sleep.night":                 "night sleep",
+	"quick_log.poo_color.yellow":            "yellow",
+	"quick_log.poo_color.green":             "green",
+	"quick_log.poo_color.brown":             "brown",
+	"quick_log.poo_color.black":             "black",
+	"quick_log.poo_color.red":               "red",
+	"quick_log.poo_color.white":             "white",
+	"quick_log.poo_texture.watery":          "watery",
+	"quick_log.poo_texture.loose":           "loose",
+	"quick_log.poo_texture.paste":           "mushy",
+	"quick_log.poo_texture.soft":            "soft",
+	"quick_log.poo_texture.formed":          "formed",
+	"quick_log.poo_texture.hard":            "hard",
+	"quick_log.warning.quota_exceeded":      "Today's smart parsing quota is used up, so basic parsing was used. Please check carefully",
+	"quick_log.warning.ai_unavailable":      "Smart parsing is unavailable, so basic parsing was used. Please check carefully",
+	"quick_log.warning.no_time":             "No time was recognized, the current time was used",
+	"quick_log.warning.future_time":         "The record time is later than now, please confirm",
+	"quick_log.warning.no_breast_duration":  "No breastfeeding duration was recognized",
+	"quick_log.warning.no_side":             "Left or right side was not specified",
+	"quick_log.warning.wake_before_sleep":   "The wake time is before the sleep time and was ignored",
+	"quick_log.warning.weight_range":        "Weight is outside the usual range, please check",
+	"quick_log.warning.height_range":        "Height is outside the usual range, please check",
+	"quick_log.warning.head_range":          "Head circumference is outside the usual range, please check",
+	"quick_log.warning.default_pee":         "Wet or dirty was not specified, logged as wet",
+	"quick_log.warning.default_formula":     "Milk type was not specified, logged as formula",
+	"quick_log.reason.no_amount":            "No amount was recognized for the bottle feeding",
+	"quick_log.reason.no_food_name":         "No food name was recognized for the solids",
+	"quick_log.reason.unknown_feeding_type": "Unrecognized feeding type: %s",
+	"quick_log.reason.unknown_diaper_type":  "Unrecognized diaper type: %s",
+	"quick_log.reason.no_measurement":       "No height, weight or head circumference was recognized",
+	"quick_log.reason.unknown_type":         "Unrecognized record type: %s",
+
+	// 辅食引入建议
+	"food.guidance.ready":          "You can try a new food. Introduce one at a time, start small and watch for %d days",
+	"food.guidance.too_young":      "The baby is under %d months old, solids are not recommended yet (usually from 6 months)",
+	"food.guidance.observing":      "Still watching \"%s\", please don't introduce other new foods until the observation period ends",
+	"food.guidance.reaction":       "\"%s\" caused an allergic reaction, please pause new foods and consult a doctor",
+	"food.guidance.severe_history": "; a severe allergic reaction has occurred, consult a doctor before introducing other allergens",
+
+	// AI助手
+	"chat.fallback_answer":       "Sorry, I can't answer this question right now, please try asking another way.",
+	"tool.get_baby_info":         "Checking baby info",
+	"tool.get_feeding_data":      "Checking feeding records",
+	"tool.get_sleep_data":        "Checking sleep records",
+	"tool.get_growth_data":       "Checking growth records",
+	"tool.get_diaper_data":       "Checking diaper records",
+	"tool.get_vaccine_data":      "Checking vaccine records",
+	"tool.get_diaper_patterns":   "Checking diaper patterns",
+	"tool.get_vaccine_reactions": "Checking vaccine reactions",
+	"tool.get_illness_episodes":  "Checking illness episodes",
+	"tool.get_temperature_data":  "Checking temperature and symptom records",
+	"tool.get_crying_data":       "Checking crying records",
+	"tool.get_milestone_data":    "Checking milestones",
+}
+
+// enUSTranslations 中文原文到英文的翻译，主要是面向用户的错误信息
+// 内部错误(数据库、缓存等)不逐条翻译，按错误码使用通用描述
+var enUSTranslations = map[string]string{
+	// 预定义错误
+	"参数错误":        "Invalid parameters",
+	"未授权":         "Unauthorized",
+	"资源不存在":       "Resource not found",
+	"数据冲突":        "Data conflict",
+	"权限不足":        "Permission denied",
+	"服务器内部错误":     "Internal server error",
+	"数据库错误":       "Database error",
+	"用户不存在":       "User not found",
+	"无效的令牌":       "Invalid token",
+	"令牌已过期":       "Token expired",
+	"宝宝不存在":       "Baby not found",
+	"家庭不存在":       "Family not found",
+	"邀请码无效或已过期":   "Invitation code is invalid or has expired",
+	"记录不存在":       "Record not found",
+	"今日AI使用额度已用完": "Today's AI quota has been used up",
+	"微信登录失败":      "WeChat login failed",
+
+	// 参数校验
+	"无效的宝宝ID":               "Invalid baby ID",
+	"无效的宝宝ID格式":             "Invalid baby ID format",
+	"无效的记录ID格式":             "Invalid record ID format",
+	"无效的喂养记录ID格式":           "Invalid feeding record ID format",
+	"无效的反应记录ID格式":           "Invalid reaction record ID format",
+	"无效的库存ID格式":             "Invalid stash item ID format",
+	"无效的患病经过ID格式":           "Invalid illness episode ID format",
+	"无效的药品ID格式":             "Invalid medication ID format",
+	"无效的给药时间格式":             "Invalid dosing time, expected HH:mm",
+	"无效的分析ID":               "Invalid analysis ID",
+	"无效的报告ID":               "Invalid report ID",
+	"无效的报告周期":               "Invalid report period",
+	"无效的接种状态":               "Invalid vaccination status",
+	"无效的状态值":                "Invalid status",
+	"无效的日期格式":               "Invalid date format",
+	"无效的语言":                 "Unsupported language",
+	"出生日期格式错误，应为YYYY-MM-DD": "Invalid birth date, expected YYYY-MM-DD",
+	"宝宝出生日期格式错误":            "Invalid birth date",
+	"解析出生日期失败":              "Invalid birth date",
+	"时区格式错误，应为IANA时区名称，如 Asia/Shanghai": "Invalid time zone, expected an IANA name such as Asia/Shanghai",
+	"开始日期格式错误，应为YYYY-MM-DD":             "Invalid start date, expected YYYY-MM-DD",
+	"结束日期格式错误，应为YYYY-MM-DD":             "Invalid end date, expected YYYY-MM-DD",
+	"开始日期不能晚于结束日期":                      "Start date cannot be after end date",
+	"结束日期不能早于开始日期":                      "End date cannot be before start date",
+	"结束时间不能早于开始时间":                      "End time cannot be before start time",
+	"统计时间范围不能超过365天":                    "Statistics range cannot exceed 365 days",
+	"记录内容不能为空":                          "Record content cannot be empty",
+	"问题不能为空":                            "Question cannot be empty",
+	"食物名称不能为空":                          "Food name cannot be empty",
+	"接种日期不能为空":                          "Vaccination date cannot be empty",
+	"接种医院不能为空":                          "Vaccination clinic cannot be empty",
+	"page参数不能为空":                        "page is required",
+	"scene参数不能为空":                       "scene is required",
+	"邀请参数不匹配":                           "Invitation parameters do not match",
+	"您已经是该宝宝的协作者":                       "You are already a caregiver of this baby",
+	"不能修改创建者的角色":                        "The creator's role cannot be changed",
+	"不能移除创建者":                           "The creator cannot be removed",
+	"喂养记录不是该宝宝的辅食记录":                    "The feeding record is not a solid food record of this baby",
+	"患病经过不属于该宝宝":                        "The illness episode does not belong to this baby",
+	"分装奶量不能超过本次吸奶总量":                    "Portions cannot exceed the pumped amount",
+	"吸奶量为0，无法入库":                        "Nothing to store: the pumped amount is 0",
+	"解冻后的母乳不能再次冷冻":                      "Thawed breast milk cannot be frozen again",
+	"该母乳已不在库存中":                         "This milk is no longer in the stash",
+	"药品已停用，无法添加用药计划":                    "This medication is stopped; schedules cannot be added",
+	"请填写给药间隔":                           "Dosing interval is required",
+	"请填写每天的给药时间":                        "Daily dosing times are required",
+	"请填写给药剂量":                           "Dose amount is required",
+	"未知的里程碑":                            "Unknown milestone",
+	"达成时间不能早于出生日期":                      "The date achieved cannot be before the birth date",
+	"达成时间不能晚于当前时间":                      "The date achieved cannot be in the future",
+	"该母乳已超过储存期限，不能入库":                   "This milk has exceeded its storage time and cannot be stored",
+	"不能与自身进行对比":                         "An analysis cannot be compared with itself",
+	"只能对比同一宝宝的分析":                       "Only analyses of the same baby can be compared",
+	"只能对比同一类型的分析":                       "Only analyses of the same type can be compared",
+	"只能对比已完成的分析":                        "Only completed analyses can be compared",
+
+	// 资源不存在
+	"AI分析记录不存在":    "AI analysis not found",
+	"未找到对应的AI分析记录": "AI analysis not found",
+	"获取分析记录失败":     "AI analysis not found",
+	"获取最新分析失败":     "No analysis found",
+	"未找到对应的每日建议":   "Daily tips not found",
+	"未找到有效的每日建议":   "No valid daily tips found",
+	"获取宝宝信息失败":     "Baby not found",
+	"协作者不存在":       "Caregiver not found",
+	"亲友团成员不存在":     "Family member not found",
+	"报告不存在":        "Report not found",
+	"邀请不存在或已失效":    "The invitation does not exist or has expired",
+	"疫苗接种日程不存在":    "Vaccination schedule not found",
+	"未找到疫苗计划模板":    "Vaccine plan template not found",
+	"没有找到任何疫苗计划模板": "No vaccine plan templates found",
+	"吸奶记录不存在":      "Pumping record not found",
+	"库存不存在":        "Stash item not found",
+
+	// 冲突
+	"该里程碑已记录":           "This milestone has already been recorded",
+	"分析任务已结束，无法取消":      "The analysis has already finished and cannot be cancelled",
+	"不能删除已完成的疫苗接种日程":    "A completed vaccination cannot be deleted",
+	"不能删除系统预设的疫苗接种日程":   "Built-in vaccination schedules cannot be deleted",
+	"只能编辑待接种状态的疫苗日程":    "Only pending vaccinations can be edited",
+	"该疫苗接种日程已完成,无法重复记录": "This vaccination has already been recorded",
+
+	// 权限
+	"您没有权限访问该宝宝":      "You do not have access to this baby",
+	"没有权限访问该宝宝信息":     "You do not have access to this baby",
+	"您没有权限访问该宝宝的记录":   "You do not have access to this baby's records",
+	"您没有权限编辑该宝宝信息":    "You do not have permission to edit this baby",
+	"您没有权限删除该记录":      "You do not have permission to delete this record",
+	"您没有权限操作该库存":      "You do not have permission to manage this stash",
+	"您没有权限邀请协作者":      "You do not have permission to invite caregivers",
+	"只有管理员可以修改角色":     "Only admins can change roles",
+	"只有管理员可以删除宝宝":     "Only admins can delete the baby",
+	"只有管理员可以更新其他成员信息": "Only admins can update other members",
+	"只有管理员可以更新协作者角色":  "Only admins can change caregiver roles",
+	"只有管理员可以移除协作者":    "Only admins can remove caregivers",
+	"只能查询当前宝宝的数据":     "Only data of the current baby can be queried",
+	"无权为该宝宝初始化疫苗日程":   "You do not have permission to set up vaccinations for this baby",
+	"无权操作该疫苗接种日程":     "You do not have permission to manage this vaccination",
+	"无权访问该宝宝的疫苗信息":    "You do not have access to this baby's vaccinations",
+}
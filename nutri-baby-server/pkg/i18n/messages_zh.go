@@ -0,0 +1,178 @@
+package i18n
+
+// zhCNMessages 中文消息目录
+// 健康提醒标题和内容以规则生成时保存的中文为准，这里不重复定义
+var zhCNMessages = map[string]string{
+	// 错误码通用描述
+	"error.1001": "参数错误",
+	"error.1002": "未授权",
+	"error.1003": "资源不存在",
+	"error.1004": "数据冲突",
+	"error.1005": "权限不足",
+	"error.2001": "服务器内部错误",
+	"error.2002": "数据库错误",
+	"error.2003": "缓存错误",
+	"error.3001": "用户不存在",
+	"error.3002": "无效的令牌",
+	"error.3003": "令牌已过期",
+	"error.3004": "宝宝不存在",
+	"error.3005": "家庭不存在",
+	"error.3006": "邀请码无效或已过期",
+	"error.3007": "记录不存在",
+	"error.3010": "今日AI使用额度已用完",
+
+	// 喂养提醒(微信订阅消息 thing 字段不超过20个字符)
+	"reminder.time_since.minutes": "约%d分钟",
+	"reminder.time_since.hour":    "约1小时",
+	"reminder.time_since.hours":   "约%d小时",
+	"reminder.breast.tip":         "该喂奶啦，注意观察宝宝的饥饿信号",
+	"reminder.bottle.tip":         "该喂奶啦，记得准备好奶瓶哦",
+	"reminder.food.tip":           "该给宝宝准备辅食啦，注意观察过敏反应",
+
+	// 系统通知
+	"notify.milk_stash.title": "%s 母乳库存临期",
+	"notify.milk_stash.tip":   "%d袋共%dml将在24小时内过期",
+	"notify.digest.title":     "%s %s成长报告",
+	"notify.medication.title": "%s该用%s啦",
+	"notify.medication.dose":  "本次剂量 %s%s",
+	"notify.medication.tip":   "用药后记得记录哦",
+	"digest.period.weekly":    "本周",
+	"digest.period.monthly":   "本月",
+
+	// 用药剂量校验
+	"medication.warning.min_interval":    "距相邻一次给药仅%.1f小时，少于最短间隔%g小时",
+	"medication.warning.max_daily_doses": "这是24小时内第%d次给药，超过每日最多%d次",
+	"medication.warning.no_strength":     "未填写药品规格，无法按毫克校验剂量",
+	"medication.warning.no_weight":       "没有体重记录，无法按体重校验剂量",
+	"medication.warning.max_dose":        "单次剂量%.0fmg超过按体重计算的上限%.0fmg(%gmg/kg×%.1fkg)",
+	"medication.warning.max_daily":       "24小时累计%.0fmg超过上限%.0fmg",
+
+	// 发育里程碑
+	"milestone.head_up_prone":        "趴着时能抬头",
+	"milestone.roll_over":            "翻身",
+	"milestone.sit_without_support":  "独坐",
+	"milestone.crawl":                "爬行",
+	"milestone.pull_to_stand":        "扶物站起",
+	"milestone.walk_with_support":    "扶走",
+	"milestone.pincer_grasp":         "拇指食指捏取",
+	"milestone.stand_alone":          "独站",
+	"milestone.walk_alone":           "独走",
+	"milestone.run":                  "跑",
+	"milestone.coo":                  "发出咕咕声",
+	"milestone.laugh":                "笑出声",
+	"milestone.babble":               "咿呀学语(ba-ba、ma-ma)",
+	"milestone.respond_name":         "叫名字有反应",
+	"milestone.first_word":           "有意识地叫爸爸妈妈",
+	"milestone.three_words":          "会说3个以上词",
+	"milestone.two_word_phrase":      "说两个词的短句",
+	"milestone.social_smile":         "逗引时会笑",
+	"milestone.recognize_caregivers": "认识亲近的人",
+	"milestone.wave_bye":             "挥手再见",
+	"milestone.point_to_show":        "用手指物给人看",
+	"milestone.pretend_play":         "假装游戏(喂娃娃等)",
+	"milestone.track_objects":        "眼睛追随移动的物体",
+	"milestone.reach_for_toys":       "伸手够玩具",
+	"milestone.object_permanence":    "寻找藏起来的东西",
+	"milestone.imitate_actions":      "模仿大人的动作",
+	"milestone.follow_one_step":      "听懂简单指令",
+	"milestone.stack_blocks":         "搭2-3块积木",
+	"milestone.alert.title":          "\"%s\"尚未记录",
+	"milestone.alert.message":        "\"%s\"通常在%d月龄前出现，目前还没有记录。每个宝宝的发育节奏不同，可以在日常中多陪宝宝练习；如果一直没有进展或同时有其他担心，下次体检时可以咨询医生。",
+
+	// 医疗安全护栏
+	"guardrail.disclaimer":                 "以上内容由AI根据记录数据生成，仅供参考，不能替代医生的诊断和治疗。",
+	"guardrail.emergency_disclaimer":       "发现需要立即关注的情况，请尽快联系医生；如宝宝出现精神差、呼吸困难、持续高热、拒奶或尿量明显减少，请立即就医或拨打120。",
+	"guardrail.seek_care":                  "请尽快联系医生或前往医院。",
+	"guardrail.no_wet_diaper.title":        "超过8小时没有小便",
+	"guardrail.no_wet_diaper.description":  "记录显示宝宝有约%d小时没有小便，婴儿长时间无尿可能是脱水的信号。",
+	"guardrail.no_wet_diaper.suggestion":   "请立即联系医生或前往医院，同时留意精神状态、口唇是否干燥、囟门是否凹陷。",
+	"guardrail.newborn_weight.title":       "新生儿体重下降超过10%",
+	"guardrail.newborn_weight.description": "最新体重%.2fkg，较出生体重%.2fkg下降约%.0f%%，超过新生儿生理性体重下降的范围。",
+	"guardrail.newborn_weight.suggestion":  "请尽快联系医生评估喂养和脱水情况。",
+	"guardrail.replace.dosing":             "用药(包括退烧药、抗生素等)及剂量请遵医嘱，不要自行给药。",
+	"guardrail.replace.discourage_care":    "如有疑虑或症状持续、加重，请及时咨询医生。",
+	"guardrail.replace.unsafe_practice":    "部分做法不适合婴幼儿，具体请以医生建议为准。",
+	"guardrail.replace.diagnosis":          "具体情况需要由医生面诊判断。",
+
+	// 快速记录预览
+	"quick_log.preview.empty":               "未能识别出可记录的内容，请换个说法试试",
+	"quick_log.preview.list":                "将添加 %d 条记录：\n%s",
+	"quick_log.summary.bottle":              "%s 喂养：奶瓶%s %dml",
+	"quick_log.summary.breast":              "%s 喂养：亲喂%s",
+	"quick_log.summary.breast_minutes":      " %d分钟",
+	"quick_log.summary.food":                "%s 喂养：辅食 %s",
+	"quick_log.summary.sleep":               "%s 睡眠：%s 至 %s，共%s",
+	"quick_log.summary.sleep_ongoing":       "%s 睡眠：%s 开始(未结束)",
+	"quick_log.summary.diaper":              "%s 换尿布：%s",
+	"quick_log.summary.growth":              "%s 生长：%s",
+	"quick_log.summary.separator":           "，",
+	"quick_log.summary.weight":              "体重 %gkg",
+	"quick_log.summary.height":              "身高 %gcm",
+	"quick_log.summary.head":                "头围 %gcm",
+	"quick_log.duration.minutes":            "%d分钟",
+	"quick_log.duration.hours":              "%d小时",
+	"quick_log.duration.hours_minutes":      "%d小时%d分钟",
+	"quick_log.bottle.formula":              "配方奶",
+	"quick_log.bottle.breast-milk":          "母乳",
+	"quick_log.side.left":                   "左侧",
+	"quick_log.side.right":                  "右侧",
+	"quick_log.side.both":                   "两侧",
+	"quick_log.side.unknown":                "未说明侧别",
+	"quick_log.diaper.pee":                  "小便",
+	"quick_log.diaper.poop":                 "大便",
+	"quick_log.diaper.both":                 "大小便",
+	"quick_log.sleep.nap":                   "小睡",
+	"quick_log.sleep.night":                 "夜间睡眠",
+	"quick_log.poo_color.yellow":            "黄色",
+	"quick_log.poo_color.green":             "绿色",
+	"quick_log.poo_color.brown":             "棕色",
+	"quick_log.poo_color.black":             "黑色",
+	"quick_log.poo_color.red":               "红色",
+	"quick_log.poo_color.white":             "白色",
+	"quick_log.poo_texture.watery":          "水样",
+	"quick_log.poo_texture.loose":           "稀",
+	"quick_log.poo_texture.paste":           "糊状",
+	"quick_log.poo_texture.soft":            "软",
+	"quick_log.poo_texture.formed":          "成形",
+	"quick_log.poo_texture.hard":            "硬",
+	"quick_log.warning.quota_exceeded":      "今日智能解析额度已用完，已使用规则解析，请仔细核对",
+	"quick_log.warning.ai_unavailable":      "智能解析暂不可用，已使用规则解析，请仔细核对",
+	"quick_log.warning.no_time":             "未识别到时间，已使用当前时间",
+	"quick_log.warning.future_time":         "记录时间晚于当前时间，请确认",
+	"quick_log.warning.no_breast_duration":  "未识别到亲喂时长",
+	"quick_log.warning.no_side":             "未说明左侧还是右侧",
+	"quick_log.warning.wake_before_sleep":   "醒来时间早于入睡时间，已忽略",
+	"quick_log.warning.weight_range":        "体重超出常见范围，请核对",
+	"quick_log.warning.height_range":        "身高超出常见范围，请核对",
+	"quick_log.warning.head_range":          "头围超出常见范围，请核对",
+	"quick_log.warning.default_pee":         "未说明大小便，默认按小便记录",
+	"quick_log.warning.default_formula":     "未说明奶的种类，默认按配方奶记录",
+	"quick_log.reason.no_amount":            "奶瓶喂养未识别到奶量",
+	"quick_log.reason.no_food_name":         "辅食未识别到食物名称",
+	"quick_log.reason.unknown_feeding_type": "无法识别的喂养方式: %s",
+	"quick_log.reason.unknown_diaper_type":  "无法识别的尿布类型: %s",
+	"quick_log.reason.no_measurement":       "生长记录未识别到身高、体重或头围",
+	"quick_log.reason.unknown_type":         "无法识别的记录类型: %s",
+
+	// 辅食引入建议
+	"food.guidance.ready":          "可以尝试新的食物, 每次只引入一种, 从少量开始并观察%d天",
+	"food.guidance.too_young":      "宝宝未满%d月龄, 暂不建议添加辅食(通常建议满6月龄开始)",
+	"food.guidance.observing":      "正在观察「%s」, 观察期结束前请不要引入其他新食物",
+	"food.guidance.reaction":       "「%s」出现过敏反应, 请暂停引入新食物并咨询医生",
+	"food.guidance.severe_history": "; 曾出现严重过敏反应, 引入其他过敏原前请先咨询医生",
+
+	// AI助手
+	"chat.fallback_answer":       "抱歉，我暂时无法回答这个问题，请换个问法试试。",
+	"tool.get_baby_info":         "查询宝宝基本信息",
+	"tool.get_feeding_data":      "查询喂养记录",
+	"tool.get_sleep_data":        "查询睡眠记录",
+	"tool.get_growth_data":       "查询成长记录",
+	"tool.get_diaper_data":       "查询尿布记录",
+	"tool.get_vaccine_data":      "查询疫苗记录",
+	"tool.get_diaper_patterns":   "查询排泄规律",
+	"tool.get_vaccine_reactions": "查询疫苗接种反应",
+	"tool.get_illness_episodes":  "查询患病经过",
+	"tool.get_temperature_data":  "查询体温和症状记录",
+	"tool.get_crying_data":       "查询哭闹记录",
+	"tool.get_milestone_data":    "查询发育里程碑",
+}
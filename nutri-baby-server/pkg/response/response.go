@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/wxlbd/nutri-baby-server/pkg/errors"
+	"github.com/wxlbd/nutri-baby-server/pkg/i18n"
 )
 
 // Response 统一响应结构
@@ -33,7 +34,7 @@ func Error(c *gin.Context, err error) {
 		// 未知错误,返回内部错误
 		c.JSON(http.StatusInternalServerError, Response{
 			Code:      int(errors.InternalError),
-			Message:   "服务器内部错误",
+			Message:   localize(c, errors.InternalError, "服务器内部错误"),
 			Timestamp: time.Now().Unix(),
 		})
 		return
@@ -44,7 +45,7 @@ func Error(c *gin.Context, err error) {
 
 	c.JSON(httpStatus, Response{
 		Code:      int(appErr.Code),
-		Message:   appErr.Message,
+		Message:   localize(c, appErr.Code, appErr.Message),
 		Timestamp: time.Now().Unix(),
 	})
 }
@@ -55,11 +56,21 @@ func ErrorWithMessage(c *gin.Context, code errors.ErrorCode, message string) {
 
 	c.JSON(httpStatus, Response{
 		Code:      int(code),
-		Message:   message,
+		Message:   localize(c, code, message),
 		Timestamp: time.Now().Unix(),
 	})
 }
 
+// localize 按请求语言翻译错误信息，中文请求原样返回
+func localize(c *gin.Context, code errors.ErrorCode, message string) string {
+	locale, _ := c.Get(i18n.GinKey)
+	l, ok := locale.(i18n.Locale)
+	if !ok {
+		return message
+	}
+	return l.ErrorMessage(int(code), message)
+}
+
 // getHTTPStatus 根据错误码获取HTTP状态码
 func getHTTPStatus(code errors.ErrorCode) int {
 	switch code {
@@ -76,6 +87,8 @@ func getHTTPStatus(code errors.ErrorCode) int {
 		return http.StatusConflict
 	case errors.PermissionDenied:
 		return http.StatusForbidden
+	case errors.QuotaExceeded:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
@@ -12,6 +12,7 @@ type App struct {
 	Config            *config.Config
 	Router            *gin.Engine
 	Scheduler         *service.SchedulerService
+	AIJobRunner       *service.AIJobRunner
 	AIAnalysisService service.AIAnalysisService
 	AIAnalysisHandler *handler.AIAnalysisHandler
 }
@@ -21,6 +22,7 @@ func NewApp(
 	cfg *config.Config,
 	router *gin.Engine,
 	scheduler *service.SchedulerService,
+	aiJobRunner *service.AIJobRunner,
 	aiAnalysisService service.AIAnalysisService,
 	aiAnalysisHandler *handler.AIAnalysisHandler,
 ) *App {
@@ -28,6 +30,7 @@ func NewApp(
 		Config:            cfg,
 		Router:            router,
 		Scheduler:         scheduler,
+		AIJobRunner:       aiJobRunner,
 		AIAnalysisService: aiAnalysisService,
 		AIAnalysisHandler: aiAnalysisHandler,
 	}
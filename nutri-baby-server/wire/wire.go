@@ -7,8 +7,11 @@ import (
 	"github.com/google/wire"
 	"github.com/wxlbd/nutri-baby-server/internal/application/service"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/guardrail"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/model"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/logger"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/persistence"
@@ -27,9 +30,13 @@ func InitApp(cfg *config.Config) (*App, error) {
 		wechat.NewClient,     // 微信 SDK 客户端
 
 		// Eino AI框架（工具调用架构）
+		offline.NewAnalyzer,           // 离线规则分析引擎(offline 提供商)
+		model.NewProviderChain,        // AI模型提供商链(回退与熔断)
 		model.NewToolCallingChatModel, // 支持工具调用的AI模型客户端
+		cache.NewAnalysisDataCache,    // AI分析数据缓存(Redis)
 		tools.NewDataQueryTools,       // 数据查询工具集
 		tools.NewBatchDataTools,       // 批量数据查询工具
+		guardrail.NewGuard,            // AI输出医疗安全护栏
 		chain.NewAnalysisChainBuilder, // AI分析链构建器
 
 		// 仓储层
@@ -48,35 +55,80 @@ func InitApp(cfg *config.Config) (*App, error) {
 		persistence.NewAIAnalysisRepository,          // AI分析结果仓储
 		persistence.NewDailyTipsRepository,           // 每日建议仓储
 		persistence.NewAppVersionRepository,          // 应用版本仓储
+		persistence.NewHealthAlertRepository,         // 健康提醒仓储
+		persistence.NewFoodReactionRepository,        // 食物反应仓储
+		persistence.NewPumpingRecordRepository,       // 吸奶记录仓储
+		persistence.NewMilkStashRepository,           // 母乳库存仓储
+		persistence.NewAIChatMessageRepository,       // AI助手对话消息仓储
+		persistence.NewAIUsageRepository,             // AI调用用量仓储
+		persistence.NewAIDigestRepository,            // AI周期报告仓储
+		persistence.NewCryingRecordRepository,        // 哭闹记录仓储
+		persistence.NewIllnessEpisodeRepository,      // 患病经过仓储
+		persistence.NewTemperatureRecordRepository,   // 体温记录仓储
+		persistence.NewSymptomRecordRepository,       // 症状记录仓储
+		persistence.NewMedicationRepository,          // 药品仓储
+		persistence.NewMedicationDoseRepository,      // 给药记录仓储
+		persistence.NewMedicationScheduleRepository,  // 用药计划仓储
+		persistence.NewMilestoneRecordRepository,     // 里程碑达成记录仓储
 
 		// 应用服务层
 		service.NewWechatService,    // 微信服务
 		service.NewSubscribeService, // 订阅消息服务
+		service.NewBabyNotifier,     // 宝宝管理员/协作者订阅消息通知
 		service.NewAuthService,
 		service.NewBabyService,
-		service.NewFeedingRecordService,   // 喂养记录服务
-		service.NewSleepRecordService,     // 睡眠记录服务
-		service.NewDiaperRecordService,    // 尿布记录服务
-		service.NewGrowthRecordService,    // 成长记录服务
-		service.NewTimelineService,        // 时间线聚合服务
-		service.NewVaccineScheduleService, // 新增：疫苗接种日程服务
-		service.NewStatisticsService,      // 新增：统计服务
-		service.NewDailyStatsService,      // 新增：按日统计服务
-		service.NewSchedulerService,       // 定时任务服务
-		service.NewUploadService,          // 文件上传服务
-		service.NewAIAnalysisService,      // AI分析服务（工具调用架构）
-		service.NewAppVersionService,      // 应用版本服务
+		service.NewFeedingRecordService,    // 喂养记录服务
+		service.NewSleepRecordService,      // 睡眠记录服务
+		service.NewDiaperRecordService,     // 尿布记录服务
+		service.NewDiaperScreeningService,  // 排泄健康筛查服务
+		service.NewGrowthRecordService,     // 成长记录服务
+		service.NewTimelineService,         // 时间线聚合服务
+		service.NewVaccineScheduleService,  // 新增：疫苗接种日程服务
+		service.NewStatisticsService,       // 新增：统计服务
+		service.NewDailyStatsService,       // 新增：按日统计服务
+		service.NewFoodIntroductionService, // 辅食引入与过敏原服务
+		service.NewMilkStashService,        // 吸奶记录与母乳库存服务
+		service.NewCryingRecordService,     // 哭闹记录服务
+		service.NewIllnessEpisodeService,   // 患病经过服务
+		service.NewHealthRecordService,     // 体温与症状记录服务
+		service.NewFeverScreeningService,   // 发热健康筛查服务
+		service.NewMedicationService,       // 用药与给药提醒服务
+		service.NewMilestoneService,        // 发育里程碑服务
+		service.NewSchedulerService,        // 定时任务服务
+		service.NewUploadService,           // 文件上传服务
+		service.NewAIAnalysisService,       // AI分析服务（工具调用架构）
+		service.NewAIJobRunner,             // AI分析任务执行器(租约 + 重试)
+		service.NewAIFingerprinter,         // AI分析输入指纹(复用相同输入的结果)
+		service.NewAnalysisProgressHub,     // AI分析进度广播(SSE)
+		service.NewAIChatService,           // AI育儿助手对话服务
+		service.NewAIUsageService,          // AI调用用量与配额服务
+		service.NewAIDigestService,         // AI周期报告(周报/月报)服务
+		service.NewQuickLogService,         // 自然语言快速记录服务
+		service.NewAppVersionService,       // 应用版本服务
+		service.NewBreastfeedingAnalyticsService,
 		// service.NewSyncService, // TODO: WebSocket同步未实现，暂时注释
 
 		// HTTP处理器
 		handler.NewAuthHandler,
 		handler.NewBabyHandler,
 		handler.NewRecordHandler,
-		handler.NewVaccineScheduleHandler, // 新增：疫苗接种日程处理器
-		handler.NewStatisticsHandler,      // 新增：统计处理器
-		handler.NewDailyStatsHandler,      // 新增：按日统计处理器
-		handler.NewSubscribeHandler,       // 订阅消息处理器
-		handler.NewAIAnalysisHandler,      // AI分析处理器（工具调用架构）
+		handler.NewVaccineScheduleHandler,  // 新增：疫苗接种日程处理器
+		handler.NewStatisticsHandler,       // 新增：统计处理器
+		handler.NewDailyStatsHandler,       // 新增：按日统计处理器
+		handler.NewFoodIntroductionHandler, // 辅食引入与过敏原处理器
+		handler.NewMilkStashHandler,        // 吸奶记录与母乳库存处理器
+		handler.NewCryingRecordHandler,     // 哭闹记录处理器
+		handler.NewIllnessEpisodeHandler,   // 患病经过处理器
+		handler.NewHealthRecordHandler,     // 体温与症状记录处理器
+		handler.NewMedicationHandler,       // 用药与补充剂处理器
+		handler.NewMilestoneHandler,        // 发育里程碑处理器
+		handler.NewAIChatHandler,           // AI育儿助手对话处理器
+		handler.NewQuickLogHandler,         // 自然语言快速记录处理器
+		handler.NewAIUsageHandler,          // AI调用用量管理处理器
+		handler.NewAIDigestHandler,         // AI周期报告处理器
+		handler.NewSubscribeHandler,        // 订阅消息处理器
+		handler.NewAIAnalysisHandler,       // AI分析处理器（工具调用架构）
+		handler.NewBreastfeedingAnalyticsHandler,
 		handler.NewSyncHandler,
 		handler.NewUploadHandler, // 文件上传处理器
 
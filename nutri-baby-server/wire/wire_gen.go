@@ -9,8 +9,11 @@ package wire
 import (
 	"github.com/wxlbd/nutri-baby-server/internal/application/service"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/config"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/cache"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/chain"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/guardrail"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/model"
+	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/offline"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/eino/tools"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/logger"
 	"github.com/wxlbd/nutri-baby-server/internal/infrastructure/persistence"
@@ -48,7 +51,8 @@ func InitApp(cfg *config.Config) (*App, error) {
 	}
 	vaccineScheduleService := service.NewVaccineScheduleService(babyVaccineScheduleRepository, babyRepository, babyCollaboratorRepository, userRepository, zapLogger)
 	wechatService := service.NewWechatService(wechatClient, cfg, zapLogger)
-	babyService := service.NewBabyService(babyRepository, babyCollaboratorRepository, babyInvitationRepository, userRepository, vaccineScheduleService, wechatService, zapLogger)
+	analysisDataCache := cache.NewAnalysisDataCache(client, zapLogger)
+	babyService := service.NewBabyService(babyRepository, babyCollaboratorRepository, babyInvitationRepository, userRepository, vaccineScheduleService, wechatService, analysisDataCache, zapLogger)
 	babyHandler := handler.NewBabyHandler(babyService, wechatService)
 	feedingRecordRepository := persistence.NewFeedingRecordRepository(db)
 	subscribeRepository := persistence.NewSubscribeRepository(db)
@@ -56,35 +60,80 @@ func InitApp(cfg *config.Config) (*App, error) {
 	subscribeService := service.NewSubscribeService(subscribeRepository, subscriptionCacheRepository, userRepository, wechatService, zapLogger)
 	aiAnalysisRepository := persistence.NewAIAnalysisRepository(db)
 	dailyTipsRepository := persistence.NewDailyTipsRepository(db)
-	toolCallingChatModel, err := model.NewToolCallingChatModel(cfg, zapLogger)
-	if err != nil {
-		return nil, err
-	}
+	aiUsageRepository := persistence.NewAIUsageRepository(db)
 	sleepRecordRepository := persistence.NewSleepRecordRepository(db)
 	diaperRecordRepository := persistence.NewDiaperRecordRepository(db)
 	growthRecordRepository := persistence.NewGrowthRecordRepository(db)
-	dataQueryTools := tools.NewDataQueryTools(feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, babyVaccineScheduleRepository, babyRepository, zapLogger)
+	healthAlertRepository := persistence.NewHealthAlertRepository(db)
+	analyzer := offline.NewAnalyzer(babyRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, babyVaccineScheduleRepository, healthAlertRepository, zapLogger)
+	providerChain := model.NewProviderChain(cfg, aiUsageRepository, analyzer, zapLogger)
+	toolCallingChatModel := model.NewToolCallingChatModel(providerChain)
+	cryingRecordRepository := persistence.NewCryingRecordRepository(db)
+	illnessEpisodeRepository := persistence.NewIllnessEpisodeRepository(db)
+	temperatureRecordRepository := persistence.NewTemperatureRecordRepository(db)
+	symptomRecordRepository := persistence.NewSymptomRecordRepository(db)
+	milestoneRecordRepository := persistence.NewMilestoneRecordRepository(db)
+	dataQueryTools := tools.NewDataQueryTools(feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, babyVaccineScheduleRepository, cryingRecordRepository, illnessEpisodeRepository, temperatureRecordRepository, symptomRecordRepository, milestoneRecordRepository, babyRepository, analysisDataCache, zapLogger)
 	batchDataTools := tools.NewBatchDataTools(babyRepository, feedingRecordRepository, sleepRecordRepository, growthRecordRepository, diaperRecordRepository, zapLogger)
-	analysisChainBuilder := chain.NewAnalysisChainBuilder(toolCallingChatModel, dataQueryTools, batchDataTools, zapLogger)
-	aiAnalysisService := service.NewAIAnalysisService(aiAnalysisRepository, dailyTipsRepository, babyRepository, analysisChainBuilder, cfg, zapLogger)
-	schedulerService := service.NewSchedulerService(babyVaccineScheduleRepository, feedingRecordRepository, userRepository, babyRepository, babyCollaboratorRepository, subscribeService, aiAnalysisService, cfg, zapLogger)
-	feedingRecordService := service.NewFeedingRecordService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, schedulerService, zapLogger)
-	sleepRecordService := service.NewSleepRecordService(babyRepository, babyCollaboratorRepository, userRepository, sleepRecordRepository, zapLogger)
-	diaperRecordService := service.NewDiaperRecordService(babyRepository, babyCollaboratorRepository, userRepository, diaperRecordRepository, zapLogger)
-	growthRecordService := service.NewGrowthRecordService(babyRepository, babyCollaboratorRepository, userRepository, growthRecordRepository, zapLogger)
-	timelineService := service.NewTimelineService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordService, sleepRecordService, diaperRecordService, growthRecordService, zapLogger)
+	guard := guardrail.NewGuard(babyRepository, diaperRecordRepository, growthRecordRepository, healthAlertRepository, zapLogger)
+	analysisChainBuilder := chain.NewAnalysisChainBuilder(toolCallingChatModel, dataQueryTools, batchDataTools, guard, zapLogger)
+	aiFingerprinter := service.NewAIFingerprinter(babyRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, babyVaccineScheduleRepository, cryingRecordRepository, illnessEpisodeRepository, temperatureRecordRepository, symptomRecordRepository, milestoneRecordRepository, analysisDataCache, analysisChainBuilder)
+	analysisProgressHub := service.NewAnalysisProgressHub()
+	aiJobRunner := service.NewAIJobRunner(aiAnalysisRepository, analysisChainBuilder, aiFingerprinter, analysisProgressHub, cfg, zapLogger)
+	aiUsageService := service.NewAIUsageService(aiUsageRepository, aiAnalysisRepository, providerChain, cfg, zapLogger)
+	aiAnalysisService := service.NewAIAnalysisService(aiAnalysisRepository, dailyTipsRepository, babyRepository, babyCollaboratorRepository, userRepository, analysisChainBuilder, aiFingerprinter, aiJobRunner, analysisProgressHub, aiUsageService, cfg, zapLogger)
+	babyNotifier := service.NewBabyNotifier(babyCollaboratorRepository, userRepository, subscribeService, cfg, zapLogger)
+	diaperScreeningService := service.NewDiaperScreeningService(babyRepository, diaperRecordRepository, healthAlertRepository, babyNotifier, zapLogger)
+	pumpingRecordRepository := persistence.NewPumpingRecordRepository(db)
+	milkStashRepository := persistence.NewMilkStashRepository(db)
+	milkStashService := service.NewMilkStashService(babyRepository, babyCollaboratorRepository, userRepository, pumpingRecordRepository, milkStashRepository, babyNotifier, zapLogger)
+	aiDigestRepository := persistence.NewAIDigestRepository(db)
+	statisticsService := service.NewStatisticsService(babyRepository, babyCollaboratorRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, temperatureRecordRepository, userRepository, zapLogger)
+	aiDigestService := service.NewAIDigestService(aiDigestRepository, aiAnalysisRepository, aiAnalysisService, statisticsService, babyNotifier, babyRepository, babyCollaboratorRepository, userRepository, zapLogger)
+	medicationRepository := persistence.NewMedicationRepository(db)
+	medicationDoseRepository := persistence.NewMedicationDoseRepository(db)
+	medicationScheduleRepository := persistence.NewMedicationScheduleRepository(db)
+	medicationService := service.NewMedicationService(babyRepository, babyCollaboratorRepository, userRepository, medicationRepository, medicationDoseRepository, medicationScheduleRepository, growthRecordRepository, babyNotifier, zapLogger)
+	schedulerService := service.NewSchedulerService(babyVaccineScheduleRepository, feedingRecordRepository, userRepository, babyRepository, babyCollaboratorRepository, subscribeService, aiAnalysisService, diaperScreeningService, milkStashService, aiDigestService, medicationService, cfg, zapLogger)
+	feedingRecordService := service.NewFeedingRecordService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, schedulerService, milkStashService, analysisDataCache, zapLogger)
+	sleepRecordService := service.NewSleepRecordService(babyRepository, babyCollaboratorRepository, userRepository, sleepRecordRepository, analysisDataCache, zapLogger)
+	diaperRecordService := service.NewDiaperRecordService(babyRepository, babyCollaboratorRepository, userRepository, diaperRecordRepository, diaperScreeningService, analysisDataCache, zapLogger)
+	growthRecordService := service.NewGrowthRecordService(babyRepository, babyCollaboratorRepository, userRepository, growthRecordRepository, analysisDataCache, zapLogger)
+	feverScreeningService := service.NewFeverScreeningService(babyRepository, temperatureRecordRepository, healthAlertRepository, babyNotifier, zapLogger)
+	healthRecordService := service.NewHealthRecordService(babyRepository, babyCollaboratorRepository, userRepository, temperatureRecordRepository, symptomRecordRepository, illnessEpisodeRepository, feverScreeningService, analysisDataCache, zapLogger)
+	milestoneService := service.NewMilestoneService(babyRepository, babyCollaboratorRepository, userRepository, milestoneRecordRepository, analysisDataCache, zapLogger)
+	timelineService := service.NewTimelineService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordService, sleepRecordService, diaperRecordService, growthRecordService, healthRecordService, milestoneService, zapLogger)
 	recordHandler := handler.NewRecordHandler(feedingRecordService, sleepRecordService, diaperRecordService, growthRecordService, timelineService)
 	vaccineScheduleHandler := handler.NewVaccineScheduleHandler(vaccineScheduleService)
-	statisticsService := service.NewStatisticsService(babyRepository, babyCollaboratorRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, userRepository, zapLogger)
 	statisticsHandler := handler.NewStatisticsHandler(statisticsService)
-	dailyStatsService := service.NewDailyStatsService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, zapLogger)
+	dailyStatsService := service.NewDailyStatsService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, sleepRecordRepository, diaperRecordRepository, growthRecordRepository, temperatureRecordRepository, symptomRecordRepository, zapLogger)
 	dailyStatsHandler := handler.NewDailyStatsHandler(dailyStatsService)
+	breastfeedingAnalyticsService := service.NewBreastfeedingAnalyticsService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, zapLogger)
+	breastfeedingAnalyticsHandler := handler.NewBreastfeedingAnalyticsHandler(breastfeedingAnalyticsService)
+	foodReactionRepository := persistence.NewFoodReactionRepository(db)
+	foodIntroductionService := service.NewFoodIntroductionService(babyRepository, babyCollaboratorRepository, userRepository, feedingRecordRepository, foodReactionRepository, zapLogger)
+	foodIntroductionHandler := handler.NewFoodIntroductionHandler(foodIntroductionService)
+	milkStashHandler := handler.NewMilkStashHandler(milkStashService)
+	cryingRecordService := service.NewCryingRecordService(babyRepository, babyCollaboratorRepository, userRepository, cryingRecordRepository, analysisDataCache, zapLogger)
+	cryingRecordHandler := handler.NewCryingRecordHandler(cryingRecordService)
+	illnessEpisodeService := service.NewIllnessEpisodeService(babyRepository, babyCollaboratorRepository, userRepository, illnessEpisodeRepository, temperatureRecordRepository, symptomRecordRepository, analysisDataCache, zapLogger)
+	illnessEpisodeHandler := handler.NewIllnessEpisodeHandler(illnessEpisodeService)
+	healthRecordHandler := handler.NewHealthRecordHandler(healthRecordService)
+	medicationHandler := handler.NewMedicationHandler(medicationService)
+	milestoneHandler := handler.NewMilestoneHandler(milestoneService)
 	subscribeHandler := handler.NewSubscribeHandler(subscribeService, zapLogger)
 	syncHandler := handler.NewSyncHandler()
 	uploadService := service.NewUploadService(cfg)
 	uploadHandler := handler.NewUploadHandler(uploadService)
 	aiAnalysisHandler := handler.NewAIAnalysisHandler(aiAnalysisService, zapLogger)
-	engine := router.NewRouter(cfg, authHandler, babyHandler, recordHandler, vaccineScheduleHandler, statisticsHandler, dailyStatsHandler, subscribeHandler, syncHandler, uploadHandler, aiAnalysisHandler, aiAnalysisService, zapLogger)
-	app := NewApp(cfg, engine, schedulerService, aiAnalysisService, aiAnalysisHandler)
+	aiChatMessageRepository := persistence.NewAIChatMessageRepository(db)
+	aiChatService := service.NewAIChatService(babyRepository, babyCollaboratorRepository, userRepository, aiChatMessageRepository, analysisChainBuilder, dataQueryTools, aiUsageService, zapLogger)
+	aiChatHandler := handler.NewAIChatHandler(aiChatService)
+	quickLogService := service.NewQuickLogService(babyRepository, babyCollaboratorRepository, userRepository, analysisChainBuilder, aiUsageService, cfg, zapLogger)
+	quickLogHandler := handler.NewQuickLogHandler(quickLogService)
+	aiUsageHandler := handler.NewAIUsageHandler(aiUsageService)
+	aiDigestHandler := handler.NewAIDigestHandler(aiDigestService)
+	engine := router.NewRouter(cfg, authHandler, babyHandler, recordHandler, vaccineScheduleHandler, statisticsHandler, dailyStatsHandler, breastfeedingAnalyticsHandler, foodIntroductionHandler, milkStashHandler, cryingRecordHandler, illnessEpisodeHandler, healthRecordHandler, medicationHandler, milestoneHandler, subscribeHandler, syncHandler, uploadHandler, aiAnalysisHandler, aiChatHandler, quickLogHandler, aiUsageHandler, aiDigestHandler, aiAnalysisService, zapLogger)
+	app := NewApp(cfg, engine, schedulerService, aiJobRunner, aiAnalysisService, aiAnalysisHandler)
 	return app, nil
 }